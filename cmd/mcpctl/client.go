@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// client calls the gateway admin API
+type client struct {
+	baseURL    string
+	token      string // Admin token sent as bearer token
+	namespace  string // Namespace of every request, the gateway's default if empty
+	language   string // Language of the error messages, English if empty
+	author     string // Author of the changes, recorded on the versions they create
+	message    string // Message of the changes
+	httpClient *http.Client
+}
+
+// newClient creates a new client of the gateway at baseURL
+func newClient(baseURL, token, namespace string, timeout time.Duration) *client {
+	return &client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		namespace:  namespace,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// languageTag returns the language tag of a language or a locale such as zh_CN.UTF-8, or an
+// empty string for the C and POSIX locales
+func languageTag(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	if locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// get sends a GET request and returns the response body
+func (c *client) get(path string) ([]byte, error) {
+	return c.do(http.MethodGet, path, nil, nil)
+}
+
+// post sends a POST request with body encoded as JSON, unless nil
+func (c *client) post(path string, body interface{}) ([]byte, error) {
+	return c.do(http.MethodPost, path, body, nil)
+}
+
+// patch sends a PATCH request with body encoded as JSON
+func (c *client) patch(path string, body interface{}) ([]byte, error) {
+	return c.do(http.MethodPatch, path, body, nil)
+}
+
+// delete sends a DELETE request
+func (c *client) delete(path string) error {
+	_, err := c.do(http.MethodDelete, path, nil, nil)
+	return err
+}
+
+// do sends a request and returns the response body, failing on non-2xx responses
+func (c *client) do(method, path string, body interface{}, header http.Header) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.send(req)
+}
+
+// postData sends a POST request with a raw body of the given content type
+func (c *client) postData(path, contentType string, data []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return c.send(req)
+}
+
+// upload sends the file at filePath as the multipart form field "file", next to the fields
+func (c *client) upload(path, filePath string, fields url.Values) ([]byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, values := range fields {
+		for _, value := range values {
+			if err := writer.WriteField(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return c.send(req)
+}
+
+// send executes req, turning error responses of the gateway into errors
+func (c *client) send(req *http.Request) ([]byte, error) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.namespace != "" {
+		req.Header.Set(namespaceHeader, c.namespace)
+	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if req.Method != http.MethodGet {
+		if c.author != "" {
+			req.Header.Set(changeAuthorHeader, c.author)
+		}
+		if c.message != "" {
+			req.Header.Set(changeMessageHeader, c.message)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Error struct {
+				Code      string `json:"code"`
+				Message   string `json:"message"`
+				RequestID string `json:"requestId"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("%s %s: %d %s: %s (request ID %s)", req.Method, req.URL.Path, resp.StatusCode,
+				apiErr.Error.Code, apiErr.Error.Message, apiErr.Error.RequestID)
+		}
+		return nil, fmt.Errorf("%s %s: %d %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,1496 @@
+// Command mcpctl manages an MCP Gateway through its admin API.
+//
+//	mcpctl interface import openapi.yaml
+//	mcpctl server create --name weather --interface http-1 --interface http-2
+//	mcpctl server create --name petstore --collection col-1
+//	mcpctl server activate mcp-1
+//	mcpctl tool invoke --param q=Paris mcp-1 get-weather
+//	mcpctl tool test --param q=Paris mcp-1 get-weather
+//	mcpctl --output yaml export > gateway.yaml
+//	mcpctl apply --dry-run --prune gateway.yaml
+//	mcpctl seed apply examples demo
+//	mcpctl --token $ADMIN_TOKEN backup restore --dry-run gateway-20250101T000000.000Z.json
+//	mcpctl --namespace payments server list
+//
+// Flags must precede the arguments of a command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// environmentHeader selects the environment of a tool invocation, see mcp.EnvironmentHeader
+const environmentHeader = "X-MCP-Environment"
+
+// namespaceHeader selects the namespace of a request, see namespace.Header
+const namespaceHeader = "X-MCP-Namespace"
+
+// cookieJarHeader selects the cookie jar of a tool invocation, see mcp.CookieJarHeader
+const cookieJarHeader = "X-MCP-Cookie-Jar"
+
+// apiKeyHeader carries the API key a tool invocation is counted against, see mcp.APIKeyHeader
+const apiKeyHeader = "X-API-Key"
+
+// changeAuthorHeader and changeMessageHeader carry the change note of the interface and server
+// versions a request creates, see api.ChangeAuthorHeader
+const (
+	changeAuthorHeader  = "X-Change-Author"
+	changeMessageHeader = "X-Change-Message"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "mcpctl",
+		Usage: "manage an MCP Gateway",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "gateway",
+				Aliases: []string{"g"},
+				Usage:   "base URL of the gateway",
+				Value:   "http://localhost:8080",
+				EnvVars: []string{"MCP_GATEWAY_URL"},
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "admin token of the gateway",
+				EnvVars: []string{"MCP_GATEWAY_TOKEN"},
+			},
+			&cli.StringFlag{
+				Name:    "namespace",
+				Aliases: []string{"n"},
+				Usage:   "namespace of the interfaces, servers and routers, default if unset",
+				EnvVars: []string{"MCP_NAMESPACE"},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output format: json or yaml",
+				Value:   "json",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "timeout of each API request",
+				Value: 30 * time.Second,
+			},
+			&cli.StringFlag{
+				Name:    "author",
+				Usage:   "author of the changes, recorded with the interface and server versions they create",
+				EnvVars: []string{"MCP_AUTHOR", "USER"},
+			},
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "message describing the changes, recorded with the interface and server versions they create",
+			},
+			&cli.StringFlag{
+				Name:    "language",
+				Usage:   "language of the error messages of the gateway, e.g. zh-CN, the one of the locale if unset",
+				EnvVars: []string{"LC_ALL", "LC_MESSAGES", "LANG"},
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if format := c.String("output"); format != "json" && format != "yaml" {
+				return fmt.Errorf("unsupported output format '%s': must be json or yaml", format)
+			}
+			return nil
+		},
+		Commands: []*cli.Command{
+			interfaceCommand(),
+			serverCommand(),
+			toolCommand(),
+			exportCommand(),
+			applyCommand(),
+			seedCommand(),
+			tenantCommand(),
+			apiKeyCommand(),
+			limitsCommand(),
+			revisionCommand(),
+			backupCommand(),
+			adminCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// interfaceCommand manages HTTP interfaces
+func interfaceCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "interface",
+		Aliases: []string{"interfaces", "if"},
+		Usage:   "manage HTTP interfaces",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list HTTP interfaces",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "include-archived", Usage: "also list archived interfaces"},
+				},
+				Action: listAction("/api/http-interfaces"),
+			},
+			{
+				Name:      "get",
+				Usage:     "get an HTTP interface",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/http-interfaces/%s"),
+			},
+			{
+				Name:      "create",
+				Usage:     "create an HTTP interface from a JSON or YAML definition",
+				ArgsUsage: "FILE",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return errors.New("expected the definition file")
+					}
+					definition, err := readDefinition(c.Args().First())
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post("/api/http-interfaces", definition))
+				},
+			},
+			{
+				Name:      "import",
+				Usage:     "create HTTP interfaces from an OpenAPI file, or the operations selected by the flags",
+				ArgsUsage: "FILE",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "tag", Usage: "import the operations with the tag, repeatable"},
+					&cli.StringSliceFlag{Name: "exclude-tag", Usage: "skip the operations with the tag, repeatable"},
+					&cli.StringSliceFlag{Name: "path-prefix", Usage: "import the paths under the prefix, e.g. /pets, repeatable"},
+					&cli.StringSliceFlag{Name: "exclude-path-prefix", Usage: "skip the paths under the prefix, repeatable"},
+					&cli.StringSliceFlag{Name: "method", Usage: "import the operations of the method, repeatable"},
+					&cli.StringSliceFlag{Name: "exclude-method", Usage: "skip the operations of the method, repeatable"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "only list the interfaces that would be created"},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return errors.New("expected the OpenAPI file")
+					}
+					fields := url.Values{
+						"tags":                c.StringSlice("tag"),
+						"excludeTags":         c.StringSlice("exclude-tag"),
+						"pathPrefixes":        c.StringSlice("path-prefix"),
+						"excludePathPrefixes": c.StringSlice("exclude-path-prefix"),
+						"methods":             c.StringSlice("method"),
+						"excludeMethods":      c.StringSlice("exclude-method"),
+					}
+					if c.Bool("dry-run") {
+						fields.Set("dryRun", "true")
+					}
+					return printResponse(c)(gatewayClient(c).upload("/api/http-interfaces/from-openapi-file", c.Args().First(), fields))
+				},
+			},
+			{
+				Name:  "from-curl",
+				Usage: "create an HTTP interface from a curl command",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "interface name", Required: true},
+					&cli.StringFlag{Name: "description", Usage: "interface description"},
+					&cli.StringFlag{Name: "command", Usage: "curl command", Required: true},
+				},
+				Action: func(c *cli.Context) error {
+					return printResponse(c)(gatewayClient(c).post("/api/http-interfaces/from-curl", map[string]string{
+						"name":        c.String("name"),
+						"description": c.String("description"),
+						"command":     c.String("command"),
+					}))
+				},
+			},
+			{
+				Name:      "versions",
+				Usage:     "list the versions of an HTTP interface with their change notes",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/http-interfaces/%s/versions"),
+			},
+			{
+				Name:      "export",
+				Usage:     "export an HTTP interface to OpenAPI",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/http-interfaces/%s/openapi"),
+			},
+			{
+				Name:      "examples",
+				Usage:     "show curl, HTTPie and MCP tools/call examples of an HTTP interface",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/http-interfaces/%s/examples"),
+			},
+			{
+				Name:      "lint",
+				Usage:     "report missing descriptions and schemas and ambiguous params of an HTTP interface",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/http-interfaces/%s/lint"),
+			},
+			{
+				Name:      "check",
+				Usage:     "probe the upstream of an HTTP interface: DNS, TCP, TLS and an optional request",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "method", Usage: "HEAD or GET request sent after connecting"},
+					&cli.StringFlag{Name: "environment", Aliases: []string{"e"}, Usage: "environment substituted in the URL"},
+				},
+				Action: func(c *cli.Context) error {
+					path, err := resolvePath(c, "/api/http-interfaces/%s/check")
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post(path, map[string]string{
+						"method":      strings.ToUpper(c.String("method")),
+						"environment": c.String("environment"),
+					}))
+				},
+			},
+			{
+				Name:      "archive",
+				Usage:     "archive an HTTP interface, hiding it without deleting it",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/http-interfaces/%s/archive"),
+			},
+			{
+				Name:      "unarchive",
+				Usage:     "restore an archived HTTP interface",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/http-interfaces/%s/unarchive"),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete an HTTP interface",
+				ArgsUsage: "ID",
+				Action:    deleteAction("/api/http-interfaces/%s"),
+			},
+		},
+	}
+}
+
+// serverCommand manages MCP servers
+func serverCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "server",
+		Aliases: []string{"servers"},
+		Usage:   "manage MCP servers",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list MCP servers",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "include-archived", Usage: "also list archived servers"},
+				},
+				Action: listAction("/api/mcp-servers"),
+			},
+			{
+				Name:      "get",
+				Usage:     "get an MCP server",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/mcp-servers/%s"),
+			},
+			{
+				Name:      "versions",
+				Usage:     "list the versions of an MCP server with their change notes",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/mcp-servers/%s/versions"),
+			},
+			{
+				Name:      "export-invocations",
+				Usage:     "export the upstream calls recorded with the invocations of an MCP server as a HAR file",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "since", Usage: "RFC 3339 start time"},
+					&cli.StringFlag{Name: "until", Usage: "RFC 3339 end time"},
+					&cli.StringFlag{Name: "tool", Usage: "only the invocations of this tool"},
+					&cli.StringFlag{Name: "status", Usage: "success or error"},
+					&cli.IntFlag{Name: "limit", Usage: "maximum number of invocations, newest kept"},
+					&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "write to FILE instead of stdout"},
+				},
+				Action: func(c *cli.Context) error {
+					id, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					query := url.Values{"format": {"har"}}
+					for _, name := range []string{"since", "until", "tool", "status"} {
+						if value := c.String(name); value != "" {
+							query.Set(name, value)
+						}
+					}
+					if limit := c.Int("limit"); limit > 0 {
+						query.Set("limit", strconv.Itoa(limit))
+					}
+					data, err := gatewayClient(c).get("/api/mcp-servers/" + id + "/invocations/export?" + query.Encode())
+					if err != nil {
+						return err
+					}
+					if file := c.String("file"); file != "" {
+						return os.WriteFile(file, data, 0644)
+					}
+					_, err = os.Stdout.Write(data)
+					return err
+				},
+			},
+			{
+				Name:  "create",
+				Usage: "create an MCP server from HTTP interfaces, a collection or external MCP servers, or a virtual server from other servers",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "server name", Required: true},
+					&cli.StringFlag{Name: "description", Usage: "server description"},
+					&cli.StringFlag{Name: "instructions", Usage: "guidance for the LLMs using the tools, sent when MCP clients initialize"},
+					&cli.StringSliceFlag{Name: "interface", Usage: "ID of an HTTP interface exposed as a tool, repeatable"},
+					&cli.StringFlag{Name: "collection", Usage: "ID of a collection whose interfaces are exposed as tools"},
+					&cli.StringSliceFlag{Name: "plugin", Usage: "ID of a WASM plugin applied to every tool, repeatable"},
+					&cli.StringFlag{Name: "default-environment", Usage: "environment used when an invocation selects none"},
+					&cli.StringFlag{Name: "external", Usage: "JSON or YAML file listing external MCP servers whose tools are proxied"},
+					&cli.StringSliceFlag{Name: "source", Usage: "ID of an MCP server whose tools a virtual server includes, as ID or ID:PREFIX, repeatable"},
+					&cli.StringFlag{Name: "conflict-resolution", Usage: "tool name conflicts between sources: error, first or last"},
+					&cli.BoolFlag{Name: "strict", Usage: "reject calls with params, headers or body fields the tools do not define"},
+				},
+				Action: func(c *cli.Context) error {
+					request := map[string]interface{}{
+						"name":               c.String("name"),
+						"description":        c.String("description"),
+						"instructions":       c.String("instructions"),
+						"httpIds":            c.StringSlice("interface"),
+						"collectionId":       c.String("collection"),
+						"plugins":            c.StringSlice("plugin"),
+						"defaultEnvironment": c.String("default-environment"),
+						"strict":             c.Bool("strict"),
+					}
+					if path := c.String("external"); path != "" {
+						data, err := os.ReadFile(path)
+						if err != nil {
+							return err
+						}
+						var external []map[string]interface{}
+						if err := yaml.Unmarshal(data, &external); err != nil {
+							return fmt.Errorf("failed to parse %s: %w", path, err)
+						}
+						request["external"] = external
+					}
+					if sources := c.StringSlice("source"); len(sources) > 0 {
+						list := make([]map[string]string, 0, len(sources))
+						for _, source := range sources {
+							id, prefix, _ := strings.Cut(source, ":")
+							list = append(list, map[string]string{"serverId": id, "prefix": prefix})
+						}
+						request["sources"] = list
+						request["conflictResolution"] = c.String("conflict-resolution")
+					}
+					return printResponse(c)(gatewayClient(c).post("/api/mcp-servers", request))
+				},
+			},
+			{
+				Name:      "activate",
+				Usage:     "activate an MCP server",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/mcp-servers/%s/activate"),
+			},
+			{
+				Name:      "client-config",
+				Usage:     "print the Claude Desktop, Cursor and VS Code configuration of an MCP server",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/mcp-servers/%s/client-config"),
+			},
+			{
+				Name:      "verify",
+				Usage:     "call the tools with example params and check the responses against their schemas",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "tool", Usage: "tool to verify, repeatable, all by default"},
+					&cli.BoolFlag{Name: "include-unsafe", Usage: "also call the tools whose method is not GET"},
+				},
+				Action: func(c *cli.Context) error {
+					path, err := resolvePath(c, "/api/mcp-servers/%s/verify")
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post(path, map[string]interface{}{
+						"tools":         c.StringSlice("tool"),
+						"includeUnsafe": c.Bool("include-unsafe"),
+					}))
+				},
+			},
+			{
+				Name:      "enrich",
+				Usage:     "generate tool and param descriptions with the configured LLM, printed for review",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "tool", Usage: "tool to describe, repeatable, all by default"},
+				},
+				Action: func(c *cli.Context) error {
+					path, err := resolvePath(c, "/api/mcp-servers/%s/enrich-descriptions")
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post(path, map[string]interface{}{"tools": c.StringSlice("tool")}))
+				},
+			},
+			{
+				Name:      "accept-descriptions",
+				Usage:     "apply the reviewed output of enrich, as JSON or YAML",
+				ArgsUsage: "ID FILE",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return errors.New("expected the server ID and the file of suggestions")
+					}
+					suggestions, err := readDefinition(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					path := "/api/mcp-servers/" + url.PathEscape(c.Args().Get(0)) + "/enrich-descriptions/accept"
+					return printResponse(c)(gatewayClient(c).post(path, suggestions))
+				},
+			},
+			{
+				Name:      "deactivate",
+				Usage:     "deactivate an MCP server",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/mcp-servers/%s/deactivate"),
+			},
+			{
+				Name:      "archive",
+				Usage:     "archive an MCP server, retiring it without deleting it or its history",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/mcp-servers/%s/archive"),
+			},
+			{
+				Name:      "unarchive",
+				Usage:     "restore an archived MCP server as inactive",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/mcp-servers/%s/unarchive"),
+			},
+			{
+				Name:      "schedule",
+				Usage:     "get the activation schedule of an MCP server with its next activation and deactivation",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/mcp-servers/%s/schedule"),
+			},
+			{
+				Name:      "set-schedule",
+				Usage:     "set the times an MCP server is activated and deactivated at",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "activate-at", Usage: "one-off activation time, RFC 3339"},
+					&cli.StringFlag{Name: "deactivate-at", Usage: "one-off deactivation time, RFC 3339"},
+					&cli.StringFlag{Name: "activate-cron", Usage: "cron expression of recurring activations, e.g. \"0 8 * * mon-fri\""},
+					&cli.StringFlag{Name: "deactivate-cron", Usage: "cron expression of recurring deactivations"},
+					&cli.StringFlag{Name: "timezone", Usage: "IANA time zone of the cron expressions, UTC by default"},
+				},
+				Action: func(c *cli.Context) error {
+					path, err := resolvePath(c, "/api/mcp-servers/%s/schedule")
+					if err != nil {
+						return err
+					}
+					schedule := map[string]string{}
+					for flag, field := range map[string]string{
+						"activate-at":     "activateAt",
+						"deactivate-at":   "deactivateAt",
+						"activate-cron":   "activateCron",
+						"deactivate-cron": "deactivateCron",
+						"timezone":        "timezone",
+					} {
+						if value := c.String(flag); value != "" {
+							schedule[field] = value
+						}
+					}
+					return printResponse(c)(gatewayClient(c).do(http.MethodPut, path, schedule, nil))
+				},
+			},
+			{
+				Name:      "delete-schedule",
+				Usage:     "remove the activation schedule of an MCP server, which keeps its status",
+				ArgsUsage: "ID",
+				Action:    deleteAction("/api/mcp-servers/%s/schedule"),
+			},
+			{
+				Name:      "sync",
+				Usage:     "regenerate the tools of an MCP server from its HTTP interfaces",
+				ArgsUsage: "ID",
+				Action:    postAction("/api/mcp-servers/%s/sync"),
+			},
+			{
+				Name:      "clone",
+				Usage:     "clone an MCP server as a new draft",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "name of the copy", Required: true},
+					&cli.StringFlag{Name: "default-environment", Usage: "environment of the copy"},
+				},
+				Action: func(c *cli.Context) error {
+					id, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post("/api/mcp-servers/"+id+"/clone", map[string]string{
+						"name":               c.String("name"),
+						"defaultEnvironment": c.String("default-environment"),
+					}))
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete an MCP server",
+				ArgsUsage: "ID",
+				Action:    deleteAction("/api/mcp-servers/%s"),
+			},
+		},
+	}
+}
+
+// toolCommand lists and invokes tools
+func toolCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "tool",
+		Aliases: []string{"tools"},
+		Usage:   "list and invoke tools",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "list the tools of an MCP server",
+				ArgsUsage: "SERVER-NAME",
+				Action:    getAction("/api/mcp-server/%s/tools"),
+			},
+			{
+				Name:      "export",
+				Usage:     "export the tools of an MCP server for function calling",
+				ArgsUsage: "SERVER-NAME",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "format", Usage: "tool format: openai or anthropic", Value: "openai"},
+				},
+				Action: func(c *cli.Context) error {
+					name, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					switch format := c.String("format"); format {
+					case "openai", "anthropic":
+						return printResponse(c)(gatewayClient(c).get("/api/mcp-server/" + name + "/" + format + "-tools"))
+					default:
+						return fmt.Errorf("invalid --format '%s': must be openai or anthropic", format)
+					}
+				},
+			},
+			{
+				Name:      "invoke",
+				Usage:     "invoke a tool of an MCP server",
+				ArgsUsage: "SERVER-ID TOOL",
+				Flags:     invokeFlags(),
+				Action:    invokeTool(""),
+			},
+			{
+				Name:      "test",
+				Usage:     "invoke a tool and report the schema warnings, the upstream request, status and latency",
+				ArgsUsage: "SERVER-ID TOOL",
+				Flags:     invokeFlags(),
+				Action:    invokeTool("/test"),
+			},
+			{
+				Name:      "render",
+				Usage:     "render the upstream request of a tool from params, and its result from a sample upstream response, without calling the upstream",
+				ArgsUsage: "SERVER-ID TOOL",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "param", Aliases: []string{"p"}, Usage: "parameter as name=value, the value is parsed as JSON if possible, repeatable"},
+					&cli.StringFlag{Name: "data", Aliases: []string{"d"}, Usage: "parameters as a JSON object, merged with --param"},
+					&cli.StringFlag{Name: "response", Usage: "file of the sample upstream response body"},
+					&cli.StringFlag{Name: "environment", Aliases: []string{"e"}, Usage: "environment the request is rendered for"},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return errors.New("expected the server ID and the tool name")
+					}
+					params := map[string]interface{}{}
+					if data := c.String("data"); data != "" {
+						if err := json.Unmarshal([]byte(data), &params); err != nil {
+							return fmt.Errorf("invalid --data: %w", err)
+						}
+					}
+					for _, param := range c.StringSlice("param") {
+						name, value, ok := strings.Cut(param, "=")
+						if !ok {
+							return fmt.Errorf("invalid --param '%s': must be name=value", param)
+						}
+						var parsed interface{}
+						if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+							parsed = value
+						}
+						params[name] = parsed
+					}
+					body := map[string]interface{}{"params": params}
+					if file := c.String("response"); file != "" {
+						data, err := os.ReadFile(file)
+						if err != nil {
+							return err
+						}
+						// Send JSON bodies as JSON and others as text
+						var response interface{}
+						if err := json.Unmarshal(data, &response); err != nil {
+							response = string(data)
+						}
+						body["response"] = response
+					}
+					header := http.Header{}
+					if environment := c.String("environment"); environment != "" {
+						header.Set(environmentHeader, environment)
+					}
+					path := "/api/mcp-servers/" + url.PathEscape(c.Args().Get(0)) + "/tools/" + url.PathEscape(c.Args().Get(1)) + "/render"
+					return printResponse(c)(gatewayClient(c).do(http.MethodPost, path, body, header))
+				},
+			},
+			{
+				Name:      "replay",
+				Usage:     "invoke the tool of a recorded invocation again, with the recorded params edited by the flags",
+				ArgsUsage: "INVOCATION-ID",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: "param", Aliases: []string{"p"}, Usage: "parameter replacing the recorded one as name=value, the value is parsed as JSON if possible, null removes it, repeatable"},
+					&cli.BoolFlag{Name: "replace", Usage: "send only the --param parameters, e.g. when the recorded ones were truncated"},
+					&cli.StringFlag{Name: "environment", Aliases: []string{"e"}, Usage: "environment of the invocation"},
+				},
+				Action: func(c *cli.Context) error {
+					id, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					params := map[string]interface{}{}
+					for _, param := range c.StringSlice("param") {
+						name, value, ok := strings.Cut(param, "=")
+						if !ok {
+							return fmt.Errorf("invalid --param '%s': must be name=value", param)
+						}
+						var parsed interface{}
+						if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+							parsed = value
+						}
+						params[name] = parsed
+					}
+					header := http.Header{}
+					if environment := c.String("environment"); environment != "" {
+						header.Set(environmentHeader, environment)
+					}
+					body := map[string]interface{}{"params": params, "replace": c.Bool("replace")}
+					return printResponse(c)(gatewayClient(c).do(http.MethodPost, "/api/invocations/"+url.PathEscape(id)+"/replay", body, header))
+				},
+			},
+			{
+				Name:      "chain",
+				Usage:     "add a chained tool calling other tools of an MCP server, from a JSON or YAML definition",
+				ArgsUsage: "SERVER-ID FILE",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return errors.New("expected the server ID and the definition file")
+					}
+					definition, err := readDefinition(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post("/api/mcp-servers/"+url.PathEscape(c.Args().Get(0))+"/chained-tools", definition))
+				},
+			},
+			{
+				Name:      "websocket",
+				Usage:     "add a tool sending a message to a WebSocket upstream and returning its replies, from a JSON or YAML definition",
+				ArgsUsage: "SERVER-ID FILE",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return errors.New("expected the server ID and the definition file")
+					}
+					definition, err := readDefinition(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).post("/api/mcp-servers/"+url.PathEscape(c.Args().Get(0))+"/websocket-tools", definition))
+				},
+			},
+			{
+				Name:      "enable",
+				Usage:     "enable a disabled tool of an MCP server",
+				ArgsUsage: "SERVER-ID TOOL",
+				Action:    toolStateAction("enable"),
+			},
+			{
+				Name:      "disable",
+				Usage:     "stop listing a tool and reject its calls without removing it from the allowed tools",
+				ArgsUsage: "SERVER-ID TOOL",
+				Action:    toolStateAction("disable"),
+			},
+			{
+				Name:      "update",
+				Usage:     "set the alias, the description, the params and the result fields MCP clients see for a tool, and its cost, hedging, latency budget, auth passthrough, upstream error mappings, partial responses and shadow tool",
+				ArgsUsage: "SERVER-ID TOOL",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "alias", Usage: "name exposed to MCP clients, empty to remove the alias"},
+					&cli.StringFlag{Name: "description", Usage: "description exposed to MCP clients, empty to remove the override"},
+					&cli.StringSliceFlag{Name: "static", Usage: "param always sent upstream as name=value, the value is parsed as JSON if possible, repeatable, replaces the static params"},
+					&cli.StringSliceFlag{Name: "map", Usage: "param renamed before calling upstream as client=upstream, repeatable, replaces the mapping"},
+					&cli.BoolFlag{Name: "clear-params", Usage: "remove the static params and the mapping"},
+					&cli.StringSliceFlag{Name: "include", Usage: "gjson path of a result field kept, repeatable, replaces the projection"},
+					&cli.StringSliceFlag{Name: "exclude", Usage: "gjson path of a result field removed, repeatable, replaces the projection"},
+					&cli.BoolFlag{Name: "selectable", Usage: "let callers choose the result fields with the _fields param, replaces the projection"},
+					&cli.BoolFlag{Name: "clear-projection", Usage: "return the whole result"},
+					&cli.Float64Flag{Name: "cost", Usage: "cost of a call counted against API key quotas, 0 for the default of 1"},
+					&cli.BoolFlag{Name: "hedge", Usage: "send a second request when the upstream is slower than usual, --hedge=false to stop, GET tools only"},
+					&cli.Float64Flag{Name: "hedge-percentile", Usage: "latency percentile after which the second request is sent, 99 by default"},
+					&cli.IntFlag{Name: "hedge-delay", Usage: "delay in milliseconds before the second request until enough latencies were observed, 100 by default"},
+					&cli.IntFlag{Name: "latency-budget", Usage: "target latency of a call in milliseconds, slower calls are counted as violations, 0 to remove the budget"},
+					&cli.BoolFlag{Name: "enforce-budget", Usage: "cancel the calls at the latency budget and return a timeout error"},
+					&cli.StringFlag{Name: "auth-passthrough", Usage: "forward, replace or strip the Authorization header of callers, empty to follow the server"},
+					&cli.StringFlag{Name: "credential", Usage: "YAML or JSON file of the auth profile sent instead of the header of callers with --auth-passthrough replace"},
+					&cli.StringSliceFlag{Name: "error", Usage: "error returned for an upstream status as status=category:message, e.g. 401=authentication:credentials expired, the status may be a class such as 5xx, repeatable, replaces the mappings"},
+					&cli.BoolFlag{Name: "clear-errors", Usage: "return the raw upstream errors"},
+					&cli.StringFlag{Name: "shadow", Usage: "tool of the server also called in the background on a sample of the calls, its results compared and logged"},
+					&cli.Float64Flag{Name: "shadow-percent", Usage: "share of the calls sent to the shadow tool, 0 to remove the shadow", Value: 100},
+					&cli.BoolFlag{Name: "partial-on-timeout", Usage: "return the body received so far when the upstream response is cut off at the timeout, --partial-on-timeout=false to stop"},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return errors.New("expected the server ID and the tool name")
+					}
+					body := map[string]interface{}{}
+					for _, name := range []string{"alias", "description"} {
+						if c.IsSet(name) {
+							body[name] = c.String(name)
+						}
+					}
+					if c.Bool("clear-params") {
+						body["staticParams"] = map[string]interface{}{}
+						body["paramMapping"] = map[string]string{}
+					}
+					if c.IsSet("static") {
+						static := map[string]interface{}{}
+						for _, param := range c.StringSlice("static") {
+							name, value, ok := strings.Cut(param, "=")
+							if !ok {
+								return fmt.Errorf("invalid --static '%s': must be name=value", param)
+							}
+							var parsed interface{}
+							if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+								parsed = value
+							}
+							static[name] = parsed
+						}
+						body["staticParams"] = static
+					}
+					if c.IsSet("map") {
+						mapping := map[string]string{}
+						for _, param := range c.StringSlice("map") {
+							client, upstream, ok := strings.Cut(param, "=")
+							if !ok {
+								return fmt.Errorf("invalid --map '%s': must be client=upstream", param)
+							}
+							mapping[client] = upstream
+						}
+						body["paramMapping"] = mapping
+					}
+					if c.Bool("clear-projection") {
+						body["projection"] = map[string]interface{}{}
+					}
+					if c.IsSet("include") || c.IsSet("exclude") || c.IsSet("selectable") {
+						body["projection"] = map[string]interface{}{
+							"include":    c.StringSlice("include"),
+							"exclude":    c.StringSlice("exclude"),
+							"selectable": c.Bool("selectable"),
+						}
+					}
+					if c.IsSet("cost") {
+						body["cost"] = c.Float64("cost")
+					}
+					if c.IsSet("hedge") {
+						body["hedging"] = map[string]interface{}{
+							"enabled":        c.Bool("hedge"),
+							"percentile":     c.Float64("hedge-percentile"),
+							"initialDelayMs": c.Int("hedge-delay"),
+						}
+					}
+					if c.IsSet("latency-budget") {
+						body["latencyBudget"] = map[string]interface{}{
+							"targetMs": c.Int("latency-budget"),
+							"enforce":  c.Bool("enforce-budget"),
+						}
+					}
+					if c.IsSet("auth-passthrough") {
+						passthrough := map[string]interface{}{"mode": c.String("auth-passthrough")}
+						if c.IsSet("credential") {
+							credential, err := readDefinition(c.String("credential"))
+							if err != nil {
+								return err
+							}
+							passthrough["credential"] = credential
+						}
+						body["authPassthrough"] = passthrough
+					}
+					if c.IsSet("shadow") || c.IsSet("shadow-percent") {
+						body["shadow"] = map[string]interface{}{
+							"tool":    c.String("shadow"),
+							"percent": c.Float64("shadow-percent"),
+						}
+					}
+					if c.IsSet("partial-on-timeout") {
+						body["partialOnTimeout"] = c.Bool("partial-on-timeout")
+					}
+					if c.Bool("clear-errors") {
+						body["errorMappings"] = []interface{}{}
+					}
+					if c.IsSet("error") {
+						mappings := []map[string]interface{}{}
+						for _, mapping := range c.StringSlice("error") {
+							status, rest, ok := strings.Cut(mapping, "=")
+							category, message, hasCategory := strings.Cut(rest, ":")
+							if !ok || !hasCategory {
+								return fmt.Errorf("invalid --error '%s': must be status=category:message", mapping)
+							}
+							mappings = append(mappings, map[string]interface{}{"status": status, "category": category, "message": message})
+						}
+						body["errorMappings"] = mappings
+					}
+					path := "/api/mcp-servers/" + url.PathEscape(c.Args().Get(0)) + "/tools/" + url.PathEscape(c.Args().Get(1))
+					return printResponse(c)(gatewayClient(c).patch(path, body))
+				},
+			},
+		},
+	}
+}
+
+// toolStateAction returns an action enabling or disabling a tool
+func toolStateAction(state string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 2 {
+			return errors.New("expected the server ID and the tool name")
+		}
+		path := "/api/mcp-servers/" + url.PathEscape(c.Args().Get(0)) + "/tools/" + url.PathEscape(c.Args().Get(1)) + "/" + state
+		return printResponse(c)(gatewayClient(c).post(path, nil))
+	}
+}
+
+// invokeFlags returns the flags of the tool invocation commands
+func invokeFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{Name: "param", Aliases: []string{"p"}, Usage: "parameter as name=value, the value is parsed as JSON if possible, repeatable"},
+		&cli.StringSliceFlag{Name: "header", Aliases: []string{"H"}, Usage: "header forwarded to the upstream as name=value, repeatable"},
+		&cli.StringFlag{Name: "data", Aliases: []string{"d"}, Usage: "parameters as a JSON object, merged with --param"},
+		&cli.StringSliceFlag{Name: "cookie", Usage: "cookie sent to the upstream as name=value, repeatable"},
+		&cli.StringFlag{Name: "environment", Aliases: []string{"e"}, Usage: "environment of the invocation"},
+		&cli.StringFlag{Name: "cookie-jar", Usage: "cookie jar keeping the upstream session between invocations"},
+		&cli.StringFlag{Name: "api-key", Usage: "API key the invocation is counted against"},
+	}
+}
+
+// invokeTool returns an action invoking a tool with the parameters and headers of the flags.
+// The suffix is appended to the path of the tool endpoint.
+func invokeTool(suffix string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 2 {
+			return errors.New("expected the server ID and the tool name")
+		}
+
+		params := map[string]interface{}{}
+		if data := c.String("data"); data != "" {
+			if err := json.Unmarshal([]byte(data), &params); err != nil {
+				return fmt.Errorf("invalid --data: %w", err)
+			}
+		}
+		for _, param := range c.StringSlice("param") {
+			name, value, ok := strings.Cut(param, "=")
+			if !ok {
+				return fmt.Errorf("invalid --param '%s': must be name=value", param)
+			}
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+				parsed = value
+			}
+			params[name] = parsed
+		}
+
+		body := params
+		if headerFlags := c.StringSlice("header"); len(headerFlags) > 0 {
+			headers := map[string]string{}
+			for _, header := range headerFlags {
+				name, value, ok := strings.Cut(header, "=")
+				if !ok {
+					return fmt.Errorf("invalid --header '%s': must be name=value", header)
+				}
+				headers[name] = value
+			}
+			body = map[string]interface{}{"headers": headers, "body": params}
+		}
+		if cookieFlags := c.StringSlice("cookie"); len(cookieFlags) > 0 {
+			cookies := map[string]string{}
+			for _, cookie := range cookieFlags {
+				name, value, ok := strings.Cut(cookie, "=")
+				if !ok {
+					return fmt.Errorf("invalid --cookie '%s': must be name=value", cookie)
+				}
+				cookies[name] = value
+			}
+			body["cookies"] = cookies
+		}
+
+		header := http.Header{}
+		if environment := c.String("environment"); environment != "" {
+			header.Set(environmentHeader, environment)
+		}
+		if jar := c.String("cookie-jar"); jar != "" {
+			header.Set(cookieJarHeader, jar)
+		}
+		if key := c.String("api-key"); key != "" {
+			header.Set(apiKeyHeader, key)
+		}
+
+		path := "/api/mcp-servers/" + url.PathEscape(c.Args().Get(0)) + "/tools/" + url.PathEscape(c.Args().Get(1)) + suffix
+		return printResponse(c)(gatewayClient(c).do(http.MethodPost, path, body, header))
+	}
+}
+
+// exportCommand dumps the HTTP interfaces, MCP servers and routers of the gateway
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "export all HTTP interfaces, MCP servers and routers",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "write to FILE instead of stdout"},
+		},
+		Action: func(c *cli.Context) error {
+			client := gatewayClient(c)
+			export := map[string]interface{}{}
+			for key, path := range map[string]string{
+				"interfaces": "/api/http-interfaces?includeArchived=true",
+				"servers":    "/api/mcp-servers?includeArchived=true",
+				"routers":    "/api/routers",
+			} {
+				data, err := client.get(path)
+				if err != nil {
+					return err
+				}
+				var items []interface{}
+				if err := json.Unmarshal(data, &items); err != nil {
+					return err
+				}
+				export[key] = items
+			}
+
+			output, err := format(c, export)
+			if err != nil {
+				return err
+			}
+			if file := c.String("file"); file != "" {
+				return os.WriteFile(file, output, 0644)
+			}
+			_, err = os.Stdout.Write(output)
+			return err
+		},
+	}
+}
+
+// applyCommand reconciles the gateway with a bundle file in the format of export
+func applyCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "apply",
+		Usage:     "create, update and optionally delete resources to match a JSON or YAML bundle",
+		ArgsUsage: "FILE",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "only print the planned changes"},
+			&cli.BoolFlag{Name: "prune", Usage: "delete resources of the listed kinds missing from the bundle"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return errors.New("expected the bundle file")
+			}
+			data, err := os.ReadFile(c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			query := url.Values{}
+			query.Set("dryRun", strconv.FormatBool(c.Bool("dry-run")))
+			query.Set("prune", strconv.FormatBool(c.Bool("prune")))
+			return printResponse(c)(gatewayClient(c).postData("/api/apply?"+query.Encode(), "application/x-yaml", data))
+		},
+	}
+}
+
+// seedCommand creates fixture resources
+func seedCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "seed",
+		Usage: "create the example resources of seed packs",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list the seed packs",
+				Action: getAction("/api/seed"),
+			},
+			{
+				Name:      "apply",
+				Usage:     "create the resources of packs, and of the packs they require, that do not exist yet",
+				ArgsUsage: "PACK...",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "dry-run", Usage: "only print the planned changes"},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return errors.New("expected at least one pack")
+					}
+					query := url.Values{}
+					query.Set("dryRun", strconv.FormatBool(c.Bool("dry-run")))
+					return printResponse(c)(gatewayClient(c).post("/api/seed?"+query.Encode(), map[string][]string{
+						"packs": c.Args().Slice(),
+					}))
+				},
+			},
+		},
+	}
+}
+
+// tenantCommand shows the usage and manages the quotas and call policies of tenants
+func tenantCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "tenant",
+		Aliases: []string{"tenants"},
+		Usage:   "show the usage and manage the quotas and call policies of tenants (namespaces)",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list tenant quotas",
+				Action: getAction("/api/tenants"),
+			},
+			{
+				Name:      "usage",
+				Usage:     "get today's usage of a tenant",
+				ArgsUsage: "TENANT",
+				Action:    getAction("/api/tenants/%s/usage"),
+			},
+			{
+				Name:      "set-quota",
+				Usage:     "set the quota of a tenant, requires --token",
+				ArgsUsage: "TENANT",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "max-tool-calls-per-day", Usage: "tool calls per UTC day, 0 for unlimited"},
+					&cli.IntFlag{Name: "max-servers", Usage: "number of MCP servers, 0 for unlimited"},
+					&cli.IntFlag{Name: "max-interfaces", Usage: "number of HTTP interfaces, 0 for unlimited"},
+				},
+				Action: func(c *cli.Context) error {
+					tenant, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).do(http.MethodPut, "/api/tenants/"+tenant+"/quota", map[string]int{
+						"maxToolCallsPerDay": c.Int("max-tool-calls-per-day"),
+						"maxServers":         c.Int("max-servers"),
+						"maxInterfaces":      c.Int("max-interfaces"),
+					}, nil))
+				},
+			},
+			{
+				Name:      "delete-quota",
+				Usage:     "delete the quota of a tenant, requires --token",
+				ArgsUsage: "TENANT",
+				Action:    deleteAction("/api/tenants/%s/quota"),
+			},
+			{
+				Name:   "policies",
+				Usage:  "list the call policies of tenants",
+				Action: getAction("/api/tenants/policies"),
+			},
+			{
+				Name:      "policy",
+				Usage:     "get the call policy of a tenant",
+				ArgsUsage: "TENANT",
+				Action:    getAction("/api/tenants/%s/policy"),
+			},
+			{
+				Name:      "set-policy",
+				Usage:     "set the call policy inherited by the servers and tools of a tenant, requires --token",
+				ArgsUsage: "TENANT",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "timeout-ms", Usage: "time allowed for a call, retries included, 0 for none"},
+					&cli.IntFlag{Name: "max-attempts", Usage: "attempts of idempotent calls whose upstream is unavailable, 0 for no retry policy"},
+					&cli.IntFlag{Name: "backoff-ms", Usage: "delay before the first retry, doubled at each retry"},
+					&cli.Float64Flag{Name: "requests-per-second", Usage: "calls of each caller per second, 0 for no rate limit"},
+					&cli.IntFlag{Name: "burst", Usage: "calls of each caller in a burst"},
+					&cli.StringSliceFlag{Name: "allowed-host", Usage: "upstream host the calls may reach, *.example.com for subdomains, repeatable"},
+				},
+				Action: func(c *cli.Context) error {
+					tenant, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					policy := map[string]interface{}{
+						"timeoutMs":    c.Int("timeout-ms"),
+						"allowedHosts": c.StringSlice("allowed-host"),
+					}
+					if c.Int("max-attempts") > 0 {
+						policy["retry"] = map[string]int{"maxAttempts": c.Int("max-attempts"), "backoffMs": c.Int("backoff-ms")}
+					}
+					if c.Float64("requests-per-second") > 0 {
+						policy["rateLimit"] = map[string]interface{}{"requestsPerSecond": c.Float64("requests-per-second"), "burst": c.Int("burst")}
+					}
+					return printResponse(c)(gatewayClient(c).do(http.MethodPut, "/api/tenants/"+tenant+"/policy", policy, nil))
+				},
+			},
+			{
+				Name:      "delete-policy",
+				Usage:     "delete the call policy of a tenant, requires --token",
+				ArgsUsage: "TENANT",
+				Action:    deleteAction("/api/tenants/%s/policy"),
+			},
+		},
+	}
+}
+
+// apiKeyCommand manages the API keys of clients
+func apiKeyCommand() *cli.Command {
+	quotaFlags := []cli.Flag{
+		&cli.StringFlag{Name: "name", Usage: "key name", Required: true},
+		&cli.Float64Flag{Name: "max-cost-per-day", Usage: "cost of the tool calls per UTC day, 0 for unlimited"},
+		&cli.Float64Flag{Name: "max-cost-per-month", Usage: "cost of the tool calls per UTC month, 0 for unlimited"},
+	}
+	quotaBody := func(c *cli.Context) map[string]interface{} {
+		return map[string]interface{}{
+			"name":            c.String("name"),
+			"maxCostPerDay":   c.Float64("max-cost-per-day"),
+			"maxCostPerMonth": c.Float64("max-cost-per-month"),
+		}
+	}
+
+	return &cli.Command{
+		Name:    "api-key",
+		Aliases: []string{"api-keys"},
+		Usage:   "manage the API keys of clients and show their usage",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list API keys",
+				Action: getAction("/api/api-keys"),
+			},
+			{
+				Name:      "usage",
+				Usage:     "get the usage of an API key per day of a month",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "month", Usage: "UTC month as YYYY-MM, the current one by default"},
+				},
+				Action: func(c *cli.Context) error {
+					id, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					path := "/api/api-keys/" + url.PathEscape(id) + "/usage"
+					if month := c.String("month"); month != "" {
+						path += "?month=" + url.QueryEscape(month)
+					}
+					return printResponse(c)(gatewayClient(c).get(path))
+				},
+			},
+			{
+				Name:  "create",
+				Usage: "create an API key, printed only once, requires --token",
+				Flags: quotaFlags,
+				Action: func(c *cli.Context) error {
+					return printResponse(c)(gatewayClient(c).post("/api/api-keys", quotaBody(c)))
+				},
+			},
+			{
+				Name:      "update",
+				Usage:     "set the name and the quotas of an API key, requires --token",
+				ArgsUsage: "ID",
+				Flags:     quotaFlags,
+				Action: func(c *cli.Context) error {
+					id, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					return printResponse(c)(gatewayClient(c).do(http.MethodPut, "/api/api-keys/"+url.PathEscape(id), quotaBody(c), nil))
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "revoke an API key, requires --token",
+				ArgsUsage: "ID",
+				Action:    deleteAction("/api/api-keys/%s"),
+			},
+		},
+	}
+}
+
+// limitsCommand shows the rate limit and the quotas left to the tool calls of the client
+func limitsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "limits",
+		Usage: "show the rate limit of this client and the quotas left to its API key and namespace",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "api-key", Usage: "API key whose quota is shown"},
+		},
+		Action: func(c *cli.Context) error {
+			header := http.Header{}
+			if key := c.String("api-key"); key != "" {
+				header.Set(apiKeyHeader, key)
+			}
+			return printResponse(c)(gatewayClient(c).do(http.MethodGet, "/api/limits/self", nil, header))
+		},
+	}
+}
+
+// revisionCommand reviews the pending changes of active MCP servers
+func revisionCommand() *cli.Command {
+	reviewAction := func(path string) cli.ActionFunc {
+		return func(c *cli.Context) error {
+			resolved, err := resolvePath(c, path)
+			if err != nil {
+				return err
+			}
+			return printResponse(c)(gatewayClient(c).post(resolved, map[string]string{"comment": c.String("comment")}))
+		}
+	}
+
+	return &cli.Command{
+		Name:    "revision",
+		Aliases: []string{"revisions"},
+		Usage:   "review the changes of active MCP servers awaiting approval",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list revisions, newest first",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "server", Usage: "ID of the MCP server whose revisions are listed"},
+					&cli.StringFlag{Name: "status", Usage: "pending, approved or rejected"},
+				},
+				Action: func(c *cli.Context) error {
+					path := "/api/revisions"
+					if server := c.String("server"); server != "" {
+						path = "/api/mcp-servers/" + url.PathEscape(server) + "/revisions"
+					}
+					if status := c.String("status"); status != "" {
+						path += "?status=" + url.QueryEscape(status)
+					}
+					return printResponse(c)(gatewayClient(c).get(path))
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "get a revision with the proposed definition of its server",
+				ArgsUsage: "ID",
+				Action:    getAction("/api/revisions/%s"),
+			},
+			{
+				Name:      "approve",
+				Usage:     "apply a revision to its server, requires --token with the approver token",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "comment", Usage: "comment of the approver"},
+				},
+				Action: reviewAction("/api/revisions/%s/approve"),
+			},
+			{
+				Name:      "reject",
+				Usage:     "reject a revision, requires --token with the approver token",
+				ArgsUsage: "ID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "comment", Usage: "reason of the rejection"},
+				},
+				Action: reviewAction("/api/revisions/%s/reject"),
+			},
+		},
+	}
+}
+
+// backupCommand lists, creates and restores the configuration backups
+func backupCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "backup",
+		Aliases: []string{"backups"},
+		Usage:   "manage the configuration backups, requires --token",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list the backups, newest first, and the outcome of the last one",
+				Action: getAction("/api/backups"),
+			},
+			{
+				Name:   "create",
+				Usage:  "back up the configuration now",
+				Action: postAction("/api/backups"),
+			},
+			{
+				Name:      "restore",
+				Usage:     "recreate deleted and revert changed resources of every namespace from a backup",
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "dry-run", Usage: "only print the planned changes"},
+					&cli.BoolFlag{Name: "prune", Usage: "also delete the resources missing from the backup"},
+				},
+				Action: func(c *cli.Context) error {
+					name, err := idArg(c)
+					if err != nil {
+						return err
+					}
+					query := url.Values{}
+					query.Set("dryRun", strconv.FormatBool(c.Bool("dry-run")))
+					query.Set("prune", strconv.FormatBool(c.Bool("prune")))
+					return printResponse(c)(gatewayClient(c).post("/api/backups/"+name+"/restore?"+query.Encode(), nil))
+				},
+			},
+		},
+	}
+}
+
+// adminCommand runs administrative operations
+func adminCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "admin",
+		Usage: "administer the gateway",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "reload",
+				Usage:  "reload the gateway configuration, requires --token",
+				Action: postAction("/api/admin/reload"),
+			},
+			{
+				Name:   "log-level",
+				Usage:  "get the log level of the gateway",
+				Action: getAction("/api/admin/log-level"),
+			},
+		},
+	}
+}
+
+// gatewayClient creates the API client configured by the global flags
+func gatewayClient(c *cli.Context) *client {
+	api := newClient(c.String("gateway"), c.String("token"), c.String("namespace"), c.Duration("timeout"))
+	api.language = languageTag(c.String("language"))
+	api.author = c.String("author")
+	api.message = c.String("message")
+	return api
+}
+
+// idArg returns the single ID argument of the command
+func idArg(c *cli.Context) (string, error) {
+	if c.NArg() != 1 {
+		return "", errors.New("expected a single ID argument")
+	}
+	return url.PathEscape(c.Args().First()), nil
+}
+
+// resolvePath fills the ID argument into path if it has a placeholder
+func resolvePath(c *cli.Context, path string) (string, error) {
+	if !strings.Contains(path, "%s") {
+		return path, nil
+	}
+	id, err := idArg(c)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(path, id), nil
+}
+
+// getAction prints the response of a GET request
+func getAction(path string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		resolved, err := resolvePath(c, path)
+		if err != nil {
+			return err
+		}
+		return printResponse(c)(gatewayClient(c).get(resolved))
+	}
+}
+
+// listAction prints a listing, with the archived entities if --include-archived is set
+func listAction(path string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		resolved := path
+		if c.Bool("include-archived") {
+			resolved += "?includeArchived=true"
+		}
+		return printResponse(c)(gatewayClient(c).get(resolved))
+	}
+}
+
+// postAction prints the response of a POST request without body
+func postAction(path string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		resolved, err := resolvePath(c, path)
+		if err != nil {
+			return err
+		}
+		return printResponse(c)(gatewayClient(c).post(resolved, nil))
+	}
+}
+
+// deleteAction sends a DELETE request
+func deleteAction(path string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		resolved, err := resolvePath(c, path)
+		if err != nil {
+			return err
+		}
+		if err := gatewayClient(c).delete(resolved); err != nil {
+			return err
+		}
+		fmt.Println("Deleted", c.Args().First())
+		return nil
+	}
+}
+
+// printResponse returns a function printing a JSON response in the selected output format
+func printResponse(c *cli.Context) func([]byte, error) error {
+	return func(data []byte, err error) error {
+		if err != nil {
+			return err
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			// Not JSON, print as is
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+
+		output, err := format(c, value)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+}
+
+// format encodes value in the selected output format
+func format(c *cli.Context, value interface{}) ([]byte, error) {
+	if c.String("output") == "yaml" {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(output, '\n'), nil
+}
+
+// readDefinition reads a JSON or YAML definition file
+func readDefinition(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var definition map[string]interface{}
+	if err := yaml.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return definition, nil
+}
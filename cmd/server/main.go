@@ -2,60 +2,153 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wangfeng/mcp-gateway2/internal/api"
+	"github.com/wangfeng/mcp-gateway2/internal/config"
 	"github.com/wangfeng/mcp-gateway2/internal/db"
+	"github.com/wangfeng/mcp-gateway2/internal/graphqlapi"
+	"github.com/wangfeng/mcp-gateway2/internal/grpcapi"
 	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/alerting"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/backup"
+	"github.com/wangfeng/mcp-gateway2/pkg/compress"
+	"github.com/wangfeng/mcp-gateway2/pkg/errorsink"
+	"github.com/wangfeng/mcp-gateway2/pkg/events"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+	"github.com/wangfeng/mcp-gateway2/pkg/grpcapi/gatewayv1"
+	"github.com/wangfeng/mcp-gateway2/pkg/health"
+	"github.com/wangfeng/mcp-gateway2/pkg/llm"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
 	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
-	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/plugin"
+	"github.com/wangfeng/mcp-gateway2/pkg/quota"
+	"github.com/wangfeng/mcp-gateway2/pkg/ratelimit"
+	"github.com/wangfeng/mcp-gateway2/pkg/rotation"
 	"github.com/wangfeng/mcp-gateway2/pkg/router"
+	"github.com/wangfeng/mcp-gateway2/pkg/seed"
+	"github.com/wangfeng/mcp-gateway2/pkg/upstream"
+	"google.golang.org/grpc"
 )
 
-const (
-	defaultPort = "8080"
-	configDir   = "./config"
-)
-
+// @title MCP Gateway API
+// @version 1.0.0
+// @description Admin API of the MCP Gateway: manage HTTP interfaces, MCP servers, routers,
+// @description upstreams, environments, WASM plugins and alert webhooks, and invoke tools.
+// @BasePath /
 func main() {
+	configPath := flag.String("config", "", "path to the YAML configuration file")
+	seedPacks := flag.String("seed", "", "comma-separated seed packs to create at startup, e.g. examples,demo")
+	flag.Parse()
+
+	// Load the configuration file, overridden by environment variables
+	configManager, err := config.NewManager(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	cfg := configManager.Current()
+
+	// Set up structured logging
+	if err := logging.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
+
+	// Report panics, repository errors and tool call failures to the configured error sink
+	errorReporter, err := errorsink.NewReporter(errorReportingConfig(cfg.ErrorReporting))
+	if err != nil {
+		log.Fatalf("Failed to set up error reporting: %v", err)
+	}
+	errorsink.SetDefault(errorReporter)
+
 	// Set up context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Create the config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(cfg.Server.ConfigDir, 0755); err != nil {
 		log.Fatalf("Failed to create config directory: %v", err)
 	}
+	if err := os.MkdirAll(cfg.Server.WasmDir, 0755); err != nil {
+		log.Fatalf("Failed to create wasm directory: %v", err)
+	}
 
-	// Initialize database connection
-	// Set default config from environment variables or use defaults
-	dbConfig := db.GetConfig()
+	// Readiness checks of the gateway's dependencies
+	healthChecker := health.NewChecker()
+	healthChecker.Add("wasmDir", health.DirWritable(cfg.Server.WasmDir))
 
-	// Use PostgreSQL if environment variable is set
-	usePostgresEnv := os.Getenv("USE_POSTGRES")
-	usePostgres := usePostgresEnv == "" || usePostgresEnv == "true" || usePostgresEnv == "1"
+	// Initialize database connection
+	dbConfig := cfg.DB()
+	usePostgres := cfg.Database.Enabled
 
 	var httpRepo repository.HTTPInterfaceRepository
 	var mcpRepo repository.MCPServerRepository
+	var upstreamRepo repository.UpstreamRepository
+	var routerRepo repository.RouterRepository
+	var invocationRepo repository.InvocationRepository
+	var alertWebhookRepo repository.AlertWebhookRepository
+	var eventWebhookRepo repository.EventWebhookRepository
+	var wasmFileRepo repository.WasmFileRepository
+	var environmentRepo repository.EnvironmentRepository
+	var quotaRepo repository.QuotaRepository
+	var policyRepo repository.NamespacePolicyRepository
+	var secretRepo repository.SecretRepository
+	var collectionRepo repository.CollectionRepository
+	var apiKeyRepo repository.APIKeyRepository
+	var revisionRepo repository.RevisionRepository
+	var sessionRepo repository.MCPSessionRepository
+	var aliasRepo repository.ServerAliasRepository
+	var notifier *db.Notifier
+	var database *sql.DB
 
 	if usePostgres {
 		// Connect to PostgreSQL database
-		database, err := db.ConnectDB()
+		db.SetSlowQueryThreshold(time.Duration(cfg.Database.SlowQueryMs) * time.Millisecond)
+		database, err = db.Connect(dbConfig)
 		if err != nil {
 			log.Fatalf("Failed to connect to database: %v", err)
 		}
 		defer database.Close()
+		healthChecker.Add("database", database.PingContext)
+
+		// Propagate MCP server changes to the other gateway instances
+		notifier = db.NewNotifier(database, dbConfig, "mcp_server_changes")
+		defer notifier.Close()
 
 		// PostgreSQL repositories
 		pgHttpRepo := repository.NewPgHTTPInterfaceRepository(database)
 		pgMcpRepo := repository.NewPgMCPServerRepository(database)
+		pgUpstreamRepo := repository.NewPgUpstreamRepository(database)
+		pgRouterRepo := repository.NewPgRouterRepository(database)
+		pgInvocationRepo := repository.NewPgInvocationRepository(database)
+		pgAlertWebhookRepo := repository.NewPgAlertWebhookRepository(database)
+		pgEventWebhookRepo := repository.NewPgEventWebhookRepository(database)
+		pgWasmFileRepo := repository.NewPgWasmFileRepository(database)
+		pgEnvironmentRepo := repository.NewPgEnvironmentRepository(database)
+		pgQuotaRepo := repository.NewPgQuotaRepository(database)
+		pgPolicyRepo := repository.NewPgNamespacePolicyRepository(database)
+		pgSecretRepo := repository.NewPgSecretRepository(database)
+		pgAPIKeyRepo := repository.NewPgAPIKeyRepository(database)
+		pgCollectionRepo := repository.NewPgCollectionRepository(database)
+		pgRevisionRepo := repository.NewPgRevisionRepository(database)
+		pgSessionRepo := repository.NewPgMCPSessionRepository(database)
+		pgAliasRepo := repository.NewPgServerAliasRepository(database)
 
 		// Initialize tables
 		if err := pgHttpRepo.Initialize(ctx); err != nil {
@@ -64,58 +157,492 @@ func main() {
 		if err := pgMcpRepo.Initialize(ctx); err != nil {
 			log.Fatalf("Failed to initialize MCP server repository: %v", err)
 		}
+		if err := pgUpstreamRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize upstream repository: %v", err)
+		}
+		if err := pgRouterRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize router repository: %v", err)
+		}
+		if err := pgInvocationRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize invocation repository: %v", err)
+		}
+		if err := pgAlertWebhookRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize alert webhook repository: %v", err)
+		}
+		if err := pgEventWebhookRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize event webhook repository: %v", err)
+		}
+		if err := pgWasmFileRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize WASM file repository: %v", err)
+		}
+		if err := pgEnvironmentRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize environment repository: %v", err)
+		}
+		if err := pgQuotaRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize quota repository: %v", err)
+		}
+		if err := pgPolicyRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize namespace policy repository: %v", err)
+		}
+		if err := pgSecretRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize secret repository: %v", err)
+		}
+		if err := pgCollectionRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize collection repository: %v", err)
+		}
+		if err := pgAPIKeyRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize API key repository: %v", err)
+		}
+		if err := pgRevisionRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize revision repository: %v", err)
+		}
+		if err := pgSessionRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize MCP session repository: %v", err)
+		}
+		if err := pgAliasRepo.Initialize(ctx); err != nil {
+			log.Fatalf("Failed to initialize server alias repository: %v", err)
+		}
 
 		httpRepo = pgHttpRepo
 		mcpRepo = pgMcpRepo
-
-		log.Printf("Using PostgreSQL repositories: %s@%s:%s/%s",
-			dbConfig.User, dbConfig.Host, dbConfig.Port, dbConfig.Database)
+		upstreamRepo = pgUpstreamRepo
+		routerRepo = pgRouterRepo
+		invocationRepo = pgInvocationRepo
+		alertWebhookRepo = pgAlertWebhookRepo
+		eventWebhookRepo = pgEventWebhookRepo
+		wasmFileRepo = pgWasmFileRepo
+		environmentRepo = pgEnvironmentRepo
+		quotaRepo = pgQuotaRepo
+		policyRepo = pgPolicyRepo
+		secretRepo = pgSecretRepo
+		collectionRepo = pgCollectionRepo
+		apiKeyRepo = pgAPIKeyRepo
+		revisionRepo = pgRevisionRepo
+		sessionRepo = pgSessionRepo
+		aliasRepo = pgAliasRepo
+
+		slog.Info("Using PostgreSQL repositories", "user", dbConfig.User, "host", dbConfig.Host,
+			"port", dbConfig.Port, "database", dbConfig.Database)
 	} else {
 		// In-memory repositories (for development)
 		httpRepo = repository.NewInMemoryHTTPInterfaceRepository()
 		mcpRepo = repository.NewInMemoryMCPServerRepository()
-		log.Println("Using in-memory repositories")
+		upstreamRepo = repository.NewInMemoryUpstreamRepository()
+		routerRepo = repository.NewInMemoryRouterRepository()
+		invocationRepo = repository.NewInMemoryInvocationRepository()
+		alertWebhookRepo = repository.NewInMemoryAlertWebhookRepository()
+		eventWebhookRepo = repository.NewInMemoryEventWebhookRepository()
+		wasmFileRepo = repository.NewInMemoryWasmFileRepository()
+		environmentRepo = repository.NewInMemoryEnvironmentRepository()
+		quotaRepo = repository.NewInMemoryQuotaRepository()
+		policyRepo = repository.NewInMemoryNamespacePolicyRepository()
+		secretRepo = repository.NewInMemorySecretRepository()
+		collectionRepo = repository.NewInMemoryCollectionRepository()
+		apiKeyRepo = repository.NewInMemoryAPIKeyRepository()
+		revisionRepo = repository.NewInMemoryRevisionRepository()
+		sessionRepo = repository.NewInMemoryMCPSessionRepository()
+		aliasRepo = repository.NewInMemoryServerAliasRepository()
+		slog.Info("Using in-memory repositories")
 	}
 
+	// Record repository error metrics
+	httpRepo = repository.NewInstrumentedHTTPInterfaceRepository(httpRepo)
+	mcpRepo = repository.NewInstrumentedMCPServerRepository(mcpRepo)
+	if notifier != nil {
+		mcpRepo = repository.NewNotifyingMCPServerRepository(mcpRepo, notifier.Publish)
+	}
+
+	// Notify event webhooks of interface and server lifecycle changes
+	eventDispatcher := events.NewDispatcher(eventWebhookRepo)
+	defer eventDispatcher.Stop()
+	httpRepo = repository.NewEventingHTTPInterfaceRepository(httpRepo, eventDispatcher)
+	mcpRepo = repository.NewEventingMCPServerRepository(mcpRepo, eventDispatcher)
+	revisionRepo = repository.NewEventingRevisionRepository(revisionRepo, eventDispatcher)
+
+	// Enforce the resource quotas of tenants
+	httpRepo = repository.NewQuotaHTTPInterfaceRepository(httpRepo, quotaRepo)
+	mcpRepo = repository.NewQuotaMCPServerRepository(mcpRepo, quotaRepo)
+
+	// Redirect the former names of renamed servers for renames.aliasDays
+	mcpRepo = repository.NewAliasingMCPServerRepository(mcpRepo, aliasRepo, func() time.Duration {
+		return time.Duration(configManager.Current().Renames.AliasDays) * 24 * time.Hour
+	})
+
+	// Limit API requests to the interfaces, servers, revisions, routers, collections and sessions of their namespace
+	httpRepo = repository.NewNamespacedHTTPInterfaceRepository(httpRepo)
+	mcpRepo = repository.NewNamespacedMCPServerRepository(mcpRepo)
+	revisionRepo = repository.NewNamespacedRevisionRepository(revisionRepo)
+	routerRepo = repository.NewNamespacedRouterRepository(routerRepo)
+	collectionRepo = repository.NewNamespacedCollectionRepository(collectionRepo)
+	sessionRepo = repository.NewNamespacedMCPSessionRepository(sessionRepo)
+
 	// Initialize MCP service
-	mcpService, err := mcp.NewMCPService(configDir)
+	mcpService, err := mcp.NewMCPService(cfg.Server.ConfigDir)
 	if err != nil {
 		log.Fatalf("Failed to initialize MCP service: %v", err)
 	}
 
+	// Load upstreams and start their health checks
+	upstreamManager := upstream.NewManager()
+	defer upstreamManager.Stop()
+	upstreams, err := upstreamRepo.GetAll(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load upstreams: %v", err)
+	}
+	for _, u := range upstreams {
+		upstreamManager.Set(u)
+	}
+	mcpService.SetURLResolver(upstreamManager)
+	mcpService.SetInvocationRecorder(invocationRepo)
+	mcpService.SetEventBroadcaster(eventDispatcher)
+	mcpService.SetEnvironmentStore(environmentRepo)
+	mcpService.SetSecretStore(secretRepo)
+
+	// Run the WASM plugins attached to servers and tools
+	pluginHost, err := plugin.NewHost(ctx, wasmFileRepo)
+	if err != nil {
+		log.Fatalf("Failed to initialize WASM plugin runtime: %v", err)
+	}
+	defer pluginHost.Close(context.Background())
+	mcpService.SetPluginRunner(pluginHost)
+
+	// Limit the tool invocations of each caller and the hosts tools may call
+	rateLimiter := ratelimit.NewLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	mcpService.SetRateLimiter(rateLimiter)
+	mcpService.SetAllowedHosts(cfg.Upstream.AllowedHosts)
+	mcpService.SetAllowStdio(cfg.Upstream.AllowStdio)
+	mcpService.SetUpstreamProtocols(cfg.Upstream.Protocols)
+	mcpService.SetNetworkZones(cfg.Network.Zones)
+	mcpService.SetRevalidation(cfg.Upstream.RevalidationEntries)
+	if err := mcpService.SetRedactions(cfg.Redaction.Rules); err != nil {
+		log.Fatalf("Invalid redaction rules: %v", err)
+	}
+
+	// Count the tool calls of each tenant against its daily quota
+	quotaTracker := quota.NewTracker(quotaRepo, httpRepo, mcpRepo)
+	mcpService.SetToolCallCounter(quotaTracker)
+
+	// Count the tool calls of each API key and their cost against its quotas
+	keyTracker := quota.NewKeyTracker(apiKeyRepo)
+	mcpService.SetAPIKeyCounter(keyTracker)
+
+	// Apply the call policies of namespaces to the servers that do not override them
+	mcpService.SetNamespacePolicyStore(repository.NewNamespacePolicyLookup(policyRepo))
+
+	// Register the active MCP servers so they are served right after a restart
+	servers, err := mcpRepo.GetAll(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load MCP servers: %v", err)
+	}
+	registered := []string{}
+	for i := range servers {
+		if servers[i].Status != "active" {
+			continue
+		}
+		if err := mcpService.RegisterServer(&servers[i]); err != nil {
+			slog.Error("Failed to register MCP server", "id", servers[i].ID, "name", servers[i].Name, "error", err)
+			continue
+		}
+		registered = append(registered, servers[i].Name)
+	}
+	slog.Info("Registered active MCP servers", "registered", len(registered), "total", len(servers), "servers", registered)
+
+	// Drop stale registrations left by changes that bypassed the API handlers
+	stopReconciler := mcpService.StartReconciler(mcpRepo, 30*time.Second)
+	defer stopReconciler()
+
+	// Activate and deactivate the MCP servers with a schedule
+	stopScheduler := mcpService.StartScheduler(mcpRepo, 15*time.Second)
+	defer stopScheduler()
+
+	// Refresh the cached OAuth2 tokens of auth profiles before they expire
+	stopTokenRefresher := mcpService.StartTokenRefresher(10 * time.Second)
+	defer stopTokenRefresher()
+
+	// Rotate the secrets with a rotation when it is due
+	secretRotator := rotation.NewRotator(secretRepo, httpRepo, mcpRepo, eventDispatcher)
+	stopRotator := secretRotator.Start(15 * time.Second)
+	defer stopRotator()
+
+	// Apply the MCP server changes made by other gateway instances
+	if notifier != nil {
+		err := notifier.Listen(func(id string) {
+			if err := mcpService.ReloadServer(context.Background(), mcpRepo, id); err != nil {
+				slog.Error("Failed to reload MCP server", "id", id, "error", err)
+			}
+		}, func() {
+			if err := mcpService.Reconcile(context.Background(), mcpRepo); err != nil {
+				slog.Error("Failed to reconcile MCP servers", "error", err)
+			}
+		})
+		if err != nil {
+			log.Fatalf("Failed to listen for MCP server changes: %v", err)
+		}
+	}
+
+	// Notify alert webhooks of failing tools and unhealthy upstream targets
+	alerter := alerting.NewAlerter(alertWebhookRepo, invocationRepo)
+	alerter.Start()
+	defer alerter.Stop()
+	upstreamManager.OnHealthChange(alerter.HandleHealthEvent)
+	healthChecker.AddOptional("upstreams", upstreamManager.CheckHealthy)
+
+	// Reconcile interfaces, servers and routers with declarative bundles
+	reconciler := gitops.NewReconciler(httpRepo, mcpRepo, routerRepo, mcpService)
+	// Reject bundles changing active servers while their changes must be approved
+	reconciler.SetApproval(func() bool {
+		return configManager.Current().Approval.Enabled
+	})
+
+	// Sync them from a git repository of declarative YAML files
+	var gitopsController *gitops.Controller
+	if cfg.GitOps.Enabled {
+		gitopsController = gitops.NewController(gitops.Config{
+			Repository: cfg.GitOps.Repository,
+			Branch:     cfg.GitOps.Branch,
+			Path:       cfg.GitOps.Path,
+			Dir:        cfg.GitOps.Dir,
+			Interval:   time.Duration(cfg.GitOps.IntervalSeconds) * time.Second,
+			Prune:      cfg.GitOps.Prune,
+		}, reconciler)
+		gitopsController.Start()
+		defer gitopsController.Stop()
+	}
+
+	// Initialize the scheduled backups of the configuration
+	var backupScheduler *backup.Scheduler
+	if cfg.Backup.Enabled {
+		backupStore, err := backup.NewStore(cfg.Backup.Destination, backup.S3Config{
+			Endpoint:        cfg.Backup.S3.Endpoint,
+			Region:          cfg.Backup.S3.Region,
+			AccessKeyID:     cfg.Backup.S3.AccessKeyID,
+			SecretAccessKey: cfg.Backup.S3.SecretAccessKey,
+		})
+		if err != nil {
+			log.Fatalf("Failed to open backup destination: %v", err)
+		}
+		backupScheduler = backup.NewScheduler(backup.Config{
+			Interval: time.Duration(cfg.Backup.IntervalMinutes) * time.Minute,
+			Keep:     cfg.Backup.Keep,
+			MaxAge:   time.Duration(cfg.Backup.MaxAgeDays) * 24 * time.Hour,
+		}, cfg.Backup.Destination, backupStore, reconciler)
+		backupScheduler.Start()
+		defer backupScheduler.Stop()
+	}
+
 	// Initialize API handlers
 	httpHandler := api.NewHTTPInterfaceHandler(httpRepo)
+	serverSyncer := mcp.NewServerSyncer(mcpRepo, httpRepo, mcpService)
+	// Hold the syncs of active servers for approval while approval.enabled is set
+	serverSyncer.SetApproval(revisionRepo, func() bool {
+		return configManager.Current().Approval.Enabled
+	})
+	httpHandler.SetServerSyncer(serverSyncer)
+	httpHandler.SetCollectionRepository(collectionRepo)
+	httpHandler.SetMCPService(mcpService)
 	mcpHandler := api.NewMCPServerHandler(mcpRepo, httpRepo, mcpService)
-	// wasmHandler := api.NewWasmFileHandler(mcpRepo, mcpService)
+	mcpHandler.SetCollectionRepository(collectionRepo)
+	// Generate tool descriptions with the configured LLM
+	llmClient := llm.NewClient(llmConfig(cfg.LLM))
+	mcpHandler.SetLLMClient(llmClient)
+	mcpHandler.SetInvocationRepository(invocationRepo)
+	mcpHandler.SetAliasRepository(aliasRepo)
+	// Hold the changes of active servers for approval while approval.enabled is set
+	mcpHandler.SetApproval(revisionRepo, func() (bool, string) {
+		current := configManager.Current()
+		return current.Approval.Enabled, current.ApproverToken()
+	})
+	collectionHandler := api.NewCollectionHandler(collectionRepo, httpRepo)
+	upstreamHandler := api.NewUpstreamHandler(upstreamRepo, upstreamManager)
+	routerHandler := api.NewRouterHandler(routerRepo)
+	invocationHandler := api.NewInvocationHandler(invocationRepo, mcpRepo)
+	statsHandler := api.NewStatsHandler(invocationRepo, mcpRepo)
+	alertWebhookHandler := api.NewAlertWebhookHandler(alertWebhookRepo, alerter)
+	eventWebhookHandler := api.NewEventWebhookHandler(eventWebhookRepo, eventDispatcher)
+	eventStreamHandler := api.NewEventStreamHandler(eventDispatcher)
+	gitopsHandler := api.NewGitOpsHandler(gitopsController)
+	backupHandler := api.NewBackupHandler(backupScheduler, func() string {
+		return configManager.Current().Admin.Token
+	})
+	applyHandler := api.NewApplyHandler(reconciler)
+	// Create fixture resources on demand unless seed.enabled is false
+	var seeder *seed.Seeder
+	if cfg.Seed.Enabled {
+		seeder = seed.NewSeeder(reconciler)
+	}
+	seedHandler := api.NewSeedHandler(seeder)
+	adminHandler := api.NewAdminHandler()
+	adminHandler.SetConfigReloader(configManager)
+	wasmHandler := api.NewWasmFileHandler(wasmFileRepo, mcpRepo, cfg.Server.WasmDir)
+	environmentHandler := api.NewEnvironmentHandler(environmentRepo)
+	tenantHandler := api.NewTenantHandler(quotaRepo, policyRepo, quotaTracker, func() string {
+		return configManager.Current().Admin.Token
+	})
+	secretHandler := api.NewSecretHandler(secretRepo, secretRotator, func() string {
+		return configManager.Current().Admin.Token
+	})
+	apiKeyHandler := api.NewAPIKeyHandler(apiKeyRepo, keyTracker, func() string {
+		return configManager.Current().Admin.Token
+	})
+	limitsHandler := api.NewLimitsHandler(rateLimiter, keyTracker, quotaTracker)
+	openAPIHandler, err := api.NewOpenAPIHandler()
+	if err != nil {
+		log.Fatalf("Failed to load API specification: %v", err)
+	}
 
 	// Initialize router handler for MCP server dynamic routing
 	mcpRouter := router.NewMCPServerRouter(mcpRepo, mcpService)
+	mcpRouter.SetAliases(aliasRepo)
+
+	// Issue sessions on the MCP transport unless sessions.enabled is false
+	if cfg.Sessions.Enabled {
+		mcpRouter.SetSessions(sessionRepo, time.Duration(cfg.Sessions.IdleTimeoutMinutes)*time.Minute)
+		stopSessionCleanup := mcpRouter.StartSessionCleanup(time.Minute)
+		defer stopSessionCleanup()
+	} else {
+		sessionRepo = nil
+	}
+	sessionHandler := api.NewMCPSessionHandler(sessionRepo, func() string {
+		return configManager.Current().Admin.Token
+	})
+
+	// Initialize rule router for gateway requests matched by routing rules
+	ruleRouter := router.NewRuleRouter(routerRepo, mcpRepo, mcpRouter, upstreamManager)
 
 	// Set up Gin router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		errorsink.CapturePanic(c.Request.Context(), recovered, debug.Stack(), c.Request)
+		apierror.Respond(c, http.StatusInternalServerError, "internal server error")
+	}))
+	router.NoRoute(func(c *gin.Context) {
+		apierror.Respond(c, http.StatusNotFound, "No route for "+c.Request.Method+" "+c.Request.URL.Path)
+	})
+
+	// Only take the client address of invocations from X-Forwarded-For when a trusted proxy sets
+	// it; without trusted proxies, the address of the connection is used
+	if err := router.SetTrustedProxies(cfg.Network.TrustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+
+	// Assign a request ID and log every request as a structured record
+	router.Use(logging.RequestIDMiddleware())
+	router.Use(logging.Middleware())
+
+	// Record HTTP handler metrics
+	router.Use(metrics.Middleware())
+
+	// Compress responses for clients that accept it
+	if cfg.Server.Compression.Enabled {
+		router.Use(compress.Middleware(cfg.Server.Compression.MinSize))
+	}
 
 	// Add CORS middleware
-	router.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+	var cors atomic.Pointer[corsPolicy]
+	cors.Store(newCORSPolicy(cfg.CORS.AllowOrigins))
+	router.Use(corsMiddleware(&cors))
+
+	// Apply the reloadable settings when the configuration is reloaded
+	configManager.OnReload(func(cfg config.Config) {
+		if err := logging.SetLevel(cfg.Log.Level); err != nil {
+			slog.Error("Failed to set log level", "error", err)
+		}
+		cors.Store(newCORSPolicy(cfg.CORS.AllowOrigins))
+		rateLimiter.SetLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+		mcpService.SetAllowedHosts(cfg.Upstream.AllowedHosts)
+		mcpService.SetAllowStdio(cfg.Upstream.AllowStdio)
+		mcpService.SetUpstreamProtocols(cfg.Upstream.Protocols)
+		mcpService.SetNetworkZones(cfg.Network.Zones)
+		mcpService.SetRevalidation(cfg.Upstream.RevalidationEntries)
+		if err := mcpService.SetRedactions(cfg.Redaction.Rules); err != nil {
+			slog.Error("Failed to set redaction rules", "error", err)
+		}
+		llmClient.SetConfig(llmConfig(cfg.LLM))
+		if err := errorReporter.SetConfig(errorReportingConfig(cfg.ErrorReporting)); err != nil {
+			slog.Error("Failed to set error reporting", "error", err)
+		}
+		db.SetSlowQueryThreshold(time.Duration(cfg.Database.SlowQueryMs) * time.Millisecond)
+		if database != nil {
+			cfg.DB().Pool.Apply(database)
+		}
+	})
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+	// Scope every request to the namespace it selects
+	router.Use(func(c *gin.Context) {
+		name := c.GetHeader(namespace.Header)
+		if name == "" {
+			name = namespace.Default
+		} else if err := namespace.Validate(name); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
 			return
 		}
+		c.Request = c.Request.WithContext(namespace.With(c.Request.Context(), name))
+		c.Next()
+	})
+
+	// Record the change note of the headers on the interface and server versions of the request
+	router.Use(api.ChangeNoteMiddleware())
 
+	// Identify the caller, API key, selected environment and cookie jar of tool invocations, and
+	// report the calls of deprecated tools and the rate limit in the response headers
+	router.Use(func(c *gin.Context) {
+		ctx := mcp.WithCaller(c.Request.Context(), c.ClientIP())
+		ctx = mcp.WithResponseHeader(ctx, c.Writer.Header())
+		if authorization := c.GetHeader("Authorization"); authorization != "" {
+			ctx = mcp.WithAuthorization(ctx, authorization)
+		}
+		if environment := c.GetHeader(mcp.EnvironmentHeader); environment != "" {
+			ctx = mcp.WithEnvironment(ctx, environment)
+		}
+		if jar := c.GetHeader(mcp.CookieJarHeader); jar != "" {
+			ctx = mcp.WithCookieJar(ctx, jar)
+		}
+		if key := c.GetHeader(mcp.APIKeyHeader); key != "" {
+			apiKey, err := keyTracker.Resolve(ctx, key)
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusUnauthorized, "Invalid API key")
+				return
+			} else if err != nil {
+				apierror.Respond(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+			ctx = mcp.WithAPIKey(ctx, apiKey, c.Writer.Header())
+		}
+		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	})
 
 	// Register API routes
 	httpHandler.RegisterRoutes(router)
 	mcpHandler.RegisterRoutes(router)
-	// wasmHandler.RegisterRoutes(router)
+	upstreamHandler.RegisterRoutes(router)
+	routerHandler.RegisterRoutes(router)
+	invocationHandler.RegisterRoutes(router)
+	statsHandler.RegisterRoutes(router)
+	alertWebhookHandler.RegisterRoutes(router)
+	eventWebhookHandler.RegisterRoutes(router)
+	eventStreamHandler.RegisterRoutes(router)
+	gitopsHandler.RegisterRoutes(router)
+	backupHandler.RegisterRoutes(router)
+	applyHandler.RegisterRoutes(router)
+	seedHandler.RegisterRoutes(router)
+	adminHandler.RegisterRoutes(router)
+	wasmHandler.RegisterRoutes(router)
+	environmentHandler.RegisterRoutes(router)
+	tenantHandler.RegisterRoutes(router)
+	secretHandler.RegisterRoutes(router)
+	apiKeyHandler.RegisterRoutes(router)
+	limitsHandler.RegisterRoutes(router)
+	collectionHandler.RegisterRoutes(router)
+	sessionHandler.RegisterRoutes(router)
+	if cfg.GraphQL.Enabled {
+		graphqlapi.NewHandler(httpRepo, mcpRepo, invocationRepo, cfg.GraphQL.MaxDepth).RegisterRoutes(router)
+	}
+	openAPIHandler.RegisterRoutes(router)
 
 	// Register MCP server router
 	mcpRouter.RegisterRoutes(router)
+	ruleRouter.RegisterRoutes(router)
 
 	// Create a basic index page
 	router.GET("/", func(c *gin.Context) {
@@ -141,21 +668,36 @@ func main() {
 		})
 	})
 
-	// Pre-add some example HTTP interfaces for testing
-	// Only in development mode or if no interfaces exist
-	if !usePostgres {
-		addExampleHTTPInterfaces(ctx, httpRepo)
-	} else {
-		// Check if we have any interfaces
-		interfaces, err := httpRepo.GetAll(ctx)
-		if err != nil {
-			log.Printf("Failed to check for existing interfaces: %v", err)
-		} else if len(interfaces) == 0 {
-			log.Println("No HTTP interfaces found, adding examples")
-			addExampleHTTPInterfaces(ctx, httpRepo)
+	// Add a readiness endpoint verifying dependencies, e.g. for Kubernetes readiness probes
+	router.GET("/health/ready", healthChecker.ReadyHandler())
+
+	// Create the fixture resources selected with --seed
+	if *seedPacks != "" {
+		if seeder == nil {
+			slog.Warn("Seeding is disabled, ignoring --seed", "packs", *seedPacks)
+		} else if _, err := seeder.Seed(ctx, strings.Split(*seedPacks, ","), false); err != nil {
+			log.Fatalf("Failed to seed: %v", err)
 		}
 	}
 
+	// Expose Prometheus metrics
+	metrics.RegisterActiveServers(func() float64 {
+		countCtx, countCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer countCancel()
+		servers, err := mcpRepo.GetAll(countCtx)
+		if err != nil {
+			return 0
+		}
+		active := 0
+		for _, server := range servers {
+			if server.Status == "active" {
+				active++
+			}
+		}
+		return float64(active)
+	})
+	router.GET("/metrics", metrics.Handler())
+
 	// Add debug routes
 	router.GET("/debug/routes", func(c *gin.Context) {
 		routes := router.Routes()
@@ -168,115 +710,174 @@ func main() {
 
 	// Add database configuration info endpoint (for debugging)
 	router.GET("/debug/db-config", func(c *gin.Context) {
-		config := db.GetConfig()
+		config := cfg.DB()
 		// Don't expose the password
 		config.Password = "********"
 		c.JSON(http.StatusOK, config)
 	})
 
-	// Determine port to listen on
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
-	}
+	// Add connection pool statistics endpoint (for capacity planning)
+	router.GET("/debug/db-stats", func(c *gin.Context) {
+		if database == nil {
+			apierror.Respond(c, http.StatusNotFound, "Database statistics require PostgreSQL")
+			return
+		}
+		c.JSON(http.StatusOK, database.Stats())
+	})
+
+	// Add OAuth2 token cache endpoint, without the tokens (for debugging)
+	router.GET("/debug/oauth-tokens", func(c *gin.Context) {
+		c.JSON(http.StatusOK, mcpService.OAuthTokens())
+	})
+
+	// Add upstream connection reuse endpoint, by host (for debugging)
+	router.GET("/debug/upstream-connections", func(c *gin.Context) {
+		c.JSON(http.StatusOK, mcpService.UpstreamConnections())
+	})
+
+	// Add effective configuration endpoint, with secrets redacted (for debugging)
+	router.GET("/debug/config", func(c *gin.Context) {
+		c.JSON(http.StatusOK, configManager.Current().Redacted())
+	})
 
 	// Start the server
+	port := cfg.Server.Port
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%s", port),
+		Addr:    fmt.Sprintf(":%d", port),
 		Handler: router,
 	}
+	// Event streams would otherwise keep the server from shutting down
+	srv.RegisterOnShutdown(eventDispatcher.CloseStreams)
 
 	// Run the server in a separate goroutine
 	go func() {
-		log.Printf("Server starting on port %s", port)
+		slog.Info("Server starting", "port", port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
+	// Serve the gRPC admin API with the handlers of the REST API
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcServer = grpc.NewServer()
+		gatewayv1.RegisterGatewayAdminServer(grpcServer, grpcapi.NewServer(router))
+		go func() {
+			slog.Info("gRPC server starting", "port", cfg.GRPC.Port)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
+	// Reload the configuration on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if _, err := configManager.Reload(); err != nil {
+				slog.Error("Failed to reload configuration", "error", err)
+			}
+		}
+	}()
+
 	// Set up graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
 
 	// Create shutdown context with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
 
+	// Let the running gRPC calls finish like the HTTP requests, then cancel them
+	grpcStopped := make(chan struct{})
+	go func() {
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+		close(grpcStopped)
+	}()
+
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+	}
+
+	slog.Info("Server exited properly")
+}
+
+// corsPolicy is the set of origins allowed to make cross-origin requests
+type corsPolicy struct {
+	allowAll bool
+	allowed  map[string]bool
+}
+
+// newCORSPolicy creates the policy allowing allowOrigins, "*" allowing any origin
+func newCORSPolicy(allowOrigins []string) *corsPolicy {
+	policy := &corsPolicy{allowed: make(map[string]bool, len(allowOrigins))}
+	for _, origin := range allowOrigins {
+		if origin == "*" {
+			policy.allowAll = true
+		}
+		policy.allowed[origin] = true
+	}
+	return policy
+}
+
+// corsMiddleware allows cross-origin requests according to the current policy
+func corsMiddleware(current *atomic.Pointer[corsPolicy]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := current.Load()
+		if policy.allowAll {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Writer.Header().Add("Vary", "Origin")
+			if origin := c.GetHeader("Origin"); policy.allowed[origin] {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		}
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID, X-MCP-Environment, X-MCP-Namespace, X-MCP-Cookie-Jar, X-API-Key, X-Change-Author, X-Change-Message, Mcp-Session-Id")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, X-Quota-Remaining-Day, X-Quota-Remaining-Month, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, Warning, Sunset, X-Truncated, Mcp-Session-Id")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	}
+}
 
-	log.Println("Server exited properly")
+// llmConfig returns the client configuration of the LLM settings
+func llmConfig(cfg config.LLMConfig) llm.Config {
+	return llm.Config{
+		Provider: cfg.Provider,
+		URL:      cfg.URL,
+		APIKey:   cfg.APIKey,
+		Model:    cfg.Model,
+		Timeout:  time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
 }
 
-// addExampleHTTPInterfaces adds some example HTTP interfaces for testing
-func addExampleHTTPInterfaces(ctx context.Context, repo repository.HTTPInterfaceRepository) {
-	// Example 1: Random User API
-	randomUserAPI := &models.HTTPInterface{
-		Name:        "get-user",
-		Description: "Get random user information",
-		Method:      "GET",
-		Path:        "https://randomuser.me/api/",
-		Headers:     []models.Header{},
-		Parameters:  []models.Param{},
-		Responses: []models.Response{
-			{
-				StatusCode:  200,
-				Description: "Random user information",
-				Body: &models.Body{
-					ContentType: "application/json",
-					Schema:      `{"type": "object"}`,
-					Example:     `{"results": [{"name": {"first": "John", "last": "Doe"}, "email": "john.doe@example.com", "location": {"city": "New York", "country": "USA"}, "phone": "123-456-7890"}]}`,
-				},
-			},
-		},
-	}
-
-	// Example 2: Weather API
-	weatherAPI := &models.HTTPInterface{
-		Name:        "get-weather",
-		Description: "Get weather information for a location",
-		Method:      "GET",
-		Path:        "https://api.openweathermap.org/data/2.5/weather",
-		Headers:     []models.Header{},
-		Parameters: []models.Param{
-			{
-				Name:        "q",
-				Description: "City name",
-				In:          "query",
-				Required:    true,
-				Type:        "string",
-			},
-			{
-				Name:        "appid",
-				Description: "API key",
-				In:          "query",
-				Required:    true,
-				Type:        "string",
-			},
-		},
-		Responses: []models.Response{
-			{
-				StatusCode:  200,
-				Description: "Weather information",
-				Body: &models.Body{
-					ContentType: "application/json",
-					Schema:      `{"type": "object"}`,
-					Example:     `{"weather": [{"main": "Clear", "description": "clear sky"}], "main": {"temp": 293.15, "humidity": 75}}`,
-				},
-			},
-		},
-	}
-
-	// Add the examples
-	if err := repo.Create(ctx, randomUserAPI); err != nil {
-		log.Printf("Failed to add example HTTP interface: %v", err)
-	}
-
-	if err := repo.Create(ctx, weatherAPI); err != nil {
-		log.Printf("Failed to add example HTTP interface: %v", err)
+// errorReportingConfig returns the reporter configuration of the error reporting settings
+func errorReportingConfig(cfg config.ErrorReportingConfig) errorsink.Config {
+	return errorsink.Config{
+		DSN:         cfg.DSN,
+		Environment: cfg.Environment,
 	}
 }
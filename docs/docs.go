@@ -0,0 +1,11974 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/admin/log-level": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the log level",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LogLevelResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Change the log level",
+                "parameters": [
+                    {
+                        "description": "New log level",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.LogLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LogLevelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/reload": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reload the configuration file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/config.Config"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/alert-webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "List alert webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AlertWebhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "Create an alert webhook",
+                "parameters": [
+                    {
+                        "description": "Alert webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/alert-webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "Get an alert webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertWebhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "Update an alert webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "Delete an alert webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/alert-webhooks/{id}/test": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alert-webhooks"
+                ],
+                "summary": "Send a test notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/api-keys": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and quotas",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/api-keys/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Get an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKey"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Update an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and quotas",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Delete an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/api-keys/{id}/usage": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Get the usage of an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UTC month as YYYY-MM, the current one by default",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyUsage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/apply": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apply"
+                ],
+                "summary": "Apply a bundle of desired resources",
+                "parameters": [
+                    {
+                        "description": "Desired interfaces, servers and routers",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitops.Bundle"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the planned changes",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete resources missing from the bundle",
+                        "name": "prune",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/backups": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backups"
+                ],
+                "summary": "List the configuration backups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BackupListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backups"
+                ],
+                "summary": "Back up the configuration now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/backup.Object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/backups/{name}/restore": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backups"
+                ],
+                "summary": "Restore a configuration backup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Backup name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the planned changes",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete resources missing from the backup",
+                        "name": "prune",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "List collections",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Collection"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Create a collection",
+                "parameters": [
+                    {
+                        "description": "Collection",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Update a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{id}/http-interfaces": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "List the HTTP interfaces of a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HTTPInterface"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/environments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "List environments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Environment"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "Create an environment",
+                "parameters": [
+                    {
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Environment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Environment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/environments/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "Get an environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Environment"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "Update an environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Environment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Environment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "Delete an environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/event-webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "List event webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EventWebhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "Create an event webhook",
+                "parameters": [
+                    {
+                        "description": "Event webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EventWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/event-webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "Get an event webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventWebhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "Update an event webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EventWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "Delete an event webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/event-webhooks/{id}/test": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "event-webhooks"
+                ],
+                "summary": "Send a test event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/events": {
+            "get": {
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream lifecycle events and tool invocations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated event types, all if empty",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gitops/drift": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gitops"
+                ],
+                "summary": "Get the drift from the repository",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gitops.Drift"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gitops/status": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gitops"
+                ],
+                "summary": "Get the GitOps sync status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gitops.Status"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/gitops/sync": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gitops"
+                ],
+                "summary": "Sync from the repository now",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gitops.Status"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/gitops.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "List HTTP interfaces",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include archived interfaces",
+                        "name": "includeArchived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HTTPInterface"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Create an HTTP interface",
+                "parameters": [
+                    {
+                        "description": "HTTP interface",
+                        "name": "interface",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/from-curl": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Create an HTTP interface from a curl command",
+                "parameters": [
+                    {
+                        "description": "curl command",
+                        "name": "command",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CurlCommand"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.CurlImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/from-openapi": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Create HTTP interfaces from an OpenAPI specification",
+                "parameters": [
+                    {
+                        "description": "OpenAPI specification",
+                        "name": "import",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.OpenAPIImport"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the interfaces that would be saved",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run, or no interface created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/from-openapi-file": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Create HTTP interfaces from an OpenAPI file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "OpenAPI file, JSON or YAML",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Import the operations with one of the tags",
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Skip the operations with one of the tags",
+                        "name": "excludeTags",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Import the paths under one of the prefixes",
+                        "name": "pathPrefixes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Skip the paths under one of the prefixes",
+                        "name": "excludePathPrefixes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Import the operations of one of the methods",
+                        "name": "methods",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Skip the operations of one of the methods",
+                        "name": "excludeMethods",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the interfaces that would be saved",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run, or no interface created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Get an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached interface",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Update an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the interface the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "HTTP interface",
+                        "name": "interface",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Delete an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/archive": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Archive an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/check": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Check the connectivity of an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Probe options",
+                        "name": "check",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcp.ConnectivityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/examples": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Get examples of calling an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.InterfaceExamples"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/lint": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Lint an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LintReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/openapi": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Export an HTTP interface to OpenAPI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/unarchive": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Unarchive an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "List the versions of an HTTP interface with their change notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VersionInfo"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/http-interfaces/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "http-interfaces"
+                ],
+                "summary": "Get a version of an HTTP interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HTTP interface ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPInterface"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invocations/{id}/replay": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invocations"
+                ],
+                "summary": "Replay a recorded tool invocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invocation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edited params",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReplayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReplayReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/limits/self": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "limits"
+                ],
+                "summary": "Get the rate limit and quotas of the caller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key of the client",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CallerLimits"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/anthropic-tools": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "Export the tools of an MCP server for Anthropic tool use",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.AnthropicTool"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/openai-tools": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "Export the tools of an MCP server for OpenAI function calling",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.OpenAITool"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/prompts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "List the prompts of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/resources": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "List the resources of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/tools": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "List the tools of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-server/{name}/tools/{tool}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-protocol"
+                ],
+                "summary": "Invoke a tool of an MCP server by server name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tool parameters",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "List MCP servers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include archived servers",
+                        "name": "includeArchived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MCPServer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Create an MCP server from HTTP interfaces",
+                "parameters": [
+                    {
+                        "description": "MCP server",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateMCPServerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/validate-name": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Check that an MCP server name is available",
+                "parameters": [
+                    {
+                        "description": "Name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateNameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateNameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached server",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Update an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the server the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "MCP server",
+                        "name": "server",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Delete an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/activate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Activate an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/aliases": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "List the former names of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServerAlias"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/aliases/{name}": {
+            "delete": {
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Delete a former name of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Former name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/archive": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Archive an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/chained-tools": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Add a chained tool to an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Chained tool",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateChainedToolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tool"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/client-config": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get MCP client configuration for an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ClientConfig"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/client-examples": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get client code examples for an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/clone": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Clone an MCP server as a new draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name of the copy",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CloneMCPServerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/deactivate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Deactivate an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/enrich-descriptions": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Generate tool descriptions with an LLM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tools to describe",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.EnrichDescriptionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DescriptionSuggestions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/enrich-descriptions/accept": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Apply reviewed tool descriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewed suggestions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DescriptionSuggestions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/http-interfaces": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "List the HTTP interfaces of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HTTPInterface"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/invocations": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invocations"
+                ],
+                "summary": "List the invocations of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "success or error",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvocationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/invocations/export": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invocations"
+                ],
+                "summary": "Export the upstream calls of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "har",
+                        "description": "Export format, only har",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "success or error",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of invocations",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of newest invocations to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.HAR"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/metadata": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get the metadata of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/register": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Register an MCP server with the service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/revisions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "List the revisions of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, approved or rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Revision"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/schedule": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get the activation schedule of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Set the activation schedule of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Activation schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ActivationSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleStatus"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Remove the activation schedule of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get the usage statistics of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window duration, e.g. 24h",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/sync": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Regenerate the tools of an MCP server from its HTTP interfaces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcp.SyncResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Synced tools of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/mcp.SyncResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Invoke a tool of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tool parameters",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Set the alias, description, params, projection, cost, hedging, latency budget, auth passthrough, error mappings, partial responses, shadow and call policy of a tool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name or alias",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias, description, params, projection, cost, hedging, latency budget, auth passthrough, error mappings, partial responses, shadow and call policy",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpdateToolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tool"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}/disable": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Disable a tool of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name or alias",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tool"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}/enable": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Enable a tool of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name or alias",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tool"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}/policy": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get the effective call policy of a tool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name or alias",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}/render": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Render the request and response templates of a tool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name or alias",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample params and upstream response",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RenderToolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcp.ToolRendering"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/tools/{tool}/test": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Test a tool of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tool parameters",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ToolTestReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/unarchive": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Unarchive an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/usage-guide": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get the usage guide of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Verify the tools of an MCP server against their response schemas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tools to verify",
+                        "name": "verify",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.VerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.VerificationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "List the versions of an MCP server with their change notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VersionInfo"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Get a version of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-servers/{id}/websocket-tools": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-servers"
+                ],
+                "summary": "Add a WebSocket tool to an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MCP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "WebSocket tool",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateWebSocketToolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tool"
+                        }
+                    },
+                    "202": {
+                        "description": "Change of an active server awaiting approval",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-sessions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-sessions"
+                ],
+                "summary": "List MCP sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the sessions of this server",
+                        "name": "serverId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MCPSession"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mcp-sessions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-sessions"
+                ],
+                "summary": "Get an MCP session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "mcp-sessions"
+                ],
+                "summary": "Delete an MCP session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mcp-sessions"
+                ],
+                "summary": "Update an MCP session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Session state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpdateMCPSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MCPSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/revisions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "List the revisions of MCP servers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, approved or rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Revision"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/revisions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Get a revision of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/revisions/{id}/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Approve a revision of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer approver token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment of the approver",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReviewRevisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/revisions/{id}/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Reject a revision of an MCP server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer approver token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the rejection",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReviewRevisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "List routers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Router"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Create a router",
+                "parameters": [
+                    {
+                        "description": "Router",
+                        "name": "router",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Get a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Update a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Router",
+                        "name": "router",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Delete a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers/{id}/activate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Activate a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers/{id}/deactivate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Deactivate a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers/{id}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "List the versions of a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/routers/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routers"
+                ],
+                "summary": "Get a version of a router",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Router"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/secrets": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "List secrets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Secret"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Create a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Secret",
+                        "name": "secret",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Secret"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Secret"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/secrets/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Get a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Secret"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Update a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Secret",
+                        "name": "secret",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Secret"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Secret"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Delete a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the secret even if it is used",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/secrets/{id}/references": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "List the uses of a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SecretReference"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/secrets/{id}/rotate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Rotate a secret now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRotationStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/secrets/{id}/rotation": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "secrets"
+                ],
+                "summary": "Get the rotation of a secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRotationStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/seed": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seed"
+                ],
+                "summary": "List the seed packs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/seed.Pack"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seed"
+                ],
+                "summary": "Create the resources of seed packs",
+                "parameters": [
+                    {
+                        "description": "Packs to seed",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SeedRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the planned changes",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get gateway-wide usage statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window duration, e.g. 24h",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/deprecated-tools": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get the usage of deprecated tools",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window duration, e.g. 24h",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end time",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list the deprecated tools not called within the window",
+                        "name": "includeIdle",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DeprecatedToolsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenants": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "List tenant quotas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Quota"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenants/policies": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "List namespace policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NamespacePolicy"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenants/{id}/policy": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Get the policy of a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NamespacePolicy"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Set the policy of a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Timeout, retries, rate limit and allowed hosts",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NamespacePolicy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Delete the policy of a namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenants/{id}/quota": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Set the quota of a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Limits, 0 for unlimited",
+                        "name": "quota",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Quota"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Quota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Delete the quota of a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenants/{id}/usage": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenants"
+                ],
+                "summary": "Get the usage of a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant (namespace)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenantUsage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/upstreams": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "List upstreams",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Upstream"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "Create an upstream",
+                "parameters": [
+                    {
+                        "description": "Upstream",
+                        "name": "upstream",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Upstream"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upstream"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/upstreams/health": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "Get the health of all upstream targets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/upstream.Health"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/upstreams/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "Get an upstream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upstream ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upstream"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "Update an upstream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upstream ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upstream",
+                        "name": "upstream",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Upstream"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upstream"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upstreams"
+                ],
+                "summary": "Delete an upstream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upstream ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wasm-files": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wasm-files"
+                ],
+                "summary": "List WASM files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner MCP server ID",
+                        "name": "serverId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WasmFile"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wasm-files"
+                ],
+                "summary": "Upload a WASM file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "WASM module",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name, defaults to the file name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner MCP server ID",
+                        "name": "serverId",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WasmFile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wasm-files/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wasm-files"
+                ],
+                "summary": "Get the metadata of a WASM file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "WASM file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WasmFile"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wasm-files"
+                ],
+                "summary": "Delete a WASM file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "WASM file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wasm-files/{id}/download": {
+            "get": {
+                "produces": [
+                    "application/wasm"
+                ],
+                "tags": [
+                    "wasm-files"
+                ],
+                "summary": "Download a WASM file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "WASM file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "maxCostPerDay": {
+                    "description": "Cost of the tool calls per UTC day, 0 for unlimited",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "maxCostPerMonth": {
+                    "description": "Cost of the tool calls per UTC month, 0 for unlimited",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.AnthropicTool": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "input_schema": {
+                    "description": "JSON Schema of the arguments",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ApplyResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitops.Change"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "description": "Number of changes that failed",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BackupListResponse": {
+            "type": "object",
+            "properties": {
+                "backups": {
+                    "description": "Newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backup.Object"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/backup.Status"
+                }
+            }
+        },
+        "api.CallerLimits": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "description": "Usage of the API key sent in X-API-Key this month, unset without a key",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.APIKeyUsage"
+                        }
+                    ]
+                },
+                "caller": {
+                    "description": "Client address the rate limit is kept for",
+                    "type": "string"
+                },
+                "rateLimit": {
+                    "description": "Unset if rate limiting is disabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.RateLimitState"
+                        }
+                    ]
+                },
+                "tenant": {
+                    "description": "Usage of the namespace of the request today",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TenantUsage"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.CheckRequest": {
+            "type": "object",
+            "properties": {
+                "environment": {
+                    "description": "Environment substituted in the URL, defaults to the X-MCP-Environment header",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "Request sent after connecting, none by default",
+                    "type": "string",
+                    "enum": [
+                        "HEAD",
+                        "GET"
+                    ]
+                }
+            }
+        },
+        "api.ClientConfig": {
+            "type": "object",
+            "properties": {
+                "claudeDesktop": {
+                    "description": "claude_desktop_config.json, through the mcp-remote bridge",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "cursor": {
+                    "description": ".cursor/mcp.json",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "url": {
+                    "description": "Streamable HTTP endpoint of the server",
+                    "type": "string"
+                },
+                "vscode": {
+                    "description": "VS Code settings.json",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "api.CloneMCPServerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "defaultEnvironment": {
+                    "description": "Environment of the copy, defaults to the environment of the source server",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CreateChainedToolRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "steps"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "inputSchema": {
+                    "description": "JSON Schema of the params, made of the params the steps read if omitted",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string"
+                },
+                "output": {
+                    "description": "gjson path selecting the result from the params and the step results, the last step result by default",
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.ToolStep"
+                    }
+                }
+            }
+        },
+        "api.CreateMCPServerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "authPassthrough": {
+                    "description": "Whether the Authorization header of callers is forwarded, replaced or stripped",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuthPassthrough"
+                        }
+                    ]
+                },
+                "changelog": {
+                    "description": "Note of the first version, over the X-Change-Author and X-Change-Message headers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "collectionId": {
+                    "description": "Collection whose HTTP interfaces are added after those of httpIds",
+                    "type": "string"
+                },
+                "conflictResolution": {
+                    "type": "string",
+                    "enum": [
+                        "error",
+                        "first",
+                        "last"
+                    ]
+                },
+                "defaultEnvironment": {
+                    "description": "Environment used when the invocation selects none",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external": {
+                    "description": "MCP servers whose tools are listed and proxied after the tools of the HTTP interfaces",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalServer"
+                    }
+                },
+                "headers": {
+                    "description": "Default headers of the tools and the client headers forwarded upstream",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HeaderPolicy"
+                        }
+                    ]
+                },
+                "httpIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instructions": {
+                    "description": "Guidance for the LLMs using the tools, sent in the MCP initialize result",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network": {
+                    "description": "Client addresses allowed to invoke the tools",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NetworkRestriction"
+                        }
+                    ]
+                },
+                "plugins": {
+                    "description": "WASM file IDs applied to every tool",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "description": "Timeout, retries, rate limit and hosts of the tools, over the policy of the namespace",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    ]
+                },
+                "redactions": {
+                    "description": "Rules hiding sensitive data of the tool results, applied after the global rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RedactionRule"
+                    }
+                },
+                "sources": {
+                    "description": "MCP servers whose tools a virtual server includes, instead of interfaces and external servers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ServerSource"
+                    }
+                },
+                "strict": {
+                    "description": "Reject calls with params, headers or body fields the tools do not define",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.CreateWebSocketToolRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers of the handshake",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "inputSchema": {
+                    "description": "JSON Schema of the params, made of the placeholders of the URL and the message if omitted",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "description": "Message sent once connected with {param} placeholders, nothing is sent if empty",
+                    "type": "string"
+                },
+                "messages": {
+                    "description": "Replies collected, 1 by default, or up to 1000 with a terminator",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "terminator": {
+                    "description": "Collecting stops after a reply containing it",
+                    "type": "string"
+                },
+                "timeoutMs": {
+                    "description": "Time allowed for the whole exchange, 10000 by default",
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "ws:// or wss:// URL with {param} placeholders",
+                    "type": "string"
+                }
+            }
+        },
+        "api.CurlCommand": {
+            "type": "object",
+            "required": [
+                "command",
+                "name"
+            ],
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CurlImportResponse": {
+            "type": "object",
+            "required": [
+                "method",
+                "name",
+                "path"
+            ],
+            "properties": {
+                "archived": {
+                    "description": "Retired, hidden from listings and not called, see the archive endpoint",
+                    "type": "boolean"
+                },
+                "auth": {
+                    "description": "Authentication applied to the requests of its tools",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Auth"
+                        }
+                    ]
+                },
+                "changelog": {
+                    "description": "Note of the change creating the version",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deprecated": {
+                    "description": "Still called, but its tools warn callers to migrate",
+                    "type": "boolean"
+                },
+                "deprecationMessage": {
+                    "description": "Migration hint of a deprecated interface, e.g. its replacement, shown in the warnings of its tools",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Header"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "PATCH"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the interface, set from the request",
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Param"
+                    }
+                },
+                "path": {
+                    "type": "string"
+                },
+                "requestBody": {
+                    "$ref": "#/definitions/models.Body"
+                },
+                "responses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Response"
+                    }
+                },
+                "sunset": {
+                    "description": "Planned removal of a deprecated interface",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LintWarning"
+                    }
+                }
+            }
+        },
+        "api.DeprecatedToolUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "deprecationMessage": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "interfaceId": {
+                    "type": "string"
+                },
+                "lastCalledAt": {
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "serverName": {
+                    "type": "string"
+                },
+                "sunset": {
+                    "type": "string"
+                },
+                "tool": {
+                    "description": "Name clients call the tool by",
+                    "type": "string"
+                }
+            }
+        },
+        "api.DeprecatedToolsResponse": {
+            "type": "object",
+            "properties": {
+                "since": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.DeprecatedToolUsage"
+                    }
+                },
+                "until": {
+                    "type": "string"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.DescriptionSuggestion": {
+            "type": "object",
+            "required": [
+                "tool"
+            ],
+            "properties": {
+                "currentDescription": {
+                    "type": "string"
+                },
+                "currentParams": {
+                    "description": "Current param descriptions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why no description was generated",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Description by param name clients see",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "tool": {
+                    "description": "Name clients see",
+                    "type": "string"
+                }
+            }
+        },
+        "api.DescriptionSuggestions": {
+            "type": "object",
+            "properties": {
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.DescriptionSuggestion"
+                    }
+                }
+            }
+        },
+        "api.EnrichDescriptionsRequest": {
+            "type": "object",
+            "properties": {
+                "tools": {
+                    "description": "Names clients see, all tools if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "message": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/api.ErrorDetail"
+                }
+            }
+        },
+        "api.HAR": {
+            "type": "object",
+            "properties": {
+                "log": {
+                    "$ref": "#/definitions/api.HARLog"
+                }
+            }
+        },
+        "api.HARContent": {
+            "type": "object",
+            "properties": {
+                "mimeType": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARCreator": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HAREntry": {
+            "type": "object",
+            "properties": {
+                "_invocationId": {
+                    "type": "string"
+                },
+                "_requestId": {
+                    "type": "string"
+                },
+                "_tool": {
+                    "type": "string"
+                },
+                "cache": {
+                    "type": "object"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "request": {
+                    "$ref": "#/definitions/api.HARRequest"
+                },
+                "response": {
+                    "$ref": "#/definitions/api.HARResponse"
+                },
+                "startedDateTime": {
+                    "type": "string"
+                },
+                "time": {
+                    "description": "Milliseconds",
+                    "type": "integer"
+                },
+                "timings": {
+                    "$ref": "#/definitions/api.HARTimings"
+                }
+            }
+        },
+        "api.HARLog": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "$ref": "#/definitions/api.HARCreator"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HAREntry"
+                    }
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARNameValue": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARPostData": {
+            "type": "object",
+            "properties": {
+                "mimeType": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARRequest": {
+            "type": "object",
+            "properties": {
+                "bodySize": {
+                    "type": "integer"
+                },
+                "cookies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HARNameValue"
+                    }
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HARNameValue"
+                    }
+                },
+                "headersSize": {
+                    "type": "integer"
+                },
+                "httpVersion": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "postData": {
+                    "$ref": "#/definitions/api.HARPostData"
+                },
+                "queryString": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HARNameValue"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARResponse": {
+            "type": "object",
+            "properties": {
+                "bodySize": {
+                    "type": "integer"
+                },
+                "content": {
+                    "$ref": "#/definitions/api.HARContent"
+                },
+                "cookies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HARNameValue"
+                    }
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HARNameValue"
+                    }
+                },
+                "headersSize": {
+                    "type": "integer"
+                },
+                "httpVersion": {
+                    "type": "string"
+                },
+                "redirectURL": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "statusText": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.HARTimings": {
+            "type": "object",
+            "properties": {
+                "receive": {
+                    "type": "integer"
+                },
+                "send": {
+                    "type": "integer"
+                },
+                "wait": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
+                },
+                "conflicts": {
+                    "description": "Names of the interfaces to save that are already taken, failing the import with 409;\nreported by dry runs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "interfaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HTTPInterface"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/api.ImportSummary"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LintWarning"
+                    }
+                }
+            }
+        },
+        "api.ImportSummary": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unchanged": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.InterfaceExamples": {
+            "type": "object",
+            "properties": {
+                "curl": {
+                    "description": "curl command calling the upstream directly",
+                    "type": "string"
+                },
+                "httpie": {
+                    "description": "HTTPie command calling the upstream directly",
+                    "type": "string"
+                },
+                "mcpInvocation": {
+                    "description": "JSON-RPC tools/call request of the tool generated from the interface",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "api.InvocationListResponse": {
+            "type": "object",
+            "properties": {
+                "invocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Invocation"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.LintReport": {
+            "type": "object",
+            "properties": {
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LintWarning"
+                    }
+                }
+            }
+        },
+        "api.LogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "description": "debug, info, warn or error",
+                    "type": "string"
+                }
+            }
+        },
+        "api.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OpenAIFunction": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "description": "JSON Schema of the arguments",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "api.OpenAITool": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/api.OpenAIFunction"
+                },
+                "type": {
+                    "description": "Always \"function\"",
+                    "type": "string"
+                }
+            }
+        },
+        "api.OpenAPIImport": {
+            "type": "object",
+            "required": [
+                "spec"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "dryRun": {
+                    "description": "Only return the interfaces that would be saved",
+                    "type": "boolean"
+                },
+                "excludeMethods": {
+                    "description": "Operations of none of the methods",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "excludePathPrefixes": {
+                    "description": "Paths under none of the prefixes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "excludeTags": {
+                    "description": "Operations without any of the tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "methods": {
+                    "description": "Operations of one of the methods",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pathPrefixes": {
+                    "description": "Paths under one of the prefixes, e.g. /pets",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "spec": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "tags": {
+                    "description": "Operations with one of the tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.RateLimitState": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "Calls allowed in a burst",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Calls allowed right now",
+                    "type": "integer"
+                },
+                "resetSeconds": {
+                    "description": "Seconds until the limit is fully restored",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.RenderToolRequest": {
+            "type": "object",
+            "properties": {
+                "params": {
+                    "description": "Params of the call, as clients send them",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "response": {
+                    "description": "Upstream response body, a string for one that is not JSON"
+                }
+            }
+        },
+        "api.ReplayReport": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "invocationId": {
+                    "description": "Replayed invocation",
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                },
+                "originalError": {
+                    "type": "string"
+                },
+                "originalStatusCode": {
+                    "description": "0 if no upstream response was received",
+                    "type": "integer"
+                },
+                "originalSuccess": {
+                    "type": "boolean"
+                },
+                "params": {
+                    "description": "Params of the replay, with their credentials redacted",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "result": {},
+                "serverId": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "tool": {
+                    "type": "string"
+                },
+                "upstreamStatus": {
+                    "description": "0 if no upstream response was received",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ReplayRequest": {
+            "type": "object",
+            "properties": {
+                "params": {
+                    "description": "Params overriding the recorded ones, null removes a param; must supply the recorded credentials",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "replace": {
+                    "description": "Call the tool with params only, e.g. when the recorded ones were truncated",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.ReviewRevisionRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "E.g. the reason of a rejection",
+                    "type": "string"
+                }
+            }
+        },
+        "api.SeedRequest": {
+            "type": "object",
+            "required": [
+                "packs"
+            ],
+            "properties": {
+                "packs": {
+                    "description": "Names of the packs, see GET /api/seed",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "servers": {
+                    "description": "Gateway-wide statistics only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UsageStats"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UsageStats"
+                    }
+                },
+                "total": {
+                    "$ref": "#/definitions/models.UsageStats"
+                },
+                "until": {
+                    "type": "string"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ToolTestReport": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "description": "Duration of the whole call, scripts and plugins included",
+                    "type": "integer"
+                },
+                "request": {
+                    "description": "Upstream request, credentials redacted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcp.ResolvedRequest"
+                        }
+                    ]
+                },
+                "result": {},
+                "tool": {
+                    "type": "string"
+                },
+                "upstreamLatencyMs": {
+                    "type": "integer"
+                },
+                "upstreamStatus": {
+                    "description": "0 if no upstream response was received",
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Whether the params match the input schema of the tool",
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "description": "Schema violations and other problems found before the call",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.ToolVerification": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Example params the tool was called with",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "problems": {
+                    "description": "Differences between the response and its schema",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "ok, drifted, failed or skipped",
+                    "type": "string"
+                },
+                "tool": {
+                    "type": "string"
+                },
+                "upstreamStatus": {
+                    "description": "0 if no upstream response was received",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.UpdateMCPSessionRequest": {
+            "type": "object",
+            "properties": {
+                "cookieJar": {
+                    "description": "Empty to share no cookies between the calls",
+                    "type": "string"
+                },
+                "environment": {
+                    "description": "Empty to select no environment",
+                    "type": "string"
+                }
+            }
+        },
+        "api.UpdateToolRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Name exposed to MCP clients",
+                    "type": "string"
+                },
+                "authPassthrough": {
+                    "description": "Authorization passthrough policy overriding the one of the server",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuthPassthrough"
+                        }
+                    ]
+                },
+                "cost": {
+                    "description": "Cost of a call counted against API key quotas, 0 for the default of 1",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "description": {
+                    "description": "Description exposed instead of the generated one",
+                    "type": "string"
+                },
+                "errorMappings": {
+                    "description": "Errors returned for unsuccessful upstream statuses, an empty list removes them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ErrorMapping"
+                    }
+                },
+                "hedging": {
+                    "description": "Hedged requests of an idempotent GET tool",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Hedging"
+                        }
+                    ]
+                },
+                "latencyBudget": {
+                    "description": "Target latency of a call, a targetMs of 0 removes it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LatencyBudget"
+                        }
+                    ]
+                },
+                "paramDescriptions": {
+                    "description": "Param descriptions exposed by the names clients use",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "paramMapping": {
+                    "description": "Upstream name of a param by the name clients use",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "partialOnTimeout": {
+                    "description": "Return the body received so far when the upstream response is cut off at the timeout",
+                    "type": "boolean"
+                },
+                "policy": {
+                    "description": "Timeout, retries, rate limit and hosts of the calls, an empty policy removes it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    ]
+                },
+                "projection": {
+                    "description": "Fields of the result returned to clients",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Projection"
+                        }
+                    ]
+                },
+                "shadow": {
+                    "description": "Tool also called in the background on a sample of the calls, a percent of 0 removes it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Shadow"
+                        }
+                    ]
+                },
+                "staticParams": {
+                    "description": "Params always sent upstream and hidden from clients",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "api.ValidateNameRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "excludeId": {
+                    "description": "Optional, used when updating",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateNameResponse": {
+            "type": "object",
+            "properties": {
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.VerificationReport": {
+            "type": "object",
+            "properties": {
+                "drifted": {
+                    "description": "Tools whose response no longer matches their schema",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ToolVerification"
+                    }
+                },
+                "verifiedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.VerifyRequest": {
+            "type": "object",
+            "properties": {
+                "includeUnsafe": {
+                    "description": "Also call the tools whose method is not GET, which may change data",
+                    "type": "boolean"
+                },
+                "tools": {
+                    "description": "Tools to verify, all the allowed tools by default",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "backup.Object": {
+            "type": "object",
+            "properties": {
+                "modified": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backup.Status": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the last backup failed",
+                    "type": "string"
+                },
+                "lastBackup": {
+                    "description": "Name of the last successful backup",
+                    "type": "string"
+                },
+                "lastRun": {
+                    "description": "Start of the last backup",
+                    "type": "string"
+                }
+            }
+        },
+        "config.AdminConfig": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Bearer token required by POST /api/admin/reload",
+                    "type": "string"
+                }
+            }
+        },
+        "config.ApprovalConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Changes of active servers create pending revisions",
+                    "type": "boolean"
+                },
+                "token": {
+                    "description": "Bearer token of the approvers, the admin token if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "config.BackupConfig": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "description": "Directory, file:///path, s3://bucket/prefix or gs://bucket/prefix",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "intervalMinutes": {
+                    "description": "Time between backups",
+                    "type": "integer"
+                },
+                "keep": {
+                    "description": "Newest backups kept, 0 keeps all",
+                    "type": "integer"
+                },
+                "maxAgeDays": {
+                    "description": "Backups older are deleted, 0 keeps them",
+                    "type": "integer"
+                },
+                "s3": {
+                    "$ref": "#/definitions/config.BackupS3Config"
+                }
+            }
+        },
+        "config.BackupS3Config": {
+            "type": "object",
+            "properties": {
+                "accessKeyId": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "Base URL of an S3 compatible service, AWS or Google Cloud Storage by default",
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "secretAccessKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.CORSConfig": {
+            "type": "object",
+            "properties": {
+                "allowOrigins": {
+                    "description": "\"*\" allows any origin",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "config.CompressionConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Negotiated with the Accept-Encoding request header",
+                    "type": "boolean"
+                },
+                "minSize": {
+                    "description": "Smallest response body in bytes that is compressed",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.Config": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/config.AdminConfig"
+                },
+                "approval": {
+                    "$ref": "#/definitions/config.ApprovalConfig"
+                },
+                "backup": {
+                    "$ref": "#/definitions/config.BackupConfig"
+                },
+                "cors": {
+                    "$ref": "#/definitions/config.CORSConfig"
+                },
+                "database": {
+                    "$ref": "#/definitions/config.DatabaseConfig"
+                },
+                "errorReporting": {
+                    "$ref": "#/definitions/config.ErrorReportingConfig"
+                },
+                "gitops": {
+                    "$ref": "#/definitions/config.GitOpsConfig"
+                },
+                "graphql": {
+                    "$ref": "#/definitions/config.GraphQLConfig"
+                },
+                "grpc": {
+                    "$ref": "#/definitions/config.GRPCConfig"
+                },
+                "llm": {
+                    "$ref": "#/definitions/config.LLMConfig"
+                },
+                "log": {
+                    "$ref": "#/definitions/config.LogConfig"
+                },
+                "network": {
+                    "$ref": "#/definitions/config.NetworkConfig"
+                },
+                "rateLimit": {
+                    "$ref": "#/definitions/config.RateLimitConfig"
+                },
+                "redaction": {
+                    "$ref": "#/definitions/config.RedactionConfig"
+                },
+                "renames": {
+                    "$ref": "#/definitions/config.RenamesConfig"
+                },
+                "seed": {
+                    "$ref": "#/definitions/config.SeedConfig"
+                },
+                "server": {
+                    "$ref": "#/definitions/config.ServerConfig"
+                },
+                "sessions": {
+                    "$ref": "#/definitions/config.SessionsConfig"
+                },
+                "upstream": {
+                    "$ref": "#/definitions/config.UpstreamConfig"
+                }
+            }
+        },
+        "config.DatabaseConfig": {
+            "type": "object",
+            "properties": {
+                "connMaxLifetimeSec": {
+                    "description": "Age after which connections are closed, 0 to reuse them forever",
+                    "type": "integer"
+                },
+                "connectBackoffMs": {
+                    "description": "Delay before the first retry, doubled after each attempt up to 30s",
+                    "type": "integer"
+                },
+                "connectRetries": {
+                    "description": "Attempts after a failed connection at startup, 0 to fail at once",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "Use in-memory repositories when false",
+                    "type": "boolean"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "maxIdleConns": {
+                    "description": "Idle connections kept open",
+                    "type": "integer"
+                },
+                "maxOpenConns": {
+                    "description": "Connections open at once, 0 for unlimited",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "slowQueryMs": {
+                    "description": "Queries taking longer are logged, 0 disables the log",
+                    "type": "integer"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.ErrorReportingConfig": {
+            "type": "object",
+            "properties": {
+                "dsn": {
+                    "description": "Sentry DSN, or URL receiving the errors as JSON; empty disables reporting",
+                    "type": "string"
+                },
+                "environment": {
+                    "description": "Environment the errors are tagged with, e.g. production",
+                    "type": "string"
+                }
+            }
+        },
+        "config.GRPCConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "port": {
+                    "type": "integer"
+                }
+            }
+        },
+        "config.GitOpsConfig": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Branch to check out",
+                    "type": "string"
+                },
+                "dir": {
+                    "description": "Local checkout",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "intervalSeconds": {
+                    "description": "Time between syncs",
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "Directory of the files within the repository",
+                    "type": "string"
+                },
+                "prune": {
+                    "description": "Delete resources of the listed kinds missing from the repository",
+                    "type": "boolean"
+                },
+                "repository": {
+                    "description": "URL passed to git clone",
+                    "type": "string"
+                }
+            }
+        },
+        "config.GraphQLConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "maxDepth": {
+                    "description": "Deepest nesting of fields accepted in a query",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.LLMConfig": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "description": "API key of the provider",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Model name",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "openai or anthropic, empty disables the integration",
+                    "type": "string"
+                },
+                "timeoutSeconds": {
+                    "description": "Timeout of a completion",
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "Base URL of an OpenAI or Anthropic compatible API, the public API by default",
+                    "type": "string"
+                }
+            }
+        },
+        "config.LogConfig": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "text or json",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "debug, info, warn or error",
+                    "type": "string"
+                }
+            }
+        },
+        "config.NetworkConfig": {
+            "type": "object",
+            "properties": {
+                "trustedProxies": {
+                    "description": "Proxies whose X-Forwarded-For gives the client address, none if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "zones": {
+                    "description": "CIDR ranges or IP addresses by zone name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "config.RateLimitConfig": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "description": "Defaults to the rate rounded up",
+                    "type": "integer"
+                },
+                "requestsPerSecond": {
+                    "description": "0 disables rate limiting",
+                    "type": "number"
+                }
+            }
+        },
+        "config.RedactionConfig": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "description": "Applied before the rules of the server",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RedactionRule"
+                    }
+                }
+            }
+        },
+        "config.RenamesConfig": {
+            "type": "object",
+            "properties": {
+                "aliasDays": {
+                    "description": "Former names redirect to the new one for this long, 0 keeps no alias",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.SeedConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Set to false in production to refuse seeding entirely",
+                    "type": "boolean"
+                }
+            }
+        },
+        "config.ServerConfig": {
+            "type": "object",
+            "properties": {
+                "compression": {
+                    "$ref": "#/definitions/config.CompressionConfig"
+                },
+                "configDir": {
+                    "description": "Generated MCP server YAML files",
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "wasmDir": {
+                    "description": "Uploaded WASM modules",
+                    "type": "string"
+                }
+            }
+        },
+        "config.SessionsConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Issue a session ID at initialize, otherwise the transport is stateless",
+                    "type": "boolean"
+                },
+                "idleTimeoutMinutes": {
+                    "description": "Sessions unused for this long expire",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.UpstreamConfig": {
+            "type": "object",
+            "properties": {
+                "allowStdio": {
+                    "description": "Allow external MCP servers that run a command on the gateway host",
+                    "type": "boolean"
+                },
+                "allowedHosts": {
+                    "description": "\"*.example.com\" matches subdomains, empty allows all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "protocols": {
+                    "description": "HTTP protocol by host name: http1, h2 or h2c; the others negotiate HTTP/2 over TLS",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "revalidationEntries": {
+                    "description": "GET responses kept for conditional requests, 0 disables them",
+                    "type": "integer"
+                }
+            }
+        },
+        "gitops.Bundle": {
+            "type": "object",
+            "properties": {
+                "interfaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HTTPInterface"
+                    }
+                },
+                "routers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Router"
+                    }
+                },
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitops.ServerSpec"
+                    }
+                }
+            }
+        },
+        "gitops.Change": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create, update or delete",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Set if the change failed or cannot be made",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "http_interface, mcp_server or router",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the resource",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace of the resource",
+                    "type": "string"
+                }
+            }
+        },
+        "gitops.Drift": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes the next sync would apply",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitops.Change"
+                    }
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "inSync": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitops.ServerSpec": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allowTools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "authPassthrough": {
+                    "description": "What reaches the upstreams of the Authorization header of callers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuthPassthrough"
+                        }
+                    ]
+                },
+                "changelog": {
+                    "description": "Note of the change creating the version",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "conflictResolution": {
+                    "description": "error (default), first or last",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "defaultEnvironment": {
+                    "description": "Environment used when the request selects none",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external": {
+                    "description": "MCP servers whose tools are proxied",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalServer"
+                    }
+                },
+                "headers": {
+                    "description": "Default headers and the client headers forwarded upstream",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HeaderPolicy"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "description": "Guidance for the LLMs using the tools, e.g. when to use them, auth notes and rate limits,\nsent in the MCP initialize result",
+                    "type": "string"
+                },
+                "interfaces": {
+                    "description": "Names of interfaces to generate tools from",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the server, set from the request",
+                    "type": "string"
+                },
+                "network": {
+                    "description": "Client addresses allowed to invoke the tools",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NetworkRestriction"
+                        }
+                    ]
+                },
+                "plugins": {
+                    "description": "WASM file IDs applied to every tool",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "description": "Timeout, retries, rate limit and hosts of the tools, over those of the namespace",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    ]
+                },
+                "redactions": {
+                    "description": "Applied to tool results after the global rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RedactionRule"
+                    }
+                },
+                "schedule": {
+                    "description": "Scheduled activations and deactivations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ActivationSchedule"
+                        }
+                    ]
+                },
+                "sources": {
+                    "description": "Servers of the gateway whose tools a virtual server includes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ServerSource"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "active",
+                        "inactive",
+                        "archived"
+                    ]
+                },
+                "strict": {
+                    "description": "Reject calls with params, headers or body fields the tool does not define",
+                    "type": "boolean"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tool"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gitops.Status": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changes applied by the last sync",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitops.Change"
+                    }
+                },
+                "commit": {
+                    "description": "Commit of the last pulled revision",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the last sync failed",
+                    "type": "string"
+                },
+                "lastSync": {
+                    "description": "Start of the last sync",
+                    "type": "string"
+                },
+                "repository": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcp.CheckStep": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mcp.ConnectivityReport": {
+            "type": "object",
+            "properties": {
+                "reachable": {
+                    "type": "boolean"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mcp.CheckStep"
+                    }
+                },
+                "url": {
+                    "description": "URL probed, after environment and upstream resolution",
+                    "type": "string"
+                }
+            }
+        },
+        "mcp.ResolvedRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "mcp.SyncResult": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Tools new on an external server",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing": {
+                    "description": "Tools whose interface or external tool no longer exists, or removed from a virtual server",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revision": {
+                    "description": "Pending revision holding the synced tools of an active server while changes require approval,\nthe server is unchanged until it is approved",
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "unreachable": {
+                    "description": "External servers that could not be reached, their tools are left unchanged",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "description": "Tools regenerated from a newer interface version or changed on their external server",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mcp.ToolRendering": {
+            "type": "object",
+            "properties": {
+                "request": {
+                    "description": "Upstream request, credentials redacted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/mcp.ResolvedRequest"
+                        }
+                    ]
+                },
+                "response": {
+                    "description": "Result rendered from the sample response, nil without one",
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Returned on creation only",
+                    "type": "string"
+                },
+                "maxCostPerDay": {
+                    "description": "Cost of the tool calls per UTC day, 0 for unlimited",
+                    "type": "number"
+                },
+                "maxCostPerMonth": {
+                    "description": "Cost of the tool calls per UTC month, 0 for unlimited",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Start of the key, to recognize it",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKeyPeriodUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "period": {
+                    "description": "YYYY-MM-DD or YYYY-MM",
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKeyUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "description": "Tool calls of the month",
+                    "type": "integer"
+                },
+                "cost": {
+                    "description": "Cost of the tool calls of the month",
+                    "type": "number"
+                },
+                "days": {
+                    "description": "Usage of the days with calls, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKeyPeriodUsage"
+                    }
+                },
+                "keyId": {
+                    "type": "string"
+                },
+                "maxCostPerDay": {
+                    "type": "number"
+                },
+                "maxCostPerMonth": {
+                    "type": "number"
+                },
+                "month": {
+                    "description": "YYYY-MM",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "remainingDay": {
+                    "description": "Cost left today, unset if unlimited or not the current month",
+                    "type": "number"
+                },
+                "remainingMonth": {
+                    "description": "Cost left this month, unset if unlimited or not the current month",
+                    "type": "number"
+                }
+            }
+        },
+        "models.ActivationSchedule": {
+            "type": "object",
+            "properties": {
+                "activateAt": {
+                    "description": "One-off activation",
+                    "type": "string"
+                },
+                "activateCron": {
+                    "description": "Cron expression of recurring activations, e.g. \"0 8 * * mon-fri\"",
+                    "type": "string"
+                },
+                "deactivateAt": {
+                    "description": "One-off deactivation, after activateAt if both are set",
+                    "type": "string"
+                },
+                "deactivateCron": {
+                    "description": "Cron expression of recurring deactivations",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone of the cron expressions, UTC by default",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AlertWebhook": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "cooldownSeconds": {
+                    "description": "Minimum time between two notifications for the same alert",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "errorRateThreshold": {
+                    "description": "Tool error rate (0-1) that fires an alert; 0 disables error rate alerts",
+                    "type": "number"
+                },
+                "format": {
+                    "description": "\"json\" (default) or \"slack\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "minCalls": {
+                    "description": "Minimum calls in the window before the error rate is considered",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "upstreamHealth": {
+                    "description": "Notify when upstream targets become unhealthy or recover",
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "windowSeconds": {
+                    "description": "Window over which the error rate is computed",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Auth": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "clientId": {
+                    "description": "oauth2",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Header or query parameter carrying the API key",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "oauth2",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret holding the password, token, API key or OAuth2 client secret",
+                    "type": "string"
+                },
+                "tokenUrl": {
+                    "description": "oauth2 token endpoint",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "basic",
+                        "bearer",
+                        "api-key-header",
+                        "api-key-query",
+                        "oauth2"
+                    ]
+                },
+                "username": {
+                    "description": "basic",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuthPassthrough": {
+            "type": "object",
+            "properties": {
+                "credential": {
+                    "description": "Auth profile sent instead of the header of the caller with replace",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Auth"
+                        }
+                    ]
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "forward",
+                        "replace",
+                        "strip"
+                    ]
+                }
+            }
+        },
+        "models.Body": {
+            "type": "object",
+            "required": [
+                "contentType",
+                "schema"
+            ],
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "example": {
+                    "type": "string"
+                },
+                "schema": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CallPolicy": {
+            "type": "object",
+            "properties": {
+                "allowedHosts": {
+                    "description": "Upstream hosts the calls may reach, \"*.example.com\" matching subdomains. Lists narrow the\ninherited ones instead of replacing them: a host must be allowed at every level setting one.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rateLimit": {
+                    "description": "Calls of each caller, counted at the level setting the limit",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallRateLimit"
+                        }
+                    ]
+                },
+                "retry": {
+                    "description": "Retries of the calls whose upstream is unavailable",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RetryPolicy"
+                        }
+                    ]
+                },
+                "timeoutMs": {
+                    "description": "Time allowed for a call, retries included",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CallRateLimit": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "description": "Defaults to the rate rounded up",
+                    "type": "integer"
+                },
+                "requestsPerSecond": {
+                    "description": "0 lifts an inherited limit",
+                    "type": "number"
+                }
+            }
+        },
+        "models.ChangeNote": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Who made the change, free-form",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "What changed and why",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interfaceIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the collection, set from the request",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Condition": {
+            "type": "object",
+            "required": [
+                "name",
+                "operator",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name of the header, query param, or path param",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "Operator for comparison",
+                    "type": "string",
+                    "enum": [
+                        "eq",
+                        "neq",
+                        "contains",
+                        "regex"
+                    ]
+                },
+                "type": {
+                    "description": "Type of condition",
+                    "type": "string",
+                    "enum": [
+                        "header",
+                        "query",
+                        "path",
+                        "method"
+                    ]
+                },
+                "value": {
+                    "description": "Value to compare against",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Environment": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "e.g. dev, staging, prod",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ErrorMapping": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category of the error, upstream if not set",
+                    "type": "string"
+                },
+                "includeBody": {
+                    "description": "Append the response body to the message",
+                    "type": "boolean"
+                },
+                "message": {
+                    "description": "Message returned to the client, e.g. \"credentials expired, ask the user to re-authenticate\"",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status code such as 401, or class such as 4xx",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Entity after the change, absent for deletions"
+                },
+                "entityId": {
+                    "type": "string"
+                },
+                "entityName": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique per event, identical across the retries of a delivery",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "Request that caused the change",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "One of EventTypes, or EventToolInvoked",
+                    "type": "string"
+                }
+            }
+        },
+        "models.EventWebhook": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "Subscribed event types, all events if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "HMAC-SHA256 signing key, never returned by the API",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExternalServer": {
+            "type": "object",
+            "required": [
+                "name",
+                "transport"
+            ],
+            "properties": {
+                "args": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "auth": {
+                    "description": "Authentication of the HTTP requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Auth"
+                        }
+                    ]
+                },
+                "command": {
+                    "description": "Program started by the stdio transport",
+                    "type": "string"
+                },
+                "env": {
+                    "description": "Added to the environment of the program",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "headers": {
+                    "description": "Sent with every HTTP request",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prepended to the names of its tools, e.g. \"github_\"",
+                    "type": "string"
+                },
+                "transport": {
+                    "type": "string",
+                    "enum": [
+                        "streamable-http",
+                        "sse",
+                        "stdio"
+                    ]
+                },
+                "url": {
+                    "description": "Endpoint of the streamable-http and sse transports",
+                    "type": "string"
+                }
+            }
+        },
+        "models.HTTPInterface": {
+            "type": "object",
+            "required": [
+                "method",
+                "name",
+                "path"
+            ],
+            "properties": {
+                "archived": {
+                    "description": "Retired, hidden from listings and not called, see the archive endpoint",
+                    "type": "boolean"
+                },
+                "auth": {
+                    "description": "Authentication applied to the requests of its tools",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Auth"
+                        }
+                    ]
+                },
+                "changelog": {
+                    "description": "Note of the change creating the version",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deprecated": {
+                    "description": "Still called, but its tools warn callers to migrate",
+                    "type": "boolean"
+                },
+                "deprecationMessage": {
+                    "description": "Migration hint of a deprecated interface, e.g. its replacement, shown in the warnings of its tools",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Header"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "PATCH"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the interface, set from the request",
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Param"
+                    }
+                },
+                "path": {
+                    "type": "string"
+                },
+                "requestBody": {
+                    "$ref": "#/definitions/models.Body"
+                },
+                "responses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Response"
+                    }
+                },
+                "sunset": {
+                    "description": "Planned removal of a deprecated interface",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Header": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "defaultValue": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "integer",
+                        "number",
+                        "boolean",
+                        "array",
+                        "object"
+                    ]
+                }
+            }
+        },
+        "models.HeaderPolicy": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "description": "Client headers forwarded upstream, \"X-Trace-*\" matches a prefix, all if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "defaults": {
+                    "description": "Sent by every tool, the headers of the tool template take precedence",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reject": {
+                    "description": "Fail the calls passing other headers instead of dropping them",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.HeaderRules": {
+            "type": "object",
+            "properties": {
+                "add": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "remove": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "set": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "expectedStatus": {
+                    "description": "Status code considered healthy",
+                    "type": "integer"
+                },
+                "healthyThreshold": {
+                    "description": "Consecutive successes before a target is marked healthy again",
+                    "type": "integer"
+                },
+                "intervalSeconds": {
+                    "description": "Time between two probes",
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "Path probed on every target; empty disables active checks",
+                    "type": "string"
+                },
+                "timeoutSeconds": {
+                    "description": "Timeout of a single probe",
+                    "type": "integer"
+                },
+                "unhealthyThreshold": {
+                    "description": "Consecutive failures before a target is marked unhealthy",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Hedging": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "initialDelayMs": {
+                    "description": "Delay used until enough latencies were observed, 100 by default",
+                    "type": "integer"
+                },
+                "percentile": {
+                    "description": "Latency percentile after which the second request is sent, 99 by default",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Invocation": {
+            "type": "object",
+            "properties": {
+                "caller": {
+                    "description": "Identity of the client that invoked the tool",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request": {
+                    "description": "Tool parameters, truncated",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "X-Request-ID of the gateway request",
+                    "type": "string"
+                },
+                "response": {
+                    "description": "Tool result, truncated",
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "serverName": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "Upstream status code, 0 if no response was received",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "tool": {
+                    "type": "string"
+                },
+                "upstream": {
+                    "description": "HTTP exchange of the call with its upstream, absent for the tools that send no HTTP request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.UpstreamExchange"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.LatencyBudget": {
+            "type": "object",
+            "properties": {
+                "enforce": {
+                    "description": "Cancel calls at the target instead of only counting them",
+                    "type": "boolean"
+                },
+                "targetMs": {
+                    "description": "Target latency of a call, 0 removes the budget",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LintWarning": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Part of the definition, e.g. parameters.city, empty for the interface",
+                    "type": "string"
+                },
+                "interface": {
+                    "description": "Name of the interface",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MCPServer": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allowTools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "authPassthrough": {
+                    "description": "What reaches the upstreams of the Authorization header of callers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuthPassthrough"
+                        }
+                    ]
+                },
+                "changelog": {
+                    "description": "Note of the change creating the version",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "conflictResolution": {
+                    "description": "error (default), first or last",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "defaultEnvironment": {
+                    "description": "Environment used when the request selects none",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external": {
+                    "description": "MCP servers whose tools are proxied",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExternalServer"
+                    }
+                },
+                "headers": {
+                    "description": "Default headers and the client headers forwarded upstream",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HeaderPolicy"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "description": "Guidance for the LLMs using the tools, e.g. when to use them, auth notes and rate limits,\nsent in the MCP initialize result",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the server, set from the request",
+                    "type": "string"
+                },
+                "network": {
+                    "description": "Client addresses allowed to invoke the tools",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NetworkRestriction"
+                        }
+                    ]
+                },
+                "plugins": {
+                    "description": "WASM file IDs applied to every tool",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "description": "Timeout, retries, rate limit and hosts of the tools, over those of the namespace",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    ]
+                },
+                "redactions": {
+                    "description": "Applied to tool results after the global rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RedactionRule"
+                    }
+                },
+                "schedule": {
+                    "description": "Scheduled activations and deactivations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ActivationSchedule"
+                        }
+                    ]
+                },
+                "sources": {
+                    "description": "Servers of the gateway whose tools a virtual server includes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ServerSource"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "active",
+                        "inactive",
+                        "archived"
+                    ]
+                },
+                "strict": {
+                    "description": "Reject calls with params, headers or body fields the tool does not define",
+                    "type": "boolean"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tool"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MCPSession": {
+            "type": "object",
+            "properties": {
+                "caller": {
+                    "description": "Client address at initialize",
+                    "type": "string"
+                },
+                "clientName": {
+                    "type": "string"
+                },
+                "clientVersion": {
+                    "type": "string"
+                },
+                "cookieJar": {
+                    "description": "Cookie jar of the tool calls without X-MCP-Cookie-Jar",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "environment": {
+                    "description": "Environment of the tool calls without X-MCP-Environment",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "Extended by every request of the session",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "protocolVersion": {
+                    "description": "Revision negotiated at initialize",
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "serverName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NamespacePolicy": {
+            "type": "object",
+            "properties": {
+                "namespace": {
+                    "type": "string"
+                },
+                "policy": {
+                    "$ref": "#/definitions/models.CallPolicy"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NetworkRestriction": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "description": "CIDR ranges, IP addresses or zone names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Param": {
+            "type": "object",
+            "required": [
+                "in",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "explode": {
+                    "description": "Repeat the key of each item of a form param, true by default",
+                    "type": "boolean"
+                },
+                "in": {
+                    "type": "string",
+                    "enum": [
+                        "query",
+                        "path",
+                        "header",
+                        "cookie"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "schema": {
+                    "type": "string"
+                },
+                "style": {
+                    "description": "Serialization of an array param as in OpenAPI: form (default), spaceDelimited or\npipeDelimited for query params, simple for path params",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "integer",
+                        "number",
+                        "boolean",
+                        "array",
+                        "object"
+                    ]
+                }
+            }
+        },
+        "models.ParamStyle": {
+            "type": "object",
+            "properties": {
+                "explode": {
+                    "type": "boolean"
+                },
+                "in": {
+                    "description": "query or path",
+                    "type": "string"
+                },
+                "style": {
+                    "description": "form, spaceDelimited, pipeDelimited or simple",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Projection": {
+            "type": "object",
+            "properties": {
+                "exclude": {
+                    "description": "Paths of the fields removed from the kept ones",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "include": {
+                    "description": "Paths of the fields kept, every field if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "selectable": {
+                    "description": "Callers may replace the include paths with the _fields param",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Quota": {
+            "type": "object",
+            "properties": {
+                "maxInterfaces": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxServers": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxToolCallsPerDay": {
+                    "description": "Tool calls per UTC day",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RedactionRule": {
+            "type": "object",
+            "properties": {
+                "builtin": {
+                    "description": "email or creditCard",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Dot separated field names, \"*\" matches any field or element, e.g. \"user.email\" or \"items.*.ssn\".\nArrays are traversed by the names of the fields of their elements.",
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "Regular expression of the text to replace",
+                    "type": "string"
+                },
+                "replacement": {
+                    "description": "Defaults to [REDACTED]",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RequestTemplate": {
+            "type": "object",
+            "required": [
+                "method",
+                "url"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "description": "Encoding of the body, taken from the interface, JSON if not set",
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "PATCH"
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Response": {
+            "type": "object",
+            "required": [
+                "statusCode"
+            ],
+            "properties": {
+                "body": {
+                    "$ref": "#/definitions/models.Body"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResponseTemplate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RetryPolicy": {
+            "type": "object",
+            "properties": {
+                "backoffMs": {
+                    "description": "Delay before the first retry, doubled at each retry, 100 by default",
+                    "type": "integer"
+                },
+                "maxAttempts": {
+                    "description": "Attempts of a call, the first included; 1 disables retries",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Revision": {
+            "type": "object",
+            "properties": {
+                "baseVersion": {
+                    "description": "Version of the server the change was made against",
+                    "type": "integer"
+                },
+                "change": {
+                    "description": "Operation that submitted the revision, e.g. \"update\" or \"tool weather\"",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Given by the approver, e.g. the reason of a rejection",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "server": {
+                    "description": "Proposed definition",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MCPServer"
+                        }
+                    ]
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "serverName": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, approved or rejected",
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version of the server created by the approval",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Rewrite": {
+            "type": "object",
+            "properties": {
+                "pathRegex": {
+                    "description": "Regular expression matched against the (stripped) path",
+                    "type": "string"
+                },
+                "pathReplacement": {
+                    "description": "Replacement for PathRegex, may reference groups like $1",
+                    "type": "string"
+                },
+                "requestHeaders": {
+                    "description": "Header changes applied to the forwarded request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HeaderRules"
+                        }
+                    ]
+                },
+                "responseHeaders": {
+                    "description": "Header changes applied to the returned response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HeaderRules"
+                        }
+                    ]
+                },
+                "stripPrefix": {
+                    "description": "Prefix removed from the request path",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Router": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Team owning the router, set from the request",
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Rule"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "inactive"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Rule": {
+            "type": "object",
+            "required": [
+                "path",
+                "targetId",
+                "targetType"
+            ],
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Condition"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path pattern (e.g., /mcp-server/{name})",
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "Higher priority rules are evaluated first",
+                    "type": "integer"
+                },
+                "rewrite": {
+                    "description": "Actions applied before the request is forwarded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Rewrite"
+                        }
+                    ]
+                },
+                "targetId": {
+                    "description": "ID of the MCP Server, or upstream name / base URL of the HTTP backend",
+                    "type": "string"
+                },
+                "targetType": {
+                    "type": "string",
+                    "enum": [
+                        "mcp-server",
+                        "http-backend"
+                    ]
+                }
+            }
+        },
+        "models.ScheduleStatus": {
+            "type": "object",
+            "properties": {
+                "nextActivation": {
+                    "type": "string"
+                },
+                "nextDeactivation": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/models.ActivationSchedule"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Secret": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rotatedAt": {
+                    "description": "Last successful rotation, set by the gateway",
+                    "type": "string"
+                },
+                "rotation": {
+                    "description": "Scheduled replacements of the value",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SecretRotation"
+                        }
+                    ]
+                },
+                "rotationError": {
+                    "description": "Error of the last rotation if it failed, set by the gateway",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SecretReference": {
+            "type": "object",
+            "properties": {
+                "interfaceId": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "http-interface, tool, external-server or auth-passthrough",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the interface, server, tool or external server",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SecretRotation": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "One-off rotation",
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "Cron expression of recurring rotations, e.g. \"0 3 1 * *\"",
+                    "type": "string"
+                },
+                "staged": {
+                    "description": "Whether a value is staged, set by the API",
+                    "type": "boolean"
+                },
+                "stagedValue": {
+                    "description": "Value swapped in at the next rotation, never returned by the API",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone of the cron expression, UTC by default",
+                    "type": "string"
+                },
+                "webhookUrl": {
+                    "description": "Called with a POST at each rotation, may answer {\"value\": \"...\"}",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SecretRotationStatus": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "nextRotation": {
+                    "type": "string"
+                },
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SecretReference"
+                    }
+                },
+                "rotatedAt": {
+                    "type": "string"
+                },
+                "rotation": {
+                    "$ref": "#/definitions/models.SecretRotation"
+                },
+                "rotationError": {
+                    "type": "string"
+                },
+                "secretId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ServerAlias": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time of the rename",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Former name of the server",
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ServerSource": {
+            "type": "object",
+            "required": [
+                "serverId"
+            ],
+            "properties": {
+                "prefix": {
+                    "description": "Prepended to the names of its tools, e.g. \"billing_\"",
+                    "type": "string"
+                },
+                "serverId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Shadow": {
+            "type": "object",
+            "properties": {
+                "percent": {
+                    "description": "Share of the calls also sent to the shadow tool, 0 to 100",
+                    "type": "number"
+                },
+                "tool": {
+                    "description": "Name of the tool of the server called in the background",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenantUsage": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "UTC day of the tool calls, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "interfaces": {
+                    "type": "integer"
+                },
+                "quota": {
+                    "description": "Limits of the tenant, all zero if it has no quota",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Quota"
+                        }
+                    ]
+                },
+                "servers": {
+                    "type": "integer"
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "toolCalls": {
+                    "description": "Tool calls counted on Day",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Tool": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "alias": {
+                    "description": "Name exposed to MCP clients instead of the name",
+                    "type": "string"
+                },
+                "auth": {
+                    "description": "Authentication profile of the interface",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Auth"
+                        }
+                    ]
+                },
+                "authPassthrough": {
+                    "description": "Overrides the Authorization passthrough policy of the server",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuthPassthrough"
+                        }
+                    ]
+                },
+                "cost": {
+                    "description": "Cost of a call counted against API key quotas, 1 if not set",
+                    "type": "number"
+                },
+                "deprecated": {
+                    "description": "Deprecation of the tool, taken from its interface: deprecated tools are still called, with a warning",
+                    "type": "boolean"
+                },
+                "deprecationMessage": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "descriptionOverride": {
+                    "description": "Description exposed instead of the generated one",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Switched off by an operator: a disabled tool stays in allowTools but is not listed and its calls\nare rejected. Enabled if not set.",
+                    "type": "boolean"
+                },
+                "errorMappings": {
+                    "description": "Errors returned for unsuccessful upstream statuses instead of the raw body",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ErrorMapping"
+                    }
+                },
+                "external": {
+                    "description": "External server the tool is proxied to",
+                    "type": "string"
+                },
+                "hedging": {
+                    "description": "Second request sent when an idempotent GET call is slow",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Hedging"
+                        }
+                    ]
+                },
+                "inputSchema": {
+                    "description": "JSON Schema of the tool arguments, generated from the parameters, headers and body of the interface",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "interfaceId": {
+                    "description": "HTTP interface the tool was generated from",
+                    "type": "string"
+                },
+                "interfaceVersion": {
+                    "description": "Version of the interface at generation",
+                    "type": "integer"
+                },
+                "latencyBudget": {
+                    "description": "Target latency of a call",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LatencyBudget"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "output": {
+                    "description": "gjson path selecting the result of a chained tool from its params and step results, the result of the last step by default",
+                    "type": "string"
+                },
+                "outputSchema": {
+                    "description": "JSON Schema of the tool result, the body schema of the successful response of the interface",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "paramDescriptions": {
+                    "description": "Descriptions exposed for params by the names clients use",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "paramMapping": {
+                    "description": "Upstream name of a param by the name clients use",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "paramStyles": {
+                    "description": "Serialization of the array params of the interface by upstream name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ParamStyle"
+                    }
+                },
+                "partialOnTimeout": {
+                    "description": "Return the body received so far when the upstream response is cut off at the timeout",
+                    "type": "boolean"
+                },
+                "plugins": {
+                    "description": "WASM file IDs applied after the server plugins",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "description": "Timeout, retries, rate limit and hosts of the calls, over those of the server",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CallPolicy"
+                        }
+                    ]
+                },
+                "postScript": {
+                    "description": "CEL expression reshaping the response",
+                    "type": "string"
+                },
+                "preScript": {
+                    "description": "CEL expression adjusting params and headers",
+                    "type": "string"
+                },
+                "projection": {
+                    "description": "Fields of the result returned to clients",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Projection"
+                        }
+                    ]
+                },
+                "remoteName": {
+                    "description": "Name of the tool on the external or source server",
+                    "type": "string"
+                },
+                "requestTemplate": {
+                    "$ref": "#/definitions/models.RequestTemplate"
+                },
+                "responseTemplate": {
+                    "$ref": "#/definitions/models.ResponseTemplate"
+                },
+                "shadow": {
+                    "description": "Tool also called in the background on a sample of the calls, to compare results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Shadow"
+                        }
+                    ]
+                },
+                "source": {
+                    "description": "MCP server the tool of a virtual server forwards to",
+                    "type": "string"
+                },
+                "staticParams": {
+                    "description": "Params always sent upstream, over those of the caller",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "steps": {
+                    "description": "Tools called in order by a chained tool",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ToolStep"
+                    }
+                },
+                "sunset": {
+                    "type": "string"
+                },
+                "websocket": {
+                    "description": "Messages exchanged with a WebSocket upstream instead of a request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WebSocketExchange"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ToolStep": {
+            "type": "object",
+            "required": [
+                "tool"
+            ],
+            "properties": {
+                "params": {
+                    "description": "Param of the step tool by gjson path, the params of the chained tool if empty",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "tool": {
+                    "description": "Name clients call the tool by",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Upstream": {
+            "type": "object",
+            "required": [
+                "name",
+                "targets"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "healthCheck": {
+                    "$ref": "#/definitions/models.HealthCheck"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targets": {
+                    "description": "Base URLs, e.g. https://10.0.0.1:8443",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpstreamExchange": {
+            "type": "object",
+            "properties": {
+                "latencyMs": {
+                    "description": "Time to the upstream response",
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "description": "Protocol of the response, e.g. HTTP/1.1, empty without a response",
+                    "type": "string"
+                },
+                "requestBody": {
+                    "type": "string"
+                },
+                "requestHeaders": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "responseBody": {
+                    "description": "Before the response plugins, with the redaction rules applied",
+                    "type": "string"
+                },
+                "responseHeaders": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "Status of the response before the response plugins, 0 without a response",
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UsageStats": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "errorRate": {
+                    "description": "Errors / calls, between 0 and 1",
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "latencyP50Ms": {
+                    "type": "number"
+                },
+                "latencyP90Ms": {
+                    "type": "number"
+                },
+                "latencyP99Ms": {
+                    "type": "number"
+                },
+                "serverId": {
+                    "type": "string"
+                },
+                "serverName": {
+                    "type": "string"
+                },
+                "tool": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VersionInfo": {
+            "type": "object",
+            "properties": {
+                "changelog": {
+                    "description": "Note of the change creating the version",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChangeNote"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "description": "When the version was created",
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WasmFile": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Hex-encoded SHA-256 of the content",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serverId": {
+                    "description": "Owner MCP server, empty for shared modules",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Incremented for every upload with the same name and owner",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebSocketExchange": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "description": "Replies collected, 1 by default, or up to 1000 with a terminator",
+                    "type": "integer"
+                },
+                "terminator": {
+                    "description": "Collecting stops after a reply containing it",
+                    "type": "string"
+                },
+                "timeoutMs": {
+                    "description": "Time allowed for the whole exchange, 10000 by default",
+                    "type": "integer"
+                }
+            }
+        },
+        "seed.Pack": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "requires": {
+                    "description": "Packs seeded first, whose resources this one uses",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "upstream.Health": {
+            "type": "object",
+            "properties": {
+                "healthCheckEnabled": {
+                    "type": "boolean"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/upstream.TargetStatus"
+                    }
+                }
+            }
+        },
+        "upstream.TargetStatus": {
+            "type": "object",
+            "properties": {
+                "consecutiveFailures": {
+                    "type": "integer"
+                },
+                "consecutiveSuccesses": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "lastChecked": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastStatusCode": {
+                    "type": "integer"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0.0",
+	Host:             "",
+	BasePath:         "/",
+	Schemes:          []string{},
+	Title:            "MCP Gateway API",
+	Description:      "Admin API of the MCP Gateway: manage HTTP interfaces, MCP servers, routers,\nupstreams, environments, WASM plugins and alert webhooks, and invoke tools.",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/config"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+)
+
+// ConfigReloader reloads the gateway configuration
+type ConfigReloader interface {
+	Current() config.Config
+	Reload() (config.Config, error)
+}
+
+// LogLevelRequest is the request for changing the log level
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required"` // debug, info, warn or error
+}
+
+// AdminHandler handles administrative API requests
+type AdminHandler struct {
+	reloader ConfigReloader
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler() *AdminHandler {
+	return &AdminHandler{}
+}
+
+// SetConfigReloader enables the configuration reload endpoint
+func (h *AdminHandler) SetConfigReloader(reloader ConfigReloader) {
+	h.reloader = reloader
+}
+
+// RegisterRoutes registers the admin API routes
+func (h *AdminHandler) RegisterRoutes(router *gin.Engine) {
+	adminGroup := router.Group("/api/admin")
+	{
+		adminGroup.GET("/log-level", h.GetLogLevel)
+		adminGroup.PUT("/log-level", h.SetLogLevel)
+		adminGroup.POST("/reload", h.ReloadConfig)
+	}
+}
+
+// GetLogLevel returns the current log level
+//
+// @Summary Get the log level
+// @Tags admin
+// @Produce json
+// @Success 200 {object} LogLevelResponse
+// @Router /api/admin/log-level [get]
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"level": logging.Level()})
+}
+
+// SetLogLevel changes the log level without restarting the gateway
+//
+// @Summary Change the log level
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body LogLevelRequest true "New log level"
+// @Success 200 {object} LogLevelResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/admin/log-level [put]
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var request LogLevelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := logging.SetLevel(request.Level); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Log level changed", "level", logging.Level())
+	c.JSON(http.StatusOK, gin.H{"level": logging.Level()})
+}
+
+// ReloadConfig reloads the configuration file, applying the log level, CORS, rate limit
+// and upstream allowlist settings without a restart. Requires the admin token.
+//
+// @Summary Reload the configuration file
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} config.Config
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/admin/reload [post]
+func (h *AdminHandler) ReloadConfig(c *gin.Context) {
+	if h.reloader == nil {
+		apierror.Respond(c, http.StatusNotFound, "Configuration reload is not available")
+		return
+	}
+
+	if !authorizeAdmin(c, h.reloader.Current().Admin.Token, "Configuration reload") {
+		return
+	}
+
+	cfg, err := h.reloader.Reload()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to reload configuration", "error", err)
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, cfg.Redacted())
+}
+
+// authorizeAdmin checks the bearer token of the request against the admin token, writing the
+// error response if it does not match. The feature is disabled while no admin token is set.
+func authorizeAdmin(c *gin.Context, token string, feature string) bool {
+	if token == "" {
+		apierror.Respond(c, http.StatusForbidden, feature+" is disabled, set admin.token to enable it")
+		return false
+	}
+	provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		apierror.Respond(c, http.StatusUnauthorized, "Invalid admin token")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/alerting"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// AlertWebhookHandler handles API requests for alert webhooks
+type AlertWebhookHandler struct {
+	repo    repository.AlertWebhookRepository
+	alerter *alerting.Alerter
+}
+
+// NewAlertWebhookHandler creates a new alert webhook handler
+func NewAlertWebhookHandler(repo repository.AlertWebhookRepository, alerter *alerting.Alerter) *AlertWebhookHandler {
+	return &AlertWebhookHandler{
+		repo:    repo,
+		alerter: alerter,
+	}
+}
+
+// RegisterRoutes registers the alert webhook API routes
+func (h *AlertWebhookHandler) RegisterRoutes(router *gin.Engine) {
+	webhookGroup := router.Group("/api/alert-webhooks")
+	{
+		webhookGroup.GET("", h.GetAllAlertWebhooks)
+		webhookGroup.GET("/:id", h.GetAlertWebhook)
+		webhookGroup.POST("", h.CreateAlertWebhook)
+		webhookGroup.PUT("/:id", h.UpdateAlertWebhook)
+		webhookGroup.DELETE("/:id", h.DeleteAlertWebhook)
+		webhookGroup.POST("/:id/test", h.TestAlertWebhook)
+	}
+}
+
+// GetAllAlertWebhooks returns all alert webhooks
+//
+// @Summary List alert webhooks
+// @Tags alert-webhooks
+// @Produce json
+// @Success 200 {array} models.AlertWebhook
+// @Failure 500 {object} ErrorResponse
+// @Router /api/alert-webhooks [get]
+func (h *AlertWebhookHandler) GetAllAlertWebhooks(c *gin.Context) {
+	webhooks, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetAlertWebhook returns a specific alert webhook
+//
+// @Summary Get an alert webhook
+// @Tags alert-webhooks
+// @Produce json
+// @Param id path string true "Alert webhook ID"
+// @Success 200 {object} models.AlertWebhook
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/alert-webhooks/{id} [get]
+func (h *AlertWebhookHandler) GetAlertWebhook(c *gin.Context) {
+	id := c.Param("id")
+	webhook, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Alert webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// CreateAlertWebhook creates a new alert webhook
+//
+// @Summary Create an alert webhook
+// @Tags alert-webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.AlertWebhook true "Alert webhook"
+// @Success 201 {object} models.AlertWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/alert-webhooks [post]
+func (h *AlertWebhookHandler) CreateAlertWebhook(c *gin.Context) {
+	var webhook models.AlertWebhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook = webhook.WithDefaults()
+	if err := validateAlertWebhook(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &webhook); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateAlertWebhook updates an alert webhook
+//
+// @Summary Update an alert webhook
+// @Tags alert-webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Alert webhook ID"
+// @Param webhook body models.AlertWebhook true "Alert webhook"
+// @Success 200 {object} models.AlertWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/alert-webhooks/{id} [put]
+func (h *AlertWebhookHandler) UpdateAlertWebhook(c *gin.Context) {
+	id := c.Param("id")
+	var webhook models.AlertWebhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	webhook.ID = id
+
+	webhook = webhook.WithDefaults()
+	if err := validateAlertWebhook(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &webhook); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Alert webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteAlertWebhook deletes an alert webhook
+//
+// @Summary Delete an alert webhook
+// @Tags alert-webhooks
+// @Produce json
+// @Param id path string true "Alert webhook ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/alert-webhooks/{id} [delete]
+func (h *AlertWebhookHandler) DeleteAlertWebhook(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Alert webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestAlertWebhook sends a test notification to an alert webhook
+//
+// @Summary Send a test notification
+// @Tags alert-webhooks
+// @Produce json
+// @Param id path string true "Alert webhook ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/alert-webhooks/{id}/test [post]
+func (h *AlertWebhookHandler) TestAlertWebhook(c *gin.Context) {
+	id := c.Param("id")
+	webhook, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Alert webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.alerter.Send(c.Request.Context(), *webhook, alerting.TestAlert()); err != nil {
+		apierror.Respond(c, http.StatusBadGateway, "Failed to send test notification: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Test notification sent successfully"})
+}
+
+// validateAlertWebhook checks the webhook URL, format and thresholds
+func validateAlertWebhook(webhook *models.AlertWebhook) error {
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL '%s': must be an absolute http or https URL", webhook.URL)
+	}
+
+	if webhook.Format != "json" && webhook.Format != "slack" {
+		return fmt.Errorf("invalid format '%s': must be json or slack", webhook.Format)
+	}
+
+	if webhook.ErrorRateThreshold < 0 || webhook.ErrorRateThreshold > 1 {
+		return fmt.Errorf("invalid errorRateThreshold %v: must be between 0 and 1", webhook.ErrorRateThreshold)
+	}
+
+	if webhook.MinCalls < 0 || webhook.WindowSeconds < 0 || webhook.CooldownSeconds < 0 {
+		return fmt.Errorf("minCalls, windowSeconds and cooldownSeconds must not be negative")
+	}
+
+	return nil
+}
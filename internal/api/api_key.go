@@ -0,0 +1,255 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/quota"
+)
+
+// APIKeyRequest sets the name and the quotas of an API key
+type APIKeyRequest struct {
+	Name            string  `json:"name" binding:"required"`
+	MaxCostPerDay   float64 `json:"maxCostPerDay" binding:"min=0"`   // Cost of the tool calls per UTC day, 0 for unlimited
+	MaxCostPerMonth float64 `json:"maxCostPerMonth" binding:"min=0"` // Cost of the tool calls per UTC month, 0 for unlimited
+}
+
+// APIKeyHandler handles API requests for the API keys of clients and their usage
+type APIKeyHandler struct {
+	repo       repository.APIKeyRepository
+	tracker    *quota.KeyTracker
+	adminToken func() string // Current admin token, required to manage keys
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(repo repository.APIKeyRepository, tracker *quota.KeyTracker, adminToken func() string) *APIKeyHandler {
+	return &APIKeyHandler{
+		repo:       repo,
+		tracker:    tracker,
+		adminToken: adminToken,
+	}
+}
+
+// RegisterRoutes registers the API key routes
+func (h *APIKeyHandler) RegisterRoutes(router *gin.Engine) {
+	keyGroup := router.Group("/api/api-keys")
+	{
+		keyGroup.GET("", h.GetAllAPIKeys)
+		keyGroup.GET("/:id", h.GetAPIKey)
+		keyGroup.GET("/:id/usage", h.GetAPIKeyUsage)
+		keyGroup.POST("", h.CreateAPIKey)
+		keyGroup.PUT("/:id", h.UpdateAPIKey)
+		keyGroup.DELETE("/:id", h.DeleteAPIKey)
+	}
+}
+
+// GetAllAPIKeys returns all API keys without the keys themselves
+//
+// @Summary List API keys
+// @Tags api-keys
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys [get]
+func (h *APIKeyHandler) GetAllAPIKeys(c *gin.Context) {
+	keys, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// GetAPIKey returns a specific API key without the key itself
+//
+// @Summary Get an API key
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.APIKey
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	key, ok := h.apiKey(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// GetAPIKeyUsage returns the tool calls and their cost per day of a month for an API key, with
+// the cost it may still spend when the month is the current one
+//
+// @Summary Get the usage of an API key
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param month query string false "UTC month as YYYY-MM, the current one by default"
+// @Success 200 {object} models.APIKeyUsage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys/{id}/usage [get]
+func (h *APIKeyHandler) GetAPIKeyUsage(c *gin.Context) {
+	month := c.Query("month")
+	if month != "" {
+		if _, err := time.Parse("2006-01", month); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("invalid month '%s': must be YYYY-MM", month))
+			return
+		}
+	}
+
+	key, ok := h.apiKey(c)
+	if !ok {
+		return
+	}
+
+	usage, err := h.tracker.Usage(c.Request.Context(), key, month)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// CreateAPIKey creates an API key. The key is only returned in this response. Requires the
+// admin token.
+//
+// @Summary Create an API key
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param request body APIKeyRequest true "Name and quotas"
+// @Success 201 {object} models.APIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing API keys") {
+		return
+	}
+
+	var req APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key := models.APIKey{
+		Name:            req.Name,
+		MaxCostPerDay:   req.MaxCostPerDay,
+		MaxCostPerMonth: req.MaxCostPerMonth,
+	}
+	if err := quota.GenerateKey(&key); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &key); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// UpdateAPIKey changes the name and the quotas of an API key, keeping the key. Requires the
+// admin token.
+//
+// @Summary Update an API key
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "API key ID"
+// @Param request body APIKeyRequest true "Name and quotas"
+// @Success 200 {object} models.APIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys/{id} [put]
+func (h *APIKeyHandler) UpdateAPIKey(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing API keys") {
+		return
+	}
+
+	var req APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key := models.APIKey{
+		ID:              c.Param("id"),
+		Name:            req.Name,
+		MaxCostPerDay:   req.MaxCostPerDay,
+		MaxCostPerMonth: req.MaxCostPerMonth,
+	}
+	if err := h.repo.Update(c.Request.Context(), &key); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "API key not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// DeleteAPIKey revokes an API key and drops its usage. Requires the admin token.
+//
+// @Summary Delete an API key
+// @Tags api-keys
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/api-keys/{id} [delete]
+func (h *APIKeyHandler) DeleteAPIKey(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing API keys") {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "API key not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// apiKey returns the API key of the request path. It responds with an error otherwise.
+func (h *APIKeyHandler) apiKey(c *gin.Context) (*models.APIKey, bool) {
+	key, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "API key not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return key, true
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+)
+
+// maxBundleSize bounds the size of applied bundles
+const maxBundleSize = 10 << 20
+
+// ApplyResponse lists the changes made, or planned on a dry run, to match a bundle
+type ApplyResponse struct {
+	DryRun  bool            `json:"dryRun"`
+	Changes []gitops.Change `json:"changes"`
+	Failed  int             `json:"failed"` // Number of changes that failed
+}
+
+// ApplyHandler handles declarative apply requests
+type ApplyHandler struct {
+	reconciler *gitops.Reconciler
+}
+
+// NewApplyHandler creates a new apply handler
+func NewApplyHandler(reconciler *gitops.Reconciler) *ApplyHandler {
+	return &ApplyHandler{
+		reconciler: reconciler,
+	}
+}
+
+// RegisterRoutes registers the apply API route
+func (h *ApplyHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/api/apply", h.Apply)
+}
+
+// Apply reconciles the gateway with a bundle of interfaces, servers and routers given as JSON or YAML.
+// Resources are matched by name. With prune, resources of the kinds listed in the bundle that it
+// does not define are deleted. With dryRun, the changes are only returned. While approval is
+// required, a bundle changing active MCP servers is rejected with 409.
+//
+// @Summary Apply a bundle of desired resources
+// @Tags apply
+// @Accept json
+// @Accept application/x-yaml
+// @Produce json
+// @Param bundle body gitops.Bundle true "Desired interfaces, servers and routers"
+// @Param dryRun query bool false "Only return the planned changes"
+// @Param prune query bool false "Delete resources missing from the bundle"
+// @Success 200 {object} ApplyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/apply [post]
+func (h *ApplyHandler) Apply(c *gin.Context) {
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	prune, err := parseBoolQuery(c, "prune")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBundleSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			apierror.Respond(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Bundle exceeds %d bytes", maxBundleSize))
+			return
+		}
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := gitops.ParseBundle(data)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var changes []gitops.Change
+	if dryRun {
+		changes, err = h.reconciler.Diff(c.Request.Context(), bundle, prune)
+	} else {
+		changes, err = h.reconciler.Apply(c.Request.Context(), bundle, prune)
+	}
+	if err != nil {
+		apierror.RespondCode(c, applyErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	response := ApplyResponse{DryRun: dryRun, Changes: changes}
+	for _, change := range changes {
+		if change.Error != "" {
+			response.Failed++
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// applyErrorStatus returns the HTTP status reported for a bundle that failed to apply
+func applyErrorStatus(err error) int {
+	if errors.Is(err, gitops.ErrApprovalRequired) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// parseBoolQuery returns the boolean query parameter name, false if absent
+func parseBoolQuery(c *gin.Context, name string) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s '%s': must be true or false", name, value)
+	}
+	return parsed, nil
+}
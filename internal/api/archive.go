@@ -0,0 +1,173 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// includeArchived reports whether a listing requests archived entities with ?includeArchived=true
+func includeArchived(c *gin.Context) bool {
+	return c.Query("includeArchived") == "true"
+}
+
+// ArchiveHTTPInterface archives an HTTP interface: it is hidden from listings, cannot be checked
+// or used to create MCP servers, and keeps its definition and versions
+//
+// @Summary Archive an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/archive [post]
+func (h *HTTPInterfaceHandler) ArchiveHTTPInterface(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveHTTPInterface restores an archived HTTP interface
+//
+// @Summary Unarchive an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/unarchive [post]
+func (h *HTTPInterfaceHandler) UnarchiveHTTPInterface(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+// setArchived archives or unarchives the HTTP interface of the request path
+func (h *HTTPInterfaceHandler) setArchived(c *gin.Context, archived bool) {
+	id := c.Param("id")
+	httpInterface, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if httpInterface.Archived == archived {
+		message := "HTTP interface is not archived"
+		if archived {
+			message = "HTTP interface is already archived"
+		}
+		apierror.Respond(c, http.StatusConflict, message)
+		return
+	}
+
+	if err := h.repo.SetArchived(c.Request.Context(), id, archived); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if archived {
+		slog.InfoContext(c.Request.Context(), "Archived HTTP interface", "id", id, "name", httpInterface.Name)
+		c.JSON(http.StatusOK, gin.H{"message": "HTTP interface archived successfully"})
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "Unarchived HTTP interface", "id", id, "name", httpInterface.Name)
+	c.JSON(http.StatusOK, gin.H{"message": "HTTP interface unarchived successfully"})
+}
+
+// ArchiveMCPServer archives an MCP server: it stops serving its tools, is hidden from listings
+// and cannot be activated, and keeps its definition, versions and invocation history
+//
+// @Summary Archive an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/archive [post]
+func (h *MCPServerHandler) ArchiveMCPServer(c *gin.Context) {
+	id := c.Param("id")
+	server, ok := h.server(c, id)
+	if !ok {
+		return
+	}
+	if server.Status == "archived" {
+		apierror.Respond(c, http.StatusConflict, "MCP Server is already archived")
+		return
+	}
+
+	if err := h.mcpRepo.UpdateStatus(c.Request.Context(), id, "archived"); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.UnregisterServer(id)
+
+	slog.InfoContext(c.Request.Context(), "Archived MCP server", "id", id, "name", server.Name, "status", server.Status)
+	c.JSON(http.StatusOK, gin.H{"message": "MCP Server archived successfully"})
+}
+
+// UnarchiveMCPServer restores an archived MCP server as inactive, to be activated again
+//
+// @Summary Unarchive an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/unarchive [post]
+func (h *MCPServerHandler) UnarchiveMCPServer(c *gin.Context) {
+	id := c.Param("id")
+	server, ok := h.server(c, id)
+	if !ok {
+		return
+	}
+	if server.Status != "archived" {
+		apierror.Respond(c, http.StatusConflict, "MCP Server is not archived")
+		return
+	}
+
+	if err := h.mcpRepo.UpdateStatus(c.Request.Context(), id, "inactive"); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Unarchived MCP server", "id", id, "name", server.Name)
+	c.JSON(http.StatusOK, gin.H{"message": "MCP Server unarchived successfully"})
+}
+
+// rejectArchivedServer responds with 409 Conflict if the server is archived
+func rejectArchivedServer(c *gin.Context, server *models.MCPServer) bool {
+	if server.Status != "archived" {
+		return false
+	}
+	apierror.Respond(c, http.StatusConflict, "MCP Server is archived, unarchive it first")
+	return true
+}
+
+// server returns the MCP server of an ID. It responds with an error otherwise.
+func (h *MCPServerHandler) server(c *gin.Context, id string) (*models.MCPServer, bool) {
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return server, true
+}
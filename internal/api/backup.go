@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/backup"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+)
+
+// BackupListResponse lists the backups and the outcome of the last one
+type BackupListResponse struct {
+	Status  backup.Status   `json:"status"`
+	Backups []backup.Object `json:"backups"` // Newest first
+}
+
+// BackupHandler handles API requests for the configuration backups. Backups span every
+// namespace, so every request requires the admin token.
+type BackupHandler struct {
+	scheduler  *backup.Scheduler // Nil if backups are disabled
+	adminToken func() string
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(scheduler *backup.Scheduler, adminToken func() string) *BackupHandler {
+	return &BackupHandler{
+		scheduler:  scheduler,
+		adminToken: adminToken,
+	}
+}
+
+// RegisterRoutes registers the backup API routes
+func (h *BackupHandler) RegisterRoutes(router *gin.Engine) {
+	backupGroup := router.Group("/api/backups")
+	{
+		backupGroup.GET("", h.GetBackups)
+		backupGroup.POST("", h.CreateBackup)
+		backupGroup.POST("/:name/restore", h.RestoreBackup)
+	}
+}
+
+// GetBackups lists the backups, newest first
+//
+// @Summary List the configuration backups
+// @Tags backups
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {object} BackupListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/backups [get]
+func (h *BackupHandler) GetBackups(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	backups, err := h.scheduler.List(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, BackupListResponse{Status: h.scheduler.Status(), Backups: backups})
+}
+
+// CreateBackup backs up the configuration without waiting for the next interval
+//
+// @Summary Back up the configuration now
+// @Tags backups
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Success 201 {object} backup.Object
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/backups [post]
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	object, err := h.scheduler.Backup(unscoped(c))
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, object)
+}
+
+// RestoreBackup applies a backup to every namespace: deleted resources are recreated and changed
+// ones reverted. With prune, resources created since the backup are deleted too. With dryRun,
+// the changes are only returned.
+//
+// @Summary Restore a configuration backup
+// @Tags backups
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param name path string true "Backup name"
+// @Param dryRun query bool false "Only return the planned changes"
+// @Param prune query bool false "Delete resources missing from the backup"
+// @Success 200 {object} ApplyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/backups/{name}/restore [post]
+func (h *BackupHandler) RestoreBackup(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	prune, err := parseBoolQuery(c, "prune")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := h.scheduler.Restore(unscoped(c), c.Param("name"), prune, dryRun)
+	if err != nil {
+		if errors.Is(err, backup.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, "Backup not found")
+			return
+		}
+		apierror.RespondCode(c, applyErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	failed := 0
+	for _, change := range changes {
+		if change.Error != "" {
+			failed++
+		}
+	}
+	c.JSON(http.StatusOK, ApplyResponse{DryRun: dryRun, Changes: changes, Failed: failed})
+}
+
+// authorize writes the error response if backups are disabled or the admin token does not match
+func (h *BackupHandler) authorize(c *gin.Context) bool {
+	if h.scheduler == nil {
+		apierror.Respond(c, http.StatusNotFound, "Backups are not enabled")
+		return false
+	}
+	return authorizeAdmin(c, h.adminToken(), "Managing backups")
+}
+
+// unscoped returns a context seeing every namespace, keeping the request ID of the logs. It is
+// not canceled with the request, so that backups and restores finish if the client goes away.
+func unscoped(c *gin.Context) context.Context {
+	return logging.With(context.Background(), "requestId", logging.RequestID(c))
+}
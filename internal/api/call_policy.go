@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+)
+
+// GetToolPolicy returns the call policy a tool applies: its own settings, completed by those of
+// its server and of its namespace
+//
+// @Summary Get the effective call policy of a tool
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name or alias"
+// @Success 200 {object} models.CallPolicy
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool}/policy [get]
+func (h *MCPServerHandler) GetToolPolicy(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	toolName := c.Param("tool")
+	tool := server.FindTool(toolName)
+	for i := range server.Tools {
+		if server.Tools[i].Name == toolName {
+			tool = &server.Tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		apierror.Respond(c, http.StatusNotFound, "Tool not found: "+toolName)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mcpService.EffectivePolicy(c.Request.Context(), server, tool))
+}
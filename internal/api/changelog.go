@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Headers of the change note recorded on the interface and server versions a request creates
+const (
+	ChangeAuthorHeader  = "X-Change-Author"
+	ChangeMessageHeader = "X-Change-Message"
+)
+
+// ChangeNoteMiddleware records the note of the X-Change-Author and X-Change-Message headers on
+// the interface and server versions created by the request
+func ChangeNoteMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		note := &models.ChangeNote{Author: c.GetHeader(ChangeAuthorHeader), Message: c.GetHeader(ChangeMessageHeader)}
+		if note.IsEmpty() {
+			c.Next()
+			return
+		}
+		if err := note.Validate(); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Request = c.Request.WithContext(repository.WithChangeNote(c.Request.Context(), note))
+		c.Next()
+	}
+}
+
+// applyChangeNote records the changelog of a request body on the versions the request creates,
+// over the note of the headers. A changelog equal to previous, the note of the version the body
+// was read from, is ignored so that sending back a definition does not repeat its note. It
+// responds with 400 and returns false if the note is invalid.
+func applyChangeNote(c *gin.Context, note *models.ChangeNote, previous *models.ChangeNote) bool {
+	note = note.Normalized()
+	if note == nil || note.Equal(previous) {
+		return true
+	}
+	if err := note.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	c.Request = c.Request.WithContext(repository.WithChangeNote(c.Request.Context(), note))
+	return true
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// CollectionHandler handles API requests for collections of HTTP interfaces
+type CollectionHandler struct {
+	repo     repository.CollectionRepository
+	httpRepo repository.HTTPInterfaceRepository
+}
+
+// NewCollectionHandler creates a new collection handler
+func NewCollectionHandler(repo repository.CollectionRepository, httpRepo repository.HTTPInterfaceRepository) *CollectionHandler {
+	return &CollectionHandler{
+		repo:     repo,
+		httpRepo: httpRepo,
+	}
+}
+
+// RegisterRoutes registers the collection API routes
+func (h *CollectionHandler) RegisterRoutes(router *gin.Engine) {
+	collectionGroup := router.Group("/api/collections")
+	{
+		collectionGroup.GET("", h.GetAllCollections)
+		collectionGroup.GET("/:id", h.GetCollection)
+		collectionGroup.POST("", h.CreateCollection)
+		collectionGroup.PUT("/:id", h.UpdateCollection)
+		collectionGroup.DELETE("/:id", h.DeleteCollection)
+		collectionGroup.GET("/:id/http-interfaces", h.GetCollectionHTTPInterfaces)
+	}
+}
+
+// GetAllCollections returns all collections
+//
+// @Summary List collections
+// @Tags collections
+// @Produce json
+// @Success 200 {array} models.Collection
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections [get]
+func (h *CollectionHandler) GetAllCollections(c *gin.Context) {
+	collections, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// GetCollection returns a specific collection
+//
+// @Summary Get a collection
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} models.Collection
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections/{id} [get]
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	collection, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Collection not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// CreateCollection creates a new collection
+//
+// @Summary Create a collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param collection body models.Collection true "Collection"
+// @Success 201 {object} models.Collection
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections [post]
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	var collection models.Collection
+	if err := c.ShouldBindJSON(&collection); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkInterfaces(c, &collection) {
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &collection); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, collection)
+}
+
+// UpdateCollection updates a collection
+//
+// @Summary Update a collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param collection body models.Collection true "Collection"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections/{id} [put]
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	var collection models.Collection
+	if err := c.ShouldBindJSON(&collection); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	collection.ID = c.Param("id")
+
+	if !h.checkInterfaces(c, &collection) {
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &collection); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Collection not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// DeleteCollection deletes a collection, keeping its HTTP interfaces
+//
+// @Summary Delete a collection
+// @Tags collections
+// @Param id path string true "Collection ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections/{id} [delete]
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Collection not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCollectionHTTPInterfaces returns the HTTP interfaces of a collection
+//
+// @Summary List the HTTP interfaces of a collection
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {array} models.HTTPInterface
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/collections/{id}/http-interfaces [get]
+func (h *CollectionHandler) GetCollectionHTTPInterfaces(c *gin.Context) {
+	collection, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Collection not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	interfaces, err := collectionInterfaces(c.Request.Context(), h.httpRepo, collection)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, interfaces)
+}
+
+// checkInterfaces answers 400 and returns false if an interface of the collection does not exist
+func (h *CollectionHandler) checkInterfaces(c *gin.Context, collection *models.Collection) bool {
+	if collection.InterfaceIDs == nil {
+		collection.InterfaceIDs = []string{}
+	}
+	for _, id := range collection.InterfaceIDs {
+		if _, err := h.httpRepo.GetByID(c.Request.Context(), id); err != nil {
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusBadRequest, "HTTP interface not found: "+id)
+				return false
+			}
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+	}
+	return true
+}
+
+// collectionInterfaces returns the HTTP interfaces of a collection in its order, skipping those deleted since
+func collectionInterfaces(ctx context.Context, httpRepo repository.HTTPInterfaceRepository, collection *models.Collection) ([]models.HTTPInterface, error) {
+	found, err := httpRepo.GetByIDs(ctx, collection.InterfaceIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.HTTPInterface, len(found))
+	for _, httpInterface := range found {
+		byID[httpInterface.ID] = httpInterface
+	}
+
+	interfaces := make([]models.HTTPInterface, 0, len(found))
+	for _, id := range collection.InterfaceIDs {
+		if httpInterface, ok := byID[id]; ok {
+			interfaces = append(interfaces, httpInterface)
+		}
+	}
+	return interfaces, nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Response bodies described in the OpenAPI specification of the admin API.
+// The handlers build them with gin.H, these types only document their shape.
+
+// ErrorResponse is returned by every endpoint on failure
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes the failure of a request
+type ErrorDetail struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	RequestID string      `json:"requestId"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// MessageResponse confirms an operation
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// LogLevelResponse is the current log level
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// ValidateNameResponse reports whether an MCP server name is available
+type ValidateNameResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// ImportResponse lists the HTTP interfaces of an OpenAPI import, the collection grouping them and
+// the weaknesses of their definitions. A dry run lists the interfaces it would save.
+type ImportResponse struct {
+	Message    string                 `json:"message"`
+	DryRun     bool                   `json:"dryRun,omitempty"`
+	Interfaces []models.HTTPInterface `json:"interfaces"`
+	Summary    ImportSummary          `json:"summary"`
+	Collection *models.Collection     `json:"collection,omitempty"`
+	Warnings   []models.LintWarning   `json:"warnings"`
+	// Names of the interfaces to save that are already taken, failing the import with 409;
+	// reported by dry runs
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// ImportSummary names the interfaces an OpenAPI import created, updated in place with a new
+// version and left unchanged, since they matched an earlier import of the spec
+type ImportSummary struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// CurlImportResponse is the HTTP interface created from a curl command and the weaknesses of its
+// definition
+type CurlImportResponse struct {
+	models.HTTPInterface
+	Warnings []models.LintWarning `json:"warnings"`
+}
+
+// LintReport lists the weaknesses of the definition of an HTTP interface
+type LintReport struct {
+	Warnings []models.LintWarning `json:"warnings"`
+}
+
+// InvocationListResponse is a page of the invocation history
+type InvocationListResponse struct {
+	Invocations []models.Invocation `json:"invocations"`
+	Total       int                 `json:"total"`
+	Limit       int                 `json:"limit"`
+	Offset      int                 `json:"offset"`
+}
+
+// StatsResponse is the usage statistics of a time window
+type StatsResponse struct {
+	Window  string              `json:"window"`
+	Since   time.Time           `json:"since"`
+	Until   time.Time           `json:"until"`
+	Total   models.UsageStats   `json:"total"`
+	Servers []models.UsageStats `json:"servers,omitempty"` // Gateway-wide statistics only
+	Tools   []models.UsageStats `json:"tools"`
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// environmentNamePattern restricts names so they can be passed in the X-MCP-Environment header
+var environmentNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// environmentVariablePattern matches the names usable in {{env:name}} placeholders
+var environmentVariablePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// EnvironmentHandler handles API requests for environments
+type EnvironmentHandler struct {
+	repo repository.EnvironmentRepository
+}
+
+// NewEnvironmentHandler creates a new environment handler
+func NewEnvironmentHandler(repo repository.EnvironmentRepository) *EnvironmentHandler {
+	return &EnvironmentHandler{
+		repo: repo,
+	}
+}
+
+// RegisterRoutes registers the environment API routes
+func (h *EnvironmentHandler) RegisterRoutes(router *gin.Engine) {
+	environmentGroup := router.Group("/api/environments")
+	{
+		environmentGroup.GET("", h.GetAllEnvironments)
+		environmentGroup.GET("/:id", h.GetEnvironment)
+		environmentGroup.POST("", h.CreateEnvironment)
+		environmentGroup.PUT("/:id", h.UpdateEnvironment)
+		environmentGroup.DELETE("/:id", h.DeleteEnvironment)
+	}
+}
+
+// GetAllEnvironments returns all environments
+//
+// @Summary List environments
+// @Tags environments
+// @Produce json
+// @Success 200 {array} models.Environment
+// @Failure 500 {object} ErrorResponse
+// @Router /api/environments [get]
+func (h *EnvironmentHandler) GetAllEnvironments(c *gin.Context) {
+	environments, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, environments)
+}
+
+// GetEnvironment returns a specific environment
+//
+// @Summary Get an environment
+// @Tags environments
+// @Produce json
+// @Param id path string true "Environment ID"
+// @Success 200 {object} models.Environment
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/environments/{id} [get]
+func (h *EnvironmentHandler) GetEnvironment(c *gin.Context) {
+	id := c.Param("id")
+	environment, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Environment not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, environment)
+}
+
+// CreateEnvironment creates a new environment
+//
+// @Summary Create an environment
+// @Tags environments
+// @Accept json
+// @Produce json
+// @Param environment body models.Environment true "Environment"
+// @Success 201 {object} models.Environment
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/environments [post]
+func (h *EnvironmentHandler) CreateEnvironment(c *gin.Context) {
+	var environment models.Environment
+	if err := c.ShouldBindJSON(&environment); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateEnvironment(&environment); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate name uniqueness
+	if _, err := h.repo.GetByName(c.Request.Context(), environment.Name); err == nil {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Environment with name '%s' already exists", environment.Name))
+		return
+	} else if err != repository.ErrNotFound {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &environment); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, environment)
+}
+
+// UpdateEnvironment updates an environment
+//
+// @Summary Update an environment
+// @Tags environments
+// @Accept json
+// @Produce json
+// @Param id path string true "Environment ID"
+// @Param environment body models.Environment true "Environment"
+// @Success 200 {object} models.Environment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/environments/{id} [put]
+func (h *EnvironmentHandler) UpdateEnvironment(c *gin.Context) {
+	id := c.Param("id")
+	var environment models.Environment
+	if err := c.ShouldBindJSON(&environment); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	environment.ID = id
+
+	if err := validateEnvironment(&environment); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate name uniqueness
+	if existing, err := h.repo.GetByName(c.Request.Context(), environment.Name); err == nil && existing.ID != id {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Environment with name '%s' already exists", environment.Name))
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &environment); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Environment not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, environment)
+}
+
+// DeleteEnvironment deletes an environment
+//
+// @Summary Delete an environment
+// @Tags environments
+// @Produce json
+// @Param id path string true "Environment ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/environments/{id} [delete]
+func (h *EnvironmentHandler) DeleteEnvironment(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Environment not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// validateEnvironment checks the environment name and variable names
+func validateEnvironment(environment *models.Environment) error {
+	if !environmentNamePattern.MatchString(environment.Name) {
+		return fmt.Errorf("invalid environment name '%s': use letters, digits, '-' and '_'", environment.Name)
+	}
+
+	if environment.Variables == nil {
+		environment.Variables = map[string]string{}
+	}
+	for name := range environment.Variables {
+		if !environmentVariablePattern.MatchString(name) {
+			return fmt.Errorf("invalid variable name '%s': use letters, digits, '.', '-' and '_'", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+)
+
+// resourceETag returns the ETag of a version of a resource. The update time tells apart the
+// resources recreated with a version number already used; it is taken in microseconds, the
+// precision PostgreSQL keeps.
+func resourceETag(version int, updatedAt time.Time) string {
+	return fmt.Sprintf(`"%d-%x"`, version, updatedAt.UnixMicro())
+}
+
+// notModified sets the ETag of a resource on the response and answers 304 Not Modified when the
+// If-None-Match header lists it, in which case the handler must not write a body
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	if matchesETag(c.GetHeader("If-None-Match"), etag, true) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// preconditionFailed answers 412 Precondition Failed when the If-Match header of an update does
+// not list the current ETag of the resource, i.e. it was changed since the client read it.
+// Otherwise the repository update of the request only applies to the version read, so that two
+// updates with the same ETag cannot both succeed.
+func preconditionFailed(c *gin.Context, id string, version int, updatedAt time.Time) bool {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" || strings.TrimSpace(ifMatch) == "*" {
+		return false
+	}
+	etag := resourceETag(version, updatedAt)
+	if !matchesETag(ifMatch, etag, false) {
+		apierror.Respond(c, http.StatusPreconditionFailed, "The resource was modified, its current ETag is "+etag)
+		return true
+	}
+	c.Request = c.Request.WithContext(repository.WithExpectedVersion(c.Request.Context(), id, version))
+	return false
+}
+
+// matchesETag reports whether a list of ETags of an If-Match or If-None-Match header contains an
+// ETag. Weak ETags only match their strong counterpart with the weak comparison of If-None-Match,
+// If-Match requires the strong comparison.
+func matchesETag(header string, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/events"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// eventStreamKeepAlive is the time between the comments keeping idle event streams open through proxies
+const eventStreamKeepAlive = 15 * time.Second
+
+// EventStreamHandler streams the lifecycle events and tool invocations of the gateway
+type EventStreamHandler struct {
+	dispatcher *events.Dispatcher
+}
+
+// NewEventStreamHandler creates a new event stream handler
+func NewEventStreamHandler(dispatcher *events.Dispatcher) *EventStreamHandler {
+	return &EventStreamHandler{dispatcher: dispatcher}
+}
+
+// RegisterRoutes registers the event stream route
+func (h *EventStreamHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/api/events", h.StreamEvents)
+}
+
+// StreamEvents streams the events of the namespace of the request as server-sent events, from the
+// time of the request on. Each event is sent with its type as the event name and its ID.
+//
+// @Summary Stream lifecycle events and tool invocations
+// @Tags events
+// @Produce text/event-stream
+// @Param types query string false "Comma separated event types, all if empty"
+// @Success 200 {object} models.Event
+// @Failure 400 {object} ErrorResponse
+// @Router /api/events [get]
+func (h *EventStreamHandler) StreamEvents(c *gin.Context) {
+	var types []string
+	if value := c.Query("types"); value != "" {
+		for _, eventType := range strings.Split(value, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if eventType != models.EventToolInvoked && !slices.Contains(models.EventTypes, eventType) {
+				apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("invalid event type '%s'", eventType))
+				return
+			}
+			types = append(types, eventType)
+		}
+	}
+	name, _ := namespace.FromContext(c.Request.Context())
+	name = namespace.OrDefault(name)
+
+	stream := h.dispatcher.Subscribe(c.Request.Context())
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for {
+		select {
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			if event.Namespace != name || (len(types) > 0 && !slices.Contains(types, event.Type)) {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+		case <-keepAlive.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
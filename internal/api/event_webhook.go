@@ -0,0 +1,244 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/events"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// EventWebhookHandler handles API requests for event webhooks
+type EventWebhookHandler struct {
+	repo       repository.EventWebhookRepository
+	dispatcher *events.Dispatcher
+}
+
+// NewEventWebhookHandler creates a new event webhook handler
+func NewEventWebhookHandler(repo repository.EventWebhookRepository, dispatcher *events.Dispatcher) *EventWebhookHandler {
+	return &EventWebhookHandler{
+		repo:       repo,
+		dispatcher: dispatcher,
+	}
+}
+
+// RegisterRoutes registers the event webhook API routes
+func (h *EventWebhookHandler) RegisterRoutes(router *gin.Engine) {
+	webhookGroup := router.Group("/api/event-webhooks")
+	{
+		webhookGroup.GET("", h.GetAllEventWebhooks)
+		webhookGroup.GET("/:id", h.GetEventWebhook)
+		webhookGroup.POST("", h.CreateEventWebhook)
+		webhookGroup.PUT("/:id", h.UpdateEventWebhook)
+		webhookGroup.DELETE("/:id", h.DeleteEventWebhook)
+		webhookGroup.POST("/:id/test", h.TestEventWebhook)
+	}
+}
+
+// GetAllEventWebhooks returns all event webhooks
+//
+// @Summary List event webhooks
+// @Tags event-webhooks
+// @Produce json
+// @Success 200 {array} models.EventWebhook
+// @Failure 500 {object} ErrorResponse
+// @Router /api/event-webhooks [get]
+func (h *EventWebhookHandler) GetAllEventWebhooks(c *gin.Context) {
+	webhooks, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetEventWebhook returns a specific event webhook
+//
+// @Summary Get an event webhook
+// @Tags event-webhooks
+// @Produce json
+// @Param id path string true "Event webhook ID"
+// @Success 200 {object} models.EventWebhook
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/event-webhooks/{id} [get]
+func (h *EventWebhookHandler) GetEventWebhook(c *gin.Context) {
+	webhook, ok := h.getEventWebhook(c)
+	if !ok {
+		return
+	}
+
+	webhook.Secret = ""
+	c.JSON(http.StatusOK, webhook)
+}
+
+// CreateEventWebhook creates a new event webhook
+//
+// @Summary Create an event webhook
+// @Tags event-webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.EventWebhook true "Event webhook"
+// @Success 201 {object} models.EventWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/event-webhooks [post]
+func (h *EventWebhookHandler) CreateEventWebhook(c *gin.Context) {
+	var webhook models.EventWebhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateEventWebhook(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &webhook); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	webhook.Secret = ""
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateEventWebhook updates an event webhook. An empty secret keeps the current one.
+//
+// @Summary Update an event webhook
+// @Tags event-webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Event webhook ID"
+// @Param webhook body models.EventWebhook true "Event webhook"
+// @Success 200 {object} models.EventWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/event-webhooks/{id} [put]
+func (h *EventWebhookHandler) UpdateEventWebhook(c *gin.Context) {
+	existing, ok := h.getEventWebhook(c)
+	if !ok {
+		return
+	}
+
+	var webhook models.EventWebhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	webhook.ID = existing.ID
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+
+	if err := validateEventWebhook(&webhook); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &webhook); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Event webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	webhook.Secret = ""
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteEventWebhook deletes an event webhook
+//
+// @Summary Delete an event webhook
+// @Tags event-webhooks
+// @Produce json
+// @Param id path string true "Event webhook ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/event-webhooks/{id} [delete]
+func (h *EventWebhookHandler) DeleteEventWebhook(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Event webhook not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestEventWebhook sends a signed test event to an event webhook
+//
+// @Summary Send a test event
+// @Tags event-webhooks
+// @Produce json
+// @Param id path string true "Event webhook ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/event-webhooks/{id}/test [post]
+func (h *EventWebhookHandler) TestEventWebhook(c *gin.Context) {
+	webhook, ok := h.getEventWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.dispatcher.Send(c.Request.Context(), *webhook, events.TestEvent()); err != nil {
+		apierror.Respond(c, http.StatusBadGateway, "Failed to send test event: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Test event sent successfully"})
+}
+
+// getEventWebhook loads the webhook of the request, writing the error response if it fails
+func (h *EventWebhookHandler) getEventWebhook(c *gin.Context) (*models.EventWebhook, bool) {
+	webhook, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Event webhook not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return webhook, true
+}
+
+// validateEventWebhook checks the webhook URL and subscribed event types
+func validateEventWebhook(webhook *models.EventWebhook) error {
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL '%s': must be an absolute http or https URL", webhook.URL)
+	}
+
+	known := make(map[string]bool, len(models.EventTypes))
+	for _, eventType := range models.EventTypes {
+		known[eventType] = true
+	}
+	for _, eventType := range webhook.Events {
+		if !known[eventType] {
+			return fmt.Errorf("invalid event type '%s': must be one of %v", eventType, models.EventTypes)
+		}
+	}
+
+	return nil
+}
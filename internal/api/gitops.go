@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+)
+
+// GitOpsHandler handles API requests for the GitOps sync
+type GitOpsHandler struct {
+	controller *gitops.Controller // Nil if GitOps is disabled
+}
+
+// NewGitOpsHandler creates a new GitOps handler
+func NewGitOpsHandler(controller *gitops.Controller) *GitOpsHandler {
+	return &GitOpsHandler{
+		controller: controller,
+	}
+}
+
+// RegisterRoutes registers the GitOps API routes
+func (h *GitOpsHandler) RegisterRoutes(router *gin.Engine) {
+	gitopsGroup := router.Group("/api/gitops")
+	{
+		gitopsGroup.GET("/status", h.GetStatus)
+		gitopsGroup.GET("/drift", h.GetDrift)
+		gitopsGroup.POST("/sync", h.Sync)
+	}
+}
+
+// GetStatus returns the outcome of the last sync
+//
+// @Summary Get the GitOps sync status
+// @Tags gitops
+// @Produce json
+// @Success 200 {object} gitops.Status
+// @Failure 404 {object} ErrorResponse
+// @Router /api/gitops/status [get]
+func (h *GitOpsHandler) GetStatus(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, h.controller.Status())
+}
+
+// GetDrift returns the changes the next sync would make to match the last pulled revision
+//
+// @Summary Get the drift from the repository
+// @Tags gitops
+// @Produce json
+// @Success 200 {object} gitops.Drift
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/gitops/drift [get]
+func (h *GitOpsHandler) GetDrift(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	drift, err := h.controller.Drift(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusConflict, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, drift)
+}
+
+// Sync pulls the repository and applies it without waiting for the next interval
+//
+// @Summary Sync from the repository now
+// @Tags gitops
+// @Produce json
+// @Success 200 {object} gitops.Status
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} gitops.Status
+// @Router /api/gitops/sync [post]
+func (h *GitOpsHandler) Sync(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	// Finish the sync even if the client goes away
+	status := h.controller.Sync(context.WithoutCancel(c.Request.Context()))
+	if status.Error != "" {
+		c.JSON(http.StatusInternalServerError, status)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// enabled writes the error response if GitOps is disabled
+func (h *GitOpsHandler) enabled(c *gin.Context) bool {
+	if h.controller == nil {
+		apierror.Respond(c, http.StatusNotFound, "GitOps sync is not enabled")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// HAR is an HTTP Archive 1.2 document, see http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of a HAR document
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator names the application that created a HAR document
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is an upstream request of a tool invocation and its response
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            int64       `json:"time"` // Milliseconds
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+	InvocationID    string      `json:"_invocationId"`
+	Tool            string      `json:"_tool"`
+	RequestID       string      `json:"_requestId,omitempty"`
+}
+
+// HARRequest is the request of a HAR entry
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is the response of a HAR entry, with status 0 if none was received
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a header, query parameter or cookie
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a HAR request
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a HAR response
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// HARTimings splits the time of a HAR entry. The gateway only measures the time to the response.
+type HARTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// newHAR returns the HAR document of the upstream calls recorded with invocations, given newest
+// first, skipping the invocations that made no upstream call
+func newHAR(invocations []models.Invocation) *HAR {
+	entries := []HAREntry{}
+	for i := len(invocations) - 1; i >= 0; i-- {
+		if invocations[i].Upstream != nil {
+			entries = append(entries, newHAREntry(&invocations[i]))
+		}
+	}
+	return &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "MCP Gateway", Version: "1.0.0"},
+		Entries: entries,
+	}}
+}
+
+// newHAREntry returns the HAR entry of the upstream call of an invocation
+func newHAREntry(invocation *models.Invocation) HAREntry {
+	exchange := invocation.Upstream
+	httpVersion := exchange.Protocol
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
+
+	entry := HAREntry{
+		// Invocations are recorded when the tool call ends
+		StartedDateTime: invocation.CreatedAt.Add(-time.Duration(invocation.DurationMs) * time.Millisecond),
+		Time:            exchange.LatencyMs,
+		Request: HARRequest{
+			Method:      exchange.Method,
+			URL:         exchange.URL,
+			HTTPVersion: httpVersion,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(exchange.RequestHeaders),
+			QueryString: harQueryString(exchange.URL),
+			HeadersSize: -1,
+			BodySize:    len(exchange.RequestBody),
+		},
+		Response: HARResponse{
+			Status:      exchange.Status,
+			StatusText:  http.StatusText(exchange.Status),
+			HTTPVersion: httpVersion,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(exchange.ResponseHeaders),
+			Content: HARContent{
+				Size:     len(exchange.ResponseBody),
+				MimeType: headerValue(exchange.ResponseHeaders, "Content-Type"),
+				Text:     exchange.ResponseBody,
+			},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings:      HARTimings{Wait: exchange.LatencyMs},
+		Comment:      fmt.Sprintf("tool %s", invocation.Tool),
+		InvocationID: invocation.ID,
+		Tool:         invocation.Tool,
+		RequestID:    invocation.RequestID,
+	}
+	if exchange.RequestBody != "" {
+		entry.Request.PostData = &HARPostData{
+			MimeType: headerValue(exchange.RequestHeaders, "Content-Type"),
+			Text:     exchange.RequestBody,
+		}
+	}
+	if invocation.Error != "" {
+		entry.Comment += ": " + invocation.Error
+	}
+	return entry
+}
+
+// harHeaders returns headers sorted by name
+func harHeaders(headers map[string]string) []HARNameValue {
+	pairs := make([]HARNameValue, 0, len(headers))
+	for name, value := range headers {
+		pairs = append(pairs, HARNameValue{Name: name, Value: value})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// harQueryString returns the query parameters of rawURL in their order
+func harQueryString(rawURL string) []HARNameValue {
+	pairs := []HARNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return pairs
+	}
+	for _, part := range strings.Split(u.RawQuery, "&") {
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		name, _ = url.QueryUnescape(name)
+		value, _ = url.QueryUnescape(value)
+		pairs = append(pairs, HARNameValue{Name: name, Value: value})
+	}
+	return pairs
+}
+
+// headerValue returns the value of a header whatever the case of its name
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if http.CanonicalHeaderKey(key) == name {
+			return value
+		}
+	}
+	return ""
+}
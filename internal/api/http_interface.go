@@ -1,23 +1,34 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
 	"gopkg.in/yaml.v3"
 )
 
 // HTTPInterfaceHandler handles API requests for HTTP interfaces
 type HTTPInterfaceHandler struct {
-	repo repository.HTTPInterfaceRepository
+	repo    repository.HTTPInterfaceRepository
+	syncer  *mcp.ServerSyncer
+	service *mcp.MCPService // Probes the upstreams of interfaces, nil to disable the check
+	// Collections recording the interfaces of each OpenAPI import, nil to not record them
+	collections repository.CollectionRepository
 }
 
 // NewHTTPInterfaceHandler creates a new HTTP interface handler
@@ -27,6 +38,21 @@ func NewHTTPInterfaceHandler(repo repository.HTTPInterfaceRepository) *HTTPInter
 	}
 }
 
+// SetServerSyncer sets the syncer regenerating the MCP server tools of updated interfaces
+func (h *HTTPInterfaceHandler) SetServerSyncer(syncer *mcp.ServerSyncer) {
+	h.syncer = syncer
+}
+
+// SetMCPService sets the service probing the upstreams of interfaces
+func (h *HTTPInterfaceHandler) SetMCPService(service *mcp.MCPService) {
+	h.service = service
+}
+
+// SetCollectionRepository sets the collections recording the interfaces of each OpenAPI import
+func (h *HTTPInterfaceHandler) SetCollectionRepository(collections repository.CollectionRepository) {
+	h.collections = collections
+}
+
 // RegisterRoutes registers the HTTP interface API routes
 func (h *HTTPInterfaceHandler) RegisterRoutes(router *gin.Engine) {
 	httpGroup := router.Group("/api/http-interfaces")
@@ -39,104 +65,256 @@ func (h *HTTPInterfaceHandler) RegisterRoutes(router *gin.Engine) {
 		httpGroup.GET("/:id/versions", h.GetHTTPInterfaceVersions)
 		httpGroup.GET("/:id/versions/:version", h.GetHTTPInterfaceByVersion)
 		httpGroup.GET("/:id/openapi", h.ExportToOpenAPI)
+		httpGroup.GET("/:id/examples", h.GetHTTPInterfaceExamples)
+		httpGroup.GET("/:id/lint", h.LintHTTPInterface)
+		httpGroup.POST("/:id/check", h.CheckHTTPInterface)
+		httpGroup.POST("/:id/archive", h.ArchiveHTTPInterface)
+		httpGroup.POST("/:id/unarchive", h.UnarchiveHTTPInterface)
 		httpGroup.POST("/from-curl", h.CreateFromCurl)
 		httpGroup.POST("/from-openapi", h.CreateFromOpenAPI)
 		httpGroup.POST("/from-openapi-file", h.CreateFromOpenAPIFile)
 	}
 }
 
-// GetAllHTTPInterfaces returns all HTTP interfaces
+// GetAllHTTPInterfaces returns all HTTP interfaces, without the archived ones unless requested
+//
+// @Summary List HTTP interfaces
+// @Tags http-interfaces
+// @Produce json
+// @Param includeArchived query bool false "Include archived interfaces"
+// @Success 200 {array} models.HTTPInterface
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces [get]
 func (h *HTTPInterfaceHandler) GetAllHTTPInterfaces(c *gin.Context) {
 	interfaces, err := h.repo.GetAll(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if !includeArchived(c) {
+		interfaces = slices.DeleteFunc(interfaces, func(httpInterface models.HTTPInterface) bool { return httpInterface.Archived })
+	}
+
 	c.JSON(http.StatusOK, interfaces)
 }
 
-// GetHTTPInterface returns a specific HTTP interface
+// GetHTTPInterface returns a specific HTTP interface, or 304 Not Modified when If-None-Match
+// lists its ETag
+//
+// @Summary Get an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Param If-None-Match header string false "ETag of the cached interface"
+// @Success 200 {object} models.HTTPInterface
+// @Success 304 "Not modified"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id} [get]
 func (h *HTTPInterfaceHandler) GetHTTPInterface(c *gin.Context) {
 	id := c.Param("id")
 	httpInterface, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface not found"})
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if notModified(c, resourceETag(httpInterface.Version, httpInterface.UpdatedAt)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, httpInterface)
 }
 
+// LintHTTPInterface reports the weaknesses of the definition of an HTTP interface: missing
+// descriptions and schemas and ambiguous params
+//
+// @Summary Lint an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {object} LintReport
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/lint [get]
+func (h *HTTPInterfaceHandler) LintHTTPInterface(c *gin.Context) {
+	httpInterface, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, LintReport{Warnings: models.LintInterfaces([]models.HTTPInterface{*httpInterface})})
+}
+
 // CreateHTTPInterface creates a new HTTP interface
+//
+// @Summary Create an HTTP interface
+// @Tags http-interfaces
+// @Accept json
+// @Produce json
+// @Param interface body models.HTTPInterface true "HTTP interface"
+// @Success 201 {object} models.HTTPInterface
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces [post]
 func (h *HTTPInterfaceHandler) CreateHTTPInterface(c *gin.Context) {
 	var httpInterface models.HTTPInterface
 	if err := c.ShouldBindJSON(&httpInterface); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if httpInterface.Auth != nil {
+		if err := httpInterface.Auth.Validate(); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := httpInterface.ValidateParamStyles(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !applyChangeNote(c, httpInterface.Changelog, nil) {
 		return
 	}
 
 	if err := h.repo.Create(c.Request.Context(), &httpInterface); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
 		return
 	}
 
 	c.JSON(http.StatusCreated, httpInterface)
 }
 
-// UpdateHTTPInterface updates an HTTP interface
+// UpdateHTTPInterface updates an HTTP interface. With If-Match, the update is rejected with 412
+// when the interface was changed since the client read it.
+//
+// @Summary Update an HTTP interface
+// @Tags http-interfaces
+// @Accept json
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Param If-Match header string false "ETag of the interface the update is based on"
+// @Param interface body models.HTTPInterface true "HTTP interface"
+// @Success 200 {object} models.HTTPInterface
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id} [put]
 func (h *HTTPInterfaceHandler) UpdateHTTPInterface(c *gin.Context) {
 	id := c.Param("id")
 	var httpInterface models.HTTPInterface
 	if err := c.ShouldBindJSON(&httpInterface); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if httpInterface.Auth != nil {
+		if err := httpInterface.Auth.Validate(); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := httpInterface.ValidateParamStyles(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Ensure ID matches
 	httpInterface.ID = id
 
+	if c.GetHeader("If-Match") != "" || httpInterface.Changelog != nil {
+		existing, err := h.repo.GetByID(c.Request.Context(), id)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+				return
+			}
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if preconditionFailed(c, existing.ID, existing.Version, existing.UpdatedAt) {
+			return
+		}
+		if !applyChangeNote(c, httpInterface.Changelog, existing.Changelog) {
+			return
+		}
+	}
+
 	if err := h.repo.Update(c.Request.Context(), &httpInterface); err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface not found"})
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
 		return
 	}
 
+	// Regenerate the tools built from this interface
+	if h.syncer != nil {
+		if _, err := h.syncer.SyncInterface(c.Request.Context(), id); err != nil {
+			slog.WarnContext(c.Request.Context(), "Failed to sync MCP servers with HTTP interface", "id", id, "error", err)
+		}
+	}
+
+	c.Header("ETag", resourceETag(httpInterface.Version, httpInterface.UpdatedAt))
 	c.JSON(http.StatusOK, httpInterface)
 }
 
 // DeleteHTTPInterface deletes an HTTP interface
+//
+// @Summary Delete an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id} [delete]
 func (h *HTTPInterfaceHandler) DeleteHTTPInterface(c *gin.Context) {
 	id := c.Param("id")
 	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface not found"})
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// GetHTTPInterfaceVersions returns all versions of an HTTP interface
+// GetHTTPInterfaceVersions returns the version history of an HTTP interface, from the oldest version
+//
+// @Summary List the versions of an HTTP interface with their change notes
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {array} models.VersionInfo
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/versions [get]
 func (h *HTTPInterfaceHandler) GetHTTPInterfaceVersions(c *gin.Context) {
 	id := c.Param("id")
 	versions, err := h.repo.GetVersions(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface not found"})
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -144,22 +322,33 @@ func (h *HTTPInterfaceHandler) GetHTTPInterfaceVersions(c *gin.Context) {
 }
 
 // GetHTTPInterfaceByVersion returns a specific version of an HTTP interface
+//
+// @Summary Get a version of an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Param version path int true "Version"
+// @Success 200 {object} models.HTTPInterface
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/versions/{version} [get]
 func (h *HTTPInterfaceHandler) GetHTTPInterfaceByVersion(c *gin.Context) {
 	id := c.Param("id")
 	version := c.Param("version")
 	versionInt := 0
 	if _, err := fmt.Sscanf(version, "%d", &versionInt); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version number"})
+		apierror.Respond(c, http.StatusBadRequest, "Invalid version number")
 		return
 	}
 
 	httpInterface, err := h.repo.GetByVersion(c.Request.Context(), id, versionInt)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface version not found"})
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface version not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -174,27 +363,39 @@ type CurlCommand struct {
 }
 
 // CreateFromCurl creates a new HTTP interface from a curl command
+//
+// @Summary Create an HTTP interface from a curl command
+// @Tags http-interfaces
+// @Accept json
+// @Produce json
+// @Param command body CurlCommand true "curl command"
+// @Success 201 {object} CurlImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/from-curl [post]
 func (h *HTTPInterfaceHandler) CreateFromCurl(c *gin.Context) {
 	var curlCmd CurlCommand
 	if err := c.ShouldBindJSON(&curlCmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Parse the curl command
 	httpInterface, err := parseCurlCommand(curlCmd.Command, curlCmd.Name, curlCmd.Description)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse curl command: " + err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, "Failed to parse curl command: "+err.Error())
 		return
 	}
 
 	// Persist the new interface
 	if err := h.repo.Create(c.Request.Context(), httpInterface); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
 		return
 	}
 
-	c.JSON(http.StatusCreated, httpInterface)
+	c.JSON(http.StatusCreated, CurlImportResponse{HTTPInterface: *httpInterface, Warnings: models.LintInterfaces([]models.HTTPInterface{*httpInterface})})
 }
 
 // parseCurlCommand parses a curl command and converts it to an HTTP interface
@@ -221,6 +422,7 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 	headerRegex := regexp.MustCompile(`-H\s+['"]([^'"]*)['"]`)
 	headerMatches := headerRegex.FindAllStringSubmatch(curlCommand, -1)
 	var headers []models.Header
+	var cookies []string
 
 	for _, match := range headerMatches {
 		if len(match) < 2 {
@@ -245,9 +447,15 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 			continue
 		}
 
+		// Cookies become cookie parameters
+		nameLower := strings.ToLower(name)
+		if nameLower == "cookie" {
+			cookies = append(cookies, value)
+			continue
+		}
+
 		// Set some common headers as required if they have values
 		isRequired := false
-		nameLower := strings.ToLower(name)
 		if value != "" && (nameLower == "content-type" || nameLower == "accept" || nameLower == "authorization") {
 			isRequired = true
 		}
@@ -267,6 +475,31 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 		})
 	}
 
+	// Extract cookies
+	cookieRegex := regexp.MustCompile(`(?:-b|--cookie)\s+['"]([^'"]*)['"]`)
+	for _, match := range cookieRegex.FindAllStringSubmatch(curlCommand, -1) {
+		cookies = append(cookies, match[1])
+	}
+	params := []models.Param{}
+	for _, cookie := range cookies {
+		for _, pair := range strings.Split(cookie, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || name == "" {
+				continue
+			}
+			param := models.Param{
+				Name:        name,
+				Description: fmt.Sprintf("The %s cookie", name),
+				In:          "cookie",
+				Type:        "string",
+			}
+			if schema, err := json.Marshal(map[string]string{"default": value}); err == nil {
+				param.Schema = string(schema)
+			}
+			params = append(params, param)
+		}
+	}
+
 	// Extract the request body
 	var requestBody *models.Body
 	// Use a regex pattern that works in Go's regexp package (no backreferences)
@@ -304,7 +537,7 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 		}
 
 		// Log the extracted data for debugging
-		fmt.Printf("Extracted request body data: %s\n", data)
+		slog.Debug("Extracted request body data", "data", data)
 
 		// If we have JSON content but the body isn't valid JSON, try to fix it
 		isJSON := strings.Contains(strings.ToLower(contentType), "json")
@@ -332,7 +565,7 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 		Method:      method,
 		Path:        url,
 		Headers:     headers,
-		Parameters:  []models.Param{},
+		Parameters:  params,
 		RequestBody: requestBody,
 		Responses:   []models.Response{},
 	}
@@ -340,18 +573,107 @@ func parseCurlCommand(curlCommand string, name string, description string) (*mod
 	return httpInterface, nil
 }
 
-// OpenAPIImport represents an OpenAPI spec to be converted to HTTP interfaces
+// findCollection returns the collection of the namespace with the name, nil if there is none or
+// collections are not available
+func (h *HTTPInterfaceHandler) findCollection(ctx context.Context, owner string, name string) (*models.Collection, error) {
+	if h.collections == nil {
+		return nil, nil
+	}
+
+	collections, err := h.collections.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range collections {
+		if collections[i].Name == name && namespace.OrDefault(collections[i].Namespace) == owner {
+			return &collections[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// saveCollection records the interfaces of an import as a collection, adding them to the
+// collection of an earlier import if there is one. It returns nil if collections are not available.
+func (h *HTTPInterfaceHandler) saveCollection(ctx context.Context, existing *models.Collection, name string, description string, interfaces []models.HTTPInterface) (*models.Collection, error) {
+	if h.collections == nil {
+		return nil, nil
+	}
+
+	if existing != nil {
+		changed := existing.Description != description
+		existing.Description = description
+		for _, httpInterface := range interfaces {
+			if !slices.Contains(existing.InterfaceIDs, httpInterface.ID) {
+				existing.InterfaceIDs = append(existing.InterfaceIDs, httpInterface.ID)
+				changed = true
+			}
+		}
+		if !changed {
+			return existing, nil
+		}
+		if err := h.collections.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	collection := &models.Collection{
+		Name:         name,
+		Description:  description,
+		InterfaceIDs: make([]string, 0, len(interfaces)),
+	}
+	for _, httpInterface := range interfaces {
+		collection.InterfaceIDs = append(collection.InterfaceIDs, httpInterface.ID)
+	}
+
+	if err := h.collections.Create(ctx, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// OpenAPIImport represents an OpenAPI spec to be converted to HTTP interfaces, with the filter
+// selecting the operations to import
 type OpenAPIImport struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
 	Spec        map[string]interface{} `json:"spec" binding:"required"`
+	models.OpenAPIFilter
+	DryRun bool `json:"dryRun"` // Only return the interfaces that would be saved
+}
+
+// OpenAPIFileImport holds the form fields of an OpenAPI file import next to the file
+type OpenAPIFileImport struct {
+	models.OpenAPIFilter
+	DryRun bool `form:"dryRun"` // Only return the interfaces that would be saved
 }
 
-// CreateFromOpenAPI creates new HTTP interfaces from an OpenAPI specification
+// CreateFromOpenAPI creates new HTTP interfaces from an OpenAPI specification. The operations
+// can be filtered by tag, path prefix and method; with dryRun, the interfaces are only returned.
+// Re-importing a spec updates the interfaces of its earlier import in place.
+//
+// @Summary Create HTTP interfaces from an OpenAPI specification
+// @Tags http-interfaces
+// @Accept json
+// @Produce json
+// @Param import body OpenAPIImport true "OpenAPI specification"
+// @Param dryRun query bool false "Only return the interfaces that would be saved"
+// @Success 200 {object} ImportResponse "Dry run, or no interface created"
+// @Success 201 {object} ImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/from-openapi [post]
 func (h *HTTPInterfaceHandler) CreateFromOpenAPI(c *gin.Context) {
 	var importReq OpenAPIImport
 	if err := c.ShouldBindJSON(&importReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -377,64 +699,340 @@ func (h *HTTPInterfaceHandler) CreateFromOpenAPI(c *gin.Context) {
 		}
 	}
 
+	h.importOpenAPI(c, name, description, importReq.Spec, &importReq.OpenAPIFilter, importReq.DryRun || dryRun, "OpenAPI spec")
+}
+
+// importOpenAPI saves the interfaces of the operations of spec selected by filter and the
+// collection grouping them, or on a dry run only lists them, and responds with them. Interfaces of
+// an earlier import of the spec are updated in place. source names the spec in the response message.
+func (h *HTTPInterfaceHandler) importOpenAPI(c *gin.Context, name string, description string, spec map[string]interface{}, filter *models.OpenAPIFilter, dryRun bool, source string) {
+	if err := filter.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Convert OpenAPI to HTTP interfaces
-	interfaces, err := models.CreateFromOpenAPI(name, description, importReq.Spec)
+	interfaces, err := models.CreateFromOpenAPI(name, description, spec, filter)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "Failed to parse OpenAPI spec: "+err.Error())
+		return
+	}
+
+	plan, err := h.planImport(c.Request.Context(), name, interfaces)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse OpenAPI spec: " + err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, ImportResponse{
+			Message:    fmt.Sprintf("Would import %d HTTP interfaces from %s", len(plan.interfaces), source),
+			DryRun:     true,
+			Interfaces: plan.interfaces,
+			Summary:    plan.summary,
+			Warnings:   append(models.LintOpenAPI(spec, filter), models.LintInterfaces(plan.interfaces)...),
+			Conflicts:  plan.conflicts,
+		})
+		return
+	}
+
+	// Fail before saving anything rather than halfway through
+	if len(plan.conflicts) > 0 {
+		apierror.RespondCode(c, http.StatusConflict, "name_taken", "HTTP interface names already taken: "+strings.Join(plan.conflicts, ", "))
 		return
 	}
 
 	// Save each interface
 	savedInterfaces := []models.HTTPInterface{}
-	for _, httpInterface := range interfaces {
-		if err := h.repo.Create(c.Request.Context(), &httpInterface); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save interfaces: " + err.Error()})
+	for i, httpInterface := range plan.interfaces {
+		switch plan.actions[i] {
+		case importCreate:
+			err = h.repo.Create(c.Request.Context(), &httpInterface)
+		case importUpdate:
+			err = h.repo.Update(c.Request.Context(), &httpInterface)
+		}
+		if err != nil {
+			apierror.RespondCode(c, createErrorStatus(err), errorCode(err), "Failed to save interfaces: "+err.Error())
 			return
 		}
 		savedInterfaces = append(savedInterfaces, httpInterface)
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message":    fmt.Sprintf("Successfully created %d HTTP interfaces from OpenAPI spec", len(savedInterfaces)),
-		"interfaces": savedInterfaces,
+	// Regenerate the tools built from the updated interfaces
+	if h.syncer != nil {
+		for i, httpInterface := range savedInterfaces {
+			if plan.actions[i] != importUpdate {
+				continue
+			}
+			if _, err := h.syncer.SyncInterface(c.Request.Context(), httpInterface.ID); err != nil {
+				slog.WarnContext(c.Request.Context(), "Failed to sync MCP servers with HTTP interface", "id", httpInterface.ID, "error", err)
+			}
+		}
+	}
+
+	collection, err := h.saveCollection(c.Request.Context(), plan.collection, name, description, savedInterfaces)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to save collection: "+err.Error())
+		return
+	}
+
+	status := http.StatusOK
+	if len(plan.summary.Created) > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, ImportResponse{
+		Message:    fmt.Sprintf("Successfully imported %d HTTP interfaces from %s", len(savedInterfaces), source),
+		Interfaces: savedInterfaces,
+		Summary:    plan.summary,
+		Collection: collection,
+		Warnings:   append(models.LintOpenAPI(spec, filter), models.LintInterfaces(savedInterfaces)...),
 	})
 }
 
+// importAction is what an import does with one of its interfaces
+type importAction int
+
+const (
+	importCreate importAction = iota
+	importUpdate
+	importUnchanged
+)
+
+// importPlan matches the interfaces of an OpenAPI import with the interfaces of the namespace the
+// request imports into
+type importPlan struct {
+	interfaces []models.HTTPInterface // Interfaces to save, with the IDs of those they update
+	actions    []importAction         // Action of each interface
+	summary    ImportSummary
+	collection *models.Collection // Collection of an earlier import of the same name, nil if none
+	conflicts  []string           // Names of the interfaces to save that are already taken
+}
+
+// planImport matches each imported interface with the interface of the same name in the
+// collection of an earlier import of the same name, the name coming from the operationId, or else
+// with an interface of the same method and path in that collection. Matched interfaces are
+// updated in place, keeping the settings an import does not carry such as their auth, unless they
+// are unchanged. Names held by other interfaces of the namespace are reported as conflicts.
+func (h *HTTPInterfaceHandler) planImport(ctx context.Context, name string, interfaces []models.HTTPInterface) (*importPlan, error) {
+	owner, _ := namespace.FromContext(ctx)
+	owner = namespace.OrDefault(owner)
+	all, err := h.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*models.HTTPInterface{}
+	byID := map[string]*models.HTTPInterface{}
+	for i := range all {
+		if namespace.OrDefault(all[i].Namespace) == owner {
+			byName[all[i].Name] = &all[i]
+			byID[all[i].ID] = &all[i]
+		}
+	}
+
+	collection, err := h.findCollection(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	members := []*models.HTTPInterface{}
+	if collection != nil {
+		for _, id := range collection.InterfaceIDs {
+			if member, ok := byID[id]; ok {
+				members = append(members, member)
+			}
+		}
+	}
+
+	// Match on names first so that an operation keeps its interface even if another operation
+	// moved to its path
+	matches := make([]*models.HTTPInterface, len(interfaces))
+	claimed := map[string]bool{}
+	for i, httpInterface := range interfaces {
+		for _, member := range members {
+			if member.Name == httpInterface.Name {
+				matches[i] = member
+				claimed[member.ID] = true
+				break
+			}
+		}
+	}
+	for i, httpInterface := range interfaces {
+		if matches[i] != nil {
+			continue
+		}
+		for _, member := range members {
+			if !claimed[member.ID] && member.Method == httpInterface.Method && member.Path == httpInterface.Path {
+				matches[i] = member
+				claimed[member.ID] = true
+				break
+			}
+		}
+	}
+
+	plan := &importPlan{
+		interfaces: make([]models.HTTPInterface, 0, len(interfaces)),
+		actions:    make([]importAction, 0, len(interfaces)),
+		summary:    ImportSummary{Created: []string{}, Updated: []string{}, Unchanged: []string{}},
+		collection: collection,
+		conflicts:  []string{},
+	}
+	for i, httpInterface := range interfaces {
+		current := matches[i]
+		// Interfaces outside the earlier import are never overwritten
+		if holder, ok := byName[httpInterface.Name]; ok && (current == nil || holder.ID != current.ID) {
+			plan.conflicts = append(plan.conflicts, httpInterface.Name)
+		}
+		if current == nil {
+			plan.interfaces = append(plan.interfaces, httpInterface)
+			plan.actions = append(plan.actions, importCreate)
+			plan.summary.Created = append(plan.summary.Created, httpInterface.Name)
+			continue
+		}
+
+		httpInterface.ID = current.ID
+		httpInterface.Namespace = current.Namespace
+		httpInterface.Auth = current.Auth
+		httpInterface.Sunset = current.Sunset
+		httpInterface.DeprecationMessage = current.DeprecationMessage
+		httpInterface.Archived = current.Archived
+		httpInterface.Version = current.Version
+		httpInterface.Changelog = current.Changelog
+		httpInterface.CreatedAt = current.CreatedAt
+		httpInterface.UpdatedAt = current.UpdatedAt
+		if gitops.Equivalent(httpInterface, *current) {
+			plan.interfaces = append(plan.interfaces, *current)
+			plan.actions = append(plan.actions, importUnchanged)
+			plan.summary.Unchanged = append(plan.summary.Unchanged, httpInterface.Name)
+			continue
+		}
+		plan.interfaces = append(plan.interfaces, httpInterface)
+		plan.actions = append(plan.actions, importUpdate)
+		plan.summary.Updated = append(plan.summary.Updated, httpInterface.Name)
+	}
+	return plan, nil
+}
+
+// CheckRequest selects how the upstream of an HTTP interface is probed
+type CheckRequest struct {
+	Environment string `json:"environment"`                               // Environment substituted in the URL, defaults to the X-MCP-Environment header
+	Method      string `json:"method" binding:"omitempty,oneof=HEAD GET"` // Request sent after connecting, none by default
+}
+
+// CheckHTTPInterface probes the upstream of an HTTP interface: DNS lookup, TCP connection,
+// TLS handshake and an optional HEAD or GET request without auth. A failed probe is
+// reported with status 200.
+//
+// @Summary Check the connectivity of an HTTP interface
+// @Tags http-interfaces
+// @Accept json
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Param check body CheckRequest false "Probe options"
+// @Success 200 {object} mcp.ConnectivityReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/check [post]
+func (h *HTTPInterfaceHandler) CheckHTTPInterface(c *gin.Context) {
+	if h.service == nil {
+		apierror.Respond(c, http.StatusNotImplemented, "Connectivity checks are not available")
+		return
+	}
+
+	var checkReq CheckRequest
+	if err := c.ShouldBindJSON(&checkReq); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpInterface, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if httpInterface.Archived {
+		apierror.Respond(c, http.StatusConflict, "HTTP interface is archived")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.CheckConnectivity(c.Request.Context(), httpInterface.Path, checkReq.Environment, checkReq.Method))
+}
+
 // ExportToOpenAPI exports an HTTP interface to OpenAPI format
+//
+// @Summary Export an HTTP interface to OpenAPI
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/openapi [get]
 func (h *HTTPInterfaceHandler) ExportToOpenAPI(c *gin.Context) {
 	id := c.Param("id")
-	fmt.Printf("Exporting OpenAPI for interface with ID: %s\n", id)
+	slog.DebugContext(c.Request.Context(), "Exporting OpenAPI for interface", "id", id)
 
 	httpInterface, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		fmt.Printf("Error getting HTTP interface: %v\n", err)
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("HTTP interface not found: %s", err.Error())})
+		slog.ErrorContext(c.Request.Context(), "Failed to get HTTP interface", "error", err)
+		apierror.Respond(c, http.StatusNotFound, fmt.Sprintf("HTTP interface not found: %s", err.Error()))
 		return
 	}
 
-	fmt.Printf("HTTP interface found: %+v\n", httpInterface)
-	fmt.Printf("Converting to OpenAPI...\n")
+	slog.DebugContext(c.Request.Context(), "HTTP interface found", "httpInterface", httpInterface)
+	slog.DebugContext(c.Request.Context(), "Converting to OpenAPI...")
 
 	openAPISpec := httpInterface.ConvertToOpenAPI()
-	fmt.Printf("OpenAPI conversion result: %+v\n", openAPISpec)
+	slog.DebugContext(c.Request.Context(), "OpenAPI conversion result", "openAPISpec", openAPISpec)
 
 	c.JSON(http.StatusOK, openAPISpec)
-	fmt.Printf("Response sent to client\n")
+	slog.DebugContext(c.Request.Context(), "Response sent to client")
 }
 
-// CreateFromOpenAPIFile handles OpenAPI file uploads and creates HTTP interfaces
+// CreateFromOpenAPIFile handles OpenAPI file uploads and creates HTTP interfaces, filtering the
+// operations and making a dry run as CreateFromOpenAPI with form fields
+//
+// @Summary Create HTTP interfaces from an OpenAPI file
+// @Tags http-interfaces
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "OpenAPI file, JSON or YAML"
+// @Param tags formData []string false "Import the operations with one of the tags" collectionFormat(multi)
+// @Param excludeTags formData []string false "Skip the operations with one of the tags" collectionFormat(multi)
+// @Param pathPrefixes formData []string false "Import the paths under one of the prefixes" collectionFormat(multi)
+// @Param excludePathPrefixes formData []string false "Skip the paths under one of the prefixes" collectionFormat(multi)
+// @Param methods formData []string false "Import the operations of one of the methods" collectionFormat(multi)
+// @Param excludeMethods formData []string false "Skip the operations of one of the methods" collectionFormat(multi)
+// @Param dryRun formData bool false "Only return the interfaces that would be saved"
+// @Success 200 {object} ImportResponse "Dry run, or no interface created"
+// @Success 201 {object} ImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/from-openapi-file [post]
 func (h *HTTPInterfaceHandler) CreateFromOpenAPIFile(c *gin.Context) {
 	// Get the uploaded file
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded: " + err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, "No file uploaded: "+err.Error())
+		return
+	}
+	var importReq OpenAPIFileImport
+	if err := c.ShouldBind(&importReq); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Open the file
 	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open uploaded file: " + err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to open uploaded file: "+err.Error())
 		return
 	}
 	defer src.Close()
@@ -442,7 +1040,7 @@ func (h *HTTPInterfaceHandler) CreateFromOpenAPIFile(c *gin.Context) {
 	// Read file content
 	fileBytes, err := io.ReadAll(src)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file: " + err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to read file: "+err.Error())
 		return
 	}
 
@@ -456,25 +1054,25 @@ func (h *HTTPInterfaceHandler) CreateFromOpenAPIFile(c *gin.Context) {
 		// Parse YAML
 		var yamlData interface{}
 		if err := yaml.Unmarshal(fileBytes, &yamlData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid YAML: " + err.Error()})
+			apierror.Respond(c, http.StatusBadRequest, "Invalid YAML: "+err.Error())
 			return
 		}
 
 		// Convert YAML to JSON format
 		jsonBytes, err := json.Marshal(yamlData)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to convert YAML to JSON: " + err.Error()})
+			apierror.Respond(c, http.StatusBadRequest, "Failed to convert YAML to JSON: "+err.Error())
 			return
 		}
 
 		if err := json.Unmarshal(jsonBytes, &openAPISpec); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid OpenAPI format: " + err.Error()})
+			apierror.Respond(c, http.StatusBadRequest, "Invalid OpenAPI format: "+err.Error())
 			return
 		}
 	} else {
 		// Parse JSON directly
 		if err := json.Unmarshal(fileBytes, &openAPISpec); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+			apierror.Respond(c, http.StatusBadRequest, "Invalid JSON: "+err.Error())
 			return
 		}
 	}
@@ -492,25 +1090,5 @@ func (h *HTTPInterfaceHandler) CreateFromOpenAPIFile(c *gin.Context) {
 		}
 	}
 
-	// Convert OpenAPI to HTTP interfaces
-	interfaces, err := models.CreateFromOpenAPI(name, description, openAPISpec)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse OpenAPI spec: " + err.Error()})
-		return
-	}
-
-	// Save each interface
-	savedInterfaces := []models.HTTPInterface{}
-	for _, httpInterface := range interfaces {
-		if err := h.repo.Create(c.Request.Context(), &httpInterface); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save interfaces: " + err.Error()})
-			return
-		}
-		savedInterfaces = append(savedInterfaces, httpInterface)
-	}
-
-	c.JSON(http.StatusCreated, gin.H{
-		"message":    fmt.Sprintf("Successfully created %d HTTP interfaces from OpenAPI file", len(savedInterfaces)),
-		"interfaces": savedInterfaces,
-	})
+	h.importOpenAPI(c, name, description, openAPISpec, &importReq.OpenAPIFilter, importReq.DryRun, "OpenAPI file")
 }
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InterfaceExamples holds ready-to-run examples of calling an HTTP interface, with sample values
+// taken from the examples, defaults and types of its params and body
+type InterfaceExamples struct {
+	Curl          string                 `json:"curl"`          // curl command calling the upstream directly
+	HTTPie        string                 `json:"httpie"`        // HTTPie command calling the upstream directly
+	MCPInvocation map[string]interface{} `json:"mcpInvocation"` // JSON-RPC tools/call request of the tool generated from the interface
+}
+
+// exampleHeader is a header of an example request, kept in the order of the definition
+type exampleHeader struct {
+	name  string
+	value string
+}
+
+// exampleRequest is the upstream request the examples send
+type exampleRequest struct {
+	method  string
+	url     string
+	headers []exampleHeader
+	body    string
+}
+
+// GetHTTPInterfaceExamples returns a curl command, an HTTPie command and an MCP tools/call
+// payload for an HTTP interface. Credentials of its auth profile appear as placeholders.
+//
+// @Summary Get examples of calling an HTTP interface
+// @Tags http-interfaces
+// @Produce json
+// @Param id path string true "HTTP interface ID"
+// @Success 200 {object} InterfaceExamples
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/http-interfaces/{id}/examples [get]
+func (h *HTTPInterfaceHandler) GetHTTPInterfaceExamples(c *gin.Context) {
+	httpInterface, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "HTTP interface not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	args := httpInterface.ExampleArguments()
+	req, err := newExampleRequest(httpInterface, args)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "Failed to generate examples: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, InterfaceExamples{
+		Curl:   req.curl(),
+		HTTPie: req.httpie(),
+		MCPInvocation: map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params": map[string]interface{}{
+				"name":      httpInterface.Name,
+				"arguments": args,
+			},
+		},
+	})
+}
+
+// newExampleRequest builds the upstream request of an interface from sample arguments, with
+// the array styles and body content type of the interface
+func newExampleRequest(httpInterface *models.HTTPInterface, args map[string]interface{}) (*exampleRequest, error) {
+	req := &exampleRequest{method: httpInterface.Method}
+
+	target := httpInterface.Path
+	query := []string{}
+	for i := range httpInterface.Parameters {
+		param := &httpInterface.Parameters[i]
+		value, ok := args[param.Name]
+		if !ok {
+			continue
+		}
+		style := param.Serialization()
+		switch param.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", url.PathEscape(strings.Join(exampleItems(value), style.Delimiter())))
+		case "query":
+			items := exampleItems(value)
+			if !style.Repeated() {
+				items = []string{strings.Join(items, style.Delimiter())}
+			}
+			for _, item := range items {
+				query = append(query, url.QueryEscape(param.Name)+"="+url.QueryEscape(item))
+			}
+		}
+	}
+
+	if auth := httpInterface.Auth; auth != nil {
+		switch auth.Type {
+		case models.AuthBasic:
+			req.headers = append(req.headers, exampleHeader{"Authorization", "Basic <base64 of " + auth.Username + ":password>"})
+		case models.AuthBearer, models.AuthOAuth2:
+			req.headers = append(req.headers, exampleHeader{"Authorization", "Bearer <token>"})
+		case models.AuthAPIKeyHeader:
+			req.headers = append(req.headers, exampleHeader{auth.Name, "<api-key>"})
+		case models.AuthAPIKeyQuery:
+			query = append(query, url.QueryEscape(auth.Name)+"=<api-key>")
+		}
+	}
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+		target += separator + strings.Join(query, "&")
+	}
+	req.url = target
+
+	if headers, ok := args["headers"].(map[string]interface{}); ok {
+		for _, header := range httpInterface.Headers {
+			req.headers = append(req.headers, exampleHeader{header.Name, exampleString(headers[header.Name])})
+		}
+		for _, param := range httpInterface.Parameters {
+			if param.In == "header" {
+				req.headers = append(req.headers, exampleHeader{param.Name, exampleString(headers[param.Name])})
+			}
+		}
+	}
+	if cookies, ok := args["cookies"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(cookies))
+		for name := range cookies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		pairs := make([]string, 0, len(names))
+		for _, name := range names {
+			pairs = append(pairs, name+"="+exampleString(cookies[name]))
+		}
+		req.headers = append(req.headers, exampleHeader{"Cookie", strings.Join(pairs, "; ")})
+	}
+
+	// Bodies of GET requests are not sent by the tools either
+	if body, ok := args["body"]; ok && httpInterface.Method != http.MethodGet {
+		contentType := httpInterface.RequestContentType()
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		data, err := mcp.EncodeBody(contentType, body)
+		if err != nil {
+			return nil, err
+		}
+		req.headers = append(req.headers, exampleHeader{"Content-Type", contentType})
+		req.body = string(data)
+	}
+	return req, nil
+}
+
+// curl returns the request as a curl command
+func (r *exampleRequest) curl() string {
+	parts := []string{"curl -X " + r.method + " " + shellQuote(r.url)}
+	for _, header := range r.headers {
+		parts = append(parts, "-H "+shellQuote(header.name+": "+header.value))
+	}
+	if r.body != "" {
+		parts = append(parts, "--data-raw "+shellQuote(r.body))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// httpie returns the request as an HTTPie command
+func (r *exampleRequest) httpie() string {
+	parts := []string{"http " + r.method + " " + shellQuote(r.url)}
+	for _, header := range r.headers {
+		parts = append(parts, shellQuote(header.name+":"+header.value))
+	}
+	if r.body != "" {
+		parts = append(parts, "--raw "+shellQuote(r.body))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// exampleItems returns the items of an array value, or the value as a single item
+func exampleItems(value interface{}) []string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return []string{exampleString(value)}
+	}
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		items = append(items, exampleString(item))
+	}
+	return items
+}
+
+// exampleString formats a sample value for a URL or header, objects and arrays as JSON
+func exampleString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// shellQuote quotes a string for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+)
+
+const (
+	defaultInvocationLimit = 50
+	maxInvocationLimit     = 500
+
+	// Bounds of the invocations of an export
+	defaultExportLimit = 1000
+	maxExportLimit     = 10000
+)
+
+// InvocationHandler handles API requests for the tool invocation history
+type InvocationHandler struct {
+	repo    repository.InvocationRepository
+	mcpRepo repository.MCPServerRepository
+}
+
+// NewInvocationHandler creates a new invocation handler
+func NewInvocationHandler(repo repository.InvocationRepository, mcpRepo repository.MCPServerRepository) *InvocationHandler {
+	return &InvocationHandler{
+		repo:    repo,
+		mcpRepo: mcpRepo,
+	}
+}
+
+// RegisterRoutes registers the invocation API routes
+func (h *InvocationHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/api/mcp-servers/:id/invocations", h.GetMCPServerInvocations)
+	router.GET("/api/mcp-servers/:id/invocations/export", h.ExportMCPServerInvocations)
+}
+
+// GetMCPServerInvocations returns the invocations of an MCP Server, newest first.
+// Supported query parameters: tool, status (success|error), since and until (RFC 3339),
+// limit and offset.
+//
+// @Summary List the invocations of an MCP server
+// @Tags invocations
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool query string false "Tool name"
+// @Param status query string false "success or error"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} InvocationListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/invocations [get]
+func (h *InvocationHandler) GetMCPServerInvocations(c *gin.Context) {
+	id := c.Param("id")
+
+	// Check MCP Server exists
+	if _, err := h.mcpRepo.GetByID(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter, err := parseInvocationFilter(c, defaultInvocationLimit, maxInvocationLimit)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.ServerID = id
+
+	invocations, total, err := h.repo.List(c.Request.Context(), filter)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invocations": invocations,
+		"total":       total,
+		"limit":       filter.Limit,
+		"offset":      filter.Offset,
+	})
+}
+
+// ExportMCPServerInvocations returns the upstream calls recorded with the invocations of an MCP
+// Server as a HAR file, oldest first, to analyze them in HTTP tooling. Supports the filters of
+// the invocation list; the limit defaults to 1000 invocations.
+//
+// @Summary Export the upstream calls of an MCP server
+// @Tags invocations
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param format query string false "Export format, only har" default(har)
+// @Param tool query string false "Tool name"
+// @Param status query string false "success or error"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Param limit query int false "Maximum number of invocations"
+// @Param offset query int false "Number of newest invocations to skip"
+// @Success 200 {object} HAR
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/invocations/export [get]
+func (h *InvocationHandler) ExportMCPServerInvocations(c *gin.Context) {
+	id := c.Param("id")
+
+	if format := c.DefaultQuery("format", "har"); format != "har" {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("invalid format '%s': must be har", format))
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter, err := parseInvocationFilter(c, defaultExportLimit, maxExportLimit)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.ServerID = id
+
+	invocations, _, err := h.repo.List(c.Request.Context(), filter)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", server.Name+"-invocations.har"))
+	c.JSON(http.StatusOK, newHAR(invocations))
+}
+
+// parseInvocationFilter reads the filter and pagination query parameters, the limit defaulting to
+// defaultLimit and bounded by maxLimit
+func parseInvocationFilter(c *gin.Context, defaultLimit, maxLimit int) (repository.InvocationFilter, error) {
+	filter := repository.InvocationFilter{
+		Tool:   c.Query("tool"),
+		Status: c.Query("status"),
+		Limit:  defaultLimit,
+	}
+
+	if filter.Status != "" && filter.Status != "success" && filter.Status != "error" {
+		return filter, fmt.Errorf("invalid status '%s': must be success or error", filter.Status)
+	}
+
+	for name, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s '%s': must be an RFC 3339 timestamp", name, value)
+			}
+			*target = parsed
+		}
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return filter, fmt.Errorf("invalid limit '%s': must be between 1 and %d", value, maxLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return filter, fmt.Errorf("invalid offset '%s': must be a non-negative integer", value)
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
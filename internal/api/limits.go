@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/quota"
+	"github.com/wangfeng/mcp-gateway2/pkg/ratelimit"
+)
+
+// RateLimitState is the rate limit of the tool calls of a caller, as reported by the
+// X-RateLimit headers of tool responses
+type RateLimitState struct {
+	Limit        int `json:"limit"`        // Calls allowed in a burst
+	Remaining    int `json:"remaining"`    // Calls allowed right now
+	ResetSeconds int `json:"resetSeconds"` // Seconds until the limit is fully restored
+}
+
+// CallerLimits holds the rate limit and the quotas that apply to the tool calls of the caller
+type CallerLimits struct {
+	Caller    string              `json:"caller"`              // Client address the rate limit is kept for
+	RateLimit *RateLimitState     `json:"rateLimit,omitempty"` // Unset if rate limiting is disabled
+	APIKey    *models.APIKeyUsage `json:"apiKey,omitempty"`    // Usage of the API key sent in X-API-Key this month, unset without a key
+	Tenant    *models.TenantUsage `json:"tenant"`              // Usage of the namespace of the request today
+}
+
+// LimitsHandler handles API requests of clients for the limits of their own tool calls
+type LimitsHandler struct {
+	limiter *ratelimit.Limiter
+	keys    *quota.KeyTracker
+	tenants *quota.Tracker
+}
+
+// NewLimitsHandler creates a new limits handler
+func NewLimitsHandler(limiter *ratelimit.Limiter, keys *quota.KeyTracker, tenants *quota.Tracker) *LimitsHandler {
+	return &LimitsHandler{
+		limiter: limiter,
+		keys:    keys,
+		tenants: tenants,
+	}
+}
+
+// RegisterRoutes registers the limits routes
+func (h *LimitsHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/api/limits/self", h.GetOwnLimits)
+}
+
+// GetOwnLimits returns the rate limit of the caller, the quota left to the API key sent in
+// X-API-Key and the quota left to the namespace of the request, without counting a call
+//
+// @Summary Get the rate limit and quotas of the caller
+// @Tags limits
+// @Produce json
+// @Param X-API-Key header string false "API key of the client"
+// @Success 200 {object} CallerLimits
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/limits/self [get]
+func (h *LimitsHandler) GetOwnLimits(c *gin.Context) {
+	ctx := c.Request.Context()
+	limits := CallerLimits{Caller: mcp.Caller(ctx)}
+
+	if state := h.limiter.Peek(limits.Caller); state.Limit > 0 {
+		limits.RateLimit = &RateLimitState{
+			Limit:        state.Limit,
+			Remaining:    state.Remaining,
+			ResetSeconds: state.ResetSeconds(),
+		}
+	}
+
+	if key := mcp.APIKeyOf(ctx); key != nil {
+		usage, err := h.keys.Usage(ctx, key, "")
+		if err != nil {
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		limits.APIKey = usage
+	}
+
+	tenant, _ := namespace.FromContext(ctx)
+	usage, err := h.tenants.Usage(ctx, namespace.OrDefault(tenant))
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	limits.Tenant = usage
+
+	c.JSON(http.StatusOK, limits)
+}
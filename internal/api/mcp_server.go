@@ -18,6 +18,7 @@ package api
 // }
 //
 // The "headers" section contains HTTP headers to be sent with the request.
+// The optional "cookies" section contains cookies to be sent with the request.
 // The "body" section contains the actual parameters for the tool.
 //
 // For backward compatibility, if the request is sent with just a JSON object without
@@ -26,15 +27,25 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/llm"
 	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/router"
 )
 
 // Create a new MCPServerValidator interface for validation logic
@@ -52,21 +63,24 @@ func NewMCPServerValidator(repo repository.MCPServerRepository) MCPServerValidat
 	return &MCPServerValidatorImpl{repo: repo}
 }
 
-// ValidateName checks if the name is already taken by another server
+// ValidateName checks that the name of a new or renamed server is a slug not taken by another server
 func (v *MCPServerValidatorImpl) ValidateName(ctx context.Context, name string, excludeID string) error {
 	if name == "" {
 		return fmt.Errorf("name cannot be empty")
 	}
+	if err := models.ValidateServerName(name); err != nil {
+		return err
+	}
 
-	servers, err := v.repo.GetAll(ctx)
-	if err != nil {
+	server, err := v.repo.GetByName(ctx, name)
+	if err == repository.ErrNotFound {
+		return nil
+	} else if err != nil {
 		return err
 	}
 
-	for _, server := range servers {
-		if server.Name == name && server.ID != excludeID {
-			return fmt.Errorf("MCP server with name '%s' already exists", name)
-		}
+	if server.ID != excludeID {
+		return repository.NameTakenError("MCP server", server.Namespace, name)
 	}
 
 	return nil
@@ -78,6 +92,19 @@ type MCPServerHandler struct {
 	httpRepo   repository.HTTPInterfaceRepository
 	mcpService *mcp.MCPService
 	validator  MCPServerValidator
+	syncer     *mcp.ServerSyncer
+	// Collections servers can be created from, nil if collections are not available
+	collections repository.CollectionRepository
+	// Model generating tool descriptions, nil if the integration is not available
+	llm *llm.Client
+	// History of the invocations that can be replayed, nil if it is not available
+	invocations repository.InvocationRepository
+	// Pending changes of active servers, nil if approval is not available
+	revisions repository.RevisionRepository
+	// Whether changes of active servers need approval, and the token of the approvers
+	approval func() (bool, string)
+	// Former names of renamed servers, nil if they are not kept
+	aliases repository.ServerAliasRepository
 }
 
 // NewMCPServerHandler creates a new MCP server handler
@@ -87,9 +114,25 @@ func NewMCPServerHandler(mcpRepo repository.MCPServerRepository, httpRepo reposi
 		httpRepo:   httpRepo,
 		mcpService: mcpService,
 		validator:  NewMCPServerValidator(mcpRepo),
+		syncer:     mcp.NewServerSyncer(mcpRepo, httpRepo, mcpService),
 	}
 }
 
+// SetCollectionRepository sets the collections servers can be created from
+func (h *MCPServerHandler) SetCollectionRepository(collections repository.CollectionRepository) {
+	h.collections = collections
+}
+
+// SetLLMClient sets the model generating tool descriptions
+func (h *MCPServerHandler) SetLLMClient(client *llm.Client) {
+	h.llm = client
+}
+
+// SetInvocationRepository sets the history of the invocations that can be replayed
+func (h *MCPServerHandler) SetInvocationRepository(invocations repository.InvocationRepository) {
+	h.invocations = invocations
+}
+
 // RegisterRoutes registers the routes for MCP servers
 func (h *MCPServerHandler) RegisterRoutes(router *gin.Engine) {
 	mcpGroup := router.Group("/api/mcp-servers")
@@ -103,7 +146,25 @@ func (h *MCPServerHandler) RegisterRoutes(router *gin.Engine) {
 	mcpGroup.POST("/:id/register", h.RegisterMCPServer)
 	mcpGroup.POST("/:id/activate", h.ActivateMCPServer)
 	mcpGroup.POST("/:id/deactivate", h.DeactivateMCPServer)
+	mcpGroup.POST("/:id/archive", h.ArchiveMCPServer)
+	mcpGroup.POST("/:id/unarchive", h.UnarchiveMCPServer)
+	mcpGroup.GET("/:id/schedule", h.GetMCPServerSchedule)
+	mcpGroup.PUT("/:id/schedule", h.SetMCPServerSchedule)
+	mcpGroup.DELETE("/:id/schedule", h.DeleteMCPServerSchedule)
+	mcpGroup.POST("/:id/sync", h.SyncMCPServer)
+	mcpGroup.POST("/:id/clone", h.CloneMCPServer)
 	mcpGroup.POST("/:id/tools/:tool", h.InvokeTool)
+	mcpGroup.PATCH("/:id/tools/:tool", h.UpdateTool)
+	mcpGroup.POST("/:id/tools/:tool/enable", h.EnableTool)
+	mcpGroup.POST("/:id/tools/:tool/disable", h.DisableTool)
+	mcpGroup.GET("/:id/tools/:tool/policy", h.GetToolPolicy)
+	mcpGroup.POST("/:id/chained-tools", h.CreateChainedTool)
+	mcpGroup.POST("/:id/websocket-tools", h.CreateWebSocketTool)
+	mcpGroup.POST("/:id/tools/:tool/test", h.TestTool)
+	mcpGroup.POST("/:id/tools/:tool/render", h.RenderTool)
+	mcpGroup.POST("/:id/verify", h.VerifyMCPServer)
+	mcpGroup.POST("/:id/enrich-descriptions", h.EnrichDescriptions)
+	mcpGroup.POST("/:id/enrich-descriptions/accept", h.AcceptDescriptions)
 	mcpGroup.GET("/:id/http-interfaces", h.GetMCPServerHTTPInterfaces)
 	mcpGroup.POST("/validate-name", h.ValidateMCPServerName)
 
@@ -111,38 +172,83 @@ func (h *MCPServerHandler) RegisterRoutes(router *gin.Engine) {
 	mcpGroup.GET("/:id/metadata", h.GetMCPServerMetadata)
 	mcpGroup.GET("/:id/usage-guide", h.GetMCPServerUsageGuide)
 	mcpGroup.GET("/:id/client-examples", h.GetMCPServerClientExamples)
+	mcpGroup.GET("/:id/client-config", h.GetMCPServerClientConfig)
 
 	// Add MCP protocol compliant endpoints
 	mcpProtoGroup := router.Group("/api/mcp-server/:name")
 	mcpProtoGroup.GET("/tools", h.GetMCPServerTools)
+	mcpProtoGroup.GET("/openai-tools", h.GetMCPServerOpenAITools)
+	mcpProtoGroup.GET("/anthropic-tools", h.GetMCPServerAnthropicTools)
 	mcpProtoGroup.GET("/resources", h.GetMCPServerResources)
 	mcpProtoGroup.GET("/prompts", h.GetMCPServerPrompts)
 
 	// Add dynamic routing for tools invocation through MCP protocol
 	mcpProtoGroup.POST("/tools/:tool", h.InvokeToolByName)
+
+	router.POST("/api/invocations/:id/replay", h.ReplayInvocation)
+
+	if h.aliases != nil {
+		mcpGroup.GET("/:id/aliases", h.GetMCPServerAliases)
+		mcpGroup.DELETE("/:id/aliases/:name", h.DeleteMCPServerAlias)
+	}
+
+	if h.revisions != nil {
+		mcpGroup.GET("/:id/revisions", h.GetMCPServerRevisions)
+		revisionGroup := router.Group("/api/revisions")
+		revisionGroup.GET("", h.GetRevisions)
+		revisionGroup.GET("/:id", h.GetRevision)
+		revisionGroup.POST("/:id/approve", h.ApproveRevision)
+		revisionGroup.POST("/:id/reject", h.RejectRevision)
+	}
 }
 
-// GetAllMCPServers returns all MCP servers
+// GetAllMCPServers returns all MCP servers, without the archived ones unless requested
+//
+// @Summary List MCP servers
+// @Tags mcp-servers
+// @Produce json
+// @Param includeArchived query bool false "Include archived servers"
+// @Success 200 {array} models.MCPServer
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers [get]
 func (h *MCPServerHandler) GetAllMCPServers(c *gin.Context) {
 	servers, err := h.mcpRepo.GetAll(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if !includeArchived(c) {
+		servers = slices.DeleteFunc(servers, func(server models.MCPServer) bool { return server.Status == "archived" })
+	}
+
 	c.JSON(http.StatusOK, servers)
 }
 
-// GetMCPServer returns a single MCP server
+// GetMCPServer returns a single MCP server, or 304 Not Modified when If-None-Match lists its ETag
+//
+// @Summary Get an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param If-None-Match header string false "ETag of the cached server"
+// @Success 200 {object} models.MCPServer
+// @Success 304 "Not modified"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id} [get]
 func (h *MCPServerHandler) GetMCPServer(c *gin.Context) {
 	id := c.Param("id")
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if notModified(c, resourceETag(server.Version, server.UpdatedAt)) {
 		return
 	}
 
@@ -151,9 +257,42 @@ func (h *MCPServerHandler) GetMCPServer(c *gin.Context) {
 
 // CreateMCPServerRequest is the request for creating a new MCP Server
 type CreateMCPServerRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	Description string   `json:"description"`
-	HTTPIDs     []string `json:"httpIds" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// Guidance for the LLMs using the tools, sent in the MCP initialize result
+	Instructions string   `json:"instructions"`
+	HTTPIDs      []string `json:"httpIds" binding:"required_without_all=CollectionID External Sources"`
+	// Collection whose HTTP interfaces are added after those of httpIds
+	CollectionID string   `json:"collectionId"`
+	Plugins      []string `json:"plugins"` // WASM file IDs applied to every tool
+	// Environment used when the invocation selects none
+	DefaultEnvironment string `json:"defaultEnvironment"`
+	// MCP servers whose tools are listed and proxied after the tools of the HTTP interfaces
+	External []models.ExternalServer `json:"external" binding:"dive"`
+	// MCP servers whose tools a virtual server includes, instead of interfaces and external servers
+	Sources            []models.ServerSource `json:"sources" binding:"dive"`
+	ConflictResolution string                `json:"conflictResolution" binding:"omitempty,oneof=error first last"`
+	// Rules hiding sensitive data of the tool results, applied after the global rules
+	Redactions []models.RedactionRule `json:"redactions"`
+	// Default headers of the tools and the client headers forwarded upstream
+	Headers *models.HeaderPolicy `json:"headers"`
+	// Whether the Authorization header of callers is forwarded, replaced or stripped
+	AuthPassthrough *models.AuthPassthrough `json:"authPassthrough"`
+	// Client addresses allowed to invoke the tools
+	Network *models.NetworkRestriction `json:"network"`
+	// Reject calls with params, headers or body fields the tools do not define
+	Strict bool `json:"strict"`
+	// Timeout, retries, rate limit and hosts of the tools, over the policy of the namespace
+	Policy *models.CallPolicy `json:"policy"`
+	// Note of the first version, over the X-Change-Author and X-Change-Message headers
+	Changelog *models.ChangeNote `json:"changelog"`
+}
+
+// CloneMCPServerRequest is the request for cloning an MCP server
+type CloneMCPServerRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Environment of the copy, defaults to the environment of the source server
+	DefaultEnvironment string `json:"defaultEnvironment"`
 }
 
 // ValidateNameRequest is the request for validating a MCP server name
@@ -163,33 +302,89 @@ type ValidateNameRequest struct {
 }
 
 // ValidateMCPServerName validates if a name is available for use
+//
+// @Summary Check that an MCP server name is available
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param request body ValidateNameRequest true "Name"
+// @Success 200 {object} ValidateNameResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/mcp-servers/validate-name [post]
 func (h *MCPServerHandler) ValidateMCPServerName(c *gin.Context) {
 	var req ValidateNameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err := h.validator.ValidateName(c.Request.Context(), req.Name, req.ExcludeID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "valid": false})
+		apierror.RespondDetails(c, http.StatusBadRequest, errorCode(err), err.Error(), gin.H{"valid": false})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"valid": true})
 }
 
-// CreateMCPServer creates a new MCP Server from HTTP interfaces
+// CreateMCPServer creates a new MCP Server from HTTP interfaces and/or a collection
+//
+// @Summary Create an MCP server from HTTP interfaces
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param request body CreateMCPServerRequest true "MCP server"
+// @Success 201 {object} models.MCPServer
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/mcp-servers [post]
 func (h *MCPServerHandler) CreateMCPServer(c *gin.Context) {
 	var req CreateMCPServerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Validate server name uniqueness
 	if err := h.validator.ValidateName(c.Request.Context(), req.Name, ""); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, nameErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	// A virtual server only includes the tools of its sources
+	if len(req.Sources) > 0 && (len(req.HTTPIDs) > 0 || req.CollectionID != "" || len(req.External) > 0) {
+		apierror.Respond(c, http.StatusBadRequest, "sources cannot be combined with httpIds, collectionId or external")
+		return
+	}
+	if err := models.ValidateSources(req.Sources, req.ConflictResolution); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.ValidateRedactions(req.Redactions); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Headers.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.AuthPassthrough.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Network.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := req.Policy.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "policy: "+err.Error())
+		return
+	}
+	if !applyChangeNote(c, req.Changelog, nil) {
 		return
 	}
 
@@ -199,33 +394,124 @@ func (h *MCPServerHandler) CreateMCPServer(c *gin.Context) {
 		httpInterface, err := h.httpRepo.GetByID(c.Request.Context(), id)
 		if err != nil {
 			if err == repository.ErrNotFound {
-				c.JSON(http.StatusNotFound, gin.H{"error": "HTTP interface not found: " + id})
+				apierror.Respond(c, http.StatusNotFound, "HTTP interface not found: "+id)
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if httpInterface.Archived {
+			apierror.Respond(c, http.StatusConflict, "HTTP interface is archived: "+id)
 			return
 		}
 		httpInterfaces = append(httpInterfaces, *httpInterface)
 	}
 
+	// Add the HTTP interfaces of the collection
+	if req.CollectionID != "" {
+		if h.collections == nil {
+			apierror.Respond(c, http.StatusBadRequest, "Collections are not available")
+			return
+		}
+		collection, err := h.collections.GetByID(c.Request.Context(), req.CollectionID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusNotFound, "Collection not found: "+req.CollectionID)
+				return
+			}
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		interfaces, err := collectionInterfaces(c.Request.Context(), h.httpRepo, collection)
+		if err != nil {
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		for _, httpInterface := range interfaces {
+			if !httpInterface.Archived && !hasInterface(httpInterfaces, httpInterface.ID) {
+				httpInterfaces = append(httpInterfaces, httpInterface)
+			}
+		}
+	}
+
 	// Create MCP Server
 	mcpServer := models.NewMCPServerFromHTTPInterfaces(req.Name, req.Description, httpInterfaces)
+	mcpServer.Instructions = req.Instructions
+	mcpServer.Plugins = req.Plugins
+	mcpServer.DefaultEnvironment = req.DefaultEnvironment
+	mcpServer.Redactions = req.Redactions
+	mcpServer.Headers = req.Headers
+	mcpServer.AuthPassthrough = req.AuthPassthrough
+	mcpServer.Network = req.Network
+	mcpServer.Strict = req.Strict
+	mcpServer.Policy = req.Policy
+
+	// Add the tools of the external servers
+	if len(req.External) > 0 {
+		if err := models.ValidateExternalServers(req.External); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		mcpServer.External = req.External
+		for _, external := range req.External {
+			tools, err := h.mcpService.ListExternalTools(c.Request.Context(), external)
+			if err != nil {
+				apierror.RespondCode(c, externalErrorStatus(err), errorCode(err), "Failed to list the tools of external server "+external.Name+": "+err.Error())
+				return
+			}
+			for _, tool := range tools {
+				if hasTool(mcpServer, tool.Name) {
+					apierror.Respond(c, http.StatusBadRequest, "Duplicate tool "+tool.Name+", set a prefix for external server "+external.Name)
+					return
+				}
+				mcpServer.Tools = append(mcpServer.Tools, tool)
+				mcpServer.AllowTools = append(mcpServer.AllowTools, tool.Name)
+			}
+		}
+	}
+
+	// Compose a virtual server from the tools of its sources
+	if len(req.Sources) > 0 {
+		mcpServer.Sources = req.Sources
+		mcpServer.ConflictResolution = req.ConflictResolution
+		if err := h.syncer.ComposeServer(c.Request.Context(), mcpServer); err != nil {
+			apierror.RespondCode(c, sourceErrorStatus(err), errorCode(err), err.Error())
+			return
+		}
+	}
 
 	// Persist in repository
 	if err := h.mcpRepo.Create(c.Request.Context(), mcpServer); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
 		return
 	}
 
 	c.JSON(http.StatusCreated, mcpServer)
 }
 
-// UpdateMCPServer updates an MCP Server
+// UpdateMCPServer updates an MCP Server. With If-Match, the update is rejected with 412 when the
+// server was changed since the client read it.
+//
+// @Summary Update an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param If-Match header string false "ETag of the server the update is based on"
+// @Param server body models.MCPServer true "MCP server"
+// @Success 200 {object} models.MCPServer
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id} [put]
 func (h *MCPServerHandler) UpdateMCPServer(c *gin.Context) {
 	id := c.Param("id")
 	var server models.MCPServer
 	if err := c.ShouldBindJSON(&server); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -236,59 +522,208 @@ func (h *MCPServerHandler) UpdateMCPServer(c *gin.Context) {
 	existingServer, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if preconditionFailed(c, existingServer.ID, existingServer.Version, existingServer.UpdatedAt) {
+		return
+	}
+	if rejectArchivedServer(c, existingServer) {
+		return
+	}
+	if server.Status == "archived" {
+		apierror.Respond(c, http.StatusBadRequest, "Archive MCP servers with POST /api/mcp-servers/{id}/archive")
+		return
+	}
+	if !applyChangeNote(c, server.Changelog, existingServer.Changelog) {
 		return
 	}
 
 	// Only validate name if it has changed
 	if existingServer.Name != server.Name {
 		if err := h.validator.ValidateName(c.Request.Context(), server.Name, id); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			apierror.RespondCode(c, nameErrorStatus(err), errorCode(err), err.Error())
+			return
+		}
+	}
+
+	// Reject scripts that do not compile
+	if err := h.mcpService.ValidateScripts(&server); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.ValidateExternalServers(server.External); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.ValidateRedactions(server.Redactions); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.Headers.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateAuthPassthrough(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.Network.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.Schedule.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateToolNames(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateChains(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateParamMappings(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateProjections(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateHedging(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateLatencyBudgets(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateErrorMappings(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateShadows(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateWebSockets(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidatePolicies(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	for i := range server.Tools {
+		if server.Tools[i].IsChained() && server.Tools[i].InputSchema == nil {
+			server.Tools[i].InputSchema = models.ChainInputSchema(server.Tools[i])
+		}
+		if server.Tools[i].IsWebSocket() && server.Tools[i].InputSchema == nil {
+			server.Tools[i].InputSchema = models.WebSocketInputSchema(server.Tools[i])
+		}
+	}
+
+	// The tools of a virtual server follow its sources
+	if len(server.Sources) > 0 {
+		if len(server.External) > 0 {
+			apierror.Respond(c, http.StatusBadRequest, "sources cannot be combined with external")
+			return
+		}
+		if err := models.ValidateSources(server.Sources, server.ConflictResolution); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := h.syncer.ComposeServer(c.Request.Context(), &server); err != nil {
+			apierror.RespondCode(c, sourceErrorStatus(err), errorCode(err), err.Error())
 			return
 		}
 	}
 
+	// Changes of active servers may need approval
+	if h.submitRevision(c, existingServer, &server, "update") {
+		return
+	}
+
 	// Update in repository
 	if err := h.mcpRepo.Update(c.Request.Context(), &server); err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
 		return
 	}
 
+	// Stop serving the previous definition
+	if server.Status == "inactive" {
+		h.mcpService.UnregisterServer(id)
+	} else {
+		h.mcpService.RefreshServer(&server)
+	}
+
+	// Compose the virtual servers including it again
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
+
+	c.Header("ETag", resourceETag(server.Version, server.UpdatedAt))
 	c.JSON(http.StatusOK, server)
 }
 
 // DeleteMCPServer deletes an MCP Server
+//
+// @Summary Delete an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id} [delete]
 func (h *MCPServerHandler) DeleteMCPServer(c *gin.Context) {
 	id := c.Param("id")
 	if err := h.mcpRepo.Delete(c.Request.Context(), id); err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	h.mcpService.UnregisterServer(id)
+
+	// Remove its tools from the virtual servers including it
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
 
 	c.Status(http.StatusNoContent)
 }
 
-// GetMCPServerVersions returns all versions of an MCP Server
+// GetMCPServerVersions returns the version history of an MCP Server, from the oldest version
+//
+// @Summary List the versions of an MCP server with their change notes
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {array} models.VersionInfo
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/versions [get]
 func (h *MCPServerHandler) GetMCPServerVersions(c *gin.Context) {
 	id := c.Param("id")
 	versions, err := h.mcpRepo.GetVersions(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -296,22 +731,33 @@ func (h *MCPServerHandler) GetMCPServerVersions(c *gin.Context) {
 }
 
 // GetMCPServerByVersion returns a specific version of an MCP Server
+//
+// @Summary Get a version of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param version path int true "Version"
+// @Success 200 {object} models.MCPServer
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/versions/{version} [get]
 func (h *MCPServerHandler) GetMCPServerByVersion(c *gin.Context) {
 	id := c.Param("id")
 	versionStr := c.Param("version")
 	version, err := strconv.Atoi(versionStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version number"})
+		apierror.Respond(c, http.StatusBadRequest, "Invalid version number")
 		return
 	}
 
 	server, err := h.mcpRepo.GetByVersion(c.Request.Context(), id, version)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server or version not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server or version not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -319,6 +765,16 @@ func (h *MCPServerHandler) GetMCPServerByVersion(c *gin.Context) {
 }
 
 // RegisterMCPServer registers an MCP Server with the service
+//
+// @Summary Register an MCP server with the service
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/register [post]
 func (h *MCPServerHandler) RegisterMCPServer(c *gin.Context) {
 	id := c.Param("id")
 
@@ -326,16 +782,19 @@ func (h *MCPServerHandler) RegisterMCPServer(c *gin.Context) {
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if rejectArchivedServer(c, server) {
 		return
 	}
 
 	// Register with the MCP service
 	if err := h.mcpService.RegisterServer(server); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register MCP Server: " + err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register MCP Server: "+err.Error())
 		return
 	}
 
@@ -343,6 +802,16 @@ func (h *MCPServerHandler) RegisterMCPServer(c *gin.Context) {
 }
 
 // ActivateMCPServer activates an MCP Server
+//
+// @Summary Activate an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/activate [post]
 func (h *MCPServerHandler) ActivateMCPServer(c *gin.Context) {
 	id := c.Param("id")
 
@@ -350,29 +819,133 @@ func (h *MCPServerHandler) ActivateMCPServer(c *gin.Context) {
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if rejectArchivedServer(c, server) {
 		return
 	}
 
 	// Register with the MCP service if not already registered
 	if err := h.mcpService.RegisterServer(server); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register MCP Server: " + err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register MCP Server: "+err.Error())
 		return
 	}
 
 	// Update status
 	if err := h.mcpRepo.UpdateStatus(c.Request.Context(), id, "active"); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "MCP Server activated successfully"})
 }
 
+// CloneMCPServer creates a draft copy of an MCP Server with a new name and a fresh version history
+//
+// @Summary Clone an MCP server as a new draft
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param request body CloneMCPServerRequest true "Name of the copy"
+// @Success 201 {object} models.MCPServer
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/clone [post]
+func (h *MCPServerHandler) CloneMCPServer(c *gin.Context) {
+	var req CloneMCPServerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Repositories return a copy that can be modified freely
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Validate server name uniqueness
+	if err := h.validator.ValidateName(c.Request.Context(), req.Name, ""); err != nil {
+		apierror.RespondCode(c, nameErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	sourceID := server.ID
+	server.ID = ""
+	server.Name = req.Name
+	server.Status = "draft"
+	if req.DefaultEnvironment != "" {
+		server.DefaultEnvironment = req.DefaultEnvironment
+	}
+
+	if err := h.mcpRepo.Create(c.Request.Context(), server); err != nil {
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Cloned MCP server", "source", sourceID, "id", server.ID, "name", server.Name)
+	c.JSON(http.StatusCreated, server)
+}
+
+// SyncMCPServer regenerates the tools of an MCP Server whose HTTP interfaces changed. The synced
+// tools of an active server are submitted as a revision with 202 while changes require approval.
+//
+// @Summary Regenerate the tools of an MCP server from its HTTP interfaces
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} mcp.SyncResult
+// @Success 202 {object} mcp.SyncResult "Synced tools of an active server awaiting approval"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/sync [post]
+func (h *MCPServerHandler) SyncMCPServer(c *gin.Context) {
+	result, err := h.syncer.SyncServer(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		var conflict *models.ToolConflictError
+		if errors.As(err, &conflict) {
+			apierror.Respond(c, http.StatusConflict, err.Error())
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if result.Revision != "" {
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // DeactivateMCPServer deactivates an MCP Server
+//
+// @Summary Deactivate an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/deactivate [post]
 func (h *MCPServerHandler) DeactivateMCPServer(c *gin.Context) {
 	id := c.Param("id")
 
@@ -380,229 +953,1392 @@ func (h *MCPServerHandler) DeactivateMCPServer(c *gin.Context) {
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Check if server is already inactive
 	if server.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
 		return
 	}
 
 	// Update status to inactive
 	if err := h.mcpRepo.UpdateStatus(c.Request.Context(), id, "inactive"); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	h.mcpService.UnregisterServer(id)
 
 	c.JSON(http.StatusOK, gin.H{"message": "MCP Server deactivated successfully"})
 }
 
 // InvokeTool invokes a tool in an MCP Server
+//
+// @Summary Invoke a tool of an MCP server by server name
+// @Tags mcp-protocol
+// @Accept json
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Param tool path string true "Tool name"
+// @Param params body object false "Tool parameters"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/tools/{tool} [post]
 func (h *MCPServerHandler) InvokeToolByName(c *gin.Context) {
 	name := c.Param("name")
 	toolName := c.Param("tool")
 
-	fmt.Printf("---InvokeToolByName--INFO: Processing tool invocation by name request: server=%s, tool=%s\n", name, toolName)
+	slog.DebugContext(c.Request.Context(), "Processing tool invocation by name request", "server", name, "tool", toolName)
 
 	// Get MCP Server by name
 	server, err := h.mcpRepo.GetByName(c.Request.Context(), name)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			fmt.Printf("ERROR: MCP Server not found: name=%s\n", name)
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			if router.RedirectRenamed(c, h.aliases, h.mcpRepo, name) {
+				return
+			}
+			slog.ErrorContext(c.Request.Context(), "MCP Server not found", "name", name)
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		fmt.Printf("ERROR: Failed to get MCP server: name=%s, error=%v\n", name, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to get MCP server", "name", name, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Check if the server is active
 	if server.Status != "active" {
-		fmt.Printf("ERROR: MCP Server is not active: name=%s, status=%s\n", name, server.Status)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+		slog.ErrorContext(c.Request.Context(), "MCP Server is not active", "name", name, "status", server.Status)
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
 		return
 	}
 
 	// Check if the tool exists
-	toolExists := false
-	for _, allowed := range server.AllowTools {
-		if allowed == toolName {
-			toolExists = true
-			break
-		}
-	}
-	if !toolExists {
-		fmt.Printf("ERROR: Tool not found or not allowed: server=%s, tool=%s\n", name, toolName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tool not found or not allowed"})
+	if !server.AllowsTool(toolName) {
+		slog.ErrorContext(c.Request.Context(), "Tool not found or not allowed", "server", name, "tool", toolName)
+		apierror.Respond(c, http.StatusNotFound, "Tool not found or not allowed")
 		return
 	}
 
 	// IMPORTANT: Register the server with the MCP service if it's not already registered
 	// This ensures the server is available in the MCP service's in-memory map
-	fmt.Printf("INFO: Ensuring server is registered with MCP service: name=%s\n", name)
+	slog.InfoContext(c.Request.Context(), "Ensuring server is registered with MCP service", "name", name)
 	err = h.mcpService.RegisterServer(server)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to register server with MCP service: name=%s, error=%v\n", name, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register server: " + err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to register server with MCP service", "name", name, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register server: "+err.Error())
 		return
 	}
 
 	// Get tool parameters
 	var params map[string]interface{}
 	if err := c.ShouldBindJSON(&params); err != nil {
-		fmt.Printf("WARNING: Could not parse request body, using empty params: error=%v\n", err)
+		slog.WarnContext(c.Request.Context(), "Could not parse request body, using empty params", "error", err)
 		params = make(map[string]interface{})
 	} else {
-		fmt.Printf("INFO: Parsed parameters: %v\n", params)
+		slog.InfoContext(c.Request.Context(), "Parsed parameters", "params", params)
 	}
 
 	// Execute the tool
-	fmt.Printf("INFO: Executing tool request: server=%s, tool=%s\n", name, toolName)
-	result, err := h.mcpService.HandleToolRequest(c.Request.Context(), server.ID, toolName, params)
+	slog.InfoContext(c.Request.Context(), "Executing tool request", "server", name, "tool", toolName)
+	var truncated bool
+	ctx := mcp.WithTruncation(c.Request.Context(), &truncated)
+	result, err := h.mcpService.HandleToolRequest(ctx, server.ID, toolName, params)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to execute tool: server=%s, tool=%s, error=%v\n", name, toolName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute tool: " + err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to execute tool", "server", name, "tool", toolName, "error", err)
+		apierror.RespondCode(c, mcp.ErrorStatus(err), errorCode(err), "Failed to execute tool: "+err.Error())
 		return
 	}
+	if truncated {
+		c.Header(mcp.TruncatedHeader, "true")
+	}
 
-	fmt.Printf("INFO: Tool executed successfully: server=%s, tool=%s\n", name, toolName)
+	slog.InfoContext(c.Request.Context(), "Tool executed successfully", "server", name, "tool", toolName)
 
 	// Try to parse result as JSON
 	var jsonResult interface{}
 	if json.Valid([]byte(result)) {
 		if err := json.Unmarshal([]byte(result), &jsonResult); err == nil {
-			fmt.Printf("INFO: Returning JSON result\n")
+			slog.InfoContext(c.Request.Context(), "Returning JSON result")
 			c.JSON(http.StatusOK, jsonResult)
 			return
 		}
 	}
 
 	// If not valid JSON, return as text
-	fmt.Printf("INFO: Returning text result\n")
+	slog.InfoContext(c.Request.Context(), "Returning text result")
 	c.JSON(http.StatusOK, gin.H{"result": result})
 }
 
 // InvokeTool invokes a tool in an MCP Server
+//
+// @Summary Invoke a tool of an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name"
+// @Param params body object false "Tool parameters"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool} [post]
 func (h *MCPServerHandler) InvokeTool(c *gin.Context) {
 	id := c.Param("id")
 	toolName := c.Param("tool")
 
-	fmt.Printf("INFO: Processing tool invocation request: server=%s, tool=%s\n", id, toolName)
+	slog.InfoContext(c.Request.Context(), "Processing tool invocation request", "server", id, "tool", toolName)
+
+	if _, ok := h.invocableServer(c, id, toolName); !ok {
+		return
+	}
+
+	// Get tool parameters
+	var params map[string]interface{}
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.WarnContext(c.Request.Context(), "Could not parse request body, using empty params", "error", err)
+		params = make(map[string]interface{})
+	} else {
+		slog.InfoContext(c.Request.Context(), "Parsed parameters", "params", params)
+	}
+
+	// Execute the tool
+	slog.InfoContext(c.Request.Context(), "Executing tool request", "server", id, "tool", toolName)
+	var truncated bool
+	ctx := mcp.WithTruncation(c.Request.Context(), &truncated)
+	result, err := h.mcpService.HandleToolRequest(ctx, id, toolName, params)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to execute tool", "server", id, "tool", toolName, "error", err)
+		apierror.RespondCode(c, mcp.ErrorStatus(err), errorCode(err), "Failed to execute tool: "+err.Error())
+		return
+	}
+	if truncated {
+		c.Header(mcp.TruncatedHeader, "true")
+	}
+
+	slog.InfoContext(c.Request.Context(), "Tool executed successfully", "server", id, "tool", toolName)
+
+	// Try to parse result as JSON
+	var jsonResult interface{}
+	if json.Valid([]byte(result)) {
+		if err := json.Unmarshal([]byte(result), &jsonResult); err == nil {
+			slog.InfoContext(c.Request.Context(), "Returning JSON result")
+			c.JSON(http.StatusOK, jsonResult)
+			return
+		}
+	}
+
+	// If not valid JSON, return as text
+	slog.InfoContext(c.Request.Context(), "Returning text result")
+	c.JSON(http.StatusOK, gin.H{"result": result})
+}
+
+// invocableServer returns the server of a tool invocation after checking that it is active,
+// allows the tool and is registered with the MCP service. It responds with an error otherwise.
+func (h *MCPServerHandler) invocableServer(c *gin.Context, id string, toolName string) (*models.MCPServer, bool) {
+	server, ok := h.activeServer(c, id)
+	if !ok {
+		return nil, false
+	}
+
+	// Check if the tool exists
+	if !server.AllowsTool(toolName) {
+		slog.ErrorContext(c.Request.Context(), "Tool not found or not allowed", "server", id, "tool", toolName)
+		apierror.Respond(c, http.StatusNotFound, "Tool not found or not allowed")
+		return nil, false
+	}
+
+	return server, true
+}
+
+// activeServer returns a server after checking that it is active and registering it with the
+// MCP service. It responds with an error otherwise.
+func (h *MCPServerHandler) activeServer(c *gin.Context, id string) (*models.MCPServer, bool) {
+	// Get MCP Server
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			slog.ErrorContext(c.Request.Context(), "MCP Server not found", "id", id)
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return nil, false
+		}
+		slog.ErrorContext(c.Request.Context(), "Failed to get MCP server", "id", id, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+
+	// Check if the server is active
+	if server.Status != "active" {
+		slog.ErrorContext(c.Request.Context(), "MCP Server is not active", "id", id, "status", server.Status)
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
+		return nil, false
+	}
+
+	// IMPORTANT: Register the server with the MCP service if it's not already registered
+	// This ensures the server is available in the MCP service's in-memory map
+	slog.InfoContext(c.Request.Context(), "Ensuring server is registered with MCP service", "id", id)
+	err = h.mcpService.RegisterServer(server)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to register server with MCP service", "id", id, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register server: "+err.Error())
+		return nil, false
+	}
+
+	return server, true
+}
+
+// ToolTestReport is the outcome of a test call of a tool
+type ToolTestReport struct {
+	Tool              string               `json:"tool"`
+	Valid             bool                 `json:"valid"`                    // Whether the params match the input schema of the tool
+	Warnings          []string             `json:"warnings"`                 // Schema violations and other problems found before the call
+	Request           *mcp.ResolvedRequest `json:"request,omitempty"`        // Upstream request, credentials redacted
+	UpstreamStatus    int                  `json:"upstreamStatus,omitempty"` // 0 if no upstream response was received
+	UpstreamLatencyMs int64                `json:"upstreamLatencyMs"`
+	LatencyMs         int64                `json:"latencyMs"` // Duration of the whole call, scripts and plugins included
+	Result            interface{}          `json:"result,omitempty"`
+	Error             string               `json:"error,omitempty"`
+}
+
+// UpdateToolRequest sets how MCP clients see a tool. Omitted fields are kept, empty ones clear
+// the override.
+type UpdateToolRequest struct {
+	Alias             *string                 `json:"alias"`                          // Name exposed to MCP clients
+	Description       *string                 `json:"description"`                    // Description exposed instead of the generated one
+	ParamDescriptions map[string]string       `json:"paramDescriptions"`              // Param descriptions exposed by the names clients use
+	StaticParams      map[string]interface{}  `json:"staticParams"`                   // Params always sent upstream and hidden from clients
+	ParamMapping      map[string]string       `json:"paramMapping"`                   // Upstream name of a param by the name clients use
+	Projection        *models.Projection      `json:"projection"`                     // Fields of the result returned to clients
+	Cost              *float64                `json:"cost" binding:"omitempty,min=0"` // Cost of a call counted against API key quotas, 0 for the default of 1
+	Hedging           *models.Hedging         `json:"hedging"`                        // Hedged requests of an idempotent GET tool
+	LatencyBudget     *models.LatencyBudget   `json:"latencyBudget"`                  // Target latency of a call, a targetMs of 0 removes it
+	AuthPassthrough   *models.AuthPassthrough `json:"authPassthrough"`                // Authorization passthrough policy overriding the one of the server
+	ErrorMappings     []models.ErrorMapping   `json:"errorMappings"`                  // Errors returned for unsuccessful upstream statuses, an empty list removes them
+	PartialOnTimeout  *bool                   `json:"partialOnTimeout"`               // Return the body received so far when the upstream response is cut off at the timeout
+	Shadow            *models.Shadow          `json:"shadow"`                         // Tool also called in the background on a sample of the calls, a percent of 0 removes it
+	Policy            *models.CallPolicy      `json:"policy"`                         // Timeout, retries, rate limit and hosts of the calls, an empty policy removes it
+}
+
+// UpdateTool sets the alias, the description override, the params, the result projection, the
+// cost, the hedging, the latency budget, the Authorization passthrough, the upstream error
+// mappings, the partial responses and the shadow tool of a tool of an MCP Server. The tool keeps
+// its name, so syncing it with its interface does not undo the change.
+//
+// @Summary Set the alias, description, params, projection, cost, hedging, latency budget, auth passthrough, error mappings, partial responses, shadow and call policy of a tool
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name or alias"
+// @Param request body UpdateToolRequest true "Alias, description, params, projection, cost, hedging, latency budget, auth passthrough, error mappings, partial responses, shadow and call policy"
+// @Success 200 {object} models.Tool
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool} [patch]
+func (h *MCPServerHandler) UpdateTool(c *gin.Context) {
+	id := c.Param("id")
+	toolName := c.Param("tool")
+
+	var req UpdateToolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Find the tool by its name, or by the alias clients call it by
+	tool := server.FindTool(toolName)
+	for i := range server.Tools {
+		if server.Tools[i].Name == toolName {
+			tool = &server.Tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		apierror.Respond(c, http.StatusNotFound, "Tool not found: "+toolName)
+		return
+	}
+
+	if req.Alias != nil {
+		tool.Alias = *req.Alias
+	}
+	if req.Description != nil {
+		tool.DescriptionOverride = *req.Description
+	}
+	if req.ParamDescriptions != nil {
+		tool.ParamDescriptions = req.ParamDescriptions
+	}
+	if req.StaticParams != nil {
+		tool.StaticParams = req.StaticParams
+	}
+	if req.ParamMapping != nil {
+		tool.ParamMapping = req.ParamMapping
+	}
+	if req.Cost != nil {
+		tool.Cost = *req.Cost
+	}
+	if req.Hedging != nil {
+		// Disabling hedging removes it
+		tool.Hedging = req.Hedging
+		if !req.Hedging.Enabled {
+			tool.Hedging = nil
+		}
+	}
+	if req.LatencyBudget != nil {
+		// A target of 0 removes the budget
+		tool.LatencyBudget = req.LatencyBudget
+		if req.LatencyBudget.TargetMs == 0 {
+			tool.LatencyBudget = nil
+		}
+	}
+	if req.AuthPassthrough != nil {
+		// An empty mode removes the override
+		tool.AuthPassthrough = req.AuthPassthrough
+		if req.AuthPassthrough.Mode == "" {
+			tool.AuthPassthrough = nil
+		}
+	}
+	if req.Shadow != nil {
+		// A percent of 0 removes the shadow
+		tool.Shadow = req.Shadow
+		if !req.Shadow.IsSet() {
+			tool.Shadow = nil
+		}
+	}
+	if req.PartialOnTimeout != nil {
+		tool.PartialOnTimeout = *req.PartialOnTimeout
+	}
+	if req.ErrorMappings != nil {
+		// An empty list removes the mappings
+		tool.ErrorMappings = req.ErrorMappings
+		if len(req.ErrorMappings) == 0 {
+			tool.ErrorMappings = nil
+		}
+	}
+	if req.Projection != nil {
+		// An empty projection removes it
+		tool.Projection = req.Projection
+		if req.Projection.IsEmpty() {
+			tool.Projection = nil
+		}
+	}
+	if req.Policy != nil {
+		// An empty policy removes it
+		tool.Policy = req.Policy
+		if req.Policy.IsEmpty() {
+			tool.Policy = nil
+		}
+	}
+	if err := server.ValidateToolNames(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateParamMappings(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateProjections(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateHedging(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateLatencyBudgets(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateErrorMappings(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateShadows(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateAuthPassthrough(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidatePolicies(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if h.submitRevision(c, server, server, "tool "+tool.Name) {
+		return
+	}
+
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.RefreshServer(server)
+
+	// The virtual servers including it expose the new name and params
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
+
+	slog.InfoContext(c.Request.Context(), "Updated tool", "id", id, "tool", tool.Name, "alias", tool.Alias)
+	c.JSON(http.StatusOK, tool)
+}
+
+// EnrichDescriptionsRequest selects the tools whose descriptions are generated
+type EnrichDescriptionsRequest struct {
+	Tools []string `json:"tools"` // Names clients see, all tools if empty
+}
+
+// DescriptionSuggestion is a description generated for a tool and its params, to review and
+// pass to AcceptDescriptions, edited or not
+type DescriptionSuggestion struct {
+	Tool               string            `json:"tool" binding:"required"` // Name clients see
+	CurrentDescription string            `json:"currentDescription,omitempty"`
+	Description        string            `json:"description"`
+	CurrentParams      map[string]string `json:"currentParams,omitempty"` // Current param descriptions
+	Params             map[string]string `json:"params,omitempty"`        // Description by param name clients see
+	Error              string            `json:"error,omitempty"`         // Why no description was generated
+}
+
+// DescriptionSuggestions lists the descriptions generated for the tools of a server
+type DescriptionSuggestions struct {
+	Suggestions []DescriptionSuggestion `json:"suggestions" binding:"dive"`
+}
+
+// EnrichDescriptions asks the configured LLM for descriptions of the tools of an MCP Server and
+// of their params, from their interface definitions. Nothing is changed: the suggestions are
+// reviewed, edited if needed and applied with AcceptDescriptions.
+//
+// @Summary Generate tool descriptions with an LLM
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param request body EnrichDescriptionsRequest false "Tools to describe"
+// @Success 200 {object} DescriptionSuggestions
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/enrich-descriptions [post]
+func (h *MCPServerHandler) EnrichDescriptions(c *gin.Context) {
+	id := c.Param("id")
+
+	var req EnrichDescriptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if h.llm == nil || !h.llm.Enabled() {
+		apierror.Respond(c, http.StatusServiceUnavailable, llm.ErrNotConfigured.Error())
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	selected := map[string]bool{}
+	for _, name := range req.Tools {
+		if server.FindTool(name) == nil {
+			apierror.Respond(c, http.StatusNotFound, "Tool not found: "+name)
+			return
+		}
+		selected[name] = true
+	}
+
+	suggestions := DescriptionSuggestions{Suggestions: []DescriptionSuggestion{}}
+	for _, tool := range server.Tools {
+		if len(selected) > 0 && !selected[tool.ExposedName()] {
+			continue
+		}
+		suggestions.Suggestions = append(suggestions.Suggestions, h.describeTool(c.Request.Context(), tool))
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// describeTool asks the LLM for the descriptions of a tool as clients see it
+func (h *MCPServerHandler) describeTool(ctx context.Context, tool models.Tool) DescriptionSuggestion {
+	parametersSchema, _, _, _ := inferParametersSchema(tool)
+	inputSchema, _ := h.toolSchemas(ctx, tool, parametersSchema)
+	suggestion := DescriptionSuggestion{
+		Tool:               tool.ExposedName(),
+		CurrentDescription: tool.ExposedDescription(),
+		CurrentParams:      paramDescriptions(inputSchema),
+	}
+
+	definition := llm.ToolDefinition{
+		Name:        tool.ExposedName(),
+		Description: tool.ExposedDescription(),
+		InputSchema: inputSchema,
+	}
+	if tool.InterfaceID != "" {
+		if httpInterface, err := h.httpRepo.GetByID(ctx, tool.InterfaceID); err == nil {
+			definition.Interface = httpInterface
+		}
+	}
+
+	description, err := h.llm.DescribeTool(ctx, definition)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to generate tool description", "tool", tool.Name, "error", err)
+		suggestion.Error = err.Error()
+		return suggestion
+	}
+	suggestion.Description = description.Description
+	suggestion.Params = description.Params
+	return suggestion
+}
+
+// paramDescriptions returns the descriptions of the properties of an input schema
+func paramDescriptions(schema map[string]interface{}) map[string]string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	descriptions := map[string]string{}
+	for name, property := range properties {
+		if p, ok := property.(map[string]interface{}); ok {
+			if description, ok := p["description"].(string); ok && description != "" {
+				descriptions[name] = description
+			}
+		}
+	}
+	return descriptions
+}
+
+// AcceptDescriptions applies reviewed description suggestions to the tools of an MCP Server, as
+// description overrides and param descriptions that syncing the tools with their interfaces keeps.
+// Suggestions with an error or an empty description and no params are skipped.
+//
+// @Summary Apply reviewed tool descriptions
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param request body DescriptionSuggestions true "Reviewed suggestions"
+// @Success 200 {object} models.MCPServer
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/enrich-descriptions/accept [post]
+func (h *MCPServerHandler) AcceptDescriptions(c *gin.Context) {
+	id := c.Param("id")
+
+	var req DescriptionSuggestions
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	accepted := []string{}
+	for _, suggestion := range req.Suggestions {
+		if suggestion.Error != "" || suggestion.Description == "" && len(suggestion.Params) == 0 {
+			continue
+		}
+		tool := server.FindTool(suggestion.Tool)
+		if tool == nil {
+			apierror.Respond(c, http.StatusNotFound, "Tool not found: "+suggestion.Tool)
+			return
+		}
+		if suggestion.Description != "" {
+			tool.DescriptionOverride = suggestion.Description
+		}
+		if len(suggestion.Params) > 0 {
+			descriptions := make(map[string]string, len(tool.ParamDescriptions)+len(suggestion.Params))
+			for name, description := range tool.ParamDescriptions {
+				descriptions[name] = description
+			}
+			for name, description := range suggestion.Params {
+				descriptions[name] = description
+			}
+			tool.ParamDescriptions = descriptions
+		}
+		accepted = append(accepted, suggestion.Tool)
+	}
+
+	if h.submitRevision(c, server, server, "descriptions") {
+		return
+	}
+
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.RefreshServer(server)
+
+	// The virtual servers including its tools expose the new descriptions
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
+
+	slog.InfoContext(c.Request.Context(), "Accepted tool descriptions", "id", id, "tools", accepted)
+	c.JSON(http.StatusOK, server)
+}
+
+// CreateChainedToolRequest is the request for adding a chained tool to an MCP Server
+type CreateChainedToolRequest struct {
+	Name        string            `json:"name" binding:"required"`
+	Description string            `json:"description"`
+	Steps       []models.ToolStep `json:"steps" binding:"required,min=1,dive"`
+	// gjson path selecting the result from the params and the step results, the last step result by default
+	Output string `json:"output"`
+	// JSON Schema of the params, made of the params the steps read if omitted
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// CreateChainedTool adds a tool to an MCP Server that calls other tools of the server in order,
+// mapping the results of each step into the params of the next ones
+//
+// @Summary Add a chained tool to an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param request body CreateChainedToolRequest true "Chained tool"
+// @Success 201 {object} models.Tool
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/chained-tools [post]
+func (h *MCPServerHandler) CreateChainedTool(c *gin.Context) {
+	id := c.Param("id")
+
+	var req CreateChainedToolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(server.Sources) > 0 {
+		apierror.Respond(c, http.StatusBadRequest, "The tools of a virtual server follow its sources, add the chained tool to a source")
+		return
+	}
+
+	tool := models.Tool{
+		Name:        req.Name,
+		Description: req.Description,
+		Steps:       req.Steps,
+		Output:      req.Output,
+		InputSchema: req.InputSchema,
+	}
+	if tool.InputSchema == nil {
+		tool.InputSchema = models.ChainInputSchema(tool)
+	}
+	server.Tools = append(server.Tools, tool)
+	server.AllowTools = append(server.AllowTools, tool.Name)
+	if err := server.ValidateToolNames(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateChains(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if h.submitRevision(c, server, server, "chained tool "+tool.Name) {
+		return
+	}
+
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.RefreshServer(server)
+
+	// The virtual servers including it expose the new tool
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
+
+	slog.InfoContext(c.Request.Context(), "Added chained tool", "id", id, "tool", tool.Name, "steps", len(tool.Steps))
+	c.JSON(http.StatusCreated, tool)
+}
+
+// CreateWebSocketToolRequest is the request for adding a tool calling a WebSocket upstream to an MCP Server
+type CreateWebSocketToolRequest struct {
+	Name        string            `json:"name" binding:"required"`
+	Description string            `json:"description"`
+	URL         string            `json:"url" binding:"required"` // ws:// or wss:// URL with {param} placeholders
+	Headers     map[string]string `json:"headers"`                // Headers of the handshake
+	Message     string            `json:"message"`                // Message sent once connected with {param} placeholders, nothing is sent if empty
+	// Replies collected and the time allowed for the exchange
+	models.WebSocketExchange
+	// JSON Schema of the params, made of the placeholders of the URL and the message if omitted
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// CreateWebSocketTool adds a tool to an MCP Server that connects to a WebSocket upstream, sends
+// a message and returns the replies, for realtime APIs without a REST equivalent
+//
+// @Summary Add a WebSocket tool to an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param request body CreateWebSocketToolRequest true "WebSocket tool"
+// @Success 201 {object} models.Tool
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/websocket-tools [post]
+func (h *MCPServerHandler) CreateWebSocketTool(c *gin.Context) {
+	id := c.Param("id")
+
+	var req CreateWebSocketToolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(server.Sources) > 0 {
+		apierror.Respond(c, http.StatusBadRequest, "The tools of a virtual server follow its sources, add the WebSocket tool to a source")
+		return
+	}
+
+	exchange := req.WebSocketExchange
+	tool := models.Tool{
+		Name:        req.Name,
+		Description: req.Description,
+		RequestTemplate: models.RequestTemplate{
+			Method:  http.MethodGet,
+			URL:     req.URL,
+			Headers: req.Headers,
+			Body:    req.Message,
+		},
+		WebSocket:   &exchange,
+		InputSchema: req.InputSchema,
+	}
+	if tool.InputSchema == nil {
+		tool.InputSchema = models.WebSocketInputSchema(tool)
+	}
+	server.Tools = append(server.Tools, tool)
+	server.AllowTools = append(server.AllowTools, tool.Name)
+	if err := server.ValidateToolNames(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := server.ValidateWebSockets(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if h.submitRevision(c, server, server, "WebSocket tool "+tool.Name) {
+		return
+	}
+
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.RefreshServer(server)
+
+	// The virtual servers including it expose the new tool
+	if _, err := h.syncer.SyncSource(c.Request.Context(), id); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", id, "error", err)
+	}
+
+	slog.InfoContext(c.Request.Context(), "Added WebSocket tool", "id", id, "tool", tool.Name, "url", tool.RequestTemplate.URL)
+	c.JSON(http.StatusCreated, tool)
+}
+
+// TestTool validates the params of a tool against its input schema, invokes it and reports
+// the resolved upstream request, the upstream status and the latency. Schema violations are
+// reported as warnings and do not prevent the call. A failed call is reported with status 200.
+//
+// @Summary Test a tool of an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name"
+// @Param params body object false "Tool parameters"
+// @Success 200 {object} ToolTestReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool}/test [post]
+func (h *MCPServerHandler) TestTool(c *gin.Context) {
+	id := c.Param("id")
+	toolName := c.Param("tool")
+
+	params := map[string]interface{}{}
+	if err := c.ShouldBindJSON(&params); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, "Invalid tool parameters: "+err.Error())
+		return
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	server, ok := h.invocableServer(c, id, toolName)
+	if !ok {
+		return
+	}
+
+	report := ToolTestReport{Tool: toolName, Valid: true, Warnings: []string{}}
+	var budget *models.LatencyBudget
+	for _, tool := range server.Tools {
+		if tool.ExposedName() != toolName {
+			continue
+		}
+		budget = tool.LatencyBudget
+		if warning := tool.DeprecationWarning(); warning != "" {
+			report.Warnings = append(report.Warnings, warning)
+		}
+		inputSchema, _ := h.toolSchemas(c.Request.Context(), tool, nil)
+		if inputSchema == nil {
+			report.Warnings = append(report.Warnings, "The tool has no input schema, the params were not validated")
+			break
+		}
+		problems, err := models.ValidateJSON(inputSchema, params)
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+			break
+		}
+		if len(problems) > 0 {
+			report.Valid = false
+			report.Warnings = append(report.Warnings, problems...)
+		}
+		break
+	}
+
+	trace := &mcp.ToolTrace{}
+	ctx := mcp.WithTrace(c.Request.Context(), trace)
+	start := time.Now()
+	result, err := h.mcpService.HandleToolRequest(ctx, id, toolName, params)
+	report.LatencyMs = time.Since(start).Milliseconds()
+	report.Request = trace.Request
+	report.UpstreamStatus = trace.UpstreamStatus
+	report.UpstreamLatencyMs = trace.UpstreamLatency.Milliseconds()
+	if budget.IsSet() && report.LatencyMs > int64(budget.TargetMs) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("The call took %dms, over the latency budget of %dms", report.LatencyMs, budget.TargetMs))
+	}
+	if trace.Truncated {
+		report.Warnings = append(report.Warnings, "The upstream response was cut off at the timeout, the result is partial")
+	}
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		var jsonResult interface{}
+		if err := json.Unmarshal([]byte(result), &jsonResult); err == nil {
+			report.Result = jsonResult
+		} else {
+			report.Result = result
+		}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RenderToolRequest holds the samples a tool is rendered from
+type RenderToolRequest struct {
+	Params   map[string]interface{} `json:"params"`   // Params of the call, as clients send them
+	Response interface{}            `json:"response"` // Upstream response body, a string for one that is not JSON
+}
+
+// RenderTool renders the upstream request of a tool from sample params, and its result from a
+// sample upstream response, without calling the upstream. The server need not be active, so that
+// templates can be checked while they are written.
+//
+// @Summary Render the request and response templates of a tool
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name or alias"
+// @Param request body RenderToolRequest true "Sample params and upstream response"
+// @Success 200 {object} mcp.ToolRendering
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool}/render [post]
+func (h *MCPServerHandler) RenderTool(c *gin.Context) {
+	toolName := c.Param("tool")
+
+	var req RenderToolRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Params == nil {
+		req.Params = map[string]interface{}{}
+	}
+
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	tool := server.FindTool(toolName)
+	for i := range server.Tools {
+		if server.Tools[i].Name == toolName {
+			tool = &server.Tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		apierror.Respond(c, http.StatusNotFound, "Tool not found: "+toolName)
+		return
+	}
+
+	// Strings are the raw body, other values are sent upstream as JSON
+	var response []byte
+	switch sample := req.Response.(type) {
+	case nil:
+	case string:
+		response = []byte(sample)
+	default:
+		response, _ = json.Marshal(sample)
+	}
+
+	rendering, err := h.mcpService.RenderTool(c.Request.Context(), server, tool, req.Params, response)
+	if err != nil {
+		// Templates, scripts and environments failing to render are errors of the samples or the tool
+		apierror.Respond(c, http.StatusBadRequest, "Failed to render tool: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, rendering)
+}
+
+// ReplayRequest edits the params of a replayed invocation
+type ReplayRequest struct {
+	Params  map[string]interface{} `json:"params"`  // Params overriding the recorded ones, null removes a param; must supply the recorded credentials
+	Replace bool                   `json:"replace"` // Call the tool with params only, e.g. when the recorded ones were truncated
+}
+
+// ReplayReport compares the replay of an invocation with the recorded one
+type ReplayReport struct {
+	InvocationID       string                 `json:"invocationId"` // Replayed invocation
+	ServerID           string                 `json:"serverId"`
+	Tool               string                 `json:"tool"`
+	Params             map[string]interface{} `json:"params"` // Params of the replay, with their credentials redacted
+	OriginalSuccess    bool                   `json:"originalSuccess"`
+	OriginalStatusCode int                    `json:"originalStatusCode"` // 0 if no upstream response was received
+	OriginalError      string                 `json:"originalError,omitempty"`
+	Success            bool                   `json:"success"`
+	UpstreamStatus     int                    `json:"upstreamStatus,omitempty"` // 0 if no upstream response was received
+	LatencyMs          int64                  `json:"latencyMs"`
+	Result             interface{}            `json:"result,omitempty"`
+	Error              string                 `json:"error,omitempty"`
+}
+
+// ReplayInvocation calls the tool of a recorded invocation again with the same params, edited
+// by the request if needed, to reproduce failures reported by agents. The credentials of the
+// recorded params are not replayed, the request must supply them. The replay is recorded as a
+// new invocation. A failed call is reported with status 200.
+//
+// @Summary Replay a recorded tool invocation
+// @Tags invocations
+// @Accept json
+// @Produce json
+// @Param id path string true "Invocation ID"
+// @Param request body ReplayRequest false "Edited params"
+// @Success 200 {object} ReplayReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/invocations/{id}/replay [post]
+func (h *MCPServerHandler) ReplayInvocation(c *gin.Context) {
+	if h.invocations == nil {
+		apierror.Respond(c, http.StatusServiceUnavailable, "The invocation history is not available")
+		return
+	}
+
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invocation, err := h.invocations.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Invocation not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	params := map[string]interface{}{}
+	credentials := []string{}
+	if !req.Replace && invocation.Request != "" {
+		if err := json.Unmarshal([]byte(invocation.Request), &params); err != nil {
+			apierror.Respond(c, http.StatusUnprocessableEntity, "The recorded params are incomplete, send all of them with replace")
+			return
+		}
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+		// The credentials of the original caller are never replayed
+		credentials = mcp.StripCredentials(params)
+	}
+	for name, value := range req.Params {
+		if value == nil {
+			delete(params, name)
+		} else {
+			params[name] = value
+		}
+	}
+	if missing := missingCredentials(params, credentials); len(missing) > 0 {
+		apierror.Respond(c, http.StatusUnprocessableEntity, fmt.Sprintf("The recorded invocation was called with credentials, send them in params: %s", strings.Join(missing, ", ")))
+		return
+	}
+
+	if _, ok := h.invocableServer(c, invocation.ServerID, invocation.Tool); !ok {
+		return
+	}
+
+	report := ReplayReport{
+		InvocationID:       invocation.ID,
+		ServerID:           invocation.ServerID,
+		Tool:               invocation.Tool,
+		Params:             mcp.RedactParams(params), // The request template consumes the params, report them as they were sent
+		OriginalSuccess:    invocation.Success,
+		OriginalStatusCode: invocation.StatusCode,
+		OriginalError:      invocation.Error,
+	}
+
+	slog.InfoContext(c.Request.Context(), "Replaying invocation", "invocation", invocation.ID, "server", invocation.ServerID, "tool", invocation.Tool)
+	trace := &mcp.ToolTrace{}
+	start := time.Now()
+	result, err := h.mcpService.HandleToolRequest(mcp.WithTrace(c.Request.Context(), trace), invocation.ServerID, invocation.Tool, params)
+	report.LatencyMs = time.Since(start).Milliseconds()
+	report.UpstreamStatus = trace.UpstreamStatus
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Success = true
+		var jsonResult interface{}
+		if err := json.Unmarshal([]byte(result), &jsonResult); err == nil {
+			report.Result = jsonResult
+		} else {
+			report.Result = result
+		}
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// missingCredentials returns the credentials stripped from the recorded params of a replay, such
+// as headers.Authorization or cookies, that the replaying user did not supply
+func missingCredentials(params map[string]interface{}, credentials []string) []string {
+	missing := []string{}
+	headers, _ := params["headers"].(map[string]interface{})
+	for _, credential := range credentials {
+		name, isHeader := strings.CutPrefix(credential, "headers.")
+		supplied := false
+		if isHeader {
+			for key := range headers {
+				supplied = supplied || http.CanonicalHeaderKey(key) == name
+			}
+		} else {
+			_, supplied = params[credential]
+		}
+		if !supplied {
+			missing = append(missing, credential)
+		}
+	}
+	return missing
+}
+
+// Verification statuses of a tool
+const (
+	VerificationOK      = "ok"      // The response matches the response schema
+	VerificationDrifted = "drifted" // The response no longer matches the response schema
+	VerificationFailed  = "failed"  // The call failed or the upstream answered with an error status
+	VerificationSkipped = "skipped" // The tool was not called
+)
+
+// VerifyRequest selects the tools of a contract verification
+type VerifyRequest struct {
+	Tools         []string `json:"tools"`         // Tools to verify, all the allowed tools by default
+	IncludeUnsafe bool     `json:"includeUnsafe"` // Also call the tools whose method is not GET, which may change data
+}
+
+// ToolVerification is the outcome of the verification of a tool
+type ToolVerification struct {
+	Tool           string                 `json:"tool"`
+	Status         string                 `json:"status"`                   // ok, drifted, failed or skipped
+	Params         map[string]interface{} `json:"params,omitempty"`         // Example params the tool was called with
+	UpstreamStatus int                    `json:"upstreamStatus,omitempty"` // 0 if no upstream response was received
+	Problems       []string               `json:"problems,omitempty"`       // Differences between the response and its schema
+	Error          string                 `json:"error,omitempty"`
+}
+
+// VerificationReport is the outcome of the contract verification of an MCP server
+type VerificationReport struct {
+	ServerID   string             `json:"serverId"`
+	VerifiedAt time.Time          `json:"verifiedAt"`
+	Drifted    []string           `json:"drifted"` // Tools whose response no longer matches their schema
+	Tools      []ToolVerification `json:"tools"`
+}
+
+// VerifyMCPServer calls the tools of an active MCP server with example params generated from
+// their input schema and checks that the upstream responses still match the stored response
+// schemas, flagging the drifted tools. Tools not using GET are skipped unless includeUnsafe is set.
+//
+// @Summary Verify the tools of an MCP server against their response schemas
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param verify body VerifyRequest false "Tools to verify"
+// @Success 200 {object} VerificationReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/verify [post]
+func (h *MCPServerHandler) VerifyMCPServer(c *gin.Context) {
+	id := c.Param("id")
 
-	// Get MCP Server
-	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
-	if err != nil {
-		if err == repository.ErrNotFound {
-			fmt.Printf("ERROR: MCP Server not found: id=%s\n", id)
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
-			return
-		}
-		fmt.Printf("ERROR: Failed to get MCP server: id=%s, error=%v\n", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var verifyReq VerifyRequest
+	if err := c.ShouldBindJSON(&verifyReq); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Check if the server is active
-	if server.Status != "active" {
-		fmt.Printf("ERROR: MCP Server is not active: id=%s, status=%s\n", id, server.Status)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+	server, ok := h.activeServer(c, id)
+	if !ok {
 		return
 	}
 
-	// Check if the tool exists
-	toolExists := false
-	for _, allowed := range server.AllowTools {
-		if allowed == toolName {
-			toolExists = true
-			break
+	selected := map[string]bool{}
+	for _, name := range verifyReq.Tools {
+		if !server.AllowsTool(name) {
+			apierror.Respond(c, http.StatusNotFound, "Tool not found or not allowed: "+name)
+			return
 		}
-	}
-	if !toolExists {
-		fmt.Printf("ERROR: Tool not found or not allowed: server=%s, tool=%s\n", id, toolName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tool not found or not allowed"})
-		return
+		selected[name] = true
 	}
 
-	// IMPORTANT: Register the server with the MCP service if it's not already registered
-	// This ensures the server is available in the MCP service's in-memory map
-	fmt.Printf("INFO: Ensuring server is registered with MCP service: id=%s\n", id)
-	err = h.mcpService.RegisterServer(server)
-	if err != nil {
-		fmt.Printf("ERROR: Failed to register server with MCP service: id=%s, error=%v\n", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register server: " + err.Error()})
-		return
+	report := VerificationReport{ServerID: id, VerifiedAt: time.Now(), Drifted: []string{}, Tools: []ToolVerification{}}
+	for _, tool := range server.Tools {
+		if !server.AllowsTool(tool.ExposedName()) || !tool.IsEnabled() || len(selected) > 0 && !selected[tool.ExposedName()] {
+			continue
+		}
+		result := h.verifyTool(c.Request.Context(), id, tool, verifyReq.IncludeUnsafe)
+		if result.Status == VerificationDrifted {
+			slog.WarnContext(c.Request.Context(), "Tool response drifted from its schema", "server", id, "tool", result.Tool, "problems", result.Problems)
+			report.Drifted = append(report.Drifted, result.Tool)
+		}
+		report.Tools = append(report.Tools, result)
 	}
 
-	// Get tool parameters
-	var params map[string]interface{}
-	if err := c.ShouldBindJSON(&params); err != nil {
-		fmt.Printf("WARNING: Could not parse request body, using empty params: error=%v\n", err)
-		params = make(map[string]interface{})
-	} else {
-		fmt.Printf("INFO: Parsed parameters: %v\n", params)
+	c.JSON(http.StatusOK, report)
+}
+
+// verifyTool calls a tool with example params and checks its upstream response against its schema
+func (h *MCPServerHandler) verifyTool(ctx context.Context, serverID string, tool models.Tool, includeUnsafe bool) ToolVerification {
+	result := ToolVerification{Tool: tool.ExposedName()}
+
+	if tool.External != "" {
+		result.Status = VerificationSkipped
+		result.Error = "The tool proxies external server " + tool.External
+		return result
+	}
+	if tool.Source != "" {
+		result.Status = VerificationSkipped
+		result.Error = "The tool forwards to source server " + tool.Source + ", verify that server instead"
+		return result
+	}
+	if tool.IsChained() {
+		result.Status = VerificationSkipped
+		result.Error = "The tool is chained, its steps are verified instead"
+		return result
 	}
 
-	// Execute the tool
-	fmt.Printf("INFO: Executing tool request: server=%s, tool=%s\n", id, toolName)
-	result, err := h.mcpService.HandleToolRequest(c.Request.Context(), id, toolName, params)
-	if err != nil {
-		fmt.Printf("ERROR: Failed to execute tool: server=%s, tool=%s, error=%v\n", id, toolName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute tool: " + err.Error()})
-		return
+	inputSchema, outputSchema := h.toolSchemas(ctx, tool, nil)
+	if outputSchema == nil {
+		result.Status = VerificationSkipped
+		result.Error = "The tool has no response schema"
+		return result
+	}
+	if !includeUnsafe && tool.RequestTemplate.Method != http.MethodGet {
+		result.Status = VerificationSkipped
+		result.Error = fmt.Sprintf("%s may change data, set includeUnsafe to call it", tool.RequestTemplate.Method)
+		return result
 	}
 
-	fmt.Printf("INFO: Tool executed successfully: server=%s, tool=%s\n", id, toolName)
+	result.Params = map[string]interface{}{}
+	if inputSchema != nil {
+		if params, ok := models.ExampleValue(inputSchema).(map[string]interface{}); ok {
+			result.Params = params
+		}
+	}
 
-	// Try to parse result as JSON
-	var jsonResult interface{}
-	if json.Valid([]byte(result)) {
-		if err := json.Unmarshal([]byte(result), &jsonResult); err == nil {
-			fmt.Printf("INFO: Returning JSON result\n")
-			c.JSON(http.StatusOK, jsonResult)
-			return
+	// The request template consumes the params, call the tool with a copy
+	params := make(map[string]interface{}, len(result.Params))
+	for key, value := range result.Params {
+		params[key] = value
+	}
+	trace := &mcp.ToolTrace{}
+	_, err := h.mcpService.HandleToolRequest(mcp.WithTrace(ctx, trace), serverID, tool.ExposedName(), params)
+	result.UpstreamStatus = trace.UpstreamStatus
+	if trace.UpstreamStatus < 200 || trace.UpstreamStatus > 299 {
+		result.Status = VerificationFailed
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Error = fmt.Sprintf("unexpected upstream status %d", trace.UpstreamStatus)
 		}
+		return result
 	}
 
-	// If not valid JSON, return as text
-	fmt.Printf("INFO: Returning text result\n")
-	c.JSON(http.StatusOK, gin.H{"result": result})
+	// Check the upstream response, even if a plugin or script failed on it
+	var body interface{}
+	if err := json.Unmarshal(trace.UpstreamBody, &body); err != nil {
+		result.Status = VerificationDrifted
+		result.Problems = []string{"the response is not JSON: " + err.Error()}
+		return result
+	}
+	problems, err := models.ValidateJSON(outputSchema, body)
+	if err != nil {
+		result.Status = VerificationFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Problems = problems
+	result.Status = VerificationOK
+	if len(problems) > 0 {
+		result.Status = VerificationDrifted
+	}
+	return result
 }
 
 // GetMCPServerHTTPInterfaces returns the HTTP interfaces used to create a specific MCP server
+//
+// @Summary List the HTTP interfaces of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {array} models.HTTPInterface
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/http-interfaces [get]
 func (h *MCPServerHandler) GetMCPServerHTTPInterfaces(c *gin.Context) {
 	id := c.Param("id")
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Get all HTTP interfaces
-	allInterfaces, err := h.httpRepo.GetAll(c.Request.Context())
+	// Fetch the interfaces the tools were generated from
+	var ids []string
+	var unlinked []models.Tool
+	for _, tool := range server.Tools {
+		if tool.InterfaceID != "" {
+			ids = append(ids, tool.InterfaceID)
+		} else {
+			unlinked = append(unlinked, tool)
+		}
+	}
+	matchedInterfaces, err := h.httpRepo.GetByIDs(c.Request.Context(), ids)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Filter interfaces that match the tools in the MCP server
-	var matchedInterfaces []models.HTTPInterface
-	for _, httpInterface := range allInterfaces {
-		for _, tool := range server.Tools {
-			if tool.Name == httpInterface.Name &&
-				tool.RequestTemplate.Method == httpInterface.Method &&
-				tool.RequestTemplate.URL == httpInterface.Path {
-				matchedInterfaces = append(matchedInterfaces, httpInterface)
-				break
+	// Tools created before the interface ID was recorded are matched by name, method and URL
+	if len(unlinked) > 0 {
+		allInterfaces, err := h.httpRepo.GetAll(c.Request.Context())
+		if err != nil {
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		linked := make(map[string]bool, len(matchedInterfaces))
+		for _, httpInterface := range matchedInterfaces {
+			linked[httpInterface.ID] = true
+		}
+		for _, httpInterface := range allInterfaces {
+			if linked[httpInterface.ID] {
+				continue
+			}
+			for _, tool := range unlinked {
+				if tool.Name == httpInterface.Name &&
+					tool.RequestTemplate.Method == httpInterface.Method &&
+					tool.RequestTemplate.URL == httpInterface.Path {
+					matchedInterfaces = append(matchedInterfaces, httpInterface)
+					break
+				}
 			}
 		}
 	}
@@ -611,130 +2347,294 @@ func (h *MCPServerHandler) GetMCPServerHTTPInterfaces(c *gin.Context) {
 }
 
 // GetMCPServerTools provides tool metadata conforming to MCP protocol
+//
+// @Summary List the tools of an MCP server
+// @Tags mcp-protocol
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/tools [get]
 func (h *MCPServerHandler) GetMCPServerTools(c *gin.Context) {
-	name := c.Param("name")
+	server, ok := h.activeServerByName(c, c.Param("name"))
+	if !ok {
+		return
+	}
 
-	// Get MCP Server
+	// Format tools according to MCP protocol specification
+	toolsResponse := make([]map[string]interface{}, 0, len(server.Tools))
+	for _, tool := range server.Tools {
+		if !tool.IsEnabled() {
+			continue
+		}
+		parametersSchema, bodyProperties, requiredBodyParams, headerProperties := inferParametersSchema(tool)
+
+		// Generate examples with the correct format
+		examples := generateParameterExamplesWithHeadersAndBody(tool, bodyProperties, requiredBodyParams, headerProperties)
+
+		inputSchema, outputSchema := h.toolSchemas(c.Request.Context(), tool, parametersSchema)
+		toolDef := map[string]interface{}{
+			"name":        tool.ExposedName(),
+			"description": tool.ExposedDescription(),
+			"inputSchema": inputSchema,
+			"parameters":  parametersSchema,
+			"examples":    examples,
+		}
+		if outputSchema != nil {
+			toolDef["outputSchema"] = outputSchema
+		}
+
+		toolsResponse = append(toolsResponse, toolDef)
+	}
+
+	c.JSON(http.StatusOK, toolsResponse)
+}
+
+// OpenAITool is a tool in the format of the tools of the OpenAI Chat Completions API
+type OpenAITool struct {
+	Type     string         `json:"type"` // Always "function"
+	Function OpenAIFunction `json:"function"`
+}
+
+// OpenAIFunction is the function definition of an OpenAITool
+type OpenAIFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"` // JSON Schema of the arguments
+}
+
+// GetMCPServerOpenAITools exports the tools of an MCP server as OpenAI function definitions
+//
+// The parameters are the input schemas listed by GetMCPServerTools, so agent frameworks
+// using OpenAI function calling can pass the arguments they receive to the tool endpoints.
+//
+// @Summary Export the tools of an MCP server for OpenAI function calling
+// @Tags mcp-protocol
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Success 200 {array} OpenAITool
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/openai-tools [get]
+func (h *MCPServerHandler) GetMCPServerOpenAITools(c *gin.Context) {
+	server, ok := h.activeServerByName(c, c.Param("name"))
+	if !ok {
+		return
+	}
+
+	tools := make([]OpenAITool, 0, len(server.Tools))
+	for _, tool := range server.Tools {
+		if !tool.IsEnabled() {
+			continue
+		}
+		tools = append(tools, OpenAITool{
+			Type: "function",
+			Function: OpenAIFunction{
+				Name:        functionName(tool.ExposedName()),
+				Description: tool.ExposedDescription(),
+				Parameters:  h.exportedInputSchema(c.Request.Context(), tool),
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, tools)
+}
+
+// AnthropicTool is a tool in the format of the tools of the Anthropic Messages API
+type AnthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"` // JSON Schema of the arguments
+}
+
+// GetMCPServerAnthropicTools exports the tools of an MCP server as Anthropic tool definitions
+//
+// @Summary Export the tools of an MCP server for Anthropic tool use
+// @Tags mcp-protocol
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Success 200 {array} AnthropicTool
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/anthropic-tools [get]
+func (h *MCPServerHandler) GetMCPServerAnthropicTools(c *gin.Context) {
+	server, ok := h.activeServerByName(c, c.Param("name"))
+	if !ok {
+		return
+	}
+
+	tools := make([]AnthropicTool, 0, len(server.Tools))
+	for _, tool := range server.Tools {
+		if !tool.IsEnabled() {
+			continue
+		}
+		tools = append(tools, AnthropicTool{
+			Name:        functionName(tool.ExposedName()),
+			Description: tool.ExposedDescription(),
+			InputSchema: h.exportedInputSchema(c.Request.Context(), tool),
+		})
+	}
+
+	c.JSON(http.StatusOK, tools)
+}
+
+// activeServerByName returns the active MCP server of the name, or writes the error response
+func (h *MCPServerHandler) activeServerByName(c *gin.Context, name string) (*models.MCPServer, bool) {
 	server, err := h.mcpRepo.GetByName(c.Request.Context(), name)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
-			return
+			if router.RedirectRenamed(c, h.aliases, h.mcpRepo, name) {
+				return nil, false
+			}
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return nil, false
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
 	}
 
-	// Check if server is active
 	if server.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
-		return
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
+		return nil, false
 	}
+	return server, true
+}
 
-	// Format tools according to MCP protocol specification
-	toolsResponse := make([]map[string]interface{}, 0, len(server.Tools))
-	for _, tool := range server.Tools {
-		// Create parameters structure with headers and body separation
-		parametersSchema := map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"headers": map[string]interface{}{
-					"type":        "object",
-					"description": "HTTP headers to include in the request",
-					"properties": map[string]interface{}{
-						"authorization": map[string]interface{}{
-							"type":        "string",
-							"description": "Bearer token for authentication",
-						},
-						"content-type": map[string]interface{}{
-							"type":        "string",
-							"description": "Content type header",
-							"default":     "application/json;charset=UTF-8",
-						},
-						"accept": map[string]interface{}{
-							"type":        "string",
-							"description": "Accept header",
-							"default":     "application/json, text/plain, */*",
-						},
+// exportedInputSchema returns the input schema of a tool as listed by GetMCPServerTools
+func (h *MCPServerHandler) exportedInputSchema(ctx context.Context, tool models.Tool) map[string]interface{} {
+	parametersSchema, _, _, _ := inferParametersSchema(tool)
+	inputSchema, _ := h.toolSchemas(ctx, tool, parametersSchema)
+	return inputSchema
+}
+
+// functionName replaces the characters that function calling APIs reject in names with
+// underscores and truncates the name to 64 characters
+func functionName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	result := b.String()
+	if len(result) > 64 {
+		result = result[:64]
+	}
+	return result
+}
+
+// inferParametersSchema builds the parameters schema of a tool from its request template, for tools
+// without a stored input schema. It also returns the body properties, required body parameters and
+// header properties it contains.
+func inferParametersSchema(tool models.Tool) (map[string]interface{}, map[string]interface{}, []string, map[string]interface{}) {
+	// Create parameters structure with headers and body separation
+	parametersSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"headers": map[string]interface{}{
+				"type":        "object",
+				"description": "HTTP headers to include in the request",
+				"properties": map[string]interface{}{
+					"authorization": map[string]interface{}{
+						"type":        "string",
+						"description": "Bearer token for authentication",
+					},
+					"content-type": map[string]interface{}{
+						"type":        "string",
+						"description": "Content type header",
+						"default":     "application/json;charset=UTF-8",
+					},
+					"accept": map[string]interface{}{
+						"type":        "string",
+						"description": "Accept header",
+						"default":     "application/json, text/plain, */*",
 					},
-				},
-				"body": map[string]interface{}{
-					"type":        "object",
-					"description": "Request body data",
 				},
 			},
-			"required": []string{"body"},
-		}
+			"body": map[string]interface{}{
+				"type":        "object",
+				"description": "Request body data",
+			},
+		},
+		"required": []string{"body"},
+	}
 
-		// Extract parameters from URL path and add to body properties
-		bodyProperties := make(map[string]interface{})
-		requiredBodyParams := []string{}
+	// Extract parameters from URL path and add to body properties
+	bodyProperties := make(map[string]interface{})
+	requiredBodyParams := []string{}
 
-		// Look for parameters in the URL path format {paramName}
-		url := tool.RequestTemplate.URL
-		urlParams := extractURLParams(url)
-		for _, param := range urlParams {
-			bodyProperties[param] = map[string]interface{}{
-				"type":        "string",
-				"description": fmt.Sprintf("Path parameter '%s'", param),
-			}
-			requiredBodyParams = append(requiredBodyParams, param)
+	// Look for parameters in the URL path format {paramName}
+	url := tool.RequestTemplate.URL
+	urlParams := extractURLParams(url)
+	for _, param := range urlParams {
+		bodyProperties[param] = map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Path parameter '%s'", param),
 		}
+		requiredBodyParams = append(requiredBodyParams, param)
+	}
 
-		// Add query parameters to body properties if they can be inferred from the URL
-		queryParams := extractQueryParams(url)
-		for paramName, paramDefault := range queryParams {
-			bodyProperties[paramName] = map[string]interface{}{
-				"type":        "string",
-				"description": fmt.Sprintf("Query parameter '%s'", paramName),
-				"default":     paramDefault,
-			}
-			// Query parameters are often optional, so not adding to required
+	// Add query parameters to body properties if they can be inferred from the URL
+	queryParams := extractQueryParams(url)
+	for paramName, paramDefault := range queryParams {
+		bodyProperties[paramName] = map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Query parameter '%s'", paramName),
+			"default":     paramDefault,
 		}
+		// Query parameters are often optional, so not adding to required
+	}
 
-		// Add body parameters based on the request template
-		if tool.RequestTemplate.Method == "POST" || tool.RequestTemplate.Method == "PUT" || tool.RequestTemplate.Method == "PATCH" {
-			// Extract params from request template if available
-			bodyParams := extractBodyParams(tool.RequestTemplate.Body)
-			if len(bodyParams) > 0 {
-				for paramName, paramInfo := range bodyParams {
-					bodyProperties[paramName] = paramInfo
-					if paramInfo["required"] == true {
-						requiredBodyParams = append(requiredBodyParams, paramName)
-					}
+	// Add body parameters based on the request template
+	if tool.RequestTemplate.Method == "POST" || tool.RequestTemplate.Method == "PUT" || tool.RequestTemplate.Method == "PATCH" {
+		// Extract params from request template if available
+		bodyParams := extractBodyParams(tool.RequestTemplate.Body)
+		if len(bodyParams) > 0 {
+			for paramName, paramInfo := range bodyParams {
+				bodyProperties[paramName] = paramInfo
+				if paramInfo["required"] == true {
+					requiredBodyParams = append(requiredBodyParams, paramName)
 				}
 			}
 		}
+	}
 
-		// Update the body properties in the parameters schema
-		bodySchema := parametersSchema["properties"].(map[string]interface{})["body"].(map[string]interface{})
-		bodySchema["properties"] = bodyProperties
-		bodySchema["required"] = requiredBodyParams
+	// Update the body properties in the parameters schema
+	bodySchema := parametersSchema["properties"].(map[string]interface{})["body"].(map[string]interface{})
+	bodySchema["properties"] = bodyProperties
+	bodySchema["required"] = requiredBodyParams
 
-		// Add headers from template to header properties
-		headerProperties := parametersSchema["properties"].(map[string]interface{})["headers"].(map[string]interface{})["properties"].(map[string]interface{})
-		for headerName, headerValue := range tool.RequestTemplate.Headers {
-			headerProperties[headerName] = map[string]interface{}{
-				"type":        "string",
-				"description": fmt.Sprintf("Header '%s'", headerName),
-				"default":     headerValue,
-			}
+	// Add headers from template to header properties
+	headerProperties := parametersSchema["properties"].(map[string]interface{})["headers"].(map[string]interface{})["properties"].(map[string]interface{})
+	for headerName, headerValue := range tool.RequestTemplate.Headers {
+		headerProperties[headerName] = map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Header '%s'", headerName),
+			"default":     headerValue,
 		}
+	}
 
-		// Generate examples with the correct format
-		examples := generateParameterExamplesWithHeadersAndBody(tool, bodyProperties, requiredBodyParams, headerProperties)
+	return parametersSchema, bodyProperties, requiredBodyParams, headerProperties
+}
 
-		toolDef := map[string]interface{}{
-			"name":        tool.Name,
-			"description": tool.Description,
-			"parameters":  parametersSchema,
-			"examples":    examples,
+// toolSchemas returns the input and output schemas of a tool as clients see them. Tools generated
+// before the schemas were kept get them from their HTTP interface, the others fall back to the
+// input schema inferred from the template and have no output schema.
+func (h *MCPServerHandler) toolSchemas(ctx context.Context, tool models.Tool, inferred map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if tool.InputSchema != nil {
+		return tool.ExposedInputSchema(tool.InputSchema), tool.OutputSchema
+	}
+	if tool.InterfaceID != "" {
+		if httpInterface, err := h.httpRepo.GetByID(ctx, tool.InterfaceID); err == nil {
+			return tool.ExposedInputSchema(httpInterface.InputSchema()), httpInterface.OutputSchema()
 		}
-
-		toolsResponse = append(toolsResponse, toolDef)
 	}
-
-	c.JSON(http.StatusOK, toolsResponse)
+	return tool.ExposedInputSchema(inferred), nil
 }
 
 // generateParameterExamplesWithHeadersAndBody creates example parameter objects with separated headers and body
@@ -1024,6 +2924,15 @@ func generateParameterExamples(tool models.Tool, properties map[string]interface
 }
 
 // GetMCPServerResources provides resources metadata conforming to MCP protocol
+//
+// @Summary List the resources of an MCP server
+// @Tags mcp-protocol
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Success 200 {array} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/resources [get]
 func (h *MCPServerHandler) GetMCPServerResources(c *gin.Context) {
 	name := c.Param("name")
 
@@ -1031,16 +2940,19 @@ func (h *MCPServerHandler) GetMCPServerResources(c *gin.Context) {
 	server, err := h.mcpRepo.GetByName(c.Request.Context(), name)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			if router.RedirectRenamed(c, h.aliases, h.mcpRepo, name) {
+				return
+			}
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Check if server is active
 	if server.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
 		return
 	}
 
@@ -1050,6 +2962,15 @@ func (h *MCPServerHandler) GetMCPServerResources(c *gin.Context) {
 }
 
 // GetMCPServerPrompts provides prompts metadata conforming to MCP protocol
+//
+// @Summary List the prompts of an MCP server
+// @Tags mcp-protocol
+// @Produce json
+// @Param name path string true "MCP server name"
+// @Success 200 {array} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-server/{name}/prompts [get]
 func (h *MCPServerHandler) GetMCPServerPrompts(c *gin.Context) {
 	name := c.Param("name")
 
@@ -1057,16 +2978,19 @@ func (h *MCPServerHandler) GetMCPServerPrompts(c *gin.Context) {
 	server, err := h.mcpRepo.GetByName(c.Request.Context(), name)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			if router.RedirectRenamed(c, h.aliases, h.mcpRepo, name) {
+				return
+			}
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Check if server is active
 	if server.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
 		return
 	}
 
@@ -1080,70 +3004,71 @@ func (h *MCPServerHandler) InvokeToolMCP(c *gin.Context) {
 	name := c.Param("name")
 	toolName := c.Param("tool")
 
-	fmt.Printf("INFO: Processing MCP tool invocation request: server=%s, tool=%s\n", name, toolName)
+	slog.InfoContext(c.Request.Context(), "Processing MCP tool invocation request", "server", name, "tool", toolName)
 
 	// Get MCP Server
 	server, err := h.mcpRepo.GetByName(c.Request.Context(), name)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			fmt.Printf("ERROR: MCP Server not found: name=%s\n", name)
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			if router.RedirectRenamed(c, h.aliases, h.mcpRepo, name) {
+				return
+			}
+			slog.ErrorContext(c.Request.Context(), "MCP Server not found", "name", name)
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		fmt.Printf("ERROR: Failed to get MCP server: name=%s, error=%v\n", name, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to get MCP server", "name", name, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Check if the server is active
 	if server.Status != "active" {
-		fmt.Printf("ERROR: MCP Server is not active: name=%s, status=%s\n", name, server.Status)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP Server is not active"})
+		slog.ErrorContext(c.Request.Context(), "MCP Server is not active", "name", name, "status", server.Status)
+		apierror.Respond(c, http.StatusBadRequest, "MCP Server is not active")
 		return
 	}
 
 	// Check if the tool exists
-	toolExists := false
-	for _, allowed := range server.AllowTools {
-		if allowed == toolName {
-			toolExists = true
-			break
-		}
-	}
-	if !toolExists {
-		fmt.Printf("ERROR: Tool not found or not allowed: server=%s, tool=%s\n", name, toolName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tool not found or not allowed"})
+	if !server.AllowsTool(toolName) {
+		slog.ErrorContext(c.Request.Context(), "Tool not found or not allowed", "server", name, "tool", toolName)
+		apierror.Respond(c, http.StatusNotFound, "Tool not found or not allowed")
 		return
 	}
 
 	// Ensure server is registered
-	fmt.Printf("INFO: Ensuring server is registered with MCP service: name=%s\n", name)
+	slog.InfoContext(c.Request.Context(), "Ensuring server is registered with MCP service", "name", name)
 	err = h.mcpService.RegisterServer(server)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to register server with MCP service: name=%s, error=%v\n", name, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register server: " + err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to register server with MCP service", "name", name, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register server: "+err.Error())
 		return
 	}
 
 	// Get tool parameters
 	var params map[string]interface{}
 	if err := c.ShouldBindJSON(&params); err != nil {
-		fmt.Printf("WARNING: Could not parse request body, using empty params: error=%v\n", err)
+		slog.WarnContext(c.Request.Context(), "Could not parse request body, using empty params", "error", err)
 		params = make(map[string]interface{})
 	} else {
-		fmt.Printf("INFO: Parsed parameters: %v\n", params)
+		slog.InfoContext(c.Request.Context(), "Parsed parameters", "params", params)
 	}
 
 	// Execute the tool
-	fmt.Printf("INFO: Executing tool request via MCP: server=%s, tool=%s\n", name, toolName)
-	result, err := h.mcpService.HandleToolRequest(c.Request.Context(), server.ID, toolName, params)
+	slog.InfoContext(c.Request.Context(), "Executing tool request via MCP", "server", name, "tool", toolName)
+	var truncated bool
+	ctx := mcp.WithTruncation(c.Request.Context(), &truncated)
+	result, err := h.mcpService.HandleToolRequest(ctx, server.ID, toolName, params)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to execute tool: server=%s, tool=%s, error=%v\n", name, toolName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute tool: " + err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to execute tool", "server", name, "tool", toolName, "error", err)
+		apierror.RespondCode(c, mcp.ErrorStatus(err), errorCode(err), "Failed to execute tool: "+err.Error())
 		return
 	}
+	if truncated {
+		c.Header(mcp.TruncatedHeader, "true")
+	}
 
-	fmt.Printf("INFO: Tool executed successfully: server=%s, tool=%s\n", name, toolName)
+	slog.InfoContext(c.Request.Context(), "Tool executed successfully", "server", name, "tool", toolName)
 
 	// Format the response according to MCP protocol
 	// Try to parse result as JSON
@@ -1160,15 +3085,24 @@ func (h *MCPServerHandler) InvokeToolMCP(c *gin.Context) {
 }
 
 // GetMCPServerMetadata returns detailed metadata about an MCP server
+//
+// @Summary Get the metadata of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/metadata [get]
 func (h *MCPServerHandler) GetMCPServerMetadata(c *gin.Context) {
 	id := c.Param("id")
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1177,6 +3111,7 @@ func (h *MCPServerHandler) GetMCPServerMetadata(c *gin.Context) {
 		"id":             server.ID,
 		"name":           server.Name,
 		"description":    server.Description,
+		"instructions":   server.ServerInstructions(),
 		"version":        server.Version,
 		"status":         server.Status,
 		"mcp_compliance": "2025-03-26", // MCP specification version
@@ -1198,8 +3133,8 @@ func (h *MCPServerHandler) GetMCPServerMetadata(c *gin.Context) {
 	toolsSummary := make([]map[string]interface{}, 0, len(server.Tools))
 	for _, tool := range server.Tools {
 		toolsSummary = append(toolsSummary, map[string]interface{}{
-			"name":        tool.Name,
-			"description": tool.Description,
+			"name":        tool.ExposedName(),
+			"description": tool.ExposedDescription(),
 			"method":      tool.RequestTemplate.Method,
 			"url":         tool.RequestTemplate.URL,
 		})
@@ -1210,15 +3145,24 @@ func (h *MCPServerHandler) GetMCPServerMetadata(c *gin.Context) {
 }
 
 // GetMCPServerUsageGuide returns a comprehensive usage guide for an MCP server
+//
+// @Summary Get the usage guide of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/usage-guide [get]
 func (h *MCPServerHandler) GetMCPServerUsageGuide(c *gin.Context) {
 	id := c.Param("id")
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1263,18 +3207,107 @@ func (h *MCPServerHandler) GetMCPServerUsageGuide(c *gin.Context) {
 }
 
 // GetMCPServerClientExamples returns example client code for different languages
+//
+// @Summary Get client code examples for an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} object
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/client-examples [get]
 func (h *MCPServerHandler) GetMCPServerClientExamples(c *gin.Context) {
 	id := c.Param("id")
 	server, err := h.mcpRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if err == repository.ErrNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "MCP Server not found"})
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	baseUrl := requestBaseURL(c)
+
+	// Generate example code for different programming languages
+	examples := map[string]interface{}{
+		"python":     generatePythonClientExample(server, baseUrl),
+		"javascript": generateJavaScriptClientExample(server, baseUrl),
+		"go":         generateGoClientExample(server, baseUrl),
+		"java":       generateJavaClientExample(server, baseUrl),
+	}
+
+	c.JSON(http.StatusOK, examples)
+}
+
+// ClientConfig holds ready-to-paste configuration blocks connecting MCP clients to a server
+// through the Streamable HTTP transport of the gateway
+type ClientConfig struct {
+	URL           string                 `json:"url"`           // Streamable HTTP endpoint of the server
+	ClaudeDesktop map[string]interface{} `json:"claudeDesktop"` // claude_desktop_config.json, through the mcp-remote bridge
+	Cursor        map[string]interface{} `json:"cursor"`        // .cursor/mcp.json
+	VSCode        map[string]interface{} `json:"vscode"`        // VS Code settings.json
+}
+
+// GetMCPServerClientConfig returns the configuration of MCP clients for a server
+//
+// @Summary Get MCP client configuration for an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} ClientConfig
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/client-config [get]
+func (h *MCPServerHandler) GetMCPServerClientConfig(c *gin.Context) {
+	server, err := h.mcpRepo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// Servers outside the default namespace are reached through the namespaced path, since
+	// clients cannot be relied on to send the namespace header
+	endpoint := requestBaseURL(c) + "/router/mcp-servers/" + url.PathEscape(server.Name) + "/" + router.TransportPath
+	if owner := namespace.OrDefault(server.Namespace); owner != namespace.Default {
+		endpoint = requestBaseURL(c) + "/router/namespaces/" + url.PathEscape(owner) + "/mcp-servers/" + url.PathEscape(server.Name) + "/" + router.TransportPath
+	}
+
+	// mcp-remote refuses plain HTTP to hosts other than localhost unless allowed
+	remoteArgs := []string{"-y", "mcp-remote", endpoint}
+	if parsed, err := url.Parse(endpoint); err == nil && parsed.Scheme == "http" && parsed.Hostname() != "localhost" {
+		remoteArgs = append(remoteArgs, "--allow-http")
+	}
+
+	c.JSON(http.StatusOK, ClientConfig{
+		URL: endpoint,
+		ClaudeDesktop: map[string]interface{}{
+			"mcpServers": map[string]interface{}{
+				server.Name: map[string]interface{}{"command": "npx", "args": remoteArgs},
+			},
+		},
+		Cursor: map[string]interface{}{
+			"mcpServers": map[string]interface{}{
+				server.Name: map[string]interface{}{"url": endpoint},
+			},
+		},
+		VSCode: map[string]interface{}{
+			"mcp": map[string]interface{}{
+				"servers": map[string]interface{}{
+					server.Name: map[string]interface{}{"type": "http", "url": endpoint},
+				},
+			},
+		},
+	})
+}
+
+// requestBaseURL returns the scheme and host the gateway was reached at
+func requestBaseURL(c *gin.Context) string {
 	baseUrl := c.Request.Host // Get the current host
 	if baseUrl == "" {
 		baseUrl = "localhost:8080" // Default if not available
@@ -1288,16 +3321,7 @@ func (h *MCPServerHandler) GetMCPServerClientExamples(c *gin.Context) {
 		}
 		baseUrl = scheme + "://" + baseUrl
 	}
-
-	// Generate example code for different programming languages
-	examples := map[string]interface{}{
-		"python":     generatePythonClientExample(server, baseUrl),
-		"javascript": generateJavaScriptClientExample(server, baseUrl),
-		"go":         generateGoClientExample(server, baseUrl),
-		"java":       generateJavaClientExample(server, baseUrl),
-	}
-
-	c.JSON(http.StatusOK, examples)
+	return baseUrl
 }
 
 // Helper functions for the new endpoints
@@ -1357,9 +3381,9 @@ func generateToolsUsageGuide(server *models.MCPServer) []map[string]interface{}
 
 		// Compile the tool usage guide
 		toolGuide := map[string]interface{}{
-			"name":             tool.Name,
-			"description":      tool.Description,
-			"endpoint":         fmt.Sprintf("/api/mcp-server/%s/tools/%s", server.Name, tool.Name),
+			"name":             tool.ExposedName(),
+			"description":      tool.ExposedDescription(),
+			"endpoint":         fmt.Sprintf("/api/mcp-server/%s/tools/%s", server.Name, tool.ExposedName()),
 			"method":           "POST", // MCP always uses POST for tool invocation
 			"parameters":       paramDescriptions,
 			"example_request":  exampleRequest,
@@ -1495,7 +3519,7 @@ if __name__ == "__main__":
         print(f"Tool result: {json.dumps(result, indent=2)}")
     except Exception as e:
         print(f"Error: {e}")
-`, baseUrl, server.Name, sampleTool.Name, sampleParams, sampleTool.Name)
+`, baseUrl, server.Name, sampleTool.ExposedName(), sampleParams, sampleTool.ExposedName())
 }
 
 // generateJavaScriptClientExample creates JavaScript code to interact with the MCP server
@@ -1591,7 +3615,7 @@ async function run() {
 
 // Run the example
 run();
-`, baseUrl, server.Name, sampleParams, sampleTool.Name)
+`, baseUrl, server.Name, sampleParams, sampleTool.ExposedName())
 }
 
 // generateExampleParamsForTool creates sample parameters based on tool definition
@@ -1726,7 +3750,7 @@ func NewMCPClient(baseURL, serverName string) *MCPClient {
 
 // GetTools retrieves the list of available tools
 func (c *MCPClient) GetTools() ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("%s/api/mcp-server/%s/tools", c.BaseURL, c.ServerName)
+	url := fmt.Sprintf("%%s/api/mcp-server/%%s/tools", c.BaseURL, c.ServerName)
 	
 	resp, err := c.Client.Get(url)
 	if err != nil {
@@ -1736,7 +3760,7 @@ func (c *MCPClient) GetTools() ([]map[string]interface{}, error) {
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get tools: %s", string(body))
+		return nil, fmt.Errorf("failed to get tools: %%s", string(body))
 	}
 	
 	var tools []map[string]interface{}
@@ -1749,7 +3773,7 @@ func (c *MCPClient) GetTools() ([]map[string]interface{}, error) {
 
 // InvokeTool invokes a tool on the MCP server
 func (c *MCPClient) InvokeTool(toolName string, parameters map[string]interface{}) (map[string]interface{}, error) {
-	url := fmt.Sprintf("%s/api/mcp-server/%s/tools/%s", c.BaseURL, c.ServerName, toolName)
+	url := fmt.Sprintf("%%s/api/mcp-server/%%s/tools/%%s", c.BaseURL, c.ServerName, toolName)
 	
 	paramJSON, err := json.Marshal(parameters)
 	if err != nil {
@@ -1764,7 +3788,7 @@ func (c *MCPClient) InvokeTool(toolName string, parameters map[string]interface{
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to invoke tool: %s", string(body))
+		return nil, fmt.Errorf("failed to invoke tool: %%s", string(body))
 	}
 	
 	var result map[string]interface{}
@@ -1782,12 +3806,12 @@ func main() {
 	// Get available tools
 	tools, err := client.GetTools()
 	if err != nil {
-		fmt.Printf("Error getting tools: %v\n", err)
+		fmt.Printf("Error getting tools: %%v\n", err)
 		return
 	}
 	
 	toolsJSON, _ := json.MarshalIndent(tools, "", "  ")
-	fmt.Printf("Available tools: %s\n", string(toolsJSON))
+	fmt.Printf("Available tools: %%s\n", string(toolsJSON))
 	
 	// Invoke a specific tool
 	parameters := map[string]interface{}{
@@ -1796,14 +3820,14 @@ func main() {
 	
 	result, err := client.InvokeTool("%s", parameters)
 	if err != nil {
-		fmt.Printf("Error invoking tool: %v\n", err)
+		fmt.Printf("Error invoking tool: %%v\n", err)
 		return
 	}
 	
 	resultJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Printf("Tool result: %s\n", string(resultJSON))
+	fmt.Printf("Tool result: %%s\n", string(resultJSON))
 }
-`, baseUrl, server.Name, sampleTool.Name, sampleTool.Name)
+`, baseUrl, server.Name, sampleTool.ExposedName(), sampleTool.ExposedName())
 }
 
 // generateJavaClientExample creates Java code to interact with the MCP server
@@ -1844,7 +3868,7 @@ public class MCPClient {
     }
 
     public JSONArray getTools() throws IOException, InterruptedException {
-        String url = String.format("%s/api/mcp-server/%s/tools", baseUrl, serverName);
+        String url = String.format("%%s/api/mcp-server/%%s/tools", baseUrl, serverName);
         
         HttpRequest request = HttpRequest.newBuilder()
                 .GET()
@@ -1862,7 +3886,7 @@ public class MCPClient {
     }
 
     public JSONObject invokeTool(String toolName, JSONObject parameters) throws IOException, InterruptedException {
-        String url = String.format("%s/api/mcp-server/%s/tools/%s", baseUrl, serverName, toolName);
+        String url = String.format("%%s/api/mcp-server/%%s/tools/%%s", baseUrl, serverName, toolName);
         
         HttpRequest request = HttpRequest.newBuilder()
                 .POST(HttpRequest.BodyPublishers.ofString(parameters.toString()))
@@ -1902,5 +3926,46 @@ public class MCPClient {
         }
     }
 }
-`, baseUrl, server.Name, sampleTool.Name, sampleTool.Name)
+`, baseUrl, server.Name, sampleTool.ExposedName(), sampleTool.ExposedName())
+}
+
+// hasTool reports whether the server has a tool of the name
+func hasTool(server *models.MCPServer, name string) bool {
+	for _, tool := range server.Tools {
+		if tool.ExposedName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// externalErrorStatus returns the status reported for a failure to reach an external MCP server
+func externalErrorStatus(err error) int {
+	if errors.Is(err, mcp.ErrHostNotAllowed) || errors.Is(err, mcp.ErrStdioNotAllowed) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadGateway
+}
+
+// sourceErrorStatus returns the status reported for a failure to compose a virtual server
+func sourceErrorStatus(err error) int {
+	var conflict *models.ToolConflictError
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, mcp.ErrVirtualSource), errors.As(err, &conflict):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// hasInterface reports whether interfaces contains the HTTP interface with the ID
+func hasInterface(interfaces []models.HTTPInterface, id string) bool {
+	for _, httpInterface := range interfaces {
+		if httpInterface.ID == id {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/wangfeng/mcp-gateway2/docs"
+)
+
+// OpenAPIHandler serves the OpenAPI specification of the admin API and a Swagger UI.
+// The specification is generated from the handler annotations with
+// `swag init -g main.go -d ./cmd/server,./internal/api,./internal/config,./pkg/models,./pkg/mcp,./pkg/upstream -o docs --outputTypes go,json`.
+type OpenAPIHandler struct {
+	spec []byte // OpenAPI 3 document
+}
+
+// NewOpenAPIHandler creates a new OpenAPI handler, converting the generated Swagger 2.0 document to OpenAPI 3
+func NewOpenAPIHandler() (*OpenAPIHandler, error) {
+	var v2 openapi2.T
+	if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &v2); err != nil {
+		return nil, fmt.Errorf("failed to parse generated API specification: %w", err)
+	}
+
+	v3, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert API specification to OpenAPI 3: %w", err)
+	}
+
+	spec, err := json.Marshal(v3)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIHandler{spec: spec}, nil
+}
+
+// RegisterRoutes registers the specification and Swagger UI routes
+func (h *OpenAPIHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/api/openapi.json", h.GetSpec)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/api/openapi.json")))
+}
+
+// GetSpec returns the OpenAPI 3 specification of the admin API
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.spec)
+}
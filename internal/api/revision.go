@@ -0,0 +1,282 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ReviewRevisionRequest is the optional request of approving or rejecting a revision
+type ReviewRevisionRequest struct {
+	Comment string `json:"comment"` // E.g. the reason of a rejection
+}
+
+// SetApproval makes the changes of active servers, syncs included, pending revisions while required
+// returns true.
+// Revisions are approved with the bearer token returned by required.
+func (h *MCPServerHandler) SetApproval(revisions repository.RevisionRepository, required func() (bool, string)) {
+	h.revisions = revisions
+	h.approval = required
+	h.syncer.SetApproval(revisions, func() bool {
+		enabled, _ := required()
+		return enabled
+	})
+}
+
+// submitRevision stores the change of an active server as a pending revision and responds with
+// 202 Accepted if approval is required. It returns false if the change can be applied at once.
+func (h *MCPServerHandler) submitRevision(c *gin.Context, current *models.MCPServer, proposed *models.MCPServer, change string) bool {
+	if h.revisions == nil || current.Status != "active" {
+		return false
+	}
+	if required, _ := h.approval(); !required {
+		return false
+	}
+
+	revision, err := repository.SubmitRevision(c.Request.Context(), h.revisions, current, proposed, change)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return true
+	}
+
+	c.JSON(http.StatusAccepted, revision)
+	return true
+}
+
+// GetRevisions returns the revisions of all MCP servers, newest first
+//
+// @Summary List the revisions of MCP servers
+// @Tags revisions
+// @Produce json
+// @Param status query string false "pending, approved or rejected"
+// @Success 200 {array} models.Revision
+// @Failure 500 {object} ErrorResponse
+// @Router /api/revisions [get]
+func (h *MCPServerHandler) GetRevisions(c *gin.Context) {
+	h.listRevisions(c, "")
+}
+
+// GetMCPServerRevisions returns the revisions of an MCP server, newest first
+//
+// @Summary List the revisions of an MCP server
+// @Tags revisions
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param status query string false "pending, approved or rejected"
+// @Success 200 {array} models.Revision
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/revisions [get]
+func (h *MCPServerHandler) GetMCPServerRevisions(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	h.listRevisions(c, server.ID)
+}
+
+// listRevisions responds with the revisions of a server, or of all servers, with the status of the query
+func (h *MCPServerHandler) listRevisions(c *gin.Context, serverID string) {
+	status := c.Query("status")
+	switch status {
+	case "", models.RevisionPending, models.RevisionApproved, models.RevisionRejected:
+	default:
+		apierror.Respond(c, http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+
+	revisions, err := h.revisions.List(c.Request.Context(), serverID, status)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetRevision returns a revision with the proposed definition of its server
+//
+// @Summary Get a revision of an MCP server
+// @Tags revisions
+// @Produce json
+// @Param id path string true "Revision ID"
+// @Success 200 {object} models.Revision
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/revisions/{id} [get]
+func (h *MCPServerHandler) GetRevision(c *gin.Context) {
+	revision, ok := h.revision(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, revision)
+}
+
+// ApproveRevision applies a pending revision to its server, which then serves the new definition.
+// The server keeps its current status. Requires the approver token.
+//
+// @Summary Approve a revision of an MCP server
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param id path string true "Revision ID"
+// @Param Authorization header string true "Bearer approver token"
+// @Param request body ReviewRevisionRequest false "Comment of the approver"
+// @Success 200 {object} models.Revision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/revisions/{id}/approve [post]
+func (h *MCPServerHandler) ApproveRevision(c *gin.Context) {
+	revision, req, ok := h.reviewRequest(c)
+	if !ok {
+		return
+	}
+
+	current, ok := h.server(c, revision.ServerID)
+	if !ok {
+		return
+	}
+	if rejectArchivedServer(c, current) {
+		return
+	}
+	if current.Version != revision.BaseVersion {
+		apierror.Respond(c, http.StatusConflict, "MCP Server changed since the revision was submitted, reject it and submit the change again")
+		return
+	}
+
+	server := revision.Server
+	server.ID = current.ID
+	server.Status = current.Status
+	if server.Name != current.Name {
+		if err := h.validator.ValidateName(c.Request.Context(), server.Name, server.ID); err != nil {
+			apierror.RespondCode(c, nameErrorStatus(err), errorCode(err), err.Error())
+			return
+		}
+	}
+	ctx := repository.WithChangeNote(c.Request.Context(), server.Changelog)
+	if err := h.mcpRepo.Update(ctx, &server); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.RespondCode(c, createErrorStatus(err), errorCode(err), err.Error())
+		return
+	}
+
+	// Serve the approved definition
+	if server.Status == "inactive" {
+		h.mcpService.UnregisterServer(server.ID)
+	} else {
+		h.mcpService.RefreshServer(&server)
+	}
+	if _, err := h.syncer.SyncSource(c.Request.Context(), server.ID); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to sync virtual servers with source", "id", server.ID, "error", err)
+	}
+
+	revision.Status = models.RevisionApproved
+	revision.Comment = req.Comment
+	revision.Version = server.Version
+	if !h.review(c, revision) {
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Approved MCP server revision", "id", revision.ID, "server", server.ID, "version", server.Version)
+	c.JSON(http.StatusOK, revision)
+}
+
+// RejectRevision rejects a pending revision, its server keeps its current definition. Requires
+// the approver token.
+//
+// @Summary Reject a revision of an MCP server
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param id path string true "Revision ID"
+// @Param Authorization header string true "Bearer approver token"
+// @Param request body ReviewRevisionRequest false "Reason of the rejection"
+// @Success 200 {object} models.Revision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/revisions/{id}/reject [post]
+func (h *MCPServerHandler) RejectRevision(c *gin.Context) {
+	revision, req, ok := h.reviewRequest(c)
+	if !ok {
+		return
+	}
+
+	revision.Status = models.RevisionRejected
+	revision.Comment = req.Comment
+	if !h.review(c, revision) {
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Rejected MCP server revision", "id", revision.ID, "server", revision.ServerID)
+	c.JSON(http.StatusOK, revision)
+}
+
+// reviewRequest authorizes an approver and returns the pending revision of the request path
+// with the comment of the request. It responds with an error otherwise.
+func (h *MCPServerHandler) reviewRequest(c *gin.Context) (*models.Revision, ReviewRevisionRequest, bool) {
+	var req ReviewRevisionRequest
+	_, token := h.approval()
+	if !authorizeAdmin(c, token, "Reviewing revisions") {
+		return nil, req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return nil, req, false
+	}
+
+	revision, ok := h.revision(c)
+	if !ok {
+		return nil, req, false
+	}
+	if revision.Status != models.RevisionPending {
+		apierror.Respond(c, http.StatusConflict, "Revision is already "+revision.Status)
+		return nil, req, false
+	}
+	return revision, req, true
+}
+
+// review records the review of a revision. It responds with an error if it fails.
+func (h *MCPServerHandler) review(c *gin.Context, revision *models.Revision) bool {
+	if err := h.revisions.Review(c.Request.Context(), revision); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			apierror.Respond(c, http.StatusNotFound, "Revision not found")
+		case repository.ErrRevisionReviewed:
+			apierror.Respond(c, http.StatusConflict, "Revision was already reviewed")
+		default:
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		}
+		return false
+	}
+	return true
+}
+
+// revision returns the revision of the request path. It responds with an error otherwise.
+func (h *MCPServerHandler) revision(c *gin.Context) (*models.Revision, bool) {
+	revision, err := h.revisions.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Revision not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return revision, true
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// RouterHandler handles API requests for routers
+type RouterHandler struct {
+	repo repository.RouterRepository
+}
+
+// NewRouterHandler creates a new router handler
+func NewRouterHandler(repo repository.RouterRepository) *RouterHandler {
+	return &RouterHandler{
+		repo: repo,
+	}
+}
+
+// RegisterRoutes registers the router API routes
+func (h *RouterHandler) RegisterRoutes(router *gin.Engine) {
+	routerGroup := router.Group("/api/routers")
+	{
+		routerGroup.GET("", h.GetAllRouters)
+		routerGroup.GET("/:id", h.GetRouter)
+		routerGroup.POST("", h.CreateRouter)
+		routerGroup.PUT("/:id", h.UpdateRouter)
+		routerGroup.DELETE("/:id", h.DeleteRouter)
+		routerGroup.GET("/:id/versions", h.GetRouterVersions)
+		routerGroup.GET("/:id/versions/:version", h.GetRouterByVersion)
+		routerGroup.POST("/:id/activate", h.ActivateRouter)
+		routerGroup.POST("/:id/deactivate", h.DeactivateRouter)
+	}
+}
+
+// GetAllRouters returns all routers
+//
+// @Summary List routers
+// @Tags routers
+// @Produce json
+// @Success 200 {array} models.Router
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers [get]
+func (h *RouterHandler) GetAllRouters(c *gin.Context) {
+	routers, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, routers)
+}
+
+// GetRouter returns a specific router
+//
+// @Summary Get a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Success 200 {object} models.Router
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id} [get]
+func (h *RouterHandler) GetRouter(c *gin.Context) {
+	id := c.Param("id")
+	router, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, router)
+}
+
+// CreateRouter creates a new router
+//
+// @Summary Create a router
+// @Tags routers
+// @Accept json
+// @Produce json
+// @Param router body models.Router true "Router"
+// @Success 201 {object} models.Router
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers [post]
+func (h *RouterHandler) CreateRouter(c *gin.Context) {
+	var router models.Router
+	if err := c.ShouldBindJSON(&router); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := router.PrepareRules(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &router); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, router)
+}
+
+// UpdateRouter updates a router
+//
+// @Summary Update a router
+// @Tags routers
+// @Accept json
+// @Produce json
+// @Param id path string true "Router ID"
+// @Param router body models.Router true "Router"
+// @Success 200 {object} models.Router
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id} [put]
+func (h *RouterHandler) UpdateRouter(c *gin.Context) {
+	id := c.Param("id")
+	var router models.Router
+	if err := c.ShouldBindJSON(&router); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	router.ID = id
+
+	if err := router.PrepareRules(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &router); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, router)
+}
+
+// DeleteRouter deletes a router
+//
+// @Summary Delete a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id} [delete]
+func (h *RouterHandler) DeleteRouter(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetRouterVersions returns all versions of a router
+//
+// @Summary List the versions of a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Success 200 {array} integer
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id}/versions [get]
+func (h *RouterHandler) GetRouterVersions(c *gin.Context) {
+	id := c.Param("id")
+	versions, err := h.repo.GetVersions(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetRouterByVersion returns a specific version of a router
+//
+// @Summary Get a version of a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Param version path int true "Version"
+// @Success 200 {object} models.Router
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id}/versions/{version} [get]
+func (h *RouterHandler) GetRouterByVersion(c *gin.Context) {
+	id := c.Param("id")
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "Invalid version number")
+		return
+	}
+
+	router, err := h.repo.GetByVersion(c.Request.Context(), id, version)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router or version not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, router)
+}
+
+// ActivateRouter activates a router so its rules are evaluated by the gateway
+//
+// @Summary Activate a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id}/activate [post]
+func (h *RouterHandler) ActivateRouter(c *gin.Context) {
+	h.updateStatus(c, "active", "Router activated successfully")
+}
+
+// DeactivateRouter deactivates a router
+//
+// @Summary Deactivate a router
+// @Tags routers
+// @Produce json
+// @Param id path string true "Router ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/routers/{id}/deactivate [post]
+func (h *RouterHandler) DeactivateRouter(c *gin.Context) {
+	h.updateStatus(c, "inactive", "Router deactivated successfully")
+}
+
+func (h *RouterHandler) updateStatus(c *gin.Context, status string, message string) {
+	id := c.Param("id")
+	if err := h.repo.UpdateStatus(c.Request.Context(), id, status); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Router not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// GetMCPServerSchedule returns the activation schedule of an MCP server with its next
+// activation and deactivation
+//
+// @Summary Get the activation schedule of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} models.ScheduleStatus
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/schedule [get]
+func (h *MCPServerHandler) GetMCPServerSchedule(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, mcp.ScheduleStatus(server, time.Now()))
+}
+
+// SetMCPServerSchedule sets the times an MCP server is activated and deactivated at. One-off
+// times and cron expressions can be combined, the scheduler applies the latest one that is due.
+// The schedule of an active server is submitted for approval if required.
+//
+// @Summary Set the activation schedule of an MCP server
+// @Tags mcp-servers
+// @Accept json
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param schedule body models.ActivationSchedule true "Activation schedule"
+// @Success 200 {object} models.ScheduleStatus
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/schedule [put]
+func (h *MCPServerHandler) SetMCPServerSchedule(c *gin.Context) {
+	var schedule models.ActivationSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := schedule.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	if rejectArchivedServer(c, server) {
+		return
+	}
+
+	server.Schedule = &schedule
+	if h.submitRevision(c, server, server, "schedule") {
+		return
+	}
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Set MCP server schedule", "id", server.ID, "name", server.Name)
+	c.JSON(http.StatusOK, mcp.ScheduleStatus(server, time.Now()))
+}
+
+// DeleteMCPServerSchedule removes the activation schedule of an MCP server, which keeps its status
+//
+// @Summary Remove the activation schedule of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {object} MessageResponse
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/schedule [delete]
+func (h *MCPServerHandler) DeleteMCPServerSchedule(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	if server.Schedule == nil {
+		apierror.Respond(c, http.StatusNotFound, "MCP Server has no schedule")
+		return
+	}
+
+	server.Schedule = nil
+	if h.submitRevision(c, server, server, "schedule removal") {
+		return
+	}
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Removed MCP server schedule", "id", server.ID, "name", server.Name)
+	c.JSON(http.StatusOK, gin.H{"message": "MCP Server schedule removed successfully"})
+}
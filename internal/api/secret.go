@@ -0,0 +1,406 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/rotation"
+)
+
+// secretNamePattern restricts the names referenced by auth profiles
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// SecretHandler handles API requests for secrets. Values, staged ones included, are write-only:
+// responses never include them.
+type SecretHandler struct {
+	repo       repository.SecretRepository
+	rotator    *rotation.Rotator
+	adminToken func() string // Current admin token, required to change secrets
+}
+
+// NewSecretHandler creates a new secret handler
+func NewSecretHandler(repo repository.SecretRepository, rotator *rotation.Rotator, adminToken func() string) *SecretHandler {
+	return &SecretHandler{
+		repo:       repo,
+		rotator:    rotator,
+		adminToken: adminToken,
+	}
+}
+
+// RegisterRoutes registers the secret API routes
+func (h *SecretHandler) RegisterRoutes(router *gin.Engine) {
+	secretGroup := router.Group("/api/secrets")
+	{
+		secretGroup.GET("", h.GetAllSecrets)
+		secretGroup.GET("/:id", h.GetSecret)
+		secretGroup.POST("", h.CreateSecret)
+		secretGroup.PUT("/:id", h.UpdateSecret)
+		secretGroup.DELETE("/:id", h.DeleteSecret)
+		secretGroup.GET("/:id/references", h.GetReferences)
+		secretGroup.GET("/:id/rotation", h.GetRotation)
+		secretGroup.POST("/:id/rotate", h.RotateSecret)
+	}
+}
+
+// GetAllSecrets returns all secrets without their values
+//
+// @Summary List secrets
+// @Tags secrets
+// @Produce json
+// @Success 200 {array} models.Secret
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets [get]
+func (h *SecretHandler) GetAllSecrets(c *gin.Context) {
+	secrets, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for i := range secrets {
+		hideValues(&secrets[i])
+	}
+	c.JSON(http.StatusOK, secrets)
+}
+
+// GetSecret returns a specific secret without its value
+//
+// @Summary Get a secret
+// @Tags secrets
+// @Produce json
+// @Param id path string true "Secret ID"
+// @Success 200 {object} models.Secret
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets/{id} [get]
+func (h *SecretHandler) GetSecret(c *gin.Context) {
+	secret, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Secret not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hideValues(secret)
+	c.JSON(http.StatusOK, secret)
+}
+
+// CreateSecret creates a new secret. Requires the admin token.
+//
+// @Summary Create a secret
+// @Tags secrets
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param secret body models.Secret true "Secret"
+// @Success 201 {object} models.Secret
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets [post]
+func (h *SecretHandler) CreateSecret(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing secrets") {
+		return
+	}
+
+	var secret models.Secret
+	if err := c.ShouldBindJSON(&secret); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !secretNamePattern.MatchString(secret.Name) {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("invalid secret name '%s': use letters, digits, '.', '-' and '_'", secret.Name))
+		return
+	}
+	if secret.Value == "" {
+		apierror.Respond(c, http.StatusBadRequest, "secret value must not be empty")
+		return
+	}
+	if err := secret.Rotation.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	secret.RotatedAt = nil
+	secret.RotationError = ""
+
+	// Validate name uniqueness
+	if _, err := h.repo.GetByName(c.Request.Context(), secret.Name); err == nil {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Secret with name '%s' already exists", secret.Name))
+		return
+	} else if err != repository.ErrNotFound {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &secret); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hideValues(&secret)
+	c.JSON(http.StatusCreated, secret)
+}
+
+// UpdateSecret updates a secret, keeping its value if none is given, and its staged value if the
+// rotation has staged set but no stagedValue. A secret used by interfaces or MCP servers cannot be
+// renamed, as they reference it by name. Requires the admin token.
+//
+// @Summary Update a secret
+// @Tags secrets
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Secret ID"
+// @Param secret body models.Secret true "Secret"
+// @Success 200 {object} models.Secret
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets/{id} [put]
+func (h *SecretHandler) UpdateSecret(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing secrets") {
+		return
+	}
+
+	id := c.Param("id")
+	var secret models.Secret
+	if err := c.ShouldBindJSON(&secret); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	secret.ID = id
+
+	if !secretNamePattern.MatchString(secret.Name) {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("invalid secret name '%s': use letters, digits, '.', '-' and '_'", secret.Name))
+		return
+	}
+
+	existing, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Secret not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if secret.Value == "" {
+		secret.Value = existing.Value
+	}
+	if secret.Rotation != nil && secret.Rotation.Staged && secret.Rotation.StagedValue == "" && existing.Rotation != nil {
+		secret.Rotation.StagedValue = existing.Rotation.StagedValue
+	}
+	if err := secret.Rotation.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	secret.RotatedAt = existing.RotatedAt
+	secret.RotationError = existing.RotationError
+
+	if secret.Name != existing.Name && !h.unreferenced(c, existing.Name, "renamed") {
+		return
+	}
+
+	// Validate name uniqueness
+	if other, err := h.repo.GetByName(c.Request.Context(), secret.Name); err == nil && other.ID != id {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Secret with name '%s' already exists", secret.Name))
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &secret); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Secret not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hideValues(&secret)
+	c.JSON(http.StatusOK, secret)
+}
+
+// DeleteSecret deletes a secret. A secret used by interfaces or MCP servers is only deleted with
+// force. Requires the admin token.
+//
+// @Summary Delete a secret
+// @Tags secrets
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Secret ID"
+// @Param force query bool false "Delete the secret even if it is used"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets/{id} [delete]
+func (h *SecretHandler) DeleteSecret(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing secrets") {
+		return
+	}
+	force, err := parseBoolQuery(c, "force")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !force {
+		secret, ok := h.secret(c)
+		if !ok || !h.unreferenced(c, secret.Name, "deleted without force") {
+			return
+		}
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Secret not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetReferences lists the interfaces, tools, external servers and Authorization passthrough
+// credentials using a secret, in every namespace
+//
+// @Summary List the uses of a secret
+// @Tags secrets
+// @Produce json
+// @Param id path string true "Secret ID"
+// @Success 200 {array} models.SecretReference
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets/{id}/references [get]
+func (h *SecretHandler) GetReferences(c *gin.Context) {
+	secret, ok := h.secret(c)
+	if !ok {
+		return
+	}
+	refs, err := h.rotator.References(c.Request.Context(), secret.Name)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, refs)
+}
+
+// GetRotation returns the rotation of a secret with its next time, the outcome of the last one
+// and the uses of the secret
+//
+// @Summary Get the rotation of a secret
+// @Tags secrets
+// @Produce json
+// @Param id path string true "Secret ID"
+// @Success 200 {object} models.SecretRotationStatus
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/secrets/{id}/rotation [get]
+func (h *SecretHandler) GetRotation(c *gin.Context) {
+	secret, ok := h.secret(c)
+	if !ok {
+		return
+	}
+	status, err := h.rotator.Status(c.Request.Context(), secret, time.Now())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// RotateSecret rotates a secret now, swapping its staged value in or calling its rotation
+// webhook, without waiting for its scheduled time. Requires the admin token.
+//
+// @Summary Rotate a secret now
+// @Tags secrets
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Secret ID"
+// @Success 200 {object} models.SecretRotationStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /api/secrets/{id}/rotate [post]
+func (h *SecretHandler) RotateSecret(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Managing secrets") {
+		return
+	}
+	secret, ok := h.secret(c)
+	if !ok {
+		return
+	}
+	if secret.Rotation == nil {
+		apierror.Respond(c, http.StatusBadRequest, "The secret has no rotation")
+		return
+	}
+
+	secret, err := h.rotator.Rotate(c.Request.Context(), secret.ID)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadGateway, "Rotation failed: "+err.Error())
+		return
+	}
+	status, err := h.rotator.Status(c.Request.Context(), secret, time.Now())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// secret returns the secret of the ID path param, writing the error response if there is none
+func (h *SecretHandler) secret(c *gin.Context) (*models.Secret, bool) {
+	secret, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Secret not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return secret, true
+}
+
+// unreferenced writes the error response, with the references, if the named secret is used
+func (h *SecretHandler) unreferenced(c *gin.Context, name string, action string) bool {
+	refs, err := h.rotator.References(c.Request.Context(), name)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+	if len(refs) > 0 {
+		message := fmt.Sprintf("Secret %s is used by %d interfaces or tools and cannot be %s", name, len(refs), action)
+		apierror.RespondDetails(c, http.StatusConflict, "secret_in_use", message, gin.H{"references": refs})
+		return false
+	}
+	return true
+}
+
+// hideValues removes the value and the staged value of a secret from responses
+func hideValues(secret *models.Secret) {
+	secret.Value = ""
+	secret.Rotation = rotation.Redact(secret.Rotation)
+}
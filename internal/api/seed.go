@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/seed"
+)
+
+// SeedRequest selects the packs to seed
+type SeedRequest struct {
+	Packs []string `json:"packs" binding:"required,min=1"` // Names of the packs, see GET /api/seed
+}
+
+// SeedHandler handles API requests creating fixture resources
+type SeedHandler struct {
+	seeder *seed.Seeder // Nil if seeding is disabled
+}
+
+// NewSeedHandler creates a new seed handler
+func NewSeedHandler(seeder *seed.Seeder) *SeedHandler {
+	return &SeedHandler{
+		seeder: seeder,
+	}
+}
+
+// RegisterRoutes registers the seed API routes
+func (h *SeedHandler) RegisterRoutes(router *gin.Engine) {
+	seedGroup := router.Group("/api/seed")
+	{
+		seedGroup.GET("", h.GetPacks)
+		seedGroup.POST("", h.Seed)
+	}
+}
+
+// GetPacks lists the packs of fixture resources
+//
+// @Summary List the seed packs
+// @Tags seed
+// @Produce json
+// @Success 200 {array} seed.Pack
+// @Failure 404 {object} ErrorResponse
+// @Router /api/seed [get]
+func (h *SeedHandler) GetPacks(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+	c.JSON(http.StatusOK, seed.Packs())
+}
+
+// Seed creates the resources of packs, and of the packs they require, in the namespace of the
+// request. Existing resources of the same name are left alone. With dryRun, the resources that
+// would be created are only returned.
+//
+// @Summary Create the resources of seed packs
+// @Tags seed
+// @Accept json
+// @Produce json
+// @Param request body SeedRequest true "Packs to seed"
+// @Param dryRun query bool false "Only return the planned changes"
+// @Success 200 {object} ApplyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/seed [post]
+func (h *SeedHandler) Seed(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	var request SeedRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := h.seeder.Seed(c.Request.Context(), request.Packs, dryRun)
+	if err != nil {
+		if errors.Is(err, seed.ErrUnknownPack) {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := ApplyResponse{DryRun: dryRun, Changes: changes}
+	for _, change := range changes {
+		if change.Error != "" {
+			response.Failed++
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// enabled writes the error response if seeding is disabled
+func (h *SeedHandler) enabled(c *gin.Context) bool {
+	if h.seeder == nil {
+		apierror.Respond(c, http.StatusNotFound, "Seeding is not enabled")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SetAliasRepository sets the former names of renamed servers, redirected to their current name
+func (h *MCPServerHandler) SetAliasRepository(aliases repository.ServerAliasRepository) {
+	h.aliases = aliases
+}
+
+// GetMCPServerAliases returns the former names of an MCP server that still redirect to it
+//
+// @Summary List the former names of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Success 200 {array} models.ServerAlias
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/aliases [get]
+func (h *MCPServerHandler) GetMCPServerAliases(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	aliases, err := h.aliases.GetByServer(c.Request.Context(), server.ID)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	now := time.Now()
+	current := make([]models.ServerAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		if !alias.Expired(now) {
+			current = append(current, alias)
+		}
+	}
+
+	c.JSON(http.StatusOK, current)
+}
+
+// DeleteMCPServerAlias stops redirecting a former name of an MCP server before its grace period
+// ends, e.g. once every client migrated, so that the name can be reused elsewhere
+//
+// @Summary Delete a former name of an MCP server
+// @Tags mcp-servers
+// @Param id path string true "MCP server ID"
+// @Param name path string true "Former name"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/aliases/{name} [delete]
+func (h *MCPServerHandler) DeleteMCPServerAlias(c *gin.Context) {
+	server, ok := h.server(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	name := c.Param("name")
+	alias, err := h.aliases.Get(c.Request.Context(), server.Namespace, name)
+	if err == repository.ErrNotFound || (err == nil && alias.ServerID != server.ID) {
+		apierror.Respond(c, http.StatusNotFound, "Alias not found")
+		return
+	} else if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.aliases.Delete(c.Request.Context(), alias.Namespace, alias.Name); err != nil && err != repository.ErrNotFound {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Deleted MCP server alias", "id", server.ID, "name", server.Name, "alias", name)
+	c.Status(http.StatusNoContent)
+}
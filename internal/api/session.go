@@ -0,0 +1,199 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// UpdateMCPSessionRequest changes the state of an MCP session; omitted fields are kept
+type UpdateMCPSessionRequest struct {
+	Environment *string `json:"environment"` // Empty to select no environment
+	CookieJar   *string `json:"cookieJar"`   // Empty to share no cookies between the calls
+}
+
+// MCPSessionHandler handles API requests for the sessions of the MCP transport
+type MCPSessionHandler struct {
+	repo       repository.MCPSessionRepository // nil when sessions are disabled
+	adminToken func() string                   // Current admin token, required since session IDs grant their state
+}
+
+// NewMCPSessionHandler creates a new session handler, repo is nil when sessions are disabled
+func NewMCPSessionHandler(repo repository.MCPSessionRepository, adminToken func() string) *MCPSessionHandler {
+	return &MCPSessionHandler{
+		repo:       repo,
+		adminToken: adminToken,
+	}
+}
+
+// RegisterRoutes registers the session routes
+func (h *MCPSessionHandler) RegisterRoutes(router *gin.Engine) {
+	sessionGroup := router.Group("/api/mcp-sessions")
+	{
+		sessionGroup.GET("", h.GetAllSessions)
+		sessionGroup.GET("/:id", h.GetSession)
+		sessionGroup.PATCH("/:id", h.UpdateSession)
+		sessionGroup.DELETE("/:id", h.DeleteSession)
+	}
+}
+
+// GetAllSessions returns the sessions of the MCP transport, newest first. Requires the admin token.
+//
+// @Summary List MCP sessions
+// @Tags mcp-sessions
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param serverId query string false "Only the sessions of this server"
+// @Success 200 {array} models.MCPSession
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-sessions [get]
+func (h *MCPSessionHandler) GetAllSessions(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	sessions, err := h.repo.GetAll(c.Request.Context(), c.Query("serverId"))
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// GetSession returns a session of the MCP transport. Requires the admin token.
+//
+// @Summary Get an MCP session
+// @Tags mcp-sessions
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.MCPSession
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-sessions/{id} [get]
+func (h *MCPSessionHandler) GetSession(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	session, ok := h.session(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// UpdateSession changes the environment or the cookie jar of the tool calls of a session, e.g.
+// to switch a connected client to staging. Requires the admin token.
+//
+// @Summary Update an MCP session
+// @Tags mcp-sessions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Session ID"
+// @Param request body UpdateMCPSessionRequest true "Session state"
+// @Success 200 {object} models.MCPSession
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-sessions/{id} [patch]
+func (h *MCPSessionHandler) UpdateSession(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	var req UpdateMCPSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	session, ok := h.session(c)
+	if !ok {
+		return
+	}
+	if req.Environment != nil {
+		session.Environment = *req.Environment
+	}
+	if req.CookieJar != nil {
+		session.CookieJar = *req.CookieJar
+	}
+
+	if err := h.repo.Update(c.Request.Context(), session); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP session not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// DeleteSession terminates a session, its client gets a 404 and starts a new one. Requires the
+// admin token.
+//
+// @Summary Delete an MCP session
+// @Tags mcp-sessions
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-sessions/{id} [delete]
+func (h *MCPSessionHandler) DeleteSession(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP session not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// authorize checks that sessions are enabled and the request has the admin token. It responds
+// with an error otherwise.
+func (h *MCPSessionHandler) authorize(c *gin.Context) bool {
+	if h.repo == nil {
+		apierror.Respond(c, http.StatusNotFound, "MCP sessions are not enabled")
+		return false
+	}
+	return authorizeAdmin(c, h.adminToken(), "Managing MCP sessions")
+}
+
+// session returns the session of the request path. It responds with an error otherwise.
+func (h *MCPSessionHandler) session(c *gin.Context) (*models.MCPSession, bool) {
+	session, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP session not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return session, true
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+const defaultStatsWindow = "24h"
+
+// StatsHandler handles API requests for usage statistics
+type StatsHandler struct {
+	repo    repository.InvocationRepository
+	mcpRepo repository.MCPServerRepository
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(repo repository.InvocationRepository, mcpRepo repository.MCPServerRepository) *StatsHandler {
+	return &StatsHandler{
+		repo:    repo,
+		mcpRepo: mcpRepo,
+	}
+}
+
+// RegisterRoutes registers the stats API routes
+func (h *StatsHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/api/stats", h.GetStats)
+	router.GET("/api/stats/deprecated-tools", h.GetDeprecatedTools)
+	router.GET("/api/mcp-servers/:id/stats", h.GetMCPServerStats)
+}
+
+// GetStats returns gateway-wide statistics with a breakdown per server and per tool
+//
+// @Summary Get gateway-wide usage statistics
+// @Tags stats
+// @Produce json
+// @Param window query string false "Window duration, e.g. 24h"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Success 200 {object} StatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	filter, window, err := parseStatsWindow(c)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	total, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByNone)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	servers, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByServer)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tools, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByTool)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window":  window,
+		"since":   filter.Since,
+		"until":   filter.Until,
+		"total":   total[0],
+		"servers": servers,
+		"tools":   tools,
+	})
+}
+
+// GetMCPServerStats returns the statistics of an MCP Server with a breakdown per tool
+//
+// @Summary Get the usage statistics of an MCP server
+// @Tags stats
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param window query string false "Window duration, e.g. 24h"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Success 200 {object} StatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/stats [get]
+func (h *StatsHandler) GetMCPServerStats(c *gin.Context) {
+	id := c.Param("id")
+
+	// Check MCP Server exists
+	if _, err := h.mcpRepo.GetByID(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "MCP Server not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter, window, err := parseStatsWindow(c)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.ServerID = id
+
+	total, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByNone)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tools, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByTool)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window": window,
+		"since":  filter.Since,
+		"until":  filter.Until,
+		"total":  total[0],
+		"tools":  tools,
+	})
+}
+
+// DeprecatedToolUsage is the usage of a deprecated tool within the window of a report
+type DeprecatedToolUsage struct {
+	ServerID           string     `json:"serverId"`
+	ServerName         string     `json:"serverName"`
+	Tool               string     `json:"tool"` // Name clients call the tool by
+	InterfaceID        string     `json:"interfaceId,omitempty"`
+	Sunset             *time.Time `json:"sunset,omitempty"`
+	DeprecationMessage string     `json:"deprecationMessage,omitempty"`
+	Calls              int        `json:"calls"`
+	Errors             int        `json:"errors"`
+	LastCalledAt       *time.Time `json:"lastCalledAt,omitempty"`
+}
+
+// DeprecatedToolsResponse lists the deprecated tools called within a window, most called first
+type DeprecatedToolsResponse struct {
+	Window string                `json:"window"`
+	Since  time.Time             `json:"since"`
+	Until  time.Time             `json:"until"`
+	Tools  []DeprecatedToolUsage `json:"tools"`
+}
+
+// GetDeprecatedTools reports the deprecated tools of the MCP servers of the namespace that are still
+// called, most called first, to find the clients to migrate before their sunset
+//
+// @Summary Get the usage of deprecated tools
+// @Tags stats
+// @Produce json
+// @Param window query string false "Window duration, e.g. 24h"
+// @Param since query string false "RFC 3339 start time"
+// @Param until query string false "RFC 3339 end time"
+// @Param includeIdle query bool false "Also list the deprecated tools not called within the window"
+// @Success 200 {object} DeprecatedToolsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/stats/deprecated-tools [get]
+func (h *StatsHandler) GetDeprecatedTools(c *gin.Context) {
+	filter, window, err := parseStatsWindow(c)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	includeIdle, err := parseBoolQuery(c, "includeIdle")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	servers, err := h.mcpRepo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	stats, err := h.repo.Stats(c.Request.Context(), filter, repository.StatsGroupByTool)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	calls := make(map[string]models.UsageStats, len(stats))
+	for _, toolStats := range stats {
+		calls[toolStats.ServerID+"/"+toolStats.Tool] = toolStats
+	}
+
+	tools := []DeprecatedToolUsage{}
+	for _, server := range servers {
+		for _, tool := range server.Tools {
+			if !tool.Deprecated {
+				continue
+			}
+			usage := DeprecatedToolUsage{
+				ServerID:           server.ID,
+				ServerName:         server.Name,
+				Tool:               tool.ExposedName(),
+				InterfaceID:        tool.InterfaceID,
+				Sunset:             tool.Sunset,
+				DeprecationMessage: tool.DeprecationMessage,
+			}
+			toolStats, called := calls[server.ID+"/"+usage.Tool]
+			if !called && !includeIdle {
+				continue
+			}
+			if called {
+				usage.Calls = toolStats.Calls
+				usage.Errors = toolStats.Errors
+				latest := filter
+				latest.ServerID, latest.Tool, latest.Limit = server.ID, usage.Tool, 1
+				invocations, _, err := h.repo.List(c.Request.Context(), latest)
+				if err != nil {
+					apierror.Respond(c, http.StatusInternalServerError, err.Error())
+					return
+				}
+				if len(invocations) > 0 {
+					usage.LastCalledAt = &invocations[0].CreatedAt
+				}
+			}
+			tools = append(tools, usage)
+		}
+	}
+	sort.SliceStable(tools, func(i, j int) bool { return tools[i].Calls > tools[j].Calls })
+
+	c.JSON(http.StatusOK, DeprecatedToolsResponse{Window: window, Since: filter.Since, Until: filter.Until, Tools: tools})
+}
+
+// parseStatsWindow reads the time window of a stats request.
+// window is a duration such as 1h, 24h or 7d ending now; since and until (RFC 3339) take precedence.
+func parseStatsWindow(c *gin.Context) (repository.InvocationFilter, string, error) {
+	filter := repository.InvocationFilter{Until: time.Now()}
+
+	window := c.DefaultQuery("window", defaultStatsWindow)
+	duration, err := parseWindow(window)
+	if err != nil {
+		return filter, "", err
+	}
+
+	if value := c.Query("until"); value != "" {
+		filter.Until, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, "", fmt.Errorf("invalid until '%s': must be an RFC 3339 timestamp", value)
+		}
+	}
+	filter.Since = filter.Until.Add(-duration)
+
+	if value := c.Query("since"); value != "" {
+		filter.Since, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, "", fmt.Errorf("invalid since '%s': must be an RFC 3339 timestamp", value)
+		}
+		window = ""
+	}
+
+	if !filter.Since.Before(filter.Until) {
+		return filter, "", fmt.Errorf("since must be before until")
+	}
+
+	return filter, window, nil
+}
+
+// parseWindow parses a Go duration, additionally accepting a number of days such as 7d
+func parseWindow(window string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if days, found := strings.CutSuffix(window, "d"); found {
+		var n int
+		n, err = strconv.Atoi(days)
+		duration = time.Duration(n) * 24 * time.Hour
+	} else {
+		duration, err = time.ParseDuration(window)
+	}
+
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid window '%s': must be a positive duration such as 1h, 24h or 7d", window)
+	}
+	return duration, nil
+}
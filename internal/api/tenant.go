@@ -0,0 +1,319 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/quota"
+)
+
+// TenantHandler handles API requests for tenant quotas, usage and call policies. Tenants are namespaces.
+type TenantHandler struct {
+	repo       repository.QuotaRepository
+	policyRepo repository.NamespacePolicyRepository
+	tracker    *quota.Tracker
+	adminToken func() string // Current admin token, required to change quotas and policies
+}
+
+// NewTenantHandler creates a new tenant handler
+func NewTenantHandler(repo repository.QuotaRepository, policyRepo repository.NamespacePolicyRepository, tracker *quota.Tracker, adminToken func() string) *TenantHandler {
+	return &TenantHandler{
+		repo:       repo,
+		policyRepo: policyRepo,
+		tracker:    tracker,
+		adminToken: adminToken,
+	}
+}
+
+// RegisterRoutes registers the tenant API routes
+func (h *TenantHandler) RegisterRoutes(router *gin.Engine) {
+	tenantGroup := router.Group("/api/tenants")
+	{
+		tenantGroup.GET("", h.GetAllQuotas)
+		tenantGroup.GET("/:id/usage", h.GetUsage)
+		tenantGroup.PUT("/:id/quota", h.SetQuota)
+		tenantGroup.DELETE("/:id/quota", h.DeleteQuota)
+		tenantGroup.GET("/policies", h.GetAllPolicies)
+		tenantGroup.GET("/:id/policy", h.GetPolicy)
+		tenantGroup.PUT("/:id/policy", h.SetPolicy)
+		tenantGroup.DELETE("/:id/policy", h.DeletePolicy)
+	}
+}
+
+// GetAllQuotas returns the quotas of all tenants having one
+//
+// @Summary List tenant quotas
+// @Tags tenants
+// @Produce json
+// @Success 200 {array} models.Quota
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants [get]
+func (h *TenantHandler) GetAllQuotas(c *gin.Context) {
+	quotas, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, quotas)
+}
+
+// GetUsage returns the tool calls of the current UTC day, the number of servers and interfaces
+// and the quota of a tenant
+//
+// @Summary Get the usage of a tenant
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant (namespace)"
+// @Success 200 {object} models.TenantUsage
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/usage [get]
+func (h *TenantHandler) GetUsage(c *gin.Context) {
+	tenant := c.Param("id")
+	if err := namespace.Validate(tenant); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usage, err := h.tracker.Usage(c.Request.Context(), tenant)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// SetQuota creates or replaces the quota of a tenant. Requires the admin token.
+//
+// @Summary Set the quota of a tenant
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Tenant (namespace)"
+// @Param quota body models.Quota true "Limits, 0 for unlimited"
+// @Success 200 {object} models.Quota
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/quota [put]
+func (h *TenantHandler) SetQuota(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Changing quotas") {
+		return
+	}
+
+	tenant := c.Param("id")
+	if err := namespace.Validate(tenant); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var limits models.Quota
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	limits.Tenant = tenant
+
+	if err := h.repo.Set(c.Request.Context(), &limits); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+// DeleteQuota removes the quota of a tenant, lifting its limits. Requires the admin token.
+//
+// @Summary Delete the quota of a tenant
+// @Tags tenants
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Tenant (namespace)"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/quota [delete]
+func (h *TenantHandler) DeleteQuota(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Changing quotas") {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Quota not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAllPolicies returns the call policies of all namespaces having one
+//
+// @Summary List namespace policies
+// @Tags tenants
+// @Produce json
+// @Success 200 {array} models.NamespacePolicy
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/policies [get]
+func (h *TenantHandler) GetAllPolicies(c *gin.Context) {
+	policies, err := h.policyRepo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// GetPolicy returns the call policy of a namespace
+//
+// @Summary Get the policy of a namespace
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant (namespace)"
+// @Success 200 {object} models.NamespacePolicy
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/policy [get]
+func (h *TenantHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.policyRepo.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Namespace policy not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// SetPolicy creates or replaces the call policy inherited by the servers and tools of a
+// namespace. Requires the admin token.
+//
+// @Summary Set the policy of a namespace
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Tenant (namespace)"
+// @Param policy body models.CallPolicy true "Timeout, retries, rate limit and allowed hosts"
+// @Success 200 {object} models.NamespacePolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/policy [put]
+func (h *TenantHandler) SetPolicy(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Changing namespace policies") {
+		return
+	}
+
+	tenant := c.Param("id")
+	if err := namespace.Validate(tenant); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var policy models.NamespacePolicy
+	if err := c.ShouldBindJSON(&policy.Policy); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := policy.Policy.Validate(); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	policy.Namespace = tenant
+
+	if err := h.policyRepo.Set(c.Request.Context(), &policy); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy removes the call policy of a namespace. Requires the admin token.
+//
+// @Summary Delete the policy of a namespace
+// @Tags tenants
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path string true "Tenant (namespace)"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/tenants/{id}/policy [delete]
+func (h *TenantHandler) DeletePolicy(c *gin.Context) {
+	if !authorizeAdmin(c, h.adminToken(), "Changing namespace policies") {
+		return
+	}
+
+	if err := h.policyRepo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Namespace policy not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// createErrorStatus returns the HTTP status reported for an error creating or updating an interface or server
+func createErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrQuotaExceeded) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, repository.ErrNameTaken) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusInternalServerError
+}
+
+// errorCode returns the code reported for an error of a repository or of a tool invocation, or an
+// empty string to report the code of the status
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, repository.ErrNameTaken):
+		return "name_taken"
+	case errors.Is(err, repository.ErrRevisionReviewed):
+		return "revision_reviewed"
+	case errors.Is(err, gitops.ErrApprovalRequired):
+		return "approval_required"
+	default:
+		return mcp.ErrorCode(err)
+	}
+}
+
+// nameErrorStatus returns the HTTP status reported for a name that is invalid or already taken
+func nameErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrNameTaken) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
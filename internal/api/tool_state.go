@@ -0,0 +1,91 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+)
+
+// EnableTool switches a disabled tool of an MCP server back on. The change of an active server is
+// submitted for approval if required.
+//
+// @Summary Enable a tool of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name or alias"
+// @Success 200 {object} models.Tool
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool}/enable [post]
+func (h *MCPServerHandler) EnableTool(c *gin.Context) {
+	h.setToolEnabled(c, true)
+}
+
+// DisableTool switches off a misbehaving tool of an MCP server. The tool stays in allowTools but
+// is no longer listed to clients and its calls are rejected with a 503 until it is enabled again.
+// The change of an active server is submitted for approval if required.
+//
+// @Summary Disable a tool of an MCP server
+// @Tags mcp-servers
+// @Produce json
+// @Param id path string true "MCP server ID"
+// @Param tool path string true "Tool name or alias"
+// @Success 200 {object} models.Tool
+// @Success 202 {object} models.Revision "Change of an active server awaiting approval"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/mcp-servers/{id}/tools/{tool}/disable [post]
+func (h *MCPServerHandler) DisableTool(c *gin.Context) {
+	h.setToolEnabled(c, false)
+}
+
+// setToolEnabled stores whether the tool of the request path is enabled and serves the server
+// again, so that tools/list and the calls reflect it immediately
+func (h *MCPServerHandler) setToolEnabled(c *gin.Context, enabled bool) {
+	id := c.Param("id")
+	toolName := c.Param("tool")
+
+	server, ok := h.server(c, id)
+	if !ok {
+		return
+	}
+	if rejectArchivedServer(c, server) {
+		return
+	}
+
+	// Find the tool by its name, or by the alias clients call it by
+	tool := server.FindTool(toolName)
+	for i := range server.Tools {
+		if server.Tools[i].Name == toolName {
+			tool = &server.Tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		apierror.Respond(c, http.StatusNotFound, "Tool not found: "+toolName)
+		return
+	}
+
+	// Enabled tools leave the field unset, as they were before any toggle
+	tool.Enabled = nil
+	if !enabled {
+		tool.Enabled = &enabled
+	}
+	if h.submitRevision(c, server, server, "tool "+tool.Name+" state") {
+		return
+	}
+	if err := h.mcpRepo.Update(c.Request.Context(), server); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.mcpService.RefreshServer(server)
+
+	slog.InfoContext(c.Request.Context(), "Set tool state", "id", id, "tool", tool.Name, "enabled", enabled)
+	c.JSON(http.StatusOK, tool)
+}
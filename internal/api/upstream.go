@@ -0,0 +1,222 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/upstream"
+)
+
+// UpstreamHandler handles API requests for upstreams
+type UpstreamHandler struct {
+	repo    repository.UpstreamRepository
+	manager *upstream.Manager
+}
+
+// NewUpstreamHandler creates a new upstream handler
+func NewUpstreamHandler(repo repository.UpstreamRepository, manager *upstream.Manager) *UpstreamHandler {
+	return &UpstreamHandler{
+		repo:    repo,
+		manager: manager,
+	}
+}
+
+// RegisterRoutes registers the upstream API routes
+func (h *UpstreamHandler) RegisterRoutes(router *gin.Engine) {
+	upstreamGroup := router.Group("/api/upstreams")
+	{
+		upstreamGroup.GET("", h.GetAllUpstreams)
+		upstreamGroup.GET("/health", h.GetUpstreamsHealth)
+		upstreamGroup.GET("/:id", h.GetUpstream)
+		upstreamGroup.POST("", h.CreateUpstream)
+		upstreamGroup.PUT("/:id", h.UpdateUpstream)
+		upstreamGroup.DELETE("/:id", h.DeleteUpstream)
+	}
+}
+
+// GetAllUpstreams returns all upstreams
+//
+// @Summary List upstreams
+// @Tags upstreams
+// @Produce json
+// @Success 200 {array} models.Upstream
+// @Failure 500 {object} ErrorResponse
+// @Router /api/upstreams [get]
+func (h *UpstreamHandler) GetAllUpstreams(c *gin.Context) {
+	upstreams, err := h.repo.GetAll(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, upstreams)
+}
+
+// GetUpstream returns a specific upstream
+//
+// @Summary Get an upstream
+// @Tags upstreams
+// @Produce json
+// @Param id path string true "Upstream ID"
+// @Success 200 {object} models.Upstream
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/upstreams/{id} [get]
+func (h *UpstreamHandler) GetUpstream(c *gin.Context) {
+	id := c.Param("id")
+	upstream, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Upstream not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, upstream)
+}
+
+// CreateUpstream creates a new upstream and starts its health checks
+//
+// @Summary Create an upstream
+// @Tags upstreams
+// @Accept json
+// @Produce json
+// @Param upstream body models.Upstream true "Upstream"
+// @Success 201 {object} models.Upstream
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/upstreams [post]
+func (h *UpstreamHandler) CreateUpstream(c *gin.Context) {
+	var upstream models.Upstream
+	if err := c.ShouldBindJSON(&upstream); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateUpstream(&upstream); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate name uniqueness
+	if _, err := h.repo.GetByName(c.Request.Context(), upstream.Name); err == nil {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Upstream with name '%s' already exists", upstream.Name))
+		return
+	} else if err != repository.ErrNotFound {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &upstream); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.manager.Set(upstream)
+
+	c.JSON(http.StatusCreated, upstream)
+}
+
+// UpdateUpstream updates an upstream and restarts its health checks
+//
+// @Summary Update an upstream
+// @Tags upstreams
+// @Accept json
+// @Produce json
+// @Param id path string true "Upstream ID"
+// @Param upstream body models.Upstream true "Upstream"
+// @Success 200 {object} models.Upstream
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/upstreams/{id} [put]
+func (h *UpstreamHandler) UpdateUpstream(c *gin.Context) {
+	id := c.Param("id")
+	var upstream models.Upstream
+	if err := c.ShouldBindJSON(&upstream); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ensure ID matches
+	upstream.ID = id
+
+	if err := validateUpstream(&upstream); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate name uniqueness
+	if existing, err := h.repo.GetByName(c.Request.Context(), upstream.Name); err == nil && existing.ID != id {
+		apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("Upstream with name '%s' already exists", upstream.Name))
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), &upstream); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Upstream not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.manager.Set(upstream)
+
+	c.JSON(http.StatusOK, upstream)
+}
+
+// DeleteUpstream deletes an upstream and stops its health checks
+//
+// @Summary Delete an upstream
+// @Tags upstreams
+// @Produce json
+// @Param id path string true "Upstream ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/upstreams/{id} [delete]
+func (h *UpstreamHandler) DeleteUpstream(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "Upstream not found")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.manager.Remove(id)
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUpstreamsHealth returns the current health check results of all upstreams
+//
+// @Summary Get the health of all upstream targets
+// @Tags upstreams
+// @Produce json
+// @Success 200 {array} upstream.Health
+// @Router /api/upstreams/health [get]
+func (h *UpstreamHandler) GetUpstreamsHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, h.manager.Status())
+}
+
+// validateUpstream checks that all targets are absolute URLs
+func validateUpstream(upstream *models.Upstream) error {
+	for _, target := range upstream.Targets {
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid target URL '%s': must be an absolute URL such as http://10.0.0.1:8080", target)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// maxWasmFileSize bounds the size of uploaded WASM modules
+const maxWasmFileSize = 50 << 20
+
+// wasmHeader is the magic number "\0asm" followed by the binary format version 1
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// WasmFileHandler handles API requests for WASM files
+type WasmFileHandler struct {
+	repo    repository.WasmFileRepository
+	mcpRepo repository.MCPServerRepository
+	wasmDir string
+}
+
+// NewWasmFileHandler creates a new WASM file handler storing uploads in wasmDir
+func NewWasmFileHandler(repo repository.WasmFileRepository, mcpRepo repository.MCPServerRepository, wasmDir string) *WasmFileHandler {
+	return &WasmFileHandler{
+		repo:    repo,
+		mcpRepo: mcpRepo,
+		wasmDir: wasmDir,
+	}
+}
+
+// RegisterRoutes registers the WASM file API routes
+func (h *WasmFileHandler) RegisterRoutes(router *gin.Engine) {
+	wasmGroup := router.Group("/api/wasm-files")
+	{
+		wasmGroup.GET("", h.GetAllWasmFiles)
+		wasmGroup.GET("/:id", h.GetWasmFile)
+		wasmGroup.GET("/:id/download", h.DownloadWasmFile)
+		wasmGroup.POST("", h.UploadWasmFile)
+		wasmGroup.DELETE("/:id", h.DeleteWasmFile)
+	}
+}
+
+// GetAllWasmFiles returns the metadata of all WASM files, optionally filtered by ?serverId=
+//
+// @Summary List WASM files
+// @Tags wasm-files
+// @Produce json
+// @Param serverId query string false "Owner MCP server ID"
+// @Success 200 {array} models.WasmFile
+// @Failure 500 {object} ErrorResponse
+// @Router /api/wasm-files [get]
+func (h *WasmFileHandler) GetAllWasmFiles(c *gin.Context) {
+	files, err := h.repo.GetAll(c.Request.Context(), c.Query("serverId"))
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, files)
+}
+
+// GetWasmFile returns the metadata of a specific WASM file
+//
+// @Summary Get the metadata of a WASM file
+// @Tags wasm-files
+// @Produce json
+// @Param id path string true "WASM file ID"
+// @Success 200 {object} models.WasmFile
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/wasm-files/{id} [get]
+func (h *WasmFileHandler) GetWasmFile(c *gin.Context) {
+	wasmFile, ok := h.getWasmFile(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, wasmFile)
+}
+
+// DownloadWasmFile returns the content of a WASM file
+//
+// @Summary Download a WASM file
+// @Tags wasm-files
+// @Produce application/wasm
+// @Param id path string true "WASM file ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/wasm-files/{id}/download [get]
+func (h *WasmFileHandler) DownloadWasmFile(c *gin.Context) {
+	wasmFile, ok := h.getWasmFile(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", wasmFile.Name))
+	c.Header("X-Checksum-SHA256", wasmFile.Checksum)
+	c.File(wasmFile.Path)
+}
+
+// UploadWasmFile stores a WASM module uploaded as multipart form field "file".
+// Optional form fields: "name" (defaults to the uploaded file name) and "serverId" (owner MCP Server).
+//
+// @Summary Upload a WASM file
+// @Tags wasm-files
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "WASM module"
+// @Param name formData string false "Name, defaults to the file name"
+// @Param serverId formData string false "Owner MCP server ID"
+// @Success 201 {object} models.WasmFile
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/wasm-files [post]
+func (h *WasmFileHandler) UploadWasmFile(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWasmFileSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "Missing multipart file field 'file': "+err.Error())
+		return
+	}
+	if fileHeader.Size > maxWasmFileSize {
+		apierror.Respond(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("WASM file exceeds %d bytes", maxWasmFileSize))
+		return
+	}
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = filepath.Base(fileHeader.Filename)
+	}
+
+	// Validate owner server
+	serverID := c.PostForm("serverId")
+	if serverID != "" {
+		if _, err := h.mcpRepo.GetByID(c.Request.Context(), serverID); err != nil {
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusBadRequest, "MCP Server not found: "+serverID)
+				return
+			}
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate WASM magic number and version
+	if !bytes.HasPrefix(content, wasmHeader) {
+		apierror.Respond(c, http.StatusBadRequest, "Not a WebAssembly binary: missing \\0asm header")
+		return
+	}
+
+	checksum := sha256.Sum256(content)
+	wasmFile := models.WasmFile{
+		ID:       "wasm-" + uuid.New().String(),
+		Name:     name,
+		ServerID: serverID,
+		Checksum: hex.EncodeToString(checksum[:]),
+		Size:     int64(len(content)),
+	}
+	wasmFile.Path = filepath.Join(h.wasmDir, wasmFile.ID+".wasm")
+
+	if err := os.WriteFile(wasmFile.Path, content, 0644); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to store WASM file: "+err.Error())
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &wasmFile); err != nil {
+		os.Remove(wasmFile.Path)
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "WASM file uploaded", "id", wasmFile.ID, "name", wasmFile.Name,
+		"serverId", wasmFile.ServerID, "version", wasmFile.Version, "size", wasmFile.Size)
+	c.JSON(http.StatusCreated, wasmFile)
+}
+
+// DeleteWasmFile deletes a WASM file and its metadata
+//
+// @Summary Delete a WASM file
+// @Tags wasm-files
+// @Produce json
+// @Param id path string true "WASM file ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/wasm-files/{id} [delete]
+func (h *WasmFileHandler) DeleteWasmFile(c *gin.Context) {
+	wasmFile, ok := h.getWasmFile(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), wasmFile.ID); err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := os.Remove(wasmFile.Path); err != nil && !os.IsNotExist(err) {
+		slog.WarnContext(c.Request.Context(), "Failed to remove WASM file", "path", wasmFile.Path, "error", err)
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getWasmFile loads the WASM file of the :id parameter, writing the error response if it fails
+func (h *WasmFileHandler) getWasmFile(c *gin.Context) (*models.WasmFile, bool) {
+	wasmFile, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == repository.ErrNotFound {
+			apierror.Respond(c, http.StatusNotFound, "WASM file not found")
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+
+	return wasmFile, true
+}
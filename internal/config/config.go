@@ -0,0 +1,762 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/db"
+	"github.com/wangfeng/mcp-gateway2/pkg/errorsink"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets in the exposed configuration
+const redacted = "********"
+
+// Config is the configuration of the gateway
+type Config struct {
+	Server    ServerConfig    `yaml:"server" json:"server"`
+	GRPC      GRPCConfig      `yaml:"grpc" json:"grpc"`
+	GraphQL   GraphQLConfig   `yaml:"graphql" json:"graphql"`
+	Database  DatabaseConfig  `yaml:"database" json:"database"`
+	Log       LogConfig       `yaml:"log" json:"log"`
+	CORS      CORSConfig      `yaml:"cors" json:"cors"`
+	RateLimit RateLimitConfig `yaml:"rateLimit" json:"rateLimit"`
+	Upstream  UpstreamConfig  `yaml:"upstream" json:"upstream"`
+	Network   NetworkConfig   `yaml:"network" json:"network"`
+	Admin     AdminConfig     `yaml:"admin" json:"admin"`
+	Approval  ApprovalConfig  `yaml:"approval" json:"approval"`
+	GitOps    GitOpsConfig    `yaml:"gitops" json:"gitops"`
+	Backup    BackupConfig    `yaml:"backup" json:"backup"`
+	Seed      SeedConfig      `yaml:"seed" json:"seed"`
+	Sessions  SessionsConfig  `yaml:"sessions" json:"sessions"`
+	Renames   RenamesConfig   `yaml:"renames" json:"renames"`
+	Redaction RedactionConfig `yaml:"redaction" json:"redaction"`
+	LLM       LLMConfig       `yaml:"llm" json:"llm"`
+
+	ErrorReporting ErrorReportingConfig `yaml:"errorReporting" json:"errorReporting"`
+}
+
+// ServerConfig configures the HTTP server and local storage
+type ServerConfig struct {
+	Port      int    `yaml:"port" json:"port"`
+	ConfigDir string `yaml:"configDir" json:"configDir"` // Generated MCP server YAML files
+	WasmDir   string `yaml:"wasmDir" json:"wasmDir"`     // Uploaded WASM modules
+
+	Compression CompressionConfig `yaml:"compression" json:"compression"`
+}
+
+// GRPCConfig serves the gRPC admin API next to the REST API
+type GRPCConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	Port    int  `yaml:"port" json:"port"`
+}
+
+// GraphQLConfig serves the read-only GraphQL admin API on /graphql
+type GraphQLConfig struct {
+	Enabled  bool `yaml:"enabled" json:"enabled"`
+	MaxDepth int  `yaml:"maxDepth" json:"maxDepth"` // Deepest nesting of fields accepted in a query
+}
+
+// CompressionConfig controls the brotli and gzip compression of responses
+type CompressionConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"` // Negotiated with the Accept-Encoding request header
+	MinSize int  `yaml:"minSize" json:"minSize"` // Smallest response body in bytes that is compressed
+}
+
+// DatabaseConfig configures the PostgreSQL connection
+type DatabaseConfig struct {
+	Enabled  bool   `yaml:"enabled" json:"enabled"` // Use in-memory repositories when false
+	Host     string `yaml:"host" json:"host"`
+	Port     int    `yaml:"port" json:"port"`
+	User     string `yaml:"user" json:"user"`
+	Password string `yaml:"password" json:"password"`
+	Name     string `yaml:"name" json:"name"`
+
+	SlowQueryMs int `yaml:"slowQueryMs" json:"slowQueryMs"` // Queries taking longer are logged, 0 disables the log
+
+	ConnectRetries   int `yaml:"connectRetries" json:"connectRetries"`     // Attempts after a failed connection at startup, 0 to fail at once
+	ConnectBackoffMs int `yaml:"connectBackoffMs" json:"connectBackoffMs"` // Delay before the first retry, doubled after each attempt up to 30s
+
+	MaxOpenConns       int `yaml:"maxOpenConns" json:"maxOpenConns"`             // Connections open at once, 0 for unlimited
+	MaxIdleConns       int `yaml:"maxIdleConns" json:"maxIdleConns"`             // Idle connections kept open
+	ConnMaxLifetimeSec int `yaml:"connMaxLifetimeSec" json:"connMaxLifetimeSec"` // Age after which connections are closed, 0 to reuse them forever
+}
+
+// LogConfig configures structured logging
+type LogConfig struct {
+	Level  string `yaml:"level" json:"level"`   // debug, info, warn or error
+	Format string `yaml:"format" json:"format"` // text or json
+}
+
+// CORSConfig configures cross-origin requests to the API
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allowOrigins" json:"allowOrigins"` // "*" allows any origin
+}
+
+// RateLimitConfig limits the tool invocations of each caller
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requestsPerSecond" json:"requestsPerSecond"` // 0 disables rate limiting
+	Burst             int     `yaml:"burst" json:"burst"`                         // Defaults to the rate rounded up
+}
+
+// UpstreamConfig restricts the hosts tools may call
+type UpstreamConfig struct {
+	AllowedHosts []string `yaml:"allowedHosts" json:"allowedHosts"` // "*.example.com" matches subdomains, empty allows all
+	AllowStdio   bool     `yaml:"allowStdio" json:"allowStdio"`     // Allow external MCP servers that run a command on the gateway host
+
+	Protocols map[string]string `yaml:"protocols" json:"protocols"` // HTTP protocol by host name: http1, h2 or h2c; the others negotiate HTTP/2 over TLS
+
+	RevalidationEntries int `yaml:"revalidationEntries" json:"revalidationEntries"` // GET responses kept for conditional requests, 0 disables them
+}
+
+// NetworkConfig names the ranges of client addresses MCP servers may restrict invocations to
+type NetworkConfig struct {
+	Zones          map[string][]string `yaml:"zones" json:"zones"`                   // CIDR ranges or IP addresses by zone name
+	TrustedProxies []string            `yaml:"trustedProxies" json:"trustedProxies"` // Proxies whose X-Forwarded-For gives the client address, none if empty
+}
+
+// AdminConfig secures the administrative endpoints
+type AdminConfig struct {
+	Token string `yaml:"token" json:"token"` // Bearer token required by POST /api/admin/reload
+}
+
+// ApprovalConfig requires the changes of active MCP servers to be approved before they are served
+type ApprovalConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"` // Changes of active servers create pending revisions
+	Token   string `yaml:"token" json:"token"`     // Bearer token of the approvers, the admin token if empty
+}
+
+// GitOpsConfig syncs interfaces, servers and routers from a git repository of declarative YAML files
+type GitOpsConfig struct {
+	Enabled         bool   `yaml:"enabled" json:"enabled"`
+	Repository      string `yaml:"repository" json:"repository"`           // URL passed to git clone
+	Branch          string `yaml:"branch" json:"branch"`                   // Branch to check out
+	Path            string `yaml:"path" json:"path"`                       // Directory of the files within the repository
+	Dir             string `yaml:"dir" json:"dir"`                         // Local checkout
+	IntervalSeconds int    `yaml:"intervalSeconds" json:"intervalSeconds"` // Time between syncs
+	Prune           bool   `yaml:"prune" json:"prune"`                     // Delete resources of the listed kinds missing from the repository
+}
+
+// BackupConfig schedules backups of the interfaces, servers and routers of every namespace
+type BackupConfig struct {
+	Enabled         bool           `yaml:"enabled" json:"enabled"`
+	Destination     string         `yaml:"destination" json:"destination"`         // Directory, file:///path, s3://bucket/prefix or gs://bucket/prefix
+	IntervalMinutes int            `yaml:"intervalMinutes" json:"intervalMinutes"` // Time between backups
+	Keep            int            `yaml:"keep" json:"keep"`                       // Newest backups kept, 0 keeps all
+	MaxAgeDays      int            `yaml:"maxAgeDays" json:"maxAgeDays"`           // Backups older are deleted, 0 keeps them
+	S3              BackupS3Config `yaml:"s3" json:"s3"`
+}
+
+// BackupS3Config holds the credentials of s3:// and gs:// backup destinations
+type BackupS3Config struct {
+	Endpoint        string `yaml:"endpoint" json:"endpoint"` // Base URL of an S3 compatible service, AWS or Google Cloud Storage by default
+	Region          string `yaml:"region" json:"region"`
+	AccessKeyID     string `yaml:"accessKeyId" json:"accessKeyId"`
+	SecretAccessKey string `yaml:"secretAccessKey" json:"secretAccessKey"`
+}
+
+// SeedConfig controls the creation of fixture resources with --seed and POST /api/seed
+type SeedConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"` // Set to false in production to refuse seeding entirely
+}
+
+// SessionsConfig controls the sessions issued by the MCP Streamable HTTP transport
+type SessionsConfig struct {
+	Enabled            bool `yaml:"enabled" json:"enabled"`                       // Issue a session ID at initialize, otherwise the transport is stateless
+	IdleTimeoutMinutes int  `yaml:"idleTimeoutMinutes" json:"idleTimeoutMinutes"` // Sessions unused for this long expire
+}
+
+// RenamesConfig controls the aliases keeping the former names of renamed MCP servers
+type RenamesConfig struct {
+	AliasDays int `yaml:"aliasDays" json:"aliasDays"` // Former names redirect to the new one for this long, 0 keeps no alias
+}
+
+// RedactionConfig hides sensitive data of the tool results of every server
+type RedactionConfig struct {
+	Rules []models.RedactionRule `yaml:"rules" json:"rules"` // Applied before the rules of the server
+}
+
+// LLMConfig selects the model generating tool descriptions
+type LLMConfig struct {
+	Provider       string `yaml:"provider" json:"provider"`             // openai or anthropic, empty disables the integration
+	URL            string `yaml:"url" json:"url"`                       // Base URL of an OpenAI or Anthropic compatible API, the public API by default
+	APIKey         string `yaml:"apiKey" json:"apiKey"`                 // API key of the provider
+	Model          string `yaml:"model" json:"model"`                   // Model name
+	TimeoutSeconds int    `yaml:"timeoutSeconds" json:"timeoutSeconds"` // Timeout of a completion
+}
+
+// ErrorReportingConfig sends handler panics, repository errors and tool call failures to Sentry
+// or to a webhook
+type ErrorReportingConfig struct {
+	DSN         string `yaml:"dsn" json:"dsn"`                 // Sentry DSN, or URL receiving the errors as JSON; empty disables reporting
+	Environment string `yaml:"environment" json:"environment"` // Environment the errors are tagged with, e.g. production
+}
+
+// Default returns the configuration used when neither a file nor environment variables set a value
+func Default() Config {
+	database := db.DefaultConfig()
+	port, _ := strconv.Atoi(database.Port)
+
+	return Config{
+		Server: ServerConfig{
+			Port:      8080,
+			ConfigDir: "./config",
+			WasmDir:   "./wasm",
+			Compression: CompressionConfig{
+				Enabled: true,
+				MinSize: 1024,
+			},
+		},
+		GRPC: GRPCConfig{
+			Port: 9090,
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth: 10,
+		},
+		Database: DatabaseConfig{
+			Enabled:  true,
+			Host:     database.Host,
+			Port:     port,
+			User:     database.User,
+			Password: database.Password,
+			Name:     database.Database,
+
+			SlowQueryMs: 200,
+
+			ConnectRetries:   database.ConnectRetries,
+			ConnectBackoffMs: int(database.ConnectBackoff.Milliseconds()),
+
+			MaxOpenConns:       database.Pool.MaxOpenConns,
+			MaxIdleConns:       database.Pool.MaxIdleConns,
+			ConnMaxLifetimeSec: int(database.Pool.ConnMaxLifetime.Seconds()),
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"*"},
+		},
+		Upstream: UpstreamConfig{
+			RevalidationEntries: 1000,
+		},
+		GitOps: GitOpsConfig{
+			Branch:          "main",
+			Dir:             "./gitops",
+			IntervalSeconds: 60,
+			Prune:           true,
+		},
+		Backup: BackupConfig{
+			Destination:     "./backups",
+			IntervalMinutes: 1440,
+			Keep:            7,
+		},
+		Seed: SeedConfig{
+			Enabled: true,
+		},
+		Sessions: SessionsConfig{
+			Enabled:            true,
+			IdleTimeoutMinutes: 30,
+		},
+		Renames: RenamesConfig{
+			AliasDays: 30,
+		},
+		LLM: LLMConfig{
+			TimeoutSeconds: 60,
+		},
+	}
+}
+
+// Load reads the YAML file at path over the defaults, applies the environment
+// variable overrides and validates the result. An empty path skips the file.
+func Load(path string) (Config, error) {
+	config := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := config.applyEnv(); err != nil {
+		return Config{}, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// applyEnv overrides the configuration with the environment variables
+func (c *Config) applyEnv() error {
+	setInt := func(name string, target *int) error {
+		if value := os.Getenv(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s '%s': must be an integer", name, value)
+			}
+			*target = parsed
+		}
+		return nil
+	}
+	setString := func(name string, target *string) {
+		if value := os.Getenv(name); value != "" {
+			*target = value
+		}
+	}
+
+	if err := setInt("PORT", &c.Server.Port); err != nil {
+		return err
+	}
+	setString("CONFIG_DIR", &c.Server.ConfigDir)
+	setString("WASM_DIR", &c.Server.WasmDir)
+	if value := os.Getenv("COMPRESSION_ENABLED"); value != "" {
+		c.Server.Compression.Enabled = value == "true" || value == "1"
+	}
+	if err := setInt("COMPRESSION_MIN_SIZE", &c.Server.Compression.MinSize); err != nil {
+		return err
+	}
+
+	if value := os.Getenv("GRPC_ENABLED"); value != "" {
+		c.GRPC.Enabled = value == "true" || value == "1"
+	}
+	if err := setInt("GRPC_PORT", &c.GRPC.Port); err != nil {
+		return err
+	}
+
+	if value := os.Getenv("GRAPHQL_ENABLED"); value != "" {
+		c.GraphQL.Enabled = value == "true" || value == "1"
+	}
+	if err := setInt("GRAPHQL_MAX_DEPTH", &c.GraphQL.MaxDepth); err != nil {
+		return err
+	}
+
+	if value := os.Getenv("USE_POSTGRES"); value != "" {
+		c.Database.Enabled = value == "true" || value == "1"
+	}
+	setString("DB_HOST", &c.Database.Host)
+	if err := setInt("DB_PORT", &c.Database.Port); err != nil {
+		return err
+	}
+	setString("DB_USER", &c.Database.User)
+	setString("DB_PASSWORD", &c.Database.Password)
+	setString("DB_NAME", &c.Database.Name)
+	if err := setInt("DB_SLOW_QUERY_MS", &c.Database.SlowQueryMs); err != nil {
+		return err
+	}
+	if err := setInt("DB_CONNECT_RETRIES", &c.Database.ConnectRetries); err != nil {
+		return err
+	}
+	if err := setInt("DB_CONNECT_BACKOFF_MS", &c.Database.ConnectBackoffMs); err != nil {
+		return err
+	}
+	if err := setInt("DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns); err != nil {
+		return err
+	}
+	if err := setInt("DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns); err != nil {
+		return err
+	}
+	if err := setInt("DB_CONN_MAX_LIFETIME_SEC", &c.Database.ConnMaxLifetimeSec); err != nil {
+		return err
+	}
+
+	setString("LOG_LEVEL", &c.Log.Level)
+	setString("LOG_FORMAT", &c.Log.Format)
+
+	if value := os.Getenv("CORS_ALLOW_ORIGINS"); value != "" {
+		c.CORS.AllowOrigins = strings.Split(value, ",")
+		for i := range c.CORS.AllowOrigins {
+			c.CORS.AllowOrigins[i] = strings.TrimSpace(c.CORS.AllowOrigins[i])
+		}
+	}
+
+	if value := os.Getenv("RATE_LIMIT_RPS"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid RATE_LIMIT_RPS '%s': must be a number", value)
+		}
+		c.RateLimit.RequestsPerSecond = parsed
+	}
+	if err := setInt("RATE_LIMIT_BURST", &c.RateLimit.Burst); err != nil {
+		return err
+	}
+
+	if value := os.Getenv("UPSTREAM_ALLOWED_HOSTS"); value != "" {
+		c.Upstream.AllowedHosts = strings.Split(value, ",")
+		for i := range c.Upstream.AllowedHosts {
+			c.Upstream.AllowedHosts[i] = strings.TrimSpace(c.Upstream.AllowedHosts[i])
+		}
+	}
+	if value := os.Getenv("UPSTREAM_PROTOCOLS"); value != "" {
+		c.Upstream.Protocols = map[string]string{}
+		for _, entry := range strings.Split(value, ",") {
+			host, protocol, ok := strings.Cut(entry, "=")
+			if !ok {
+				return fmt.Errorf("UPSTREAM_PROTOCOLS entry '%s' must be host=protocol", strings.TrimSpace(entry))
+			}
+			c.Upstream.Protocols[strings.TrimSpace(host)] = strings.TrimSpace(protocol)
+		}
+	}
+	if value := os.Getenv("UPSTREAM_ALLOW_STDIO"); value != "" {
+		c.Upstream.AllowStdio = value == "true" || value == "1"
+	}
+	if err := setInt("UPSTREAM_REVALIDATION_ENTRIES", &c.Upstream.RevalidationEntries); err != nil {
+		return err
+	}
+
+	if value := os.Getenv("NETWORK_TRUSTED_PROXIES"); value != "" {
+		c.Network.TrustedProxies = strings.Split(value, ",")
+		for i := range c.Network.TrustedProxies {
+			c.Network.TrustedProxies[i] = strings.TrimSpace(c.Network.TrustedProxies[i])
+		}
+	}
+
+	setString("ADMIN_TOKEN", &c.Admin.Token)
+
+	if value := os.Getenv("APPROVAL_ENABLED"); value != "" {
+		c.Approval.Enabled = value == "true" || value == "1"
+	}
+	setString("APPROVAL_TOKEN", &c.Approval.Token)
+
+	if value := os.Getenv("GITOPS_ENABLED"); value != "" {
+		c.GitOps.Enabled = value == "true" || value == "1"
+	}
+	setString("GITOPS_REPOSITORY", &c.GitOps.Repository)
+	setString("GITOPS_BRANCH", &c.GitOps.Branch)
+	setString("GITOPS_PATH", &c.GitOps.Path)
+	setString("GITOPS_DIR", &c.GitOps.Dir)
+	if err := setInt("GITOPS_INTERVAL_SECONDS", &c.GitOps.IntervalSeconds); err != nil {
+		return err
+	}
+	if value := os.Getenv("GITOPS_PRUNE"); value != "" {
+		c.GitOps.Prune = value == "true" || value == "1"
+	}
+
+	if value := os.Getenv("BACKUP_ENABLED"); value != "" {
+		c.Backup.Enabled = value == "true" || value == "1"
+	}
+	setString("BACKUP_DESTINATION", &c.Backup.Destination)
+	if err := setInt("BACKUP_INTERVAL_MINUTES", &c.Backup.IntervalMinutes); err != nil {
+		return err
+	}
+	if err := setInt("BACKUP_KEEP", &c.Backup.Keep); err != nil {
+		return err
+	}
+	if err := setInt("BACKUP_MAX_AGE_DAYS", &c.Backup.MaxAgeDays); err != nil {
+		return err
+	}
+	setString("BACKUP_S3_ENDPOINT", &c.Backup.S3.Endpoint)
+	setString("BACKUP_S3_REGION", &c.Backup.S3.Region)
+	setString("BACKUP_S3_ACCESS_KEY_ID", &c.Backup.S3.AccessKeyID)
+	setString("BACKUP_S3_SECRET_ACCESS_KEY", &c.Backup.S3.SecretAccessKey)
+
+	if value := os.Getenv("SEED_ENABLED"); value != "" {
+		c.Seed.Enabled = value == "true" || value == "1"
+	}
+
+	if value := os.Getenv("SESSIONS_ENABLED"); value != "" {
+		c.Sessions.Enabled = value == "true" || value == "1"
+	}
+	if err := setInt("SESSIONS_IDLE_TIMEOUT_MINUTES", &c.Sessions.IdleTimeoutMinutes); err != nil {
+		return err
+	}
+	if err := setInt("RENAMES_ALIAS_DAYS", &c.Renames.AliasDays); err != nil {
+		return err
+	}
+
+	setString("LLM_PROVIDER", &c.LLM.Provider)
+	setString("LLM_URL", &c.LLM.URL)
+	setString("LLM_API_KEY", &c.LLM.APIKey)
+	setString("LLM_MODEL", &c.LLM.Model)
+	if err := setInt("LLM_TIMEOUT_SECONDS", &c.LLM.TimeoutSeconds); err != nil {
+		return err
+	}
+
+	setString("ERROR_REPORTING_DSN", &c.ErrorReporting.DSN)
+	setString("ERROR_REPORTING_ENVIRONMENT", &c.ErrorReporting.Environment)
+
+	return nil
+}
+
+// Validate reports all invalid settings at once
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port %d must be between 1 and 65535", c.Server.Port))
+	}
+	if c.Server.ConfigDir == "" {
+		errs = append(errs, errors.New("server.configDir must not be empty"))
+	}
+	if c.Server.WasmDir == "" {
+		errs = append(errs, errors.New("server.wasmDir must not be empty"))
+	}
+	if c.Server.Compression.MinSize < 0 {
+		errs = append(errs, fmt.Errorf("server.compression.minSize %d must not be negative", c.Server.Compression.MinSize))
+	}
+
+	if c.GRPC.Enabled {
+		if c.GRPC.Port < 1 || c.GRPC.Port > 65535 {
+			errs = append(errs, fmt.Errorf("grpc.port %d must be between 1 and 65535", c.GRPC.Port))
+		} else if c.GRPC.Port == c.Server.Port {
+			errs = append(errs, fmt.Errorf("grpc.port %d must differ from server.port", c.GRPC.Port))
+		}
+	}
+	if c.GraphQL.MaxDepth < 1 {
+		errs = append(errs, fmt.Errorf("graphql.maxDepth %d must be at least 1", c.GraphQL.MaxDepth))
+	}
+
+	if c.Database.Enabled {
+		if c.Database.Host == "" {
+			errs = append(errs, errors.New("database.host must not be empty"))
+		}
+		if c.Database.Port < 1 || c.Database.Port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port %d must be between 1 and 65535", c.Database.Port))
+		}
+		if c.Database.Name == "" {
+			errs = append(errs, errors.New("database.name must not be empty"))
+		}
+		if c.Database.SlowQueryMs < 0 {
+			errs = append(errs, fmt.Errorf("database.slowQueryMs %d must not be negative", c.Database.SlowQueryMs))
+		}
+		if c.Database.ConnectRetries < 0 {
+			errs = append(errs, fmt.Errorf("database.connectRetries %d must not be negative", c.Database.ConnectRetries))
+		}
+		if c.Database.ConnectRetries > 0 && c.Database.ConnectBackoffMs <= 0 {
+			errs = append(errs, fmt.Errorf("database.connectBackoffMs %d must be positive", c.Database.ConnectBackoffMs))
+		}
+		if c.Database.MaxOpenConns < 0 {
+			errs = append(errs, fmt.Errorf("database.maxOpenConns %d must not be negative", c.Database.MaxOpenConns))
+		}
+		if c.Database.MaxIdleConns < 0 {
+			errs = append(errs, fmt.Errorf("database.maxIdleConns %d must not be negative", c.Database.MaxIdleConns))
+		}
+		if c.Database.ConnMaxLifetimeSec < 0 {
+			errs = append(errs, fmt.Errorf("database.connMaxLifetimeSec %d must not be negative", c.Database.ConnMaxLifetimeSec))
+		}
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log.level '%s' must be debug, info, warn or error", c.Log.Level))
+	}
+	if format := strings.ToLower(c.Log.Format); format != "text" && format != "json" {
+		errs = append(errs, fmt.Errorf("log.format '%s' must be text or json", c.Log.Format))
+	}
+
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.Path != "" {
+			errs = append(errs, fmt.Errorf("cors.allowOrigins entry '%s' must be '*' or an http(s) origin", origin))
+		}
+	}
+
+	if c.RateLimit.RequestsPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.requestsPerSecond %g must not be negative", c.RateLimit.RequestsPerSecond))
+	}
+	if c.RateLimit.Burst < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.burst %d must not be negative", c.RateLimit.Burst))
+	}
+
+	for _, host := range c.Upstream.AllowedHosts {
+		if host == "" || strings.ContainsAny(host, "/: ") || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			errs = append(errs, fmt.Errorf("upstream.allowedHosts entry '%s' must be a host name, optionally prefixed with '*.'", host))
+		}
+	}
+
+	for host, protocol := range c.Upstream.Protocols {
+		if host == "" || strings.ContainsAny(host, "/: ") || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			errs = append(errs, fmt.Errorf("upstream.protocols host '%s' must be a host name, optionally prefixed with '*.'", host))
+		}
+		if !models.IsUpstreamProtocol(protocol) {
+			errs = append(errs, fmt.Errorf("upstream.protocols.%s '%s' must be http1, h2 or h2c", host, protocol))
+		}
+	}
+
+	if c.Upstream.RevalidationEntries < 0 {
+		errs = append(errs, fmt.Errorf("upstream.revalidationEntries %d must not be negative", c.Upstream.RevalidationEntries))
+	}
+
+	for name, ranges := range c.Network.Zones {
+		if !models.IsNetworkZoneName(name) {
+			errs = append(errs, fmt.Errorf("network.zones name '%s' must start with a letter and contain letters, digits, '-' and '_'", name))
+		}
+		for _, entry := range ranges {
+			if _, err := models.ParseNetwork(entry); err != nil {
+				errs = append(errs, fmt.Errorf("network.zones.%s entry '%s' must be a CIDR range or an IP address", name, entry))
+			}
+		}
+	}
+	for _, entry := range c.Network.TrustedProxies {
+		if _, err := models.ParseNetwork(entry); err != nil {
+			errs = append(errs, fmt.Errorf("network.trustedProxies entry '%s' must be a CIDR range or an IP address", entry))
+		}
+	}
+
+	if c.Approval.Enabled && c.ApproverToken() == "" {
+		errs = append(errs, errors.New("approval.enabled requires approval.token or admin.token, revisions could not be approved"))
+	}
+
+	if c.GitOps.Enabled {
+		if c.GitOps.Repository == "" {
+			errs = append(errs, errors.New("gitops.repository must not be empty"))
+		}
+		if c.GitOps.Branch == "" {
+			errs = append(errs, errors.New("gitops.branch must not be empty"))
+		}
+		if c.GitOps.Dir == "" {
+			errs = append(errs, errors.New("gitops.dir must not be empty"))
+		}
+		if c.GitOps.IntervalSeconds < 1 {
+			errs = append(errs, fmt.Errorf("gitops.intervalSeconds %d must be positive", c.GitOps.IntervalSeconds))
+		}
+		if c.GitOps.Path != "" && !filepath.IsLocal(filepath.FromSlash(c.GitOps.Path)) {
+			errs = append(errs, fmt.Errorf("gitops.path '%s' must be a relative path within the repository", c.GitOps.Path))
+		}
+	}
+
+	if c.Backup.Enabled {
+		if c.Backup.Destination == "" {
+			errs = append(errs, errors.New("backup.destination must not be empty"))
+		} else if strings.HasPrefix(c.Backup.Destination, "s3://") || strings.HasPrefix(c.Backup.Destination, "gs://") {
+			if c.Backup.S3.AccessKeyID == "" || c.Backup.S3.SecretAccessKey == "" {
+				errs = append(errs, fmt.Errorf("backup.destination '%s' requires backup.s3.accessKeyId and backup.s3.secretAccessKey", c.Backup.Destination))
+			}
+		}
+		if c.Backup.IntervalMinutes < 1 {
+			errs = append(errs, fmt.Errorf("backup.intervalMinutes %d must be positive", c.Backup.IntervalMinutes))
+		}
+		if c.Backup.Keep < 0 {
+			errs = append(errs, fmt.Errorf("backup.keep %d must not be negative", c.Backup.Keep))
+		}
+		if c.Backup.MaxAgeDays < 0 {
+			errs = append(errs, fmt.Errorf("backup.maxAgeDays %d must not be negative", c.Backup.MaxAgeDays))
+		}
+	}
+
+	if c.Sessions.Enabled && c.Sessions.IdleTimeoutMinutes < 1 {
+		errs = append(errs, fmt.Errorf("sessions.idleTimeoutMinutes %d must be positive", c.Sessions.IdleTimeoutMinutes))
+	}
+	if c.Renames.AliasDays < 0 {
+		errs = append(errs, fmt.Errorf("renames.aliasDays %d must not be negative", c.Renames.AliasDays))
+	}
+
+	if c.LLM.Provider != "" {
+		if c.LLM.Provider != "openai" && c.LLM.Provider != "anthropic" {
+			errs = append(errs, fmt.Errorf("llm.provider '%s' must be openai or anthropic", c.LLM.Provider))
+		}
+		if c.LLM.Model == "" {
+			errs = append(errs, errors.New("llm.model must not be empty"))
+		}
+		if parsed, err := url.Parse(c.LLM.URL); c.LLM.URL != "" && (err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "") {
+			errs = append(errs, fmt.Errorf("llm.url '%s' must be an http(s) URL", c.LLM.URL))
+		}
+		if c.LLM.TimeoutSeconds < 1 {
+			errs = append(errs, fmt.Errorf("llm.timeoutSeconds %d must be positive", c.LLM.TimeoutSeconds))
+		}
+	}
+
+	if c.ErrorReporting.DSN != "" {
+		if err := errorsink.ParseDSN(c.ErrorReporting.DSN); err != nil {
+			errs = append(errs, fmt.Errorf("errorReporting.dsn %w", err))
+		}
+	}
+
+	for i, rule := range c.Redaction.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("redaction.rules[%d]: %w", i, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DB returns the connection parameters of the database
+func (c *Config) DB() db.Config {
+	return db.Config{
+		Host:     c.Database.Host,
+		Port:     strconv.Itoa(c.Database.Port),
+		User:     c.Database.User,
+		Password: c.Database.Password,
+		Database: c.Database.Name,
+
+		ConnectRetries: c.Database.ConnectRetries,
+		ConnectBackoff: time.Duration(c.Database.ConnectBackoffMs) * time.Millisecond,
+
+		Pool: db.PoolConfig{
+			MaxOpenConns:    c.Database.MaxOpenConns,
+			MaxIdleConns:    c.Database.MaxIdleConns,
+			ConnMaxLifetime: time.Duration(c.Database.ConnMaxLifetimeSec) * time.Second,
+		},
+	}
+}
+
+// ApproverToken returns the bearer token approving revisions of MCP servers
+func (c Config) ApproverToken() string {
+	if c.Approval.Token != "" {
+		return c.Approval.Token
+	}
+	return c.Admin.Token
+}
+
+// Redacted returns a copy of the configuration safe to expose, with secrets replaced
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	if c.Admin.Token != "" {
+		c.Admin.Token = redacted
+	}
+	if c.Approval.Token != "" {
+		c.Approval.Token = redacted
+	}
+	if c.LLM.APIKey != "" {
+		c.LLM.APIKey = redacted
+	}
+	if c.Backup.S3.SecretAccessKey != "" {
+		c.Backup.S3.SecretAccessKey = redacted
+	}
+	if parsed, err := url.Parse(c.ErrorReporting.DSN); err == nil && parsed.User != nil {
+		parsed.User = url.User(redacted)
+		c.ErrorReporting.DSN = parsed.String()
+	}
+	if parsed, err := url.Parse(c.GitOps.Repository); err == nil && parsed.User != nil {
+		if _, ok := parsed.User.Password(); ok {
+			parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+			c.GitOps.Repository = parsed.String()
+		}
+	}
+	c.CORS.AllowOrigins = append([]string(nil), c.CORS.AllowOrigins...)
+	c.Upstream.AllowedHosts = append([]string(nil), c.Upstream.AllowedHosts...)
+	c.Upstream.Protocols = maps.Clone(c.Upstream.Protocols)
+	c.Network.Zones = maps.Clone(c.Network.Zones)
+	c.Network.TrustedProxies = append([]string(nil), c.Network.TrustedProxies...)
+	c.Redaction.Rules = append([]models.RedactionRule(nil), c.Redaction.Rules...)
+	return c
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// Manager holds the current configuration and reloads it from its file
+type Manager struct {
+	path     string
+	current  Config
+	onReload []func(Config)
+	mu       sync.RWMutex
+	reloadMu sync.Mutex // Serializes reloads
+}
+
+// NewManager loads the configuration file at path, see Load
+func NewManager(path string) (*Manager, error) {
+	config, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{path: path, current: config}, nil
+}
+
+// Current returns the current configuration
+func (m *Manager) Current() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.current
+}
+
+// OnReload registers a function applying the reloadable settings of a new configuration
+func (m *Manager) OnReload(apply func(Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onReload = append(m.onReload, apply)
+}
+
+// Reload reads the configuration file and environment again and applies the new configuration.
+// An invalid configuration is rejected and the current one kept.
+// Server, database and GitOps settings only take effect after a restart.
+func (m *Manager) Reload() (Config, error) {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	config, err := Load(m.path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	m.mu.Lock()
+	previous := m.current
+	m.current = config
+	hooks := append([]func(Config){}, m.onReload...)
+	m.mu.Unlock()
+
+	if config.Server != previous.Server {
+		slog.Warn("Server configuration changed, restart the gateway to apply it")
+	}
+	if config.Database != previous.Database {
+		slog.Warn("Database configuration changed, restart the gateway to apply it")
+	}
+
+	if config.GitOps != previous.GitOps {
+		slog.Warn("GitOps configuration changed, restart the gateway to apply it")
+	}
+
+	for _, apply := range hooks {
+		apply(config)
+	}
+
+	slog.Info("Configuration reloaded", "path", m.path)
+	return config, nil
+}
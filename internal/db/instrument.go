@@ -0,0 +1,236 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+)
+
+// maxLoggedQuery bounds the statement text of slow query records
+const maxLoggedQuery = 1000
+
+// slowQueryThreshold is the duration above which statements are logged, 0 disables the log
+var slowQueryThreshold atomic.Int64
+
+// statementTable matches the table a statement reads or writes first
+var statementTable = regexp.MustCompile(`(?i)\b(?:from|into|update|table(?:\s+if\s+(?:not\s+)?exists)?)\s+([a-z_][a-z0-9_]*)`)
+
+// SetSlowQueryThreshold sets the duration above which statements are logged as slow queries,
+// 0 disables the log. Statement durations are exported as metrics in any case.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// instrumentedConnector opens connections that time every statement they run
+type instrumentedConnector struct {
+	next driver.Connector
+}
+
+func (c instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.next.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn}, nil
+}
+
+func (c instrumentedConnector) Driver() driver.Driver {
+	return c.next.Driver()
+}
+
+// instrumentedConn times the statements run on a driver connection. It implements the optional
+// interfaces of lib/pq connections by delegating to them. A connection that failed with a
+// transient error, e.g. because the database restarted, is reported as invalid so that the pool
+// discards it and dials a new one.
+type instrumentedConn struct {
+	driver.Conn
+	broken bool // Connections are used by one goroutine at a time
+}
+
+// check marks the connection as broken if err is transient
+func (c *instrumentedConn) check(err error) error {
+	if err != nil && err != driver.ErrSkip && isTransient(err) {
+		c.broken = true
+	}
+	return err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		observeQuery(ctx, query, time.Since(start))
+	}
+	return rows, c.check(err)
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		observeQuery(ctx, query, time.Since(start))
+	}
+	return result, c.check(err)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, c.check(err)
+	}
+	return &instrumentedStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err := beginner.BeginTx(ctx, opts)
+		return tx, c.check(err)
+	}
+	tx, err := c.Conn.Begin() //nolint:staticcheck // Fallback of drivers without BeginTx
+	return tx, c.check(err)
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if c.broken {
+		return driver.ErrBadConn
+	}
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return c.check(pinger.Ping(ctx))
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if c.broken {
+		return driver.ErrBadConn
+	}
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if c.broken {
+		return false
+	}
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// instrumentedStmt times the executions of a prepared statement
+type instrumentedStmt struct {
+	driver.Stmt
+	conn  *instrumentedConn
+	query string
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	defer func() { observeQuery(ctx, s.query, time.Since(start)) }()
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err := queryer.QueryContext(ctx, args)
+		return rows, s.conn.check(err)
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Stmt.Query(values) //nolint:staticcheck // Fallback of drivers without QueryContext
+	return rows, s.conn.check(err)
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	defer func() { observeQuery(ctx, s.query, time.Since(start)) }()
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err := execer.ExecContext(ctx, args)
+		return result, s.conn.check(err)
+	}
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.Stmt.Exec(values) //nolint:staticcheck // Fallback of drivers without ExecContext
+	return result, s.conn.check(err)
+}
+
+// namedValues converts positional named values for the statement methods without context
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, driver.ErrSkip
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+// observeQuery exports the duration of a statement and logs it if it is slow. The arguments are
+// never logged, they may hold secrets.
+func observeQuery(ctx context.Context, query string, duration time.Duration) {
+	operation, table := statementLabels(query)
+	threshold := time.Duration(slowQueryThreshold.Load())
+	slow := threshold > 0 && duration >= threshold
+	metrics.ObserveDBQuery(operation, table, duration, slow)
+	if slow {
+		slog.WarnContext(ctx, "Slow database query", "operation", operation, "table", table,
+			"durationMs", duration.Milliseconds(), "query", compactQuery(query))
+	}
+}
+
+// statementLabels returns the operation of a statement, e.g. SELECT, and the first table it names
+func statementLabels(query string) (string, string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "OTHER", ""
+	}
+	operation := strings.ToUpper(fields[0])
+	switch operation {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH", "CREATE", "ALTER", "DROP":
+	default:
+		operation = "OTHER"
+	}
+
+	table := ""
+	if match := statementTable.FindStringSubmatch(query); match != nil {
+		table = strings.ToLower(match[1])
+	}
+	return operation, table
+}
+
+// compactQuery collapses the whitespace of a statement for logging and bounds its length
+func compactQuery(query string) string {
+	compact := strings.Join(strings.Fields(query), " ")
+	if len(compact) > maxLoggedQuery {
+		compact = compact[:maxLoggedQuery] + "..."
+	}
+	return compact
+}
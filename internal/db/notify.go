@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// notification is the payload published on a channel
+type notification struct {
+	Origin string `json:"origin"` // Instance that made the change
+	ID     string `json:"id"`     // Changed entity
+}
+
+// Notifier propagates changes between gateway instances sharing a database using LISTEN/NOTIFY
+type Notifier struct {
+	db         *sql.DB
+	config     Config
+	channel    string
+	instanceID string
+	listener   *pq.Listener
+}
+
+// NewNotifier creates a notifier publishing and listening on channel
+func NewNotifier(db *sql.DB, config Config, channel string) *Notifier {
+	return &Notifier{
+		db:         db,
+		config:     config,
+		channel:    channel,
+		instanceID: uuid.New().String(),
+	}
+}
+
+// Publish notifies the other instances that the entity with the given ID changed
+func (n *Notifier) Publish(ctx context.Context, id string) error {
+	payload, err := json.Marshal(notification{Origin: n.instanceID, ID: id})
+	if err != nil {
+		return err
+	}
+
+	_, err = n.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", n.channel, string(payload))
+	return err
+}
+
+// Listen calls onChange for every change published by another instance.
+// Notifications may be lost while the connection is down, so onReconnect is called
+// after the listener reconnects to let the caller resynchronize its state.
+func (n *Notifier) Listen(onChange func(id string), onReconnect func()) error {
+	n.listener = pq.NewListener(n.config.ConnString(), 10*time.Second, time.Minute,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				slog.Warn("Database listener error", "channel", n.channel, "error", err)
+			}
+		})
+	if err := n.listener.Listen(n.channel); err != nil {
+		n.listener.Close()
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case notice, ok := <-n.listener.Notify:
+				if !ok {
+					return
+				}
+				// A nil notification signals a reconnection
+				if notice == nil {
+					slog.Info("Database listener reconnected", "channel", n.channel)
+					onReconnect()
+					continue
+				}
+
+				var payload notification
+				if err := json.Unmarshal([]byte(notice.Extra), &payload); err != nil {
+					slog.Warn("Invalid change notification", "channel", n.channel, "payload", notice.Extra, "error", err)
+					continue
+				}
+				if payload.Origin == n.instanceID {
+					continue
+				}
+				onChange(payload.ID)
+			case <-time.After(90 * time.Second):
+				// Detect broken connections while no notifications arrive
+				go n.listener.Ping()
+			}
+		}
+	}()
+
+	slog.Info("Listening for change notifications", "channel", n.channel, "instance", n.instanceID)
+	return nil
+}
+
+// Close stops listening for notifications
+func (n *Notifier) Close() error {
+	if n.listener == nil {
+		return nil
+	}
+	return n.listener.Close()
+}
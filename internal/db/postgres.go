@@ -1,13 +1,23 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"os"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// maxConnectBackoff caps the delay between two connection attempts at startup
+const maxConnectBackoff = 30 * time.Second
+
 // Config holds the PostgreSQL connection parameters
 type Config struct {
 	Host     string
@@ -15,6 +25,25 @@ type Config struct {
 	User     string
 	Password string
 	Database string
+
+	ConnectRetries int           // Attempts after a failed connection at startup, 0 to fail at once
+	ConnectBackoff time.Duration // Delay before the first retry, doubled after each attempt
+
+	Pool PoolConfig
+}
+
+// PoolConfig sizes the connection pool, see the corresponding setters of sql.DB
+type PoolConfig struct {
+	MaxOpenConns    int           // Connections open at once, 0 for unlimited
+	MaxIdleConns    int           // Idle connections kept open, 0 to close them at once
+	ConnMaxLifetime time.Duration // Age after which connections are closed, 0 to reuse them forever
+}
+
+// Apply sets the pool limits of db
+func (p PoolConfig) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
 }
 
 // DefaultConfig returns the default database configuration
@@ -25,6 +54,15 @@ func DefaultConfig() Config {
 		User:     "admin",
 		Password: "Admin123",
 		Database: "mcp-gateway",
+
+		ConnectRetries: 10,
+		ConnectBackoff: 500 * time.Millisecond,
+
+		Pool: PoolConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
 	}
 }
 
@@ -56,25 +94,79 @@ func GetConfig() Config {
 	return config
 }
 
-// ConnectDB establishes a connection to the PostgreSQL database
-func ConnectDB() (*sql.DB, error) {
-	config := GetConfig()
+// ConnString returns the lib/pq connection string of the configuration
+func (c Config) ConnString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		c.Host, c.Port, c.User, c.Password, c.Database)
+}
 
-	// Construct the connection string
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		config.Host, config.Port, config.User, config.Password, config.Database)
+// ConnectDB establishes a connection to the PostgreSQL database configured by environment variables
+func ConnectDB() (*sql.DB, error) {
+	return Connect(GetConfig())
+}
 
+// Connect establishes a connection to the PostgreSQL database. The statements run on it are
+// timed, see SetSlowQueryThreshold. While the database is unreachable, e.g. because it is still
+// starting, the connection is retried config.ConnectRetries times with exponential backoff.
+// Pooled connections broken later on are replaced transparently.
+func Connect(config Config) (*sql.DB, error) {
 	// Open a connection to the database
-	db, err := sql.Open("postgres", connStr)
+	connector, err := pq.NewConnector(config.ConnString())
 	if err != nil {
 		return nil, fmt.Errorf("error opening database connection: %v", err)
 	}
-
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("error connecting to database: %v", err)
+	db := sql.OpenDB(instrumentedConnector{next: connector})
+	config.Pool.Apply(db)
+
+	// Test the connection, waiting for the database to come up
+	backoff := config.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		err = pingDB(db)
+		if err == nil {
+			break
+		}
+		if attempt >= config.ConnectRetries || !(isTransient(err) || errors.Is(err, context.DeadlineExceeded)) {
+			db.Close()
+			return nil, fmt.Errorf("error connecting to database: %v", err)
+		}
+
+		slog.Warn("Database not reachable, retrying", "host", config.Host, "port", config.Port,
+			"attempt", attempt+1, "retries", config.ConnectRetries, "retryInMs", backoff.Milliseconds(), "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
 	}
 
 	return db, nil
 }
+
+// pingDB tests a connection to the database, bounding the attempt so that retries are not
+// stalled by an unresponsive host
+func pingDB(db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+// isTransient reports whether err is caused by an unreachable database or a broken connection,
+// which a new connection may not suffer from, rather than by the statement or the credentials
+func isTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+			return true
+		}
+		return pqErr.Code.Class() == "08" // connection_exception
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+// Package graphqlapi serves the read-only GraphQL admin API of the gateway.
+package graphqlapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// Request is a GraphQL query, sent as the JSON body of a POST or the query parameters of a GET
+type Request struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries over the HTTP interfaces, MCP servers, their versions and the
+// invocation history, for nested reads the REST list endpoints need many requests for
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// NewHandler creates a GraphQL handler rejecting queries nested deeper than maxDepth
+func NewHandler(httpRepo repository.HTTPInterfaceRepository, mcpRepo repository.MCPServerRepository, invocationRepo repository.InvocationRepository, maxDepth int) *Handler {
+	root := &resolver{httpRepo: httpRepo, mcpRepo: mcpRepo, invocationRepo: invocationRepo}
+	return &Handler{schema: graphql.MustParseSchema(schema, root, graphql.MaxDepth(maxDepth))}
+}
+
+// RegisterRoutes registers the GraphQL route
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/graphql", h.Query)
+	router.POST("/graphql", h.Query)
+}
+
+// Query runs a query in the namespace of the request. Errors of the query are part of the
+// response, as GraphQL clients expect, only unreadable requests fail with 400.
+func (h *Handler) Query(c *gin.Context) {
+	var req Request
+	if c.Request.Method == http.MethodGet {
+		if err := c.ShouldBindQuery(&req); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				apierror.Respond(c, http.StatusBadRequest, "invalid variables: "+err.Error())
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.schema.Exec(c.Request.Context(), req.Query, req.OperationName, req.Variables))
+}
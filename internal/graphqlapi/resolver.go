@@ -0,0 +1,427 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Page sizes of the invocations, as in the REST API
+const (
+	defaultInvocationLimit = 50
+	maxInvocationLimit     = 500
+)
+
+// serverStatuses are the statuses the servers can be listed by
+var serverStatuses = []string{"draft", "active", "inactive", "archived"}
+
+// jsonValue is the JSON scalar, written as the JSON encoding of its value
+type jsonValue struct {
+	value interface{}
+}
+
+// ImplementsGraphQLType binds the type to the JSON scalar
+func (jsonValue) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+// UnmarshalGraphQL accepts any input value
+func (v *jsonValue) UnmarshalGraphQL(input interface{}) error {
+	v.value = input
+	return nil
+}
+
+// MarshalJSON writes the value
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// resolver is the root of the queries
+type resolver struct {
+	httpRepo       repository.HTTPInterfaceRepository
+	mcpRepo        repository.MCPServerRepository
+	invocationRepo repository.InvocationRepository
+}
+
+// HTTPInterfaces lists the HTTP interfaces of the namespace
+func (r *resolver) HTTPInterfaces(ctx context.Context, args struct{ IncludeArchived *bool }) ([]*interfaceResolver, error) {
+	interfaces, err := r.httpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*interfaceResolver, 0, len(interfaces))
+	for i := range interfaces {
+		if interfaces[i].Archived && (args.IncludeArchived == nil || !*args.IncludeArchived) {
+			continue
+		}
+		resolvers = append(resolvers, &interfaceResolver{root: r, httpInterface: &interfaces[i]})
+	}
+	return resolvers, nil
+}
+
+// HTTPInterface returns an HTTP interface, nil if it does not exist
+func (r *resolver) HTTPInterface(ctx context.Context, args struct{ ID graphql.ID }) (*interfaceResolver, error) {
+	return r.httpInterface(ctx, string(args.ID))
+}
+
+// httpInterface returns the resolver of an HTTP interface, nil if it does not exist
+func (r *resolver) httpInterface(ctx context.Context, id string) (*interfaceResolver, error) {
+	httpInterface, err := r.httpRepo.GetByID(ctx, id)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &interfaceResolver{root: r, httpInterface: httpInterface}, nil
+}
+
+// MCPServers lists the MCP servers of the namespace
+func (r *resolver) MCPServers(ctx context.Context, args struct {
+	Status          *string
+	IncludeArchived *bool
+}) ([]*serverResolver, error) {
+	if args.Status != nil && !slices.Contains(serverStatuses, *args.Status) {
+		return nil, fmt.Errorf("invalid status '%s': must be one of %s", *args.Status, strings.Join(serverStatuses, ", "))
+	}
+	servers, err := r.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*serverResolver, 0, len(servers))
+	for i := range servers {
+		if args.Status != nil && servers[i].Status != *args.Status {
+			continue
+		}
+		// Servers of the archived status requested are listed without includeArchived
+		if args.Status == nil && servers[i].Status == "archived" && (args.IncludeArchived == nil || !*args.IncludeArchived) {
+			continue
+		}
+		resolvers = append(resolvers, &serverResolver{root: r, server: &servers[i]})
+	}
+	return resolvers, nil
+}
+
+// MCPServer returns an MCP server, nil if it does not exist
+func (r *resolver) MCPServer(ctx context.Context, args struct{ ID graphql.ID }) (*serverResolver, error) {
+	server, err := r.mcpRepo.GetByID(ctx, string(args.ID))
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &serverResolver{root: r, server: server}, nil
+}
+
+// invocations returns a page of the invocations matching the arguments
+func (r *resolver) invocations(ctx context.Context, filter repository.InvocationFilter, args invocationArgs) (*invocationPageResolver, error) {
+	filter.Limit = defaultInvocationLimit
+	if args.Status != nil {
+		if *args.Status != "success" && *args.Status != "error" {
+			return nil, fmt.Errorf("invalid status '%s': must be success or error", *args.Status)
+		}
+		filter.Status = *args.Status
+	}
+	if args.Since != nil {
+		filter.Since = args.Since.Time
+	}
+	if args.Until != nil {
+		filter.Until = args.Until.Time
+	}
+	if args.Limit != nil {
+		if *args.Limit < 1 || *args.Limit > maxInvocationLimit {
+			return nil, fmt.Errorf("invalid limit %d: must be between 1 and %d", *args.Limit, maxInvocationLimit)
+		}
+		filter.Limit = int(*args.Limit)
+	}
+	if args.Offset != nil {
+		if *args.Offset < 0 {
+			return nil, fmt.Errorf("invalid offset %d: must be a non-negative integer", *args.Offset)
+		}
+		filter.Offset = int(*args.Offset)
+	}
+
+	invocations, total, err := r.invocationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &invocationPageResolver{invocations: invocations, total: total}, nil
+}
+
+// invocationArgs are the filter and pagination arguments of the invocations of servers and tools
+type invocationArgs struct {
+	Status *string
+	Since  *graphql.Time
+	Until  *graphql.Time
+	Limit  *int32
+	Offset *int32
+}
+
+// interfaceResolver resolves the fields of an HTTP interface
+type interfaceResolver struct {
+	root          *resolver
+	httpInterface *models.HTTPInterface
+}
+
+func (r *interfaceResolver) ID() graphql.ID      { return graphql.ID(r.httpInterface.ID) }
+func (r *interfaceResolver) Name() string        { return r.httpInterface.Name }
+func (r *interfaceResolver) Namespace() string   { return r.httpInterface.Namespace }
+func (r *interfaceResolver) Description() string { return r.httpInterface.Description }
+func (r *interfaceResolver) Method() string      { return r.httpInterface.Method }
+func (r *interfaceResolver) Path() string        { return r.httpInterface.Path }
+func (r *interfaceResolver) Archived() bool      { return r.httpInterface.Archived }
+func (r *interfaceResolver) Deprecated() bool    { return r.httpInterface.Deprecated }
+func (r *interfaceResolver) Sunset() *graphql.Time {
+	return optionalTime(r.httpInterface.Sunset)
+}
+func (r *interfaceResolver) DeprecationMessage() string { return r.httpInterface.DeprecationMessage }
+func (r *interfaceResolver) Version() int32             { return int32(r.httpInterface.Version) }
+func (r *interfaceResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.httpInterface.CreatedAt}
+}
+func (r *interfaceResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.httpInterface.UpdatedAt}
+}
+func (r *interfaceResolver) Definition() jsonValue { return jsonValue{value: r.httpInterface} }
+func (r *interfaceResolver) Changelog() *changeNoteResolver {
+	return newChangeNoteResolver(r.httpInterface.Changelog)
+}
+
+// Versions returns all versions of the interface, oldest first
+func (r *interfaceResolver) Versions(ctx context.Context) ([]*interfaceResolver, error) {
+	versions, err := r.root.httpRepo.GetVersions(ctx, r.httpInterface.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*interfaceResolver, 0, len(versions))
+	for _, version := range versions {
+		httpInterface, err := r.root.httpRepo.GetByVersion(ctx, r.httpInterface.ID, version.Version)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &interfaceResolver{root: r.root, httpInterface: httpInterface})
+	}
+	return resolvers, nil
+}
+
+// AtVersion returns a version of the interface, nil if it does not exist
+func (r *interfaceResolver) AtVersion(ctx context.Context, args struct{ Version int32 }) (*interfaceResolver, error) {
+	httpInterface, err := r.root.httpRepo.GetByVersion(ctx, r.httpInterface.ID, int(args.Version))
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &interfaceResolver{root: r.root, httpInterface: httpInterface}, nil
+}
+
+// Servers returns the MCP servers of the namespace with tools generated from the interface
+func (r *interfaceResolver) Servers(ctx context.Context) ([]*serverResolver, error) {
+	servers, err := r.root.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resolvers []*serverResolver
+	for i := range servers {
+		if slices.ContainsFunc(servers[i].Tools, func(tool models.Tool) bool { return tool.InterfaceID == r.httpInterface.ID }) {
+			resolvers = append(resolvers, &serverResolver{root: r.root, server: &servers[i]})
+		}
+	}
+	return resolvers, nil
+}
+
+// serverResolver resolves the fields of an MCP server
+type serverResolver struct {
+	root   *resolver
+	server *models.MCPServer
+}
+
+func (r *serverResolver) ID() graphql.ID          { return graphql.ID(r.server.ID) }
+func (r *serverResolver) Name() string            { return r.server.Name }
+func (r *serverResolver) Namespace() string       { return r.server.Namespace }
+func (r *serverResolver) Description() string     { return r.server.Description }
+func (r *serverResolver) Instructions() string    { return r.server.Instructions }
+func (r *serverResolver) Status() string          { return r.server.Status }
+func (r *serverResolver) Version() int32          { return int32(r.server.Version) }
+func (r *serverResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.server.CreatedAt} }
+func (r *serverResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.server.UpdatedAt} }
+func (r *serverResolver) Definition() jsonValue   { return jsonValue{value: r.server} }
+func (r *serverResolver) Changelog() *changeNoteResolver {
+	return newChangeNoteResolver(r.server.Changelog)
+}
+
+// Tools returns the tools of the server
+func (r *serverResolver) Tools() []*toolResolver {
+	resolvers := make([]*toolResolver, 0, len(r.server.Tools))
+	for i := range r.server.Tools {
+		resolvers = append(resolvers, &toolResolver{root: r.root, server: r.server, tool: &r.server.Tools[i]})
+	}
+	return resolvers
+}
+
+// Tool returns the tool of a name or alias, nil if the server has none
+func (r *serverResolver) Tool(args struct{ Name string }) *toolResolver {
+	tool := r.server.FindTool(args.Name)
+	if tool == nil {
+		return nil
+	}
+	return &toolResolver{root: r.root, server: r.server, tool: tool}
+}
+
+// Versions returns all versions of the server, oldest first
+func (r *serverResolver) Versions(ctx context.Context) ([]*serverResolver, error) {
+	versions, err := r.root.mcpRepo.GetVersions(ctx, r.server.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*serverResolver, 0, len(versions))
+	for _, version := range versions {
+		server, err := r.root.mcpRepo.GetByVersion(ctx, r.server.ID, version.Version)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &serverResolver{root: r.root, server: server})
+	}
+	return resolvers, nil
+}
+
+// AtVersion returns a version of the server, nil if it does not exist
+func (r *serverResolver) AtVersion(ctx context.Context, args struct{ Version int32 }) (*serverResolver, error) {
+	server, err := r.root.mcpRepo.GetByVersion(ctx, r.server.ID, int(args.Version))
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &serverResolver{root: r.root, server: server}, nil
+}
+
+// Invocations returns a page of the invocations of the server, newest first
+func (r *serverResolver) Invocations(ctx context.Context, args struct {
+	Tool *string
+	invocationArgs
+}) (*invocationPageResolver, error) {
+	filter := repository.InvocationFilter{ServerID: r.server.ID}
+	if args.Tool != nil {
+		filter.Tool = *args.Tool
+	}
+	return r.root.invocations(ctx, filter, args.invocationArgs)
+}
+
+// toolResolver resolves the fields of a tool of an MCP server
+type toolResolver struct {
+	root   *resolver
+	server *models.MCPServer
+	tool   *models.Tool
+}
+
+func (r *toolResolver) Name() string        { return r.tool.Name }
+func (r *toolResolver) ExposedName() string { return r.tool.ExposedName() }
+func (r *toolResolver) Description() string { return r.tool.Description }
+func (r *toolResolver) Method() string      { return r.tool.RequestTemplate.Method }
+func (r *toolResolver) URL() string         { return r.tool.RequestTemplate.URL }
+func (r *toolResolver) Deprecated() bool    { return r.tool.Deprecated }
+func (r *toolResolver) Sunset() *graphql.Time {
+	return optionalTime(r.tool.Sunset)
+}
+func (r *toolResolver) DeprecationMessage() string { return r.tool.DeprecationMessage }
+func (r *toolResolver) Definition() jsonValue {
+	return jsonValue{value: r.tool}
+}
+
+// Interface returns the current version of the HTTP interface the tool was generated from
+func (r *toolResolver) Interface(ctx context.Context) (*interfaceResolver, error) {
+	if r.tool.InterfaceID == "" {
+		return nil, nil
+	}
+	return r.root.httpInterface(ctx, r.tool.InterfaceID)
+}
+
+// InterfaceVersion returns the version of the interface the tool was generated from
+func (r *toolResolver) InterfaceVersion() *int32 {
+	if r.tool.InterfaceID == "" {
+		return nil
+	}
+	version := int32(r.tool.InterfaceVersion)
+	return &version
+}
+
+// Invocations returns a page of the invocations recorded under the name of the tool, newest first
+func (r *toolResolver) Invocations(ctx context.Context, args invocationArgs) (*invocationPageResolver, error) {
+	return r.root.invocations(ctx, repository.InvocationFilter{ServerID: r.server.ID, Tool: r.tool.Name}, args)
+}
+
+// invocationPageResolver resolves a page of invocations
+type invocationPageResolver struct {
+	invocations []models.Invocation
+	total       int
+}
+
+// Items returns the invocations of the page
+func (r *invocationPageResolver) Items() []*invocationResolver {
+	resolvers := make([]*invocationResolver, 0, len(r.invocations))
+	for i := range r.invocations {
+		resolvers = append(resolvers, &invocationResolver{invocation: &r.invocations[i]})
+	}
+	return resolvers
+}
+
+// Total returns the number of matching invocations
+func (r *invocationPageResolver) Total() int32 {
+	return int32(r.total)
+}
+
+// invocationResolver resolves the fields of a recorded invocation
+type invocationResolver struct {
+	invocation *models.Invocation
+}
+
+func (r *invocationResolver) ID() graphql.ID       { return graphql.ID(r.invocation.ID) }
+func (r *invocationResolver) ServerID() graphql.ID { return graphql.ID(r.invocation.ServerID) }
+func (r *invocationResolver) ServerName() string   { return r.invocation.ServerName }
+func (r *invocationResolver) Tool() string         { return r.invocation.Tool }
+func (r *invocationResolver) Caller() string       { return r.invocation.Caller }
+func (r *invocationResolver) RequestID() string    { return r.invocation.RequestID }
+func (r *invocationResolver) StatusCode() int32    { return int32(r.invocation.StatusCode) }
+func (r *invocationResolver) Success() bool        { return r.invocation.Success }
+func (r *invocationResolver) Error() string        { return r.invocation.Error }
+func (r *invocationResolver) DurationMs() float64  { return float64(r.invocation.DurationMs) }
+func (r *invocationResolver) Request() string      { return r.invocation.Request }
+func (r *invocationResolver) Response() string     { return r.invocation.Response }
+func (r *invocationResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.invocation.CreatedAt}
+}
+
+// changeNoteResolver resolves the fields of the change note of a version
+type changeNoteResolver struct {
+	note *models.ChangeNote
+}
+
+// newChangeNoteResolver returns the resolver of a change note, nil without a note
+func newChangeNoteResolver(note *models.ChangeNote) *changeNoteResolver {
+	if note == nil {
+		return nil
+	}
+	return &changeNoteResolver{note: note}
+}
+
+func (r *changeNoteResolver) Author() string  { return r.note.Author }
+func (r *changeNoteResolver) Message() string { return r.note.Message }
+
+// optionalTime returns the GraphQL time of t, nil if t is not set
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
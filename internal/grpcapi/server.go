@@ -0,0 +1,398 @@
+// Package grpcapi serves the gRPC admin API of the gateway.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/grpcapi/gatewayv1"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// chunkSize is the largest part of a tool result sent in one event of StreamInvokeTool
+const chunkSize = 32 * 1024
+
+// forwardedHeaders are the headers set from the metadata of the same name
+var forwardedHeaders = []string{
+	"Authorization",
+	namespace.Header,
+	mcp.APIKeyHeader,
+	mcp.EnvironmentHeader,
+	mcp.CookieJarHeader,
+	logging.RequestIDHeader,
+}
+
+// Server implements the gRPC admin API by serving each call with the REST handler of the same
+// operation, so that both APIs validate, authorize and record changes the same way
+type Server struct {
+	gatewayv1.UnimplementedGatewayAdminServer
+	handler http.Handler
+}
+
+// NewServer creates a gRPC admin API served by the handler of the REST API
+func NewServer(handler http.Handler) *Server {
+	return &Server{handler: handler}
+}
+
+// ListHTTPInterfaces lists the HTTP interfaces of the namespace
+func (s *Server) ListHTTPInterfaces(ctx context.Context, req *gatewayv1.ListRequest) (*gatewayv1.ListResponse, error) {
+	return s.list(ctx, "/api/http-interfaces", req)
+}
+
+// GetHTTPInterface returns an HTTP interface
+func (s *Server) GetHTTPInterface(ctx context.Context, req *gatewayv1.IDRequest) (*structpb.Struct, error) {
+	return s.resource(ctx, http.MethodGet, "/api/http-interfaces/"+url.PathEscape(req.Id), nil)
+}
+
+// CreateHTTPInterface creates an HTTP interface
+func (s *Server) CreateHTTPInterface(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	return s.resource(ctx, http.MethodPost, "/api/http-interfaces", req)
+}
+
+// UpdateHTTPInterface replaces the definition of an HTTP interface
+func (s *Server) UpdateHTTPInterface(ctx context.Context, req *gatewayv1.UpdateRequest) (*structpb.Struct, error) {
+	return s.resource(ctx, http.MethodPut, "/api/http-interfaces/"+url.PathEscape(req.Id), req.Definition)
+}
+
+// DeleteHTTPInterface deletes an HTTP interface
+func (s *Server) DeleteHTTPInterface(ctx context.Context, req *gatewayv1.IDRequest) (*emptypb.Empty, error) {
+	if _, err := s.call(ctx, http.MethodDelete, "/api/http-interfaces/"+url.PathEscape(req.Id), nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListMCPServers lists the MCP servers of the namespace
+func (s *Server) ListMCPServers(ctx context.Context, req *gatewayv1.ListRequest) (*gatewayv1.ListResponse, error) {
+	return s.list(ctx, "/api/mcp-servers", req)
+}
+
+// GetMCPServer returns an MCP server
+func (s *Server) GetMCPServer(ctx context.Context, req *gatewayv1.IDRequest) (*structpb.Struct, error) {
+	return s.resource(ctx, http.MethodGet, "/api/mcp-servers/"+url.PathEscape(req.Id), nil)
+}
+
+// CreateMCPServer creates an MCP server
+func (s *Server) CreateMCPServer(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	return s.resource(ctx, http.MethodPost, "/api/mcp-servers", req)
+}
+
+// UpdateMCPServer replaces the definition of an MCP server, or submits the change for approval
+func (s *Server) UpdateMCPServer(ctx context.Context, req *gatewayv1.UpdateRequest) (*gatewayv1.MCPServerChange, error) {
+	resp, err := s.call(ctx, http.MethodPut, "/api/mcp-servers/"+url.PathEscape(req.Id), req.Definition)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := decodeStruct(resp.body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if resp.status == http.StatusAccepted {
+		return &gatewayv1.MCPServerChange{Revision: resource}, nil
+	}
+	return &gatewayv1.MCPServerChange{Server: resource}, nil
+}
+
+// DeleteMCPServer deletes an MCP server
+func (s *Server) DeleteMCPServer(ctx context.Context, req *gatewayv1.IDRequest) (*emptypb.Empty, error) {
+	if _, err := s.call(ctx, http.MethodDelete, "/api/mcp-servers/"+url.PathEscape(req.Id), nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ActivateMCPServer activates an MCP server
+func (s *Server) ActivateMCPServer(ctx context.Context, req *gatewayv1.IDRequest) (*gatewayv1.StatusResponse, error) {
+	return s.statusChange(ctx, "/api/mcp-servers/"+url.PathEscape(req.Id)+"/activate")
+}
+
+// DeactivateMCPServer deactivates an MCP server
+func (s *Server) DeactivateMCPServer(ctx context.Context, req *gatewayv1.IDRequest) (*gatewayv1.StatusResponse, error) {
+	return s.statusChange(ctx, "/api/mcp-servers/"+url.PathEscape(req.Id)+"/deactivate")
+}
+
+// InvokeTool invokes a tool of an MCP server
+func (s *Server) InvokeTool(ctx context.Context, req *gatewayv1.InvokeToolRequest) (*gatewayv1.InvokeToolResponse, error) {
+	resp, err := s.call(ctx, http.MethodPost, toolPath(req), params(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var result structpb.Value
+	if err := result.UnmarshalJSON(resp.body.Bytes()); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid tool result: %v", err)
+	}
+	return &gatewayv1.InvokeToolResponse{Result: &result, RequestId: resp.header.Get(logging.RequestIDHeader)}, nil
+}
+
+// StreamInvokeTool invokes a tool of an MCP server, streaming the result as it is written
+func (s *Server) StreamInvokeTool(req *gatewayv1.InvokeToolRequest, stream grpc.ServerStreamingServer[gatewayv1.InvokeToolEvent]) error {
+	// Choose the request ID now, so that the caller knows it before the result
+	ctx := stream.Context()
+	requestID := requestIDOf(ctx)
+	md := incomingMetadata(ctx).Copy()
+	md.Set(logging.RequestIDHeader, requestID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	start := time.Now()
+	if err := stream.Send(&gatewayv1.InvokeToolEvent{Event: &gatewayv1.InvokeToolEvent_Started_{
+		Started: &gatewayv1.InvokeToolEvent_Started{RequestId: requestID},
+	}}); err != nil {
+		return err
+	}
+
+	send := func(data []byte) error {
+		for len(data) > 0 {
+			n := min(len(data), chunkSize)
+			// The message is encoded before Send returns, so the buffer may be reused
+			if err := stream.Send(&gatewayv1.InvokeToolEvent{Event: &gatewayv1.InvokeToolEvent_Chunk{Chunk: data[:n]}}); err != nil {
+				return err
+			}
+			data = data[n:]
+		}
+		return nil
+	}
+	if _, err := s.serve(ctx, http.MethodPost, toolPath(req), params(req), send); err != nil {
+		return err
+	}
+
+	return stream.Send(&gatewayv1.InvokeToolEvent{Event: &gatewayv1.InvokeToolEvent_Completed_{
+		Completed: &gatewayv1.InvokeToolEvent_Completed{DurationMs: time.Since(start).Milliseconds()},
+	}})
+}
+
+// list returns the resources of a REST list endpoint
+func (s *Server) list(ctx context.Context, path string, req *gatewayv1.ListRequest) (*gatewayv1.ListResponse, error) {
+	if req.IncludeArchived {
+		path += "?includeArchived=true"
+	}
+	resp, err := s.call(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(resp.body.Bytes(), &items); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid list response: %v", err)
+	}
+	list := &gatewayv1.ListResponse{Items: make([]*structpb.Struct, 0, len(items))}
+	for _, item := range items {
+		resource, err := structpb.NewStruct(item)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid resource: %v", err)
+		}
+		list.Items = append(list.Items, resource)
+	}
+	return list, nil
+}
+
+// resource returns the resource of the response of a REST endpoint
+func (s *Server) resource(ctx context.Context, method string, path string, body *structpb.Struct) (*structpb.Struct, error) {
+	resp, err := s.call(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStruct(resp.body.Bytes())
+}
+
+// statusChange calls an activation endpoint and returns its message
+func (s *Server) statusChange(ctx context.Context, path string) (*gatewayv1.StatusResponse, error) {
+	resp, err := s.call(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var message struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.body.Bytes(), &message); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid response: %v", err)
+	}
+	return &gatewayv1.StatusResponse{Message: message.Message}, nil
+}
+
+// call serves a REST request and returns the buffered response
+func (s *Server) call(ctx context.Context, method string, path string, body interface{}) (*recorder, error) {
+	return s.serve(ctx, method, path, body, nil)
+}
+
+// serve serves a REST request with the metadata of the call as headers, returning the response
+// or the gRPC error of its status. Successful bodies are passed to send as they are written if
+// it is set, and buffered otherwise.
+func (s *Server) serve(ctx context.Context, method string, path string, body interface{}, send func([]byte) error) (*recorder, error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	md := incomingMetadata(ctx)
+	for _, name := range forwardedHeaders {
+		if values := md.Get(name); len(values) > 0 {
+			req.Header.Set(name, values[0])
+		}
+	}
+	// Rate limits and network restrictions apply to the address of the gRPC client
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	resp := &recorder{header: make(http.Header), send: send}
+	s.handler.ServeHTTP(resp, req)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+
+	// Pass on the request ID and the quota headers, in the trailer of streams whose header was sent
+	header := metadata.MD{}
+	for name, values := range resp.header {
+		if strings.HasPrefix(name, "X-") {
+			header.Append(name, values...)
+		}
+	}
+	setMetadata := grpc.SetHeader
+	if send != nil {
+		setMetadata = grpc.SetTrailer
+	}
+	if err := setMetadata(ctx, header); err != nil {
+		return nil, err
+	}
+
+	if resp.status >= http.StatusBadRequest {
+		return nil, errorStatus(resp)
+	}
+	return resp, nil
+}
+
+// errorStatus returns the gRPC error of a failed REST response
+func errorStatus(resp *recorder) error {
+	message := http.StatusText(resp.status)
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(resp.body.Bytes(), &body) == nil && body.Error != "" {
+		message = body.Error
+	}
+
+	code := codes.Internal
+	switch resp.status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		code = codes.Unavailable
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, message)
+}
+
+// decodeStruct decodes a JSON object
+func decodeStruct(data []byte) (*structpb.Struct, error) {
+	var resource structpb.Struct
+	if err := resource.UnmarshalJSON(data); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid resource: %v", err)
+	}
+	return &resource, nil
+}
+
+// toolPath returns the REST path invoking the tool of a request
+func toolPath(req *gatewayv1.InvokeToolRequest) string {
+	return "/api/mcp-servers/" + url.PathEscape(req.ServerId) + "/tools/" + url.PathEscape(req.Tool)
+}
+
+// params returns the params of a tool invocation, an empty object if there are none
+func params(req *gatewayv1.InvokeToolRequest) interface{} {
+	if req.Params == nil {
+		return map[string]interface{}{}
+	}
+	return req.Params
+}
+
+// incomingMetadata returns the metadata of the call, empty if there is none
+func incomingMetadata(ctx context.Context) metadata.MD {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return metadata.MD{}
+	}
+	return md
+}
+
+// requestIDOf returns the request ID the caller sent, or a new one
+func requestIDOf(ctx context.Context) string {
+	if values := incomingMetadata(ctx).Get(logging.RequestIDHeader); len(values) > 0 && values[0] != "" && len(values[0]) <= 128 {
+		return values[0]
+	}
+	return uuid.New().String()
+}
+
+// recorder is the response of a REST request served for a gRPC call
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	send   func([]byte) error // Receives the body of successful responses as it is written if set
+	err    error              // First error of send
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	if r.send == nil || r.status >= http.StatusBadRequest {
+		return r.body.Write(data)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	if err := r.send(data); err != nil {
+		r.err = err
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Flush implements http.Flusher, writes are never held back
+func (r *recorder) Flush() {}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryAlertWebhookRepository implements AlertWebhookRepository using an in-memory store
+type InMemoryAlertWebhookRepository struct {
+	mu        sync.RWMutex
+	webhooks  map[string]models.AlertWebhook
+	idCounter int
+}
+
+// NewInMemoryAlertWebhookRepository creates a new in-memory alert webhook repository
+func NewInMemoryAlertWebhookRepository() *InMemoryAlertWebhookRepository {
+	return &InMemoryAlertWebhookRepository{
+		webhooks:  make(map[string]models.AlertWebhook),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new alert webhook to the repository
+func (r *InMemoryAlertWebhookRepository) Create(ctx context.Context, webhook *models.AlertWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	webhook.ID = generateID("webhook", r.idCounter)
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+
+	r.webhooks[webhook.ID] = *webhook
+
+	return nil
+}
+
+// GetByID retrieves an alert webhook by ID
+func (r *InMemoryAlertWebhookRepository) GetByID(ctx context.Context, id string) (*models.AlertWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &webhook, nil
+}
+
+// GetAll retrieves all alert webhooks
+func (r *InMemoryAlertWebhookRepository) GetAll(ctx context.Context) ([]models.AlertWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]models.AlertWebhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+// Update updates an alert webhook
+func (r *InMemoryAlertWebhookRepository) Update(ctx context.Context, webhook *models.AlertWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.webhooks[webhook.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	webhook.CreatedAt = existing.CreatedAt
+	webhook.UpdatedAt = time.Now()
+
+	r.webhooks[webhook.ID] = *webhook
+
+	return nil
+}
+
+// Delete removes an alert webhook
+func (r *InMemoryAlertWebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.webhooks, id)
+
+	return nil
+}
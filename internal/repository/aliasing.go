@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// AliasingMCPServerRepository keeps the former name of a renamed MCP server as an alias for the
+// grace period, so that clients using it are redirected. A server created or renamed with the
+// name of an alias takes it over, and the aliases of a deleted server are dropped. It must be
+// wrapped by the namespaced repository, which assigns the namespace.
+type AliasingMCPServerRepository struct {
+	MCPServerRepository
+	aliases ServerAliasRepository
+	grace   func() time.Duration // Lifetime of new aliases, renames keep no alias if it is 0
+}
+
+// NewAliasingMCPServerRepository wraps an MCP server repository with rename aliases
+func NewAliasingMCPServerRepository(next MCPServerRepository, aliases ServerAliasRepository, grace func() time.Duration) *AliasingMCPServerRepository {
+	return &AliasingMCPServerRepository{MCPServerRepository: next, aliases: aliases, grace: grace}
+}
+
+func (r *AliasingMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	if err := r.MCPServerRepository.Create(ctx, mcpServer); err != nil {
+		return err
+	}
+	r.release(ctx, mcpServer)
+	return nil
+}
+
+func (r *AliasingMCPServerRepository) Update(ctx context.Context, mcpServer *models.MCPServer) error {
+	existing, err := r.MCPServerRepository.GetByID(ctx, mcpServer.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.MCPServerRepository.Update(ctx, mcpServer); err != nil {
+		return err
+	}
+
+	renamed := existing.Name != mcpServer.Name || namespace.OrDefault(existing.Namespace) != namespace.OrDefault(mcpServer.Namespace)
+	if !renamed {
+		return nil
+	}
+	r.release(ctx, mcpServer)
+	if grace := r.grace(); grace > 0 {
+		alias := &models.ServerAlias{
+			Namespace: namespace.OrDefault(existing.Namespace),
+			Name:      existing.Name,
+			ServerID:  mcpServer.ID,
+			ExpiresAt: time.Now().Add(grace),
+		}
+		if err := r.aliases.Set(ctx, alias); err != nil {
+			slog.WarnContext(ctx, "Failed to keep the former name of a renamed MCP server", "id", mcpServer.ID, "name", existing.Name, "error", err)
+		}
+	}
+	return nil
+}
+
+func (r *AliasingMCPServerRepository) Delete(ctx context.Context, id string) error {
+	if err := r.MCPServerRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := r.aliases.DeleteByServer(ctx, id); err != nil {
+		slog.WarnContext(ctx, "Failed to delete the aliases of an MCP server", "id", id, "error", err)
+	}
+	return nil
+}
+
+// release drops the alias of the current name of the server, now taken by it
+func (r *AliasingMCPServerRepository) release(ctx context.Context, mcpServer *models.MCPServer) {
+	err := r.aliases.Delete(ctx, mcpServer.Namespace, mcpServer.Name)
+	if err != nil && err != ErrNotFound {
+		slog.WarnContext(ctx, "Failed to release the alias of an MCP server name", "name", mcpServer.Name, "error", err)
+	}
+}
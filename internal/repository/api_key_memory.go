@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryAPIKeyRepository implements APIKeyRepository using an in-memory store
+type InMemoryAPIKeyRepository struct {
+	mu        sync.Mutex
+	keys      map[string]models.APIKey
+	usage     map[string]map[string]models.APIKeyPeriodUsage // Usage by key ID and period
+	idCounter int
+}
+
+// NewInMemoryAPIKeyRepository creates a new in-memory API key repository
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		keys:      make(map[string]models.APIKey),
+		usage:     make(map[string]map[string]models.APIKeyPeriodUsage),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new API key to the repository
+func (r *InMemoryAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	key.ID = generateID("apikey", r.idCounter)
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = time.Now()
+
+	stored := *key
+	stored.Key = ""
+	r.keys[key.ID] = stored
+
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *InMemoryAPIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &key, nil
+}
+
+// GetByHash retrieves an API key by the hash of the key
+func (r *InMemoryAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return &key, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// GetAll retrieves all API keys ordered by name
+func (r *InMemoryAPIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]models.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+
+	return keys, nil
+}
+
+// Update updates the name and the quotas of an API key
+func (r *InMemoryAPIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.keys[key.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	existing.Name = key.Name
+	existing.MaxCostPerDay = key.MaxCostPerDay
+	existing.MaxCostPerMonth = key.MaxCostPerMonth
+	existing.UpdatedAt = time.Now()
+	r.keys[key.ID] = existing
+	*key = existing
+
+	return nil
+}
+
+// Delete removes an API key and its usage
+func (r *InMemoryAPIKeyRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.keys, id)
+	delete(r.usage, id)
+
+	return nil
+}
+
+// CountCall counts a call of the key on day and in month unless it would exceed a limit
+func (r *InMemoryAPIKeyRepository) CountCall(ctx context.Context, id string, day string, month string, cost float64, dayLimit float64, monthLimit float64) (bool, models.APIKeyPeriodUsage, models.APIKeyPeriodUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage, ok := r.usage[id]
+	if !ok {
+		usage = make(map[string]models.APIKeyPeriodUsage)
+		r.usage[id] = usage
+	}
+	dayUsage, monthUsage := usage[day], usage[month]
+	dayUsage.Period, monthUsage.Period = day, month
+
+	if dayLimit > 0 && dayUsage.Cost+cost > dayLimit || monthLimit > 0 && monthUsage.Cost+cost > monthLimit {
+		return false, dayUsage, monthUsage, nil
+	}
+
+	dayUsage.Calls++
+	dayUsage.Cost += cost
+	monthUsage.Calls++
+	monthUsage.Cost += cost
+	usage[day], usage[month] = dayUsage, monthUsage
+
+	return true, dayUsage, monthUsage, nil
+}
+
+// Usage returns the usage of the key in the periods starting with prefix
+func (r *InMemoryAPIKeyRepository) Usage(ctx context.Context, id string, prefix string) ([]models.APIKeyPeriodUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage := []models.APIKeyPeriodUsage{}
+	for period, periodUsage := range r.usage[id] {
+		if strings.HasPrefix(period, prefix) {
+			usage = append(usage, periodUsage)
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Period < usage[j].Period
+	})
+
+	return usage, nil
+}
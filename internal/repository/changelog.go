@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+type changeNoteKey struct{}
+
+// WithChangeNote returns a copy of ctx carrying the note recorded on the interface and server
+// versions created within it
+func WithChangeNote(ctx context.Context, note *models.ChangeNote) context.Context {
+	return context.WithValue(ctx, changeNoteKey{}, note.Normalized())
+}
+
+// WithDefaultChangeNote returns a copy of ctx carrying note unless ctx already carries one, so
+// that the note given by a caller wins over the note of the operation it triggered
+func WithDefaultChangeNote(ctx context.Context, note *models.ChangeNote) context.Context {
+	if ChangeNoteFromContext(ctx) != nil {
+		return ctx
+	}
+	return WithChangeNote(ctx, note)
+}
+
+// ChangeNoteFromContext returns the change note of ctx, nil if it has none
+func ChangeNoteFromContext(ctx context.Context) *models.ChangeNote {
+	note, _ := ctx.Value(changeNoteKey{}).(*models.ChangeNote)
+	if note == nil {
+		return nil
+	}
+	copied := *note
+	return &copied
+}
+
+// sortVersions orders a version history from the oldest version
+func sortVersions(versions []models.VersionInfo) {
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryCollectionRepository implements CollectionRepository using an in-memory store
+type InMemoryCollectionRepository struct {
+	mu          sync.RWMutex
+	collections map[string]models.Collection
+	idCounter   int
+}
+
+// NewInMemoryCollectionRepository creates a new in-memory collection repository
+func NewInMemoryCollectionRepository() *InMemoryCollectionRepository {
+	return &InMemoryCollectionRepository{
+		collections: make(map[string]models.Collection),
+		idCounter:   0,
+	}
+}
+
+// Create adds a new collection to the repository
+func (r *InMemoryCollectionRepository) Create(ctx context.Context, collection *models.Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	collection.ID = generateID("collection", r.idCounter)
+	collection.CreatedAt = time.Now()
+	collection.UpdatedAt = time.Now()
+
+	r.collections[collection.ID] = cloneCollection(*collection)
+
+	return nil
+}
+
+// GetByID retrieves a collection by ID
+func (r *InMemoryCollectionRepository) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collection, ok := r.collections[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	clone := cloneCollection(collection)
+	return &clone, nil
+}
+
+// GetAll retrieves all collections ordered by name
+func (r *InMemoryCollectionRepository) GetAll(ctx context.Context) ([]models.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collections := make([]models.Collection, 0, len(r.collections))
+	for _, collection := range r.collections {
+		collections = append(collections, cloneCollection(collection))
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	return collections, nil
+}
+
+// Update updates a collection
+func (r *InMemoryCollectionRepository) Update(ctx context.Context, collection *models.Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.collections[collection.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	collection.CreatedAt = existing.CreatedAt
+	collection.UpdatedAt = time.Now()
+
+	r.collections[collection.ID] = cloneCollection(*collection)
+
+	return nil
+}
+
+// Delete removes a collection
+func (r *InMemoryCollectionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.collections[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.collections, id)
+
+	return nil
+}
+
+// cloneCollection copies the interface IDs so callers cannot modify the stored collection
+func cloneCollection(collection models.Collection) models.Collection {
+	collection.InterfaceIDs = append([]string{}, collection.InterfaceIDs...)
+	return collection
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryEnvironmentRepository implements EnvironmentRepository using an in-memory store
+type InMemoryEnvironmentRepository struct {
+	mu           sync.RWMutex
+	environments map[string]models.Environment
+	idCounter    int
+}
+
+// NewInMemoryEnvironmentRepository creates a new in-memory environment repository
+func NewInMemoryEnvironmentRepository() *InMemoryEnvironmentRepository {
+	return &InMemoryEnvironmentRepository{
+		environments: make(map[string]models.Environment),
+		idCounter:    0,
+	}
+}
+
+// Create adds a new environment to the repository
+func (r *InMemoryEnvironmentRepository) Create(ctx context.Context, environment *models.Environment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	environment.ID = generateID("env", r.idCounter)
+	environment.CreatedAt = time.Now()
+	environment.UpdatedAt = time.Now()
+
+	r.environments[environment.ID] = cloneEnvironment(*environment)
+
+	return nil
+}
+
+// GetByID retrieves an environment by ID
+func (r *InMemoryEnvironmentRepository) GetByID(ctx context.Context, id string) (*models.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	environment, ok := r.environments[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	clone := cloneEnvironment(environment)
+	return &clone, nil
+}
+
+// GetByName retrieves an environment by name
+func (r *InMemoryEnvironmentRepository) GetByName(ctx context.Context, name string) (*models.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, environment := range r.environments {
+		if environment.Name == name {
+			clone := cloneEnvironment(environment)
+			return &clone, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// GetAll retrieves all environments ordered by name
+func (r *InMemoryEnvironmentRepository) GetAll(ctx context.Context) ([]models.Environment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	environments := make([]models.Environment, 0, len(r.environments))
+	for _, environment := range r.environments {
+		environments = append(environments, cloneEnvironment(environment))
+	}
+
+	sort.Slice(environments, func(i, j int) bool {
+		return environments[i].Name < environments[j].Name
+	})
+
+	return environments, nil
+}
+
+// Update updates an environment
+func (r *InMemoryEnvironmentRepository) Update(ctx context.Context, environment *models.Environment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.environments[environment.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	environment.CreatedAt = existing.CreatedAt
+	environment.UpdatedAt = time.Now()
+
+	r.environments[environment.ID] = cloneEnvironment(*environment)
+
+	return nil
+}
+
+// Delete removes an environment
+func (r *InMemoryEnvironmentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.environments[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.environments, id)
+
+	return nil
+}
+
+// cloneEnvironment copies the variables so callers cannot modify the stored environment
+func cloneEnvironment(environment models.Environment) models.Environment {
+	variables := make(map[string]string, len(environment.Variables))
+	for k, v := range environment.Variables {
+		variables[k] = v
+	}
+	environment.Variables = variables
+	return environment
+}
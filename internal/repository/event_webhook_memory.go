@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryEventWebhookRepository implements EventWebhookRepository using an in-memory store
+type InMemoryEventWebhookRepository struct {
+	mu        sync.RWMutex
+	webhooks  map[string]models.EventWebhook
+	idCounter int
+}
+
+// NewInMemoryEventWebhookRepository creates a new in-memory event webhook repository
+func NewInMemoryEventWebhookRepository() *InMemoryEventWebhookRepository {
+	return &InMemoryEventWebhookRepository{
+		webhooks:  make(map[string]models.EventWebhook),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new event webhook to the repository
+func (r *InMemoryEventWebhookRepository) Create(ctx context.Context, webhook *models.EventWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	webhook.ID = generateID("event-webhook", r.idCounter)
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+
+	r.webhooks[webhook.ID] = *webhook
+
+	return nil
+}
+
+// GetByID retrieves an event webhook by ID
+func (r *InMemoryEventWebhookRepository) GetByID(ctx context.Context, id string) (*models.EventWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &webhook, nil
+}
+
+// GetAll retrieves all event webhooks
+func (r *InMemoryEventWebhookRepository) GetAll(ctx context.Context) ([]models.EventWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]models.EventWebhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+// Update updates an event webhook
+func (r *InMemoryEventWebhookRepository) Update(ctx context.Context, webhook *models.EventWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.webhooks[webhook.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	webhook.CreatedAt = existing.CreatedAt
+	webhook.UpdatedAt = time.Now()
+
+	r.webhooks[webhook.ID] = *webhook
+
+	return nil
+}
+
+// Delete removes an event webhook
+func (r *InMemoryEventWebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.webhooks, id)
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// EventPublisher delivers lifecycle events of gateway entities
+type EventPublisher interface {
+	Publish(ctx context.Context, eventType string, entityID string, entityName string, data interface{})
+}
+
+// EventingHTTPInterfaceRepository publishes a lifecycle event for every HTTP interface it changes
+type EventingHTTPInterfaceRepository struct {
+	HTTPInterfaceRepository
+	publisher EventPublisher
+}
+
+// NewEventingHTTPInterfaceRepository wraps an HTTP interface repository with lifecycle events
+func NewEventingHTTPInterfaceRepository(next HTTPInterfaceRepository, publisher EventPublisher) *EventingHTTPInterfaceRepository {
+	return &EventingHTTPInterfaceRepository{HTTPInterfaceRepository: next, publisher: publisher}
+}
+
+func (r *EventingHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	if err := r.HTTPInterfaceRepository.Create(ctx, httpInterface); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventHTTPInterfaceCreated, httpInterface.ID, httpInterface.Name, httpInterface)
+	return nil
+}
+
+func (r *EventingHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	if err := r.HTTPInterfaceRepository.Update(ctx, httpInterface); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventHTTPInterfaceUpdated, httpInterface.ID, httpInterface.Name, httpInterface)
+	return nil
+}
+
+func (r *EventingHTTPInterfaceRepository) Delete(ctx context.Context, id string) error {
+	// Look the interface up first so the event carries its name
+	var name string
+	if existing, err := r.HTTPInterfaceRepository.GetByID(ctx, id); err == nil {
+		name = existing.Name
+	}
+	if err := r.HTTPInterfaceRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventHTTPInterfaceDeleted, id, name, nil)
+	return nil
+}
+
+func (r *EventingHTTPInterfaceRepository) SetArchived(ctx context.Context, id string, archived bool) error {
+	if err := r.HTTPInterfaceRepository.SetArchived(ctx, id, archived); err != nil {
+		return err
+	}
+
+	eventType := models.EventHTTPInterfaceUnarchived
+	if archived {
+		eventType = models.EventHTTPInterfaceArchived
+	}
+
+	httpInterface, err := r.HTTPInterfaceRepository.GetByID(ctx, id)
+	if err != nil {
+		r.publisher.Publish(ctx, eventType, id, "", nil)
+		return nil
+	}
+	r.publisher.Publish(ctx, eventType, id, httpInterface.Name, httpInterface)
+	return nil
+}
+
+// EventingMCPServerRepository publishes a lifecycle event for every MCP server it changes
+type EventingMCPServerRepository struct {
+	MCPServerRepository
+	publisher EventPublisher
+}
+
+// NewEventingMCPServerRepository wraps an MCP server repository with lifecycle events
+func NewEventingMCPServerRepository(next MCPServerRepository, publisher EventPublisher) *EventingMCPServerRepository {
+	return &EventingMCPServerRepository{MCPServerRepository: next, publisher: publisher}
+}
+
+func (r *EventingMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	if err := r.MCPServerRepository.Create(ctx, mcpServer); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventMCPServerCreated, mcpServer.ID, mcpServer.Name, mcpServer)
+	return nil
+}
+
+func (r *EventingMCPServerRepository) Update(ctx context.Context, mcpServer *models.MCPServer) error {
+	if err := r.MCPServerRepository.Update(ctx, mcpServer); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventMCPServerUpdated, mcpServer.ID, mcpServer.Name, mcpServer)
+	return nil
+}
+
+func (r *EventingMCPServerRepository) Delete(ctx context.Context, id string) error {
+	// Look the server up first so the event carries its name
+	var name string
+	if existing, err := r.MCPServerRepository.GetByID(ctx, id); err == nil {
+		name = existing.Name
+	}
+	if err := r.MCPServerRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventMCPServerDeleted, id, name, nil)
+	return nil
+}
+
+func (r *EventingMCPServerRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	// Look the server up first to tell unarchiving from deactivating
+	var previous string
+	if existing, err := r.MCPServerRepository.GetByID(ctx, id); err == nil {
+		previous = existing.Status
+	}
+	if err := r.MCPServerRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+
+	eventType := models.EventMCPServerUpdated
+	switch {
+	case status == "active":
+		eventType = models.EventMCPServerActivated
+	case status == "archived":
+		eventType = models.EventMCPServerArchived
+	case previous == "archived":
+		eventType = models.EventMCPServerUnarchived
+	case status == "inactive":
+		eventType = models.EventMCPServerDeactivated
+	}
+
+	server, err := r.MCPServerRepository.GetByID(ctx, id)
+	if err != nil {
+		r.publisher.Publish(ctx, eventType, id, "", nil)
+		return nil
+	}
+	r.publisher.Publish(ctx, eventType, id, server.Name, server)
+	return nil
+}
+
+// EventingRevisionRepository publishes a lifecycle event for every revision submitted or reviewed
+type EventingRevisionRepository struct {
+	RevisionRepository
+	publisher EventPublisher
+}
+
+// NewEventingRevisionRepository wraps a revision repository with lifecycle events
+func NewEventingRevisionRepository(next RevisionRepository, publisher EventPublisher) *EventingRevisionRepository {
+	return &EventingRevisionRepository{RevisionRepository: next, publisher: publisher}
+}
+
+func (r *EventingRevisionRepository) Create(ctx context.Context, revision *models.Revision) error {
+	if err := r.RevisionRepository.Create(ctx, revision); err != nil {
+		return err
+	}
+	r.publisher.Publish(ctx, models.EventRevisionSubmitted, revision.ServerID, revision.ServerName, revision)
+	return nil
+}
+
+func (r *EventingRevisionRepository) Review(ctx context.Context, revision *models.Revision) error {
+	if err := r.RevisionRepository.Review(ctx, revision); err != nil {
+		return err
+	}
+	eventType := models.EventRevisionRejected
+	if revision.Status == models.RevisionApproved {
+		eventType = models.EventRevisionApproved
+	}
+	r.publisher.Publish(ctx, eventType, revision.ServerID, revision.ServerName, revision)
+	return nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrVersionConflict is returned when an update expected another version of the entity
+var ErrVersionConflict = errors.New("the entity was modified by another update")
+
+type expectedVersionKey struct{}
+
+type expectedVersionValue struct {
+	id      string
+	version int
+}
+
+// WithExpectedVersion returns a copy of ctx in which updates of the entity id only apply while it
+// is still at version, so that two updates based on the same version cannot both succeed. The
+// entities updated along with it, such as the servers synced with an interface, are not affected.
+func WithExpectedVersion(ctx context.Context, id string, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, expectedVersionValue{id: id, version: version})
+}
+
+// expectedVersion returns the version ctx expects the entity id at, 0 if any version
+func expectedVersion(ctx context.Context, id string) int {
+	expected, _ := ctx.Value(expectedVersionKey{}).(expectedVersionValue)
+	if expected.id != id {
+		return 0
+	}
+	return expected.version
+}
@@ -13,6 +13,7 @@ type InMemoryHTTPInterfaceRepository struct {
 	mu         sync.RWMutex
 	interfaces map[string]*models.HTTPInterface
 	versions   map[string]map[int]*models.HTTPInterface
+	names      map[string]string // Interface IDs by nameKey
 	idCounter  int
 }
 
@@ -21,6 +22,7 @@ func NewInMemoryHTTPInterfaceRepository() *InMemoryHTTPInterfaceRepository {
 	return &InMemoryHTTPInterfaceRepository{
 		interfaces: make(map[string]*models.HTTPInterface),
 		versions:   make(map[string]map[int]*models.HTTPInterface),
+		names:      make(map[string]string),
 		idCounter:  0,
 	}
 }
@@ -30,13 +32,24 @@ func (r *InMemoryHTTPInterfaceRepository) Create(ctx context.Context, httpInterf
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	key := nameKey(httpInterface.Namespace, httpInterface.Name)
+	if _, ok := r.names[key]; ok {
+		return NameTakenError("HTTP interface", httpInterface.Namespace, httpInterface.Name)
+	}
+	if limit := createLimit(ctx, "HTTP interface"); limit > 0 && countNames(r.names, httpInterface.Namespace) >= limit {
+		return ErrQuotaExceeded
+	}
+
 	r.idCounter++
 	httpInterface.ID = generateID("http", r.idCounter)
+	httpInterface.Archived = false
 	httpInterface.CreatedAt = time.Now()
 	httpInterface.UpdatedAt = time.Now()
 	httpInterface.Version = 1
+	httpInterface.Changelog = ChangeNoteFromContext(ctx)
 
 	r.interfaces[httpInterface.ID] = httpInterface
+	r.names[key] = httpInterface.ID
 
 	// Store version
 	if _, ok := r.versions[httpInterface.ID]; !ok {
@@ -73,6 +86,25 @@ func (r *InMemoryHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.
 	return interfaces, nil
 }
 
+// GetByIDs retrieves the HTTP interfaces of the IDs, skipping unknown ones
+func (r *InMemoryHTTPInterfaceRepository) GetByIDs(ctx context.Context, ids []string) ([]models.HTTPInterface, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	interfaces := make([]models.HTTPInterface, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		httpInterface, ok := r.interfaces[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		interfaces = append(interfaces, *cloneHTTPInterface(httpInterface))
+	}
+
+	return interfaces, nil
+}
+
 // Update updates an HTTP interface
 func (r *InMemoryHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *models.HTTPInterface) error {
 	r.mu.Lock()
@@ -82,13 +114,25 @@ func (r *InMemoryHTTPInterfaceRepository) Update(ctx context.Context, httpInterf
 	if !ok {
 		return ErrNotFound
 	}
+	if expected := expectedVersion(ctx, httpInterface.ID); expected != 0 && existing.Version != expected {
+		return ErrVersionConflict
+	}
+
+	key := nameKey(httpInterface.Namespace, httpInterface.Name)
+	if id, ok := r.names[key]; ok && id != httpInterface.ID {
+		return NameTakenError("HTTP interface", httpInterface.Namespace, httpInterface.Name)
+	}
 
 	// Increment version
 	httpInterface.Version = existing.Version + 1
+	httpInterface.Changelog = ChangeNoteFromContext(ctx)
 	httpInterface.UpdatedAt = time.Now()
 	httpInterface.CreatedAt = existing.CreatedAt
+	httpInterface.Archived = existing.Archived
 
+	delete(r.names, nameKey(existing.Namespace, existing.Name))
 	r.interfaces[httpInterface.ID] = httpInterface
+	r.names[key] = httpInterface.ID
 
 	// Store version
 	if _, ok := r.versions[httpInterface.ID]; !ok {
@@ -104,18 +148,36 @@ func (r *InMemoryHTTPInterfaceRepository) Delete(ctx context.Context, id string)
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.interfaces[id]; !ok {
+	httpInterface, ok := r.interfaces[id]
+	if !ok {
 		return ErrNotFound
 	}
 
+	delete(r.names, nameKey(httpInterface.Namespace, httpInterface.Name))
 	delete(r.interfaces, id)
 	delete(r.versions, id)
 
 	return nil
 }
 
-// GetVersions retrieves all version numbers for an HTTP interface
-func (r *InMemoryHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+// SetArchived archives or unarchives an HTTP interface
+func (r *InMemoryHTTPInterfaceRepository) SetArchived(ctx context.Context, id string, archived bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	httpInterface, ok := r.interfaces[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	httpInterface.Archived = archived
+	httpInterface.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetVersions retrieves the version history of an HTTP interface
+func (r *InMemoryHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -123,10 +185,11 @@ func (r *InMemoryHTTPInterfaceRepository) GetVersions(ctx context.Context, id st
 		return nil, ErrNotFound
 	}
 
-	versions := make([]int, 0, len(r.versions[id]))
-	for v := range r.versions[id] {
-		versions = append(versions, v)
+	versions := make([]models.VersionInfo, 0, len(r.versions[id]))
+	for v, stored := range r.versions[id] {
+		versions = append(versions, models.VersionInfo{Version: v, UpdatedAt: stored.UpdatedAt, Changelog: stored.Changelog})
 	}
+	sortVersions(versions)
 
 	return versions, nil
 }
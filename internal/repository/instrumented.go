@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/errorsink"
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// observe counts and reports a failed repository operation; not-found results are not failures
+func observe(ctx context.Context, repository string, operation string, err error) {
+	if err != nil && err != ErrNotFound {
+		metrics.RepositoryErrors.WithLabelValues(repository, operation).Inc()
+		errorsink.Capture(ctx, errorsink.KindRepository, err, "repository", repository, "operation", operation)
+	}
+}
+
+// InstrumentedHTTPInterfaceRepository records error metrics for an HTTPInterfaceRepository
+type InstrumentedHTTPInterfaceRepository struct {
+	next HTTPInterfaceRepository
+}
+
+// NewInstrumentedHTTPInterfaceRepository wraps an HTTP interface repository with metrics
+func NewInstrumentedHTTPInterfaceRepository(next HTTPInterfaceRepository) *InstrumentedHTTPInterfaceRepository {
+	return &InstrumentedHTTPInterfaceRepository{next: next}
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	err := r.next.Create(ctx, httpInterface)
+	observe(ctx, "http_interface", "create", err)
+	return err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) GetByID(ctx context.Context, id string) (*models.HTTPInterface, error) {
+	result, err := r.next.GetByID(ctx, id)
+	observe(ctx, "http_interface", "get_by_id", err)
+	return result, err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPInterface, error) {
+	result, err := r.next.GetAll(ctx)
+	observe(ctx, "http_interface", "get_all", err)
+	return result, err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) GetByIDs(ctx context.Context, ids []string) ([]models.HTTPInterface, error) {
+	result, err := r.next.GetByIDs(ctx, ids)
+	observe(ctx, "http_interface", "get_by_ids", err)
+	return result, err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	err := r.next.Update(ctx, httpInterface)
+	observe(ctx, "http_interface", "update", err)
+	return err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	observe(ctx, "http_interface", "delete", err)
+	return err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
+	result, err := r.next.GetVersions(ctx, id)
+	observe(ctx, "http_interface", "get_versions", err)
+	return result, err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) GetByVersion(ctx context.Context, id string, version int) (*models.HTTPInterface, error) {
+	result, err := r.next.GetByVersion(ctx, id, version)
+	observe(ctx, "http_interface", "get_by_version", err)
+	return result, err
+}
+
+func (r *InstrumentedHTTPInterfaceRepository) SetArchived(ctx context.Context, id string, archived bool) error {
+	err := r.next.SetArchived(ctx, id, archived)
+	observe(ctx, "http_interface", "set_archived", err)
+	return err
+}
+
+// InstrumentedMCPServerRepository records error metrics for an MCPServerRepository
+type InstrumentedMCPServerRepository struct {
+	next MCPServerRepository
+}
+
+// NewInstrumentedMCPServerRepository wraps an MCP server repository with metrics
+func NewInstrumentedMCPServerRepository(next MCPServerRepository) *InstrumentedMCPServerRepository {
+	return &InstrumentedMCPServerRepository{next: next}
+}
+
+func (r *InstrumentedMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	err := r.next.Create(ctx, mcpServer)
+	observe(ctx, "mcp_server", "create", err)
+	return err
+}
+
+func (r *InstrumentedMCPServerRepository) GetByID(ctx context.Context, id string) (*models.MCPServer, error) {
+	result, err := r.next.GetByID(ctx, id)
+	observe(ctx, "mcp_server", "get_by_id", err)
+	return result, err
+}
+
+func (r *InstrumentedMCPServerRepository) GetByName(ctx context.Context, name string) (*models.MCPServer, error) {
+	result, err := r.next.GetByName(ctx, name)
+	observe(ctx, "mcp_server", "get_by_name", err)
+	return result, err
+}
+
+func (r *InstrumentedMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer, error) {
+	result, err := r.next.GetAll(ctx)
+	observe(ctx, "mcp_server", "get_all", err)
+	return result, err
+}
+
+func (r *InstrumentedMCPServerRepository) Update(ctx context.Context, mcpServer *models.MCPServer) error {
+	err := r.next.Update(ctx, mcpServer)
+	observe(ctx, "mcp_server", "update", err)
+	return err
+}
+
+func (r *InstrumentedMCPServerRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	observe(ctx, "mcp_server", "delete", err)
+	return err
+}
+
+func (r *InstrumentedMCPServerRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
+	result, err := r.next.GetVersions(ctx, id)
+	observe(ctx, "mcp_server", "get_versions", err)
+	return result, err
+}
+
+func (r *InstrumentedMCPServerRepository) GetByVersion(ctx context.Context, id string, version int) (*models.MCPServer, error) {
+	result, err := r.next.GetByVersion(ctx, id, version)
+	observe(ctx, "mcp_server", "get_by_version", err)
+	return result, err
+}
+
+func (r *InstrumentedMCPServerRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	err := r.next.UpdateStatus(ctx, id, status)
+	observe(ctx, "mcp_server", "update_status", err)
+	return err
+}
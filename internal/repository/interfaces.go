@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
 )
@@ -11,25 +12,42 @@ type HTTPInterfaceRepository interface {
 	Create(ctx context.Context, httpInterface *models.HTTPInterface) error
 	GetByID(ctx context.Context, id string) (*models.HTTPInterface, error)
 	GetAll(ctx context.Context) ([]models.HTTPInterface, error)
+	// GetByIDs returns the interfaces of the IDs that exist, in no particular order
+	GetByIDs(ctx context.Context, ids []string) ([]models.HTTPInterface, error)
 	Update(ctx context.Context, httpInterface *models.HTTPInterface) error
 	Delete(ctx context.Context, id string) error
-	GetVersions(ctx context.Context, id string) ([]int, error)
+	// GetVersions returns the version history of an interface, from the oldest version
+	GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error)
 	GetByVersion(ctx context.Context, id string, version int) (*models.HTTPInterface, error)
+	// SetArchived archives or unarchives an interface without creating a version
+	SetArchived(ctx context.Context, id string, archived bool) error
 }
 
 // MCPServerRepository defines the interface for MCP Server operations
 type MCPServerRepository interface {
 	Create(ctx context.Context, mcpServer *models.MCPServer) error
 	GetByID(ctx context.Context, id string) (*models.MCPServer, error)
+	// GetByName returns the server of the name in the namespace of ctx, the default one if unscoped
 	GetByName(ctx context.Context, name string) (*models.MCPServer, error)
 	GetAll(ctx context.Context) ([]models.MCPServer, error)
 	Update(ctx context.Context, mcpServer *models.MCPServer) error
 	Delete(ctx context.Context, id string) error
-	GetVersions(ctx context.Context, id string) ([]int, error)
+	// GetVersions returns the version history of a server, from the oldest version
+	GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error)
 	GetByVersion(ctx context.Context, id string, version int) (*models.MCPServer, error)
 	UpdateStatus(ctx context.Context, id string, status string) error
 }
 
+// UpstreamRepository defines the interface for Upstream operations
+type UpstreamRepository interface {
+	Create(ctx context.Context, upstream *models.Upstream) error
+	GetByID(ctx context.Context, id string) (*models.Upstream, error)
+	GetByName(ctx context.Context, name string) (*models.Upstream, error)
+	GetAll(ctx context.Context) ([]models.Upstream, error)
+	Update(ctx context.Context, upstream *models.Upstream) error
+	Delete(ctx context.Context, id string) error
+}
+
 // RouterRepository defines the interface for Router operations
 type RouterRepository interface {
 	Create(ctx context.Context, router *models.Router) error
@@ -41,3 +59,171 @@ type RouterRepository interface {
 	GetByVersion(ctx context.Context, id string, version int) (*models.Router, error)
 	UpdateStatus(ctx context.Context, id string, status string) error
 }
+
+// InvocationFilter narrows down a query over recorded invocations
+type InvocationFilter struct {
+	ServerID string
+	Tool     string
+	Status   string // "success", "error" or empty for both
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	Offset   int
+}
+
+// Grouping of invocation statistics
+const (
+	StatsGroupByNone   = ""       // A single entry for all invocations
+	StatsGroupByServer = "server" // One entry per server
+	StatsGroupByTool   = "tool"   // One entry per server and tool
+)
+
+// InvocationRepository defines the interface for tool invocation history operations
+type InvocationRepository interface {
+	Create(ctx context.Context, invocation *models.Invocation) error
+	GetByID(ctx context.Context, id string) (*models.Invocation, error)
+	// List returns a page of matching invocations, newest first, and the total number of matches
+	List(ctx context.Context, filter InvocationFilter) ([]models.Invocation, int, error)
+	// Stats aggregates matching invocations grouped by StatsGroupBy*, ignoring limit and offset
+	Stats(ctx context.Context, filter InvocationFilter, groupBy string) ([]models.UsageStats, error)
+}
+
+// AlertWebhookRepository defines the interface for alert webhook operations
+type AlertWebhookRepository interface {
+	Create(ctx context.Context, webhook *models.AlertWebhook) error
+	GetByID(ctx context.Context, id string) (*models.AlertWebhook, error)
+	GetAll(ctx context.Context) ([]models.AlertWebhook, error)
+	Update(ctx context.Context, webhook *models.AlertWebhook) error
+	Delete(ctx context.Context, id string) error
+}
+
+// EventWebhookRepository defines the interface for event webhook operations
+type EventWebhookRepository interface {
+	Create(ctx context.Context, webhook *models.EventWebhook) error
+	GetByID(ctx context.Context, id string) (*models.EventWebhook, error)
+	GetAll(ctx context.Context) ([]models.EventWebhook, error)
+	Update(ctx context.Context, webhook *models.EventWebhook) error
+	Delete(ctx context.Context, id string) error
+}
+
+// EnvironmentRepository defines the interface for environment operations
+type EnvironmentRepository interface {
+	Create(ctx context.Context, environment *models.Environment) error
+	GetByID(ctx context.Context, id string) (*models.Environment, error)
+	GetByName(ctx context.Context, name string) (*models.Environment, error)
+	GetAll(ctx context.Context) ([]models.Environment, error)
+	Update(ctx context.Context, environment *models.Environment) error
+	Delete(ctx context.Context, id string) error
+}
+
+// CollectionRepository defines the interface for HTTP interface collection operations
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *models.Collection) error
+	GetByID(ctx context.Context, id string) (*models.Collection, error)
+	GetAll(ctx context.Context) ([]models.Collection, error)
+	Update(ctx context.Context, collection *models.Collection) error
+	Delete(ctx context.Context, id string) error
+}
+
+// SecretRepository defines the interface for secret operations
+type SecretRepository interface {
+	Create(ctx context.Context, secret *models.Secret) error
+	GetByID(ctx context.Context, id string) (*models.Secret, error)
+	GetByName(ctx context.Context, name string) (*models.Secret, error)
+	GetAll(ctx context.Context) ([]models.Secret, error)
+	Update(ctx context.Context, secret *models.Secret) error
+	Delete(ctx context.Context, id string) error
+}
+
+// NamespacePolicyRepository defines the interface for the call policies of namespaces
+type NamespacePolicyRepository interface {
+	// Get returns the policy of a namespace, ErrNotFound if it has none
+	Get(ctx context.Context, namespace string) (*models.NamespacePolicy, error)
+	GetAll(ctx context.Context) ([]models.NamespacePolicy, error)
+	// Set creates or replaces the policy of its namespace
+	Set(ctx context.Context, policy *models.NamespacePolicy) error
+	Delete(ctx context.Context, namespace string) error
+}
+
+// QuotaRepository defines the interface for tenant quota and usage counter operations
+type QuotaRepository interface {
+	// Get returns the quota of a tenant, ErrNotFound if it has none
+	Get(ctx context.Context, tenant string) (*models.Quota, error)
+	GetAll(ctx context.Context) ([]models.Quota, error)
+	// Set creates or replaces the quota of its tenant
+	Set(ctx context.Context, quota *models.Quota) error
+	Delete(ctx context.Context, tenant string) error
+	// CountToolCall counts a tool call of the tenant on day (YYYY-MM-DD) unless limit calls were
+	// already counted, a zero limit being unlimited. It returns whether the call was counted.
+	CountToolCall(ctx context.Context, tenant string, day string, limit int) (bool, error)
+	// ToolCalls returns the number of tool calls counted for the tenant on day
+	ToolCalls(ctx context.Context, tenant string, day string) (int, error)
+}
+
+// APIKeyRepository defines the interface for API key and usage counter operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByID(ctx context.Context, id string) (*models.APIKey, error)
+	// GetByHash returns the key with a SHA-256 hash, ErrNotFound if there is none
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	Update(ctx context.Context, key *models.APIKey) error
+	// Delete removes a key and its usage
+	Delete(ctx context.Context, id string) error
+	// CountCall adds a call of cost to the usage of the key on day (YYYY-MM-DD) and in month
+	// (YYYY-MM) unless it would exceed dayLimit or monthLimit, zero limits being unlimited. It
+	// returns whether the call was counted and the usage of the day and of the month after it.
+	CountCall(ctx context.Context, id string, day string, month string, cost float64, dayLimit float64, monthLimit float64) (bool, models.APIKeyPeriodUsage, models.APIKeyPeriodUsage, error)
+	// Usage returns the usage of the key in the days and months starting with prefix, by period
+	Usage(ctx context.Context, id string, prefix string) ([]models.APIKeyPeriodUsage, error)
+}
+
+// WasmFileRepository defines the interface for WASM file metadata operations
+type WasmFileRepository interface {
+	// Create stores new metadata, assigning the next version for the file's name and owner server
+	Create(ctx context.Context, wasmFile *models.WasmFile) error
+	GetByID(ctx context.Context, id string) (*models.WasmFile, error)
+	// GetAll returns all WASM files, or those of one server if serverID is not empty
+	GetAll(ctx context.Context, serverID string) ([]models.WasmFile, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// RevisionRepository defines the interface for MCP server revision operations
+type RevisionRepository interface {
+	Create(ctx context.Context, revision *models.Revision) error
+	GetByID(ctx context.Context, id string) (*models.Revision, error)
+	// List returns the revisions of a server, or of all servers if serverID is empty, with a
+	// status if it is not empty, newest first
+	List(ctx context.Context, serverID string, status string) ([]models.Revision, error)
+	// Review records the approval or rejection of a pending revision, ErrRevisionReviewed if it
+	// was already reviewed
+	Review(ctx context.Context, revision *models.Revision) error
+}
+
+// MCPSessionRepository defines the interface for MCP transport session operations
+type MCPSessionRepository interface {
+	// Create stores a session with the ID it was issued
+	Create(ctx context.Context, session *models.MCPSession) error
+	GetByID(ctx context.Context, id string) (*models.MCPSession, error)
+	// GetAll returns the sessions, or those of one server if serverID is not empty, newest first
+	GetAll(ctx context.Context, serverID string) ([]models.MCPSession, error)
+	Update(ctx context.Context, session *models.MCPSession) error
+	Delete(ctx context.Context, id string) error
+	// DeleteExpired removes the sessions expired at now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// ServerAliasRepository defines the interface for the former names of renamed MCP servers
+type ServerAliasRepository interface {
+	// Set creates the alias of its namespace and name or replaces it
+	Set(ctx context.Context, alias *models.ServerAlias) error
+	// Get returns the alias of a name in a namespace, ErrNotFound if there is none
+	Get(ctx context.Context, namespace string, name string) (*models.ServerAlias, error)
+	// GetByServer returns the aliases of a server, newest first
+	GetByServer(ctx context.Context, serverID string) ([]models.ServerAlias, error)
+	Delete(ctx context.Context, namespace string, name string) error
+	// DeleteByServer removes the aliases of a server
+	DeleteByServer(ctx context.Context, serverID string) error
+	// DeleteExpired removes the aliases expired at now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// maxInMemoryInvocations bounds the history kept by the in-memory repository
+const maxInMemoryInvocations = 10000
+
+// InMemoryInvocationRepository implements InvocationRepository using an in-memory store
+type InMemoryInvocationRepository struct {
+	mu          sync.RWMutex
+	invocations []models.Invocation // Oldest first
+	idCounter   int
+}
+
+// NewInMemoryInvocationRepository creates a new in-memory invocation repository
+func NewInMemoryInvocationRepository() *InMemoryInvocationRepository {
+	return &InMemoryInvocationRepository{
+		idCounter: 0,
+	}
+}
+
+// Create records a new invocation, dropping the oldest one once the history is full
+func (r *InMemoryInvocationRepository) Create(ctx context.Context, invocation *models.Invocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	invocation.ID = generateID("invocation", r.idCounter)
+	if invocation.CreatedAt.IsZero() {
+		invocation.CreatedAt = time.Now()
+	}
+
+	r.invocations = append(r.invocations, *invocation)
+	if len(r.invocations) > maxInMemoryInvocations {
+		r.invocations = r.invocations[len(r.invocations)-maxInMemoryInvocations:]
+	}
+
+	return nil
+}
+
+// GetByID returns an invocation still kept in the history
+func (r *InMemoryInvocationRepository) GetByID(ctx context.Context, id string) (*models.Invocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.invocations) - 1; i >= 0; i-- {
+		if r.invocations[i].ID == id {
+			invocation := r.invocations[i]
+			return &invocation, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// List returns a page of matching invocations, newest first
+func (r *InMemoryInvocationRepository) List(ctx context.Context, filter InvocationFilter) ([]models.Invocation, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []models.Invocation
+	for i := len(r.invocations) - 1; i >= 0; i-- {
+		if matchInvocation(&r.invocations[i], filter) {
+			matches = append(matches, r.invocations[i])
+		}
+	}
+
+	total := len(matches)
+	if filter.Offset >= total {
+		return []models.Invocation{}, total, nil
+	}
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+
+	return matches, total, nil
+}
+
+// matchInvocation checks whether an invocation satisfies the filter
+func matchInvocation(invocation *models.Invocation, filter InvocationFilter) bool {
+	if filter.ServerID != "" && invocation.ServerID != filter.ServerID {
+		return false
+	}
+	if filter.Tool != "" && invocation.Tool != filter.Tool {
+		return false
+	}
+	if filter.Status == "success" && !invocation.Success {
+		return false
+	}
+	if filter.Status == "error" && invocation.Success {
+		return false
+	}
+	if !filter.Since.IsZero() && invocation.CreatedAt.Before(filter.Since) {
+		return false
+	}
+	if !filter.Until.IsZero() && !invocation.CreatedAt.Before(filter.Until) {
+		return false
+	}
+	return true
+}
+
+// Stats aggregates matching invocations
+func (r *InMemoryInvocationRepository) Stats(ctx context.Context, filter InvocationFilter, groupBy string) ([]models.UsageStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type group struct {
+		stats     models.UsageStats
+		durations []int64
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for i := range r.invocations {
+		invocation := &r.invocations[i]
+		if !matchInvocation(invocation, filter) {
+			continue
+		}
+
+		var key string
+		stats := models.UsageStats{}
+		switch groupBy {
+		case StatsGroupByServer:
+			key = invocation.ServerID
+			stats.ServerID, stats.ServerName = invocation.ServerID, invocation.ServerName
+		case StatsGroupByTool:
+			key = invocation.ServerID + "/" + invocation.Tool
+			stats.ServerID, stats.ServerName, stats.Tool = invocation.ServerID, invocation.ServerName, invocation.Tool
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{stats: stats}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.stats.Calls++
+		if !invocation.Success {
+			g.stats.Errors++
+		}
+		g.durations = append(g.durations, invocation.DurationMs)
+	}
+
+	result := make([]models.UsageStats, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		sort.Slice(g.durations, func(i, j int) bool { return g.durations[i] < g.durations[j] })
+		g.stats.ErrorRate = float64(g.stats.Errors) / float64(g.stats.Calls)
+		g.stats.LatencyP50Ms = percentile(g.durations, 0.5)
+		g.stats.LatencyP90Ms = percentile(g.durations, 0.9)
+		g.stats.LatencyP99Ms = percentile(g.durations, 0.99)
+		result = append(result, g.stats)
+	}
+
+	// Most used first, like the PostgreSQL implementation
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Calls > result[j].Calls })
+
+	if groupBy == StatsGroupByNone && len(result) == 0 {
+		result = append(result, models.UsageStats{})
+	}
+
+	return result, nil
+}
+
+// percentile interpolates the p-th percentile of sorted values, as PostgreSQL's percentile_cont does
+func percentile(sorted []int64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower+1 >= len(sorted) {
+		return float64(sorted[lower])
+	}
+	fraction := rank - float64(lower)
+	return float64(sorted[lower]) + fraction*float64(sorted[lower+1]-sorted[lower])
+}
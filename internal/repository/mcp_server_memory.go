@@ -3,21 +3,32 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
 )
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrNameTaken is returned when another entity of the namespace has the name
+	ErrNameTaken = errors.New("name already taken")
 )
 
+// NameTakenError reports that an entity of the kind already has the name in the namespace
+func NameTakenError(kind string, owner string, name string) error {
+	return fmt.Errorf("%w: %s '%s' already exists in namespace %s", ErrNameTaken, kind, name, namespace.OrDefault(owner))
+}
+
 // InMemoryMCPServerRepository implements MCPServerRepository using an in-memory store
 type InMemoryMCPServerRepository struct {
 	mu        sync.RWMutex
 	servers   map[string]*models.MCPServer
 	versions  map[string]map[int]*models.MCPServer
+	names     map[string]string // Server IDs by nameKey
 	idCounter int
 }
 
@@ -26,6 +37,7 @@ func NewInMemoryMCPServerRepository() *InMemoryMCPServerRepository {
 	return &InMemoryMCPServerRepository{
 		servers:   make(map[string]*models.MCPServer),
 		versions:  make(map[string]map[int]*models.MCPServer),
+		names:     make(map[string]string),
 		idCounter: 0,
 	}
 }
@@ -35,13 +47,23 @@ func (r *InMemoryMCPServerRepository) Create(ctx context.Context, server *models
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	key := nameKey(server.Namespace, server.Name)
+	if _, ok := r.names[key]; ok {
+		return NameTakenError("MCP server", server.Namespace, server.Name)
+	}
+	if limit := createLimit(ctx, "MCP server"); limit > 0 && countNames(r.names, server.Namespace) >= limit {
+		return ErrQuotaExceeded
+	}
+
 	r.idCounter++
 	server.ID = generateID("mcp", r.idCounter)
 	server.CreatedAt = time.Now()
 	server.UpdatedAt = time.Now()
 	server.Version = 1
+	server.Changelog = ChangeNoteFromContext(ctx)
 
 	r.servers[server.ID] = server
+	r.names[key] = server.ID
 
 	// Store version
 	if _, ok := r.versions[server.ID]; !ok {
@@ -87,13 +109,24 @@ func (r *InMemoryMCPServerRepository) Update(ctx context.Context, server *models
 	if !ok {
 		return ErrNotFound
 	}
+	if expected := expectedVersion(ctx, server.ID); expected != 0 && existing.Version != expected {
+		return ErrVersionConflict
+	}
+
+	key := nameKey(server.Namespace, server.Name)
+	if id, ok := r.names[key]; ok && id != server.ID {
+		return NameTakenError("MCP server", server.Namespace, server.Name)
+	}
 
 	// Increment version
 	server.Version = existing.Version + 1
+	server.Changelog = ChangeNoteFromContext(ctx)
 	server.UpdatedAt = time.Now()
 	server.CreatedAt = existing.CreatedAt
 
+	delete(r.names, nameKey(existing.Namespace, existing.Name))
 	r.servers[server.ID] = server
+	r.names[key] = server.ID
 
 	// Store version
 	if _, ok := r.versions[server.ID]; !ok {
@@ -109,18 +142,20 @@ func (r *InMemoryMCPServerRepository) Delete(ctx context.Context, id string) err
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.servers[id]; !ok {
+	server, ok := r.servers[id]
+	if !ok {
 		return ErrNotFound
 	}
 
+	delete(r.names, nameKey(server.Namespace, server.Name))
 	delete(r.servers, id)
 	delete(r.versions, id)
 
 	return nil
 }
 
-// GetVersions retrieves all version numbers for an MCP server
-func (r *InMemoryMCPServerRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+// GetVersions retrieves the version history of an MCP server
+func (r *InMemoryMCPServerRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -128,10 +163,11 @@ func (r *InMemoryMCPServerRepository) GetVersions(ctx context.Context, id string
 		return nil, ErrNotFound
 	}
 
-	versions := make([]int, 0, len(r.versions[id]))
-	for v := range r.versions[id] {
-		versions = append(versions, v)
+	versions := make([]models.VersionInfo, 0, len(r.versions[id]))
+	for v, stored := range r.versions[id] {
+		versions = append(versions, models.VersionInfo{Version: v, UpdatedAt: stored.UpdatedAt, Changelog: stored.Changelog})
 	}
+	sortVersions(versions)
 
 	return versions, nil
 }
@@ -169,18 +205,17 @@ func (r *InMemoryMCPServerRepository) UpdateStatus(ctx context.Context, id strin
 	return nil
 }
 
-// GetByName retrieves an MCP server by name
+// GetByName retrieves an MCP server by name in the namespace of the context
 func (r *InMemoryMCPServerRepository) GetByName(ctx context.Context, name string) (*models.MCPServer, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	for _, server := range r.servers {
-		if server.Name == name {
-			return cloneMCPServer(server), nil
-		}
+	id, ok := r.names[nameKey(lookupNamespace(ctx), name)]
+	if !ok {
+		return nil, ErrNotFound
 	}
 
-	return nil, ErrNotFound
+	return cloneMCPServer(r.servers[id]), nil
 }
 
 // Helper function to clone an MCP server
@@ -188,10 +223,54 @@ func cloneMCPServer(server *models.MCPServer) *models.MCPServer {
 	clone := *server
 	clone.AllowTools = make([]string, len(server.AllowTools))
 	copy(clone.AllowTools, server.AllowTools)
+	clone.Plugins = append([]string(nil), server.Plugins...)
+	clone.External = append([]models.ExternalServer(nil), server.External...)
+	clone.Sources = append([]models.ServerSource(nil), server.Sources...)
+	clone.Redactions = append([]models.RedactionRule(nil), server.Redactions...)
+	if server.Schedule != nil {
+		schedule := *server.Schedule
+		clone.Schedule = &schedule
+	}
+	if server.Headers != nil {
+		headers := *server.Headers
+		headers.Defaults = maps.Clone(server.Headers.Defaults)
+		headers.Allow = append([]string(nil), server.Headers.Allow...)
+		clone.Headers = &headers
+	}
+	clone.AuthPassthrough = cloneAuthPassthrough(server.AuthPassthrough)
+	if server.Network != nil {
+		clone.Network = &models.NetworkRestriction{Allow: append([]string(nil), server.Network.Allow...)}
+	}
+	clone.Policy = cloneCallPolicy(server.Policy)
 
 	clone.Tools = make([]models.Tool, len(server.Tools))
 	for i, tool := range server.Tools {
 		cloneTool := tool
+		cloneTool.Plugins = append([]string(nil), tool.Plugins...)
+		cloneTool.ParamStyles = maps.Clone(tool.ParamStyles)
+		cloneTool.ErrorMappings = append([]models.ErrorMapping(nil), tool.ErrorMappings...)
+		if tool.Hedging != nil {
+			hedging := *tool.Hedging
+			cloneTool.Hedging = &hedging
+		}
+		if tool.LatencyBudget != nil {
+			budget := *tool.LatencyBudget
+			cloneTool.LatencyBudget = &budget
+		}
+		if tool.Enabled != nil {
+			enabled := *tool.Enabled
+			cloneTool.Enabled = &enabled
+		}
+		if tool.Shadow != nil {
+			shadow := *tool.Shadow
+			cloneTool.Shadow = &shadow
+		}
+		cloneTool.AuthPassthrough = cloneAuthPassthrough(tool.AuthPassthrough)
+		cloneTool.Policy = cloneCallPolicy(tool.Policy)
+		if tool.WebSocket != nil {
+			exchange := *tool.WebSocket
+			cloneTool.WebSocket = &exchange
+		}
 		if tool.RequestTemplate.Headers != nil {
 			cloneTool.RequestTemplate.Headers = make(map[string]string)
 			for k, v := range tool.RequestTemplate.Headers {
@@ -204,6 +283,37 @@ func cloneMCPServer(server *models.MCPServer) *models.MCPServer {
 	return &clone
 }
 
+// cloneAuthPassthrough copies a passthrough policy with its credential
+func cloneAuthPassthrough(policy *models.AuthPassthrough) *models.AuthPassthrough {
+	if policy == nil {
+		return nil
+	}
+	clone := *policy
+	if policy.Credential != nil {
+		credential := *policy.Credential
+		credential.Scopes = append([]string(nil), policy.Credential.Scopes...)
+		clone.Credential = &credential
+	}
+	return &clone
+}
+
+func cloneCallPolicy(policy *models.CallPolicy) *models.CallPolicy {
+	if policy == nil {
+		return nil
+	}
+	clone := *policy
+	if policy.Retry != nil {
+		retry := *policy.Retry
+		clone.Retry = &retry
+	}
+	if policy.RateLimit != nil {
+		rateLimit := *policy.RateLimit
+		clone.RateLimit = &rateLimit
+	}
+	clone.AllowedHosts = append([]string(nil), policy.AllowedHosts...)
+	return &clone
+}
+
 // Helper function to generate ID
 func generateID(prefix string, counter int) string {
 	return prefix + "-" + time.Now().Format("20060102") + "-" + intToString(counter)
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// NamespacePolicyLookup serves the call policies of a repository to the MCP service
+type NamespacePolicyLookup struct {
+	repo NamespacePolicyRepository
+}
+
+// NewNamespacePolicyLookup creates a lookup of the policies of a repository
+func NewNamespacePolicyLookup(repo NamespacePolicyRepository) *NamespacePolicyLookup {
+	return &NamespacePolicyLookup{repo: repo}
+}
+
+// Policy returns the call policy of a namespace, nil if it has none
+func (l *NamespacePolicyLookup) Policy(ctx context.Context, namespace string) (*models.CallPolicy, error) {
+	policy, err := l.repo.Get(ctx, namespace)
+	if err == ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &policy.Policy, nil
+}
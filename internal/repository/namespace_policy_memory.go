@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryNamespacePolicyRepository implements NamespacePolicyRepository using an in-memory store
+type InMemoryNamespacePolicyRepository struct {
+	mu       sync.Mutex
+	policies map[string]models.NamespacePolicy
+}
+
+// NewInMemoryNamespacePolicyRepository creates a new in-memory namespace policy repository
+func NewInMemoryNamespacePolicyRepository() *InMemoryNamespacePolicyRepository {
+	return &InMemoryNamespacePolicyRepository{
+		policies: make(map[string]models.NamespacePolicy),
+	}
+}
+
+// Get retrieves the policy of a namespace
+func (r *InMemoryNamespacePolicyRepository) Get(ctx context.Context, namespace string) (*models.NamespacePolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policy, ok := r.policies[namespace]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	policy.Policy = *cloneCallPolicy(&policy.Policy)
+	return &policy, nil
+}
+
+// GetAll retrieves all policies ordered by namespace
+func (r *InMemoryNamespacePolicyRepository) GetAll(ctx context.Context) ([]models.NamespacePolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policies := make([]models.NamespacePolicy, 0, len(r.policies))
+	for _, policy := range r.policies {
+		policy.Policy = *cloneCallPolicy(&policy.Policy)
+		policies = append(policies, policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Namespace < policies[j].Namespace
+	})
+
+	return policies, nil
+}
+
+// Set creates or replaces the policy of a namespace
+func (r *InMemoryNamespacePolicyRepository) Set(ctx context.Context, policy *models.NamespacePolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	stored := *policy
+	stored.Policy = *cloneCallPolicy(&policy.Policy)
+	r.policies[policy.Namespace] = stored
+
+	return nil
+}
+
+// Delete removes the policy of a namespace
+func (r *InMemoryNamespacePolicyRepository) Delete(ctx context.Context, namespace string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.policies[namespace]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.policies, namespace)
+
+	return nil
+}
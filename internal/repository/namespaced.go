@@ -0,0 +1,496 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// visible reports whether an entity of the namespace owner can be seen within ctx
+func visible(ctx context.Context, owner string) bool {
+	name, scoped := namespace.FromContext(ctx)
+	return !scoped || namespace.OrDefault(owner) == name
+}
+
+// assign returns the namespace of an entity created or updated within ctx.
+// Unscoped callers keep the namespace they set, falling back to the default one.
+func assign(ctx context.Context, requested string) string {
+	if name, scoped := namespace.FromContext(ctx); scoped {
+		return name
+	}
+	return namespace.OrDefault(requested)
+}
+
+// lookupNamespace returns the namespace searched by name lookups: the namespace of ctx,
+// or the default namespace for unscoped callers
+func lookupNamespace(ctx context.Context) string {
+	name, _ := namespace.FromContext(ctx)
+	return namespace.OrDefault(name)
+}
+
+// nameKey returns the key of a name in a namespace. Names are unique within a namespace.
+func nameKey(owner string, name string) string {
+	return namespace.OrDefault(owner) + "/" + name
+}
+
+// countNames returns the number of names of a namespace among the keys of names
+func countNames(names map[string]string, owner string) int {
+	prefix := nameKey(owner, "")
+	count := 0
+	for key := range names {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// NamespacedHTTPInterfaceRepository limits an HTTPInterfaceRepository to the namespace of the context.
+// Interfaces of other namespaces are reported as not found.
+type NamespacedHTTPInterfaceRepository struct {
+	next HTTPInterfaceRepository
+}
+
+// NewNamespacedHTTPInterfaceRepository wraps an HTTP interface repository with namespace scoping
+func NewNamespacedHTTPInterfaceRepository(next HTTPInterfaceRepository) *NamespacedHTTPInterfaceRepository {
+	return &NamespacedHTTPInterfaceRepository{next: next}
+}
+
+func (r *NamespacedHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	httpInterface.Namespace = assign(ctx, httpInterface.Namespace)
+	return r.next.Create(ctx, httpInterface)
+}
+
+func (r *NamespacedHTTPInterfaceRepository) GetByID(ctx context.Context, id string) (*models.HTTPInterface, error) {
+	httpInterface, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, httpInterface.Namespace) {
+		return nil, ErrNotFound
+	}
+	return httpInterface, nil
+}
+
+func (r *NamespacedHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPInterface, error) {
+	interfaces, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.HTTPInterface, 0, len(interfaces))
+	for _, httpInterface := range interfaces {
+		if visible(ctx, httpInterface.Namespace) {
+			result = append(result, httpInterface)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedHTTPInterfaceRepository) GetByIDs(ctx context.Context, ids []string) ([]models.HTTPInterface, error) {
+	interfaces, err := r.next.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.HTTPInterface, 0, len(interfaces))
+	for _, httpInterface := range interfaces {
+		if visible(ctx, httpInterface.Namespace) {
+			result = append(result, httpInterface)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	existing, err := r.GetByID(ctx, httpInterface.ID)
+	if err != nil {
+		return err
+	}
+	httpInterface.Namespace = assign(ctx, namespaceOr(httpInterface.Namespace, existing.Namespace))
+	return r.next.Update(ctx, httpInterface)
+}
+
+func (r *NamespacedHTTPInterfaceRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+func (r *NamespacedHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.next.GetVersions(ctx, id)
+}
+
+func (r *NamespacedHTTPInterfaceRepository) GetByVersion(ctx context.Context, id string, version int) (*models.HTTPInterface, error) {
+	httpInterface, err := r.next.GetByVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, httpInterface.Namespace) {
+		return nil, ErrNotFound
+	}
+	return httpInterface, nil
+}
+
+func (r *NamespacedHTTPInterfaceRepository) SetArchived(ctx context.Context, id string, archived bool) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.SetArchived(ctx, id, archived)
+}
+
+// NamespacedMCPServerRepository limits an MCPServerRepository to the namespace of the context.
+// Servers of other namespaces are reported as not found.
+type NamespacedMCPServerRepository struct {
+	next MCPServerRepository
+}
+
+// NewNamespacedMCPServerRepository wraps an MCP server repository with namespace scoping
+func NewNamespacedMCPServerRepository(next MCPServerRepository) *NamespacedMCPServerRepository {
+	return &NamespacedMCPServerRepository{next: next}
+}
+
+func (r *NamespacedMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	mcpServer.Namespace = assign(ctx, mcpServer.Namespace)
+	return r.next.Create(ctx, mcpServer)
+}
+
+func (r *NamespacedMCPServerRepository) GetByID(ctx context.Context, id string) (*models.MCPServer, error) {
+	mcpServer, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, mcpServer.Namespace) {
+		return nil, ErrNotFound
+	}
+	return mcpServer, nil
+}
+
+// GetByName returns the server of the name in the namespace of the context
+func (r *NamespacedMCPServerRepository) GetByName(ctx context.Context, name string) (*models.MCPServer, error) {
+	mcpServer, err := r.next.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, mcpServer.Namespace) {
+		return nil, ErrNotFound
+	}
+	return mcpServer, nil
+}
+
+func (r *NamespacedMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer, error) {
+	servers, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.MCPServer, 0, len(servers))
+	for _, mcpServer := range servers {
+		if visible(ctx, mcpServer.Namespace) {
+			result = append(result, mcpServer)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedMCPServerRepository) Update(ctx context.Context, mcpServer *models.MCPServer) error {
+	existing, err := r.GetByID(ctx, mcpServer.ID)
+	if err != nil {
+		return err
+	}
+	mcpServer.Namespace = assign(ctx, namespaceOr(mcpServer.Namespace, existing.Namespace))
+	return r.next.Update(ctx, mcpServer)
+}
+
+func (r *NamespacedMCPServerRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+func (r *NamespacedMCPServerRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.next.GetVersions(ctx, id)
+}
+
+func (r *NamespacedMCPServerRepository) GetByVersion(ctx context.Context, id string, version int) (*models.MCPServer, error) {
+	mcpServer, err := r.next.GetByVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, mcpServer.Namespace) {
+		return nil, ErrNotFound
+	}
+	return mcpServer, nil
+}
+
+func (r *NamespacedMCPServerRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+// NamespacedRouterRepository limits a RouterRepository to the namespace of the context.
+// Routers of other namespaces are reported as not found and never match a request.
+type NamespacedRouterRepository struct {
+	next RouterRepository
+}
+
+// NewNamespacedRouterRepository wraps a router repository with namespace scoping
+func NewNamespacedRouterRepository(next RouterRepository) *NamespacedRouterRepository {
+	return &NamespacedRouterRepository{next: next}
+}
+
+func (r *NamespacedRouterRepository) Create(ctx context.Context, router *models.Router) error {
+	router.Namespace = assign(ctx, router.Namespace)
+	return r.next.Create(ctx, router)
+}
+
+func (r *NamespacedRouterRepository) GetByID(ctx context.Context, id string) (*models.Router, error) {
+	router, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, router.Namespace) {
+		return nil, ErrNotFound
+	}
+	return router, nil
+}
+
+func (r *NamespacedRouterRepository) GetAll(ctx context.Context) ([]models.Router, error) {
+	routers, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.Router, 0, len(routers))
+	for _, router := range routers {
+		if visible(ctx, router.Namespace) {
+			result = append(result, router)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedRouterRepository) Update(ctx context.Context, router *models.Router) error {
+	existing, err := r.GetByID(ctx, router.ID)
+	if err != nil {
+		return err
+	}
+	router.Namespace = assign(ctx, namespaceOr(router.Namespace, existing.Namespace))
+	return r.next.Update(ctx, router)
+}
+
+func (r *NamespacedRouterRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+func (r *NamespacedRouterRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.next.GetVersions(ctx, id)
+}
+
+func (r *NamespacedRouterRepository) GetByVersion(ctx context.Context, id string, version int) (*models.Router, error) {
+	router, err := r.next.GetByVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, router.Namespace) {
+		return nil, ErrNotFound
+	}
+	return router, nil
+}
+
+func (r *NamespacedRouterRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+// NamespacedCollectionRepository limits a CollectionRepository to the namespace of the context.
+// Collections of other namespaces are reported as not found.
+type NamespacedCollectionRepository struct {
+	next CollectionRepository
+}
+
+// NewNamespacedCollectionRepository wraps a collection repository with namespace scoping
+func NewNamespacedCollectionRepository(next CollectionRepository) *NamespacedCollectionRepository {
+	return &NamespacedCollectionRepository{next: next}
+}
+
+func (r *NamespacedCollectionRepository) Create(ctx context.Context, collection *models.Collection) error {
+	collection.Namespace = assign(ctx, collection.Namespace)
+	return r.next.Create(ctx, collection)
+}
+
+func (r *NamespacedCollectionRepository) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	collection, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, collection.Namespace) {
+		return nil, ErrNotFound
+	}
+	return collection, nil
+}
+
+func (r *NamespacedCollectionRepository) GetAll(ctx context.Context) ([]models.Collection, error) {
+	collections, err := r.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.Collection, 0, len(collections))
+	for _, collection := range collections {
+		if visible(ctx, collection.Namespace) {
+			result = append(result, collection)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedCollectionRepository) Update(ctx context.Context, collection *models.Collection) error {
+	existing, err := r.GetByID(ctx, collection.ID)
+	if err != nil {
+		return err
+	}
+	collection.Namespace = assign(ctx, namespaceOr(collection.Namespace, existing.Namespace))
+	return r.next.Update(ctx, collection)
+}
+
+func (r *NamespacedCollectionRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// NamespacedRevisionRepository limits a RevisionRepository to the namespace of the context.
+// Revisions of servers of other namespaces are reported as not found.
+type NamespacedRevisionRepository struct {
+	next RevisionRepository
+}
+
+// NewNamespacedRevisionRepository wraps a revision repository with namespace scoping
+func NewNamespacedRevisionRepository(next RevisionRepository) *NamespacedRevisionRepository {
+	return &NamespacedRevisionRepository{next: next}
+}
+
+func (r *NamespacedRevisionRepository) Create(ctx context.Context, revision *models.Revision) error {
+	revision.Namespace = assign(ctx, revision.Namespace)
+	return r.next.Create(ctx, revision)
+}
+
+func (r *NamespacedRevisionRepository) GetByID(ctx context.Context, id string) (*models.Revision, error) {
+	revision, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, revision.Namespace) {
+		return nil, ErrNotFound
+	}
+	return revision, nil
+}
+
+func (r *NamespacedRevisionRepository) List(ctx context.Context, serverID string, status string) ([]models.Revision, error) {
+	revisions, err := r.next.List(ctx, serverID, status)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		if visible(ctx, revision.Namespace) {
+			result = append(result, revision)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedRevisionRepository) Review(ctx context.Context, revision *models.Revision) error {
+	if _, err := r.GetByID(ctx, revision.ID); err != nil {
+		return err
+	}
+	return r.next.Review(ctx, revision)
+}
+
+// NamespacedMCPSessionRepository limits an MCPSessionRepository to the namespace of the context.
+// Sessions of other namespaces are reported as not found.
+type NamespacedMCPSessionRepository struct {
+	next MCPSessionRepository
+}
+
+// NewNamespacedMCPSessionRepository wraps a session repository with namespace scoping
+func NewNamespacedMCPSessionRepository(next MCPSessionRepository) *NamespacedMCPSessionRepository {
+	return &NamespacedMCPSessionRepository{next: next}
+}
+
+func (r *NamespacedMCPSessionRepository) Create(ctx context.Context, session *models.MCPSession) error {
+	session.Namespace = assign(ctx, session.Namespace)
+	return r.next.Create(ctx, session)
+}
+
+func (r *NamespacedMCPSessionRepository) GetByID(ctx context.Context, id string) (*models.MCPSession, error) {
+	session, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(ctx, session.Namespace) {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+func (r *NamespacedMCPSessionRepository) GetAll(ctx context.Context, serverID string) ([]models.MCPSession, error) {
+	sessions, err := r.next.GetAll(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.MCPSession, 0, len(sessions))
+	for _, session := range sessions {
+		if visible(ctx, session.Namespace) {
+			result = append(result, session)
+		}
+	}
+	return result, nil
+}
+
+func (r *NamespacedMCPSessionRepository) Update(ctx context.Context, session *models.MCPSession) error {
+	existing, err := r.GetByID(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+	session.Namespace = existing.Namespace
+	return r.next.Update(ctx, session)
+}
+
+func (r *NamespacedMCPSessionRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
+
+// DeleteExpired removes the expired sessions of every namespace
+func (r *NamespacedMCPSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	return r.next.DeleteExpired(ctx, now)
+}
+
+// namespaceOr returns requested, or current if the update leaves the namespace empty
+func namespaceOr(requested string, current string) string {
+	if requested == "" {
+		return current
+	}
+	return requested
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PublishFunc announces that the entity with the given ID changed
+type PublishFunc func(ctx context.Context, id string) error
+
+// NotifyingMCPServerRepository publishes the ID of every MCP server it changes,
+// so other gateway instances can refresh their registrations
+type NotifyingMCPServerRepository struct {
+	MCPServerRepository
+	publish PublishFunc
+}
+
+// NewNotifyingMCPServerRepository wraps an MCP server repository with change notifications
+func NewNotifyingMCPServerRepository(next MCPServerRepository, publish PublishFunc) *NotifyingMCPServerRepository {
+	return &NotifyingMCPServerRepository{MCPServerRepository: next, publish: publish}
+}
+
+func (r *NotifyingMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	err := r.MCPServerRepository.Create(ctx, mcpServer)
+	r.notify(ctx, mcpServer.ID, err)
+	return err
+}
+
+func (r *NotifyingMCPServerRepository) Update(ctx context.Context, mcpServer *models.MCPServer) error {
+	err := r.MCPServerRepository.Update(ctx, mcpServer)
+	r.notify(ctx, mcpServer.ID, err)
+	return err
+}
+
+func (r *NotifyingMCPServerRepository) Delete(ctx context.Context, id string) error {
+	err := r.MCPServerRepository.Delete(ctx, id)
+	r.notify(ctx, id, err)
+	return err
+}
+
+func (r *NotifyingMCPServerRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	err := r.MCPServerRepository.UpdateStatus(ctx, id, status)
+	r.notify(ctx, id, err)
+	return err
+}
+
+// notify publishes a successful change; a lost notification is repaired by the periodic reconciliation
+func (r *NotifyingMCPServerRepository) notify(ctx context.Context, id string, err error) {
+	if err != nil {
+		return
+	}
+	if err := r.publish(ctx, id); err != nil {
+		slog.WarnContext(ctx, "Failed to publish MCP server change", "id", id, "error", err)
+	}
+}
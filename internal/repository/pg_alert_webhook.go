@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgAlertWebhookRepository is a PostgreSQL implementation of AlertWebhookRepository
+type PgAlertWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPgAlertWebhookRepository creates a new PostgreSQL-based alert webhook repository
+func NewPgAlertWebhookRepository(db *sql.DB) *PgAlertWebhookRepository {
+	return &PgAlertWebhookRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgAlertWebhookRepository) Initialize(ctx context.Context) error {
+	// Create alert_webhooks table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS alert_webhooks (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			url TEXT NOT NULL,
+			format TEXT NOT NULL,
+			enabled BOOLEAN NOT NULL,
+			error_rate_threshold DOUBLE PRECISION NOT NULL,
+			min_calls INTEGER NOT NULL,
+			window_seconds INTEGER NOT NULL,
+			upstream_health BOOLEAN NOT NULL,
+			cooldown_seconds INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanAlertWebhook scans a single alert webhook row
+func scanAlertWebhook(scanner interface{ Scan(...interface{}) error }) (*models.AlertWebhook, error) {
+	var webhook models.AlertWebhook
+
+	err := scanner.Scan(
+		&webhook.ID,
+		&webhook.Name,
+		&webhook.URL,
+		&webhook.Format,
+		&webhook.Enabled,
+		&webhook.ErrorRateThreshold,
+		&webhook.MinCalls,
+		&webhook.WindowSeconds,
+		&webhook.UpstreamHealth,
+		&webhook.CooldownSeconds,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetAll returns all alert webhooks
+func (r *PgAlertWebhookRepository) GetAll(ctx context.Context) ([]models.AlertWebhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, url, format, enabled, error_rate_threshold, min_calls, window_seconds,
+			upstream_health, cooldown_seconds, created_at, updated_at
+		FROM alert_webhooks
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []models.AlertWebhook
+	for rows.Next() {
+		webhook, err := scanAlertWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetByID returns a specific alert webhook by ID
+func (r *PgAlertWebhookRepository) GetByID(ctx context.Context, id string) (*models.AlertWebhook, error) {
+	webhook, err := scanAlertWebhook(r.db.QueryRowContext(ctx, `
+		SELECT id, name, url, format, enabled, error_rate_threshold, min_calls, window_seconds,
+			upstream_health, cooldown_seconds, created_at, updated_at
+		FROM alert_webhooks
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return webhook, err
+}
+
+// Create creates a new alert webhook
+func (r *PgAlertWebhookRepository) Create(ctx context.Context, webhook *models.AlertWebhook) error {
+	// Generate ID if not provided
+	if webhook.ID == "" {
+		webhook.ID = fmt.Sprintf("webhook-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO alert_webhooks (
+			id, name, url, format, enabled, error_rate_threshold, min_calls, window_seconds,
+			upstream_health, cooldown_seconds, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		webhook.ID,
+		webhook.Name,
+		webhook.URL,
+		webhook.Format,
+		webhook.Enabled,
+		webhook.ErrorRateThreshold,
+		webhook.MinCalls,
+		webhook.WindowSeconds,
+		webhook.UpstreamHealth,
+		webhook.CooldownSeconds,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing alert webhook
+func (r *PgAlertWebhookRepository) Update(ctx context.Context, webhook *models.AlertWebhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE alert_webhooks SET
+			name = $1,
+			url = $2,
+			format = $3,
+			enabled = $4,
+			error_rate_threshold = $5,
+			min_calls = $6,
+			window_seconds = $7,
+			upstream_health = $8,
+			cooldown_seconds = $9,
+			updated_at = $10
+		WHERE id = $11
+	`,
+		webhook.Name,
+		webhook.URL,
+		webhook.Format,
+		webhook.Enabled,
+		webhook.ErrorRateThreshold,
+		webhook.MinCalls,
+		webhook.WindowSeconds,
+		webhook.UpstreamHealth,
+		webhook.CooldownSeconds,
+		webhook.UpdatedAt,
+		webhook.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an alert webhook
+func (r *PgAlertWebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM alert_webhooks WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
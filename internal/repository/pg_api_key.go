@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgAPIKeyRepository is a PostgreSQL implementation of APIKeyRepository
+type PgAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPgAPIKeyRepository creates a new PostgreSQL-based API key repository
+func NewPgAPIKeyRepository(db *sql.DB) *PgAPIKeyRepository {
+	return &PgAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgAPIKeyRepository) Initialize(ctx context.Context) error {
+	// Create api_keys table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			prefix TEXT NOT NULL,
+			hash TEXT NOT NULL UNIQUE,
+			max_cost_per_day DOUBLE PRECISION NOT NULL,
+			max_cost_per_month DOUBLE PRECISION NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Create api_key_usage table, one row of counters per key and day or month
+	_, err = r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS api_key_usage (
+			key_id TEXT NOT NULL,
+			period TEXT NOT NULL,
+			calls INTEGER NOT NULL,
+			cost DOUBLE PRECISION NOT NULL,
+			PRIMARY KEY (key_id, period)
+		)
+	`)
+	return err
+}
+
+// scanAPIKey scans a single API key row
+func scanAPIKey(scanner interface{ Scan(...interface{}) error }) (*models.APIKey, error) {
+	var key models.APIKey
+	err := scanner.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		&key.MaxCostPerDay,
+		&key.MaxCostPerMonth,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Create creates a new API key, storing the hash of the key only
+func (r *PgAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	key.ID = fmt.Sprintf("apikey-%s", uuid.New().String())
+	now := time.Now()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO api_keys (id, name, prefix, hash, max_cost_per_day, max_cost_per_month, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		key.MaxCostPerDay,
+		key.MaxCostPerMonth,
+		key.CreatedAt,
+		key.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetByID returns a specific API key by ID
+func (r *PgAPIKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, `
+		SELECT id, name, prefix, hash, max_cost_per_day, max_cost_per_month, created_at, updated_at
+		FROM api_keys
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return key, err
+}
+
+// GetByHash returns the API key with a hash
+func (r *PgAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, `
+		SELECT id, name, prefix, hash, max_cost_per_day, max_cost_per_month, created_at, updated_at
+		FROM api_keys
+		WHERE hash = $1
+	`, hash))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return key, err
+}
+
+// GetAll returns all API keys ordered by name
+func (r *PgAPIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, prefix, hash, max_cost_per_day, max_cost_per_month, created_at, updated_at
+		FROM api_keys
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Update updates the name and the quotas of an API key
+func (r *PgAPIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	updated, err := scanAPIKey(r.db.QueryRowContext(ctx, `
+		UPDATE api_keys SET
+			name = $1,
+			max_cost_per_day = $2,
+			max_cost_per_month = $3,
+			updated_at = $4
+		WHERE id = $5
+		RETURNING id, name, prefix, hash, max_cost_per_day, max_cost_per_month, created_at, updated_at
+	`,
+		key.Name,
+		key.MaxCostPerDay,
+		key.MaxCostPerMonth,
+		time.Now(),
+		key.ID,
+	))
+
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	*key = *updated
+	return nil
+}
+
+// Delete removes an API key and its usage
+func (r *PgAPIKeyRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM api_keys WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM api_key_usage WHERE key_id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CountCall counts a call of the key on day and in month unless it would exceed a limit. Both
+// counters are updated in a transaction holding their rows, so concurrent calls and gateway
+// instances cannot exceed the limits.
+func (r *PgAPIKeyRepository) CountCall(ctx context.Context, id string, day string, month string, cost float64, dayLimit float64, monthLimit float64) (bool, models.APIKeyPeriodUsage, models.APIKeyPeriodUsage, error) {
+	dayUsage := models.APIKeyPeriodUsage{Period: day}
+	monthUsage := models.APIKeyPeriodUsage{Period: month}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, dayUsage, monthUsage, err
+	}
+	defer tx.Rollback()
+
+	counted := (dayLimit <= 0 || cost <= dayLimit) && (monthLimit <= 0 || cost <= monthLimit)
+	if counted {
+		if counted, err = countPeriod(ctx, tx, id, &dayUsage, cost, dayLimit); err != nil {
+			return false, dayUsage, monthUsage, err
+		}
+	}
+	if counted {
+		if counted, err = countPeriod(ctx, tx, id, &monthUsage, cost, monthLimit); err != nil {
+			return false, dayUsage, monthUsage, err
+		}
+	}
+	if counted {
+		return true, dayUsage, monthUsage, tx.Commit()
+	}
+
+	// Report the usage that prevented the call, the transaction counted nothing
+	tx.Rollback()
+	for _, usage := range []*models.APIKeyPeriodUsage{&dayUsage, &monthUsage} {
+		err := r.db.QueryRowContext(ctx, `
+			SELECT calls, cost FROM api_key_usage WHERE key_id = $1 AND period = $2
+		`, id, usage.Period).Scan(&usage.Calls, &usage.Cost)
+		if err != nil && err != sql.ErrNoRows {
+			return false, dayUsage, monthUsage, err
+		}
+	}
+	return false, dayUsage, monthUsage, nil
+}
+
+// countPeriod adds a call of cost to the usage of a key in a period unless it would exceed limit
+func countPeriod(ctx context.Context, tx *sql.Tx, id string, usage *models.APIKeyPeriodUsage, cost float64, limit float64) (bool, error) {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO api_key_usage (key_id, period, calls, cost)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (key_id, period) DO UPDATE SET
+			calls = api_key_usage.calls + 1,
+			cost = api_key_usage.cost + EXCLUDED.cost
+		WHERE $4::DOUBLE PRECISION <= 0 OR api_key_usage.cost + EXCLUDED.cost <= $4::DOUBLE PRECISION
+		RETURNING calls, cost
+	`, id, usage.Period, cost, limit).Scan(&usage.Calls, &usage.Cost)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Usage returns the usage of the key in the periods starting with prefix
+func (r *PgAPIKeyRepository) Usage(ctx context.Context, id string, prefix string) ([]models.APIKeyPeriodUsage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT period, calls, cost
+		FROM api_key_usage
+		WHERE key_id = $1 AND starts_with(period, $2)
+		ORDER BY period
+	`, id, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := []models.APIKeyPeriodUsage{}
+	for rows.Next() {
+		var periodUsage models.APIKeyPeriodUsage
+		if err := rows.Scan(&periodUsage.Period, &periodUsage.Calls, &periodUsage.Cost); err != nil {
+			return nil, err
+		}
+		usage = append(usage, periodUsage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgCollectionRepository is a PostgreSQL implementation of CollectionRepository
+type PgCollectionRepository struct {
+	db *sql.DB
+}
+
+// NewPgCollectionRepository creates a new PostgreSQL-based collection repository
+func NewPgCollectionRepository(db *sql.DB) *PgCollectionRepository {
+	return &PgCollectionRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgCollectionRepository) Initialize(ctx context.Context) error {
+	// Create collections table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS collections (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT 'default',
+			description TEXT,
+			interface_ids JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanCollection scans a single collection row
+func scanCollection(scanner interface{ Scan(...interface{}) error }) (*models.Collection, error) {
+	var collection models.Collection
+	var interfaceIDsJSON []byte
+
+	err := scanner.Scan(
+		&collection.ID,
+		&collection.Name,
+		&collection.Namespace,
+		&collection.Description,
+		&interfaceIDsJSON,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal interface IDs
+	if err := json.Unmarshal(interfaceIDsJSON, &collection.InterfaceIDs); err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// GetAll returns all collections ordered by name
+func (r *PgCollectionRepository) GetAll(ctx context.Context) ([]models.Collection, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, namespace, description, interface_ids, created_at, updated_at
+		FROM collections
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []models.Collection{}
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// GetByID returns a specific collection by ID
+func (r *PgCollectionRepository) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	collection, err := scanCollection(r.db.QueryRowContext(ctx, `
+		SELECT id, name, namespace, description, interface_ids, created_at, updated_at
+		FROM collections
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return collection, err
+}
+
+// Create creates a new collection
+func (r *PgCollectionRepository) Create(ctx context.Context, collection *models.Collection) error {
+	// Generate ID if not provided
+	if collection.ID == "" {
+		collection.ID = fmt.Sprintf("collection-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	collection.CreatedAt = now
+	collection.UpdatedAt = now
+
+	interfaceIDsJSON, err := json.Marshal(collection.InterfaceIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO collections (id, name, namespace, description, interface_ids, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		collection.ID,
+		collection.Name,
+		collection.Namespace,
+		collection.Description,
+		interfaceIDsJSON,
+		collection.CreatedAt,
+		collection.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing collection
+func (r *PgCollectionRepository) Update(ctx context.Context, collection *models.Collection) error {
+	collection.UpdatedAt = time.Now()
+
+	interfaceIDsJSON, err := json.Marshal(collection.InterfaceIDs)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE collections SET
+			name = $1,
+			namespace = $2,
+			description = $3,
+			interface_ids = $4,
+			updated_at = $5
+		WHERE id = $6
+	`,
+		collection.Name,
+		collection.Namespace,
+		collection.Description,
+		interfaceIDsJSON,
+		collection.UpdatedAt,
+		collection.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a collection
+func (r *PgCollectionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM collections WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
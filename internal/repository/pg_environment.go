@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgEnvironmentRepository is a PostgreSQL implementation of EnvironmentRepository
+type PgEnvironmentRepository struct {
+	db *sql.DB
+}
+
+// NewPgEnvironmentRepository creates a new PostgreSQL-based environment repository
+func NewPgEnvironmentRepository(db *sql.DB) *PgEnvironmentRepository {
+	return &PgEnvironmentRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgEnvironmentRepository) Initialize(ctx context.Context) error {
+	// Create environments table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS environments (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			variables JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanEnvironment scans a single environment row
+func scanEnvironment(scanner interface{ Scan(...interface{}) error }) (*models.Environment, error) {
+	var environment models.Environment
+	var variablesJSON []byte
+
+	err := scanner.Scan(
+		&environment.ID,
+		&environment.Name,
+		&environment.Description,
+		&variablesJSON,
+		&environment.CreatedAt,
+		&environment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal variables
+	if err := json.Unmarshal(variablesJSON, &environment.Variables); err != nil {
+		return nil, err
+	}
+
+	return &environment, nil
+}
+
+// GetAll returns all environments ordered by name
+func (r *PgEnvironmentRepository) GetAll(ctx context.Context) ([]models.Environment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, variables, created_at, updated_at
+		FROM environments
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	environments := []models.Environment{}
+	for rows.Next() {
+		environment, err := scanEnvironment(rows)
+		if err != nil {
+			return nil, err
+		}
+		environments = append(environments, *environment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return environments, nil
+}
+
+// GetByID returns a specific environment by ID
+func (r *PgEnvironmentRepository) GetByID(ctx context.Context, id string) (*models.Environment, error) {
+	environment, err := scanEnvironment(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, variables, created_at, updated_at
+		FROM environments
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return environment, err
+}
+
+// GetByName returns a specific environment by name
+func (r *PgEnvironmentRepository) GetByName(ctx context.Context, name string) (*models.Environment, error) {
+	environment, err := scanEnvironment(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, variables, created_at, updated_at
+		FROM environments
+		WHERE name = $1
+	`, name))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return environment, err
+}
+
+// Create creates a new environment
+func (r *PgEnvironmentRepository) Create(ctx context.Context, environment *models.Environment) error {
+	// Generate ID if not provided
+	if environment.ID == "" {
+		environment.ID = fmt.Sprintf("env-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	environment.CreatedAt = now
+	environment.UpdatedAt = now
+
+	variablesJSON, err := json.Marshal(environment.Variables)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO environments (id, name, description, variables, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		environment.ID,
+		environment.Name,
+		environment.Description,
+		variablesJSON,
+		environment.CreatedAt,
+		environment.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing environment
+func (r *PgEnvironmentRepository) Update(ctx context.Context, environment *models.Environment) error {
+	environment.UpdatedAt = time.Now()
+
+	variablesJSON, err := json.Marshal(environment.Variables)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE environments SET
+			name = $1,
+			description = $2,
+			variables = $3,
+			updated_at = $4
+		WHERE id = $5
+	`,
+		environment.Name,
+		environment.Description,
+		variablesJSON,
+		environment.UpdatedAt,
+		environment.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an environment
+func (r *PgEnvironmentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM environments WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgEventWebhookRepository is a PostgreSQL implementation of EventWebhookRepository
+type PgEventWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPgEventWebhookRepository creates a new PostgreSQL-based event webhook repository
+func NewPgEventWebhookRepository(db *sql.DB) *PgEventWebhookRepository {
+	return &PgEventWebhookRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgEventWebhookRepository) Initialize(ctx context.Context) error {
+	// Create event_webhooks table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS event_webhooks (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events JSONB NOT NULL,
+			enabled BOOLEAN NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanEventWebhook scans a single event webhook row
+func scanEventWebhook(scanner interface{ Scan(...interface{}) error }) (*models.EventWebhook, error) {
+	var webhook models.EventWebhook
+	var eventsJSON []byte
+
+	err := scanner.Scan(
+		&webhook.ID,
+		&webhook.Name,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventsJSON,
+		&webhook.Enabled,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(eventsJSON, &webhook.Events); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetAll returns all event webhooks
+func (r *PgEventWebhookRepository) GetAll(ctx context.Context) ([]models.EventWebhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, url, secret, events, enabled, created_at, updated_at
+		FROM event_webhooks
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []models.EventWebhook
+	for rows.Next() {
+		webhook, err := scanEventWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetByID returns a specific event webhook by ID
+func (r *PgEventWebhookRepository) GetByID(ctx context.Context, id string) (*models.EventWebhook, error) {
+	webhook, err := scanEventWebhook(r.db.QueryRowContext(ctx, `
+		SELECT id, name, url, secret, events, enabled, created_at, updated_at
+		FROM event_webhooks
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return webhook, err
+}
+
+// Create creates a new event webhook
+func (r *PgEventWebhookRepository) Create(ctx context.Context, webhook *models.EventWebhook) error {
+	// Generate ID if not provided
+	if webhook.ID == "" {
+		webhook.ID = fmt.Sprintf("event-webhook-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO event_webhooks (
+			id, name, url, secret, events, enabled, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		webhook.ID,
+		webhook.Name,
+		webhook.URL,
+		webhook.Secret,
+		eventsJSON,
+		webhook.Enabled,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing event webhook
+func (r *PgEventWebhookRepository) Update(ctx context.Context, webhook *models.EventWebhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE event_webhooks SET
+			name = $1,
+			url = $2,
+			secret = $3,
+			events = $4,
+			enabled = $5,
+			updated_at = $6
+		WHERE id = $7
+	`,
+		webhook.Name,
+		webhook.URL,
+		webhook.Secret,
+		eventsJSON,
+		webhook.Enabled,
+		webhook.UpdatedAt,
+		webhook.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an event webhook
+func (r *PgEventWebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM event_webhooks WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -8,8 +8,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
 )
 
 // PgHTTPInterfaceRepository is a PostgreSQL implementation of HTTPInterfaceRepository
@@ -43,30 +44,76 @@ func (r *PgHTTPInterfaceRepository) Initialize(ctx context.Context) error {
 			updated_at TIMESTAMP NOT NULL
 		)
 	`)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Add columns introduced after the initial schema
+	_, err = r.db.ExecContext(ctx, `
+		ALTER TABLE http_interfaces
+			ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default',
+			ADD COLUMN IF NOT EXISTS auth JSONB,
+			ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE,
+			ADD COLUMN IF NOT EXISTS deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+			ADD COLUMN IF NOT EXISTS sunset TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS deprecation_message TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS changelog JSONB NOT NULL DEFAULT 'null'
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Names are unique within a namespace
+	_, err = r.db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS http_interfaces_namespace_name ON http_interfaces (namespace, name)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to index HTTP interface names, rename the interfaces sharing a name in a namespace: %w", err)
+	}
+	return nil
 }
 
 // GetAll returns all HTTP interfaces
 func (r *PgHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPInterface, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, name, description, method, path, headers, parameters, request_body, responses, version, created_at, updated_at
+		SELECT id, name, namespace, description, method, path, headers, parameters, request_body, responses, auth, archived, deprecated, sunset, deprecation_message, version, changelog, created_at, updated_at
 		FROM http_interfaces
 	`)
 	if err != nil {
 		return nil, err
 	}
+	return scanHTTPInterfaces(rows)
+}
+
+// GetByIDs returns the HTTP interfaces of the IDs, skipping unknown ones
+func (r *PgHTTPInterfaceRepository) GetByIDs(ctx context.Context, ids []string) ([]models.HTTPInterface, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, namespace, description, method, path, headers, parameters, request_body, responses, auth, archived, deprecated, sunset, deprecation_message, version, changelog, created_at, updated_at
+		FROM http_interfaces
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanHTTPInterfaces(rows)
+}
+
+// scanHTTPInterfaces reads the HTTP interfaces of rows and closes them
+func scanHTTPInterfaces(rows *sql.Rows) ([]models.HTTPInterface, error) {
 	defer rows.Close()
 
 	var interfaces []models.HTTPInterface
 	for rows.Next() {
 		var iface models.HTTPInterface
-		var headersJSON, paramsJSON, responsesJSON []byte
+		var headersJSON, paramsJSON, responsesJSON, changelogJSON []byte
 		var requestBodyJSON sql.NullString
+		var authJSON sql.NullString
 
 		// Scan rows into variables
 		err := rows.Scan(
 			&iface.ID,
 			&iface.Name,
+			&iface.Namespace,
 			&iface.Description,
 			&iface.Method,
 			&iface.Path,
@@ -74,7 +121,13 @@ func (r *PgHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPIn
 			&paramsJSON,
 			&requestBodyJSON,
 			&responsesJSON,
+			&authJSON,
+			&iface.Archived,
+			&iface.Deprecated,
+			&iface.Sunset,
+			&iface.DeprecationMessage,
 			&iface.Version,
+			&changelogJSON,
 			&iface.CreatedAt,
 			&iface.UpdatedAt,
 		)
@@ -106,6 +159,20 @@ func (r *PgHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPIn
 			return nil, err
 		}
 
+		// Unmarshal auth (if exists)
+		if authJSON.Valid {
+			var auth models.Auth
+			if err := json.Unmarshal([]byte(authJSON.String), &auth); err != nil {
+				return nil, err
+			}
+			iface.Auth = &auth
+		}
+
+		// Unmarshal change note
+		if err := json.Unmarshal(changelogJSON, &iface.Changelog); err != nil {
+			return nil, err
+		}
+
 		interfaces = append(interfaces, iface)
 	}
 
@@ -119,16 +186,18 @@ func (r *PgHTTPInterfaceRepository) GetAll(ctx context.Context) ([]models.HTTPIn
 // GetByID returns a specific HTTP interface by ID
 func (r *PgHTTPInterfaceRepository) GetByID(ctx context.Context, id string) (*models.HTTPInterface, error) {
 	var iface models.HTTPInterface
-	var headersJSON, paramsJSON, responsesJSON []byte
+	var headersJSON, paramsJSON, responsesJSON, changelogJSON []byte
 	var requestBodyJSON sql.NullString
+	var authJSON sql.NullString
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, name, description, method, path, headers, parameters, request_body, responses, version, created_at, updated_at
+		SELECT id, name, namespace, description, method, path, headers, parameters, request_body, responses, auth, archived, deprecated, sunset, deprecation_message, version, changelog, created_at, updated_at
 		FROM http_interfaces
 		WHERE id = $1
 	`, id).Scan(
 		&iface.ID,
 		&iface.Name,
+		&iface.Namespace,
 		&iface.Description,
 		&iface.Method,
 		&iface.Path,
@@ -136,7 +205,13 @@ func (r *PgHTTPInterfaceRepository) GetByID(ctx context.Context, id string) (*mo
 		&paramsJSON,
 		&requestBodyJSON,
 		&responsesJSON,
+		&authJSON,
+		&iface.Archived,
+		&iface.Deprecated,
+		&iface.Sunset,
+		&iface.DeprecationMessage,
 		&iface.Version,
+		&changelogJSON,
 		&iface.CreatedAt,
 		&iface.UpdatedAt,
 	)
@@ -171,6 +246,20 @@ func (r *PgHTTPInterfaceRepository) GetByID(ctx context.Context, id string) (*mo
 		return nil, err
 	}
 
+	// Unmarshal auth (if exists)
+	if authJSON.Valid {
+		var auth models.Auth
+		if err := json.Unmarshal([]byte(authJSON.String), &auth); err != nil {
+			return nil, err
+		}
+		iface.Auth = &auth
+	}
+
+	// Unmarshal change note
+	if err := json.Unmarshal(changelogJSON, &iface.Changelog); err != nil {
+		return nil, err
+	}
+
 	return &iface, nil
 }
 
@@ -181,8 +270,10 @@ func (r *PgHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *m
 		httpInterface.ID = fmt.Sprintf("http-%s", uuid.New().String())
 	}
 
-	// Set version and timestamps
+	// Set version and timestamps, new interfaces are never archived
+	httpInterface.Archived = false
 	httpInterface.Version = 1
+	httpInterface.Changelog = ChangeNoteFromContext(ctx)
 	now := time.Now()
 	httpInterface.CreatedAt = now
 	httpInterface.UpdatedAt = now
@@ -212,28 +303,52 @@ func (r *PgHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *m
 		return err
 	}
 
-	// Insert the HTTP interface
-	_, err = r.db.ExecContext(ctx, `
-		INSERT INTO http_interfaces (
-			id, name, description, method, path, headers, parameters, 
-			request_body, responses, version, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	`,
-		httpInterface.ID,
-		httpInterface.Name,
-		httpInterface.Description,
-		httpInterface.Method,
-		httpInterface.Path,
-		headersJSON,
-		paramsJSON,
-		requestBodyStr,
-		responsesJSON,
-		httpInterface.Version,
-		httpInterface.CreatedAt,
-		httpInterface.UpdatedAt,
-	)
+	var authStr sql.NullString
+	if httpInterface.Auth != nil {
+		authJSON, err := json.Marshal(httpInterface.Auth)
+		if err != nil {
+			return err
+		}
+		authStr = sql.NullString{String: string(authJSON), Valid: true}
+	}
+
+	changelogJSON, err := json.Marshal(httpInterface.Changelog)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Insert the HTTP interface, unless the namespace is out of quota
+	err = insertWithinQuota(ctx, r.db, "http_interfaces", namespace.OrDefault(httpInterface.Namespace), createLimit(ctx, "HTTP interface"), func(db execer) error {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO http_interfaces (
+				id, name, description, method, path, headers, parameters, 
+				request_body, responses, version, created_at, updated_at, namespace, auth,
+				deprecated, sunset, deprecation_message, changelog
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		`,
+			httpInterface.ID,
+			httpInterface.Name,
+			httpInterface.Description,
+			httpInterface.Method,
+			httpInterface.Path,
+			headersJSON,
+			paramsJSON,
+			requestBodyStr,
+			responsesJSON,
+			httpInterface.Version,
+			httpInterface.CreatedAt,
+			httpInterface.UpdatedAt,
+			httpInterface.Namespace,
+			authStr,
+			httpInterface.Deprecated,
+			httpInterface.Sunset,
+			httpInterface.DeprecationMessage,
+			changelogJSON,
+		)
+		return err
+	})
+
+	return nameTaken(err, "HTTP interface", httpInterface.Namespace, httpInterface.Name)
 }
 
 // Update updates an existing HTTP interface
@@ -249,9 +364,14 @@ func (r *PgHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *m
 	} else if err != nil {
 		return err
 	}
+	expected := expectedVersion(ctx, httpInterface.ID)
+	if expected != 0 && currentVersion != expected {
+		return ErrVersionConflict
+	}
 
 	// Increment version and update timestamp
 	httpInterface.Version = currentVersion + 1
+	httpInterface.Changelog = ChangeNoteFromContext(ctx)
 	httpInterface.UpdatedAt = time.Now()
 
 	// Serialize complex types to JSON
@@ -279,8 +399,23 @@ func (r *PgHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *m
 		return err
 	}
 
-	// Update the HTTP interface
-	result, err := r.db.ExecContext(ctx, `
+	var authStr sql.NullString
+	if httpInterface.Auth != nil {
+		authJSON, err := json.Marshal(httpInterface.Auth)
+		if err != nil {
+			return err
+		}
+		authStr = sql.NullString{String: string(authJSON), Valid: true}
+	}
+
+	changelogJSON, err := json.Marshal(httpInterface.Changelog)
+	if err != nil {
+		return err
+	}
+
+	// Update the HTTP interface, keeping whether it is archived, unless another update changed the
+	// expected version meanwhile
+	err = r.db.QueryRowContext(ctx, `
 		UPDATE http_interfaces SET
 			name = $1,
 			description = $2,
@@ -291,8 +426,15 @@ func (r *PgHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *m
 			request_body = $7,
 			responses = $8,
 			version = $9,
-			updated_at = $10
-		WHERE id = $11
+			updated_at = $10,
+			namespace = $11,
+			auth = $12,
+			deprecated = $13,
+			sunset = $14,
+			deprecation_message = $15,
+			changelog = $16
+		WHERE id = $17 AND ($18 = 0 OR version = $18)
+		RETURNING archived
 	`,
 		httpInterface.Name,
 		httpInterface.Description,
@@ -304,9 +446,32 @@ func (r *PgHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *m
 		responsesJSON,
 		httpInterface.Version,
 		httpInterface.UpdatedAt,
+		httpInterface.Namespace,
+		authStr,
+		httpInterface.Deprecated,
+		httpInterface.Sunset,
+		httpInterface.DeprecationMessage,
+		changelogJSON,
 		httpInterface.ID,
-	)
+		expected,
+	).Scan(&httpInterface.Archived)
+
+	if err == sql.ErrNoRows && expected != 0 {
+		return ErrVersionConflict
+	} else if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return nameTaken(err, "HTTP interface", httpInterface.Namespace, httpInterface.Name)
+	}
+
+	return nil
+}
 
+// Delete deletes an HTTP interface by ID
+func (r *PgHTTPInterfaceRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM http_interfaces WHERE id = $1
+	`, id)
 	if err != nil {
 		return err
 	}
@@ -323,11 +488,11 @@ func (r *PgHTTPInterfaceRepository) Update(ctx context.Context, httpInterface *m
 	return nil
 }
 
-// Delete deletes an HTTP interface by ID
-func (r *PgHTTPInterfaceRepository) Delete(ctx context.Context, id string) error {
+// SetArchived archives or unarchives an HTTP interface
+func (r *PgHTTPInterfaceRepository) SetArchived(ctx context.Context, id string, archived bool) error {
 	result, err := r.db.ExecContext(ctx, `
-		DELETE FROM http_interfaces WHERE id = $1
-	`, id)
+		UPDATE http_interfaces SET archived = $1, updated_at = $2 WHERE id = $3
+	`, archived, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -344,14 +509,15 @@ func (r *PgHTTPInterfaceRepository) Delete(ctx context.Context, id string) error
 	return nil
 }
 
-// GetVersions returns all versions of a specific HTTP interface
+// GetVersions returns the version history of a specific HTTP interface
 // Note: In this implementation, we only store the current version
-// so this will just return a single-element array with the current version number
-func (r *PgHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
-	var version int
+// so this will just return a single-element array with the current version
+func (r *PgHTTPInterfaceRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
+	var info models.VersionInfo
+	var changelogJSON []byte
 	err := r.db.QueryRowContext(ctx, `
-		SELECT version FROM http_interfaces WHERE id = $1
-	`, id).Scan(&version)
+		SELECT version, updated_at, changelog FROM http_interfaces WHERE id = $1
+	`, id).Scan(&info.Version, &info.UpdatedAt, &changelogJSON)
 
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
@@ -359,7 +525,11 @@ func (r *PgHTTPInterfaceRepository) GetVersions(ctx context.Context, id string)
 		return nil, err
 	}
 
-	return []int{version}, nil
+	if err := json.Unmarshal(changelogJSON, &info.Changelog); err != nil {
+		return nil, err
+	}
+
+	return []models.VersionInfo{info}, nil
 }
 
 // GetByVersion returns a specific version of an HTTP interface
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgInvocationRepository is a PostgreSQL implementation of InvocationRepository
+type PgInvocationRepository struct {
+	db *sql.DB
+}
+
+// NewPgInvocationRepository creates a new PostgreSQL-based invocation repository
+func NewPgInvocationRepository(db *sql.DB) *PgInvocationRepository {
+	return &PgInvocationRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgInvocationRepository) Initialize(ctx context.Context) error {
+	// Create invocations table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS invocations (
+			id TEXT PRIMARY KEY,
+			server_id TEXT NOT NULL,
+			server_name TEXT,
+			tool TEXT NOT NULL,
+			caller TEXT,
+			request_id TEXT,
+			status_code INTEGER NOT NULL,
+			success BOOLEAN NOT NULL,
+			error TEXT,
+			duration_ms BIGINT NOT NULL,
+			request TEXT,
+			response TEXT,
+			created_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Add columns introduced after the initial schema
+	_, err = r.db.ExecContext(ctx, `
+		ALTER TABLE invocations
+			ADD COLUMN IF NOT EXISTS upstream JSONB NOT NULL DEFAULT 'null'
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Index the columns used by the history query
+	_, err = r.db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS invocations_server_created_idx
+		ON invocations (server_id, created_at DESC)
+	`)
+	return err
+}
+
+// Create records a new invocation
+func (r *PgInvocationRepository) Create(ctx context.Context, invocation *models.Invocation) error {
+	invocation.ID = fmt.Sprintf("invocation-%s", uuid.New().String())
+	if invocation.CreatedAt.IsZero() {
+		invocation.CreatedAt = time.Now()
+	}
+
+	upstreamJSON, err := json.Marshal(invocation.Upstream)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO invocations (id, server_id, server_name, tool, caller, request_id, status_code,
+			success, error, duration_ms, request, response, created_at, upstream)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		invocation.ID,
+		invocation.ServerID,
+		invocation.ServerName,
+		invocation.Tool,
+		invocation.Caller,
+		invocation.RequestID,
+		invocation.StatusCode,
+		invocation.Success,
+		invocation.Error,
+		invocation.DurationMs,
+		invocation.Request,
+		invocation.Response,
+		invocation.CreatedAt,
+		upstreamJSON,
+	)
+	return err
+}
+
+// GetByID returns an invocation
+func (r *PgInvocationRepository) GetByID(ctx context.Context, id string) (*models.Invocation, error) {
+	var invocation models.Invocation
+	var upstreamJSON []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, server_id, server_name, tool, caller, request_id, status_code,
+			success, error, duration_ms, request, response, created_at, upstream
+		FROM invocations WHERE id = $1
+	`, id).Scan(
+		&invocation.ID,
+		&invocation.ServerID,
+		&invocation.ServerName,
+		&invocation.Tool,
+		&invocation.Caller,
+		&invocation.RequestID,
+		&invocation.StatusCode,
+		&invocation.Success,
+		&invocation.Error,
+		&invocation.DurationMs,
+		&invocation.Request,
+		&invocation.Response,
+		&invocation.CreatedAt,
+		&upstreamJSON,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(upstreamJSON, &invocation.Upstream); err != nil {
+		return nil, err
+	}
+	return &invocation, nil
+}
+
+// List returns a page of matching invocations, newest first
+func (r *PgInvocationRepository) List(ctx context.Context, filter InvocationFilter) ([]models.Invocation, int, error) {
+	where, args := invocationWhere(filter)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM invocations"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, server_id, server_name, tool, caller, request_id, status_code,
+			success, error, duration_ms, request, response, created_at, upstream
+		FROM invocations` + where + `
+		ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	invocations := []models.Invocation{}
+	for rows.Next() {
+		var invocation models.Invocation
+		var upstreamJSON []byte
+		err := rows.Scan(
+			&invocation.ID,
+			&invocation.ServerID,
+			&invocation.ServerName,
+			&invocation.Tool,
+			&invocation.Caller,
+			&invocation.RequestID,
+			&invocation.StatusCode,
+			&invocation.Success,
+			&invocation.Error,
+			&invocation.DurationMs,
+			&invocation.Request,
+			&invocation.Response,
+			&invocation.CreatedAt,
+			&upstreamJSON,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(upstreamJSON, &invocation.Upstream); err != nil {
+			return nil, 0, err
+		}
+		invocations = append(invocations, invocation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return invocations, total, nil
+}
+
+// invocationWhere builds the WHERE clause and its arguments for a filter
+func invocationWhere(filter InvocationFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ServerID != "" {
+		add("server_id = $%d", filter.ServerID)
+	}
+	if filter.Tool != "" {
+		add("tool = $%d", filter.Tool)
+	}
+	if filter.Status == "success" || filter.Status == "error" {
+		add("success = $%d", filter.Status == "success")
+	}
+	if !filter.Since.IsZero() {
+		add("created_at >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add("created_at < $%d", filter.Until)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// Stats aggregates matching invocations
+func (r *PgInvocationRepository) Stats(ctx context.Context, filter InvocationFilter, groupBy string) ([]models.UsageStats, error) {
+	where, args := invocationWhere(filter)
+
+	var columns, groupClause string
+	switch groupBy {
+	case StatsGroupByServer:
+		columns = "server_id, MAX(server_name), '' AS tool,"
+		groupClause = " GROUP BY server_id"
+	case StatsGroupByTool:
+		columns = "server_id, MAX(server_name), tool,"
+		groupClause = " GROUP BY server_id, tool"
+	default:
+		columns = "'' AS server_id, '' AS server_name, '' AS tool,"
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+columns+`
+			COUNT(*) AS calls,
+			COUNT(*) FILTER (WHERE NOT success) AS errors,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_ms), 0),
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY duration_ms), 0),
+			COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_ms), 0)
+		FROM invocations`+where+groupClause+`
+		ORDER BY calls DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []models.UsageStats{}
+	for rows.Next() {
+		var stats models.UsageStats
+		err := rows.Scan(
+			&stats.ServerID,
+			&stats.ServerName,
+			&stats.Tool,
+			&stats.Calls,
+			&stats.Errors,
+			&stats.LatencyP50Ms,
+			&stats.LatencyP90Ms,
+			&stats.LatencyP99Ms,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if stats.Calls > 0 {
+			stats.ErrorRate = float64(stats.Errors) / float64(stats.Calls)
+		}
+		result = append(result, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
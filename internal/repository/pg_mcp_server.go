@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
 )
 
 // PgMCPServerRepository is a PostgreSQL implementation of MCPServerRepository
@@ -39,13 +42,56 @@ func (r *PgMCPServerRepository) Initialize(ctx context.Context) error {
 			updated_at TIMESTAMP NOT NULL
 		)
 	`)
+	if err != nil {
+		return err
+	}
+
+	// Add columns introduced after the initial schema
+	_, err = r.db.ExecContext(ctx, `
+		ALTER TABLE mcp_servers
+			ADD COLUMN IF NOT EXISTS plugins JSONB NOT NULL DEFAULT '[]',
+			ADD COLUMN IF NOT EXISTS default_environment TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default',
+			ADD COLUMN IF NOT EXISTS external JSONB NOT NULL DEFAULT '[]',
+			ADD COLUMN IF NOT EXISTS sources JSONB NOT NULL DEFAULT '[]',
+			ADD COLUMN IF NOT EXISTS conflict_resolution TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS redactions JSONB NOT NULL DEFAULT '[]',
+			ADD COLUMN IF NOT EXISTS schedule JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS auth_passthrough JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS network JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS instructions TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS strict BOOLEAN NOT NULL DEFAULT false,
+			ADD COLUMN IF NOT EXISTS policy JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS changelog JSONB NOT NULL DEFAULT 'null'
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Names are unique within a namespace, the index also serves lookups by name
+	_, err = r.db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS mcp_servers_namespace_name ON mcp_servers (namespace, name)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to index MCP server names, rename the servers sharing a name in a namespace: %w", err)
+	}
+	return nil
+}
+
+// nameTaken maps the unique violations of a name index to ErrNameTaken
+func nameTaken(err error, kind string, owner string, name string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return NameTakenError(kind, owner, name)
+	}
 	return err
 }
 
 // GetAll returns all MCP servers
 func (r *PgMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, name, description, tools, allow_tools, status, version, created_at, updated_at
+		SELECT id, name, namespace, description, instructions, tools, allow_tools, plugins, default_environment, external, sources, conflict_resolution, redactions, schedule, headers, auth_passthrough, network, policy, strict, status, version, changelog, created_at, updated_at
 		FROM mcp_servers
 	`)
 	if err != nil {
@@ -56,17 +102,32 @@ func (r *PgMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer,
 	var servers []models.MCPServer
 	for rows.Next() {
 		var server models.MCPServer
-		var toolsJSON, allowToolsJSON []byte
+		var toolsJSON, allowToolsJSON, pluginsJSON, externalJSON, sourcesJSON, redactionsJSON, scheduleJSON, headersJSON, passthroughJSON, networkJSON, policyJSON, changelogJSON []byte
 
 		// Scan rows into variables
 		err := rows.Scan(
 			&server.ID,
 			&server.Name,
+			&server.Namespace,
 			&server.Description,
+			&server.Instructions,
 			&toolsJSON,
 			&allowToolsJSON,
+			&pluginsJSON,
+			&server.DefaultEnvironment,
+			&externalJSON,
+			&sourcesJSON,
+			&server.ConflictResolution,
+			&redactionsJSON,
+			&scheduleJSON,
+			&headersJSON,
+			&passthroughJSON,
+			&networkJSON,
+			&policyJSON,
+			&server.Strict,
 			&server.Status,
 			&server.Version,
+			&changelogJSON,
 			&server.CreatedAt,
 			&server.UpdatedAt,
 		)
@@ -84,6 +145,56 @@ func (r *PgMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer,
 			return nil, err
 		}
 
+		// Unmarshal plugins
+		if err := json.Unmarshal(pluginsJSON, &server.Plugins); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal external servers
+		if err := json.Unmarshal(externalJSON, &server.External); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal source servers
+		if err := json.Unmarshal(sourcesJSON, &server.Sources); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal redaction rules
+		if err := json.Unmarshal(redactionsJSON, &server.Redactions); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal activation schedule
+		if err := json.Unmarshal(scheduleJSON, &server.Schedule); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal header policy
+		if err := json.Unmarshal(headersJSON, &server.Headers); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal Authorization passthrough policy
+		if err := json.Unmarshal(passthroughJSON, &server.AuthPassthrough); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal network restriction
+		if err := json.Unmarshal(networkJSON, &server.Network); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal call policy
+		if err := json.Unmarshal(policyJSON, &server.Policy); err != nil {
+			return nil, err
+		}
+
+		// Unmarshal change note
+		if err := json.Unmarshal(changelogJSON, &server.Changelog); err != nil {
+			return nil, err
+		}
+
 		servers = append(servers, server)
 	}
 
@@ -97,20 +208,35 @@ func (r *PgMCPServerRepository) GetAll(ctx context.Context) ([]models.MCPServer,
 // GetByID returns a specific MCP server by ID
 func (r *PgMCPServerRepository) GetByID(ctx context.Context, id string) (*models.MCPServer, error) {
 	var server models.MCPServer
-	var toolsJSON, allowToolsJSON []byte
+	var toolsJSON, allowToolsJSON, pluginsJSON, externalJSON, sourcesJSON, redactionsJSON, scheduleJSON, headersJSON, passthroughJSON, networkJSON, policyJSON, changelogJSON []byte
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, name, description, tools, allow_tools, status, version, created_at, updated_at
+		SELECT id, name, namespace, description, instructions, tools, allow_tools, plugins, default_environment, external, sources, conflict_resolution, redactions, schedule, headers, auth_passthrough, network, policy, strict, status, version, changelog, created_at, updated_at
 		FROM mcp_servers
 		WHERE id = $1
 	`, id).Scan(
 		&server.ID,
 		&server.Name,
+		&server.Namespace,
 		&server.Description,
+		&server.Instructions,
 		&toolsJSON,
 		&allowToolsJSON,
+		&pluginsJSON,
+		&server.DefaultEnvironment,
+		&externalJSON,
+		&sourcesJSON,
+		&server.ConflictResolution,
+		&redactionsJSON,
+		&scheduleJSON,
+		&headersJSON,
+		&passthroughJSON,
+		&networkJSON,
+		&policyJSON,
+		&server.Strict,
 		&server.Status,
 		&server.Version,
+		&changelogJSON,
 		&server.CreatedAt,
 		&server.UpdatedAt,
 	)
@@ -131,6 +257,56 @@ func (r *PgMCPServerRepository) GetByID(ctx context.Context, id string) (*models
 		return nil, err
 	}
 
+	// Unmarshal plugins
+	if err := json.Unmarshal(pluginsJSON, &server.Plugins); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal external servers
+	if err := json.Unmarshal(externalJSON, &server.External); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal source servers
+	if err := json.Unmarshal(sourcesJSON, &server.Sources); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal redaction rules
+	if err := json.Unmarshal(redactionsJSON, &server.Redactions); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal activation schedule
+	if err := json.Unmarshal(scheduleJSON, &server.Schedule); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal header policy
+	if err := json.Unmarshal(headersJSON, &server.Headers); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal Authorization passthrough policy
+	if err := json.Unmarshal(passthroughJSON, &server.AuthPassthrough); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal network restriction
+	if err := json.Unmarshal(networkJSON, &server.Network); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal call policy
+	if err := json.Unmarshal(policyJSON, &server.Policy); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal change note
+	if err := json.Unmarshal(changelogJSON, &server.Changelog); err != nil {
+		return nil, err
+	}
+
 	return &server, nil
 }
 
@@ -143,6 +319,7 @@ func (r *PgMCPServerRepository) Create(ctx context.Context, server *models.MCPSe
 
 	// Set version and timestamps
 	server.Version = 1
+	server.Changelog = ChangeNoteFromContext(ctx)
 	now := time.Now()
 	server.CreatedAt = now
 	server.UpdatedAt = now
@@ -163,24 +340,92 @@ func (r *PgMCPServerRepository) Create(ctx context.Context, server *models.MCPSe
 		return err
 	}
 
-	// Insert the MCP server
-	_, err = r.db.ExecContext(ctx, `
-		INSERT INTO mcp_servers (
-			id, name, description, tools, allow_tools, status, version, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`,
-		server.ID,
-		server.Name,
-		server.Description,
-		toolsJSON,
-		allowToolsJSON,
-		server.Status,
-		server.Version,
-		server.CreatedAt,
-		server.UpdatedAt,
-	)
+	pluginsJSON, err := json.Marshal(server.Plugins)
+	if err != nil {
+		return err
+	}
 
-	return err
+	externalJSON, err := json.Marshal(server.External)
+	if err != nil {
+		return err
+	}
+
+	sourcesJSON, err := json.Marshal(server.Sources)
+	if err != nil {
+		return err
+	}
+
+	redactionsJSON, err := json.Marshal(server.Redactions)
+	if err != nil {
+		return err
+	}
+
+	scheduleJSON, err := json.Marshal(server.Schedule)
+	if err != nil {
+		return err
+	}
+
+	headersJSON, err := json.Marshal(server.Headers)
+	if err != nil {
+		return err
+	}
+
+	passthroughJSON, err := json.Marshal(server.AuthPassthrough)
+	if err != nil {
+		return err
+	}
+
+	networkJSON, err := json.Marshal(server.Network)
+	if err != nil {
+		return err
+	}
+
+	policyJSON, err := json.Marshal(server.Policy)
+	if err != nil {
+		return err
+	}
+
+	changelogJSON, err := json.Marshal(server.Changelog)
+	if err != nil {
+		return err
+	}
+
+	// Insert the MCP server, unless the namespace is out of quota
+	err = insertWithinQuota(ctx, r.db, "mcp_servers", namespace.OrDefault(server.Namespace), createLimit(ctx, "MCP server"), func(db execer) error {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO mcp_servers (
+				id, name, description, tools, allow_tools, plugins, default_environment, status, version, created_at, updated_at, namespace, external, sources, conflict_resolution, redactions, schedule, headers, auth_passthrough, network, instructions, strict, policy, changelog
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+		`,
+			server.ID,
+			server.Name,
+			server.Description,
+			toolsJSON,
+			allowToolsJSON,
+			pluginsJSON,
+			server.DefaultEnvironment,
+			server.Status,
+			server.Version,
+			server.CreatedAt,
+			server.UpdatedAt,
+			server.Namespace,
+			externalJSON,
+			sourcesJSON,
+			server.ConflictResolution,
+			redactionsJSON,
+			scheduleJSON,
+			headersJSON,
+			passthroughJSON,
+			networkJSON,
+			server.Instructions,
+			server.Strict,
+			policyJSON,
+			changelogJSON,
+		)
+		return err
+	})
+
+	return nameTaken(err, "MCP server", server.Namespace, server.Name)
 }
 
 // Update updates an existing MCP server
@@ -196,9 +441,14 @@ func (r *PgMCPServerRepository) Update(ctx context.Context, server *models.MCPSe
 	} else if err != nil {
 		return err
 	}
+	expected := expectedVersion(ctx, server.ID)
+	if expected != 0 && currentVersion != expected {
+		return ErrVersionConflict
+	}
 
 	// Set new version and update timestamp
 	server.Version = currentVersion + 1
+	server.Changelog = ChangeNoteFromContext(ctx)
 	server.UpdatedAt = time.Now()
 
 	// Serialize complex types to JSON
@@ -212,30 +462,111 @@ func (r *PgMCPServerRepository) Update(ctx context.Context, server *models.MCPSe
 		return err
 	}
 
-	// Update the MCP server
+	pluginsJSON, err := json.Marshal(server.Plugins)
+	if err != nil {
+		return err
+	}
+
+	externalJSON, err := json.Marshal(server.External)
+	if err != nil {
+		return err
+	}
+
+	sourcesJSON, err := json.Marshal(server.Sources)
+	if err != nil {
+		return err
+	}
+
+	redactionsJSON, err := json.Marshal(server.Redactions)
+	if err != nil {
+		return err
+	}
+
+	scheduleJSON, err := json.Marshal(server.Schedule)
+	if err != nil {
+		return err
+	}
+
+	headersJSON, err := json.Marshal(server.Headers)
+	if err != nil {
+		return err
+	}
+
+	passthroughJSON, err := json.Marshal(server.AuthPassthrough)
+	if err != nil {
+		return err
+	}
+
+	networkJSON, err := json.Marshal(server.Network)
+	if err != nil {
+		return err
+	}
+
+	policyJSON, err := json.Marshal(server.Policy)
+	if err != nil {
+		return err
+	}
+
+	changelogJSON, err := json.Marshal(server.Changelog)
+	if err != nil {
+		return err
+	}
+
+	// Update the MCP server, unless another update changed the expected version meanwhile
 	result, err := r.db.ExecContext(ctx, `
 		UPDATE mcp_servers SET
 			name = $1,
 			description = $2,
 			tools = $3,
 			allow_tools = $4,
-			status = $5,
-			version = $6,
-			updated_at = $7
-		WHERE id = $8
+			plugins = $5,
+			default_environment = $6,
+			status = $7,
+			version = $8,
+			updated_at = $9,
+			namespace = $10,
+			external = $11,
+			sources = $12,
+			conflict_resolution = $13,
+			redactions = $14,
+			schedule = $15,
+			headers = $16,
+			auth_passthrough = $17,
+			network = $18,
+			instructions = $19,
+			strict = $20,
+			policy = $21,
+			changelog = $22
+		WHERE id = $23 AND ($24 = 0 OR version = $24)
 	`,
 		server.Name,
 		server.Description,
 		toolsJSON,
 		allowToolsJSON,
+		pluginsJSON,
+		server.DefaultEnvironment,
 		server.Status,
 		server.Version,
 		server.UpdatedAt,
+		server.Namespace,
+		externalJSON,
+		sourcesJSON,
+		server.ConflictResolution,
+		redactionsJSON,
+		scheduleJSON,
+		headersJSON,
+		passthroughJSON,
+		networkJSON,
+		server.Instructions,
+		server.Strict,
+		policyJSON,
+		changelogJSON,
 		server.ID,
+		expected,
 	)
 
 	if err != nil {
-		return err
+		return nameTaken(err, "MCP server", server.Namespace, server.Name)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -244,6 +575,9 @@ func (r *PgMCPServerRepository) Update(ctx context.Context, server *models.MCPSe
 	}
 
 	if rowsAffected == 0 {
+		if expected != 0 {
+			return ErrVersionConflict
+		}
 		return ErrNotFound
 	}
 
@@ -271,19 +605,23 @@ func (r *PgMCPServerRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// GetVersions returns all version numbers for an MCP server
-func (r *PgMCPServerRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+// GetVersions returns the version history of an MCP server
+func (r *PgMCPServerRepository) GetVersions(ctx context.Context, id string) ([]models.VersionInfo, error) {
 	// In a real implementation, you'd store past versions
 	// For this simplified version, just return the current version
-	var version int
-	err := r.db.QueryRowContext(ctx, "SELECT version FROM mcp_servers WHERE id = $1", id).Scan(&version)
+	var info models.VersionInfo
+	var changelogJSON []byte
+	err := r.db.QueryRowContext(ctx, "SELECT version, updated_at, changelog FROM mcp_servers WHERE id = $1", id).Scan(&info.Version, &info.UpdatedAt, &changelogJSON)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
 	} else if err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(changelogJSON, &info.Changelog); err != nil {
+		return nil, err
+	}
 
-	return []int{version}, nil
+	return []models.VersionInfo{info}, nil
 }
 
 // GetByVersion retrieves a specific version of an MCP server
@@ -326,23 +664,38 @@ func (r *PgMCPServerRepository) UpdateStatus(ctx context.Context, id string, sta
 	return nil
 }
 
-// GetByName returns a specific MCP server by name
+// GetByName returns the MCP server of the name in the namespace of the context
 func (r *PgMCPServerRepository) GetByName(ctx context.Context, name string) (*models.MCPServer, error) {
 	var server models.MCPServer
-	var toolsJSON, allowToolsJSON []byte
+	var toolsJSON, allowToolsJSON, pluginsJSON, externalJSON, sourcesJSON, redactionsJSON, scheduleJSON, headersJSON, passthroughJSON, networkJSON, policyJSON, changelogJSON []byte
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, name, description, tools, allow_tools, status, version, created_at, updated_at
+		SELECT id, name, namespace, description, instructions, tools, allow_tools, plugins, default_environment, external, sources, conflict_resolution, redactions, schedule, headers, auth_passthrough, network, policy, strict, status, version, changelog, created_at, updated_at
 		FROM mcp_servers
-		WHERE name = $1
-	`, name).Scan(
+		WHERE namespace = $1 AND name = $2
+	`, lookupNamespace(ctx), name).Scan(
 		&server.ID,
 		&server.Name,
+		&server.Namespace,
 		&server.Description,
+		&server.Instructions,
 		&toolsJSON,
 		&allowToolsJSON,
+		&pluginsJSON,
+		&server.DefaultEnvironment,
+		&externalJSON,
+		&sourcesJSON,
+		&server.ConflictResolution,
+		&redactionsJSON,
+		&scheduleJSON,
+		&headersJSON,
+		&passthroughJSON,
+		&networkJSON,
+		&policyJSON,
+		&server.Strict,
 		&server.Status,
 		&server.Version,
+		&changelogJSON,
 		&server.CreatedAt,
 		&server.UpdatedAt,
 	)
@@ -363,5 +716,55 @@ func (r *PgMCPServerRepository) GetByName(ctx context.Context, name string) (*mo
 		return nil, err
 	}
 
+	// Unmarshal plugins
+	if err := json.Unmarshal(pluginsJSON, &server.Plugins); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal external servers
+	if err := json.Unmarshal(externalJSON, &server.External); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal source servers
+	if err := json.Unmarshal(sourcesJSON, &server.Sources); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal redaction rules
+	if err := json.Unmarshal(redactionsJSON, &server.Redactions); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal activation schedule
+	if err := json.Unmarshal(scheduleJSON, &server.Schedule); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal header policy
+	if err := json.Unmarshal(headersJSON, &server.Headers); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal Authorization passthrough policy
+	if err := json.Unmarshal(passthroughJSON, &server.AuthPassthrough); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal network restriction
+	if err := json.Unmarshal(networkJSON, &server.Network); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal call policy
+	if err := json.Unmarshal(policyJSON, &server.Policy); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal change note
+	if err := json.Unmarshal(changelogJSON, &server.Changelog); err != nil {
+		return nil, err
+	}
+
 	return &server, nil
 }
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgNamespacePolicyRepository is a PostgreSQL implementation of NamespacePolicyRepository
+type PgNamespacePolicyRepository struct {
+	db *sql.DB
+}
+
+// NewPgNamespacePolicyRepository creates a new PostgreSQL-based namespace policy repository
+func NewPgNamespacePolicyRepository(db *sql.DB) *PgNamespacePolicyRepository {
+	return &PgNamespacePolicyRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgNamespacePolicyRepository) Initialize(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS namespace_policies (
+			namespace TEXT PRIMARY KEY,
+			policy JSONB NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanNamespacePolicy scans a single namespace policy row
+func scanNamespacePolicy(scanner interface{ Scan(...interface{}) error }) (*models.NamespacePolicy, error) {
+	var policy models.NamespacePolicy
+	var policyJSON []byte
+	if err := scanner.Scan(&policy.Namespace, &policyJSON, &policy.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(policyJSON, &policy.Policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Get returns the policy of a namespace
+func (r *PgNamespacePolicyRepository) Get(ctx context.Context, namespace string) (*models.NamespacePolicy, error) {
+	policy, err := scanNamespacePolicy(r.db.QueryRowContext(ctx, `
+		SELECT namespace, policy, updated_at
+		FROM namespace_policies
+		WHERE namespace = $1
+	`, namespace))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return policy, err
+}
+
+// GetAll returns all policies ordered by namespace
+func (r *PgNamespacePolicyRepository) GetAll(ctx context.Context) ([]models.NamespacePolicy, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT namespace, policy, updated_at
+		FROM namespace_policies
+		ORDER BY namespace
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []models.NamespacePolicy{}
+	for rows.Next() {
+		policy, err := scanNamespacePolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Set creates or replaces the policy of a namespace
+func (r *PgNamespacePolicyRepository) Set(ctx context.Context, policy *models.NamespacePolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	policyJSON, err := json.Marshal(policy.Policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO namespace_policies (namespace, policy, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (namespace) DO UPDATE SET
+			policy = EXCLUDED.policy,
+			updated_at = EXCLUDED.updated_at
+	`,
+		policy.Namespace,
+		policyJSON,
+		policy.UpdatedAt,
+	)
+
+	return err
+}
+
+// Delete removes the policy of a namespace
+func (r *PgNamespacePolicyRepository) Delete(ctx context.Context, namespace string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM namespace_policies WHERE namespace = $1
+	`, namespace)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// PgQuotaRepository is a PostgreSQL implementation of QuotaRepository
+type PgQuotaRepository struct {
+	db *sql.DB
+}
+
+// NewPgQuotaRepository creates a new PostgreSQL-based quota repository
+func NewPgQuotaRepository(db *sql.DB) *PgQuotaRepository {
+	return &PgQuotaRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgQuotaRepository) Initialize(ctx context.Context) error {
+	// Create quotas table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS quotas (
+			tenant TEXT PRIMARY KEY,
+			max_tool_calls_per_day INTEGER NOT NULL,
+			max_servers INTEGER NOT NULL,
+			max_interfaces INTEGER NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Create tenant_usage table, one row of counters per tenant and day
+	_, err = r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS tenant_usage (
+			tenant TEXT NOT NULL,
+			day DATE NOT NULL,
+			tool_calls INTEGER NOT NULL,
+			PRIMARY KEY (tenant, day)
+		)
+	`)
+	return err
+}
+
+// scanQuota scans a single quota row
+func scanQuota(scanner interface{ Scan(...interface{}) error }) (*models.Quota, error) {
+	var quota models.Quota
+	err := scanner.Scan(
+		&quota.Tenant,
+		&quota.MaxToolCallsPerDay,
+		&quota.MaxServers,
+		&quota.MaxInterfaces,
+		&quota.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// Get returns the quota of a tenant
+func (r *PgQuotaRepository) Get(ctx context.Context, tenant string) (*models.Quota, error) {
+	quota, err := scanQuota(r.db.QueryRowContext(ctx, `
+		SELECT tenant, max_tool_calls_per_day, max_servers, max_interfaces, updated_at
+		FROM quotas
+		WHERE tenant = $1
+	`, tenant))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return quota, err
+}
+
+// GetAll returns all quotas ordered by tenant
+func (r *PgQuotaRepository) GetAll(ctx context.Context) ([]models.Quota, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tenant, max_tool_calls_per_day, max_servers, max_interfaces, updated_at
+		FROM quotas
+		ORDER BY tenant
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quotas := []models.Quota{}
+	for rows.Next() {
+		quota, err := scanQuota(rows)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, *quota)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return quotas, nil
+}
+
+// Set creates or replaces the quota of a tenant
+func (r *PgQuotaRepository) Set(ctx context.Context, quota *models.Quota) error {
+	quota.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO quotas (tenant, max_tool_calls_per_day, max_servers, max_interfaces, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant) DO UPDATE SET
+			max_tool_calls_per_day = EXCLUDED.max_tool_calls_per_day,
+			max_servers = EXCLUDED.max_servers,
+			max_interfaces = EXCLUDED.max_interfaces,
+			updated_at = EXCLUDED.updated_at
+	`,
+		quota.Tenant,
+		quota.MaxToolCallsPerDay,
+		quota.MaxServers,
+		quota.MaxInterfaces,
+		quota.UpdatedAt,
+	)
+
+	return err
+}
+
+// Delete removes the quota of a tenant
+func (r *PgQuotaRepository) Delete(ctx context.Context, tenant string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM quotas WHERE tenant = $1
+	`, tenant)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CountToolCall counts a tool call of the tenant on day unless the limit is reached.
+// The check and the increment are a single statement, so concurrent calls and
+// gateway instances cannot exceed the limit.
+func (r *PgQuotaRepository) CountToolCall(ctx context.Context, tenant string, day string, limit int) (bool, error) {
+	var toolCalls int
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tenant_usage (tenant, day, tool_calls)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (tenant, day) DO UPDATE SET
+			tool_calls = tenant_usage.tool_calls + 1
+		WHERE $3 = 0 OR tenant_usage.tool_calls < $3
+		RETURNING tool_calls
+	`, tenant, day, limit).Scan(&toolCalls)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ToolCalls returns the tool calls counted for the tenant on day
+func (r *PgQuotaRepository) ToolCalls(ctx context.Context, tenant string, day string) (int, error) {
+	var toolCalls int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT tool_calls FROM tenant_usage WHERE tenant = $1 AND day = $2
+	`, tenant, day).Scan(&toolCalls)
+
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return toolCalls, err
+}
+
+// execer runs statements on a database or within a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertWithinQuota runs insert unless table already holds limit rows of the namespace tenant,
+// none if limit is 0. The rows are counted and inserted in a transaction holding the quota row of
+// the tenant, so concurrent creations and gateway instances cannot exceed the quota.
+func insertWithinQuota(ctx context.Context, db *sql.DB, table string, tenant string, limit int, insert func(execer) error) error {
+	if limit <= 0 {
+		return insert(db)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM quotas WHERE tenant = $1 FOR UPDATE`, tenant); err != nil {
+		return err
+	}
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE COALESCE(NULLIF(namespace, ''), $2) = $1`, tenant, namespace.Default).Scan(&count); err != nil {
+		return err
+	}
+	if count >= limit {
+		return ErrQuotaExceeded
+	}
+	if err := insert(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgRevisionRepository is a PostgreSQL implementation of RevisionRepository
+type PgRevisionRepository struct {
+	db *sql.DB
+}
+
+// NewPgRevisionRepository creates a new PostgreSQL-based revision repository
+func NewPgRevisionRepository(db *sql.DB) *PgRevisionRepository {
+	return &PgRevisionRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgRevisionRepository) Initialize(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS mcp_server_revisions (
+			id TEXT PRIMARY KEY,
+			server_id TEXT NOT NULL,
+			server_name TEXT NOT NULL,
+			namespace TEXT NOT NULL DEFAULT 'default',
+			change TEXT NOT NULL,
+			base_version INTEGER NOT NULL,
+			server JSONB NOT NULL,
+			status TEXT NOT NULL,
+			comment TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL DEFAULT 0,
+			submitted_at TIMESTAMP NOT NULL,
+			reviewed_at TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS mcp_server_revisions_server ON mcp_server_revisions (server_id, submitted_at)
+	`)
+	return err
+}
+
+// scanRevision scans a single revision row
+func scanRevision(scanner interface{ Scan(...interface{}) error }) (*models.Revision, error) {
+	var revision models.Revision
+	var serverJSON []byte
+	var reviewedAt sql.NullTime
+
+	err := scanner.Scan(
+		&revision.ID,
+		&revision.ServerID,
+		&revision.ServerName,
+		&revision.Namespace,
+		&revision.Change,
+		&revision.BaseVersion,
+		&serverJSON,
+		&revision.Status,
+		&revision.Comment,
+		&revision.Version,
+		&revision.SubmittedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(serverJSON, &revision.Server); err != nil {
+		return nil, err
+	}
+	if reviewedAt.Valid {
+		revision.ReviewedAt = &reviewedAt.Time
+	}
+
+	return &revision, nil
+}
+
+// Create stores a new pending revision
+func (r *PgRevisionRepository) Create(ctx context.Context, revision *models.Revision) error {
+	revision.ID = fmt.Sprintf("revision-%s", uuid.New().String())
+	revision.Status = models.RevisionPending
+	revision.SubmittedAt = time.Now()
+
+	serverJSON, err := json.Marshal(revision.Server)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO mcp_server_revisions (id, server_id, server_name, namespace, change, base_version, server, status, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		revision.ID,
+		revision.ServerID,
+		revision.ServerName,
+		revision.Namespace,
+		revision.Change,
+		revision.BaseVersion,
+		serverJSON,
+		revision.Status,
+		revision.SubmittedAt,
+	)
+
+	return err
+}
+
+// GetByID returns a specific revision by ID
+func (r *PgRevisionRepository) GetByID(ctx context.Context, id string) (*models.Revision, error) {
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, `
+		SELECT id, server_id, server_name, namespace, change, base_version, server, status, comment, version, submitted_at, reviewed_at
+		FROM mcp_server_revisions
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return revision, err
+}
+
+// List returns the matching revisions, newest first
+func (r *PgRevisionRepository) List(ctx context.Context, serverID string, status string) ([]models.Revision, error) {
+	var conditions []string
+	var args []interface{}
+	if serverID != "" {
+		args = append(args, serverID)
+		conditions = append(conditions, fmt.Sprintf("server_id = $%d", len(args)))
+	}
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, server_id, server_name, namespace, change, base_version, server, status, comment, version, submitted_at, reviewed_at
+		FROM mcp_server_revisions
+		`+where+`
+		ORDER BY submitted_at DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []models.Revision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// Review records the status, comment and resulting version of a pending revision
+func (r *PgRevisionRepository) Review(ctx context.Context, revision *models.Revision) error {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE mcp_server_revisions SET
+			status = $1,
+			comment = $2,
+			version = $3,
+			reviewed_at = $4
+		WHERE id = $5 AND status = $6
+	`,
+		revision.Status,
+		revision.Comment,
+		revision.Version,
+		now,
+		revision.ID,
+		models.RevisionPending,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if _, err := r.GetByID(ctx, revision.ID); err != nil {
+			return err
+		}
+		return ErrRevisionReviewed
+	}
+
+	revision.ReviewedAt = &now
+	return nil
+}
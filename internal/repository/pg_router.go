@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgRouterRepository is a PostgreSQL implementation of RouterRepository
+type PgRouterRepository struct {
+	db *sql.DB
+}
+
+// NewPgRouterRepository creates a new PostgreSQL-based router repository
+func NewPgRouterRepository(db *sql.DB) *PgRouterRepository {
+	return &PgRouterRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgRouterRepository) Initialize(ctx context.Context) error {
+	// Create routers table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS routers (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			description TEXT,
+			rules JSONB,
+			status TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Add columns introduced after the initial schema
+	_, err = r.db.ExecContext(ctx, `
+		ALTER TABLE routers
+			ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL DEFAULT 'default'
+	`)
+	return err
+}
+
+// scanRouter scans a single router row
+func scanRouter(scanner interface{ Scan(...interface{}) error }) (*models.Router, error) {
+	var router models.Router
+	var rulesJSON []byte
+
+	err := scanner.Scan(
+		&router.ID,
+		&router.Name,
+		&router.Namespace,
+		&router.Description,
+		&rulesJSON,
+		&router.Status,
+		&router.Version,
+		&router.CreatedAt,
+		&router.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal rules
+	if err := json.Unmarshal(rulesJSON, &router.Rules); err != nil {
+		return nil, err
+	}
+
+	return &router, nil
+}
+
+// GetAll returns all routers
+func (r *PgRouterRepository) GetAll(ctx context.Context) ([]models.Router, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, namespace, description, rules, status, version, created_at, updated_at
+		FROM routers
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routers []models.Router
+	for rows.Next() {
+		router, err := scanRouter(rows)
+		if err != nil {
+			return nil, err
+		}
+		routers = append(routers, *router)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return routers, nil
+}
+
+// GetByID returns a specific router by ID
+func (r *PgRouterRepository) GetByID(ctx context.Context, id string) (*models.Router, error) {
+	router, err := scanRouter(r.db.QueryRowContext(ctx, `
+		SELECT id, name, namespace, description, rules, status, version, created_at, updated_at
+		FROM routers
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return router, err
+}
+
+// Create creates a new router
+func (r *PgRouterRepository) Create(ctx context.Context, router *models.Router) error {
+	// Generate ID if not provided
+	if router.ID == "" {
+		router.ID = fmt.Sprintf("router-%s", uuid.New().String())
+	}
+
+	// Set version and timestamps
+	router.Version = 1
+	now := time.Now()
+	router.CreatedAt = now
+	router.UpdatedAt = now
+
+	// Set status if not provided
+	if router.Status == "" {
+		router.Status = "inactive"
+	}
+
+	rulesJSON, err := json.Marshal(router.Rules)
+	if err != nil {
+		return err
+	}
+
+	// Insert the router
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO routers (
+			id, name, description, rules, status, version, created_at, updated_at, namespace
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		router.ID,
+		router.Name,
+		router.Description,
+		rulesJSON,
+		router.Status,
+		router.Version,
+		router.CreatedAt,
+		router.UpdatedAt,
+		router.Namespace,
+	)
+
+	return err
+}
+
+// Update updates an existing router
+func (r *PgRouterRepository) Update(ctx context.Context, router *models.Router) error {
+	// Get current version
+	var currentVersion int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT version FROM routers WHERE id = $1
+	`, router.ID).Scan(&currentVersion)
+
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	// Set new version and update timestamp
+	router.Version = currentVersion + 1
+	router.UpdatedAt = time.Now()
+
+	rulesJSON, err := json.Marshal(router.Rules)
+	if err != nil {
+		return err
+	}
+
+	// Update the router
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE routers SET
+			name = $1,
+			description = $2,
+			rules = $3,
+			status = $4,
+			version = $5,
+			updated_at = $6,
+			namespace = $7
+		WHERE id = $8
+	`,
+		router.Name,
+		router.Description,
+		rulesJSON,
+		router.Status,
+		router.Version,
+		router.UpdatedAt,
+		router.Namespace,
+		router.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a router
+func (r *PgRouterRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM routers WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetVersions returns all version numbers for a router
+// Note: In this implementation, we only store the current version
+func (r *PgRouterRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, "SELECT version FROM routers WHERE id = $1", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return []int{version}, nil
+}
+
+// GetByVersion retrieves a specific version of a router
+// Note: In this implementation, we only store the current version
+func (r *PgRouterRepository) GetByVersion(ctx context.Context, id string, version int) (*models.Router, error) {
+	router, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if router.Version != version {
+		return nil, ErrNotFound
+	}
+
+	return router, nil
+}
+
+// UpdateStatus updates the status of a router
+func (r *PgRouterRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE routers SET
+			status = $1,
+			updated_at = $2
+		WHERE id = $3
+	`, status, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgSecretRepository is a PostgreSQL implementation of SecretRepository
+type PgSecretRepository struct {
+	db *sql.DB
+}
+
+// NewPgSecretRepository creates a new PostgreSQL-based secret repository
+func NewPgSecretRepository(db *sql.DB) *PgSecretRepository {
+	return &PgSecretRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgSecretRepository) Initialize(ctx context.Context) error {
+	// Create secrets table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS secrets (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			value TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Add columns introduced after the initial schema
+	_, err = r.db.ExecContext(ctx, `
+		ALTER TABLE secrets
+			ADD COLUMN IF NOT EXISTS rotation JSONB NOT NULL DEFAULT 'null',
+			ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP,
+			ADD COLUMN IF NOT EXISTS rotation_error TEXT NOT NULL DEFAULT ''
+	`)
+	return err
+}
+
+// scanSecret scans a single secret row
+func scanSecret(scanner interface{ Scan(...interface{}) error }) (*models.Secret, error) {
+	var secret models.Secret
+	var rotation []byte
+	var rotatedAt sql.NullTime
+	err := scanner.Scan(
+		&secret.ID,
+		&secret.Name,
+		&secret.Description,
+		&secret.Value,
+		&rotation,
+		&rotatedAt,
+		&secret.RotationError,
+		&secret.CreatedAt,
+		&secret.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rotation, &secret.Rotation); err != nil {
+		return nil, err
+	}
+	if rotatedAt.Valid {
+		secret.RotatedAt = &rotatedAt.Time
+	}
+	return &secret, nil
+}
+
+// GetAll returns all secrets ordered by name
+func (r *PgSecretRepository) GetAll(ctx context.Context) ([]models.Secret, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, value, rotation, rotated_at, rotation_error, created_at, updated_at
+		FROM secrets
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	secrets := []models.Secret{}
+	for rows.Next() {
+		secret, err := scanSecret(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, *secret)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// GetByID returns a specific secret by ID
+func (r *PgSecretRepository) GetByID(ctx context.Context, id string) (*models.Secret, error) {
+	secret, err := scanSecret(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, value, rotation, rotated_at, rotation_error, created_at, updated_at
+		FROM secrets
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return secret, err
+}
+
+// GetByName returns a specific secret by name
+func (r *PgSecretRepository) GetByName(ctx context.Context, name string) (*models.Secret, error) {
+	secret, err := scanSecret(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, value, rotation, rotated_at, rotation_error, created_at, updated_at
+		FROM secrets
+		WHERE name = $1
+	`, name))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return secret, err
+}
+
+// Create creates a new secret
+func (r *PgSecretRepository) Create(ctx context.Context, secret *models.Secret) error {
+	// Generate ID if not provided
+	if secret.ID == "" {
+		secret.ID = fmt.Sprintf("secret-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	secret.CreatedAt = now
+	secret.UpdatedAt = now
+
+	rotation, err := json.Marshal(secret.Rotation)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO secrets (id, name, description, value, rotation, rotated_at, rotation_error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		secret.ID,
+		secret.Name,
+		secret.Description,
+		secret.Value,
+		rotation,
+		secret.RotatedAt,
+		secret.RotationError,
+		secret.CreatedAt,
+		secret.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing secret
+func (r *PgSecretRepository) Update(ctx context.Context, secret *models.Secret) error {
+	secret.UpdatedAt = time.Now()
+
+	rotation, err := json.Marshal(secret.Rotation)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE secrets SET
+			name = $1,
+			description = $2,
+			value = $3,
+			rotation = $4,
+			rotated_at = $5,
+			rotation_error = $6,
+			updated_at = $7
+		WHERE id = $8
+	`,
+		secret.Name,
+		secret.Description,
+		secret.Value,
+		rotation,
+		secret.RotatedAt,
+		secret.RotationError,
+		secret.UpdatedAt,
+		secret.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a secret
+func (r *PgSecretRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM secrets WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// PgServerAliasRepository is a PostgreSQL implementation of ServerAliasRepository
+type PgServerAliasRepository struct {
+	db *sql.DB
+}
+
+// NewPgServerAliasRepository creates a new PostgreSQL-based server alias repository
+func NewPgServerAliasRepository(db *sql.DB) *PgServerAliasRepository {
+	return &PgServerAliasRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgServerAliasRepository) Initialize(ctx context.Context) error {
+	// Create mcp_server_aliases table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS mcp_server_aliases (
+			namespace TEXT NOT NULL DEFAULT 'default',
+			name TEXT NOT NULL,
+			server_id TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (namespace, name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Index for the aliases of a server
+	_, err = r.db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_mcp_server_aliases_server_id ON mcp_server_aliases(server_id)
+	`)
+	return err
+}
+
+// scanServerAlias scans a single alias row
+func scanServerAlias(scanner interface{ Scan(...interface{}) error }) (*models.ServerAlias, error) {
+	var alias models.ServerAlias
+	err := scanner.Scan(
+		&alias.Namespace,
+		&alias.Name,
+		&alias.ServerID,
+		&alias.CreatedAt,
+		&alias.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// Set creates or replaces an alias
+func (r *PgServerAliasRepository) Set(ctx context.Context, alias *models.ServerAlias) error {
+	alias.Namespace = namespace.OrDefault(alias.Namespace)
+	alias.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO mcp_server_aliases (namespace, name, server_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (namespace, name) DO UPDATE SET
+			server_id = EXCLUDED.server_id,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+	`,
+		alias.Namespace,
+		alias.Name,
+		alias.ServerID,
+		alias.CreatedAt,
+		alias.ExpiresAt,
+	)
+	return err
+}
+
+// Get returns the alias of a name in a namespace
+func (r *PgServerAliasRepository) Get(ctx context.Context, owner string, name string) (*models.ServerAlias, error) {
+	alias, err := scanServerAlias(r.db.QueryRowContext(ctx, `
+		SELECT namespace, name, server_id, created_at, expires_at
+		FROM mcp_server_aliases
+		WHERE namespace = $1 AND name = $2
+	`, namespace.OrDefault(owner), name))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return alias, err
+}
+
+// GetByServer returns the aliases of a server, newest first
+func (r *PgServerAliasRepository) GetByServer(ctx context.Context, serverID string) ([]models.ServerAlias, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT namespace, name, server_id, created_at, expires_at
+		FROM mcp_server_aliases
+		WHERE server_id = $1
+		ORDER BY created_at DESC
+	`, serverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := []models.ServerAlias{}
+	for rows.Next() {
+		alias, err := scanServerAlias(rows)
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, *alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// Delete removes an alias
+func (r *PgServerAliasRepository) Delete(ctx context.Context, owner string, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM mcp_server_aliases WHERE namespace = $1 AND name = $2
+	`, namespace.OrDefault(owner), name)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByServer removes the aliases of a server
+func (r *PgServerAliasRepository) DeleteByServer(ctx context.Context, serverID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM mcp_server_aliases WHERE server_id = $1
+	`, serverID)
+	return err
+}
+
+// DeleteExpired removes the aliases expired at now
+func (r *PgServerAliasRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM mcp_server_aliases WHERE expires_at <= $1
+	`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
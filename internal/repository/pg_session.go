@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgMCPSessionRepository is a PostgreSQL implementation of MCPSessionRepository, sharing the
+// sessions between the gateway instances behind a load balancer
+type PgMCPSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPgMCPSessionRepository creates a new PostgreSQL-based session repository
+func NewPgMCPSessionRepository(db *sql.DB) *PgMCPSessionRepository {
+	return &PgMCPSessionRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgMCPSessionRepository) Initialize(ctx context.Context) error {
+	// Create mcp_sessions table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS mcp_sessions (
+			id TEXT PRIMARY KEY,
+			namespace TEXT NOT NULL DEFAULT 'default',
+			server_id TEXT NOT NULL,
+			server_name TEXT NOT NULL,
+			protocol_version TEXT NOT NULL,
+			client_name TEXT NOT NULL DEFAULT '',
+			client_version TEXT NOT NULL DEFAULT '',
+			caller TEXT NOT NULL DEFAULT '',
+			environment TEXT NOT NULL DEFAULT '',
+			cookie_jar TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL,
+			last_seen_at TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Index for the cleanup of expired sessions
+	_, err = r.db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_mcp_sessions_expires_at ON mcp_sessions(expires_at)
+	`)
+	return err
+}
+
+const sessionColumns = `id, namespace, server_id, server_name, protocol_version, client_name, client_version,
+	caller, environment, cookie_jar, created_at, last_seen_at, expires_at`
+
+// scanSession scans a single session row
+func scanSession(scanner interface{ Scan(...interface{}) error }) (*models.MCPSession, error) {
+	var session models.MCPSession
+	err := scanner.Scan(
+		&session.ID,
+		&session.Namespace,
+		&session.ServerID,
+		&session.ServerName,
+		&session.ProtocolVersion,
+		&session.ClientName,
+		&session.ClientVersion,
+		&session.Caller,
+		&session.Environment,
+		&session.CookieJar,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Create creates a new session
+func (r *PgMCPSessionRepository) Create(ctx context.Context, session *models.MCPSession) error {
+	if session.ID == "" {
+		session.ID = fmt.Sprintf("session-%s", uuid.New().String())
+	}
+	session.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO mcp_sessions (`+sessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		session.ID,
+		session.Namespace,
+		session.ServerID,
+		session.ServerName,
+		session.ProtocolVersion,
+		session.ClientName,
+		session.ClientVersion,
+		session.Caller,
+		session.Environment,
+		session.CookieJar,
+		session.CreatedAt,
+		session.LastSeenAt,
+		session.ExpiresAt,
+	)
+	return err
+}
+
+// GetByID returns a specific session by ID
+func (r *PgMCPSessionRepository) GetByID(ctx context.Context, id string) (*models.MCPSession, error) {
+	session, err := scanSession(r.db.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM mcp_sessions
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return session, err
+}
+
+// GetAll returns the sessions, or those of a server, newest first
+func (r *PgMCPSessionRepository) GetAll(ctx context.Context, serverID string) ([]models.MCPSession, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM mcp_sessions
+		WHERE $1 = '' OR server_id = $1
+		ORDER BY created_at DESC
+	`, serverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.MCPSession{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Update updates the state and the expiry of a session
+func (r *PgMCPSessionRepository) Update(ctx context.Context, session *models.MCPSession) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE mcp_sessions SET
+			namespace = $1,
+			environment = $2,
+			cookie_jar = $3,
+			last_seen_at = $4,
+			expires_at = $5
+		WHERE id = $6
+	`,
+		session.Namespace,
+		session.Environment,
+		session.CookieJar,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		session.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a session
+func (r *PgMCPSessionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM mcp_sessions WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the sessions expired at now
+func (r *PgMCPSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM mcp_sessions WHERE expires_at <= $1
+	`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgUpstreamRepository is a PostgreSQL implementation of UpstreamRepository
+type PgUpstreamRepository struct {
+	db *sql.DB
+}
+
+// NewPgUpstreamRepository creates a new PostgreSQL-based upstream repository
+func NewPgUpstreamRepository(db *sql.DB) *PgUpstreamRepository {
+	return &PgUpstreamRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgUpstreamRepository) Initialize(ctx context.Context) error {
+	// Create upstreams table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS upstreams (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			targets JSONB,
+			health_check JSONB,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)
+	`)
+	return err
+}
+
+// scanUpstream scans a single upstream row
+func scanUpstream(scanner interface{ Scan(...interface{}) error }) (*models.Upstream, error) {
+	var upstream models.Upstream
+	var targetsJSON, healthCheckJSON []byte
+
+	err := scanner.Scan(
+		&upstream.ID,
+		&upstream.Name,
+		&upstream.Description,
+		&targetsJSON,
+		&healthCheckJSON,
+		&upstream.CreatedAt,
+		&upstream.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal targets
+	if err := json.Unmarshal(targetsJSON, &upstream.Targets); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal health check
+	if err := json.Unmarshal(healthCheckJSON, &upstream.HealthCheck); err != nil {
+		return nil, err
+	}
+
+	return &upstream, nil
+}
+
+// GetAll returns all upstreams
+func (r *PgUpstreamRepository) GetAll(ctx context.Context) ([]models.Upstream, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, targets, health_check, created_at, updated_at
+		FROM upstreams
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var upstreams []models.Upstream
+	for rows.Next() {
+		upstream, err := scanUpstream(rows)
+		if err != nil {
+			return nil, err
+		}
+		upstreams = append(upstreams, *upstream)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return upstreams, nil
+}
+
+// GetByID returns a specific upstream by ID
+func (r *PgUpstreamRepository) GetByID(ctx context.Context, id string) (*models.Upstream, error) {
+	upstream, err := scanUpstream(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, targets, health_check, created_at, updated_at
+		FROM upstreams
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return upstream, err
+}
+
+// GetByName returns a specific upstream by name
+func (r *PgUpstreamRepository) GetByName(ctx context.Context, name string) (*models.Upstream, error) {
+	upstream, err := scanUpstream(r.db.QueryRowContext(ctx, `
+		SELECT id, name, description, targets, health_check, created_at, updated_at
+		FROM upstreams
+		WHERE name = $1
+	`, name))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return upstream, err
+}
+
+// Create creates a new upstream
+func (r *PgUpstreamRepository) Create(ctx context.Context, upstream *models.Upstream) error {
+	// Generate ID if not provided
+	if upstream.ID == "" {
+		upstream.ID = fmt.Sprintf("upstream-%s", uuid.New().String())
+	}
+
+	now := time.Now()
+	upstream.CreatedAt = now
+	upstream.UpdatedAt = now
+
+	// Serialize complex types to JSON
+	targetsJSON, err := json.Marshal(upstream.Targets)
+	if err != nil {
+		return err
+	}
+
+	healthCheckJSON, err := json.Marshal(upstream.HealthCheck)
+	if err != nil {
+		return err
+	}
+
+	// Insert the upstream
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO upstreams (
+			id, name, description, targets, health_check, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		upstream.ID,
+		upstream.Name,
+		upstream.Description,
+		targetsJSON,
+		healthCheckJSON,
+		upstream.CreatedAt,
+		upstream.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update updates an existing upstream
+func (r *PgUpstreamRepository) Update(ctx context.Context, upstream *models.Upstream) error {
+	upstream.UpdatedAt = time.Now()
+
+	// Serialize complex types to JSON
+	targetsJSON, err := json.Marshal(upstream.Targets)
+	if err != nil {
+		return err
+	}
+
+	healthCheckJSON, err := json.Marshal(upstream.HealthCheck)
+	if err != nil {
+		return err
+	}
+
+	// Update the upstream
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE upstreams SET
+			name = $1,
+			description = $2,
+			targets = $3,
+			health_check = $4,
+			updated_at = $5
+		WHERE id = $6
+	`,
+		upstream.Name,
+		upstream.Description,
+		targetsJSON,
+		healthCheckJSON,
+		upstream.UpdatedAt,
+		upstream.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an upstream
+func (r *PgUpstreamRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM upstreams WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// PgWasmFileRepository is a PostgreSQL implementation of WasmFileRepository
+type PgWasmFileRepository struct {
+	db *sql.DB
+}
+
+// NewPgWasmFileRepository creates a new PostgreSQL-based WASM file repository
+func NewPgWasmFileRepository(db *sql.DB) *PgWasmFileRepository {
+	return &PgWasmFileRepository{
+		db: db,
+	}
+}
+
+// Initialize creates the necessary tables if they don't exist
+func (r *PgWasmFileRepository) Initialize(ctx context.Context) error {
+	// Create wasm_files table
+	_, err := r.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS wasm_files (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			server_id TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			checksum TEXT NOT NULL,
+			size BIGINT NOT NULL,
+			path TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			UNIQUE (server_id, name, version)
+		)
+	`)
+	return err
+}
+
+// scanWasmFile scans a single WASM file row
+func scanWasmFile(scanner interface{ Scan(...interface{}) error }) (*models.WasmFile, error) {
+	var wasmFile models.WasmFile
+
+	err := scanner.Scan(
+		&wasmFile.ID,
+		&wasmFile.Name,
+		&wasmFile.ServerID,
+		&wasmFile.Version,
+		&wasmFile.Checksum,
+		&wasmFile.Size,
+		&wasmFile.Path,
+		&wasmFile.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmFile, nil
+}
+
+// Create stores new WASM file metadata with the next version for its name and owner
+func (r *PgWasmFileRepository) Create(ctx context.Context, wasmFile *models.WasmFile) error {
+	// Generate ID if not provided
+	if wasmFile.ID == "" {
+		wasmFile.ID = fmt.Sprintf("wasm-%s", uuid.New().String())
+	}
+	wasmFile.CreatedAt = time.Now()
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO wasm_files (id, name, server_id, version, checksum, size, path, created_at)
+		SELECT $1, $2, $3, COALESCE(MAX(version), 0) + 1, $4, $5, $6, $7
+		FROM wasm_files
+		WHERE server_id = $3 AND name = $2
+		RETURNING version
+	`,
+		wasmFile.ID,
+		wasmFile.Name,
+		wasmFile.ServerID,
+		wasmFile.Checksum,
+		wasmFile.Size,
+		wasmFile.Path,
+		wasmFile.CreatedAt,
+	).Scan(&wasmFile.Version)
+}
+
+// GetByID returns specific WASM file metadata by ID
+func (r *PgWasmFileRepository) GetByID(ctx context.Context, id string) (*models.WasmFile, error) {
+	wasmFile, err := scanWasmFile(r.db.QueryRowContext(ctx, `
+		SELECT id, name, server_id, version, checksum, size, path, created_at
+		FROM wasm_files
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return wasmFile, err
+}
+
+// GetAll returns all WASM files, newest first, optionally restricted to one server
+func (r *PgWasmFileRepository) GetAll(ctx context.Context, serverID string) ([]models.WasmFile, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, server_id, version, checksum, size, path, created_at
+		FROM wasm_files
+		WHERE $1 = '' OR server_id = $1
+		ORDER BY created_at DESC
+	`, serverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	files := []models.WasmFile{}
+	for rows.Next() {
+		wasmFile, err := scanWasmFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, *wasmFile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// Delete removes WASM file metadata
+func (r *PgWasmFileRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM wasm_files WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
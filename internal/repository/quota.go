@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// ErrQuotaExceeded is returned when a tenant has used up one of its quotas
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// limitOf returns a limit of the quota of tenant, 0 if the tenant has no quota
+func limitOf(ctx context.Context, quotas QuotaRepository, tenant string, limit func(*models.Quota) int) (int, error) {
+	quota, err := quotas.Get(ctx, tenant)
+	if err == ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return limit(quota), nil
+}
+
+type createLimitKey struct{}
+
+type createLimitValue struct {
+	kind  string
+	limit int
+}
+
+// withCreateLimit returns a copy of ctx in which creating an entity of kind fails with
+// ErrQuotaExceeded when its namespace already holds limit entities of the kind, none if limit is 0.
+// The repository counts and inserts atomically, so that concurrent creations cannot exceed it.
+func withCreateLimit(ctx context.Context, kind string, limit int) context.Context {
+	return context.WithValue(ctx, createLimitKey{}, createLimitValue{kind: kind, limit: limit})
+}
+
+// createLimit returns the number of entities of kind ctx limits their namespace to, 0 if any
+func createLimit(ctx context.Context, kind string) int {
+	limit, _ := ctx.Value(createLimitKey{}).(createLimitValue)
+	if limit.kind != kind {
+		return 0
+	}
+	return limit.limit
+}
+
+// QuotaHTTPInterfaceRepository rejects HTTP interfaces beyond the maxInterfaces quota of their namespace.
+// It must be wrapped by the namespaced repository, which assigns the namespace.
+type QuotaHTTPInterfaceRepository struct {
+	HTTPInterfaceRepository
+	quotas QuotaRepository
+}
+
+// NewQuotaHTTPInterfaceRepository wraps an HTTP interface repository with the tenant quotas
+func NewQuotaHTTPInterfaceRepository(next HTTPInterfaceRepository, quotas QuotaRepository) *QuotaHTTPInterfaceRepository {
+	return &QuotaHTTPInterfaceRepository{HTTPInterfaceRepository: next, quotas: quotas}
+}
+
+func (r *QuotaHTTPInterfaceRepository) Create(ctx context.Context, httpInterface *models.HTTPInterface) error {
+	tenant := namespace.OrDefault(httpInterface.Namespace)
+	limit, err := limitOf(ctx, r.quotas, tenant, func(quota *models.Quota) int { return quota.MaxInterfaces })
+	if err != nil {
+		return err
+	}
+	err = r.HTTPInterfaceRepository.Create(withCreateLimit(ctx, "HTTP interface", limit), httpInterface)
+	if errors.Is(err, ErrQuotaExceeded) {
+		return fmt.Errorf("%w: tenant %s is limited to %d HTTP interfaces", ErrQuotaExceeded, tenant, limit)
+	}
+	return err
+}
+
+// QuotaMCPServerRepository rejects MCP servers beyond the maxServers quota of their namespace.
+// It must be wrapped by the namespaced repository, which assigns the namespace.
+type QuotaMCPServerRepository struct {
+	MCPServerRepository
+	quotas QuotaRepository
+}
+
+// NewQuotaMCPServerRepository wraps an MCP server repository with the tenant quotas
+func NewQuotaMCPServerRepository(next MCPServerRepository, quotas QuotaRepository) *QuotaMCPServerRepository {
+	return &QuotaMCPServerRepository{MCPServerRepository: next, quotas: quotas}
+}
+
+func (r *QuotaMCPServerRepository) Create(ctx context.Context, mcpServer *models.MCPServer) error {
+	tenant := namespace.OrDefault(mcpServer.Namespace)
+	limit, err := limitOf(ctx, r.quotas, tenant, func(quota *models.Quota) int { return quota.MaxServers })
+	if err != nil {
+		return err
+	}
+	err = r.MCPServerRepository.Create(withCreateLimit(ctx, "MCP server", limit), mcpServer)
+	if errors.Is(err, ErrQuotaExceeded) {
+		return fmt.Errorf("%w: tenant %s is limited to %d MCP servers", ErrQuotaExceeded, tenant, limit)
+	}
+	return err
+}
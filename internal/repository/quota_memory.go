@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryQuotaRepository implements QuotaRepository using an in-memory store
+type InMemoryQuotaRepository struct {
+	mu        sync.Mutex
+	quotas    map[string]models.Quota
+	toolCalls map[string]int // Tool calls by tenant and day
+}
+
+// NewInMemoryQuotaRepository creates a new in-memory quota repository
+func NewInMemoryQuotaRepository() *InMemoryQuotaRepository {
+	return &InMemoryQuotaRepository{
+		quotas:    make(map[string]models.Quota),
+		toolCalls: make(map[string]int),
+	}
+}
+
+// Get retrieves the quota of a tenant
+func (r *InMemoryQuotaRepository) Get(ctx context.Context, tenant string) (*models.Quota, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quota, ok := r.quotas[tenant]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &quota, nil
+}
+
+// GetAll retrieves all quotas ordered by tenant
+func (r *InMemoryQuotaRepository) GetAll(ctx context.Context) ([]models.Quota, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quotas := make([]models.Quota, 0, len(r.quotas))
+	for _, quota := range r.quotas {
+		quotas = append(quotas, quota)
+	}
+
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Tenant < quotas[j].Tenant
+	})
+
+	return quotas, nil
+}
+
+// Set creates or replaces the quota of a tenant
+func (r *InMemoryQuotaRepository) Set(ctx context.Context, quota *models.Quota) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quota.UpdatedAt = time.Now()
+	r.quotas[quota.Tenant] = *quota
+
+	return nil
+}
+
+// Delete removes the quota of a tenant
+func (r *InMemoryQuotaRepository) Delete(ctx context.Context, tenant string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.quotas[tenant]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.quotas, tenant)
+
+	return nil
+}
+
+// CountToolCall counts a tool call of the tenant on day unless the limit is reached
+func (r *InMemoryQuotaRepository) CountToolCall(ctx context.Context, tenant string, day string, limit int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tenant + "/" + day
+	if limit > 0 && r.toolCalls[key] >= limit {
+		return false, nil
+	}
+	r.toolCalls[key]++
+
+	return true, nil
+}
+
+// ToolCalls returns the tool calls counted for the tenant on day
+func (r *InMemoryQuotaRepository) ToolCalls(ctx context.Context, tenant string, day string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.toolCalls[tenant+"/"+day], nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SubmitRevision stores a change of a server as a pending revision of its current version, to be
+// applied once approved. The note of the change in ctx is recorded on the version the approval
+// creates.
+func SubmitRevision(ctx context.Context, revisions RevisionRepository, current *models.MCPServer, proposed *models.MCPServer, change string) (*models.Revision, error) {
+	proposed.Changelog = ChangeNoteFromContext(ctx)
+	revision := models.Revision{
+		ServerID:    current.ID,
+		ServerName:  current.Name,
+		Namespace:   current.Namespace,
+		Change:      change,
+		BaseVersion: current.Version,
+		Server:      *proposed,
+	}
+	if err := revisions.Create(ctx, &revision); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Submitted MCP server revision", "id", revision.ID, "server", current.ID, "change", change)
+	return &revision, nil
+}
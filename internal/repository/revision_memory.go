@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrRevisionReviewed is returned when a revision was already approved or rejected
+var ErrRevisionReviewed = errors.New("revision was already reviewed")
+
+// InMemoryRevisionRepository implements RevisionRepository using an in-memory store
+type InMemoryRevisionRepository struct {
+	mu        sync.RWMutex
+	revisions map[string]models.Revision
+	idCounter int
+}
+
+// NewInMemoryRevisionRepository creates a new in-memory revision repository
+func NewInMemoryRevisionRepository() *InMemoryRevisionRepository {
+	return &InMemoryRevisionRepository{
+		revisions: make(map[string]models.Revision),
+	}
+}
+
+// Create stores a new pending revision
+func (r *InMemoryRevisionRepository) Create(ctx context.Context, revision *models.Revision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	revision.ID = generateID("revision", r.idCounter)
+	revision.Status = models.RevisionPending
+	revision.SubmittedAt = time.Now()
+
+	r.revisions[revision.ID] = cloneRevision(*revision)
+
+	return nil
+}
+
+// GetByID retrieves a revision by ID
+func (r *InMemoryRevisionRepository) GetByID(ctx context.Context, id string) (*models.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revision, ok := r.revisions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	clone := cloneRevision(revision)
+	return &clone, nil
+}
+
+// List returns the matching revisions, newest first
+func (r *InMemoryRevisionRepository) List(ctx context.Context, serverID string, status string) ([]models.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := []models.Revision{}
+	for _, revision := range r.revisions {
+		if serverID != "" && revision.ServerID != serverID {
+			continue
+		}
+		if status != "" && revision.Status != status {
+			continue
+		}
+		revisions = append(revisions, cloneRevision(revision))
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].SubmittedAt.After(revisions[j].SubmittedAt)
+	})
+
+	return revisions, nil
+}
+
+// Review records the status, comment and resulting version of a pending revision
+func (r *InMemoryRevisionRepository) Review(ctx context.Context, revision *models.Revision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.revisions[revision.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	if existing.Status != models.RevisionPending {
+		return ErrRevisionReviewed
+	}
+
+	now := time.Now()
+	existing.Status = revision.Status
+	existing.Comment = revision.Comment
+	existing.Version = revision.Version
+	existing.ReviewedAt = &now
+	r.revisions[revision.ID] = existing
+
+	*revision = cloneRevision(existing)
+	return nil
+}
+
+// cloneRevision copies the proposed server so callers cannot modify the stored revision
+func cloneRevision(revision models.Revision) models.Revision {
+	revision.Server = *cloneMCPServer(&revision.Server)
+	return revision
+}
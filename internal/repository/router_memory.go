@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryRouterRepository implements RouterRepository using an in-memory store
+type InMemoryRouterRepository struct {
+	mu        sync.RWMutex
+	routers   map[string]*models.Router
+	versions  map[string]map[int]*models.Router
+	idCounter int
+}
+
+// NewInMemoryRouterRepository creates a new in-memory router repository
+func NewInMemoryRouterRepository() *InMemoryRouterRepository {
+	return &InMemoryRouterRepository{
+		routers:   make(map[string]*models.Router),
+		versions:  make(map[string]map[int]*models.Router),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new router to the repository
+func (r *InMemoryRouterRepository) Create(ctx context.Context, router *models.Router) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	router.ID = generateID("router", r.idCounter)
+	router.CreatedAt = time.Now()
+	router.UpdatedAt = time.Now()
+	router.Version = 1
+
+	r.routers[router.ID] = cloneRouter(router)
+
+	// Store version
+	if _, ok := r.versions[router.ID]; !ok {
+		r.versions[router.ID] = make(map[int]*models.Router)
+	}
+	r.versions[router.ID][router.Version] = cloneRouter(router)
+
+	return nil
+}
+
+// GetByID retrieves a router by ID
+func (r *InMemoryRouterRepository) GetByID(ctx context.Context, id string) (*models.Router, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	router, ok := r.routers[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return cloneRouter(router), nil
+}
+
+// GetAll retrieves all routers
+func (r *InMemoryRouterRepository) GetAll(ctx context.Context) ([]models.Router, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routers := make([]models.Router, 0, len(r.routers))
+	for _, router := range r.routers {
+		routers = append(routers, *cloneRouter(router))
+	}
+
+	return routers, nil
+}
+
+// Update updates a router
+func (r *InMemoryRouterRepository) Update(ctx context.Context, router *models.Router) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.routers[router.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	// Increment version
+	router.Version = existing.Version + 1
+	router.UpdatedAt = time.Now()
+	router.CreatedAt = existing.CreatedAt
+
+	r.routers[router.ID] = cloneRouter(router)
+
+	// Store version
+	if _, ok := r.versions[router.ID]; !ok {
+		r.versions[router.ID] = make(map[int]*models.Router)
+	}
+	r.versions[router.ID][router.Version] = cloneRouter(router)
+
+	return nil
+}
+
+// Delete removes a router
+func (r *InMemoryRouterRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.routers[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.routers, id)
+	delete(r.versions, id)
+
+	return nil
+}
+
+// GetVersions retrieves all version numbers for a router
+func (r *InMemoryRouterRepository) GetVersions(ctx context.Context, id string) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.versions[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	versions := make([]int, 0, len(r.versions[id]))
+	for v := range r.versions[id] {
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// GetByVersion retrieves a specific version of a router
+func (r *InMemoryRouterRepository) GetByVersion(ctx context.Context, id string, version int) (*models.Router, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.versions[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	router, ok := r.versions[id][version]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return cloneRouter(router), nil
+}
+
+// UpdateStatus updates the status of a router
+func (r *InMemoryRouterRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	router, ok := r.routers[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	router.Status = status
+	router.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Helper function to clone a router
+func cloneRouter(router *models.Router) *models.Router {
+	clone := *router
+	clone.Rules = make([]models.Rule, len(router.Rules))
+	for i, rule := range router.Rules {
+		cloneRule := rule
+		if rule.Conditions != nil {
+			cloneRule.Conditions = make([]models.Condition, len(rule.Conditions))
+			copy(cloneRule.Conditions, rule.Conditions)
+		}
+		if rule.Rewrite != nil {
+			rewrite := *rule.Rewrite
+			cloneRule.Rewrite = &rewrite
+		}
+		clone.Rules[i] = cloneRule
+	}
+	return &clone
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemorySecretRepository implements SecretRepository using an in-memory store
+type InMemorySecretRepository struct {
+	mu        sync.RWMutex
+	secrets   map[string]models.Secret
+	idCounter int
+}
+
+// NewInMemorySecretRepository creates a new in-memory secret repository
+func NewInMemorySecretRepository() *InMemorySecretRepository {
+	return &InMemorySecretRepository{
+		secrets:   make(map[string]models.Secret),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new secret to the repository
+func (r *InMemorySecretRepository) Create(ctx context.Context, secret *models.Secret) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	secret.ID = generateID("secret", r.idCounter)
+	secret.CreatedAt = time.Now()
+	secret.UpdatedAt = time.Now()
+
+	r.secrets[secret.ID] = *secret
+
+	return nil
+}
+
+// GetByID retrieves a secret by ID
+func (r *InMemorySecretRepository) GetByID(ctx context.Context, id string) (*models.Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	secret, ok := r.secrets[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &secret, nil
+}
+
+// GetByName retrieves a secret by name
+func (r *InMemorySecretRepository) GetByName(ctx context.Context, name string) (*models.Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, secret := range r.secrets {
+		if secret.Name == name {
+			return &secret, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// GetAll retrieves all secrets ordered by name
+func (r *InMemorySecretRepository) GetAll(ctx context.Context) ([]models.Secret, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	secrets := make([]models.Secret, 0, len(r.secrets))
+	for _, secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	return secrets, nil
+}
+
+// Update updates a secret
+func (r *InMemorySecretRepository) Update(ctx context.Context, secret *models.Secret) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.secrets[secret.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	secret.CreatedAt = existing.CreatedAt
+	secret.UpdatedAt = time.Now()
+
+	r.secrets[secret.ID] = *secret
+
+	return nil
+}
+
+// Delete removes a secret
+func (r *InMemorySecretRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.secrets[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.secrets, id)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryServerAliasRepository implements ServerAliasRepository using an in-memory store
+type InMemoryServerAliasRepository struct {
+	mu      sync.RWMutex
+	aliases map[string]models.ServerAlias // By namespace and name
+}
+
+// NewInMemoryServerAliasRepository creates a new in-memory server alias repository
+func NewInMemoryServerAliasRepository() *InMemoryServerAliasRepository {
+	return &InMemoryServerAliasRepository{
+		aliases: make(map[string]models.ServerAlias),
+	}
+}
+
+// Set creates or replaces an alias
+func (r *InMemoryServerAliasRepository) Set(ctx context.Context, alias *models.ServerAlias) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alias.CreatedAt = time.Now()
+	r.aliases[nameKey(alias.Namespace, alias.Name)] = *alias
+
+	return nil
+}
+
+// Get retrieves the alias of a name in a namespace
+func (r *InMemoryServerAliasRepository) Get(ctx context.Context, namespace string, name string) (*models.ServerAlias, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alias, ok := r.aliases[nameKey(namespace, name)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &alias, nil
+}
+
+// GetByServer retrieves the aliases of a server, newest first
+func (r *InMemoryServerAliasRepository) GetByServer(ctx context.Context, serverID string) ([]models.ServerAlias, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aliases := []models.ServerAlias{}
+	for _, alias := range r.aliases {
+		if alias.ServerID == serverID {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].CreatedAt.After(aliases[j].CreatedAt)
+	})
+
+	return aliases, nil
+}
+
+// Delete removes an alias
+func (r *InMemoryServerAliasRepository) Delete(ctx context.Context, namespace string, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := nameKey(namespace, name)
+	if _, ok := r.aliases[key]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.aliases, key)
+
+	return nil
+}
+
+// DeleteByServer removes the aliases of a server
+func (r *InMemoryServerAliasRepository) DeleteByServer(ctx context.Context, serverID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, alias := range r.aliases {
+		if alias.ServerID == serverID {
+			delete(r.aliases, key)
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the aliases expired at now
+func (r *InMemoryServerAliasRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for key, alias := range r.aliases {
+		if alias.Expired(now) {
+			delete(r.aliases, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryMCPSessionRepository implements MCPSessionRepository using an in-memory store
+type InMemoryMCPSessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]models.MCPSession
+}
+
+// NewInMemoryMCPSessionRepository creates a new in-memory session repository
+func NewInMemoryMCPSessionRepository() *InMemoryMCPSessionRepository {
+	return &InMemoryMCPSessionRepository{
+		sessions: make(map[string]models.MCPSession),
+	}
+}
+
+// Create adds a new session to the repository
+func (r *InMemoryMCPSessionRepository) Create(ctx context.Context, session *models.MCPSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Session IDs select the state of a client, so they are random rather than counted
+	if session.ID == "" {
+		session.ID = fmt.Sprintf("session-%s", uuid.New().String())
+	}
+	session.CreatedAt = time.Now()
+
+	r.sessions[session.ID] = *session
+
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *InMemoryMCPSessionRepository) GetByID(ctx context.Context, id string) (*models.MCPSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &session, nil
+}
+
+// GetAll retrieves the sessions, or those of a server, newest first
+func (r *InMemoryMCPSessionRepository) GetAll(ctx context.Context, serverID string) ([]models.MCPSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := make([]models.MCPSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		if serverID == "" || session.ServerID == serverID {
+			sessions = append(sessions, session)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Update updates a session
+func (r *InMemoryMCPSessionRepository) Update(ctx context.Context, session *models.MCPSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.sessions[session.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	session.CreatedAt = existing.CreatedAt
+	r.sessions[session.ID] = *session
+
+	return nil
+}
+
+// Delete removes a session
+func (r *InMemoryMCPSessionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.sessions, id)
+
+	return nil
+}
+
+// DeleteExpired removes the sessions expired at now
+func (r *InMemoryMCPSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, session := range r.sessions {
+		if session.Expired(now) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryUpstreamRepository implements UpstreamRepository using an in-memory store
+type InMemoryUpstreamRepository struct {
+	mu        sync.RWMutex
+	upstreams map[string]*models.Upstream
+	idCounter int
+}
+
+// NewInMemoryUpstreamRepository creates a new in-memory upstream repository
+func NewInMemoryUpstreamRepository() *InMemoryUpstreamRepository {
+	return &InMemoryUpstreamRepository{
+		upstreams: make(map[string]*models.Upstream),
+		idCounter: 0,
+	}
+}
+
+// Create adds a new upstream to the repository
+func (r *InMemoryUpstreamRepository) Create(ctx context.Context, upstream *models.Upstream) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idCounter++
+	upstream.ID = generateID("upstream", r.idCounter)
+	upstream.CreatedAt = time.Now()
+	upstream.UpdatedAt = time.Now()
+
+	r.upstreams[upstream.ID] = cloneUpstream(upstream)
+
+	return nil
+}
+
+// GetByID retrieves an upstream by ID
+func (r *InMemoryUpstreamRepository) GetByID(ctx context.Context, id string) (*models.Upstream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	upstream, ok := r.upstreams[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return cloneUpstream(upstream), nil
+}
+
+// GetByName retrieves an upstream by name
+func (r *InMemoryUpstreamRepository) GetByName(ctx context.Context, name string) (*models.Upstream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, upstream := range r.upstreams {
+		if upstream.Name == name {
+			return cloneUpstream(upstream), nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// GetAll retrieves all upstreams
+func (r *InMemoryUpstreamRepository) GetAll(ctx context.Context) ([]models.Upstream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	upstreams := make([]models.Upstream, 0, len(r.upstreams))
+	for _, upstream := range r.upstreams {
+		upstreams = append(upstreams, *cloneUpstream(upstream))
+	}
+
+	return upstreams, nil
+}
+
+// Update updates an upstream
+func (r *InMemoryUpstreamRepository) Update(ctx context.Context, upstream *models.Upstream) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.upstreams[upstream.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	upstream.CreatedAt = existing.CreatedAt
+	upstream.UpdatedAt = time.Now()
+
+	r.upstreams[upstream.ID] = cloneUpstream(upstream)
+
+	return nil
+}
+
+// Delete removes an upstream
+func (r *InMemoryUpstreamRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.upstreams[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.upstreams, id)
+
+	return nil
+}
+
+// Helper function to clone an upstream
+func cloneUpstream(upstream *models.Upstream) *models.Upstream {
+	clone := *upstream
+	clone.Targets = make([]string, len(upstream.Targets))
+	copy(clone.Targets, upstream.Targets)
+	return &clone
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// InMemoryWasmFileRepository implements WasmFileRepository using an in-memory store
+type InMemoryWasmFileRepository struct {
+	mu        sync.RWMutex
+	files     map[string]models.WasmFile
+	idCounter int
+}
+
+// NewInMemoryWasmFileRepository creates a new in-memory WASM file repository
+func NewInMemoryWasmFileRepository() *InMemoryWasmFileRepository {
+	return &InMemoryWasmFileRepository{
+		files:     make(map[string]models.WasmFile),
+		idCounter: 0,
+	}
+}
+
+// Create adds new WASM file metadata to the repository
+func (r *InMemoryWasmFileRepository) Create(ctx context.Context, wasmFile *models.WasmFile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Next version for the same name and owner
+	wasmFile.Version = 1
+	for _, existing := range r.files {
+		if existing.Name == wasmFile.Name && existing.ServerID == wasmFile.ServerID && existing.Version >= wasmFile.Version {
+			wasmFile.Version = existing.Version + 1
+		}
+	}
+
+	if wasmFile.ID == "" {
+		r.idCounter++
+		wasmFile.ID = generateID("wasm", r.idCounter)
+	}
+	wasmFile.CreatedAt = time.Now()
+
+	r.files[wasmFile.ID] = *wasmFile
+
+	return nil
+}
+
+// GetByID retrieves WASM file metadata by ID
+func (r *InMemoryWasmFileRepository) GetByID(ctx context.Context, id string) (*models.WasmFile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wasmFile, ok := r.files[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &wasmFile, nil
+}
+
+// GetAll retrieves all WASM files, newest first, optionally restricted to one server
+func (r *InMemoryWasmFileRepository) GetAll(ctx context.Context, serverID string) ([]models.WasmFile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	files := make([]models.WasmFile, 0, len(r.files))
+	for _, wasmFile := range r.files {
+		if serverID == "" || wasmFile.ServerID == serverID {
+			files = append(files, wasmFile)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].CreatedAt.After(files[j].CreatedAt)
+	})
+
+	return files, nil
+}
+
+// Delete removes WASM file metadata
+func (r *InMemoryWasmFileRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.files[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.files, id)
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/upstream"
+)
+
+const (
+	// evaluationInterval is the time between two evaluations of the tool error rates
+	evaluationInterval = 30 * time.Second
+
+	alertTypeToolErrorRate  = "tool_error_rate"
+	alertTypeUpstreamHealth = "upstream_health"
+	alertTypeTest           = "test"
+
+	statusFiring   = "firing"
+	statusResolved = "resolved"
+)
+
+// alertState tracks the notifications sent to one webhook for one alert
+type alertState struct {
+	firing    bool // A firing notification was sent and not resolved yet
+	lastFired time.Time
+	alert     models.Alert
+}
+
+// Alerter notifies alert webhooks when tool error rates or upstream health cross their thresholds.
+// A firing alert is sent at most once per cooldown period and per webhook, and is resolved once
+// the condition clears.
+type Alerter struct {
+	webhooks    repository.AlertWebhookRepository
+	invocations repository.InvocationRepository
+	httpClient  *http.Client
+	mu          sync.Mutex
+	states      map[string]*alertState // keyed by webhook ID and alert key
+	cancel      context.CancelFunc
+}
+
+// NewAlerter creates a new alerter
+func NewAlerter(webhooks repository.AlertWebhookRepository, invocations repository.InvocationRepository) *Alerter {
+	return &Alerter{
+		webhooks:    webhooks,
+		invocations: invocations,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		states:      make(map[string]*alertState),
+	}
+}
+
+// Start evaluates the tool error rates periodically until Stop is called
+func (a *Alerter) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(evaluationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.evaluate(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic evaluation
+func (a *Alerter) Stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+}
+
+// HandleHealthEvent notifies webhooks subscribed to upstream health of a target state change
+func (a *Alerter) HandleHealthEvent(event upstream.HealthEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	webhooks, err := a.webhooks.GetAll(ctx)
+	if err != nil {
+		slog.Error("Failed to get alert webhooks", "error", err)
+		return
+	}
+
+	alert := models.Alert{
+		Type:      alertTypeUpstreamHealth,
+		Status:    statusFiring,
+		Summary:   fmt.Sprintf("Upstream %s target %s is unhealthy: %s", event.Upstream, event.Target, event.Error),
+		Labels:    map[string]string{"upstream": event.Upstream, "target": event.Target},
+		Timestamp: event.Time,
+	}
+	if event.Healthy {
+		alert.Status = statusResolved
+		alert.Summary = fmt.Sprintf("Upstream %s target %s recovered", event.Upstream, event.Target)
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Enabled && webhook.UpstreamHealth {
+			a.dispatch(ctx, webhook.WithDefaults(), alertTypeUpstreamHealth+":"+event.Upstream+"/"+event.Target, alert)
+		}
+	}
+}
+
+// evaluate checks the tool error rates against the thresholds of all webhooks
+func (a *Alerter) evaluate(ctx context.Context) {
+	webhooks, err := a.webhooks.GetAll(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get alert webhooks", "error", err)
+		return
+	}
+
+	now := time.Now()
+	statsByWindow := make(map[int][]models.UsageStats)
+
+	for _, webhook := range webhooks {
+		webhook = webhook.WithDefaults()
+		if !webhook.Enabled || webhook.ErrorRateThreshold <= 0 {
+			continue
+		}
+
+		// Webhooks sharing a window share the query
+		stats, ok := statsByWindow[webhook.WindowSeconds]
+		if !ok {
+			filter := repository.InvocationFilter{
+				Since: now.Add(-time.Duration(webhook.WindowSeconds) * time.Second),
+				Until: now,
+			}
+			stats, err = a.invocations.Stats(ctx, filter, repository.StatsGroupByTool)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to compute tool statistics", "error", err)
+				return
+			}
+			statsByWindow[webhook.WindowSeconds] = stats
+		}
+
+		firing := make(map[string]bool)
+		for _, toolStats := range stats {
+			if toolStats.Calls < webhook.MinCalls || toolStats.ErrorRate < webhook.ErrorRateThreshold {
+				continue
+			}
+
+			key := alertTypeToolErrorRate + ":" + toolStats.ServerID + "/" + toolStats.Tool
+			firing[key] = true
+			a.dispatch(ctx, webhook, key, models.Alert{
+				Type:   alertTypeToolErrorRate,
+				Status: statusFiring,
+				Summary: fmt.Sprintf("Tool %s of MCP server %s failed %d of %d calls (%.0f%%) in the last %s",
+					toolStats.Tool, toolStats.ServerName, toolStats.Errors, toolStats.Calls, toolStats.ErrorRate*100,
+					time.Duration(webhook.WindowSeconds)*time.Second),
+				Labels:    map[string]string{"serverId": toolStats.ServerID, "server": toolStats.ServerName, "tool": toolStats.Tool},
+				Value:     toolStats.ErrorRate,
+				Threshold: webhook.ErrorRateThreshold,
+				Timestamp: now,
+			})
+		}
+
+		// Resolve the alerts of this webhook whose condition cleared
+		for _, resolved := range a.clearedAlerts(webhook.ID, alertTypeToolErrorRate, firing) {
+			resolved.alert.Status = statusResolved
+			resolved.alert.Summary = fmt.Sprintf("Tool %s of MCP server %s error rate is back below %.0f%%",
+				resolved.alert.Labels["tool"], resolved.alert.Labels["server"], webhook.ErrorRateThreshold*100)
+			resolved.alert.Timestamp = now
+			a.dispatch(ctx, webhook, resolved.key, resolved.alert)
+		}
+	}
+}
+
+type clearedAlert struct {
+	key   string
+	alert models.Alert
+}
+
+// clearedAlerts returns the firing alerts of a webhook and type that are not firing anymore
+func (a *Alerter) clearedAlerts(webhookID string, alertType string, firing map[string]bool) []clearedAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prefix := webhookID + "|" + alertType + ":"
+	var cleared []clearedAlert
+	for stateKey, state := range a.states {
+		key := strings.TrimPrefix(stateKey, webhookID+"|")
+		if state.firing && strings.HasPrefix(stateKey, prefix) && !firing[key] {
+			cleared = append(cleared, clearedAlert{key: key, alert: state.alert})
+		}
+	}
+	return cleared
+}
+
+// dispatch sends an alert unless it is deduplicated: a firing alert is sent at most once per
+// cooldown, and a resolved alert only if its firing notification was sent
+func (a *Alerter) dispatch(ctx context.Context, webhook models.AlertWebhook, key string, alert models.Alert) {
+	a.mu.Lock()
+	stateKey := webhook.ID + "|" + key
+	state, ok := a.states[stateKey]
+	if !ok {
+		state = &alertState{}
+		a.states[stateKey] = state
+	}
+
+	switch alert.Status {
+	case statusFiring:
+		if time.Since(state.lastFired) < time.Duration(webhook.CooldownSeconds)*time.Second {
+			a.mu.Unlock()
+			return
+		}
+		state.firing = true
+		state.lastFired = time.Now()
+		state.alert = alert
+	case statusResolved:
+		if !state.firing {
+			a.mu.Unlock()
+			return
+		}
+		state.firing = false
+	}
+	a.mu.Unlock()
+
+	if err := a.Send(ctx, webhook, alert); err != nil {
+		slog.ErrorContext(ctx, "Failed to send alert", "webhook", webhook.Name, "alert", key, "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Alert sent", "webhook", webhook.Name, "alert", key, "status", alert.Status)
+}
+
+// Send posts an alert to a webhook in the webhook's format
+func (a *Alerter) Send(ctx context.Context, webhook models.AlertWebhook, alert models.Alert) error {
+	payload, err := Payload(webhook.Format, alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TestAlert returns the alert sent when testing a webhook
+func TestAlert() models.Alert {
+	return models.Alert{
+		Type:      alertTypeTest,
+		Status:    statusFiring,
+		Summary:   "Test notification from MCP Gateway",
+		Labels:    map[string]string{},
+		Timestamp: time.Now(),
+	}
+}
+
+// Payload renders an alert as JSON or as a Slack-compatible message
+func Payload(format string, alert models.Alert) ([]byte, error) {
+	switch format {
+	case "", "json":
+		return json.Marshal(alert)
+	case "slack":
+		icon := ":rotating_light:"
+		if alert.Status == statusResolved {
+			icon = ":white_check_mark:"
+		}
+		return json.Marshal(map[string]string{
+			"text": fmt.Sprintf("%s [%s] %s", icon, strings.ToUpper(alert.Status), alert.Summary),
+		})
+	default:
+		return nil, fmt.Errorf("unsupported webhook format '%s': must be json or slack", format)
+	}
+}
@@ -0,0 +1,98 @@
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/i18n"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+)
+
+// Codes of the errors of the HTTP statuses, returned unless the handler knows a more specific one
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeConflict           = "conflict"
+	CodePreconditionFailed = "precondition_failed"
+	CodePayloadTooLarge    = "payload_too_large"
+	CodeUnprocessable      = "unprocessable"
+	CodeRateLimited        = "rate_limited"
+	CodeInternal           = "internal"
+	CodeNotImplemented     = "not_implemented"
+	CodeBadGateway         = "bad_gateway"
+	CodeUnavailable        = "unavailable"
+	CodeTimeout            = "timeout"
+)
+
+// statusCodes are the codes of the HTTP statuses
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeInvalidRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusUnprocessableEntity:   CodeUnprocessable,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusNotImplemented:        CodeNotImplemented,
+	http.StatusBadGateway:            CodeBadGateway,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+	http.StatusGatewayTimeout:        CodeTimeout,
+}
+
+// Error describes a failed request
+type Error struct {
+	Code      string      `json:"code"`              // Machine-readable code, e.g. not_found or rate_limited
+	Message   string      `json:"message"`           // Human-readable message, in the language the client accepts
+	RequestID string      `json:"requestId"`         // ID of the request in the logs
+	Details   interface{} `json:"details,omitempty"` // Data on the error, depending on the code
+}
+
+// Response is the body of every error response
+type Response struct {
+	Error Error `json:"error"`
+}
+
+// StatusCode returns the code of an HTTP status
+func StatusCode(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
+
+// Respond aborts the request with an error of the code of the status
+func Respond(c *gin.Context, status int, message string) {
+	RespondDetails(c, status, "", message, nil)
+}
+
+// RespondCode aborts the request with an error of a code, the one of the status if empty
+func RespondCode(c *gin.Context, status int, code string, message string) {
+	RespondDetails(c, status, code, message, nil)
+}
+
+// RespondDetails aborts the request with an error of a code, the one of the status if empty,
+// and details. The message is translated into the language of the Accept-Language header.
+func RespondDetails(c *gin.Context, status int, code string, message string, details interface{}) {
+	if code == "" {
+		code = StatusCode(status)
+	}
+	language := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", language)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	c.AbortWithStatusJSON(status, Response{Error: Error{
+		Code:      code,
+		Message:   i18n.Translate(language, message),
+		RequestID: logging.RequestID(c),
+		Details:   details,
+	}})
+}
@@ -0,0 +1,255 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Backup files are named after the time of the backup, so that names sort by age
+const (
+	namePrefix = "gateway-"
+	nameSuffix = ".json"
+	nameTime   = "20060102T150405.000Z"
+)
+
+// retryDelay is the time before a failed scheduled backup is retried, unless the interval is shorter
+const retryDelay = 5 * time.Minute
+
+// Config configures the scheduled backups and their retention
+type Config struct {
+	Interval time.Duration // Time between backups
+	Keep     int           // Newest backups kept, 0 keeps all
+	MaxAge   time.Duration // Backups older are deleted, 0 keeps them
+}
+
+// Status is the outcome of the last backup
+type Status struct {
+	Destination string    `json:"destination"`
+	LastRun     time.Time `json:"lastRun,omitempty"`    // Start of the last backup
+	LastBackup  string    `json:"lastBackup,omitempty"` // Name of the last successful backup
+	Error       string    `json:"error,omitempty"`      // Why the last backup failed
+}
+
+// Scheduler periodically exports the bundle of the HTTP interfaces, MCP servers and routers of
+// every namespace to a store, deletes the backups past retention, and restores backups
+type Scheduler struct {
+	config     Config
+	store      Store
+	reconciler *gitops.Reconciler
+	status     Status
+	mu         sync.RWMutex
+	runMu      sync.Mutex // Serializes backups
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler writing to the store of destination
+func NewScheduler(config Config, destination string, store Store, reconciler *gitops.Reconciler) *Scheduler {
+	return &Scheduler{
+		config:     config,
+		store:      store,
+		reconciler: reconciler,
+		status:     Status{Destination: redactDestination(destination)},
+		done:       make(chan struct{}),
+	}
+}
+
+// Start backs up once the interval since the newest backup has elapsed, right away if there is
+// none, and then every interval until Stop is called
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		wait := s.untilDue(context.Background())
+		for {
+			timer := time.NewTimer(wait)
+			select {
+			case <-s.done:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			wait = s.config.Interval
+			if _, err := s.Backup(context.Background()); err != nil {
+				wait = min(s.config.Interval, retryDelay)
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic backups
+func (s *Scheduler) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// Status returns the outcome of the last backup
+func (s *Scheduler) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Backup exports the bundle to a new backup and deletes the backups past retention. Failures
+// are logged and recorded in the status.
+func (s *Scheduler) Backup(ctx context.Context) (Object, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	start := time.Now().UTC()
+	object, err := s.backup(ctx, start)
+
+	s.mu.Lock()
+	s.status.LastRun = start
+	s.status.Error = ""
+	if err != nil {
+		s.status.Error = err.Error()
+	} else {
+		s.status.LastBackup = object.Name
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Backup failed", "destination", s.status.Destination, "error", err)
+		return Object{}, err
+	}
+	slog.InfoContext(ctx, "Backup completed", "destination", s.status.Destination, "name", object.Name, "size", object.Size)
+
+	if err := s.applyRetention(ctx, start); err != nil {
+		slog.WarnContext(ctx, "Failed to delete old backups", "destination", s.status.Destination, "error", err)
+	}
+	return object, nil
+}
+
+// backup writes the backup of the time now
+func (s *Scheduler) backup(ctx context.Context, now time.Time) (Object, error) {
+	bundle, err := s.reconciler.Export(ctx)
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to export the configuration: %w", err)
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return Object{}, err
+	}
+
+	object := Object{Name: namePrefix + now.Format(nameTime) + nameSuffix, Size: int64(len(data)), Modified: now}
+	if err := s.store.Put(ctx, object.Name, data); err != nil {
+		return Object{}, err
+	}
+	return object, nil
+}
+
+// List returns the backups, newest first
+func (s *Scheduler) List(ctx context.Context) ([]Object, error) {
+	objects, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Object, 0, len(objects))
+	for _, object := range objects {
+		if _, ok := backupTime(object.Name); ok {
+			backups = append(backups, object)
+		}
+	}
+	slices.SortFunc(backups, func(a, b Object) int { return strings.Compare(b.Name, a.Name) })
+	return backups, nil
+}
+
+// Restore applies a backup like POST /api/apply: resources missing from the gateway are
+// recreated and changed ones reverted, and with prune the resources it does not list are
+// deleted. With dryRun, the changes are only returned.
+func (s *Scheduler) Restore(ctx context.Context, name string, prune bool, dryRun bool) ([]gitops.Change, error) {
+	if _, ok := backupTime(name); !ok {
+		return nil, ErrNotFound
+	}
+	data, err := s.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := gitops.ParseBundle(data)
+	if err != nil {
+		return nil, fmt.Errorf("backup %s: %w", name, err)
+	}
+
+	if dryRun {
+		return s.reconciler.Diff(ctx, bundle, prune)
+	}
+	slog.InfoContext(ctx, "Restoring backup", "name", name, "prune", prune)
+	note := &models.ChangeNote{Author: "backup", Message: "Restored backup " + name}
+	return s.reconciler.Apply(repository.WithDefaultChangeNote(ctx, note), bundle, prune)
+}
+
+// applyRetention deletes the backups beyond the newest Keep ones and those older than MaxAge,
+// never the newest backup
+func (s *Scheduler) applyRetention(ctx context.Context, now time.Time) error {
+	if s.config.Keep <= 0 && s.config.MaxAge <= 0 {
+		return nil
+	}
+	backups, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	for i, object := range backups {
+		if i == 0 {
+			continue
+		}
+		created, _ := backupTime(object.Name)
+		if (s.config.Keep > 0 && i >= s.config.Keep) || (s.config.MaxAge > 0 && now.Sub(created) > s.config.MaxAge) {
+			if err := s.store.Delete(ctx, object.Name); err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "Deleted old backup", "name", object.Name)
+		}
+	}
+	return nil
+}
+
+// untilDue returns the time until the next backup is due, zero if there is no backup yet or the
+// store cannot be listed
+func (s *Scheduler) untilDue(ctx context.Context) time.Duration {
+	backups, err := s.List(ctx)
+	if err != nil || len(backups) == 0 {
+		return 0
+	}
+	newest, _ := backupTime(backups[0].Name)
+	return max(0, time.Until(newest.Add(s.config.Interval)))
+}
+
+// backupTime returns the time of a backup name, false for names of other files
+func backupTime(name string) (time.Time, bool) {
+	value, ok := strings.CutPrefix(name, namePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	value, ok = strings.CutSuffix(value, nameSuffix)
+	if !ok {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(nameTime, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// redactDestination hides the credentials of a destination URL
+func redactDestination(destination string) string {
+	parsed, err := url.Parse(destination)
+	if err != nil || parsed.User == nil {
+		return destination
+	}
+	parsed.User = nil
+	return parsed.String()
+}
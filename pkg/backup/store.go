@@ -0,0 +1,143 @@
+// Package backup periodically exports the configuration bundle of the gateway to a local
+// directory or an S3 compatible bucket, and restores it.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned for backups that do not exist
+var ErrNotFound = errors.New("backup not found")
+
+// Object is a backup file in a store
+type Object struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// Store keeps backup files by name
+type Store interface {
+	Put(ctx context.Context, name string, data []byte) error
+	// Get returns the content of a backup, ErrNotFound if it does not exist
+	Get(ctx context.Context, name string) ([]byte, error)
+	List(ctx context.Context) ([]Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// S3Config holds the connection settings of S3 compatible destinations
+type S3Config struct {
+	Endpoint        string // Base URL of the service, AWS or Google Cloud Storage by default
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewStore returns the store of a destination: a directory, file:///path, s3://bucket/prefix,
+// or gs://bucket/prefix for the S3 compatible API of Google Cloud Storage with HMAC keys
+func NewStore(destination string, config S3Config) (Store, error) {
+	parsed, err := url.Parse(destination)
+	if err != nil || parsed.Scheme == "" || len(parsed.Scheme) == 1 {
+		// Plain paths, including Windows drive letters
+		return newDirStore(destination)
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return newDirStore(parsed.Path)
+	case "s3", "gs":
+		if parsed.Host == "" {
+			return nil, fmt.Errorf("backup destination '%s' must name a bucket", destination)
+		}
+		if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return nil, fmt.Errorf("backup destination '%s' requires an access key ID and secret access key", destination)
+		}
+		if config.Endpoint == "" && parsed.Scheme == "gs" {
+			config.Endpoint = "https://storage.googleapis.com"
+		}
+		if config.Region == "" {
+			config.Region = "us-east-1"
+			if parsed.Scheme == "gs" {
+				config.Region = "auto"
+			}
+		}
+		return newS3Store(parsed.Host, strings.Trim(parsed.Path, "/"), config)
+	default:
+		return nil, fmt.Errorf("unsupported backup destination '%s': must be a directory, file://, s3:// or gs://", destination)
+	}
+}
+
+// dirStore keeps backups as files of a local directory
+type dirStore struct {
+	dir string
+}
+
+// newDirStore creates the directory if needed
+func newDirStore(dir string) (*dirStore, error) {
+	if dir == "" {
+		return nil, errors.New("backup directory must not be empty")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &dirStore{dir: dir}, nil
+}
+
+// Put writes the file through a temporary one, so that a failed write leaves no partial backup
+func (s *dirStore) Put(ctx context.Context, name string, data []byte) error {
+	temp, err := os.CreateTemp(s.dir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), filepath.Join(s.dir, name))
+}
+
+func (s *dirStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (s *dirStore) List(ctx context.Context) ([]Object, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]Object, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		objects = append(objects, Object{Name: entry.Name(), Size: info.Size(), Modified: info.ModTime().UTC()})
+	}
+	return objects, nil
+}
+
+func (s *dirStore) Delete(ctx context.Context, name string) error {
+	err := os.Remove(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
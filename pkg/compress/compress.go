@@ -0,0 +1,213 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// Content codings supported by the middleware, in order of preference
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+)
+
+// brotliLevel trades ratio for speed, as responses are compressed on the fly
+const brotliLevel = 4
+
+var (
+	gzipWriters   = sync.Pool{New: func() any { w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression); return w }}
+	brotliWriters = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, brotliLevel) }}
+)
+
+// Middleware compresses response bodies of at least minSize bytes with brotli or gzip, as
+// negotiated by the Accept-Encoding header of the request. Event streams, partial content,
+// responses that already set a Content-Encoding and media that is compressed by itself are
+// sent as they are.
+func Middleware(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiate(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &writer{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = w
+		defer w.close()
+		c.Next()
+	}
+}
+
+// negotiate returns the preferred content coding accepted by the Accept-Encoding header, or ""
+func negotiate(header string) string {
+	weights := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range []string{Brotli, Gzip} {
+		q, ok := weights[encoding]
+		if !ok {
+			q = weights["*"]
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// writer buffers the start of a response body until it is known whether the body is large
+// enough to be compressed
+type writer struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buf      []byte
+	started  bool           // The headers were finalized and the buffer written
+	encoder  io.WriteCloser // nil if the response is sent uncompressed
+}
+
+func (w *writer) Write(data []byte) (int, error) {
+	if !w.started {
+		if !w.compressible() {
+			if err := w.start(false); err != nil {
+				return 0, err
+			}
+		} else {
+			w.buf = append(w.buf, data...)
+			if len(w.buf) < w.minSize {
+				return len(data), nil
+			}
+			return len(data), w.start(true)
+		}
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers uncompressed, as the body is not known yet
+func (w *writer) WriteHeaderNow() {
+	if !w.started {
+		w.start(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends the buffered body, compressing it only if it reached the minimum size
+func (w *writer) Flush() {
+	if !w.started {
+		w.start(len(w.buf) >= w.minSize && w.compressible())
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// compressible reports whether the response, as described by its headers so far, may be compressed
+func (w *writer) compressible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	switch w.Status() {
+	case http.StatusPartialContent, http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if strings.HasPrefix(contentType, "text/event-stream") {
+		return false
+	}
+	for _, prefix := range []string{"image/", "audio/", "video/", "font/woff", "application/zip", "application/gzip", "application/x-gzip"} {
+		if strings.HasPrefix(contentType, prefix) && !strings.HasPrefix(contentType, "image/svg") {
+			return false
+		}
+	}
+	return true
+}
+
+// start finalizes the headers and writes the buffered body, through an encoder if compress is set
+func (w *writer) start(compress bool) error {
+	w.started = true
+	if compress {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// The encoded body differs from the one a strong ETag identifies
+		if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = newEncoder(w.encoding, w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close writes the rest of the response and returns the encoder to its pool
+func (w *writer) close() {
+	if !w.started {
+		w.start(false)
+	}
+	if w.encoder == nil {
+		return
+	}
+	w.encoder.Close()
+	switch encoder := w.encoder.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	case *brotli.Writer:
+		brotliWriters.Put(encoder)
+	}
+	w.encoder = nil
+}
+
+// newEncoder returns a pooled encoder of the content coding writing to dst
+func newEncoder(encoding string, dst io.Writer) io.WriteCloser {
+	if encoding == Brotli {
+		encoder := brotliWriters.Get().(*brotli.Writer)
+		encoder.Reset(dst)
+		return encoder
+	}
+	encoder := gzipWriters.Get().(*gzip.Writer)
+	encoder.Reset(dst)
+	return encoder
+}
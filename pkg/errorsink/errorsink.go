@@ -0,0 +1,259 @@
+package errorsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+)
+
+// Kinds of the reported errors
+const (
+	KindPanic      = "panic"      // Panic recovered in an HTTP handler
+	KindRepository = "repository" // Failed repository operation
+	KindTool       = "tool"       // Failed tool call
+)
+
+// Reporting limits
+const (
+	queueSize   = 100              // Events waiting to be sent, more are dropped
+	sendTimeout = 10 * time.Second // Time allowed to send an event
+)
+
+// Config selects where errors are reported
+type Config struct {
+	DSN         string // Sentry DSN, or URL receiving the events as JSON; empty disables reporting
+	Environment string // Environment the events are tagged with, e.g. production
+}
+
+// Request is the HTTP request an error occurred in
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// Event is an error reported to the sink. Webhooks receive it as is.
+type Event struct {
+	ID          string            `json:"id"`
+	Time        time.Time         `json:"time"`
+	Kind        string            `json:"kind"` // panic, repository or tool
+	Message     string            `json:"message"`
+	Environment string            `json:"environment,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`    // Fields of the request context, e.g. requestId, server and tool
+	Request     *Request          `json:"request,omitempty"` // Set for panics
+	Stack       string            `json:"stack,omitempty"`   // Stack trace of panics
+}
+
+// target is the parsed DSN
+type target struct {
+	url       string // URL the events are posted to
+	sentryKey string // Public key of a Sentry DSN, empty for webhooks
+}
+
+// ParseDSN checks a DSN: an http(s) URL, which is a Sentry DSN if it has a public key such as
+// https://key@o1.ingest.sentry.io/42
+func ParseDSN(dsn string) error {
+	_, err := parseDSN(dsn)
+	return err
+}
+
+// parseDSN returns the target of a DSN. Sentry events are posted to the store endpoint of the
+// project. The errors leave the DSN out, as it holds a key.
+func parseDSN(dsn string) (*target, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("must be an http(s) URL")
+	}
+	if parsed.User == nil {
+		return &target{url: dsn}, nil
+	}
+
+	path := strings.TrimSuffix(parsed.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	project := path[slash+1:]
+	if project == "" {
+		return nil, errors.New("has no Sentry project ID, e.g. https://key@o1.ingest.sentry.io/42")
+	}
+	store := url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: path[:slash] + "/api/" + project + "/store/"}
+	return &target{url: store.String(), sentryKey: parsed.User.Username()}, nil
+}
+
+// Reporter sends the reported errors to the sink of its configuration in the background. The
+// events are dropped while the queue is full, so that reporting never slows down requests.
+type Reporter struct {
+	mu          sync.RWMutex
+	target      *target
+	environment string
+	events      chan Event
+	httpClient  *http.Client
+}
+
+// NewReporter creates a reporter for a configuration and starts sending its events
+func NewReporter(config Config) (*Reporter, error) {
+	r := &Reporter{
+		events:     make(chan Event, queueSize),
+		httpClient: &http.Client{Timeout: sendTimeout},
+	}
+	if err := r.SetConfig(config); err != nil {
+		return nil, err
+	}
+	go r.run()
+	return r, nil
+}
+
+// SetConfig replaces the configuration, e.g. after a reload
+func (r *Reporter) SetConfig(config Config) error {
+	var t *target
+	if config.DSN != "" {
+		var err error
+		if t, err = parseDSN(config.DSN); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.target = t
+	r.environment = config.Environment
+	return nil
+}
+
+// Enabled reports whether a DSN is configured
+func (r *Reporter) Enabled() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.target != nil
+}
+
+// Capture reports an error with the fields of ctx and additional tags given as key-value pairs
+func (r *Reporter) Capture(ctx context.Context, kind string, err error, tags ...string) {
+	if err == nil || !r.Enabled() {
+		return
+	}
+	event := r.event(ctx, kind, err.Error())
+	for i := 0; i+1 < len(tags); i += 2 {
+		event.Tags[tags[i]] = tags[i+1]
+	}
+	r.enqueue(event)
+}
+
+// CapturePanic reports a panic recovered while handling req with its stack trace
+func (r *Reporter) CapturePanic(ctx context.Context, recovered any, stack []byte, req *http.Request) {
+	if !r.Enabled() {
+		return
+	}
+	event := r.event(ctx, KindPanic, fmt.Sprint(recovered))
+	event.Stack = string(stack)
+	if req != nil {
+		event.Request = &Request{Method: req.Method, URL: req.URL.String()}
+	}
+	r.enqueue(event)
+}
+
+// event returns the event of an error tagged with the fields of ctx
+func (r *Reporter) event(ctx context.Context, kind string, message string) Event {
+	r.mu.RLock()
+	environment := r.environment
+	r.mu.RUnlock()
+
+	tags := map[string]string{}
+	for _, field := range logging.Fields(ctx) {
+		tags[field.Key] = field.Value.String()
+	}
+	return Event{
+		ID:          strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Time:        time.Now().UTC(),
+		Kind:        kind,
+		Message:     message,
+		Environment: environment,
+		Tags:        tags,
+	}
+}
+
+// enqueue queues an event unless the queue is full
+func (r *Reporter) enqueue(event Event) {
+	select {
+	case r.events <- event:
+	default:
+		slog.Warn("Error report dropped, the queue is full", "kind", event.Kind, "eventId", event.ID)
+	}
+}
+
+// run sends the queued events
+func (r *Reporter) run() {
+	for event := range r.events {
+		r.mu.RLock()
+		t := r.target
+		r.mu.RUnlock()
+		if t == nil {
+			continue
+		}
+		if err := r.send(t, event); err != nil {
+			slog.Warn("Failed to report error", "kind", event.Kind, "eventId", event.ID, "error", err)
+		}
+	}
+}
+
+// send posts an event to a target
+func (r *Reporter) send(t *target, event Event) error {
+	var payload interface{} = event
+	header := http.Header{}
+	if t.sentryKey != "" {
+		payload = sentryEvent(event)
+		header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, t.sentryKey))
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error sink responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// defaultReporter reports the errors of the packages that have no reporter of their own
+var defaultReporter *Reporter
+
+// SetDefault installs the reporter used by Capture and CapturePanic
+func SetDefault(r *Reporter) {
+	defaultReporter = r
+}
+
+// Capture reports an error to the default reporter, if any
+func Capture(ctx context.Context, kind string, err error, tags ...string) {
+	if defaultReporter != nil {
+		defaultReporter.Capture(ctx, kind, err, tags...)
+	}
+}
+
+// CapturePanic reports a panic to the default reporter, if any
+func CapturePanic(ctx context.Context, recovered any, stack []byte, req *http.Request) {
+	if defaultReporter != nil {
+		defaultReporter.CapturePanic(ctx, recovered, stack, req)
+	}
+}
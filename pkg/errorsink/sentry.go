@@ -0,0 +1,35 @@
+package errorsink
+
+// sentryClient identifies the gateway to Sentry
+const sentryClient = "mcp-gateway/1.0.0"
+
+// sentryEvent returns the payload of an event for the store endpoint of Sentry
+func sentryEvent(event Event) map[string]interface{} {
+	tags := map[string]string{"kind": event.Kind}
+	for key, value := range event.Tags {
+		tags[key] = value
+	}
+
+	payload := map[string]interface{}{
+		"event_id":  event.ID,
+		"timestamp": event.Time.Format("2006-01-02T15:04:05.000Z"),
+		"level":     "error",
+		"platform":  "go",
+		"logger":    "mcp-gateway",
+		"message":   map[string]string{"formatted": event.Message},
+		"exception": map[string]interface{}{
+			"values": []map[string]string{{"type": event.Kind, "value": event.Message}},
+		},
+		"tags": tags,
+	}
+	if event.Environment != "" {
+		payload["environment"] = event.Environment
+	}
+	if event.Request != nil {
+		payload["request"] = map[string]string{"method": event.Request.Method, "url": event.Request.URL}
+	}
+	if event.Stack != "" {
+		payload["extra"] = map[string]string{"stack": event.Stack}
+	}
+	return payload
+}
@@ -0,0 +1,207 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// Headers of event deliveries
+const (
+	EventHeader     = "X-MCP-Gateway-Event"
+	DeliveryHeader  = "X-MCP-Gateway-Delivery"
+	TimestampHeader = "X-MCP-Gateway-Timestamp"
+	SignatureHeader = "X-MCP-Gateway-Signature"
+)
+
+// retryDelays are the waits before the retries of a failed delivery
+var retryDelays = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+// Dispatcher delivers lifecycle events to the subscribed event webhooks.
+//
+// Deliveries run in the background and are retried on network errors, 429 and
+// 5xx responses. Each request is signed with the webhook secret: the
+// X-MCP-Gateway-Signature header is "sha256=" followed by the hex HMAC-SHA256
+// of the X-MCP-Gateway-Timestamp header, a dot and the body.
+type Dispatcher struct {
+	webhooks   repository.EventWebhookRepository
+	httpClient *http.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu           sync.Mutex
+	subscribers  map[chan StreamedEvent]struct{} // Streams of GET /api/events
+	streams      context.Context                 // Done once the streams are closed
+	closeStreams context.CancelFunc
+}
+
+// NewDispatcher creates a new dispatcher
+func NewDispatcher(webhooks repository.EventWebhookRepository) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	streams, closeStreams := context.WithCancel(context.Background())
+	return &Dispatcher{
+		webhooks:   webhooks,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		ctx:        ctx,
+		cancel:     cancel,
+
+		subscribers:  make(map[chan StreamedEvent]struct{}),
+		streams:      streams,
+		closeStreams: closeStreams,
+	}
+}
+
+// Stop abandons the pending retries and waits for the running deliveries
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// Publish sends an event to every enabled webhook subscribed to its type and to the event streams
+func (d *Dispatcher) Publish(ctx context.Context, eventType string, entityID string, entityName string, data interface{}) {
+	event := newEvent(ctx, eventType, entityID, entityName, data)
+
+	// Encode now, the entity may change once the caller returns
+	payload, err := json.Marshal(event)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode event", "event", eventType, "error", err)
+		return
+	}
+	d.broadcast(event, payload)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		listCtx, cancel := context.WithTimeout(d.ctx, 10*time.Second)
+		webhooks, err := d.webhooks.GetAll(listCtx)
+		cancel()
+		if err != nil {
+			slog.Error("Failed to get event webhooks", "event", event.Type, "error", err)
+			return
+		}
+
+		for _, webhook := range webhooks {
+			if !webhook.Enabled || !webhook.Subscribed(event.Type) {
+				continue
+			}
+			d.wg.Add(1)
+			go func(webhook models.EventWebhook) {
+				defer d.wg.Done()
+				d.deliver(webhook, event, payload)
+			}(webhook)
+		}
+	}()
+}
+
+// newEvent returns an event of the namespace and request of ctx
+func newEvent(ctx context.Context, eventType string, entityID string, entityName string, data interface{}) models.Event {
+	name, _ := namespace.FromContext(ctx)
+	return models.Event{
+		ID:         "evt-" + uuid.New().String(),
+		Type:       eventType,
+		Namespace:  namespace.OrDefault(name),
+		EntityID:   entityID,
+		EntityName: entityName,
+		RequestID:  logging.RequestID(ctx),
+		Data:       data,
+		Timestamp:  time.Now(),
+	}
+}
+
+// deliver sends an event to a webhook, retrying failed attempts
+func (d *Dispatcher) deliver(webhook models.EventWebhook, event models.Event, payload []byte) {
+	for attempt := 0; ; attempt++ {
+		retry, err := d.send(d.ctx, webhook, event, payload)
+		if err == nil {
+			slog.Info("Event delivered", "webhook", webhook.Name, "event", event.Type, "delivery", event.ID, "attempt", attempt+1)
+			return
+		}
+		if !retry || attempt == len(retryDelays) {
+			slog.Error("Failed to deliver event", "webhook", webhook.Name, "event", event.Type, "delivery", event.ID,
+				"attempts", attempt+1, "error", err)
+			return
+		}
+
+		slog.Warn("Event delivery failed, retrying", "webhook", webhook.Name, "event", event.Type, "delivery", event.ID,
+			"attempt", attempt+1, "retryIn", retryDelays[attempt], "error", err)
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(retryDelays[attempt]):
+		}
+	}
+}
+
+// Send delivers an event to a webhook once
+func (d *Dispatcher) Send(ctx context.Context, webhook models.EventWebhook, event models.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = d.send(ctx, webhook, event, payload)
+	return err
+}
+
+// send posts a signed payload, reporting whether a failure is worth retrying
+func (d *Dispatcher) send(ctx context.Context, webhook models.EventWebhook, event models.Event, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, payload))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// Sign returns the signature header value of a payload sent at timestamp
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestEvent returns the event sent when testing a webhook
+func TestEvent() models.Event {
+	return models.Event{
+		ID:        "evt-" + uuid.New().String(),
+		Type:      "test",
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// streamBuffer is the number of events kept for a stream that is slower than they are published,
+// further events are dropped until it catches up
+const streamBuffer = 64
+
+// StreamedEvent is an event sent to the event streams, encoded once for all of them
+type StreamedEvent struct {
+	ID        string
+	Type      string
+	Namespace string
+	Payload   []byte // JSON of the event
+}
+
+// Subscribe returns the events published from now on. The stream ends when ctx is done or the
+// streams are closed.
+func (d *Dispatcher) Subscribe(ctx context.Context) <-chan StreamedEvent {
+	ch := make(chan StreamedEvent, streamBuffer)
+	d.mu.Lock()
+	d.subscribers[ch] = struct{}{}
+	d.mu.Unlock()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(d.streams, cancel)
+	context.AfterFunc(streamCtx, func() {
+		stop()
+		d.mu.Lock()
+		delete(d.subscribers, ch)
+		d.mu.Unlock()
+		close(ch)
+	})
+	return ch
+}
+
+// CloseStreams ends the event streams, so that the server can shut down without waiting for them
+func (d *Dispatcher) CloseStreams() {
+	d.closeStreams()
+}
+
+// Broadcast sends an event to the event streams only, for events too frequent to notify webhooks of
+func (d *Dispatcher) Broadcast(ctx context.Context, eventType string, entityID string, entityName string, data interface{}) {
+	event := newEvent(ctx, eventType, entityID, entityName, data)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode event", "event", eventType, "error", err)
+		return
+	}
+	d.broadcast(event, payload)
+}
+
+// broadcast sends an encoded event to every stream without waiting for the slow ones
+func (d *Dispatcher) broadcast(event models.Event, payload []byte) {
+	streamed := StreamedEvent{ID: event.ID, Type: event.Type, Namespace: event.Namespace, Payload: payload}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for ch := range d.subscribers {
+		select {
+		case ch <- streamed:
+		default:
+			slog.Warn("Dropped event of a slow event stream", "event", event.Type, "id", event.ID)
+		}
+	}
+}
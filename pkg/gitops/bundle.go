@@ -0,0 +1,294 @@
+package gitops
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle is the desired state of the HTTP interfaces, MCP servers and routers, identified by namespace and name.
+// Its layout matches the output of mcpctl export, so an export can seed a repository.
+// A nil list leaves the resources of its kind alone, even when pruning.
+type Bundle struct {
+	Interfaces []models.HTTPInterface `json:"interfaces"`
+	Servers    []ServerSpec           `json:"servers"`
+	Routers    []models.Router        `json:"routers"`
+}
+
+// ServerSpec is the desired state of an MCP server
+type ServerSpec struct {
+	models.MCPServer
+	Interfaces []string `json:"interfaces,omitempty"` // Names of interfaces to generate tools from
+}
+
+// LoadDir reads the bundle from the .yaml, .yml and .json files in dir and its subdirectories.
+// A file may hold several YAML documents, each with interfaces, servers and routers lists.
+func LoadDir(dir string) (*Bundle, error) {
+	bundle := &Bundle{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := bundle.decode(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// ParseBundle reads a bundle from JSON or from YAML documents
+func ParseBundle(data []byte) (*Bundle, error) {
+	bundle := &Bundle{}
+	if err := bundle.decode(data); err != nil {
+		return nil, err
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// decode appends the resources of every YAML document in data
+func (b *Bundle) decode(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document interface{}
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if document == nil {
+			continue
+		}
+
+		// Go through JSON so the resources use the same field names as the API
+		encoded, err := json.Marshal(document)
+		if err != nil {
+			return err
+		}
+		var part Bundle
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&part); err != nil {
+			return err
+		}
+		if part.Interfaces != nil {
+			b.Interfaces = append(b.Interfaces, part.Interfaces...)
+			if b.Interfaces == nil {
+				b.Interfaces = []models.HTTPInterface{}
+			}
+		}
+		if part.Servers != nil {
+			b.Servers = append(b.Servers, part.Servers...)
+			if b.Servers == nil {
+				b.Servers = []ServerSpec{}
+			}
+		}
+		if part.Routers != nil {
+			b.Routers = append(b.Routers, part.Routers...)
+			if b.Routers == nil {
+				b.Routers = []models.Router{}
+			}
+		}
+	}
+}
+
+// Validate checks that names are set and unique within their namespace and that namespaces, methods,
+// statuses and rules are valid
+func (b *Bundle) Validate() error {
+	var errs []error
+
+	interfaces := make(map[string]bool, len(b.Interfaces))
+	for _, httpInterface := range b.Interfaces {
+		if httpInterface.Name == "" {
+			errs = append(errs, errors.New("HTTP interface without name"))
+			continue
+		}
+		if interfaces[key(httpInterface.Namespace, httpInterface.Name)] {
+			errs = append(errs, fmt.Errorf("HTTP interface %s is defined more than once", httpInterface.Name))
+		}
+		interfaces[key(httpInterface.Namespace, httpInterface.Name)] = true
+		if httpInterface.Namespace != "" {
+			if err := namespace.Validate(httpInterface.Namespace); err != nil {
+				errs = append(errs, fmt.Errorf("HTTP interface %s: %w", httpInterface.Name, err))
+			}
+		}
+
+		switch httpInterface.Method {
+		case "GET", "POST", "PUT", "DELETE", "PATCH":
+		default:
+			errs = append(errs, fmt.Errorf("HTTP interface %s: invalid method '%s'", httpInterface.Name, httpInterface.Method))
+		}
+		if httpInterface.Path == "" {
+			errs = append(errs, fmt.Errorf("HTTP interface %s: path must not be empty", httpInterface.Name))
+		}
+		if httpInterface.Auth != nil {
+			if err := httpInterface.Auth.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("HTTP interface %s: %w", httpInterface.Name, err))
+			}
+		}
+		if err := httpInterface.ValidateParamStyles(); err != nil {
+			errs = append(errs, fmt.Errorf("HTTP interface %s: %w", httpInterface.Name, err))
+		}
+	}
+
+	servers := make(map[string]bool, len(b.Servers))
+	for _, server := range b.Servers {
+		if server.Name == "" {
+			errs = append(errs, errors.New("MCP server without name"))
+			continue
+		}
+		if servers[key(server.Namespace, server.Name)] {
+			errs = append(errs, fmt.Errorf("MCP server %s is defined more than once", server.Name))
+		}
+		servers[key(server.Namespace, server.Name)] = true
+		if server.Namespace != "" {
+			if err := namespace.Validate(server.Namespace); err != nil {
+				errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+			}
+		}
+
+		switch server.Status {
+		case "", "draft", "active", "inactive", "archived":
+		default:
+			errs = append(errs, fmt.Errorf("MCP server %s: invalid status '%s'", server.Name, server.Status))
+		}
+		if err := server.Schedule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateHedging(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := models.ValidateExternalServers(server.External); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		// Tool names and chains cover the tools the reconciler generates from the interfaces
+		generated := withInterfaceTools(server)
+		if err := generated.ValidateToolNames(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := generated.ValidateChains(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateParamMappings(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := models.ValidateRedactions(server.Redactions); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateProjections(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateLatencyBudgets(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateErrorMappings(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateShadows(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateWebSockets(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidatePolicies(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.Headers.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.ValidateAuthPassthrough(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+		if err := server.Network.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("MCP server %s: %w", server.Name, err))
+		}
+	}
+
+	routers := make(map[string]bool, len(b.Routers))
+	for _, router := range b.Routers {
+		if router.Name == "" {
+			errs = append(errs, errors.New("router without name"))
+			continue
+		}
+		if routers[key(router.Namespace, router.Name)] {
+			errs = append(errs, fmt.Errorf("router %s is defined more than once", router.Name))
+		}
+		routers[key(router.Namespace, router.Name)] = true
+		if router.Namespace != "" {
+			if err := namespace.Validate(router.Namespace); err != nil {
+				errs = append(errs, fmt.Errorf("router %s: %w", router.Name, err))
+			}
+		}
+
+		switch router.Status {
+		case "", "active", "inactive":
+		default:
+			errs = append(errs, fmt.Errorf("router %s: invalid status '%s'", router.Name, router.Status))
+		}
+		// Validate a copy, rule IDs are assigned when applying; name new rules by position
+		router.Rules = append([]models.Rule(nil), router.Rules...)
+		for i := range router.Rules {
+			if router.Rules[i].ID == "" {
+				router.Rules[i].ID = "#" + strconv.Itoa(i+1)
+			}
+		}
+		if err := router.PrepareRules(); err != nil {
+			errs = append(errs, fmt.Errorf("router %s: %w", router.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid bundle: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// withInterfaceTools returns the server of spec with a tool for each of its interfaces it does not
+// define, named after the interface like the tools the reconciler generates
+func withInterfaceTools(spec ServerSpec) models.MCPServer {
+	server := spec.MCPServer
+	server.Tools = append([]models.Tool(nil), server.Tools...)
+	for _, name := range spec.Interfaces {
+		if !hasTool(server.Tools, name) {
+			server.Tools = append(server.Tools, models.Tool{Name: name})
+		}
+	}
+	return server
+}
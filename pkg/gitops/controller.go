@@ -0,0 +1,244 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Config configures the repository synced by a controller
+type Config struct {
+	Repository string        // URL passed to git clone
+	Branch     string        // Branch to check out
+	Path       string        // Directory of the files within the repository
+	Dir        string        // Local checkout
+	Interval   time.Duration // Time between syncs
+	Prune      bool          // Delete resources missing from the repository
+}
+
+// Status is the outcome of the last sync
+type Status struct {
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch"`
+	Commit     string    `json:"commit,omitempty"`   // Commit of the last pulled revision
+	LastSync   time.Time `json:"lastSync,omitempty"` // Start of the last sync
+	Error      string    `json:"error,omitempty"`    // Why the last sync failed
+	Changes    []Change  `json:"changes"`            // Changes applied by the last sync
+}
+
+// Drift describes how the gateway differs from the last pulled revision
+type Drift struct {
+	Commit  string   `json:"commit"`
+	InSync  bool     `json:"inSync"`
+	Changes []Change `json:"changes"` // Changes the next sync would apply
+}
+
+// Controller periodically pulls a git repository of declarative YAML files and
+// reconciles the HTTP interfaces, MCP servers and routers with it. It runs the git command.
+type Controller struct {
+	config     Config
+	repository string // Repository URL safe to expose
+	reconciler *Reconciler
+	status     Status
+	mu         sync.RWMutex
+	syncMu     sync.Mutex // Serializes syncs
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewController creates a new controller
+func NewController(config Config, reconciler *Reconciler) *Controller {
+	repository := redactURL(config.Repository)
+	return &Controller{
+		config:     config,
+		repository: repository,
+		reconciler: reconciler,
+		status: Status{
+			Repository: repository,
+			Branch:     config.Branch,
+			Changes:    []Change{},
+		},
+		done: make(chan struct{}),
+	}
+}
+
+// Start syncs right away and then every interval until Stop is called
+func (c *Controller) Start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+		for {
+			c.Sync(context.Background())
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic syncs
+func (c *Controller) Stop() {
+	close(c.done)
+	c.wg.Wait()
+}
+
+// Status returns the outcome of the last sync
+func (c *Controller) Status() Status {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	status := c.status
+	status.Changes = append([]Change{}, c.status.Changes...)
+	return status
+}
+
+// Sync pulls the repository and applies it, returning the outcome
+func (c *Controller) Sync(ctx context.Context) Status {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	status := Status{
+		Repository: c.repository,
+		Branch:     c.config.Branch,
+		LastSync:   time.Now(),
+		Changes:    []Change{},
+	}
+
+	commit, err := c.pull(ctx)
+	if err != nil {
+		// The checkout still holds the previous revision
+		c.mu.RLock()
+		commit = c.status.Commit
+		c.mu.RUnlock()
+	}
+	status.Commit = commit
+	if err == nil {
+		var bundle *Bundle
+		bundle, err = LoadDir(c.filesDir())
+		if err == nil {
+			note := &models.ChangeNote{Author: "gitops", Message: fmt.Sprintf("Synced commit %s of %s", commit, c.repository)}
+			status.Changes, err = c.reconciler.Apply(repository.WithDefaultChangeNote(ctx, note), bundle, c.config.Prune)
+		}
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+		slog.ErrorContext(ctx, "GitOps sync failed", "commit", commit, "error", err)
+	} else {
+		failed := 0
+		for _, change := range status.Changes {
+			if change.Error != "" {
+				failed++
+				slog.ErrorContext(ctx, "Failed to apply GitOps change", "kind", change.Kind, "name", change.Name,
+					"action", change.Action, "error", change.Error)
+			}
+		}
+		if len(status.Changes) > 0 {
+			slog.InfoContext(ctx, "GitOps sync applied changes", "commit", commit, "changes", len(status.Changes), "failed", failed)
+		} else {
+			slog.DebugContext(ctx, "GitOps sync found no changes", "commit", commit)
+		}
+	}
+
+	c.mu.Lock()
+	c.status = status
+	c.mu.Unlock()
+	return status
+}
+
+// Drift compares the gateway with the last pulled revision without changing either
+func (c *Controller) Drift(ctx context.Context) (*Drift, error) {
+	c.mu.RLock()
+	commit := c.status.Commit
+	c.mu.RUnlock()
+	if commit == "" {
+		return nil, fmt.Errorf("repository has not been pulled yet")
+	}
+
+	bundle, err := LoadDir(c.filesDir())
+	if err != nil {
+		return nil, err
+	}
+	changes, err := c.reconciler.Diff(ctx, bundle, c.config.Prune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Drift{
+		Commit:  commit,
+		InSync:  len(changes) == 0,
+		Changes: changes,
+	}, nil
+}
+
+// filesDir returns the directory of the declarative files in the checkout
+func (c *Controller) filesDir() string {
+	return filepath.Join(c.config.Dir, filepath.FromSlash(c.config.Path))
+}
+
+// pull clones the repository or fetches the branch into the checkout, returning the checked out commit
+func (c *Controller) pull(ctx context.Context) (string, error) {
+	if _, err := os.Stat(filepath.Join(c.config.Dir, ".git")); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Clean(c.config.Dir)), 0755); err != nil {
+			return "", err
+		}
+		if _, err := c.git(ctx, "", "clone", "--depth", "1", "--branch", c.config.Branch, "--", c.config.Repository, c.config.Dir); err != nil {
+			return "", err
+		}
+	} else {
+		if _, err := c.git(ctx, c.config.Dir, "fetch", "--depth", "1", "origin", c.config.Branch); err != nil {
+			return "", err
+		}
+		if _, err := c.git(ctx, c.config.Dir, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return "", err
+		}
+	}
+
+	return c.git(ctx, c.config.Dir, "rev-parse", "HEAD")
+}
+
+// git runs a git command in dir and returns its trimmed output
+func (c *Controller) git(ctx context.Context, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.ReplaceAll(strings.TrimSpace(stderr.String()), c.config.Repository, c.repository)
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, message)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// redactURL hides the password of a repository URL
+func redactURL(repository string) string {
+	parsed, err := url.Parse(repository)
+	if err != nil || parsed.User == nil {
+		return repository
+	}
+	if _, ok := parsed.User.Password(); !ok {
+		return repository
+	}
+	parsed.User = url.UserPassword(parsed.User.Username(), "********")
+	return parsed.String()
+}
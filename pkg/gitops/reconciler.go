@@ -0,0 +1,595 @@
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// Kinds of resources
+const (
+	KindHTTPInterface = "http_interface"
+	KindMCPServer     = "mcp_server"
+	KindRouter        = "router"
+)
+
+// Actions of changes
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a difference between the desired and the current state of a resource
+type Change struct {
+	Kind      string `json:"kind"`      // http_interface, mcp_server or router
+	Namespace string `json:"namespace"` // Namespace of the resource
+	Name      string `json:"name"`      // Name of the resource
+	Action    string `json:"action"`    // create, update or delete
+	ID        string `json:"id,omitempty"`
+	Error     string `json:"error,omitempty"` // Set if the change failed or cannot be made
+}
+
+// Reconciler brings the HTTP interfaces, MCP servers and routers in line with a bundle.
+// Resources are matched by namespace and name; IDs, versions and timestamps of the bundle are ignored.
+// Resources without namespace belong to the namespace of the context, or the default one.
+type Reconciler struct {
+	httpRepo   repository.HTTPInterfaceRepository
+	mcpRepo    repository.MCPServerRepository
+	routerRepo repository.RouterRepository
+	service    *mcp.MCPService
+	approval   func() bool // Reports whether the changes of active servers must be approved, nil if never
+}
+
+// ErrApprovalRequired rejects a bundle changing active MCP servers while their changes must be
+// approved as revisions
+var ErrApprovalRequired = errors.New("changes of active MCP servers require approval, submit them as revisions")
+
+// NewReconciler creates a new reconciler
+func NewReconciler(httpRepo repository.HTTPInterfaceRepository, mcpRepo repository.MCPServerRepository,
+	routerRepo repository.RouterRepository, service *mcp.MCPService) *Reconciler {
+	return &Reconciler{
+		httpRepo:   httpRepo,
+		mcpRepo:    mcpRepo,
+		routerRepo: routerRepo,
+		service:    service,
+	}
+}
+
+// SetApproval makes Apply reject bundles changing active MCP servers while required returns true
+func (r *Reconciler) SetApproval(required func() bool) {
+	r.approval = required
+}
+
+// Diff returns the changes Apply would make, without making them
+func (r *Reconciler) Diff(ctx context.Context, bundle *Bundle, prune bool) ([]Change, error) {
+	return r.reconcile(ctx, bundle, prune, false)
+}
+
+// Apply creates and updates the resources of the bundle and, with prune, deletes the resources
+// of the kinds listed in the bundle that are missing from it. Failed changes are reported in
+// their Error field and do not stop the others. While approval is required, a bundle changing
+// active MCP servers is rejected with ErrApprovalRequired before any change is made.
+func (r *Reconciler) Apply(ctx context.Context, bundle *Bundle, prune bool) ([]Change, error) {
+	if err := r.checkApproval(ctx, bundle, prune); err != nil {
+		return nil, err
+	}
+	return r.reconcile(ctx, bundle, prune, true)
+}
+
+// checkApproval returns ErrApprovalRequired naming the active MCP servers the bundle changes if
+// their changes must be approved
+func (r *Reconciler) checkApproval(ctx context.Context, bundle *Bundle, prune bool) error {
+	if r.approval == nil || !r.approval() {
+		return nil
+	}
+
+	changes, err := r.Diff(ctx, bundle, prune)
+	if err != nil {
+		return err
+	}
+	servers, err := r.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	active := map[string]bool{}
+	for _, server := range servers {
+		if server.Status == "active" {
+			active[server.ID] = true
+		}
+	}
+	held := []string{}
+	for _, change := range changes {
+		if change.Kind == KindMCPServer && active[change.ID] {
+			held = append(held, key(change.Namespace, change.Name))
+		}
+	}
+	if len(held) > 0 {
+		return fmt.Errorf("%w: %s", ErrApprovalRequired, strings.Join(held, ", "))
+	}
+	return nil
+}
+
+// Export returns the bundle of the current interfaces, servers and routers visible within ctx,
+// archived ones included, so that applying it recreates them. Router rules target MCP servers
+// of their namespace by name, the IDs of recreated servers differ.
+func (r *Reconciler) Export(ctx context.Context) (*Bundle, error) {
+	interfaces, err := r.httpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := r.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routers, err := r.routerRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Interfaces: interfaces,
+		Servers:    make([]ServerSpec, 0, len(servers)),
+		Routers:    routers,
+	}
+	byID := make(map[string]models.MCPServer, len(servers))
+	for _, server := range servers {
+		bundle.Servers = append(bundle.Servers, ServerSpec{MCPServer: server})
+		byID[server.ID] = server
+	}
+	for i := range bundle.Routers {
+		router := &bundle.Routers[i]
+		router.Rules = append([]models.Rule(nil), router.Rules...)
+		for j := range router.Rules {
+			rule := &router.Rules[j]
+			server, ok := byID[rule.TargetID]
+			if ok && rule.TargetType == "mcp-server" && key(server.Namespace, "") == key(router.Namespace, "") {
+				rule.TargetID = server.Name
+			}
+		}
+	}
+	return bundle, nil
+}
+
+// reconciliation holds the state of a single Diff or Apply
+type reconciliation struct {
+	*Reconciler
+	bundle     *Bundle
+	apply      bool
+	changes    []Change
+	interfaces map[string]models.HTTPInterface // Current interfaces by key
+	servers    map[string]models.MCPServer     // Current servers by key
+	routers    map[string]models.Router        // Current routers by key
+}
+
+// key identifies a resource by its namespace and name
+func key(ns string, name string) string {
+	return namespace.OrDefault(ns) + "/" + name
+}
+
+// reconcile computes the changes and makes them if apply is set. Resources are created and
+// updated before the resources referencing them, and deleted in the opposite order.
+func (r *Reconciler) reconcile(ctx context.Context, bundle *Bundle, prune bool, apply bool) ([]Change, error) {
+	bundle = resolveNamespaces(ctx, bundle)
+	rec := &reconciliation{Reconciler: r, bundle: bundle, apply: apply, changes: []Change{}}
+
+	currentInterfaces, err := r.httpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec.interfaces = make(map[string]models.HTTPInterface, len(currentInterfaces))
+	for _, httpInterface := range currentInterfaces {
+		rec.interfaces[key(httpInterface.Namespace, httpInterface.Name)] = httpInterface
+	}
+	currentServers, err := r.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec.servers = make(map[string]models.MCPServer, len(currentServers))
+	for _, server := range currentServers {
+		rec.servers[key(server.Namespace, server.Name)] = server
+	}
+	currentRouters, err := r.routerRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec.routers = make(map[string]models.Router, len(currentRouters))
+	for _, router := range currentRouters {
+		rec.routers[key(router.Namespace, router.Name)] = router
+	}
+
+	for _, httpInterface := range bundle.Interfaces {
+		rec.upsertInterface(ctx, httpInterface)
+	}
+	for _, spec := range bundle.Servers {
+		rec.upsertServer(ctx, spec)
+	}
+	for _, router := range bundle.Routers {
+		rec.upsertRouter(ctx, router)
+	}
+
+	if !prune {
+		return rec.changes, nil
+	}
+
+	if bundle.Routers != nil {
+		desired := make(map[string]bool, len(bundle.Routers))
+		for _, router := range bundle.Routers {
+			desired[key(router.Namespace, router.Name)] = true
+		}
+		for _, router := range currentRouters {
+			if !desired[key(router.Namespace, router.Name)] {
+				rec.delete(KindRouter, router.Namespace, router.Name, router.ID, func() error {
+					return r.routerRepo.Delete(ctx, router.ID)
+				})
+			}
+		}
+	}
+	if bundle.Servers != nil {
+		desired := make(map[string]bool, len(bundle.Servers))
+		for _, spec := range bundle.Servers {
+			desired[key(spec.Namespace, spec.Name)] = true
+		}
+		for _, server := range currentServers {
+			if !desired[key(server.Namespace, server.Name)] {
+				rec.delete(KindMCPServer, server.Namespace, server.Name, server.ID, func() error {
+					if err := r.mcpRepo.Delete(ctx, server.ID); err != nil {
+						return err
+					}
+					r.service.UnregisterServer(server.ID)
+					return nil
+				})
+			}
+		}
+	}
+	if bundle.Interfaces != nil {
+		desired := make(map[string]bool, len(bundle.Interfaces))
+		for _, httpInterface := range bundle.Interfaces {
+			desired[key(httpInterface.Namespace, httpInterface.Name)] = true
+		}
+		for _, httpInterface := range currentInterfaces {
+			if !desired[key(httpInterface.Namespace, httpInterface.Name)] {
+				rec.delete(KindHTTPInterface, httpInterface.Namespace, httpInterface.Name, httpInterface.ID, func() error {
+					return r.httpRepo.Delete(ctx, httpInterface.ID)
+				})
+			}
+		}
+	}
+
+	return rec.changes, nil
+}
+
+// upsertInterface creates or updates an interface differing from the desired one
+func (rec *reconciliation) upsertInterface(ctx context.Context, desired models.HTTPInterface) {
+	current, exists := rec.interfaces[key(desired.Namespace, desired.Name)]
+	if exists {
+		desired.ID = current.ID
+		desired.Version = current.Version
+		desired.Changelog = current.Changelog
+		desired.CreatedAt = current.CreatedAt
+		desired.UpdatedAt = current.UpdatedAt
+		if Equivalent(desired, current) {
+			return
+		}
+	}
+
+	change := newChange(KindHTTPInterface, desired.Namespace, desired.Name, desired.ID, exists)
+	if err := checkNamespace(ctx, desired.Namespace); err != nil {
+		change.Error = err.Error()
+	} else if rec.apply {
+		var err error
+		if exists {
+			err = rec.httpRepo.Update(ctx, &desired)
+		} else {
+			desired.ID = ""
+			err = rec.httpRepo.Create(ctx, &desired)
+		}
+		change.ID = desired.ID
+		if err != nil {
+			change.Error = err.Error()
+		} else {
+			rec.interfaces[key(desired.Namespace, desired.Name)] = desired
+		}
+	}
+	rec.changes = append(rec.changes, change)
+}
+
+// upsertServer creates or updates a server differing from the desired one and serves it according to its status
+func (rec *reconciliation) upsertServer(ctx context.Context, spec ServerSpec) {
+	current, exists := rec.servers[key(spec.Namespace, spec.Name)]
+	desired, err := rec.desiredServer(spec)
+	if exists {
+		desired.ID = current.ID
+		desired.Version = current.Version
+		desired.Changelog = current.Changelog
+		desired.CreatedAt = current.CreatedAt
+		desired.UpdatedAt = current.UpdatedAt
+		if err == nil && Equivalent(desired, current) {
+			return
+		}
+	}
+
+	change := newChange(KindMCPServer, desired.Namespace, desired.Name, desired.ID, exists)
+	if err == nil && !exists {
+		err = models.ValidateServerName(desired.Name)
+	}
+	if err == nil {
+		err = checkNamespace(ctx, desired.Namespace)
+	}
+	if err == nil {
+		err = rec.service.ValidateScripts(&desired)
+	}
+	if err != nil {
+		change.Error = err.Error()
+		rec.changes = append(rec.changes, change)
+		return
+	}
+
+	if rec.apply {
+		if exists {
+			err = rec.mcpRepo.Update(ctx, &desired)
+		} else {
+			desired.ID = ""
+			err = rec.mcpRepo.Create(ctx, &desired)
+		}
+		change.ID = desired.ID
+		if err != nil {
+			change.Error = err.Error()
+		} else {
+			rec.servers[key(desired.Namespace, desired.Name)] = desired
+			if err := rec.service.ReloadServer(ctx, rec.mcpRepo, desired.ID); err != nil {
+				slog.WarnContext(ctx, "Failed to reload MCP server", "id", desired.ID, "name", desired.Name, "error", err)
+			}
+		}
+	}
+	rec.changes = append(rec.changes, change)
+}
+
+// upsertRouter creates or updates a router differing from the desired one
+func (rec *reconciliation) upsertRouter(ctx context.Context, desired models.Router) {
+	current, exists := rec.routers[key(desired.Namespace, desired.Name)]
+	if desired.Status == "" {
+		desired.Status = "active"
+	}
+	desired.Rules = append([]models.Rule(nil), desired.Rules...)
+	for i := range desired.Rules {
+		rule := &desired.Rules[i]
+		// Keep the IDs of the current rules, they are generated
+		if rule.ID == "" && exists && i < len(current.Rules) {
+			rule.ID = current.Rules[i].ID
+		}
+		// MCP server targets may be given by name
+		if rule.TargetType == "mcp-server" {
+			if server, ok := rec.servers[key(desired.Namespace, rule.TargetID)]; ok && !rec.isServerID(rule.TargetID) {
+				rule.TargetID = server.ID
+			}
+		}
+	}
+	if exists {
+		desired.ID = current.ID
+		desired.Version = current.Version
+		desired.CreatedAt = current.CreatedAt
+		desired.UpdatedAt = current.UpdatedAt
+		if Equivalent(desired, current) {
+			return
+		}
+	}
+
+	change := newChange(KindRouter, desired.Namespace, desired.Name, desired.ID, exists)
+	if err := checkNamespace(ctx, desired.Namespace); err != nil {
+		change.Error = err.Error()
+	} else if rec.apply {
+		err := desired.PrepareRules()
+		if err == nil {
+			if exists {
+				err = rec.routerRepo.Update(ctx, &desired)
+			} else {
+				desired.ID = ""
+				err = rec.routerRepo.Create(ctx, &desired)
+			}
+		}
+		change.ID = desired.ID
+		if err != nil {
+			change.Error = err.Error()
+		}
+	}
+	rec.changes = append(rec.changes, change)
+}
+
+// delete records the deletion of a resource, deleting it if applying
+func (rec *reconciliation) delete(kind, ns, name, id string, deleteFunc func() error) {
+	change := Change{Kind: kind, Namespace: namespace.OrDefault(ns), Name: name, Action: ActionDelete, ID: id}
+	if rec.apply {
+		if err := deleteFunc(); err != nil && err != repository.ErrNotFound {
+			change.Error = err.Error()
+		}
+	}
+	rec.changes = append(rec.changes, change)
+}
+
+// desiredServer builds the server of a spec, appending the tools generated from its interfaces
+func (rec *reconciliation) desiredServer(spec ServerSpec) (models.MCPServer, error) {
+	server := spec.MCPServer
+	if server.Status == "" {
+		server.Status = "draft"
+	}
+	server.Tools = append([]models.Tool(nil), server.Tools...)
+	server.AllowTools = append([]string(nil), server.AllowTools...)
+
+	for _, name := range spec.Interfaces {
+		httpInterface, ok := rec.interfaces[key(server.Namespace, name)]
+		if !ok {
+			if !rec.inBundle(server.Namespace, name) {
+				return server, fmt.Errorf("unknown HTTP interface %s", name)
+			}
+			// Not created yet when diffing
+			httpInterface = models.HTTPInterface{Name: name, Namespace: server.Namespace}
+		}
+		tool := models.NewToolFromHTTPInterface(httpInterface)
+		if !hasTool(server.Tools, tool.Name) {
+			server.Tools = append(server.Tools, tool)
+		}
+		if !contains(server.AllowTools, tool.Name) {
+			server.AllowTools = append(server.AllowTools, tool.Name)
+		}
+	}
+
+	return server, nil
+}
+
+// inBundle reports whether the bundle defines an interface named name in the namespace
+func (rec *reconciliation) inBundle(ns string, name string) bool {
+	for _, httpInterface := range rec.bundle.Interfaces {
+		if httpInterface.Namespace == ns && httpInterface.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerID reports whether id is the ID of a current server
+func (rec *reconciliation) isServerID(id string) bool {
+	for _, server := range rec.servers {
+		if server.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveNamespaces returns a copy of the bundle where resources without namespace
+// belong to the namespace of ctx, or the default one
+func resolveNamespaces(ctx context.Context, bundle *Bundle) *Bundle {
+	ns, scoped := namespace.FromContext(ctx)
+	if !scoped {
+		ns = namespace.Default
+	}
+
+	resolved := *bundle
+	if bundle.Interfaces != nil {
+		resolved.Interfaces = append([]models.HTTPInterface{}, bundle.Interfaces...)
+		for i := range resolved.Interfaces {
+			if resolved.Interfaces[i].Namespace == "" {
+				resolved.Interfaces[i].Namespace = ns
+			}
+		}
+	}
+	if bundle.Servers != nil {
+		resolved.Servers = append([]ServerSpec{}, bundle.Servers...)
+		for i := range resolved.Servers {
+			if resolved.Servers[i].Namespace == "" {
+				resolved.Servers[i].Namespace = ns
+			}
+		}
+	}
+	if bundle.Routers != nil {
+		resolved.Routers = append([]models.Router{}, bundle.Routers...)
+		for i := range resolved.Routers {
+			if resolved.Routers[i].Namespace == "" {
+				resolved.Routers[i].Namespace = ns
+			}
+		}
+	}
+	return &resolved
+}
+
+// checkNamespace fails if a resource of namespace ns cannot be changed within ctx
+func checkNamespace(ctx context.Context, ns string) error {
+	if current, scoped := namespace.FromContext(ctx); scoped && ns != current {
+		return fmt.Errorf("resource belongs to namespace %s, not %s", ns, current)
+	}
+	return nil
+}
+
+// newChange creates the change updating an existing resource or creating a new one
+func newChange(kind, ns, name, id string, exists bool) Change {
+	change := Change{Kind: kind, Namespace: ns, Name: name, Action: ActionCreate, ID: id}
+	if exists {
+		change.Action = ActionUpdate
+	}
+	return change
+}
+
+// hasTool reports whether tools has a tool named name
+func hasTool(tools []models.Tool, name string) bool {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Equivalent compares two resources by their JSON form, treating missing, null and empty lists and objects alike
+func Equivalent(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// normalize returns the JSON form of v without null and empty values
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return dropEmpty(decoded)
+}
+
+// dropEmpty drops the null, empty list and empty object values of decoded JSON
+func dropEmpty(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			item = dropEmpty(item)
+			if isEmpty(item) {
+				delete(value, key)
+			} else {
+				value[key] = item
+			}
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = dropEmpty(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+// isEmpty reports whether a decoded JSON value is null, an empty list or an empty object
+func isEmpty(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
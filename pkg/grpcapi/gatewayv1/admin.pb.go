@@ -0,0 +1,772 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: gateway/v1/admin.proto
+
+package gatewayv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Include the archived resources
+	IncludeArchived bool `protobuf:"varint,1,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*structpb.Struct     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListResponse) GetItems() []*structpb.Struct {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type IDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IDRequest) Reset() {
+	*x = IDRequest{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDRequest) ProtoMessage() {}
+
+func (x *IDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDRequest.ProtoReflect.Descriptor instead.
+func (*IDRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Whole definition of the resource, as sent to the REST API
+	Definition    *structpb.Struct `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetDefinition() *structpb.Struct {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+type MCPServerChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The server after the change, unset if the change awaits approval
+	Server *structpb.Struct `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// The revision awaiting approval, when changes of active servers need one
+	Revision      *structpb.Struct `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MCPServerChange) Reset() {
+	*x = MCPServerChange{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MCPServerChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MCPServerChange) ProtoMessage() {}
+
+func (x *MCPServerChange) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MCPServerChange.ProtoReflect.Descriptor instead.
+func (*MCPServerChange) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MCPServerChange) GetServer() *structpb.Struct {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *MCPServerChange) GetRevision() *structpb.Struct {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type InvokeToolRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	// Name or alias of the tool
+	Tool string `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	// Params of the call, including the headers, cookies and body objects
+	Params        *structpb.Struct `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolRequest) Reset() {
+	*x = InvokeToolRequest{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolRequest) ProtoMessage() {}
+
+func (x *InvokeToolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolRequest.ProtoReflect.Descriptor instead.
+func (*InvokeToolRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InvokeToolRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *InvokeToolRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *InvokeToolRequest) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type InvokeToolResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON result of the tool, or {"result": text} for results that are not JSON
+	Result        *structpb.Value `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	RequestId     string          `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolResponse) Reset() {
+	*x = InvokeToolResponse{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolResponse) ProtoMessage() {}
+
+func (x *InvokeToolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolResponse.ProtoReflect.Descriptor instead.
+func (*InvokeToolResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InvokeToolResponse) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *InvokeToolResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type InvokeToolEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*InvokeToolEvent_Started_
+	//	*InvokeToolEvent_Chunk
+	//	*InvokeToolEvent_Completed_
+	Event         isInvokeToolEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolEvent) Reset() {
+	*x = InvokeToolEvent{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolEvent) ProtoMessage() {}
+
+func (x *InvokeToolEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolEvent.ProtoReflect.Descriptor instead.
+func (*InvokeToolEvent) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InvokeToolEvent) GetEvent() isInvokeToolEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *InvokeToolEvent) GetStarted() *InvokeToolEvent_Started {
+	if x != nil {
+		if x, ok := x.Event.(*InvokeToolEvent_Started_); ok {
+			return x.Started
+		}
+	}
+	return nil
+}
+
+func (x *InvokeToolEvent) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Event.(*InvokeToolEvent_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+func (x *InvokeToolEvent) GetCompleted() *InvokeToolEvent_Completed {
+	if x != nil {
+		if x, ok := x.Event.(*InvokeToolEvent_Completed_); ok {
+			return x.Completed
+		}
+	}
+	return nil
+}
+
+type isInvokeToolEvent_Event interface {
+	isInvokeToolEvent_Event()
+}
+
+type InvokeToolEvent_Started_ struct {
+	// First event, sent once the invocation started
+	Started *InvokeToolEvent_Started `protobuf:"bytes,1,opt,name=started,proto3,oneof"`
+}
+
+type InvokeToolEvent_Chunk struct {
+	// Part of the JSON result, the parts concatenated make the result of InvokeTool
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type InvokeToolEvent_Completed_ struct {
+	// Last event of a successful invocation, failures end the stream with an error instead
+	Completed *InvokeToolEvent_Completed `protobuf:"bytes,3,opt,name=completed,proto3,oneof"`
+}
+
+func (*InvokeToolEvent_Started_) isInvokeToolEvent_Event() {}
+
+func (*InvokeToolEvent_Chunk) isInvokeToolEvent_Event() {}
+
+func (*InvokeToolEvent_Completed_) isInvokeToolEvent_Event() {}
+
+type InvokeToolEvent_Started struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolEvent_Started) Reset() {
+	*x = InvokeToolEvent_Started{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolEvent_Started) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolEvent_Started) ProtoMessage() {}
+
+func (x *InvokeToolEvent_Started) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolEvent_Started.ProtoReflect.Descriptor instead.
+func (*InvokeToolEvent_Started) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *InvokeToolEvent_Started) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type InvokeToolEvent_Completed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DurationMs    int64                  `protobuf:"varint,1,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolEvent_Completed) Reset() {
+	*x = InvokeToolEvent_Completed{}
+	mi := &file_gateway_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolEvent_Completed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolEvent_Completed) ProtoMessage() {}
+
+func (x *InvokeToolEvent_Completed) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolEvent_Completed.ProtoReflect.Descriptor instead.
+func (*InvokeToolEvent_Completed) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_admin_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *InvokeToolEvent_Completed) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+var File_gateway_v1_admin_proto protoreflect.FileDescriptor
+
+const file_gateway_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x16gateway/v1/admin.proto\x12\n" +
+	"gateway.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"8\n" +
+	"\vListRequest\x12)\n" +
+	"\x10include_archived\x18\x01 \x01(\bR\x0fincludeArchived\"=\n" +
+	"\fListResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.google.protobuf.StructR\x05items\"\x1b\n" +
+	"\tIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"X\n" +
+	"\rUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\n" +
+	"definition\x18\x02 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"definition\"w\n" +
+	"\x0fMCPServerChange\x12/\n" +
+	"\x06server\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06server\x123\n" +
+	"\brevision\x18\x02 \x01(\v2\x17.google.protobuf.StructR\brevision\"*\n" +
+	"\x0eStatusResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"u\n" +
+	"\x11InvokeToolRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
+	"\x04tool\x18\x02 \x01(\tR\x04tool\x12/\n" +
+	"\x06params\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06params\"c\n" +
+	"\x12InvokeToolResponse\x12.\n" +
+	"\x06result\x18\x01 \x01(\v2\x16.google.protobuf.ValueR\x06result\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"\x92\x02\n" +
+	"\x0fInvokeToolEvent\x12?\n" +
+	"\astarted\x18\x01 \x01(\v2#.gateway.v1.InvokeToolEvent.StartedH\x00R\astarted\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunk\x12E\n" +
+	"\tcompleted\x18\x03 \x01(\v2%.gateway.v1.InvokeToolEvent.CompletedH\x00R\tcompleted\x1a(\n" +
+	"\aStarted\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x1a,\n" +
+	"\tCompleted\x12\x1f\n" +
+	"\vduration_ms\x18\x01 \x01(\x03R\n" +
+	"durationMsB\a\n" +
+	"\x05event2\xfd\a\n" +
+	"\fGatewayAdmin\x12G\n" +
+	"\x12ListHTTPInterfaces\x12\x17.gateway.v1.ListRequest\x1a\x18.gateway.v1.ListResponse\x12B\n" +
+	"\x10GetHTTPInterface\x12\x15.gateway.v1.IDRequest\x1a\x17.google.protobuf.Struct\x12G\n" +
+	"\x13CreateHTTPInterface\x12\x17.google.protobuf.Struct\x1a\x17.google.protobuf.Struct\x12I\n" +
+	"\x13UpdateHTTPInterface\x12\x19.gateway.v1.UpdateRequest\x1a\x17.google.protobuf.Struct\x12D\n" +
+	"\x13DeleteHTTPInterface\x12\x15.gateway.v1.IDRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0eListMCPServers\x12\x17.gateway.v1.ListRequest\x1a\x18.gateway.v1.ListResponse\x12>\n" +
+	"\fGetMCPServer\x12\x15.gateway.v1.IDRequest\x1a\x17.google.protobuf.Struct\x12C\n" +
+	"\x0fCreateMCPServer\x12\x17.google.protobuf.Struct\x1a\x17.google.protobuf.Struct\x12I\n" +
+	"\x0fUpdateMCPServer\x12\x19.gateway.v1.UpdateRequest\x1a\x1b.gateway.v1.MCPServerChange\x12@\n" +
+	"\x0fDeleteMCPServer\x12\x15.gateway.v1.IDRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\x11ActivateMCPServer\x12\x15.gateway.v1.IDRequest\x1a\x1a.gateway.v1.StatusResponse\x12H\n" +
+	"\x13DeactivateMCPServer\x12\x15.gateway.v1.IDRequest\x1a\x1a.gateway.v1.StatusResponse\x12K\n" +
+	"\n" +
+	"InvokeTool\x12\x1d.gateway.v1.InvokeToolRequest\x1a\x1e.gateway.v1.InvokeToolResponse\x12P\n" +
+	"\x10StreamInvokeTool\x12\x1d.gateway.v1.InvokeToolRequest\x1a\x1b.gateway.v1.InvokeToolEvent0\x01BBZ@github.com/wangfeng/mcp-gateway2/pkg/grpcapi/gatewayv1;gatewayv1b\x06proto3"
+
+var (
+	file_gateway_v1_admin_proto_rawDescOnce sync.Once
+	file_gateway_v1_admin_proto_rawDescData []byte
+)
+
+func file_gateway_v1_admin_proto_rawDescGZIP() []byte {
+	file_gateway_v1_admin_proto_rawDescOnce.Do(func() {
+		file_gateway_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gateway_v1_admin_proto_rawDesc), len(file_gateway_v1_admin_proto_rawDesc)))
+	})
+	return file_gateway_v1_admin_proto_rawDescData
+}
+
+var file_gateway_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_gateway_v1_admin_proto_goTypes = []any{
+	(*ListRequest)(nil),               // 0: gateway.v1.ListRequest
+	(*ListResponse)(nil),              // 1: gateway.v1.ListResponse
+	(*IDRequest)(nil),                 // 2: gateway.v1.IDRequest
+	(*UpdateRequest)(nil),             // 3: gateway.v1.UpdateRequest
+	(*MCPServerChange)(nil),           // 4: gateway.v1.MCPServerChange
+	(*StatusResponse)(nil),            // 5: gateway.v1.StatusResponse
+	(*InvokeToolRequest)(nil),         // 6: gateway.v1.InvokeToolRequest
+	(*InvokeToolResponse)(nil),        // 7: gateway.v1.InvokeToolResponse
+	(*InvokeToolEvent)(nil),           // 8: gateway.v1.InvokeToolEvent
+	(*InvokeToolEvent_Started)(nil),   // 9: gateway.v1.InvokeToolEvent.Started
+	(*InvokeToolEvent_Completed)(nil), // 10: gateway.v1.InvokeToolEvent.Completed
+	(*structpb.Struct)(nil),           // 11: google.protobuf.Struct
+	(*structpb.Value)(nil),            // 12: google.protobuf.Value
+	(*emptypb.Empty)(nil),             // 13: google.protobuf.Empty
+}
+var file_gateway_v1_admin_proto_depIdxs = []int32{
+	11, // 0: gateway.v1.ListResponse.items:type_name -> google.protobuf.Struct
+	11, // 1: gateway.v1.UpdateRequest.definition:type_name -> google.protobuf.Struct
+	11, // 2: gateway.v1.MCPServerChange.server:type_name -> google.protobuf.Struct
+	11, // 3: gateway.v1.MCPServerChange.revision:type_name -> google.protobuf.Struct
+	11, // 4: gateway.v1.InvokeToolRequest.params:type_name -> google.protobuf.Struct
+	12, // 5: gateway.v1.InvokeToolResponse.result:type_name -> google.protobuf.Value
+	9,  // 6: gateway.v1.InvokeToolEvent.started:type_name -> gateway.v1.InvokeToolEvent.Started
+	10, // 7: gateway.v1.InvokeToolEvent.completed:type_name -> gateway.v1.InvokeToolEvent.Completed
+	0,  // 8: gateway.v1.GatewayAdmin.ListHTTPInterfaces:input_type -> gateway.v1.ListRequest
+	2,  // 9: gateway.v1.GatewayAdmin.GetHTTPInterface:input_type -> gateway.v1.IDRequest
+	11, // 10: gateway.v1.GatewayAdmin.CreateHTTPInterface:input_type -> google.protobuf.Struct
+	3,  // 11: gateway.v1.GatewayAdmin.UpdateHTTPInterface:input_type -> gateway.v1.UpdateRequest
+	2,  // 12: gateway.v1.GatewayAdmin.DeleteHTTPInterface:input_type -> gateway.v1.IDRequest
+	0,  // 13: gateway.v1.GatewayAdmin.ListMCPServers:input_type -> gateway.v1.ListRequest
+	2,  // 14: gateway.v1.GatewayAdmin.GetMCPServer:input_type -> gateway.v1.IDRequest
+	11, // 15: gateway.v1.GatewayAdmin.CreateMCPServer:input_type -> google.protobuf.Struct
+	3,  // 16: gateway.v1.GatewayAdmin.UpdateMCPServer:input_type -> gateway.v1.UpdateRequest
+	2,  // 17: gateway.v1.GatewayAdmin.DeleteMCPServer:input_type -> gateway.v1.IDRequest
+	2,  // 18: gateway.v1.GatewayAdmin.ActivateMCPServer:input_type -> gateway.v1.IDRequest
+	2,  // 19: gateway.v1.GatewayAdmin.DeactivateMCPServer:input_type -> gateway.v1.IDRequest
+	6,  // 20: gateway.v1.GatewayAdmin.InvokeTool:input_type -> gateway.v1.InvokeToolRequest
+	6,  // 21: gateway.v1.GatewayAdmin.StreamInvokeTool:input_type -> gateway.v1.InvokeToolRequest
+	1,  // 22: gateway.v1.GatewayAdmin.ListHTTPInterfaces:output_type -> gateway.v1.ListResponse
+	11, // 23: gateway.v1.GatewayAdmin.GetHTTPInterface:output_type -> google.protobuf.Struct
+	11, // 24: gateway.v1.GatewayAdmin.CreateHTTPInterface:output_type -> google.protobuf.Struct
+	11, // 25: gateway.v1.GatewayAdmin.UpdateHTTPInterface:output_type -> google.protobuf.Struct
+	13, // 26: gateway.v1.GatewayAdmin.DeleteHTTPInterface:output_type -> google.protobuf.Empty
+	1,  // 27: gateway.v1.GatewayAdmin.ListMCPServers:output_type -> gateway.v1.ListResponse
+	11, // 28: gateway.v1.GatewayAdmin.GetMCPServer:output_type -> google.protobuf.Struct
+	11, // 29: gateway.v1.GatewayAdmin.CreateMCPServer:output_type -> google.protobuf.Struct
+	4,  // 30: gateway.v1.GatewayAdmin.UpdateMCPServer:output_type -> gateway.v1.MCPServerChange
+	13, // 31: gateway.v1.GatewayAdmin.DeleteMCPServer:output_type -> google.protobuf.Empty
+	5,  // 32: gateway.v1.GatewayAdmin.ActivateMCPServer:output_type -> gateway.v1.StatusResponse
+	5,  // 33: gateway.v1.GatewayAdmin.DeactivateMCPServer:output_type -> gateway.v1.StatusResponse
+	7,  // 34: gateway.v1.GatewayAdmin.InvokeTool:output_type -> gateway.v1.InvokeToolResponse
+	8,  // 35: gateway.v1.GatewayAdmin.StreamInvokeTool:output_type -> gateway.v1.InvokeToolEvent
+	22, // [22:36] is the sub-list for method output_type
+	8,  // [8:22] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_gateway_v1_admin_proto_init() }
+func file_gateway_v1_admin_proto_init() {
+	if File_gateway_v1_admin_proto != nil {
+		return
+	}
+	file_gateway_v1_admin_proto_msgTypes[8].OneofWrappers = []any{
+		(*InvokeToolEvent_Started_)(nil),
+		(*InvokeToolEvent_Chunk)(nil),
+		(*InvokeToolEvent_Completed_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_v1_admin_proto_rawDesc), len(file_gateway_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gateway_v1_admin_proto_goTypes,
+		DependencyIndexes: file_gateway_v1_admin_proto_depIdxs,
+		MessageInfos:      file_gateway_v1_admin_proto_msgTypes,
+	}.Build()
+	File_gateway_v1_admin_proto = out.File
+	file_gateway_v1_admin_proto_goTypes = nil
+	file_gateway_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,659 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: gateway/v1/admin.proto
+
+package gatewayv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GatewayAdmin_ListHTTPInterfaces_FullMethodName  = "/gateway.v1.GatewayAdmin/ListHTTPInterfaces"
+	GatewayAdmin_GetHTTPInterface_FullMethodName    = "/gateway.v1.GatewayAdmin/GetHTTPInterface"
+	GatewayAdmin_CreateHTTPInterface_FullMethodName = "/gateway.v1.GatewayAdmin/CreateHTTPInterface"
+	GatewayAdmin_UpdateHTTPInterface_FullMethodName = "/gateway.v1.GatewayAdmin/UpdateHTTPInterface"
+	GatewayAdmin_DeleteHTTPInterface_FullMethodName = "/gateway.v1.GatewayAdmin/DeleteHTTPInterface"
+	GatewayAdmin_ListMCPServers_FullMethodName      = "/gateway.v1.GatewayAdmin/ListMCPServers"
+	GatewayAdmin_GetMCPServer_FullMethodName        = "/gateway.v1.GatewayAdmin/GetMCPServer"
+	GatewayAdmin_CreateMCPServer_FullMethodName     = "/gateway.v1.GatewayAdmin/CreateMCPServer"
+	GatewayAdmin_UpdateMCPServer_FullMethodName     = "/gateway.v1.GatewayAdmin/UpdateMCPServer"
+	GatewayAdmin_DeleteMCPServer_FullMethodName     = "/gateway.v1.GatewayAdmin/DeleteMCPServer"
+	GatewayAdmin_ActivateMCPServer_FullMethodName   = "/gateway.v1.GatewayAdmin/ActivateMCPServer"
+	GatewayAdmin_DeactivateMCPServer_FullMethodName = "/gateway.v1.GatewayAdmin/DeactivateMCPServer"
+	GatewayAdmin_InvokeTool_FullMethodName          = "/gateway.v1.GatewayAdmin/InvokeTool"
+	GatewayAdmin_StreamInvokeTool_FullMethodName    = "/gateway.v1.GatewayAdmin/StreamInvokeTool"
+)
+
+// GatewayAdminClient is the client API for GatewayAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GatewayAdmin manages the HTTP interfaces and MCP servers of the gateway and invokes their tools.
+// Every call behaves like the REST endpoint named in its comment: resources are the JSON objects
+// of the REST API, and the authorization, x-mcp-namespace, x-api-key, x-mcp-environment and
+// x-mcp-cookie-jar metadata are passed on as the headers of the same name.
+type GatewayAdminClient interface {
+	// GET /api/http-interfaces
+	ListHTTPInterfaces(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// GET /api/http-interfaces/:id
+	GetHTTPInterface(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// POST /api/http-interfaces
+	CreateHTTPInterface(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// PUT /api/http-interfaces/:id
+	UpdateHTTPInterface(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// DELETE /api/http-interfaces/:id
+	DeleteHTTPInterface(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GET /api/mcp-servers
+	ListMCPServers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// GET /api/mcp-servers/:id
+	GetMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// POST /api/mcp-servers
+	CreateMCPServer(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// PUT /api/mcp-servers/:id
+	UpdateMCPServer(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*MCPServerChange, error)
+	// DELETE /api/mcp-servers/:id
+	DeleteMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// POST /api/mcp-servers/:id/activate
+	ActivateMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// POST /api/mcp-servers/:id/deactivate
+	DeactivateMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// POST /api/mcp-servers/:id/tools/:tool
+	InvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (*InvokeToolResponse, error)
+	// POST /api/mcp-servers/:id/tools/:tool, sending the result in chunks as it is written
+	StreamInvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InvokeToolEvent], error)
+}
+
+type gatewayAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGatewayAdminClient(cc grpc.ClientConnInterface) GatewayAdminClient {
+	return &gatewayAdminClient{cc}
+}
+
+func (c *gatewayAdminClient) ListHTTPInterfaces(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, GatewayAdmin_ListHTTPInterfaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) GetHTTPInterface(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, GatewayAdmin_GetHTTPInterface_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) CreateHTTPInterface(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, GatewayAdmin_CreateHTTPInterface_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) UpdateHTTPInterface(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, GatewayAdmin_UpdateHTTPInterface_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) DeleteHTTPInterface(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, GatewayAdmin_DeleteHTTPInterface_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) ListMCPServers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, GatewayAdmin_ListMCPServers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) GetMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, GatewayAdmin_GetMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) CreateMCPServer(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, GatewayAdmin_CreateMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) UpdateMCPServer(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*MCPServerChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MCPServerChange)
+	err := c.cc.Invoke(ctx, GatewayAdmin_UpdateMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) DeleteMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, GatewayAdmin_DeleteMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) ActivateMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, GatewayAdmin_ActivateMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) DeactivateMCPServer(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, GatewayAdmin_DeactivateMCPServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) InvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (*InvokeToolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvokeToolResponse)
+	err := c.cc.Invoke(ctx, GatewayAdmin_InvokeTool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayAdminClient) StreamInvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InvokeToolEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GatewayAdmin_ServiceDesc.Streams[0], GatewayAdmin_StreamInvokeTool_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InvokeToolRequest, InvokeToolEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GatewayAdmin_StreamInvokeToolClient = grpc.ServerStreamingClient[InvokeToolEvent]
+
+// GatewayAdminServer is the server API for GatewayAdmin service.
+// All implementations must embed UnimplementedGatewayAdminServer
+// for forward compatibility.
+//
+// GatewayAdmin manages the HTTP interfaces and MCP servers of the gateway and invokes their tools.
+// Every call behaves like the REST endpoint named in its comment: resources are the JSON objects
+// of the REST API, and the authorization, x-mcp-namespace, x-api-key, x-mcp-environment and
+// x-mcp-cookie-jar metadata are passed on as the headers of the same name.
+type GatewayAdminServer interface {
+	// GET /api/http-interfaces
+	ListHTTPInterfaces(context.Context, *ListRequest) (*ListResponse, error)
+	// GET /api/http-interfaces/:id
+	GetHTTPInterface(context.Context, *IDRequest) (*structpb.Struct, error)
+	// POST /api/http-interfaces
+	CreateHTTPInterface(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// PUT /api/http-interfaces/:id
+	UpdateHTTPInterface(context.Context, *UpdateRequest) (*structpb.Struct, error)
+	// DELETE /api/http-interfaces/:id
+	DeleteHTTPInterface(context.Context, *IDRequest) (*emptypb.Empty, error)
+	// GET /api/mcp-servers
+	ListMCPServers(context.Context, *ListRequest) (*ListResponse, error)
+	// GET /api/mcp-servers/:id
+	GetMCPServer(context.Context, *IDRequest) (*structpb.Struct, error)
+	// POST /api/mcp-servers
+	CreateMCPServer(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// PUT /api/mcp-servers/:id
+	UpdateMCPServer(context.Context, *UpdateRequest) (*MCPServerChange, error)
+	// DELETE /api/mcp-servers/:id
+	DeleteMCPServer(context.Context, *IDRequest) (*emptypb.Empty, error)
+	// POST /api/mcp-servers/:id/activate
+	ActivateMCPServer(context.Context, *IDRequest) (*StatusResponse, error)
+	// POST /api/mcp-servers/:id/deactivate
+	DeactivateMCPServer(context.Context, *IDRequest) (*StatusResponse, error)
+	// POST /api/mcp-servers/:id/tools/:tool
+	InvokeTool(context.Context, *InvokeToolRequest) (*InvokeToolResponse, error)
+	// POST /api/mcp-servers/:id/tools/:tool, sending the result in chunks as it is written
+	StreamInvokeTool(*InvokeToolRequest, grpc.ServerStreamingServer[InvokeToolEvent]) error
+	mustEmbedUnimplementedGatewayAdminServer()
+}
+
+// UnimplementedGatewayAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGatewayAdminServer struct{}
+
+func (UnimplementedGatewayAdminServer) ListHTTPInterfaces(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHTTPInterfaces not implemented")
+}
+func (UnimplementedGatewayAdminServer) GetHTTPInterface(context.Context, *IDRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHTTPInterface not implemented")
+}
+func (UnimplementedGatewayAdminServer) CreateHTTPInterface(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHTTPInterface not implemented")
+}
+func (UnimplementedGatewayAdminServer) UpdateHTTPInterface(context.Context, *UpdateRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHTTPInterface not implemented")
+}
+func (UnimplementedGatewayAdminServer) DeleteHTTPInterface(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHTTPInterface not implemented")
+}
+func (UnimplementedGatewayAdminServer) ListMCPServers(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMCPServers not implemented")
+}
+func (UnimplementedGatewayAdminServer) GetMCPServer(context.Context, *IDRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) CreateMCPServer(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) UpdateMCPServer(context.Context, *UpdateRequest) (*MCPServerChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) DeleteMCPServer(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) ActivateMCPServer(context.Context, *IDRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) DeactivateMCPServer(context.Context, *IDRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateMCPServer not implemented")
+}
+func (UnimplementedGatewayAdminServer) InvokeTool(context.Context, *InvokeToolRequest) (*InvokeToolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeTool not implemented")
+}
+func (UnimplementedGatewayAdminServer) StreamInvokeTool(*InvokeToolRequest, grpc.ServerStreamingServer[InvokeToolEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamInvokeTool not implemented")
+}
+func (UnimplementedGatewayAdminServer) mustEmbedUnimplementedGatewayAdminServer() {}
+func (UnimplementedGatewayAdminServer) testEmbeddedByValue()                      {}
+
+// UnsafeGatewayAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GatewayAdminServer will
+// result in compilation errors.
+type UnsafeGatewayAdminServer interface {
+	mustEmbedUnimplementedGatewayAdminServer()
+}
+
+func RegisterGatewayAdminServer(s grpc.ServiceRegistrar, srv GatewayAdminServer) {
+	// If the following call pancis, it indicates UnimplementedGatewayAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GatewayAdmin_ServiceDesc, srv)
+}
+
+func _GatewayAdmin_ListHTTPInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).ListHTTPInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_ListHTTPInterfaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).ListHTTPInterfaces(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_GetHTTPInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).GetHTTPInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_GetHTTPInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).GetHTTPInterface(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_CreateHTTPInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).CreateHTTPInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_CreateHTTPInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).CreateHTTPInterface(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_UpdateHTTPInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).UpdateHTTPInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_UpdateHTTPInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).UpdateHTTPInterface(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_DeleteHTTPInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).DeleteHTTPInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_DeleteHTTPInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).DeleteHTTPInterface(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_ListMCPServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).ListMCPServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_ListMCPServers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).ListMCPServers(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_GetMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).GetMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_GetMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).GetMCPServer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_CreateMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).CreateMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_CreateMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).CreateMCPServer(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_UpdateMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).UpdateMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_UpdateMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).UpdateMCPServer(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_DeleteMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).DeleteMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_DeleteMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).DeleteMCPServer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_ActivateMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).ActivateMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_ActivateMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).ActivateMCPServer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_DeactivateMCPServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).DeactivateMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_DeactivateMCPServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).DeactivateMCPServer(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_InvokeTool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeToolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayAdminServer).InvokeTool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayAdmin_InvokeTool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayAdminServer).InvokeTool(ctx, req.(*InvokeToolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayAdmin_StreamInvokeTool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvokeToolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GatewayAdminServer).StreamInvokeTool(m, &grpc.GenericServerStream[InvokeToolRequest, InvokeToolEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GatewayAdmin_StreamInvokeToolServer = grpc.ServerStreamingServer[InvokeToolEvent]
+
+// GatewayAdmin_ServiceDesc is the grpc.ServiceDesc for GatewayAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GatewayAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gateway.v1.GatewayAdmin",
+	HandlerType: (*GatewayAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListHTTPInterfaces",
+			Handler:    _GatewayAdmin_ListHTTPInterfaces_Handler,
+		},
+		{
+			MethodName: "GetHTTPInterface",
+			Handler:    _GatewayAdmin_GetHTTPInterface_Handler,
+		},
+		{
+			MethodName: "CreateHTTPInterface",
+			Handler:    _GatewayAdmin_CreateHTTPInterface_Handler,
+		},
+		{
+			MethodName: "UpdateHTTPInterface",
+			Handler:    _GatewayAdmin_UpdateHTTPInterface_Handler,
+		},
+		{
+			MethodName: "DeleteHTTPInterface",
+			Handler:    _GatewayAdmin_DeleteHTTPInterface_Handler,
+		},
+		{
+			MethodName: "ListMCPServers",
+			Handler:    _GatewayAdmin_ListMCPServers_Handler,
+		},
+		{
+			MethodName: "GetMCPServer",
+			Handler:    _GatewayAdmin_GetMCPServer_Handler,
+		},
+		{
+			MethodName: "CreateMCPServer",
+			Handler:    _GatewayAdmin_CreateMCPServer_Handler,
+		},
+		{
+			MethodName: "UpdateMCPServer",
+			Handler:    _GatewayAdmin_UpdateMCPServer_Handler,
+		},
+		{
+			MethodName: "DeleteMCPServer",
+			Handler:    _GatewayAdmin_DeleteMCPServer_Handler,
+		},
+		{
+			MethodName: "ActivateMCPServer",
+			Handler:    _GatewayAdmin_ActivateMCPServer_Handler,
+		},
+		{
+			MethodName: "DeactivateMCPServer",
+			Handler:    _GatewayAdmin_DeactivateMCPServer_Handler,
+		},
+		{
+			MethodName: "InvokeTool",
+			Handler:    _GatewayAdmin_InvokeTool_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInvokeTool",
+			Handler:       _GatewayAdmin_StreamInvokeTool_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gateway/v1/admin.proto",
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+
+	// checkTimeout bounds the duration of a single component check
+	checkTimeout = 2 * time.Second
+)
+
+// Check verifies a single dependency and returns an error if it is not usable
+type Check func(ctx context.Context) error
+
+// ComponentStatus is the result of a component check
+type ComponentStatus struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// Report is the aggregated result of all component checks
+type Report struct {
+	Status     string                     `json:"status"`
+	Time       string                     `json:"time"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+type component struct {
+	name     string
+	check    Check
+	optional bool // Only run when requested with ?<name>=true
+}
+
+// Checker runs the readiness checks of the gateway's dependencies
+type Checker struct {
+	components []component
+}
+
+// NewChecker creates a new health checker
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add registers a component checked on every readiness request
+func (c *Checker) Add(name string, check Check) {
+	c.components = append(c.components, component{name: name, check: check})
+}
+
+// AddOptional registers a component only checked when the request asks for it with ?<name>=true
+func (c *Checker) AddOptional(name string, check Check) {
+	c.components = append(c.components, component{name: name, check: check, optional: true})
+}
+
+// Run checks the selected components concurrently
+func (c *Checker) Run(ctx context.Context, include func(name string) bool) Report {
+	report := Report{
+		Status:     StatusUp,
+		Time:       time.Now().Format(time.RFC3339),
+		Components: make(map[string]ComponentStatus),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, comp := range c.components {
+		if comp.optional && !include(comp.name) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(comp component) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := comp.check(checkCtx)
+			status := ComponentStatus{Status: StatusUp, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[comp.name] = status
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}(comp)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// ReadyHandler serves the readiness report, with status 503 if any component is down
+func (c *Checker) ReadyHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := c.Run(ctx.Request.Context(), func(name string) bool {
+			return ctx.Query(name) == "true"
+		})
+
+		status := http.StatusOK
+		if report.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		ctx.JSON(status, report)
+	}
+}
+
+// DirWritable returns a check verifying that files can be created in dir
+func DirWritable(dir string) Check {
+	return func(ctx context.Context) error {
+		file, err := os.CreateTemp(dir, ".health-*")
+		if err != nil {
+			return fmt.Errorf("directory %s is not writable: %v", filepath.Clean(dir), err)
+		}
+		name := file.Name()
+		file.Close()
+		return os.Remove(name)
+	}
+}
@@ -0,0 +1,195 @@
+// Package i18n translates the messages of error responses into the language the client accepts.
+// Messages are written in English, the source language; a bundle maps them to one language.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages of the bundles
+const (
+	English           = "en"
+	SimplifiedChinese = "zh-CN"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// bundles are the messages of each language, English excluded
+var bundles = map[string]*bundle{}
+
+func init() {
+	for _, language := range []string{SimplifiedChinese} {
+		data, err := localeFiles.ReadFile("locales/" + language + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("missing bundle of %s: %v", language, err))
+		}
+		b, err := parseBundle(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundle of %s: %v", language, err))
+		}
+		bundles[language] = b
+	}
+}
+
+// verbPattern matches the placeholders of messages, as in fmt. Translations may select the value
+// of a placeholder by its position, as in %[2]s.
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?[sdv]`)
+
+// placeholderValue matches the value of a placeholder. Values do not span the ": " separating the
+// segments of error chains, so that a pattern cannot swallow the segments around its own.
+const placeholderValue = `((?:[^:]|:[^ ])+?)`
+
+// pattern is a message with placeholders, such as "Secret with name '%s' already exists"
+type pattern struct {
+	source      *regexp.Regexp
+	translation string
+}
+
+// bundle holds the translations of the messages of one language
+type bundle struct {
+	messages map[string]string // Messages without placeholders
+	patterns []pattern         // Messages with placeholders, longest first
+}
+
+// parseBundle reads a bundle: a JSON object mapping English messages to their translation.
+// Placeholders (%s, %d, %v) match any text within a segment and are replaced in order in the
+// translation, or by position (%[1]s) if the translation orders them differently.
+func parseBundle(data []byte) (*bundle, error) {
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	b := &bundle{messages: map[string]string{}}
+	sources := make([]string, 0, len(entries))
+	for source, translation := range entries {
+		if !verbPattern.MatchString(source) {
+			b.messages[source] = translation
+			continue
+		}
+		count := len(verbPattern.FindAllString(source, -1))
+		for _, verb := range verbPattern.FindAllStringSubmatch(translation, -1) {
+			if position, _ := strconv.Atoi(verb[1]); position > count {
+				return nil, fmt.Errorf("translation of '%s' uses placeholder %d of %d", source, position, count)
+			}
+		}
+		if got := len(verbPattern.FindAllString(translation, -1)); got != count {
+			return nil, fmt.Errorf("translation of '%s' has %d placeholders instead of %d", source, got, count)
+		}
+		sources = append(sources, source)
+	}
+
+	// Try the most specific patterns first
+	sort.Slice(sources, func(i, j int) bool {
+		if len(sources[i]) != len(sources[j]) {
+			return len(sources[i]) > len(sources[j])
+		}
+		return sources[i] < sources[j]
+	})
+	for _, source := range sources {
+		parts := verbPattern.Split(source, -1)
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		b.patterns = append(b.patterns, pattern{
+			source:      regexp.MustCompile("^" + strings.Join(parts, placeholderValue) + "$"),
+			translation: entries[source],
+		})
+	}
+	return b, nil
+}
+
+// Translate returns message in language, English if the language has no bundle. Error chains
+// such as "Failed to execute tool: rate limit exceeded" are translated segment by segment, and
+// the segments without translation, such as names and upstream responses, are kept as they are.
+func Translate(language string, message string) string {
+	b := bundles[language]
+	if b == nil || message == "" {
+		return message
+	}
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = b.translate(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// translate returns the translation of a message, else the message with its first segment and
+// the rest translated separately
+func (b *bundle) translate(message string) string {
+	if translation, ok := b.lookup(message); ok {
+		return translation
+	}
+	head, rest, ok := strings.Cut(message, ": ")
+	if !ok {
+		return message
+	}
+	if translation, ok := b.lookup(head); ok {
+		head = translation
+	}
+	return head + ": " + b.translate(rest)
+}
+
+// lookup returns the translation of a whole message
+func (b *bundle) lookup(message string) (string, bool) {
+	if translation, ok := b.messages[message]; ok {
+		return translation, true
+	}
+	for _, p := range b.patterns {
+		match := p.source.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		values, next := match[1:], 0
+		return verbPattern.ReplaceAllStringFunc(p.translation, func(verb string) string {
+			if position, _ := strconv.Atoi(verbPattern.FindStringSubmatch(verb)[1]); position > 0 {
+				return values[position-1]
+			}
+			next++
+			return values[next-1]
+		}), true
+	}
+	return "", false
+}
+
+// Negotiate returns the language of the bundles that best matches an Accept-Language header,
+// English if none does. Any Chinese variant selects Simplified Chinese, the only Chinese bundle.
+func Negotiate(acceptLanguage string) string {
+	best, bestQuality := English, 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		language := match(strings.TrimSpace(tag))
+		if language != "" && quality > bestQuality {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
+
+// match returns the language of the bundles of a language tag, or an empty string
+func match(tag string) string {
+	primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	switch primary {
+	case "en":
+		return English
+	case "zh":
+		return SimplifiedChinese
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,190 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Providers of the chat APIs the client speaks
+const (
+	ProviderOpenAI    = "openai"    // Chat Completions API, also served by most compatible endpoints
+	ProviderAnthropic = "anthropic" // Messages API
+)
+
+// ErrNotConfigured is returned while no provider and model are configured
+var ErrNotConfigured = errors.New("no LLM is configured, set llm.provider and llm.model")
+
+// maxTokens bounds the length of the completions
+const maxTokens = 1024
+
+// Config selects the chat endpoint and the model
+type Config struct {
+	Provider string        // openai or anthropic, empty disables the client
+	URL      string        // Base URL of the API, the public API of the provider by default
+	APIKey   string        // Sent as a bearer token to OpenAI, as x-api-key to Anthropic
+	Model    string        // Model name, e.g. gpt-4o-mini or claude-3-5-haiku-latest
+	Timeout  time.Duration // Timeout of a completion
+}
+
+// Client completes prompts with the configured model
+type Client struct {
+	mu         sync.RWMutex
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a client for a configuration
+func NewClient(config Config) *Client {
+	return &Client{config: config, httpClient: &http.Client{}}
+}
+
+// SetConfig replaces the configuration, e.g. after a reload
+func (c *Client) SetConfig(config Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = config
+}
+
+// Enabled reports whether a provider and a model are configured
+func (c *Client) Enabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config.Provider != "" && c.config.Model != ""
+}
+
+// Complete returns the answer of the model to a prompt under a system prompt
+func (c *Client) Complete(ctx context.Context, system, prompt string) (string, error) {
+	c.mu.RLock()
+	config := c.config
+	c.mu.RUnlock()
+	if config.Provider == "" || config.Model == "" {
+		return "", ErrNotConfigured
+	}
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	switch config.Provider {
+	case ProviderOpenAI:
+		return c.completeOpenAI(ctx, config, system, prompt)
+	case ProviderAnthropic:
+		return c.completeAnthropic(ctx, config, system, prompt)
+	}
+	return "", fmt.Errorf("unknown LLM provider '%s'", config.Provider)
+}
+
+// completeOpenAI calls the Chat Completions API
+func (c *Client) completeOpenAI(ctx context.Context, config Config, system, prompt string) (string, error) {
+	base := config.URL
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+	request := map[string]interface{}{
+		"model": config.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": prompt},
+		},
+		"max_tokens":  maxTokens,
+		"temperature": 0.2,
+	}
+	header := http.Header{}
+	if config.APIKey != "" {
+		header.Set("Authorization", "Bearer "+config.APIKey)
+	}
+
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := c.post(ctx, strings.TrimSuffix(base, "/")+"/chat/completions", header, request, &response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", errors.New("the LLM returned no choices")
+	}
+	return response.Choices[0].Message.Content, nil
+}
+
+// completeAnthropic calls the Messages API
+func (c *Client) completeAnthropic(ctx context.Context, config Config, system, prompt string) (string, error) {
+	base := config.URL
+	if base == "" {
+		base = "https://api.anthropic.com/v1"
+	}
+	request := map[string]interface{}{
+		"model":      config.Model,
+		"system":     system,
+		"messages":   []map[string]string{{"role": "user", "content": prompt}},
+		"max_tokens": maxTokens,
+	}
+	header := http.Header{}
+	header.Set("anthropic-version", "2023-06-01")
+	if config.APIKey != "" {
+		header.Set("x-api-key", config.APIKey)
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := c.post(ctx, strings.TrimSuffix(base, "/")+"/messages", header, request, &response); err != nil {
+		return "", err
+	}
+	parts := []string{}
+	for _, content := range response.Content {
+		if content.Type == "text" {
+			parts = append(parts, content.Text)
+		}
+	}
+	if len(parts) == 0 {
+		return "", errors.New("the LLM returned no text")
+	}
+	return strings.Join(parts, ""), nil
+}
+
+// post sends a JSON request and decodes the JSON response
+func (c *Client) post(ctx context.Context, url string, header http.Header, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("LLM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("LLM request failed with status code %d: %s", resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, response); err != nil {
+		return fmt.Errorf("invalid LLM response: %w", err)
+	}
+	return nil
+}
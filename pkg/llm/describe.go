@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+const describeSystemPrompt = `You write the descriptions of the tools an AI agent calls through the Model Context Protocol.
+A good tool description says in one to three sentences what the tool does, what it returns and when to use it.
+A good parameter description says what the value means, its format and an example when useful.
+Answer with a single JSON object {"description": "...", "params": {"<param name>": "..."}} and nothing else.
+Only describe the params of the input schema, under their exact names.`
+
+// ToolDefinition is what the model is shown of a tool
+type ToolDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"`
+	Interface   *models.HTTPInterface  `json:"interface,omitempty"` // HTTP interface the tool was generated from
+}
+
+// Description is the description the model proposes for a tool and its params
+type Description struct {
+	Description string            `json:"description"`
+	Params      map[string]string `json:"params,omitempty"` // Description by param name
+}
+
+// DescribeTool asks the model for the descriptions of a tool and of its params
+func (c *Client) DescribeTool(ctx context.Context, tool ToolDefinition) (Description, error) {
+	definition, err := json.MarshalIndent(tool, "", "  ")
+	if err != nil {
+		return Description{}, err
+	}
+	prompt := "Describe this tool. Its current description may be missing, terse or generated from the API path.\n\n" + string(definition)
+
+	answer, err := c.Complete(ctx, describeSystemPrompt, prompt)
+	if err != nil {
+		return Description{}, err
+	}
+
+	// Models may wrap the object in prose or code fences
+	start, end := strings.Index(answer, "{"), strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return Description{}, fmt.Errorf("the LLM answer is not a JSON object: %s", answer)
+	}
+	var description Description
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &description); err != nil {
+		return Description{}, fmt.Errorf("the LLM answer is not a JSON object: %w", err)
+	}
+	description.Description = strings.TrimSpace(description.Description)
+
+	// Drop the params the tool does not have
+	properties, _ := tool.InputSchema["properties"].(map[string]interface{})
+	for name := range description.Params {
+		if _, ok := properties[name]; !ok {
+			delete(description.Params, name)
+		}
+	}
+	return description, nil
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// level is the runtime-adjustable level shared by all loggers
+var level = new(slog.LevelVar)
+
+type contextKey struct{}
+
+// Setup installs the default logger writing text or JSON records to stdout
+func Setup(levelName string, format string) error {
+	return SetupWriter(os.Stdout, levelName, format)
+}
+
+// SetupWriter installs the default logger writing to w
+func SetupWriter(w io.Writer, levelName string, format string) error {
+	if err := SetLevel(levelName); err != nil {
+		return err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", "text":
+		handler = slog.NewTextHandler(w, options)
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return fmt.Errorf("unsupported log format '%s': must be text or json", format)
+	}
+
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+	return nil
+}
+
+// SetLevel changes the minimum level of emitted records at runtime
+func SetLevel(levelName string) error {
+	if levelName == "" {
+		levelName = "info"
+	}
+
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(levelName)); err != nil {
+		return fmt.Errorf("unsupported log level '%s': must be debug, info, warn or error", levelName)
+	}
+	level.Set(l)
+	return nil
+}
+
+// Level returns the current minimum level
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// With returns a copy of ctx carrying additional fields added to every record logged with it,
+// e.g. logging.With(ctx, "server", serverName, "tool", toolName)
+func With(ctx context.Context, args ...any) context.Context {
+	fields, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	record := slog.Record{}
+	record.Add(args...)
+
+	merged := make([]slog.Attr, 0, len(fields)+record.NumAttrs())
+	merged = append(merged, fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		merged = append(merged, attr)
+		return true
+	})
+
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// contextHandler adds the fields stored in the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if fields, ok := ctx.Value(contextKey{}).([]slog.Attr); ok {
+			record.AddAttrs(fields...)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Middleware logs every request once it has been handled
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		logLevel := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			logLevel = slog.LevelError
+		} else if status >= http.StatusBadRequest {
+			logLevel = slog.LevelWarn
+		}
+
+		slog.Log(c.Request.Context(), logLevel, "HTTP request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", status,
+			"durationMs", time.Since(start).Milliseconds(),
+			"clientIp", c.ClientIP())
+	}
+}
+
+// Fields returns the fields ctx adds to every record logged with it
+func Fields(ctx context.Context) []slog.Attr {
+	fields, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return fields
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID on incoming requests, responses and upstream calls
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// RequestIDMiddleware accepts the caller's X-Request-ID or generates one, echoes it in the
+// response and attaches it to the request context so every record logged for the request carries it
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.New().String()
+		}
+
+		// Keep the header on the request so proxied backends receive it
+		c.Request.Header.Set(RequestIDHeader, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, requestID)
+		ctx = With(ctx, "requestId", requestID)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// RequestID returns the request ID stored in ctx, or an empty string
+func RequestID(ctx context.Context) string {
+	if c, ok := ctx.(*gin.Context); ok {
+		if c.Request == nil {
+			return ""
+		}
+		ctx = c.Request.Context()
+	}
+
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// APIKeyHeader carries the API key of a client invoking tools
+const APIKeyHeader = "X-API-Key"
+
+// Response headers reporting the cost the API key of a tool invocation may still spend
+const (
+	QuotaRemainingDayHeader   = "X-Quota-Remaining-Day"
+	QuotaRemainingMonthHeader = "X-Quota-Remaining-Month"
+)
+
+// APIKeyCounter counts the tool calls of API keys against their quotas
+type APIKeyCounter interface {
+	// CountCall counts a tool call of cost for the key, returning false if it would exceed a
+	// quota of the key, and the cost the key may still spend
+	CountCall(ctx context.Context, key *models.APIKey, cost float64) (bool, models.QuotaRemaining, error)
+}
+
+// SetAPIKeyCounter sets the counter enforcing the quotas of API keys
+func (s *MCPService) SetAPIKeyCounter(counter APIKeyCounter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyCounter = counter
+}
+
+// apiKeyCall is the API key of a tool invocation and the header of its response
+type apiKeyCall struct {
+	key    *models.APIKey
+	header http.Header
+}
+
+type apiKeyKey struct{}
+
+// WithAPIKey returns a copy of ctx in which tool calls are counted against the quotas of key.
+// The quota left after a call is reported in header, the header of the response.
+func WithAPIKey(ctx context.Context, key *models.APIKey, header http.Header) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, &apiKeyCall{key: key, header: header})
+}
+
+// APIKeyOf returns the API key stored in ctx, or nil
+func APIKeyOf(ctx context.Context) *models.APIKey {
+	if call, ok := ctx.Value(apiKeyKey{}).(*apiKeyCall); ok {
+		return call.key
+	}
+	return nil
+}
+
+// countKeyCall returns ErrKeyQuotaExceeded if a call of the tool would exceed a quota of the API
+// key of the caller. Like tenant quotas, calls are let through if they cannot be counted.
+func (s *MCPService) countKeyCall(ctx context.Context, tool *models.Tool) error {
+	call, ok := ctx.Value(apiKeyKey{}).(*apiKeyCall)
+	if !ok {
+		return nil
+	}
+	s.mu.RLock()
+	counter := s.keyCounter
+	s.mu.RUnlock()
+	if counter == nil {
+		return nil
+	}
+
+	allowed, remaining, err := counter.CountCall(ctx, call.key, tool.CallCost())
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to count tool call of API key", "apiKey", call.key.Name, "error", err)
+		return nil
+	}
+	if call.header != nil {
+		if remaining.Day >= 0 {
+			call.header.Set(QuotaRemainingDayHeader, strconv.FormatFloat(remaining.Day, 'f', -1, 64))
+		}
+		if remaining.Month >= 0 {
+			call.header.Set(QuotaRemainingMonthHeader, strconv.FormatFloat(remaining.Month, 'f', -1, 64))
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w for %s", ErrKeyQuotaExceeded, call.key.Name)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SecretStore looks up the secrets referenced by auth profiles
+type SecretStore interface {
+	GetByName(ctx context.Context, name string) (*models.Secret, error)
+}
+
+// SetSecretStore sets the store resolving the secrets of auth profiles
+func (s *MCPService) SetSecretStore(store SecretStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets = store
+}
+
+// applyAuth authenticates req with the auth profile of the tool
+func (s *MCPService) applyAuth(ctx context.Context, auth *models.Auth, req *http.Request) error {
+	if auth == nil || auth.Type == models.AuthNone {
+		return nil
+	}
+
+	secret, err := s.secret(ctx, auth.Secret)
+	if err != nil {
+		return err
+	}
+
+	switch auth.Type {
+	case models.AuthBasic:
+		req.SetBasicAuth(auth.Username, secret)
+	case models.AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+secret)
+	case models.AuthAPIKeyHeader:
+		req.Header.Set(auth.Name, secret)
+	case models.AuthAPIKeyQuery:
+		q := req.URL.Query()
+		q.Set(auth.Name, secret)
+		req.URL.RawQuery = q.Encode()
+	case models.AuthOAuth2:
+		token, err := s.oauthToken(ctx, auth, secret)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	default:
+		return fmt.Errorf("invalid auth type '%s'", auth.Type)
+	}
+
+	slog.DebugContext(ctx, "Applied auth profile", "type", auth.Type, "secret", auth.Secret)
+	return nil
+}
+
+// secret returns the value of the named secret
+func (s *MCPService) secret(ctx context.Context, name string) (string, error) {
+	s.mu.RLock()
+	store := s.secrets
+	s.mu.RUnlock()
+	if store == nil {
+		return "", fmt.Errorf("secret %s: secrets are not configured", name)
+	}
+
+	secret, err := store.GetByName(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", name, err)
+	}
+	return secret.Value, nil
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Content types of request bodies encoded other than as JSON
+const (
+	contentTypeForm = "application/x-www-form-urlencoded"
+	contentTypeXML  = "application/xml"
+	contentTypeText = "text/plain"
+)
+
+// xmlName matches the keys of body params that can be XML element names
+var xmlName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// mediaType returns the media type of a Content-Type without its params, in lower case
+func mediaType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// isJSONContentType reports whether bodies of the content type are JSON, as they are without one
+func isJSONContentType(contentType string) bool {
+	media := mediaType(contentType)
+	return media == "" || media == "application/json" || strings.HasSuffix(media, "+json")
+}
+
+// isXMLContentType reports whether bodies of the content type are XML
+func isXMLContentType(contentType string) bool {
+	media := mediaType(contentType)
+	return media == contentTypeXML || media == "text/xml" || strings.HasSuffix(media, "+xml")
+}
+
+// EncodeBody encodes the body param of a call in a content type. Strings are sent as they are;
+// objects are form encoded or written as XML elements, JSON and other content types get them as
+// JSON.
+func EncodeBody(contentType string, body interface{}) ([]byte, error) {
+	if text, ok := body.(string); ok {
+		return []byte(text), nil
+	}
+	switch {
+	case mediaType(contentType) == contentTypeForm:
+		fields, ok := body.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("body of content type %s must be an object or a string", contentType)
+		}
+		return []byte(formValues(fields).Encode()), nil
+	case isXMLContentType(contentType):
+		return encodeXML(body)
+	default:
+		return json.Marshal(body)
+	}
+}
+
+// formValues converts an object to form fields: arrays repeat their field, objects are sent as JSON
+func formValues(fields map[string]interface{}) url.Values {
+	values := url.Values{}
+	for name, value := range fields {
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		for _, item := range items {
+			values.Add(name, formValue(item))
+		}
+	}
+	return values
+}
+
+// formValue formats a form field, objects and arrays as JSON
+func formValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(value)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// encodeXML writes an object as XML elements. An object with a single key is the root element,
+// others are wrapped in <request>. Arrays repeat their element.
+func encodeXML(body interface{}) ([]byte, error) {
+	root := "request"
+	if fields, ok := body.(map[string]interface{}); ok && len(fields) == 1 {
+		for name, value := range fields {
+			root, body = name, value
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := writeXMLElement(&buf, root, body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXMLElement writes value as the element name
+func writeXMLElement(buf *bytes.Buffer, name string, value interface{}) error {
+	if !xmlName.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid XML element name", name)
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := writeXMLElement(buf, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil:
+		buf.WriteString("<" + name + "/>")
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteString("<" + name + ">")
+		for _, key := range keys {
+			if err := writeXMLElement(buf, key, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</" + name + ">")
+		return nil
+	default:
+		buf.WriteString("<" + name + ">")
+		xml.EscapeText(buf, []byte(fmt.Sprintf("%v", v)))
+		buf.WriteString("</" + name + ">")
+		return nil
+	}
+}
+
+// bodyEscaper returns how the values substituted in a body template of the content type are
+// escaped, nil for none
+func bodyEscaper(contentType string) func(string) string {
+	switch {
+	case mediaType(contentType) == contentTypeForm:
+		return url.QueryEscape
+	case isXMLContentType(contentType):
+		return func(value string) string {
+			var buf bytes.Buffer
+			xml.EscapeText(&buf, []byte(value))
+			return buf.String()
+		}
+	default:
+		return nil
+	}
+}
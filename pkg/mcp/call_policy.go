@@ -0,0 +1,280 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/ratelimit"
+)
+
+// ErrCallTimeout is returned for the calls cut off at the timeout of their call policy
+var ErrCallTimeout = errors.New("tool call timed out")
+
+// NamespacePolicyStore looks up the call policies of namespaces
+type NamespacePolicyStore interface {
+	// Policy returns the call policy of a namespace, nil if it has none
+	Policy(ctx context.Context, namespace string) (*models.CallPolicy, error)
+}
+
+// SetNamespacePolicyStore sets the store of the call policies the servers of a namespace inherit
+func (s *MCPService) SetNamespacePolicyStore(store NamespacePolicyStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespacePolicies = store
+}
+
+// hostAllowlist is the list of upstream hosts of a level of call policies
+type hostAllowlist struct {
+	level string // e.g. namespace team-a
+	hosts []string
+}
+
+// callPolicy is the policy of a call, each setting taken from the tool, else the server, else the
+// namespace
+type callPolicy struct {
+	timeout      time.Duration
+	retry        *models.RetryPolicy
+	rateLimit    *models.CallRateLimit
+	rateScope    string          // Level setting the rate limit, whose calls share the limit of each caller
+	allowedHosts []hostAllowlist // Lists of the levels setting one, a host must be in each
+}
+
+type callPolicyKey struct{}
+
+// withCallPolicy returns a copy of ctx carrying the policy of a call
+func withCallPolicy(ctx context.Context, policy callPolicy) context.Context {
+	return context.WithValue(ctx, callPolicyKey{}, policy)
+}
+
+// callPolicyOf returns the policy of the call of ctx, empty outside of tool calls
+func callPolicyOf(ctx context.Context) callPolicy {
+	policy, _ := ctx.Value(callPolicyKey{}).(callPolicy)
+	return policy
+}
+
+// namespacePolicy returns the call policy of a namespace, nil if it has none. Calls go on without
+// it if it cannot be read, so an unavailable database only disables namespace policies.
+func (s *MCPService) namespacePolicy(ctx context.Context, name string) *models.CallPolicy {
+	s.mu.RLock()
+	store := s.namespacePolicies
+	s.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+
+	policy, err := store.Policy(ctx, name)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read namespace policy", "namespace", name, "error", err)
+		return nil
+	}
+	return policy
+}
+
+// resolvePolicy returns the policy of the calls of a tool of server
+func (s *MCPService) resolvePolicy(ctx context.Context, server *models.MCPServer, tool *models.Tool) callPolicy {
+	tenant := namespace.OrDefault(server.Namespace)
+	levels := []struct {
+		level  string
+		scope  string
+		policy *models.CallPolicy
+	}{
+		{"tool " + tool.ExposedName(), "tool/" + server.ID + "/" + tool.Name, tool.Policy},
+		{"server " + server.Name, "server/" + server.ID, server.Policy},
+		{"namespace " + tenant, "namespace/" + tenant, s.namespacePolicy(ctx, tenant)},
+	}
+
+	var resolved callPolicy
+	for _, level := range levels {
+		policy := level.policy
+		if policy == nil {
+			continue
+		}
+		if resolved.timeout == 0 && policy.TimeoutMs > 0 {
+			resolved.timeout = policy.Timeout()
+		}
+		if resolved.retry == nil {
+			resolved.retry = policy.Retry
+		}
+		if resolved.rateLimit == nil && policy.RateLimit != nil {
+			resolved.rateLimit, resolved.rateScope = policy.RateLimit, level.scope
+		}
+		if len(policy.AllowedHosts) > 0 {
+			hosts := make([]string, 0, len(policy.AllowedHosts))
+			for _, host := range policy.AllowedHosts {
+				hosts = append(hosts, strings.ToLower(strings.TrimSpace(host)))
+			}
+			resolved.allowedHosts = append(resolved.allowedHosts, hostAllowlist{level: level.level, hosts: hosts})
+		}
+	}
+	return resolved
+}
+
+// EffectivePolicy returns the call policy a tool of server applies, its own settings completed by
+// those of the server and of the namespace. The allowed hosts are those of the most specific
+// level setting a list; the calls must also match the lists of the levels above it.
+func (s *MCPService) EffectivePolicy(ctx context.Context, server *models.MCPServer, tool *models.Tool) models.CallPolicy {
+	resolved := s.resolvePolicy(ctx, server, tool)
+	policy := models.CallPolicy{
+		TimeoutMs: int(resolved.timeout.Milliseconds()),
+		Retry:     resolved.retry,
+		RateLimit: resolved.rateLimit,
+	}
+	if len(resolved.allowedHosts) > 0 {
+		policy.AllowedHosts = resolved.allowedHosts[0].hosts
+	}
+	return policy
+}
+
+// policyLimiters keeps the rate limiters of the levels of call policies setting a rate limit
+type policyLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*ratelimit.Limiter
+}
+
+// take consumes a call of caller against the limit of the scope, updated to its current settings
+func (l *policyLimiters) take(scope string, limit *models.CallRateLimit, caller string) (bool, ratelimit.State) {
+	l.mu.Lock()
+	if l.limiters == nil {
+		l.limiters = make(map[string]*ratelimit.Limiter)
+	}
+	limiter, ok := l.limiters[scope]
+	if !ok {
+		limiter = ratelimit.NewLimiter(limit.RequestsPerSecond, limit.Burst)
+		l.limiters[scope] = limiter
+	}
+	l.mu.Unlock()
+
+	limiter.SetLimit(limit.RequestsPerSecond, limit.Burst)
+	return limiter.Take(caller)
+}
+
+// allowPolicyCaller reports whether the rate limit of the call policy lets the caller of ctx
+// invoke the tool now, and reports that limit in the response header of ctx
+func (s *MCPService) allowPolicyCaller(ctx context.Context, policy callPolicy) bool {
+	if policy.rateLimit == nil || policy.rateLimit.RequestsPerSecond <= 0 {
+		return true
+	}
+
+	allowed, state := s.policyLimiters.take(policy.rateScope, policy.rateLimit, Caller(ctx))
+	header, _ := ctx.Value(responseHeaderKey{}).(http.Header)
+	if header != nil {
+		header.Set(RateLimitLimitHeader, strconv.Itoa(state.Limit))
+		header.Set(RateLimitRemainingHeader, strconv.Itoa(state.Remaining))
+		header.Set(RateLimitResetHeader, strconv.Itoa(state.ResetSeconds()))
+		if !allowed {
+			header.Set(RetryAfterHeader, strconv.Itoa(state.RetryAfterSeconds()))
+		}
+	}
+	return allowed
+}
+
+// withCallTimeout returns the context of a call, canceled at the timeout of its policy if any
+func withCallTimeout(ctx context.Context, policy callPolicy) (context.Context, context.CancelFunc) {
+	if policy.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, policy.timeout)
+}
+
+// checkCallTimeout returns the error of a call, ErrCallTimeout if callCtx was canceled at the
+// timeout of the policy while ctx, the context of the client, was not
+func checkCallTimeout(ctx, callCtx context.Context, policy callPolicy, err error) error {
+	if err == nil || policy.timeout <= 0 || ctx.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w after %s", ErrCallTimeout, policy.timeout)
+}
+
+// checkPolicyHosts returns ErrHostNotAllowed if host is missing from an allowlist of the call
+// policy of ctx
+func checkPolicyHosts(ctx context.Context, host string) error {
+	host = strings.ToLower(host)
+	for _, allowlist := range callPolicyOf(ctx).allowedHosts {
+		allowed := false
+		for _, pattern := range allowlist.hosts {
+			if hostMatches(pattern, host) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s by the policy of %s", ErrHostNotAllowed, host, allowlist.level)
+		}
+	}
+	return nil
+}
+
+// retryableStatus reports whether an upstream status is worth retrying
+func retryableStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// idempotentMethod reports whether requests of a method can be sent twice
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendWithRetry sends req with send, and again under the retry policy of ctx while it fails to
+// reach the upstream or gets a 502, 503 or 504 response. The last response or error is returned.
+func sendWithRetry(ctx context.Context, server *models.MCPServer, tool *models.Tool, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	retry := callPolicyOf(ctx).retry
+	canRetry := retry != nil && retry.MaxAttempts > 1 && idempotentMethod(req.Method) &&
+		(req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+	if !canRetry {
+		return send(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := send(req)
+		failed := err != nil || retryableStatus(resp.StatusCode)
+		if !failed || attempt == retry.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		// Retry with a fresh body after the backoff, unless the call is canceled meanwhile
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			next.Body = body
+		}
+		backoff := retry.Backoff(attempt)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		slog.WarnContext(ctx, "Retrying upstream request", "attempt", attempt+1, "status", status, "error", err, "backoff", backoff)
+		metrics.ObserveUpstreamRetry(server.Name, tool.Name)
+		req = next
+	}
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/tidwall/gjson"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// runChain executes the steps of a chained tool in order, each with the params mapped from the
+// params of the chained tool and the results of the previous steps. It returns the output of the
+// chain and the upstream status of the last step.
+func (s *MCPService) runChain(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (string, int, error) {
+	document := map[string]interface{}{"params": params, "steps": []interface{}{}}
+	steps := []interface{}{}
+	statusCode := 0
+	last := ""
+
+	for i, step := range tool.Steps {
+		target := server.FindTool(step.Tool)
+		if target == nil {
+			return "", 0, fmt.Errorf("step %d of chained tool %s: %w: %s", i, tool.Name, ErrToolNotFound, step.Tool)
+		}
+		if !target.IsEnabled() {
+			return "", 0, fmt.Errorf("step %d of chained tool %s: %w: %s", i, tool.Name, ErrToolDisabled, step.Tool)
+		}
+		if target.IsChained() {
+			return "", 0, fmt.Errorf("step %d of chained tool %s calls chained tool %s", i, tool.Name, step.Tool)
+		}
+
+		data, err := json.Marshal(document)
+		if err != nil {
+			return "", 0, err
+		}
+		stepParams := params
+		if len(step.Params) > 0 {
+			stepParams = make(map[string]interface{}, len(step.Params))
+			for name, path := range step.Params {
+				// Leave out the params whose path matches nothing
+				if value := gjson.GetBytes(data, path); value.Exists() {
+					stepParams[name] = value.Value()
+				}
+			}
+		}
+
+		slog.DebugContext(ctx, "Running chain step", "step", i, "tool", step.Tool, "params", stepParams)
+		result, status, err := s.executeToolRequest(ctx, server, target, stepParams)
+		if err != nil {
+			return "", status, fmt.Errorf("step %d (%s) of chained tool %s failed: %w", i, step.Tool, tool.Name, err)
+		}
+		statusCode = status
+		last = result
+
+		steps = append(steps, stepResult(result))
+		document["steps"] = steps
+	}
+
+	if tool.Output == "" {
+		return last, statusCode, nil
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return "", statusCode, err
+	}
+	output := gjson.GetBytes(data, tool.Output)
+	if output.Type == gjson.String {
+		return output.String(), statusCode, nil
+	}
+	return output.Raw, statusCode, nil
+}
+
+// stepResult decodes the result of a step as JSON, keeping it as a string if it is not JSON
+func stepResult(result string) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		return result
+	}
+	return decoded
+}
@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// checkTimeout bounds each step of a connectivity check
+const checkTimeout = 5 * time.Second
+
+// certificateExpiryWarning is how long before its expiry a certificate is reported as expiring soon
+const certificateExpiryWarning = 14 * 24 * time.Hour
+
+// CheckStep is the outcome of one step of a connectivity check: resolve, url, dns, tcp, tls or http
+type CheckStep struct {
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	DurationMs int64  `json:"durationMs"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ConnectivityReport describes whether the upstream of a URL is reachable. The steps stop at the
+// first failure.
+type ConnectivityReport struct {
+	URL       string      `json:"url"` // URL probed, after environment and upstream resolution
+	Reachable bool        `json:"reachable"`
+	Steps     []CheckStep `json:"steps"`
+}
+
+// CheckConnectivity probes the host of rawURL: it resolves the {{env:name}} placeholders with the
+// environment and upstream names to a target, looks up the host, opens a TCP connection and, for
+// https, performs the TLS handshake. If method is HEAD or GET, it also sends that request without
+// auth and reports the status; any response counts as reachable.
+func (s *MCPService) CheckConnectivity(ctx context.Context, rawURL string, environment string, method string) *ConnectivityReport {
+	report := &ConnectivityReport{URL: rawURL, Steps: []CheckStep{}}
+	step := func(name string, start time.Time, detail string, err error) bool {
+		result := CheckStep{Name: name, OK: err == nil, DurationMs: time.Since(start).Milliseconds(), Detail: detail}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		report.Steps = append(report.Steps, result)
+		return err == nil
+	}
+
+	// Resolve the URL as a tool call would
+	start := time.Now()
+	target, err := s.resolveCheckURL(ctx, rawURL, environment)
+	if !step("resolve", start, target, err) {
+		return report
+	}
+	report.URL = target
+
+	start = time.Now()
+	parsed, err := url.Parse(target)
+	if err == nil && (parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Hostname() == "") {
+		err = fmt.Errorf("not an absolute http or https URL: %s", target)
+	}
+	if err == nil {
+		err = s.checkHost(parsed.Hostname())
+	}
+	if !step("url", start, "", err) {
+		return report
+	}
+	host := parsed.Hostname()
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	// Look up the host
+	start = time.Now()
+	dnsCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	addrs, err := net.DefaultResolver.LookupHost(dnsCtx, host)
+	cancel()
+	if !step("dns", start, strings.Join(addrs, ", "), err) {
+		return report
+	}
+
+	// Connect to the port
+	start = time.Now()
+	dialer := &net.Dialer{Timeout: checkTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	detail := ""
+	if err == nil {
+		detail = conn.RemoteAddr().String()
+	}
+	if !step("tcp", start, detail, err) {
+		return report
+	}
+
+	// Perform the TLS handshake of https URLs
+	if parsed.Scheme == "https" {
+		start = time.Now()
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		tlsCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err = tlsConn.HandshakeContext(tlsCtx)
+		cancel()
+		detail = ""
+		if err == nil {
+			detail = describeCertificate(tlsConn.ConnectionState())
+		}
+		conn = tlsConn
+		if !step("tls", start, detail, err) {
+			conn.Close()
+			return report
+		}
+	}
+	conn.Close()
+
+	// Send the optional request
+	if method == http.MethodHead || method == http.MethodGet {
+		start = time.Now()
+		httpCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		var resp *http.Response
+		req, err := http.NewRequestWithContext(httpCtx, method, target, nil)
+		if err == nil {
+			resp, err = s.httpClient.Do(req)
+		}
+		detail = ""
+		if err == nil {
+			resp.Body.Close()
+			detail = resp.Status
+		}
+		if !step("http", start, detail, err) {
+			return report
+		}
+	}
+
+	report.Reachable = true
+	return report
+}
+
+// resolveCheckURL substitutes the environment variables of rawURL and resolves its upstream name
+func (s *MCPService) resolveCheckURL(ctx context.Context, rawURL string, environment string) (string, error) {
+	tool := &models.Tool{RequestTemplate: models.RequestTemplate{URL: rawURL}}
+	if usesEnvironment(tool) && environment == "" && EnvironmentName(ctx) == "" {
+		return "", fmt.Errorf("the URL uses environment variables but no environment is selected")
+	}
+	tool, err := s.applyEnvironment(ctx, &models.MCPServer{DefaultEnvironment: environment}, tool)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.RLock()
+	resolver := s.resolver
+	s.mu.RUnlock()
+	if resolver == nil {
+		return tool.RequestTemplate.URL, nil
+	}
+	return resolver.ResolveURL(tool.RequestTemplate.URL)
+}
+
+// describeCertificate summarizes the TLS version and the leaf certificate of a connection
+func describeCertificate(state tls.ConnectionState) string {
+	detail := tls.VersionName(state.Version)
+	if len(state.PeerCertificates) == 0 {
+		return detail
+	}
+	leaf := state.PeerCertificates[0]
+	detail += fmt.Sprintf(", certificate %s expires %s", leaf.Subject.CommonName, leaf.NotAfter.UTC().Format(time.RFC3339))
+	if time.Until(leaf.NotAfter) < certificateExpiryWarning {
+		detail += " (expires soon)"
+	}
+	return detail
+}
@@ -0,0 +1,534 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// clientProtocolVersion is the MCP revision requested from external servers
+const clientProtocolVersion = "2025-06-18"
+
+// errClientClosed is returned by calls on a connection that was closed or lost
+var errClientClosed = errors.New("connection to external MCP server closed")
+
+// rpcRequest is a JSON-RPC 2.0 request, or a notification if it has no ID
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// rpcMessage is a JSON-RPC 2.0 message received from an external server
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"` // Set on requests and notifications of the server
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// clientTransport exchanges JSON-RPC messages with an external MCP server
+type clientTransport interface {
+	// roundTrip sends req and returns its response, or nil for notifications
+	roundTrip(ctx context.Context, req *rpcRequest) (*rpcMessage, error)
+	close() error
+}
+
+// mcpClient is a connection to an external MCP server
+type mcpClient struct {
+	transport clientTransport
+	nextID    atomic.Int64
+}
+
+// call sends a request and decodes its result into result
+func (c *mcpClient) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	id := c.nextID.Add(1)
+	reply, err := c.transport.roundTrip(ctx, &rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	if reply.Error != nil {
+		return fmt.Errorf("%s failed: %s (code %d)", method, reply.Error.Message, reply.Error.Code)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(reply.Result, result)
+}
+
+// notify sends a notification
+func (c *mcpClient) notify(ctx context.Context, method string) error {
+	_, err := c.transport.roundTrip(ctx, &rpcRequest{JSONRPC: "2.0", Method: method})
+	return err
+}
+
+// initialize performs the initialization handshake, returning the negotiated protocol version
+func (c *mcpClient) initialize(ctx context.Context) (string, error) {
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	err := c.call(ctx, "initialize", map[string]interface{}{
+		"protocolVersion": clientProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "mcp-gateway", "version": "1.0.0"},
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.ProtocolVersion, c.notify(ctx, "notifications/initialized")
+}
+
+func (c *mcpClient) close() error {
+	return c.transport.close()
+}
+
+// httpTransport is the Streamable HTTP transport: every message is POSTed to the endpoint and
+// the response comes back as a JSON body or as an event stream
+type httpTransport struct {
+	client   *http.Client
+	endpoint string
+	prepare  func(ctx context.Context, req *http.Request) error // Adds the headers and auth of the server
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+}
+
+func (t *httpTransport) roundTrip(ctx context.Context, rpc *rpcRequest) (*rpcMessage, error) {
+	data, err := json.Marshal(rpc)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", t.protocolVersion)
+	}
+	t.mu.Unlock()
+	if err := t.prepare(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRecordedPayload))
+		return nil, fmt.Errorf("external MCP server returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if rpc.ID == nil {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Wait for the response among the messages of the stream
+		var reply *rpcMessage
+		err := readEvents(resp.Body, func(event string, data string) bool {
+			var message rpcMessage
+			if json.Unmarshal([]byte(data), &message) != nil || !sameID(message.ID, *rpc.ID) || message.Method != "" {
+				return true
+			}
+			reply = &message
+			return false
+		})
+		if reply == nil {
+			if err == nil {
+				err = fmt.Errorf("event stream ended without a response to %s", rpc.Method)
+			}
+			return nil, err
+		}
+		return reply, nil
+	}
+
+	var reply rpcMessage
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("invalid response to %s: %w", rpc.Method, err)
+	}
+	return &reply, nil
+}
+
+// setProtocolVersion sets the version sent with the requests following the initialization
+func (t *httpTransport) setProtocolVersion(version string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.protocolVersion = version
+}
+
+// close ends the session, if the server issued one
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, t.endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+	if err := t.prepare(ctx, req); err != nil {
+		return err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// streamConn matches the responses read from a stream to the requests waiting for them, for
+// the transports receiving every message of the server on one stream (sse and stdio)
+type streamConn struct {
+	write func(ctx context.Context, data []byte) error
+
+	mu      sync.Mutex
+	pending map[int64]chan *rpcMessage
+	done    chan struct{}
+	err     error
+	once    sync.Once
+}
+
+func newStreamConn(write func(ctx context.Context, data []byte) error) *streamConn {
+	return &streamConn{
+		write:   write,
+		pending: make(map[int64]chan *rpcMessage),
+		done:    make(chan struct{}),
+	}
+}
+
+func (c *streamConn) roundTrip(ctx context.Context, rpc *rpcRequest) (*rpcMessage, error) {
+	data, err := json.Marshal(rpc)
+	if err != nil {
+		return nil, err
+	}
+	if rpc.ID == nil {
+		return nil, c.write(ctx, data)
+	}
+
+	replies := make(chan *rpcMessage, 1)
+	c.mu.Lock()
+	c.pending[*rpc.ID] = replies
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, *rpc.ID)
+		c.mu.Unlock()
+	}()
+
+	if err := c.write(ctx, data); err != nil {
+		return nil, err
+	}
+	select {
+	case reply := <-replies:
+		return reply, nil
+	case <-c.done:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dispatch delivers a message read from the stream. Pings of the server are answered, its
+// other requests and notifications are ignored.
+func (c *streamConn) dispatch(data []byte) {
+	var message rpcMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		slog.Debug("Ignoring invalid message of external MCP server", "error", err)
+		return
+	}
+	if message.Method != "" {
+		if message.Method == "ping" && len(message.ID) > 0 {
+			reply, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": message.ID, "result": map[string]interface{}{}})
+			go c.write(context.Background(), reply)
+		}
+		return
+	}
+
+	var id int64
+	if err := json.Unmarshal(message.ID, &id); err != nil {
+		return
+	}
+	c.mu.Lock()
+	replies, ok := c.pending[id]
+	c.mu.Unlock()
+	if ok {
+		replies <- &message
+	}
+}
+
+// fail fails the waiting and future requests with err
+func (c *streamConn) fail(err error) {
+	c.once.Do(func() {
+		if err == nil {
+			err = errClientClosed
+		}
+		c.err = err
+		close(c.done)
+	})
+}
+
+// sseTransport is the HTTP+SSE transport: the server sends every message on an event stream
+// opened with a GET and announces in its first event the endpoint messages are POSTed to
+type sseTransport struct {
+	*streamConn
+	cancel context.CancelFunc
+}
+
+// dialSSE opens the event stream of url and waits for the message endpoint
+func dialSSE(ctx context.Context, client *http.Client, rawURL string, prepare func(ctx context.Context, req *http.Request) error, checkHost func(host string) error) (*sseTransport, error) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if err := prepare(ctx, req); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Give up on the stream if the endpoint is not announced in time
+	stop := context.AfterFunc(ctx, cancel)
+	resp, err := client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("external MCP server returned status %d for the event stream", resp.StatusCode)
+	}
+
+	endpoints := make(chan string, 1)
+	t := &sseTransport{cancel: cancel}
+	var endpoint string
+	t.streamConn = newStreamConn(func(ctx context.Context, data []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if err := prepare(ctx, req); err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("external MCP server returned status %d", resp.StatusCode)
+		}
+		return nil
+	})
+
+	go func() {
+		defer resp.Body.Close()
+		err := readEvents(resp.Body, func(event string, data string) bool {
+			if event == "endpoint" {
+				select {
+				case endpoints <- data:
+				default:
+				}
+				return true
+			}
+			t.dispatch([]byte(data))
+			return true
+		})
+		t.fail(err)
+	}()
+
+	select {
+	case announced := <-endpoints:
+		stop()
+		resolved, err := resolveEndpoint(rawURL, announced)
+		if err == nil {
+			err = checkHost(resolved.Hostname())
+		}
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		endpoint = resolved.String()
+		return t, nil
+	case <-t.done:
+		cancel()
+		return nil, fmt.Errorf("event stream ended before the endpoint was announced: %w", t.err)
+	case <-ctx.Done():
+		cancel()
+		return nil, ctx.Err()
+	}
+}
+
+func (t *sseTransport) close() error {
+	t.cancel()
+	t.fail(errClientClosed)
+	return nil
+}
+
+// resolveEndpoint resolves the message endpoint announced by an SSE server against the stream URL
+func resolveEndpoint(streamURL string, endpoint string) (*url.URL, error) {
+	base, err := url.Parse(streamURL)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint announced by external MCP server: %w", err)
+	}
+	return base.ResolveReference(ref), nil
+}
+
+// stdioTransport runs the external server as a child process exchanging newline-delimited
+// messages over its standard input and output
+type stdioTransport struct {
+	*streamConn
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// startStdio starts the program and reads its messages
+func startStdio(command string, args []string, env map[string]string) (*stdioTransport, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	t := &stdioTransport{cmd: cmd, stdin: stdin}
+	var writeMu sync.Mutex
+	t.streamConn = newStreamConn(func(ctx context.Context, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_, err := stdin.Write(append(data, '\n'))
+		return err
+	})
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			slog.Debug("External MCP server output", "command", command, "line", scanner.Text())
+		}
+	}()
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				t.dispatch(line)
+			}
+		}
+		err := scanner.Err()
+		if waitErr := cmd.Wait(); err == nil && waitErr != nil {
+			err = fmt.Errorf("external MCP server exited: %w", waitErr)
+		}
+		t.fail(err)
+	}()
+
+	return t, nil
+}
+
+func (t *stdioTransport) close() error {
+	t.stdin.Close()
+	t.fail(errClientClosed)
+	if t.cmd.Process != nil {
+		t.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// readEvents calls handle with the event type and data of every event of a text/event-stream
+// body until handle returns false or the stream ends
+func readEvents(body io.Reader, handle func(event string, data string) bool) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	event := ""
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if !handle(event, strings.Join(data, "\n")) {
+					return nil
+				}
+			}
+			event, data = "", nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// sameID reports whether a raw JSON-RPC ID is the number id
+func sameID(raw json.RawMessage, id int64) bool {
+	var parsed int64
+	return json.Unmarshal(raw, &parsed) == nil && parsed == id
+}
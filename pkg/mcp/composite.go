@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrSourceInactive is returned when the source server of a tool of a virtual server is not active
+var ErrSourceInactive = errors.New("source MCP server is not active")
+
+// callSourceTool forwards a tool call of a virtual server to the current definition of the tool on
+// its source server, which runs with the templates, plugins, scripts and environment of that server
+func (s *MCPService) callSourceTool(ctx context.Context, tool *models.Tool, params map[string]interface{}) (string, int, error) {
+	s.mu.RLock()
+	source, ok := s.servers[tool.Source]
+	s.mu.RUnlock()
+	if !ok {
+		return "", 0, fmt.Errorf("%w: %s", ErrSourceInactive, tool.Source)
+	}
+
+	if !source.AllowsTool(tool.RemoteName) {
+		return "", 0, fmt.Errorf("%w: %s of server %s", ErrToolNotFound, tool.RemoteName, source.Name)
+	}
+	sourceTool := *source.FindTool(tool.RemoteName)
+	if !sourceTool.IsEnabled() {
+		return "", 0, fmt.Errorf("%w: %s of server %s", ErrToolDisabled, tool.RemoteName, source.Name)
+	}
+	// A source turned virtual after it was included is not followed further
+	if sourceTool.Source != "" {
+		return "", 0, fmt.Errorf("%w: %s", ErrVirtualSource, source.Name)
+	}
+	ctx = logging.With(ctx, "source", source.Name)
+	// The source retries under its own call policy, within the host allowlists of the virtual server
+	policy := s.resolvePolicy(ctx, source, &sourceTool)
+	policy.allowedHosts = append(policy.allowedHosts, callPolicyOf(ctx).allowedHosts...)
+	ctx = withCallPolicy(ctx, policy)
+	fields, params := requestedFields(&sourceTool, params)
+	result, statusCode, err := s.executeToolRequest(ctx, source, &sourceTool, params)
+	if err != nil {
+		return "", statusCode, err
+	}
+	// The projection and the redaction rules of the source apply to its results wherever they are returned
+	result = project(sourceTool.Projection, fields, result)
+	return s.redact(source.Redactions, result), statusCode, nil
+}
+
+// ErrVirtualSource is returned when a virtual server is given as the source of another
+var ErrVirtualSource = errors.New("a virtual server cannot be a source")
+
+// ComposeServer sets the tools of a virtual server to the union of the allowed tools of its
+// sources. Tools the server already had keep their place in the allow list, new tools are allowed.
+func (s *ServerSyncer) ComposeServer(ctx context.Context, server *models.MCPServer) error {
+	_, _, _, err := s.composeServer(ctx, server, true)
+	return err
+}
+
+// composeServer composes a virtual server and returns the names of the added, updated and removed
+// tools. Unless strict is set, sources that no longer exist are left out instead of failing.
+func (s *ServerSyncer) composeServer(ctx context.Context, server *models.MCPServer, strict bool) ([]string, []string, []string, error) {
+	if err := models.ValidateSources(server.Sources, server.ConflictResolution); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sources := make([]models.ServerSource, 0, len(server.Sources))
+	servers := make([]models.MCPServer, 0, len(server.Sources))
+	for _, source := range server.Sources {
+		if source.ServerID == server.ID {
+			return nil, nil, nil, fmt.Errorf("%w: a server cannot include itself", ErrVirtualSource)
+		}
+		sourceServer, err := s.mcpRepo.GetByID(ctx, source.ServerID)
+		if errors.Is(err, repository.ErrNotFound) && !strict {
+			slog.WarnContext(ctx, "Source server of virtual server not found", "id", server.ID, "source", source.ServerID)
+			continue
+		} else if err != nil {
+			return nil, nil, nil, fmt.Errorf("source server %s: %w", source.ServerID, err)
+		}
+		if len(sourceServer.Sources) > 0 {
+			return nil, nil, nil, fmt.Errorf("%w: %s", ErrVirtualSource, sourceServer.Name)
+		}
+		sources = append(sources, source)
+		servers = append(servers, *sourceServer)
+	}
+
+	tools, err := models.ComposeTools(sources, servers, server.ConflictResolution)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	previous := make(map[string]models.Tool, len(server.Tools))
+	for _, tool := range server.Tools {
+		previous[tool.Name] = tool
+	}
+	current := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		current[tool.Name] = true
+	}
+
+	// Keep the allowed tools that remain, then allow the new ones
+	allowTools := []string{}
+	for _, name := range server.AllowTools {
+		if _, ok := previous[name]; ok && current[name] {
+			allowTools = append(allowTools, name)
+		}
+	}
+	var added, updated, removed []string
+	for _, tool := range tools {
+		old, ok := previous[tool.Name]
+		if !ok {
+			added = append(added, tool.Name)
+			allowTools = append(allowTools, tool.Name)
+		} else if !reflect.DeepEqual(old, tool) {
+			updated = append(updated, tool.Name)
+		}
+	}
+	for _, tool := range server.Tools {
+		if !current[tool.Name] {
+			removed = append(removed, tool.Name)
+		}
+	}
+
+	server.Tools = tools
+	server.AllowTools = allowTools
+	return added, updated, removed, nil
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+	"time"
+)
+
+// CookieJarHeader selects the cookie jar of a tool invocation
+const CookieJarHeader = "X-MCP-Cookie-Jar"
+
+// cookieJarTTL is how long an unused cookie jar is kept
+const cookieJarTTL = 30 * time.Minute
+
+type cookieJarKey struct{}
+
+// WithCookieJar returns a copy of ctx selecting the cookie jar used by tool invocations
+func WithCookieJar(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, cookieJarKey{}, id)
+}
+
+// CookieJarID returns the cookie jar selected in ctx, or an empty string
+func CookieJarID(ctx context.Context) string {
+	id, _ := ctx.Value(cookieJarKey{}).(string)
+	return id
+}
+
+// cookieJars keeps the cookies set by upstreams between the invocations selecting the same jar,
+// so a tool can act on the session opened by a login tool
+type cookieJars struct {
+	mu   sync.Mutex
+	jars map[string]*cookieJarEntry
+}
+
+type cookieJarEntry struct {
+	jar      *cookiejar.Jar
+	lastUsed time.Time
+}
+
+// get returns the jar with the key, creating it if needed, and drops the jars unused for cookieJarTTL
+func (j *cookieJars) get(key string) *cookiejar.Jar {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range j.jars {
+		if now.Sub(entry.lastUsed) > cookieJarTTL {
+			delete(j.jars, k)
+		}
+	}
+
+	entry, ok := j.jars[key]
+	if !ok {
+		// cookiejar.New only fails on invalid options
+		jar, _ := cookiejar.New(nil)
+		entry = &cookieJarEntry{jar: jar}
+		j.jars[key] = entry
+	}
+	entry.lastUsed = now
+	return entry.jar
+}
+
+// cookieJar returns the jar selected in ctx for the server namespace, nil if none is selected
+func (s *MCPService) cookieJar(ctx context.Context, tenant string) *cookiejar.Jar {
+	id := CookieJarID(ctx)
+	if id == "" {
+		return nil
+	}
+	// Jars are per namespace so teams selecting the same ID don't share sessions
+	return s.jars.get(tenant + "/" + id)
+}
+
+// addJarCookies adds the cookies of the jar for the request URL, except those already set on req
+func addJarCookies(req *http.Request, jar *cookiejar.Jar) {
+	for _, cookie := range jar.Cookies(req.URL) {
+		if _, err := req.Cookie(cookie.Name); err == nil {
+			continue
+		}
+		req.AddCookie(cookie)
+	}
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Response headers of the calls of deprecated tools
+const (
+	WarningHeader = "Warning" // RFC 7234 warning with the deprecation warning of the tool
+	SunsetHeader  = "Sunset"  // RFC 8594 removal date of the tool
+)
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a copy of ctx in which calls of deprecated tools and the rate limit of
+// the caller are reported in header, the header of the response
+func WithResponseHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, header)
+}
+
+// warnDeprecated logs the call of a deprecated tool and reports it in the response header of ctx
+func warnDeprecated(ctx context.Context, tool *models.Tool) {
+	warning := tool.DeprecationWarning()
+	if warning == "" {
+		return
+	}
+	slog.WarnContext(ctx, "Deprecated tool called", "caller", Caller(ctx), "sunset", tool.Sunset)
+
+	header, _ := ctx.Value(responseHeaderKey{}).(http.Header)
+	if header == nil {
+		return
+	}
+	header.Set(WarningHeader, "299 - "+strconv.Quote(warning))
+	if tool.Sunset != nil {
+		header.Set(SunsetHeader, tool.Sunset.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// EnvironmentHeader selects the environment of a tool invocation
+const EnvironmentHeader = "X-MCP-Environment"
+
+// envPlaceholder matches {{env:name}} placeholders in tool templates
+var envPlaceholder = regexp.MustCompile(`\{\{env:([A-Za-z0-9_.-]+)\}\}`)
+
+// EnvironmentStore looks up environments by name
+type EnvironmentStore interface {
+	GetByName(ctx context.Context, name string) (*models.Environment, error)
+}
+
+type environmentKey struct{}
+
+// WithEnvironment returns a copy of ctx selecting the environment used by tool invocations
+func WithEnvironment(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, environmentKey{}, name)
+}
+
+// EnvironmentName returns the environment selected in ctx, or an empty string
+func EnvironmentName(ctx context.Context) string {
+	name, _ := ctx.Value(environmentKey{}).(string)
+	return name
+}
+
+// SetEnvironmentStore sets the store resolving the environments of tool invocations
+func (s *MCPService) SetEnvironmentStore(store EnvironmentStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.environments = store
+}
+
+// applyEnvironment returns a copy of tool with the {{env:name}} placeholders of its
+// request template replaced by the variables of the selected environment.
+// The environment selected in ctx takes precedence over the server default.
+func (s *MCPService) applyEnvironment(ctx context.Context, server *models.MCPServer, tool *models.Tool) (*models.Tool, error) {
+	if !usesEnvironment(tool) {
+		return tool, nil
+	}
+
+	name := EnvironmentName(ctx)
+	if name == "" {
+		name = server.DefaultEnvironment
+	}
+	if name == "" {
+		return nil, fmt.Errorf("tool %s uses environment variables but no environment is selected: set the %s header or the server defaultEnvironment", tool.Name, EnvironmentHeader)
+	}
+
+	s.mu.RLock()
+	store := s.environments
+	s.mu.RUnlock()
+	if store == nil {
+		return nil, fmt.Errorf("environment %s: environments are not configured", name)
+	}
+
+	environment, err := store.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("environment %s: %w", name, err)
+	}
+
+	var missing []string
+	replace := func(template string) string {
+		return envPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+			variable := envPlaceholder.FindStringSubmatch(placeholder)[1]
+			value, ok := environment.Variables[variable]
+			if !ok {
+				missing = append(missing, variable)
+			}
+			return value
+		})
+	}
+
+	resolved := *tool
+	resolved.RequestTemplate.URL = replace(tool.RequestTemplate.URL)
+	resolved.RequestTemplate.Body = replace(tool.RequestTemplate.Body)
+	resolved.RequestTemplate.Headers = make(map[string]string, len(tool.RequestTemplate.Headers))
+	for key, value := range tool.RequestTemplate.Headers {
+		resolved.RequestTemplate.Headers[key] = replace(value)
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment %s has no variables %v", name, missing)
+	}
+
+	return &resolved, nil
+}
+
+// usesEnvironment reports whether the request template of tool contains {{env:name}} placeholders
+func usesEnvironment(tool *models.Tool) bool {
+	if envPlaceholder.MatchString(tool.RequestTemplate.URL) || envPlaceholder.MatchString(tool.RequestTemplate.Body) {
+		return true
+	}
+	for _, value := range tool.RequestTemplate.Headers {
+		if envPlaceholder.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/tmpl"
+)
+
+// UpstreamError is the error of a call whose upstream answered with a status the tool maps to a
+// category and a message for the model
+type UpstreamError struct {
+	Status   int    // Status code of the upstream response
+	Category string // Category of the mapping, upstream if not set
+	Message  string // Rendered message of the mapping
+}
+
+// Error returns the category and the message
+func (e *UpstreamError) Error() string {
+	return e.Category + ": " + e.Message
+}
+
+// upstreamError returns the error of a call of tool answered with an unsuccessful status: the
+// mapped error if the tool maps the status, else the status and the raw body
+func upstreamError(ctx context.Context, tool *models.Tool, status int, body []byte) error {
+	mapping := tool.ErrorMappingFor(status)
+	if mapping == nil {
+		return fmt.Errorf("request failed with status code %d: %s", status, string(body))
+	}
+
+	message := mapping.Message
+	if tmpl.Uses(message) {
+		var decoded interface{}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			decoded = string(body)
+		}
+		rendered, err := tmpl.Render(message, map[string]interface{}{"status": status, "body": decoded})
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to render error mapping message", "tool", tool.Name, "status", status, "error", err)
+		} else {
+			message = rendered
+		}
+	}
+	if mapping.IncludeBody && len(body) > 0 {
+		message += ": " + string(body)
+	}
+
+	category := mapping.Category
+	if category == "" {
+		category = models.ErrorCategoryUpstream
+	}
+	return &UpstreamError{Status: status, Category: category, Message: message}
+}
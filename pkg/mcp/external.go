@@ -0,0 +1,242 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrStdioNotAllowed is returned for external servers of the stdio transport while it is disabled
+var ErrStdioNotAllowed = errors.New("the stdio transport is disabled, set upstream.allowStdio to enable it")
+
+// externalClients holds the open connections to external servers by server ID and external server name
+type externalClients struct {
+	mu      sync.Mutex
+	clients map[string]*externalClient
+}
+
+// externalClient is a pooled connection, replaced when the configuration of its server changes
+type externalClient struct {
+	config string // JSON of the external server it was opened with
+	client *mcpClient
+}
+
+// SetAllowStdio enables external servers of the stdio transport, which run a command on the gateway host
+func (s *MCPService) SetAllowStdio(allow bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowStdio = allow
+}
+
+// ListExternalTools connects to an external server and returns the tools proxying its tools
+func (s *MCPService) ListExternalTools(ctx context.Context, external models.ExternalServer) ([]models.Tool, error) {
+	client, err := s.connectExternal(ctx, external)
+	if err != nil {
+		return nil, err
+	}
+	defer client.close()
+
+	tools := []models.Tool{}
+	cursor := ""
+	for {
+		params := map[string]interface{}{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		var result struct {
+			Tools []struct {
+				Name         string                 `json:"name"`
+				Description  string                 `json:"description"`
+				InputSchema  map[string]interface{} `json:"inputSchema"`
+				OutputSchema map[string]interface{} `json:"outputSchema"`
+			} `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := client.call(ctx, "tools/list", params, &result); err != nil {
+			return nil, err
+		}
+		for _, tool := range result.Tools {
+			tools = append(tools, models.NewToolFromExternal(external, tool.Name, tool.Description, tool.InputSchema, tool.OutputSchema))
+		}
+		if result.NextCursor == "" || result.NextCursor == cursor {
+			return tools, nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
+// callExternalTool forwards a tool call to the external server of the tool. Tool errors
+// reported by the external server are returned as errors carrying their text.
+func (s *MCPService) callExternalTool(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (string, error) {
+	var external *models.ExternalServer
+	for i := range server.External {
+		if server.External[i].Name == tool.External {
+			external = &server.External[i]
+		}
+	}
+	if external == nil {
+		return "", fmt.Errorf("external server %s of tool %s not found", tool.External, tool.Name)
+	}
+	// The connections are shared by the calls, the policy of each call is checked on its own
+	if parsed, err := url.Parse(external.URL); err == nil && external.URL != "" {
+		if err := checkPolicyHosts(ctx, parsed.Hostname()); err != nil {
+			return "", err
+		}
+	}
+
+	client, err := s.externalClient(ctx, server.ID, *external)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Content           []map[string]interface{} `json:"content"`
+		StructuredContent interface{}              `json:"structuredContent"`
+		IsError           bool                     `json:"isError"`
+	}
+	slog.InfoContext(ctx, "Calling external tool", "external", external.Name, "remoteName", tool.RemoteName)
+	err = client.call(ctx, "tools/call", map[string]interface{}{"name": tool.RemoteName, "arguments": params}, &result)
+	if err != nil {
+		// Reconnect on the next call, the connection may be lost
+		s.dropExternalClient(server.ID, external.Name, client)
+		return "", err
+	}
+
+	text := contentText(result.Content, result.StructuredContent)
+	if result.IsError {
+		return "", fmt.Errorf("external tool failed: %s", text)
+	}
+	return text, nil
+}
+
+// contentText flattens the content of a tool result: text items are joined, other items are
+// kept as JSON. Structured content is used when there is no content.
+func contentText(content []map[string]interface{}, structured interface{}) string {
+	if len(content) == 0 && structured != nil {
+		data, _ := json.Marshal(structured)
+		return string(data)
+	}
+	parts := make([]string, 0, len(content))
+	for _, item := range content {
+		if text, ok := item["text"].(string); ok && item["type"] == "text" {
+			parts = append(parts, text)
+			continue
+		}
+		data, _ := json.Marshal(item)
+		parts = append(parts, string(data))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// externalClient returns the pooled connection to an external server of a server, opening it if needed
+func (s *MCPService) externalClient(ctx context.Context, serverID string, external models.ExternalServer) (*mcpClient, error) {
+	config, _ := json.Marshal(external)
+	key := serverID + "/" + external.Name
+
+	s.external.mu.Lock()
+	defer s.external.mu.Unlock()
+	if pooled, ok := s.external.clients[key]; ok {
+		if pooled.config == string(config) {
+			return pooled.client, nil
+		}
+		pooled.client.close()
+		delete(s.external.clients, key)
+	}
+
+	client, err := s.connectExternal(ctx, external)
+	if err != nil {
+		return nil, err
+	}
+	s.external.clients[key] = &externalClient{config: string(config), client: client}
+	return client, nil
+}
+
+// dropExternalClient closes a pooled connection unless it was replaced already
+func (s *MCPService) dropExternalClient(serverID string, name string, client *mcpClient) {
+	key := serverID + "/" + name
+
+	s.external.mu.Lock()
+	defer s.external.mu.Unlock()
+	if pooled, ok := s.external.clients[key]; ok && pooled.client == client {
+		client.close()
+		delete(s.external.clients, key)
+	}
+}
+
+// closeExternalClients closes the connections of a server
+func (s *MCPService) closeExternalClients(serverID string) {
+	s.external.mu.Lock()
+	defer s.external.mu.Unlock()
+	for key, pooled := range s.external.clients {
+		if strings.HasPrefix(key, serverID+"/") {
+			pooled.client.close()
+			delete(s.external.clients, key)
+		}
+	}
+}
+
+// connectExternal opens a connection to an external server and initializes it
+func (s *MCPService) connectExternal(ctx context.Context, external models.ExternalServer) (*mcpClient, error) {
+	if err := external.Validate(); err != nil {
+		return nil, err
+	}
+
+	prepare := func(ctx context.Context, req *http.Request) error {
+		for key, value := range external.Headers {
+			req.Header.Set(key, value)
+		}
+		return s.applyAuth(ctx, external.Auth, req)
+	}
+
+	var transport clientTransport
+	switch external.Transport {
+	case models.TransportStreamableHTTP, models.TransportSSE:
+		parsed, err := url.Parse(external.URL)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkHost(parsed.Hostname()); err != nil {
+			return nil, err
+		}
+		if external.Transport == models.TransportSSE {
+			transport, err = dialSSE(ctx, s.httpClient, external.URL, prepare, s.checkHost)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to external server %s: %w", external.Name, err)
+			}
+		} else {
+			transport = &httpTransport{client: s.httpClient, endpoint: external.URL, prepare: prepare}
+		}
+	case models.TransportStdio:
+		s.mu.RLock()
+		allowStdio := s.allowStdio
+		s.mu.RUnlock()
+		if !allowStdio {
+			return nil, ErrStdioNotAllowed
+		}
+		var err error
+		transport, err = startStdio(external.Command, external.Args, external.Env)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start external server %s: %w", external.Name, err)
+		}
+	}
+
+	client := &mcpClient{transport: transport}
+	version, err := client.initialize(ctx)
+	if err != nil {
+		client.close()
+		return nil, fmt.Errorf("failed to initialize external server %s: %w", external.Name, err)
+	}
+	if t, ok := transport.(*httpTransport); ok {
+		t.setProtocolVersion(version)
+	}
+	slog.InfoContext(ctx, "Connected to external MCP server", "external", external.Name, "transport", external.Transport, "protocolVersion", version)
+	return client, nil
+}
@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+const (
+	// latencyWindow is the number of recent latencies kept per hedged tool
+	latencyWindow = 200
+	// minLatencySamples is the number of latencies needed before the percentile replaces the initial delay
+	minLatencySamples = 20
+	// minHedgeDelay bounds the delay of second requests, so that fast upstreams are not called twice
+	minHedgeDelay = 10 * time.Millisecond
+)
+
+// toolLatencies keeps the recent upstream latencies of hedged tools
+type toolLatencies struct {
+	mu      sync.Mutex
+	windows map[string]*latencyRing
+}
+
+// latencyRing holds the last latencyWindow latencies of a tool
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+}
+
+// observe records a latency of the tool with the key
+func (l *toolLatencies) observe(key string, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.windows == nil {
+		l.windows = make(map[string]*latencyRing)
+	}
+	ring, ok := l.windows[key]
+	if !ok {
+		ring = &latencyRing{}
+		l.windows[key] = ring
+	}
+	if len(ring.samples) < latencyWindow {
+		ring.samples = append(ring.samples, latency)
+		return
+	}
+	ring.samples[ring.next] = latency
+	ring.next = (ring.next + 1) % latencyWindow
+}
+
+// percentile returns the percentile p of the recent latencies of the tool with the key, false if
+// fewer than minLatencySamples were observed
+func (l *toolLatencies) percentile(key string, p float64) (time.Duration, bool) {
+	l.mu.Lock()
+	ring, ok := l.windows[key]
+	if !ok || len(ring.samples) < minLatencySamples {
+		l.mu.Unlock()
+		return 0, false
+	}
+	sorted := slices.Clone(ring.samples)
+	l.mu.Unlock()
+
+	slices.Sort(sorted)
+	index := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[max(index, 0)], true
+}
+
+// hedgeDelay returns the time to wait for a response before sending the second request of a tool
+func (s *MCPService) hedgeDelay(key string, hedging *models.Hedging) time.Duration {
+	delay, ok := s.latencies.percentile(key, hedging.PercentileOrDefault())
+	if !ok {
+		delay = hedging.InitialDelay()
+	}
+	return max(delay, minHedgeDelay)
+}
+
+// hedgeAttempt is the outcome of one of the requests of a hedged call
+type hedgeAttempt struct {
+	resp  *http.Response
+	err   error
+	hedge bool
+}
+
+// sendHedged sends req, and a copy of it if no response arrived after the hedge delay of the
+// tool. The first response received in full wins and the other request is canceled. The
+// returned response has its body read already.
+func (s *MCPService) sendHedged(server *models.MCPServer, tool *models.Tool, req *http.Request) (*http.Response, error) {
+	key := server.ID + "/" + tool.Name
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	results := make(chan hedgeAttempt, 2)
+	send := func(r *http.Request, hedge bool) {
+		start := time.Now()
+		resp, err := s.httpClient.Do(r)
+		if err == nil {
+			var body []byte
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err == nil {
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				s.latencies.observe(key, time.Since(start))
+			}
+		}
+		results <- hedgeAttempt{resp: resp, err: err, hedge: hedge}
+	}
+	go send(req.Clone(ctx), false)
+
+	// Requests whose body cannot be sent twice are not hedged
+	canHedge := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	timer := time.NewTimer(s.hedgeDelay(key, tool.Hedging))
+	defer timer.Stop()
+
+	pending := 1
+	hedged := false
+	for {
+		select {
+		case <-timer.C:
+			if !canHedge {
+				continue
+			}
+			second := req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					continue
+				}
+				second.Body = body
+			}
+			hedged = true
+			pending++
+			slog.DebugContext(ctx, "Sending hedged request", "server", server.ID, "tool", tool.Name)
+			go send(second, true)
+		case result := <-results:
+			pending--
+			if result.err != nil && pending > 0 {
+				continue
+			}
+			if hedged {
+				winner := "none"
+				if result.err == nil {
+					winner = "primary"
+					if result.hedge {
+						winner = "hedge"
+					}
+				}
+				metrics.ObserveHedgedRequest(server.Name, tool.Name, winner)
+			}
+			return result.resp, result.err
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// maxRecordedPayload bounds the request and response stored with an invocation
+const maxRecordedPayload = 4096
+
+// InvocationRecorder persists the history of tool invocations
+type InvocationRecorder interface {
+	Create(ctx context.Context, invocation *models.Invocation) error
+}
+
+// EventBroadcaster streams events to the clients following the changes of the gateway
+type EventBroadcaster interface {
+	Broadcast(ctx context.Context, eventType string, entityID string, entityName string, data interface{})
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx identifying the client invoking tools
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Caller returns the client identity stored in ctx, or an empty string
+func Caller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// SetInvocationRecorder sets the recorder storing the history of tool invocations
+func (s *MCPService) SetInvocationRecorder(recorder InvocationRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recorder = recorder
+}
+
+// SetEventBroadcaster sets the streams notified of every tool invocation
+func (s *MCPService) SetEventBroadcaster(broadcaster EventBroadcaster) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.broadcaster = broadcaster
+}
+
+// broadcastInvocation streams a summary of an invocation, in the namespace of its server
+func (s *MCPService) broadcastInvocation(ctx context.Context, server *models.MCPServer, toolName string, statusCode int, err error, duration time.Duration) {
+	s.mu.RLock()
+	broadcaster := s.broadcaster
+	s.mu.RUnlock()
+	if broadcaster == nil {
+		return
+	}
+
+	summary := models.InvocationSummary{
+		Tool:       toolName,
+		Caller:     Caller(ctx),
+		StatusCode: statusCode,
+		Success:    err == nil,
+		DurationMs: duration.Milliseconds(),
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	ctx = namespace.With(ctx, namespace.OrDefault(server.Namespace))
+	broadcaster.Broadcast(ctx, models.EventToolInvoked, server.ID, server.Name, summary)
+}
+
+// recordInvocation stores an invocation in the background so recording never delays the caller
+func (s *MCPService) recordInvocation(ctx context.Context, server *models.MCPServer, toolName string, request []byte, result string, statusCode int, err error, duration time.Duration) {
+	s.mu.RLock()
+	recorder := s.recorder
+	s.mu.RUnlock()
+	if recorder == nil {
+		return
+	}
+
+	invocation := &models.Invocation{
+		ServerID:   server.ID,
+		ServerName: server.Name,
+		Tool:       toolName,
+		Caller:     Caller(ctx),
+		RequestID:  logging.RequestID(ctx),
+		StatusCode: statusCode,
+		Success:    err == nil,
+		DurationMs: duration.Milliseconds(),
+		Request:    truncate(string(request), maxRecordedPayload),
+		Response:   truncate(result, maxRecordedPayload),
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		invocation.Error = truncate(err.Error(), maxRecordedPayload)
+	}
+
+	// Keep the log fields but outlive the request
+	recordCtx := context.WithoutCancel(ctx)
+	trace := traceOf(ctx)
+	go func() {
+		if trace != nil && trace.Request != nil {
+			invocation.Upstream = s.upstreamExchange(server, trace)
+		}
+		recordCtx, cancel := context.WithTimeout(recordCtx, 5*time.Second)
+		defer cancel()
+		if err := recorder.Create(recordCtx, invocation); err != nil {
+			slog.ErrorContext(recordCtx, "Failed to record invocation", "error", err)
+		}
+	}()
+}
+
+// upstreamExchange returns the upstream exchange recorded by trace, with the cookies the upstream
+// sets and the data matching the redaction rules of the server hidden
+func (s *MCPService) upstreamExchange(server *models.MCPServer, trace *ToolTrace) *models.UpstreamExchange {
+	exchange := &models.UpstreamExchange{
+		Method:         trace.Request.Method,
+		URL:            trace.Request.URL,
+		RequestHeaders: trace.Request.Headers,
+		RequestBody:    truncate(s.redact(s.serverRedactions(server), trace.Request.Body), maxRecordedPayload),
+		Status:         trace.UpstreamStatus,
+		Protocol:       trace.UpstreamProto,
+		LatencyMs:      trace.UpstreamLatency.Milliseconds(),
+	}
+	if len(trace.UpstreamHeader) > 0 {
+		exchange.ResponseHeaders = make(map[string]string, len(trace.UpstreamHeader))
+		for key, values := range trace.UpstreamHeader {
+			if http.CanonicalHeaderKey(key) == "Set-Cookie" {
+				exchange.ResponseHeaders[key] = redacted
+			} else if len(values) > 0 {
+				exchange.ResponseHeaders[key] = values[0]
+			}
+		}
+	}
+	if len(trace.UpstreamBody) > 0 {
+		exchange.ResponseBody = truncate(s.redact(s.serverRedactions(server), string(trace.UpstreamBody)), maxRecordedPayload)
+	}
+	return exchange
+}
+
+// truncate shortens s to at most max bytes
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "...(truncated)"
+}
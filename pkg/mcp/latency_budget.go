@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrLatencyBudgetExceeded is returned for the calls cut off at the enforced latency budget of their tool
+var ErrLatencyBudgetExceeded = errors.New("latency budget exceeded")
+
+// withLatencyBudget returns the context of a call of tool, canceled at the target latency if the
+// budget of the tool is enforced
+func withLatencyBudget(ctx context.Context, tool *models.Tool) (context.Context, context.CancelFunc) {
+	if !tool.LatencyBudget.IsEnforced() {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, tool.LatencyBudget.Target())
+}
+
+// checkLatencyBudget records a call of tool that took longer than its latency budget and returns
+// the error of the call, ErrLatencyBudgetExceeded if callCtx was canceled at the target while ctx,
+// the context of the client, was not
+func checkLatencyBudget(ctx, callCtx context.Context, server *models.MCPServer, tool *models.Tool, duration time.Duration, err error) error {
+	budget := tool.LatencyBudget
+	if !budget.IsSet() || duration <= budget.Target() {
+		return err
+	}
+	// Calls cut off at the target fail, unless they returned a partial response
+	cutOff := budget.IsEnforced() && errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	metrics.ObserveLatencyBudgetViolation(server.Name, tool.Name, cutOff)
+	slog.WarnContext(ctx, "Latency budget exceeded", "budgetMs", budget.TargetMs,
+		"durationMs", duration.Milliseconds(), "enforced", cutOff)
+	if cutOff && err != nil {
+		return fmt.Errorf("%w: tool %s did not complete within %s", ErrLatencyBudgetExceeded, tool.ExposedName(), budget.Target())
+	}
+	return err
+}
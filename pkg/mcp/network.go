@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SetNetworkZones sets the named ranges of client addresses the network restrictions of servers
+// may refer to. Invalid entries are skipped.
+func (s *MCPService) SetNetworkZones(zones map[string][]string) {
+	parsed := make(map[string][]netip.Prefix, len(zones))
+	for name, entries := range zones {
+		prefixes := make([]netip.Prefix, 0, len(entries))
+		for _, entry := range entries {
+			prefix, err := models.ParseNetwork(entry)
+			if err != nil {
+				slog.Warn("Skipping invalid network zone entry", "zone", name, "entry", entry, "error", err)
+				continue
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		parsed[name] = prefixes
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.zones = parsed
+}
+
+// checkNetwork returns ErrNetworkNotAllowed if the server restricts its invocations to networks
+// the address of the caller is not part of. Callers without an address and zones missing from
+// the configuration are refused.
+func (s *MCPService) checkNetwork(ctx context.Context, server *models.MCPServer) error {
+	if server.Network == nil {
+		return nil
+	}
+
+	caller := Caller(ctx)
+	addr, err := netip.ParseAddr(caller)
+	if err != nil {
+		return fmt.Errorf("%w: unknown client address", ErrNetworkNotAllowed)
+	}
+	addr = addr.Unmap()
+
+	s.mu.RLock()
+	zones := s.zones
+	s.mu.RUnlock()
+	for _, entry := range server.Network.Allow {
+		if models.IsNetworkZoneName(entry) {
+			prefixes, ok := zones[entry]
+			if !ok {
+				slog.WarnContext(ctx, "Network zone is not configured", "zone", entry)
+			}
+			for _, prefix := range prefixes {
+				if prefix.Contains(addr) {
+					return nil
+				}
+			}
+			continue
+		}
+		if prefix, err := models.ParseNetwork(entry); err == nil && prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNetworkNotAllowed, caller)
+}
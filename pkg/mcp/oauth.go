@@ -0,0 +1,270 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+const (
+	// tokenExpiryMargin stops serving OAuth2 tokens this long before they expire
+	tokenExpiryMargin = 30 * time.Second
+	// tokenRefreshAhead refreshes OAuth2 tokens in the background this long before they expire, or
+	// halfway through their lifetime if it is shorter
+	tokenRefreshAhead = 2 * time.Minute
+	// tokenIdleTimeout drops the OAuth2 tokens no call used for this long instead of refreshing them
+	tokenIdleTimeout = 30 * time.Minute
+)
+
+// oauthToken is a cached OAuth2 access token with what is needed to refresh it
+type oauthToken struct {
+	auth         models.Auth // Profile the token was obtained for
+	fingerprint  string      // Hash of the client secret, a rotated secret invalidates the token
+	accessToken  string
+	obtainedAt   time.Time
+	expiresAt    time.Time
+	lastUsedAt   time.Time
+	refreshes    int
+	refreshError string
+}
+
+// OAuthTokenState is a cached OAuth2 access token, without the token
+type OAuthTokenState struct {
+	TokenURL     string    `json:"tokenUrl"`
+	ClientID     string    `json:"clientId"`
+	Scopes       []string  `json:"scopes,omitempty"`
+	Secret       string    `json:"secret"` // Secret holding the client secret
+	ObtainedAt   time.Time `json:"obtainedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	ExpiresInSec int64     `json:"expiresInSec"` // Negative once expired
+	Valid        bool      `json:"valid"`        // Whether calls are served the token
+	LastUsedAt   time.Time `json:"lastUsedAt"`
+	Refreshes    int       `json:"refreshes"`              // Background refreshes of the token
+	RefreshError string    `json:"refreshError,omitempty"` // Error of the last background refresh if it failed
+}
+
+// tokenKey returns the cache key of the credential of an OAuth2 profile
+func tokenKey(auth *models.Auth) string {
+	return auth.TokenURL + " " + auth.ClientID + " " + strings.Join(auth.Scopes, " ") + " " + auth.Secret
+}
+
+// secretFingerprint returns a hash of a client secret, kept instead of the secret
+func secretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// oauthToken returns an access token obtained with the client credentials grant, cached per
+// credential until shortly before it expires
+func (s *MCPService) oauthToken(ctx context.Context, auth *models.Auth, clientSecret string) (string, error) {
+	key := tokenKey(auth)
+	fingerprint := secretFingerprint(clientSecret)
+	now := time.Now()
+
+	s.tokenMu.Lock()
+	if token, ok := s.tokens[key]; ok && token.fingerprint == fingerprint && now.Before(token.expiresAt.Add(-tokenExpiryMargin)) {
+		token.lastUsedAt = now
+		s.tokenMu.Unlock()
+		return token.accessToken, nil
+	}
+	s.tokenMu.Unlock()
+
+	accessToken, expiresIn, err := s.requestToken(ctx, auth, clientSecret)
+	if err != nil {
+		return "", err
+	}
+
+	// Tokens without a lifetime are requested again for every call
+	if expiresIn > 0 {
+		obtainedAt := time.Now()
+		s.tokenMu.Lock()
+		s.tokens[key] = &oauthToken{
+			auth:        *auth,
+			fingerprint: fingerprint,
+			accessToken: accessToken,
+			obtainedAt:  obtainedAt,
+			expiresAt:   obtainedAt.Add(expiresIn),
+			lastUsedAt:  obtainedAt,
+		}
+		s.tokenMu.Unlock()
+	}
+
+	slog.DebugContext(ctx, "Obtained OAuth2 token", "tokenUrl", auth.TokenURL, "clientId", auth.ClientID, "expiresIn", expiresIn)
+	return accessToken, nil
+}
+
+// requestToken requests an access token with the client credentials grant, returning its lifetime,
+// 0 if the response has none
+func (s *MCPService) requestToken(ctx context.Context, auth *models.Auth, clientSecret string) (string, time.Duration, error) {
+	tokenURL, err := url.Parse(auth.TokenURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid OAuth2 token URL: %w", err)
+	}
+	if err := s.checkHost(tokenURL.Hostname()); err != nil {
+		return "", 0, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {auth.ClientID},
+		"client_secret": {clientSecret},
+	}
+	if len(auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("OAuth2 token request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.AccessToken == "" {
+		return "", 0, fmt.Errorf("OAuth2 token response has no access_token")
+	}
+	return result.AccessToken, time.Duration(result.ExpiresIn) * time.Second, nil
+}
+
+// RefreshTokens requests new access tokens for the cached ones expiring soon after now, with the
+// current value of their client secret, so that calls never wait for a token. Tokens no call used
+// recently are dropped instead. A failed refresh keeps the token until it expires, the calls then
+// request a new one themselves.
+func (s *MCPService) RefreshTokens(ctx context.Context, now time.Time) {
+	type dueToken struct {
+		key  string
+		auth models.Auth
+	}
+	var due []dueToken
+
+	s.tokenMu.Lock()
+	for key, token := range s.tokens {
+		if now.Sub(token.lastUsedAt) > tokenIdleTimeout {
+			delete(s.tokens, key)
+			continue
+		}
+		ahead := min(tokenRefreshAhead, token.expiresAt.Sub(token.obtainedAt)/2)
+		if !now.Before(token.expiresAt.Add(-ahead)) {
+			due = append(due, dueToken{key: key, auth: token.auth})
+		}
+	}
+	s.tokenMu.Unlock()
+
+	for _, token := range due {
+		err := s.refreshToken(ctx, token.key, &token.auth)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to refresh OAuth2 token", "tokenUrl", token.auth.TokenURL, "clientId", token.auth.ClientID, "error", err)
+		}
+	}
+}
+
+// refreshToken replaces the cached token of key with a new one, or records why it could not
+func (s *MCPService) refreshToken(ctx context.Context, key string, auth *models.Auth) error {
+	clientSecret, err := s.secret(ctx, auth.Secret)
+	var accessToken string
+	var expiresIn time.Duration
+	if err == nil {
+		accessToken, expiresIn, err = s.requestToken(ctx, auth, clientSecret)
+	}
+	if err == nil && expiresIn <= 0 {
+		err = fmt.Errorf("OAuth2 token response has no expires_in")
+	}
+
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+	token, ok := s.tokens[key]
+	if !ok {
+		return err
+	}
+	if err != nil {
+		token.refreshError = err.Error()
+		return err
+	}
+	token.fingerprint = secretFingerprint(clientSecret)
+	token.accessToken = accessToken
+	token.obtainedAt = time.Now()
+	token.expiresAt = token.obtainedAt.Add(expiresIn)
+	token.refreshes++
+	token.refreshError = ""
+	slog.DebugContext(ctx, "Refreshed OAuth2 token", "tokenUrl", auth.TokenURL, "clientId", auth.ClientID, "expiresIn", expiresIn)
+	return nil
+}
+
+// StartTokenRefresher refreshes the cached OAuth2 tokens every interval until stop is called
+func (s *MCPService) StartTokenRefresher(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				s.RefreshTokens(context.Background(), now)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// OAuthTokens returns the cached OAuth2 tokens, without the tokens, by token URL and client
+func (s *MCPService) OAuthTokens() []OAuthTokenState {
+	now := time.Now()
+	s.tokenMu.Lock()
+	states := make([]OAuthTokenState, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		states = append(states, OAuthTokenState{
+			TokenURL:     token.auth.TokenURL,
+			ClientID:     token.auth.ClientID,
+			Scopes:       slices.Clone(token.auth.Scopes),
+			Secret:       token.auth.Secret,
+			ObtainedAt:   token.obtainedAt,
+			ExpiresAt:    token.expiresAt,
+			ExpiresInSec: int64(token.expiresAt.Sub(now).Seconds()),
+			Valid:        now.Before(token.expiresAt.Add(-tokenExpiryMargin)),
+			LastUsedAt:   token.lastUsedAt,
+			Refreshes:    token.refreshes,
+			RefreshError: token.refreshError,
+		})
+	}
+	s.tokenMu.Unlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].TokenURL != states[j].TokenURL {
+			return states[i].TokenURL < states[j].TokenURL
+		}
+		return states[i].ClientID < states[j].ClientID
+	})
+	return states
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// TruncatedHeader is set to true on the responses of the REST tool calls whose result is the
+// partial upstream response received before the timeout
+const TruncatedHeader = "X-Truncated"
+
+type truncationKey struct{}
+
+// WithTruncation returns a copy of ctx in which tool calls returning a partial upstream
+// response set truncated
+func WithTruncation(ctx context.Context, truncated *bool) context.Context {
+	return context.WithValue(ctx, truncationKey{}, truncated)
+}
+
+// markTruncated reports in ctx that the result of the call is a partial upstream response
+func markTruncated(ctx context.Context) {
+	if truncated, _ := ctx.Value(truncationKey{}).(*bool); truncated != nil {
+		*truncated = true
+	}
+	if trace := traceOf(ctx); trace != nil {
+		trace.Truncated = true
+	}
+}
+
+// keepsPartial reports whether the body received before reading the response failed with err is
+// returned instead of the error: the tool must allow it, the upstream must have answered with a
+// successful status and sent part of the body, and the read must have hit a timeout. Results
+// redacted with path rules are never partial, as an incomplete JSON document cannot be redacted
+// field by field.
+func keepsPartial(tool *models.Tool, rules []models.RedactionRule, status int, body []byte, err error) bool {
+	if !tool.PartialOnTimeout || len(body) == 0 || status < 200 || status >= 300 || !isTimeout(err) {
+		return false
+	}
+	for _, rule := range rules {
+		if rule.Path != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isTimeout reports whether err is a deadline or timeout error
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+type authorizationKey struct{}
+
+// WithAuthorization returns a copy of ctx carrying the Authorization header of the caller, which
+// the passthrough policies of servers and tools may forward upstream
+func WithAuthorization(ctx context.Context, authorization string) context.Context {
+	return context.WithValue(ctx, authorizationKey{}, authorization)
+}
+
+// callerAuthorization returns the Authorization header of the caller in ctx, or an empty string
+func callerAuthorization(ctx context.Context) string {
+	authorization, _ := ctx.Value(authorizationKey{}).(string)
+	return authorization
+}
+
+// applyAuthPassthrough returns a copy of tool and params applying the Authorization passthrough
+// policy of the tool or its server. The Authorization of the headers param, or else the one of the
+// request of the call, is removed from the params and then forwarded, replaced by the credential
+// of the policy, or dropped. Without a policy, tool and params are returned unchanged.
+func applyAuthPassthrough(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (*models.Tool, map[string]interface{}) {
+	policy := server.AuthPassthroughOf(tool)
+	if policy == nil {
+		return tool, params
+	}
+
+	authorization := callerAuthorization(ctx)
+	if clientHeaders, ok := params["headers"].(map[string]interface{}); ok {
+		remaining := make(map[string]interface{}, len(clientHeaders))
+		for name, value := range clientHeaders {
+			if http.CanonicalHeaderKey(name) == "Authorization" {
+				if value, ok := value.(string); ok && value != "" {
+					authorization = value
+				}
+				continue
+			}
+			remaining[name] = value
+		}
+		params = maps.Clone(params)
+		params["headers"] = remaining
+	}
+
+	resolved := *tool
+	switch policy.Mode {
+	case models.PassthroughForward:
+		if authorization == "" {
+			break
+		}
+		headers := make(map[string]string, len(tool.RequestTemplate.Headers)+1)
+		for name, value := range tool.RequestTemplate.Headers {
+			if http.CanonicalHeaderKey(name) != "Authorization" {
+				headers[name] = value
+			}
+		}
+		headers["Authorization"] = authorization
+		resolved.RequestTemplate.Headers = headers
+		// The header of the caller takes the place of the one of the auth profile
+		if tool.Auth.SetsAuthorization() {
+			resolved.Auth = nil
+		}
+	case models.PassthroughReplace:
+		resolved.Auth = policy.Credential
+	}
+	slog.DebugContext(ctx, "Applied auth passthrough", "mode", policy.Mode, "callerAuthorization", authorization != "")
+	return &resolved, params
+}
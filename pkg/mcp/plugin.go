@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/plugin"
+)
+
+// PluginRunner applies WASM plugins to outgoing requests and upstream responses
+type PluginRunner interface {
+	OnRequest(ctx context.Context, pluginIDs []string, req *plugin.Request) (*plugin.Request, error)
+	OnResponse(ctx context.Context, pluginIDs []string, resp *plugin.Response) (*plugin.Response, error)
+}
+
+// SetPluginRunner sets the runner executing the plugins attached to servers and tools
+func (s *MCPService) SetPluginRunner(runner PluginRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plugins = runner
+}
+
+// toolPlugins returns the plugins of the server followed by those of the tool
+func toolPlugins(server *models.MCPServer, tool *models.Tool) []string {
+	ids := make([]string, 0, len(server.Plugins)+len(tool.Plugins))
+	ids = append(ids, server.Plugins...)
+	return append(ids, tool.Plugins...)
+}
+
+// applyRequestPlugins lets the plugins rewrite req, returning the request to send
+func (s *MCPService) applyRequestPlugins(ctx context.Context, pluginIDs []string, req *http.Request) (*http.Request, error) {
+	s.mu.RLock()
+	runner := s.plugins
+	s.mu.RUnlock()
+	if runner == nil || len(pluginIDs) == 0 {
+		return req, nil
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	in := &plugin.Request{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: flattenHeader(req.Header),
+		Body:    string(body),
+	}
+	out, err := runner.OnRequest(ctx, pluginIDs, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if out.Body != "" {
+		reqBody = bytes.NewBufferString(out.Body)
+	}
+	rewritten, err := http.NewRequestWithContext(ctx, out.Method, out.URL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range out.Headers {
+		rewritten.Header.Set(key, value)
+	}
+
+	return rewritten, nil
+}
+
+// applyResponsePlugins lets the plugins transform the upstream response.
+// Response plugins run in reverse order so the tool plugins see the raw upstream response first.
+func (s *MCPService) applyResponsePlugins(ctx context.Context, pluginIDs []string, statusCode int, header http.Header, body []byte) (int, []byte, error) {
+	s.mu.RLock()
+	runner := s.plugins
+	s.mu.RUnlock()
+	if runner == nil || len(pluginIDs) == 0 {
+		return statusCode, body, nil
+	}
+
+	reversed := make([]string, len(pluginIDs))
+	for i, id := range pluginIDs {
+		reversed[len(pluginIDs)-1-i] = id
+	}
+
+	out, err := runner.OnResponse(ctx, reversed, &plugin.Response{
+		StatusCode: statusCode,
+		Headers:    flattenHeader(header),
+		Body:       string(body),
+	})
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	return out.StatusCode, []byte(out.Body), nil
+}
+
+// flattenHeader keeps the first value of every header
+func flattenHeader(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for key := range header {
+		flat[key] = header.Get(key)
+	}
+	return flat
+}
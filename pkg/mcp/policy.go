@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/ratelimit"
+)
+
+var (
+	ErrRateLimited    = errors.New("rate limit exceeded")
+	ErrHostNotAllowed = errors.New("upstream host not allowed")
+	ErrQuotaExceeded  = errors.New("daily tool call quota exceeded")
+	// ErrKeyQuotaExceeded is returned when a call would exceed a quota of the API key of the caller
+	ErrKeyQuotaExceeded = errors.New("API key quota exceeded")
+	// ErrHeaderNotAllowed is returned when a client passes headers the header policy of the server rejects
+	ErrHeaderNotAllowed = errors.New("header not allowed")
+	// ErrNetworkNotAllowed is returned when the address of the caller is outside the networks the server allows
+	ErrNetworkNotAllowed = errors.New("client network not allowed")
+)
+
+// Response headers reporting the rate limit of the caller of a tool invocation
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"     // Calls allowed in a burst
+	RateLimitRemainingHeader = "X-RateLimit-Remaining" // Calls allowed right now
+	RateLimitResetHeader     = "X-RateLimit-Reset"     // Seconds until the limit is fully restored
+	RetryAfterHeader         = "Retry-After"           // Seconds until the next call is allowed, on rate limited calls
+)
+
+// RateLimiter limits the tool invocations of each caller
+type RateLimiter interface {
+	// Take consumes a call of key, returning false if its rate limit is used up, and the state
+	// of the limit of key after the call
+	Take(key string) (bool, ratelimit.State)
+}
+
+// SetRateLimiter sets the limiter applied to the tool invocations of every caller
+func (s *MCPService) SetRateLimiter(limiter RateLimiter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limiter = limiter
+}
+
+// ToolCallCounter counts the tool calls of each tenant against its daily quota
+type ToolCallCounter interface {
+	// CountToolCall counts a tool call of the tenant, returning false if its quota is used up
+	CountToolCall(ctx context.Context, tenant string) (bool, error)
+}
+
+// SetToolCallCounter sets the counter enforcing the daily tool call quotas of tenants
+func (s *MCPService) SetToolCallCounter(counter ToolCallCounter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter = counter
+}
+
+// SetAllowedHosts restricts the hosts tools may call. Entries are host names,
+// "*.example.com" matches any subdomain; an empty list allows every host.
+func (s *MCPService) SetAllowedHosts(hosts []string) {
+	allowed := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		allowed = append(allowed, strings.ToLower(strings.TrimSpace(host)))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedHosts = allowed
+}
+
+// allowCaller reports whether the rate limit lets the caller of ctx invoke a tool now, and
+// reports the limit in the response header of ctx while limiting is enabled
+func (s *MCPService) allowCaller(ctx context.Context) bool {
+	s.mu.RLock()
+	limiter := s.limiter
+	s.mu.RUnlock()
+	if limiter == nil {
+		return true
+	}
+
+	allowed, state := limiter.Take(Caller(ctx))
+	header, _ := ctx.Value(responseHeaderKey{}).(http.Header)
+	if header != nil && state.Limit > 0 {
+		header.Set(RateLimitLimitHeader, strconv.Itoa(state.Limit))
+		header.Set(RateLimitRemainingHeader, strconv.Itoa(state.Remaining))
+		header.Set(RateLimitResetHeader, strconv.Itoa(state.ResetSeconds()))
+		if !allowed {
+			header.Set(RetryAfterHeader, strconv.Itoa(state.RetryAfterSeconds()))
+		}
+	}
+	return allowed
+}
+
+// countToolCall returns ErrQuotaExceeded if the tenant has used up its daily tool calls.
+// Calls are let through if they cannot be counted, so an unavailable database only disables quotas.
+func (s *MCPService) countToolCall(ctx context.Context, tenant string) error {
+	s.mu.RLock()
+	counter := s.counter
+	s.mu.RUnlock()
+	if counter == nil {
+		return nil
+	}
+
+	allowed, err := counter.CountToolCall(ctx, tenant)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to count tool call", "tenant", tenant, "error", err)
+		return nil
+	}
+	if !allowed {
+		return fmt.Errorf("%w for tenant %s", ErrQuotaExceeded, tenant)
+	}
+	return nil
+}
+
+// checkHost returns ErrHostNotAllowed if host is not in the upstream allowlist
+func (s *MCPService) checkHost(host string) error {
+	s.mu.RLock()
+	allowed := s.allowedHosts
+	s.mu.RUnlock()
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	host = strings.ToLower(host)
+	for _, pattern := range allowed {
+		if hostMatches(pattern, host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
+
+// hostMatches reports whether a lowercase host name matches a pattern: the host itself, or
+// "*.example.com" for its subdomains
+func hostMatches(pattern string, host string) bool {
+	if pattern == host {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(pattern, "*")
+	return ok && strings.HasSuffix(host, suffix)
+}
+
+// ErrorStatus returns the HTTP status reported to clients for a tool invocation error
+func ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrRateLimited), errors.Is(err, ErrQuotaExceeded), errors.Is(err, ErrKeyQuotaExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrHostNotAllowed), errors.Is(err, ErrStdioNotAllowed), errors.Is(err, ErrNetworkNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, ErrHeaderNotAllowed), errors.Is(err, ErrUnsafeParam), errors.Is(err, ErrInvalidParams):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrSourceInactive), errors.Is(err, ErrToolDisabled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrLatencyBudgetExceeded), errors.Is(err, ErrCallTimeout):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorCode returns the machine-readable code reported to clients for a tool invocation error,
+// the category of mapped upstream errors, or an empty string if the error has none
+func ErrorCode(err error) string {
+	var upstream *UpstreamError
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, ErrKeyQuotaExceeded):
+		return "key_quota_exceeded"
+	case errors.Is(err, ErrHostNotAllowed):
+		return "host_not_allowed"
+	case errors.Is(err, ErrStdioNotAllowed):
+		return "stdio_not_allowed"
+	case errors.Is(err, ErrNetworkNotAllowed):
+		return "network_not_allowed"
+	case errors.Is(err, ErrHeaderNotAllowed):
+		return "header_not_allowed"
+	case errors.Is(err, ErrUnsafeParam):
+		return "unsafe_param"
+	case errors.Is(err, ErrInvalidParams):
+		return "invalid_params"
+	case errors.Is(err, ErrSourceInactive):
+		return "source_inactive"
+	case errors.Is(err, ErrToolDisabled):
+		return "tool_disabled"
+	case errors.Is(err, ErrLatencyBudgetExceeded):
+		return "latency_budget_exceeded"
+	case errors.Is(err, ErrCallTimeout):
+		return "call_timeout"
+	case errors.Is(err, ErrServerNotFound):
+		return "server_not_found"
+	case errors.Is(err, ErrToolNotFound):
+		return "tool_not_found"
+	case errors.As(err, &upstream):
+		return upstream.Category
+	default:
+		return ""
+	}
+}
+
+// applyHeaderPolicy returns a copy of tool sending the default headers of its server under its
+// own, and the params without the client headers the server does not allow. It returns
+// ErrHeaderNotAllowed instead if the server rejects the calls passing them.
+func applyHeaderPolicy(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (*models.Tool, map[string]interface{}, error) {
+	policy := server.Headers
+	if policy == nil {
+		return tool, params, nil
+	}
+
+	if len(policy.Defaults) > 0 {
+		headers := make(map[string]string, len(policy.Defaults)+len(tool.RequestTemplate.Headers))
+		for name, value := range policy.Defaults {
+			headers[http.CanonicalHeaderKey(name)] = value
+		}
+		for name, value := range tool.RequestTemplate.Headers {
+			headers[http.CanonicalHeaderKey(name)] = value
+		}
+		resolved := *tool
+		resolved.RequestTemplate.Headers = headers
+		tool = &resolved
+	}
+
+	clientHeaders, ok := params["headers"].(map[string]interface{})
+	if !ok {
+		return tool, params, nil
+	}
+	allowed := make(map[string]interface{}, len(clientHeaders))
+	var dropped []string
+	for name, value := range clientHeaders {
+		if policy.Allows(name) {
+			allowed[name] = value
+		} else {
+			dropped = append(dropped, name)
+		}
+	}
+	if len(dropped) == 0 {
+		return tool, params, nil
+	}
+
+	slices.Sort(dropped)
+	if policy.Reject {
+		return nil, nil, fmt.Errorf("%w: %s", ErrHeaderNotAllowed, strings.Join(dropped, ", "))
+	}
+	slog.DebugContext(ctx, "Dropped client headers not allowed by the server", "headers", dropped)
+	params = maps.Clone(params)
+	params["headers"] = allowed
+	return tool, params, nil
+}
@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// missing stands for the elements of a projected array that have none of the included fields
+type missing struct{}
+
+// requestedFields takes the _fields param out of the params of a selectable tool. The param is
+// a list of paths, or a comma separated string of paths.
+func requestedFields(tool *models.Tool, params map[string]interface{}) ([]string, map[string]interface{}) {
+	if tool.Projection == nil || !tool.Projection.Selectable {
+		return nil, params
+	}
+	value, ok := params[models.FieldsParam]
+	if !ok {
+		return nil, params
+	}
+
+	rest := make(map[string]interface{}, len(params)-1)
+	for name, param := range params {
+		if name != models.FieldsParam {
+			rest[name] = param
+		}
+	}
+	fields := []string{}
+	switch v := value.(type) {
+	case string:
+		for _, field := range strings.Split(v, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	case []interface{}:
+		for _, field := range v {
+			if s, ok := field.(string); ok && s != "" {
+				fields = append(fields, s)
+			}
+		}
+	}
+	return fields, rest
+}
+
+// project trims a tool result to the fields of the projection of the tool, the requested fields
+// replacing its include paths. Results that are not JSON are returned unchanged.
+func project(projection *models.Projection, fields []string, result string) string {
+	if projection.IsEmpty() {
+		return result
+	}
+	include := projection.Include
+	if len(fields) > 0 {
+		include = fields
+	}
+	if len(include) == 0 && len(projection.Exclude) == 0 {
+		return result
+	}
+
+	var document interface{}
+	decoder := json.NewDecoder(strings.NewReader(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return result
+	}
+
+	if len(include) > 0 {
+		var projected interface{} = missing{}
+		for _, path := range include {
+			if picked, ok := pickPath(document, models.SplitFieldPath(path)); ok {
+				projected = mergeProjected(projected, picked)
+			}
+		}
+		switch document.(type) {
+		case map[string]interface{}:
+			projected = mergeProjected(map[string]interface{}{}, projected)
+		case []interface{}:
+			projected = mergeProjected([]interface{}{}, projected)
+		}
+		document = dropMissing(projected)
+	}
+	for _, path := range projection.Exclude {
+		document = removePath(document, models.SplitFieldPath(path))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return result
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// pickPath returns the part of a value holding the fields at a path, keeping its structure
+func pickPath(value interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return nil, false
+		}
+		picked, ok := pickPath(child, path[1:])
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{path[0]: picked}, true
+	case []interface{}:
+		// Keep the positions of the elements so that the picks of several paths merge
+		picked := make([]interface{}, len(v))
+		found := false
+		index, err := strconv.Atoi(path[0])
+		for i, element := range v {
+			picked[i] = missing{}
+			if path[0] != "#" && (err != nil || i != index) {
+				continue
+			}
+			if p, ok := pickPath(element, path[1:]); ok {
+				picked[i] = p
+				found = true
+			}
+		}
+		return picked, found
+	}
+	return nil, false
+}
+
+// mergeProjected merges the picks of two paths of the same document
+func mergeProjected(a, b interface{}) interface{} {
+	if _, ok := a.(missing); ok {
+		return b
+	}
+	if _, ok := b.(missing); ok {
+		return a
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			merged := make(map[string]interface{}, len(av)+len(bv))
+			for key, value := range av {
+				merged[key] = value
+			}
+			for key, value := range bv {
+				if existing, ok := merged[key]; ok {
+					merged[key] = mergeProjected(existing, value)
+				} else {
+					merged[key] = value
+				}
+			}
+			return merged
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok && len(av) == len(bv) {
+			merged := make([]interface{}, len(av))
+			for i := range av {
+				merged[i] = mergeProjected(av[i], bv[i])
+			}
+			return merged
+		}
+	}
+	return b
+}
+
+// dropMissing removes the placeholders of the elements without included fields
+func dropMissing(value interface{}) interface{} {
+	switch v := value.(type) {
+	case missing:
+		return nil
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = dropMissing(child)
+		}
+	case []interface{}:
+		kept := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			if _, ok := element.(missing); !ok {
+				kept = append(kept, dropMissing(element))
+			}
+		}
+		return kept
+	}
+	return value
+}
+
+// removePath removes the fields at a path of a value
+func removePath(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+		} else if child, ok := v[path[0]]; ok {
+			v[path[0]] = removePath(child, path[1:])
+		}
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if len(path) == 1 {
+			switch {
+			case path[0] == "#":
+				return []interface{}{}
+			case err == nil && index >= 0 && index < len(v):
+				return append(v[:index:index], v[index+1:]...)
+			}
+			return v
+		}
+		for i, element := range v {
+			if path[0] == "#" || err == nil && i == index {
+				v[i] = removePath(element, path[1:])
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Reconcile compares the registered servers with the repository, refreshing those
+// with a different version or status and unregistering deleted and inactive ones
+func (s *MCPService) Reconcile(ctx context.Context, repo repository.MCPServerRepository) error {
+	s.mu.RLock()
+	cached := make([]*models.MCPServer, 0, len(s.servers))
+	for _, server := range s.servers {
+		cached = append(cached, server)
+	}
+	s.mu.RUnlock()
+
+	for _, server := range cached {
+		current, err := repo.GetByID(ctx, server.ID)
+		if err == repository.ErrNotFound {
+			s.UnregisterServer(server.ID)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if current.Status == "inactive" || current.Status == "archived" {
+			s.UnregisterServer(server.ID)
+			continue
+		}
+		if current.Version != server.Version || current.Status != server.Status {
+			if s.RefreshServer(current) {
+				slog.InfoContext(ctx, "Refreshed stale MCP server", "id", current.ID,
+					"cachedVersion", server.Version, "version", current.Version)
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartReconciler reconciles the registered servers with the repository every interval until stop is called
+func (s *MCPService) StartReconciler(repo repository.MCPServerRepository, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.Reconcile(context.Background(), repo); err != nil {
+					slog.Error("Failed to reconcile MCP servers", "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// ReloadServer applies a change of the server made elsewhere: deleted, inactive and archived
+// servers are unregistered, active ones are registered with their current definition
+func (s *MCPService) ReloadServer(ctx context.Context, repo repository.MCPServerRepository, id string) error {
+	server, err := repo.GetByID(ctx, id)
+	if err == repository.ErrNotFound {
+		s.UnregisterServer(id)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	switch server.Status {
+	case "active":
+		return s.RegisterServer(server)
+	case "inactive", "archived":
+		s.UnregisterServer(id)
+	default:
+		s.RefreshServer(server)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+var (
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	creditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// SetRedactions sets the redaction rules applied to the tool results of every server, before
+// the rules of the server
+func (s *MCPService) SetRedactions(rules []models.RedactionRule) error {
+	if err := models.ValidateRedactions(rules); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.redactions = append([]models.RedactionRule(nil), rules...)
+	return nil
+}
+
+// serverRedactions returns the global redaction rules followed by those of the server
+func (s *MCPService) serverRedactions(server *models.MCPServer) []models.RedactionRule {
+	s.mu.RLock()
+	rules := s.redactions
+	s.mu.RUnlock()
+	if len(server.Redactions) == 0 {
+		return rules
+	}
+	return append(append([]models.RedactionRule(nil), rules...), server.Redactions...)
+}
+
+// redact applies redaction rules to a tool result. JSON results are redacted field by field and
+// keep their formatting unless something was redacted; other results are redacted as text.
+func (s *MCPService) redact(rules []models.RedactionRule, result string) string {
+	if len(rules) == 0 {
+		return result
+	}
+
+	var document interface{}
+	decoder := json.NewDecoder(strings.NewReader(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		redacted, _ := s.redactText(rules, result)
+		return redacted
+	}
+
+	changed := false
+	for _, rule := range rules {
+		if rule.Path != "" {
+			var redacted bool
+			document, redacted = redactPath(document, strings.Split(rule.Path, "."), rule.ReplacementText())
+			changed = changed || redacted
+		}
+	}
+	if s.redactStrings(rules, &document) {
+		changed = true
+	}
+	if !changed {
+		return result
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return result
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactPath replaces the values at a path of a JSON document
+func redactPath(value interface{}, path []string, replacement string) (interface{}, bool) {
+	if len(path) == 0 {
+		return replacement, true
+	}
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path[0] != "*" && path[0] != key {
+				continue
+			}
+			var redacted bool
+			v[key], redacted = redactPath(child, path[1:], replacement)
+			changed = changed || redacted
+		}
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		for i, element := range v {
+			var redacted bool
+			switch {
+			case path[0] == "*":
+				v[i], redacted = redactPath(element, path[1:], replacement)
+			case err == nil:
+				if i == index {
+					v[i], redacted = redactPath(element, path[1:], replacement)
+				}
+			default:
+				// Look for the field in every element
+				v[i], redacted = redactPath(element, path, replacement)
+			}
+			changed = changed || redacted
+		}
+	}
+	return value, changed
+}
+
+// redactStrings applies the pattern and builtin rules to every string of a JSON document
+func (s *MCPService) redactStrings(rules []models.RedactionRule, value *interface{}) bool {
+	changed := false
+	switch v := (*value).(type) {
+	case string:
+		redacted, ok := s.redactText(rules, v)
+		if ok {
+			*value = redacted
+			changed = true
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			if s.redactStrings(rules, &child) {
+				v[key] = child
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if s.redactStrings(rules, &v[i]) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// redactText applies the pattern and builtin rules to a text, reporting whether it changed
+func (s *MCPService) redactText(rules []models.RedactionRule, text string) (string, bool) {
+	redacted := text
+	for _, rule := range rules {
+		switch {
+		case rule.Builtin == models.RedactEmail:
+			redacted = emailPattern.ReplaceAllLiteralString(redacted, rule.ReplacementText())
+		case rule.Builtin == models.RedactCreditCard:
+			// Only replace the numbers passing the Luhn check
+			redacted = creditCardPattern.ReplaceAllStringFunc(redacted, func(number string) string {
+				if luhn(number) {
+					return rule.ReplacementText()
+				}
+				return number
+			})
+		case rule.Pattern != "":
+			if pattern := s.redactionPattern(rule.Pattern); pattern != nil {
+				redacted = pattern.ReplaceAllLiteralString(redacted, rule.ReplacementText())
+			}
+		}
+	}
+	return redacted, redacted != text
+}
+
+// redactionPattern returns the compiled pattern of a rule, nil if it is invalid
+func (s *MCPService) redactionPattern(expr string) *regexp.Regexp {
+	if cached, ok := s.redactionPatterns.Load(expr); ok {
+		return cached.(*regexp.Regexp)
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil
+	}
+	s.redactionPatterns.Store(expr, pattern)
+	return pattern
+}
+
+// luhn reports whether the digits of a number pass the Luhn checksum of card numbers
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
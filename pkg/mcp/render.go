@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrNotRenderable is returned when rendering a tool that does not send an HTTP request itself
+var ErrNotRenderable = errors.New("only tools calling an HTTP interface can be rendered")
+
+// ToolRendering is the upstream request and the result a tool call would produce, rendered from
+// sample params and a sample upstream response without calling the upstream
+type ToolRendering struct {
+	Request  *ResolvedRequest `json:"request"`            // Upstream request, credentials redacted
+	Response *string          `json:"response,omitempty"` // Result rendered from the sample response, nil without one
+}
+
+// RenderTool renders the request of a tool of a server from params, with the param mapping,
+// header policy, environment, pre script and request template applied like for a call. The
+// credentials of the auth profile are shown redacted rather than fetched, and request plugins are
+// not run. With a sample upstream response body, it also renders the result through the post
+// script and the response template.
+func (s *MCPService) RenderTool(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}, response []byte) (*ToolRendering, error) {
+	if tool.External != "" || tool.Source != "" || tool.IsChained() || tool.IsWebSocket() {
+		return nil, ErrNotRenderable
+	}
+
+	params = tool.UpstreamParams(params)
+	tool, params = applyAuthPassthrough(ctx, server, tool, params)
+	tool, params, err := applyHeaderPolicy(ctx, server, tool, params)
+	if err != nil {
+		return nil, err
+	}
+	tool, err = s.applyEnvironment(ctx, server, tool)
+	if err != nil {
+		return nil, err
+	}
+	var scriptHeaders map[string]string
+	if tool.PreScript != "" {
+		scriptHeaders, err = s.scripts.RunPre(ctx, tool.PreScript, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Render without the auth profile, which may fetch a token
+	unauthenticated := *tool
+	unauthenticated.Auth = nil
+	req, err := s.createRequest(ctx, &unauthenticated, params)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range scriptHeaders {
+		req.Header.Set(key, value)
+	}
+	previewAuth(tool.Auth, req)
+	rendering := &ToolRendering{Request: resolveRequest(req, tool.Auth)}
+
+	if response != nil {
+		body := response
+		if tool.PostScript != "" {
+			body, err = s.scripts.RunPost(ctx, tool.PostScript, http.StatusOK, map[string]string{}, body)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result, err := s.processResponse(tool, body)
+		if err != nil {
+			return nil, err
+		}
+		rendering.Response = &result
+	}
+	return rendering, nil
+}
+
+// previewAuth sets where the auth profile puts its credential, which resolveRequest redacts
+func previewAuth(auth *models.Auth, req *http.Request) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case models.AuthBasic, models.AuthBearer, models.AuthOAuth2:
+		req.Header.Set("Authorization", redacted)
+	case models.AuthAPIKeyHeader:
+		req.Header.Set(auth.Name, redacted)
+	case models.AuthAPIKeyQuery:
+		q := req.URL.Query()
+		q.Set(auth.Name, redacted)
+		req.URL.RawQuery = q.Encode()
+	}
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// maxRevalidationBody is the largest upstream response body kept for revalidation
+const maxRevalidationBody = 1 << 20
+
+// cachedResponse is an upstream response kept to be served again when the upstream answers a
+// conditional request with 304 Not Modified
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	statusCode   int
+	header       http.Header
+	body         []byte
+	lastUsed     time.Time
+}
+
+// responseCache keeps the last response of the GET calls of each tool by URL and request headers
+type responseCache struct {
+	mu         sync.Mutex
+	maxEntries int // 0 disables revalidation
+	entries    map[string]*cachedResponse
+}
+
+// SetRevalidation keeps up to maxEntries upstream responses carrying an ETag or a Last-Modified
+// header, and sends If-None-Match or If-Modified-Since on the next identical GET call of the
+// tool. A maxEntries of 0 disables revalidation and drops the kept responses.
+func (s *MCPService) SetRevalidation(maxEntries int) {
+	s.responses.mu.Lock()
+	defer s.responses.mu.Unlock()
+
+	s.responses.maxEntries = maxEntries
+	if maxEntries == 0 {
+		s.responses.entries = make(map[string]*cachedResponse)
+		return
+	}
+	for len(s.responses.entries) > maxEntries {
+		s.responses.evict()
+	}
+}
+
+// revalidationKey identifies the GET calls of a tool that get the same response: the same URL
+// with the same headers, so that callers with different credentials never share a response. The
+// forwarded request ID differs on every call and is left out.
+func revalidationKey(server *models.MCPServer, tool *models.Tool, req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(server.ID + "\n" + tool.Name + "\n" + req.URL.String() + "\n"))
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if name != http.CanonicalHeaderKey(logging.RequestIDHeader) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		hash.Write([]byte(name + ": " + strings.Join(req.Header[name], ", ") + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// revalidate adds the validators of the kept response of the request to it and returns that
+// response, or nil if there is none. Requests already carrying a condition are left unchanged.
+func (c *responseCache) revalidate(key string, req *http.Request) *cachedResponse {
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	cached.lastUsed = time.Now()
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
+	}
+	return cached
+}
+
+// store keeps a successful response with validators for the next call of the request. The kept
+// response is dropped if the new one cannot be revalidated or the upstream forbids storing it.
+func (c *responseCache) store(key string, resp *http.Response, body []byte) {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	storable := resp.StatusCode == http.StatusOK && (etag != "" || lastModified != "") && len(body) <= maxRevalidationBody &&
+		!strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !storable || c.maxEntries == 0 {
+		delete(c.entries, key)
+		return
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = &cachedResponse{
+		etag:         etag,
+		lastModified: lastModified,
+		statusCode:   resp.StatusCode,
+		header:       resp.Header.Clone(),
+		body:         bytes.Clone(body),
+		lastUsed:     time.Now(),
+	}
+}
+
+// evict drops the least recently used response. The caller holds the lock.
+func (c *responseCache) evict() {
+	var oldest string
+	var oldestUsed time.Time
+	for key, cached := range c.entries {
+		if oldest == "" || cached.lastUsed.Before(oldestUsed) {
+			oldest, oldestUsed = key, cached.lastUsed
+		}
+	}
+	delete(c.entries, oldest)
+}
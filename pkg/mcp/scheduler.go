@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// scheduleLookback bounds how far back due activations and deactivations are looked for, e.g. after
+// the gateway was stopped
+const scheduleLookback = 24 * time.Hour
+
+// ApplySchedules activates and deactivates the servers whose schedule is due at now. Only the
+// latest activation or deactivation since the last change of a server is applied, so that a
+// server changed by hand keeps its status until its next scheduled time. Archived servers are skipped.
+func (s *MCPService) ApplySchedules(ctx context.Context, repo repository.MCPServerRepository, now time.Time) error {
+	servers, err := repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range servers {
+		server := &servers[i]
+		if server.Schedule.IsEmpty() || server.Status == "archived" {
+			continue
+		}
+
+		since := server.UpdatedAt
+		if earliest := now.Add(-scheduleLookback); since.Before(earliest) {
+			since = earliest
+		}
+		activation := server.Schedule.LastActivation(since, now)
+		deactivation := server.Schedule.LastDeactivation(since, now)
+
+		switch {
+		case activation != nil && (deactivation == nil || activation.After(*deactivation)):
+			if server.Status == "active" {
+				continue
+			}
+			if err := s.RegisterServer(server); err != nil {
+				slog.ErrorContext(ctx, "Failed to activate scheduled MCP server", "id", server.ID, "name", server.Name, "error", err)
+				continue
+			}
+			if err := repo.UpdateStatus(ctx, server.ID, "active"); err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "Activated scheduled MCP server", "id", server.ID, "name", server.Name, "scheduledAt", *activation)
+		case deactivation != nil:
+			if server.Status != "active" {
+				continue
+			}
+			if err := repo.UpdateStatus(ctx, server.ID, "inactive"); err != nil {
+				return err
+			}
+			s.UnregisterServer(server.ID)
+			slog.InfoContext(ctx, "Deactivated scheduled MCP server", "id", server.ID, "name", server.Name, "scheduledAt", *deactivation)
+		}
+	}
+
+	return nil
+}
+
+// ScheduleStatus returns the schedule of a server with its next activation and deactivation after now
+func ScheduleStatus(server *models.MCPServer, now time.Time) models.ScheduleStatus {
+	status := models.ScheduleStatus{
+		ServerID: server.ID,
+		Status:   server.Status,
+		Schedule: server.Schedule,
+	}
+	if server.Schedule != nil && server.Status != "archived" {
+		status.NextActivation = server.Schedule.NextActivation(now)
+		status.NextDeactivation = server.Schedule.NextDeactivation(now)
+	}
+	return status
+}
+
+// StartScheduler applies the schedules of the servers every interval until stop is called
+func (s *MCPService) StartScheduler(repo repository.MCPServerRepository, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := s.ApplySchedules(context.Background(), repo, now); err != nil {
+					slog.Error("Failed to apply MCP server schedules", "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -7,29 +7,69 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/tidwall/gjson"
+	"github.com/wangfeng/mcp-gateway2/pkg/errorsink"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+	"github.com/wangfeng/mcp-gateway2/pkg/script"
+	"github.com/wangfeng/mcp-gateway2/pkg/tmpl"
 	"gopkg.in/yaml.v3"
 )
 
 var (
 	ErrServerNotFound  = errors.New("MCP Server not found")
 	ErrToolNotFound    = errors.New("tool not found")
+	ErrToolDisabled    = errors.New("tool is disabled")
 	ErrInvalidResponse = errors.New("invalid response from MCP Server")
 )
 
+// URLResolver rewrites upstream references in tool URLs to concrete targets
+type URLResolver interface {
+	ResolveURL(rawURL string) (string, error)
+}
+
 // MCPService provides functionality for managing MCP Servers
 type MCPService struct {
-	configDir  string
-	servers    map[string]*models.MCPServer
-	httpClient *http.Client
-	mu         sync.RWMutex
+	configDir         string
+	servers           map[string]*models.MCPServer
+	httpClient        *http.Client
+	transport         *upstreamTransport // Transport of httpClient
+	resolver          URLResolver
+	recorder          InvocationRecorder
+	broadcaster       EventBroadcaster
+	plugins           PluginRunner
+	scripts           *script.Engine
+	environments      EnvironmentStore
+	secrets           SecretStore
+	limiter           RateLimiter
+	policyLimiters    policyLimiters // Rate limiters of the call policies setting one
+	counter           ToolCallCounter
+	keyCounter        APIKeyCounter
+	namespacePolicies NamespacePolicyStore
+	allowedHosts      []string
+	allowStdio        bool
+	zones             map[string][]netip.Prefix // Named client networks servers may restrict invocations to
+	redactions        []models.RedactionRule    // Applied to the results of every server
+	mu                sync.RWMutex
+	tokens            map[string]*oauthToken // OAuth2 access tokens by token URL, client, scopes and secret
+	tokenMu           sync.Mutex
+	jars              *cookieJars
+	external          *externalClients
+	latencies         toolLatencies // Recent upstream latencies of hedged tools
+	responses         responseCache // Upstream responses kept for revalidation
+
+	redactionPatterns sync.Map // Compiled redaction patterns by expression
 }
 
 // NewMCPService creates a new MCP Service
@@ -39,21 +79,73 @@ func NewMCPService(configDir string) (*MCPService, error) {
 		return nil, err
 	}
 
+	scripts, err := script.NewEngine()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := newUpstreamTransport()
 	return &MCPService{
 		configDir:  configDir,
 		servers:    make(map[string]*models.MCPServer),
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Transport: transport},
+		transport:  transport,
+		scripts:    scripts,
+		tokens:     make(map[string]*oauthToken),
+		jars:       &cookieJars{jars: make(map[string]*cookieJarEntry)},
+		external:   &externalClients{clients: make(map[string]*externalClient)},
+		responses:  responseCache{entries: make(map[string]*cachedResponse)},
 	}, nil
 }
 
+// ValidateScripts compiles the pre and post scripts and parses the request and response
+// templates of every tool of the server
+func (s *MCPService) ValidateScripts(server *models.MCPServer) error {
+	for _, tool := range server.Tools {
+		if err := s.scripts.Validate(tool.PreScript, tool.PostScript); err != nil {
+			return fmt.Errorf("tool %s: %w", tool.Name, err)
+		}
+		if err := validateTemplates(&tool); err != nil {
+			return fmt.Errorf("tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateTemplates parses the template actions of a tool, other than its {{env:name}}
+// placeholders which are replaced before the templates run
+func validateTemplates(tool *models.Tool) error {
+	templates := [][2]string{
+		{"url", tool.RequestTemplate.URL},
+		{"body", tool.RequestTemplate.Body},
+		{"responseTemplate", tool.ResponseTemplate.Body},
+	}
+	for key, value := range tool.RequestTemplate.Headers {
+		templates = append(templates, [2]string{"header " + key, value})
+	}
+	for _, template := range templates {
+		if err := tmpl.Validate(envPlaceholder.ReplaceAllString(template[1], "")); err != nil {
+			return fmt.Errorf("%s: %w", template[0], err)
+		}
+	}
+	return nil
+}
+
+// SetURLResolver sets the resolver used to map upstream names in tool URLs to healthy targets
+func (s *MCPService) SetURLResolver(resolver URLResolver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolver = resolver
+}
+
 // GenerateYAML generates a YAML configuration for a MCP Server
 func (s *MCPService) GenerateYAML(mcpServer *models.MCPServer) (string, error) {
 	if mcpServer == nil {
-		fmt.Printf("ERROR: Cannot generate YAML for nil MCP server\n")
+		slog.Error("Cannot generate YAML for nil MCP server")
 		return "", fmt.Errorf("nil MCP server")
 	}
 
-	fmt.Printf("INFO: Generating YAML for MCP server: id=%s, name=%s\n", mcpServer.ID, mcpServer.Name)
+	slog.Info("Generating YAML for MCP server", "id", mcpServer.ID, "name", mcpServer.Name)
 
 	// Convert MCP Server model to a map
 	yamlData := map[string]interface{}{
@@ -88,83 +180,157 @@ func (s *MCPService) GenerateYAML(mcpServer *models.MCPServer) (string, error) {
 			toolMap["requestTemplate"].(map[string]interface{})["body"] = tool.RequestTemplate.Body
 		}
 
+		// Proxied tools name the external or source server and the tool they call
+		if tool.External != "" {
+			toolMap["external"] = tool.External
+			toolMap["remoteName"] = tool.RemoteName
+		}
+		if tool.Source != "" {
+			toolMap["source"] = tool.Source
+			toolMap["remoteName"] = tool.RemoteName
+		}
+
+		// Add the params set or renamed before calling upstream
+		if len(tool.StaticParams) > 0 {
+			toolMap["staticParams"] = tool.StaticParams
+		}
+		if len(tool.ParamMapping) > 0 {
+			toolMap["paramMapping"] = tool.ParamMapping
+		}
+
+		// Add the fields of the result returned to clients
+		if !tool.Projection.IsEmpty() {
+			toolMap["projection"] = tool.Projection
+		}
+
+		// Add the cost counted against API key quotas
+		if tool.Cost > 0 {
+			toolMap["cost"] = tool.Cost
+		}
+
+		// Add the pipeline of chained tools
+		if tool.IsChained() {
+			toolMap["steps"] = tool.Steps
+			if tool.Output != "" {
+				toolMap["output"] = tool.Output
+			}
+		}
+
+		// Add the names and descriptions exposed to clients if overridden
+		if tool.Alias != "" {
+			toolMap["alias"] = tool.Alias
+		}
+		if tool.DescriptionOverride != "" {
+			toolMap["descriptionOverride"] = tool.DescriptionOverride
+		}
+		if len(tool.ParamDescriptions) > 0 {
+			toolMap["paramDescriptions"] = tool.ParamDescriptions
+		}
+
 		yamlData["tools"] = append(yamlData["tools"].([]map[string]interface{}), toolMap)
 	}
 
 	// Marshal to YAML
 	yamlBytes, err := yaml.Marshal(yamlData)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to marshal YAML: %v\n", err)
+		slog.Error("Failed to marshal YAML", "error", err)
 		return "", err
 	}
 
-	fmt.Printf("INFO: Successfully generated YAML for MCP server: id=%s\n", mcpServer.ID)
+	slog.Info("Successfully generated YAML for MCP server", "id", mcpServer.ID)
 	return string(yamlBytes), nil
 }
 
 // SaveYAML saves the YAML configuration for a MCP Server to disk
 func (s *MCPService) SaveYAML(mcpServer *models.MCPServer) (string, error) {
 	if mcpServer == nil {
-		fmt.Printf("ERROR: Cannot save YAML for nil MCP server\n")
+		slog.Error("Cannot save YAML for nil MCP server")
 		return "", fmt.Errorf("nil MCP server")
 	}
 
-	fmt.Printf("INFO: Saving YAML for MCP server: id=%s\n", mcpServer.ID)
+	slog.Info("Saving YAML for MCP server", "id", mcpServer.ID)
 
 	yaml, err := s.GenerateYAML(mcpServer)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to generate YAML: %v\n", err)
+		slog.Error("Failed to generate YAML", "error", err)
 		return "", err
 	}
 
 	// Create directory if it doesn't exist
 	configPath := filepath.Join(s.configDir, "config")
 	if err := os.MkdirAll(configPath, 0755); err != nil {
-		fmt.Printf("ERROR: Failed to create config directory: %v\n", err)
+		slog.Error("Failed to create config directory", "error", err)
 		return "", err
 	}
 
 	// Write YAML to file
 	filePath := filepath.Join(configPath, fmt.Sprintf("%s.yaml", mcpServer.ID))
 	if err := os.WriteFile(filePath, []byte(yaml), 0644); err != nil {
-		fmt.Printf("ERROR: Failed to write YAML file: %v\n", err)
+		slog.Error("Failed to write YAML file", "error", err)
 		return "", err
 	}
 
-	fmt.Printf("INFO: Saved YAML file to: %s\n", filePath)
+	slog.Info("Saved YAML file", "path", filePath)
 	return filePath, nil
 }
 
 // RegisterServer registers an MCP Server with the service
 func (s *MCPService) RegisterServer(mcpServer *models.MCPServer) error {
 	if mcpServer == nil {
-		fmt.Printf("ERROR: Cannot register nil MCP server\n")
+		slog.Error("Cannot register nil MCP server")
 		return fmt.Errorf("nil MCP server")
 	}
 
-	fmt.Printf("INFO: Registering MCP server: id=%s, name=%s\n", mcpServer.ID, mcpServer.Name)
+	slog.Info("Registering MCP server", "id", mcpServer.ID, "name", mcpServer.Name)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Check if the server has tools
 	if len(mcpServer.Tools) == 0 {
-		fmt.Printf("WARNING: MCP server has no tools: id=%s\n", mcpServer.ID)
+		slog.Warn("MCP server has no tools", "id", mcpServer.ID)
 	} else {
-		fmt.Printf("INFO: MCP server has %d tools\n", len(mcpServer.Tools))
+		slog.Info("MCP server tools", "id", mcpServer.ID, "count", len(mcpServer.Tools))
 		for i, tool := range mcpServer.Tools {
-			fmt.Printf("INFO: Tool %d: name=%s, method=%s, url=%s\n",
-				i, tool.Name, tool.RequestTemplate.Method, tool.RequestTemplate.URL)
+			slog.Debug("MCP server tool", "index", i, "name", tool.Name,
+				"method", tool.RequestTemplate.Method, "url", tool.RequestTemplate.URL)
 		}
 	}
 
 	// Cache the server
 	s.servers[mcpServer.ID] = mcpServer
-	fmt.Printf("INFO: Successfully registered MCP server in cache: id=%s\n", mcpServer.ID)
+	slog.Info("Successfully registered MCP server in cache", "id", mcpServer.ID)
 
 	return nil
 }
 
+// RefreshServer replaces the cached definition of a registered server, returning false if it is not registered
+func (s *MCPService) RefreshServer(mcpServer *models.MCPServer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.servers[mcpServer.ID]; !ok {
+		return false
+	}
+	s.servers[mcpServer.ID] = mcpServer
+	return true
+}
+
+// UnregisterServer removes a server from the cache, returning false if it was not registered
+func (s *MCPService) UnregisterServer(id string) bool {
+	s.mu.Lock()
+	_, ok := s.servers[id]
+	delete(s.servers, id)
+	s.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	s.closeExternalClients(id)
+	slog.Info("Unregistered MCP server", "id", id)
+	return true
+}
+
 // HandleToolRequest handles a tool request for an MCP Server
 func (s *MCPService) HandleToolRequest(ctx context.Context, serverID, toolName string, params map[string]interface{}) (string, error) {
 	// Get the server definition
@@ -173,92 +339,298 @@ func (s *MCPService) HandleToolRequest(ctx context.Context, serverID, toolName s
 	s.mu.RUnlock()
 
 	if !ok {
-		fmt.Printf("ERROR: Server not found: %s\n", serverID)
+		slog.ErrorContext(ctx, "Server not found", "serverId", serverID)
 		return "", ErrServerNotFound
 	}
 
-	// Find the tool definition
-	var toolDef *models.Tool
-	for _, tool := range server.Tools {
-		if tool.Name == toolName {
-			toolDef = &tool
-			break
-		}
+	// Attach server and tool to every record logged for this invocation
+	ctx = logging.With(ctx, "server", server.Name, "tool", toolName)
+
+	if err := s.checkNetwork(ctx, server); err != nil {
+		slog.WarnContext(ctx, "Caller network not allowed", "caller", Caller(ctx))
+		return "", err
 	}
 
+	if !s.allowCaller(ctx) {
+		slog.WarnContext(ctx, "Rate limit exceeded", "caller", Caller(ctx))
+		return "", ErrRateLimited
+	}
+
+	// Find the tool definition by the name clients call it
+	toolDef := server.FindTool(toolName)
 	if toolDef == nil {
-		fmt.Printf("ERROR: Tool not found: %s for server: %s\n", toolName, serverID)
+		slog.ErrorContext(ctx, "Tool not found")
 		return "", ErrToolNotFound
 	}
+	if !toolDef.IsEnabled() {
+		slog.WarnContext(ctx, "Tool is disabled")
+		return "", ErrToolDisabled
+	}
+	warnDeprecated(ctx, toolDef)
 
-	fmt.Printf("INFO: Executing tool request: %s for server: %s with params: %+v\n", toolName, serverID, params)
+	// Apply the call policy of the tool, completed by those of the server and the namespace
+	policy := s.resolvePolicy(ctx, server, toolDef)
+	if !s.allowPolicyCaller(ctx, policy) {
+		slog.WarnContext(ctx, "Policy rate limit exceeded", "caller", Caller(ctx), "scope", policy.rateScope)
+		return "", ErrRateLimited
+	}
+	ctx = withCallPolicy(ctx, policy)
+
+	if err := checkStrict(server, toolDef, params); err != nil {
+		slog.WarnContext(ctx, "Rejected tool call in strict mode", "error", err)
+		return "", err
+	}
+
+	if err := s.countToolCall(ctx, namespace.OrDefault(server.Namespace)); err != nil {
+		slog.WarnContext(ctx, "Tool call quota exceeded", "tenant", namespace.OrDefault(server.Namespace))
+		return "", err
+	}
+	if err := s.countKeyCall(ctx, toolDef); err != nil {
+		slog.WarnContext(ctx, "API key quota exceeded", "apiKey", APIKeyOf(ctx).Name)
+		return "", err
+	}
 
-	// Execute the tool request using the tool definition
-	resp, err := s.executeToolRequest(ctx, server, toolDef, params)
+	slog.InfoContext(ctx, "Executing tool request", "params", RedactParams(params))
+
+	// Capture the parameters before the request template consumes them, without the credentials
+	// of the caller
+	request, _ := json.Marshal(RedactParams(params))
+	fields, params := requestedFields(toolDef, params)
+	shadowed := shadowParams(toolDef, params)
+
+	// Trace the upstream request and response to record them with the invocation
+	if traceOf(ctx) == nil {
+		ctx = WithTrace(ctx, &ToolTrace{})
+	}
+
+	// Execute the tool request using the tool definition, within the timeout of the policy and the
+	// latency budget if enforced
+	timeoutCtx, cancelTimeout := withCallTimeout(ctx, policy)
+	defer cancelTimeout()
+	callCtx, cancel := withLatencyBudget(timeoutCtx, toolDef)
+	defer cancel()
+	start := time.Now()
+	resp, statusCode, err := s.executeToolRequest(callCtx, server, toolDef, params)
+	if err == nil {
+		// Trim the result to the fields clients need and hide sensitive data before it is
+		// recorded or returned
+		resp = project(toolDef.Projection, fields, resp)
+		resp = s.redact(s.serverRedactions(server), resp)
+	}
+	duration := time.Since(start)
+	err = checkLatencyBudget(ctx, callCtx, server, toolDef, duration, err)
+	err = checkCallTimeout(ctx, timeoutCtx, policy, err)
+	if shadowed != nil {
+		s.callShadow(ctx, server, toolDef, shadowed, fields, resp, err)
+	}
+	metrics.ObserveToolInvocation(server.Name, toolName, err, duration)
+	s.recordInvocation(ctx, server, toolName, request, resp, statusCode, err, duration)
+	s.broadcastInvocation(ctx, server, toolName, statusCode, err, duration)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to execute tool request: %s - %v\n", toolName, err)
+		slog.ErrorContext(ctx, "Failed to execute tool request", "error", err)
+		errorsink.Capture(ctx, errorsink.KindTool, err)
 		return "", err
 	}
 
-	fmt.Printf("INFO: Tool request completed successfully: %s\n", toolName)
+	slog.InfoContext(ctx, "Tool request completed successfully")
 	return resp, nil
 }
 
-// executeToolRequest executes a tool request using the tool definition
-func (s *MCPService) executeToolRequest(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (string, error) {
+// executeToolRequest executes a tool request using the tool definition.
+// The returned status code is 0 if no upstream response was received.
+func (s *MCPService) executeToolRequest(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}) (string, int, error) {
+	// Rename the params clients know to the upstream names and add the static params
+	params = tool.UpstreamParams(params)
+
+	// Forward the calls of proxied tools to their external server
+	if tool.External != "" {
+		result, err := s.callExternalTool(ctx, server, tool, params)
+		return result, 0, err
+	}
+	// Forward the calls of the tools of a virtual server to their source server
+	if tool.Source != "" {
+		return s.callSourceTool(ctx, tool, params)
+	}
+	// Run the steps of a chained tool
+	if tool.IsChained() {
+		return s.runChain(ctx, server, tool, params)
+	}
+
+	// Forward, replace or strip the Authorization of the caller
+	tool, params = applyAuthPassthrough(ctx, server, tool, params)
+
+	// Send the default headers of the server and only the client headers it allows
+	tool, params, err := applyHeaderPolicy(ctx, server, tool, params)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Substitute the variables of the selected environment
+	tool, err = s.applyEnvironment(ctx, server, tool)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply environment", "error", err)
+		return "", 0, err
+	}
+
+	// Let the pre script adjust the parameters and add headers
+	var scriptHeaders map[string]string
+	if tool.PreScript != "" {
+		scriptHeaders, err = s.scripts.RunPre(ctx, tool.PreScript, params)
+		if err != nil {
+			slog.ErrorContext(ctx, "Pre script failed", "error", err)
+			return "", 0, err
+		}
+	}
+
+	// Exchange messages with WebSocket upstreams instead of sending a request
+	if tool.IsWebSocket() {
+		return s.callWebSocket(ctx, tool, params, scriptHeaders)
+	}
+
 	// Create request based on the tool's request template
 	req, err := s.createRequest(ctx, tool, params)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to create request for tool %s: %v\n", tool.Name, err)
-		return "", err
+		slog.ErrorContext(ctx, "Failed to create request", "error", err)
+		return "", 0, err
+	}
+	for key, value := range scriptHeaders {
+		req.Header.Set(key, value)
 	}
 
-	fmt.Printf("INFO: Sending request to: %s %s\n", req.Method, req.URL.String())
+	// Let the attached plugins rewrite the outgoing request
+	pluginIDs := toolPlugins(server, tool)
+	req, err = s.applyRequestPlugins(ctx, pluginIDs, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "Request plugin failed", "error", err)
+		return "", 0, err
+	}
+
+	// Only call the upstream hosts of the allowlists
+	if err := s.checkHost(req.URL.Hostname()); err != nil {
+		slog.ErrorContext(ctx, "Upstream host rejected", "error", err)
+		return "", 0, err
+	}
+	if err := checkPolicyHosts(ctx, req.URL.Hostname()); err != nil {
+		slog.ErrorContext(ctx, "Upstream host rejected", "error", err)
+		return "", 0, err
+	}
+
+	// Send the session cookies of the selected cookie jar
+	jar := s.cookieJar(ctx, namespace.OrDefault(server.Namespace))
+	if jar != nil {
+		addJarCookies(req, jar)
+	}
+
+	// Revalidate the response kept for the same GET call instead of downloading it again
+	var cacheKey string
+	var cached *cachedResponse
+	if req.Method == http.MethodGet {
+		cacheKey = revalidationKey(server, tool, req)
+		cached = s.responses.revalidate(cacheKey, req)
+	}
+
+	slog.InfoContext(ctx, "Sending request", "method", req.Method, "url", req.URL.String())
+	trace := traceOf(ctx)
+	if trace != nil {
+		trace.Request = resolveRequest(req, tool.Auth)
+	}
 
 	// Execute request
-	resp, err := s.httpClient.Do(req)
+	start := time.Now()
+	var resp *http.Response
+	resp, err = sendWithRetry(ctx, server, tool, req, func(req *http.Request) (*http.Response, error) {
+		if tool.Hedging.IsEnabled() {
+			return s.sendHedged(server, tool, req)
+		}
+		return s.httpClient.Do(req)
+	})
+	if trace != nil {
+		trace.UpstreamLatency = time.Since(start)
+	}
 	if err != nil {
-		fmt.Printf("ERROR: HTTP request failed for tool %s: %v\n", tool.Name, err)
-		return "", err
+		metrics.ObserveUpstreamRequest(req.URL.Host, req.Method, 0, time.Since(start))
+		slog.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return "", 0, err
 	}
 	defer resp.Body.Close()
+	if trace != nil {
+		trace.UpstreamStatus = resp.StatusCode
+		trace.UpstreamProto = resp.Proto
+		trace.UpstreamHeader = resp.Header.Clone()
+	}
+	if jar != nil {
+		jar.SetCookies(req.URL, resp.Cookies())
+	}
 
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
+	metrics.ObserveUpstreamRequest(req.URL.Host, req.Method, resp.StatusCode, time.Since(start))
 	if err != nil {
-		fmt.Printf("ERROR: Failed to read response body for tool %s: %v\n", tool.Name, err)
-		return "", err
+		if !keepsPartial(tool, s.serverRedactions(server), resp.StatusCode, body, err) {
+			slog.ErrorContext(ctx, "Failed to read response body", "error", err)
+			return "", resp.StatusCode, err
+		}
+		// Return what was received, as is, rather than discarding it
+		slog.WarnContext(ctx, "Upstream response cut off, returning the partial body", "bytes", len(body), "error", err)
+		if trace != nil {
+			trace.UpstreamBody = body
+		}
+		markTruncated(ctx)
+		return string(body), resp.StatusCode, nil
+	}
+	if trace != nil {
+		trace.UpstreamBody = body
 	}
 
-	// 打印详细的响应信息
-	fmt.Printf("INFO: ======== RESPONSE DETAILS ========\n")
-	fmt.Printf("INFO: Status: %d %s\n", resp.StatusCode, resp.Status)
-	fmt.Printf("INFO: Headers:\n")
-	for key, values := range resp.Header {
-		for _, value := range values {
-			fmt.Printf("INFO:   %s: %s\n", key, value)
+	// Serve the kept response if the upstream did not modify it
+	upstreamStatus, header := resp.StatusCode, resp.Header
+	if cacheKey != "" {
+		if cached != nil && resp.StatusCode == http.StatusNotModified {
+			slog.DebugContext(ctx, "Upstream response not modified", "url", req.URL.String())
+			upstreamStatus, header, body = cached.statusCode, cached.header.Clone(), bytes.Clone(cached.body)
+		} else {
+			s.responses.store(cacheKey, resp, body)
+		}
+		if cached != nil {
+			metrics.ObserveUpstreamRevalidation(server.Name, tool.Name, resp.StatusCode == http.StatusNotModified)
 		}
 	}
-	fmt.Printf("INFO: Body: %s\n", string(body))
-	fmt.Printf("INFO: ================================\n")
+
+	// 打印详细的响应信息
+	slog.DebugContext(ctx, "Response details", "status", upstreamStatus, "headers", header, "body", string(body))
+
+	// Let the attached plugins transform the upstream response
+	statusCode, body, err := s.applyResponsePlugins(ctx, pluginIDs, upstreamStatus, header, body)
+	if err != nil {
+		slog.ErrorContext(ctx, "Response plugin failed", "error", err)
+		return "", upstreamStatus, err
+	}
 
 	// If the status code is not successful, return an error
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		errMessage := fmt.Sprintf("request failed with status code %d: %s", resp.StatusCode, string(body))
-		fmt.Printf("ERROR: %s\n", errMessage)
-		return "", fmt.Errorf(errMessage)
+	if statusCode < 200 || statusCode >= 300 {
+		slog.ErrorContext(ctx, "Upstream request failed", "status", statusCode, "body", string(body))
+		return "", statusCode, upstreamError(ctx, tool, statusCode, body)
+	}
+
+	// Let the post script reshape the response
+	if tool.PostScript != "" {
+		body, err = s.scripts.RunPost(ctx, tool.PostScript, statusCode, flattenHeader(header), body)
+		if err != nil {
+			slog.ErrorContext(ctx, "Post script failed", "error", err)
+			return "", statusCode, err
+		}
 	}
 
 	// Process response according to the tool's response template
 	result, err := s.processResponse(tool, body)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to process response for tool %s: %v\n", tool.Name, err)
-		return "", err
+		slog.ErrorContext(ctx, "Failed to process response", "error", err)
+		return "", statusCode, err
 	}
 
 	// 打印处理后的结果
-	fmt.Printf("INFO: Processed response result: %s\n", result)
-	return result, nil
+	slog.DebugContext(ctx, "Processed response result", "result", result)
+	return result, statusCode, nil
 }
 
 // createRequest creates an HTTP request based on the tool definition and parameters
@@ -267,28 +639,54 @@ func (s *MCPService) createRequest(ctx context.Context, tool *models.Tool, param
 	url := tool.RequestTemplate.URL
 	method := tool.RequestTemplate.Method
 
-	fmt.Printf("DEBUG: Creating request with URL template: %s\n", url)
+	slog.DebugContext(ctx, "Creating request with URL template", "url", url)
 
-	// Replace URL parameters with values from params
-	// Example: If URL is "https://api.example.com/{param1}/{param2}"
-	// and params has {"param1": "value1", "param2": "value2"},
-	// the result should be "https://api.example.com/value1/value2"
-	for key, value := range params {
-		placeholder := fmt.Sprintf("{%s}", key)
-		if !strings.Contains(url, placeholder) {
-			fmt.Printf("DEBUG: Parameter '%s' not found in URL template\n", key)
-			continue
+	// Run the template actions of the URL, whose params are not sent as query params
+	urlFields, err := tmpl.Fields(url)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Uses(url) {
+		if url, err = tmpl.Render(url, params); err != nil {
+			return nil, err
 		}
+		if err := checkDotSegments(url); err != nil {
+			slog.WarnContext(ctx, "Unsafe URL parameter", "error", err)
+			return nil, err
+		}
+	}
 
-		strValue := fmt.Sprintf("%v", value)
-		url = strings.ReplaceAll(url, placeholder, strValue)
-		fmt.Printf("DEBUG: Replaced '%s' with '%s' in URL\n", placeholder, strValue)
+	// Replace URL parameters with values from params, encoded for their part of the URL
+	// Example: If URL is "https://api.example.com/{param1}/{param2}"
+	// and params has {"param1": "value1", "param2": "a/b"},
+	// the result should be "https://api.example.com/value1/a%2Fb"
+	url, err = substituteURLParams(tool, url, params)
+	if err != nil {
+		slog.WarnContext(ctx, "Unsafe URL parameter", "error", err)
+		return nil, err
 	}
 
-	fmt.Printf("DEBUG: Final URL after parameter replacement: %s\n", url)
+	slog.DebugContext(ctx, "Final URL after parameter replacement", "url", url)
+
+	// Resolve upstream names to a healthy target
+	s.mu.RLock()
+	resolver := s.resolver
+	s.mu.RUnlock()
+	if resolver != nil {
+		resolvedURL, err := resolver.ResolveURL(url)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to resolve upstream", "url", url, "error", err)
+			return nil, err
+		}
+		if resolvedURL != url {
+			slog.DebugContext(ctx, "Resolved upstream URL", "from", url, "to", resolvedURL)
+			url = resolvedURL
+		}
+	}
 
 	// Extract user-provided headers, body, and other parameters from params
 	userHeaders := map[string]string{}
+	userCookies := map[string]string{}
 	userBody := map[string]interface{}{}
 
 	// Check if headers are provided in the params
@@ -302,8 +700,21 @@ func (s *MCPService) createRequest(ctx context.Context, tool *models.Tool, param
 		}
 	}
 
+	// Check if cookies are provided in the params
+	if cookiesParam, ok := params["cookies"]; ok {
+		if cookiesMap, ok := cookiesParam.(map[string]interface{}); ok {
+			for k, v := range cookiesMap {
+				userCookies[k] = fmt.Sprintf("%v", v)
+			}
+			// Remove cookies from params to avoid confusion with URL or query params
+			delete(params, "cookies")
+		}
+	}
+
 	// Check if body is provided in the params
-	if bodyParam, ok := params["body"]; ok {
+	contentType := tool.RequestTemplate.ContentType
+	bodyParam, hasBody := params["body"]
+	if hasBody {
 		if bodyMap, ok := bodyParam.(map[string]interface{}); ok {
 			userBody = bodyMap
 			// Remove body from params to avoid confusion with URL or query params
@@ -323,26 +734,36 @@ func (s *MCPService) createRequest(ctx context.Context, tool *models.Tool, param
 	var reqBody io.Reader
 	var bodyJson string
 	if method != "GET" {
-		if len(userBody) > 0 {
+		if hasBody && !isJSONContentType(contentType) && bodyParam != nil && bodyParam != "" {
+			// Encode the body as the interface declares, strings are sent as they are
+			data, err := EncodeBody(contentType, bodyParam)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to encode user body", "contentType", contentType, "error", err)
+				return nil, err
+			}
+			bodyJson = string(data)
+			slog.DebugContext(ctx, "Using user-provided body", "contentType", contentType, "body", bodyJson)
+			reqBody = bytes.NewBuffer(data)
+		} else if len(userBody) > 0 {
 			// User provided a body
 			jsonData, err := json.Marshal(userBody)
 			if err != nil {
-				fmt.Printf("ERROR: Failed to marshal user body: %v\n", err)
+				slog.ErrorContext(ctx, "Failed to marshal user body", "error", err)
 				return nil, err
 			}
 			bodyJson = string(jsonData)
-			fmt.Printf("DEBUG: Using user-provided body: %s\n", bodyJson)
+			slog.DebugContext(ctx, "Using user-provided body", "body", bodyJson)
 			reqBody = bytes.NewBuffer(jsonData)
 		} else if tool.RequestTemplate.Body != "" {
 			// Use template body with parameter replacement
 			bodyTemplate := tool.RequestTemplate.Body
 			var err error
-			bodyJson, err = replaceParams(bodyTemplate, params)
+			bodyJson, err = replaceParamsEscaped(bodyTemplate, params, bodyEscaper(contentType))
 			if err != nil {
-				fmt.Printf("ERROR: Failed to replace parameters in request body: %v\n", err)
+				slog.ErrorContext(ctx, "Failed to replace parameters in request body", "error", err)
 				return nil, err
 			}
-			fmt.Printf("DEBUG: Request body after parameter replacement: %s\n", bodyJson)
+			slog.DebugContext(ctx, "Request body after parameter replacement", "body", bodyJson)
 			reqBody = bytes.NewBuffer([]byte(bodyJson))
 		}
 	}
@@ -350,26 +771,45 @@ func (s *MCPService) createRequest(ctx context.Context, tool *models.Tool, param
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to create HTTP request: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to create HTTP request", "error", err)
 		return nil, err
 	}
 
 	// Add default headers from tool definition first
 	for key, value := range tool.RequestTemplate.Headers {
+		if tmpl.Uses(value) {
+			if value, err = tmpl.Render(value, params); err != nil {
+				return nil, fmt.Errorf("header %s: %w", key, err)
+			}
+		}
 		req.Header.Set(key, value)
-		fmt.Printf("DEBUG: Added default header: %s: %s\n", key, value)
+		slog.DebugContext(ctx, "Added default header", "name", key, "value", value)
 	}
 
 	// Override with user-provided headers
 	for key, value := range userHeaders {
 		req.Header.Set(key, value)
-		fmt.Printf("DEBUG: Overrode with user header: %s: %s\n", key, value)
+		slog.DebugContext(ctx, "Overrode with user header", "name", key, "value", value)
+	}
+
+	// Add user-provided cookies
+	for name, value := range userCookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+		slog.DebugContext(ctx, "Added cookie", "name", name)
+	}
+
+	// Forward the request ID so the upstream call can be correlated with the gateway logs
+	if requestID := logging.RequestID(ctx); requestID != "" && req.Header.Get(logging.RequestIDHeader) == "" {
+		req.Header.Set(logging.RequestIDHeader, requestID)
 	}
 
-	// Set default Content-Type if not provided and body exists
+	// Set the Content-Type of the interface, or JSON, if not provided and body exists
 	if reqBody != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
-		fmt.Printf("DEBUG: Added default Content-Type: application/json\n")
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+		slog.DebugContext(ctx, "Added default Content-Type", "value", contentType)
 	}
 
 	// Handle query parameters for GET requests (or other methods if URL contains query params)
@@ -378,37 +818,53 @@ func (s *MCPService) createRequest(ctx context.Context, tool *models.Tool, param
 		for key, value := range params {
 			// Skip parameters that were used in the URL template
 			placeholder := fmt.Sprintf("{%s}", key)
-			if strings.Contains(tool.RequestTemplate.URL, placeholder) {
+			if strings.Contains(tool.RequestTemplate.URL, placeholder) || urlFields[key] {
 				continue
 			}
 
-			q.Add(key, fmt.Sprintf("%v", value))
-			fmt.Printf("DEBUG: Added query parameter: %s=%v\n", key, value)
+			for _, item := range paramValues(tool, key, value) {
+				q.Add(key, item)
+			}
+			slog.DebugContext(ctx, "Added query parameter", "name", key, "value", value)
 		}
 		req.URL.RawQuery = q.Encode()
-		fmt.Printf("DEBUG: Final query string: %s\n", req.URL.RawQuery)
+		slog.DebugContext(ctx, "Final query string", "query", req.URL.RawQuery)
 	}
 
-	// 打印完整的请求信息
-	fmt.Printf("INFO: ======== REQUEST DETAILS ========\n")
-	fmt.Printf("INFO: Method: %s\n", req.Method)
-	fmt.Printf("INFO: URL: %s\n", req.URL.String())
-	fmt.Printf("INFO: Headers:\n")
-	for key, values := range req.Header {
-		for _, value := range values {
-			fmt.Printf("INFO:   %s: %s\n", key, value)
-		}
-	}
-	if reqBody != nil {
-		fmt.Printf("INFO: Body: %s\n", bodyJson)
-	} else {
-		fmt.Printf("INFO: Body: <none>\n")
+	// Authenticate with the auth profile of the interface
+	if err := s.applyAuth(ctx, tool.Auth, req); err != nil {
+		slog.ErrorContext(ctx, "Failed to apply auth profile", "error", err)
+		return nil, err
 	}
-	fmt.Printf("INFO: ================================\n")
+
+	// 打印完整的请求信息
+	slog.DebugContext(ctx, "Request details", "method", req.Method, "url", req.URL.String(), "headers", req.Header, "body", bodyJson)
 
 	return req, nil
 }
 
+// paramValues returns the values an upstream param is sent as: one per item for the arrays of
+// exploded form params, otherwise a single value with the items joined as its style defines
+func paramValues(tool *models.Tool, name string, value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%v", value)}
+	}
+	style, ok := tool.ParamStyles[name]
+	if !ok {
+		// Without a style from the interface, arrays are sent as OpenAPI sends queries by default
+		style = models.ParamStyle{In: "query", Style: models.ParamStyleForm, Explode: true}
+	}
+	values := make([]string, len(items))
+	for i, item := range items {
+		values[i] = fmt.Sprintf("%v", item)
+	}
+	if style.Repeated() {
+		return values
+	}
+	return []string{strings.Join(values, style.Delimiter())}
+}
+
 // processResponse processes the response according to the tool's response template
 func (s *MCPService) processResponse(tool *models.Tool, responseBody []byte) (string, error) {
 	// If there's no response template, return the raw response
@@ -416,6 +872,18 @@ func (s *MCPService) processResponse(tool *models.Tool, responseBody []byte) (st
 		return string(responseBody), nil
 	}
 
+	// Run the template actions with the decoded response, or the body if it is not JSON
+	if tmpl.Uses(tool.ResponseTemplate.Body) {
+		var data interface{} = string(responseBody)
+		decoder := json.NewDecoder(bytes.NewReader(responseBody))
+		decoder.UseNumber()
+		var document interface{}
+		if err := decoder.Decode(&document); err == nil {
+			data = document
+		}
+		return tmpl.Render(tool.ResponseTemplate.Body, data)
+	}
+
 	// Parse the response JSON
 	result := gjson.ParseBytes(responseBody)
 
@@ -431,14 +899,29 @@ func (s *MCPService) processResponse(tool *models.Tool, responseBody []byte) (st
 
 // replaceParams replaces parameter placeholders in a template string with actual values
 func replaceParams(template string, params map[string]interface{}) (string, error) {
+	return replaceParamsEscaped(template, params, nil)
+}
+
+// replaceParamsEscaped replaces the parameter placeholders of a template, escaping the values
+// substituted in templates that are not JSON with escape if it is not nil
+func replaceParamsEscaped(template string, params map[string]interface{}, escape func(string) string) (string, error) {
+	// Run the template actions before substituting the {name} placeholders
+	if tmpl.Uses(template) {
+		rendered, err := tmpl.Render(template, params)
+		if err != nil {
+			return "", err
+		}
+		template = rendered
+	}
+
 	// Check if the template is a valid JSON
 	var jsonObj interface{}
 	if json.Valid([]byte(template)) {
-		fmt.Printf("DEBUG: Template is valid JSON\n")
+		slog.Debug("Template is valid JSON")
 		// Try to replace parameters in the JSON template
 		// First decode the JSON template
 		if err := json.Unmarshal([]byte(template), &jsonObj); err != nil {
-			fmt.Printf("ERROR: Failed to unmarshal JSON template: %v\n", err)
+			slog.Error("Failed to unmarshal JSON template", "error", err)
 			return "", err
 		}
 
@@ -448,7 +931,7 @@ func replaceParams(template string, params map[string]interface{}) (string, erro
 		// Marshal back to JSON
 		result, err := json.Marshal(jsonObj)
 		if err != nil {
-			fmt.Printf("ERROR: Failed to marshal JSON after parameter replacement: %v\n", err)
+			slog.Error("Failed to marshal JSON after parameter replacement", "error", err)
 			return "", err
 		}
 
@@ -456,15 +939,18 @@ func replaceParams(template string, params map[string]interface{}) (string, erro
 	}
 
 	// If not valid JSON, treat as string template
-	fmt.Printf("DEBUG: Template is not a valid JSON, treating as string template\n")
+	slog.Debug("Template is not a valid JSON, treating as string template")
 	result := template
 
 	// Replace parameters in the string template
 	for key, value := range params {
 		placeholder := fmt.Sprintf("{%s}", key)
 		strValue := fmt.Sprintf("%v", value)
+		if escape != nil {
+			strValue = escape(strValue)
+		}
 		result = strings.ReplaceAll(result, placeholder, strValue)
-		fmt.Printf("DEBUG: Replaced '%s' with '%s' in string template\n", placeholder, strValue)
+		slog.Debug("Replaced template parameter", "placeholder", placeholder, "value", strValue)
 	}
 
 	return result, nil
@@ -478,7 +964,7 @@ func replaceJSONParams(value interface{}, params map[string]interface{}) interfa
 			placeholder := fmt.Sprintf("{%s}", key)
 			if strings.Contains(strValue, placeholder) {
 				strValue = strings.ReplaceAll(strValue, placeholder, fmt.Sprintf("%v", paramValue))
-				fmt.Printf("DEBUG: Replaced '%s' with '%v' in JSON string\n", placeholder, paramValue)
+				slog.Debug("Replaced JSON parameter", "placeholder", placeholder, "value", paramValue)
 			}
 		}
 		return strValue
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// Shadow call limits
+const (
+	shadowTimeout        = 30 * time.Second // Time allowed for a shadow call
+	maxShadowDifferences = 10               // Differing fields logged for a mismatch
+)
+
+// Results of shadow calls
+const (
+	shadowMatch    = "match"
+	shadowMismatch = "mismatch"
+	shadowError    = "error"
+)
+
+// shadowParams returns a copy of the params of a call of tool for its shadow tool if the call is
+// sampled, or nil
+func shadowParams(tool *models.Tool, params map[string]interface{}) map[string]interface{} {
+	if !tool.Shadow.IsSet() || rand.Float64()*100 >= tool.Shadow.Percent {
+		return nil
+	}
+	// The request template of the tool consumes its params
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	var copied map[string]interface{}
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil
+	}
+	return copied
+}
+
+// callShadow calls the shadow tool of tool with params in the background and compares its result
+// with result and err, those of the call returned to the client. The shadow call is neither
+// recorded nor counted against quotas, and never reaches the client.
+func (s *MCPService) callShadow(ctx context.Context, server *models.MCPServer, tool *models.Tool, params map[string]interface{}, fields []string, result string, err error) {
+	var shadow *models.Tool
+	for i := range server.Tools {
+		if server.Tools[i].Name == tool.Shadow.Tool {
+			shadow = &server.Tools[i]
+			break
+		}
+	}
+	if shadow == nil || !shadow.IsEnabled() {
+		return
+	}
+
+	// Outlive the call of the client, without filling its trace or reporting in its response
+	ctx = WithTruncation(WithTrace(context.WithoutCancel(ctx), nil), nil)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, shadowTimeout)
+		defer cancel()
+		start := time.Now()
+		shadowResult, _, shadowErr := s.executeToolRequest(ctx, server, shadow, params)
+		duration := time.Since(start)
+		if shadowErr == nil {
+			shadowResult = project(shadow.Projection, fields, shadowResult)
+			shadowResult = s.redact(s.serverRedactions(server), shadowResult)
+		}
+
+		outcome := shadowMatch
+		switch {
+		case shadowErr != nil:
+			outcome = shadowError
+			slog.WarnContext(ctx, "Shadow call failed", "shadow", shadow.Name, "durationMs", duration.Milliseconds(), "error", shadowErr)
+		case err != nil:
+			outcome = shadowMismatch
+			slog.WarnContext(ctx, "Shadow call succeeded where the tool failed", "shadow", shadow.Name, "durationMs", duration.Milliseconds(), "error", err)
+		default:
+			if differences := resultDifferences(result, shadowResult); len(differences) > 0 {
+				outcome = shadowMismatch
+				slog.WarnContext(ctx, "Shadow result differs", "shadow", shadow.Name, "durationMs", duration.Milliseconds(),
+					"differences", differences, "bytes", len(result), "shadowBytes", len(shadowResult))
+			} else {
+				slog.DebugContext(ctx, "Shadow result matches", "shadow", shadow.Name, "durationMs", duration.Milliseconds())
+			}
+		}
+		metrics.ObserveShadowCall(server.Name, tool.Name, outcome)
+	}()
+}
+
+// resultDifferences returns the paths of the fields that differ between two JSON results, or
+// result if either is not JSON and they differ. Values are left out, so that logging them leaks
+// nothing.
+func resultDifferences(result, shadow string) []string {
+	var document, shadowDocument interface{}
+	if json.Unmarshal([]byte(result), &document) != nil || json.Unmarshal([]byte(shadow), &shadowDocument) != nil {
+		if result != shadow {
+			return []string{"result"}
+		}
+		return nil
+	}
+	var differences []string
+	diffValues(document, shadowDocument, "", &differences)
+	sort.Strings(differences)
+	return differences
+}
+
+// diffValues appends to differences the paths under path at which a and b differ
+func diffValues(a, b interface{}, path string, differences *[]string) {
+	if len(*differences) >= maxShadowDifferences {
+		return
+	}
+	name := path
+	if name == "" {
+		name = "result"
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			*differences = append(*differences, name)
+			return
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for key := range av {
+			keys = append(keys, key)
+		}
+		for key := range bv {
+			if _, ok := av[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffValues(av[key], bv[key], joinPath(path, key), differences)
+		}
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			*differences = append(*differences, name)
+			return
+		}
+		for i := range av {
+			diffValues(av[i], bv[i], joinPath(path, fmt.Sprint(i)), differences)
+		}
+	default:
+		if !reflect.DeepEqual(a, b) {
+			*differences = append(*differences, name)
+		}
+	}
+}
+
+// joinPath appends a key to a dotted path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrInvalidParams is returned by strict servers for calls whose params do not match the input
+// schema of the tool
+var ErrInvalidParams = errors.New("invalid tool params")
+
+// checkStrict rejects, on strict servers, the calls passing params the input schema of the tool
+// does not define, fields its body schema does not define, or missing required params, headers
+// and fields. Headers the header policy of the server explicitly allows may be passed too. Tools
+// without an input schema are not checked.
+func checkStrict(server *models.MCPServer, tool *models.Tool, params map[string]interface{}) error {
+	if !server.Strict {
+		return nil
+	}
+	schema := tool.ExposedInputSchema(tool.InputSchema)
+	if schema == nil {
+		return nil
+	}
+
+	params = matchHeaderNames(schema, params)
+	problems, err := models.ValidateJSON(schema, params)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidParams, err)
+	}
+	allowHeader := func(name string) bool {
+		return server.Headers != nil && len(server.Headers.Allow) > 0 && server.Headers.Allows(name)
+	}
+	problems = append(problems, unknownFields(schema, params, "", allowHeader)...)
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w: %s", ErrInvalidParams, strings.Join(problems, "; "))
+}
+
+// matchHeaderNames returns the params with the headers named as in the schema, since header
+// names are case-insensitive
+func matchHeaderNames(schema map[string]interface{}, params map[string]interface{}) map[string]interface{} {
+	headers, ok := params["headers"].(map[string]interface{})
+	if !ok {
+		return params
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	headerSchema, _ := properties["headers"].(map[string]interface{})
+	defined, _ := headerSchema["properties"].(map[string]interface{})
+	names := make(map[string]string, len(defined))
+	for name := range defined {
+		names[http.CanonicalHeaderKey(name)] = name
+	}
+
+	matched := make(map[string]interface{}, len(headers))
+	for name, value := range headers {
+		if schemaName, ok := names[http.CanonicalHeaderKey(name)]; ok {
+			name = schemaName
+		}
+		matched[name] = value
+	}
+	params = maps.Clone(params)
+	params["headers"] = matched
+	return params
+}
+
+// unknownFields returns the fields of value that the object schemas it matches do not define.
+// Objects whose schema has no properties or allows additional ones may hold any field.
+func unknownFields(schema map[string]interface{}, value interface{}, path string, allowHeader func(string) bool) []string {
+	var problems []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, defined := schema["properties"].(map[string]interface{})
+		additional, hasAdditional := schema["additionalProperties"]
+		closed := defined && (!hasAdditional || additional == false)
+		for name, field := range v {
+			property, ok := properties[name].(map[string]interface{})
+			if ok {
+				problems = append(problems, unknownFields(property, field, path+name+".", allowHeader)...)
+				continue
+			}
+			if !closed {
+				continue
+			}
+			switch {
+			case path == "":
+				problems = append(problems, name+": unknown parameter")
+			case path == "headers.":
+				if !allowHeader(name) {
+					problems = append(problems, path+name+": unknown header")
+				}
+			default:
+				problems = append(problems, path+name+": unknown field")
+			}
+		}
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range v {
+			problems = append(problems, unknownFields(items, item, fmt.Sprintf("%s%d.", path, i), allowHeader)...)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SyncResult describes the outcome of syncing an MCP server with its HTTP interfaces
+type SyncResult struct {
+	ServerID string   `json:"serverId"`
+	Version  int      `json:"version"`
+	Updated  []string `json:"updated"` // Tools regenerated from a newer interface version or changed on their external server
+	Added    []string `json:"added"`   // Tools new on an external server
+	Missing  []string `json:"missing"` // Tools whose interface or external tool no longer exists, or removed from a virtual server
+	// External servers that could not be reached, their tools are left unchanged
+	Unreachable []string `json:"unreachable"`
+	// Pending revision holding the synced tools of an active server while changes require approval,
+	// the server is unchanged until it is approved
+	Revision string `json:"revision,omitempty"`
+}
+
+// summary describes the tools changed by a sync, as the note of the server version it creates
+func (r *SyncResult) summary() string {
+	parts := []string{}
+	for _, group := range []struct {
+		label string
+		tools []string
+	}{{"updated", r.Updated}, {"added", r.Added}, {"missing", r.Missing}} {
+		if len(group.tools) > 0 {
+			parts = append(parts, group.label+" "+strings.Join(group.tools, ", "))
+		}
+	}
+	return "Synced tools: " + strings.Join(parts, "; ")
+}
+
+// ServerSyncer regenerates the tools of MCP servers from the HTTP interfaces they were built from.
+// Only the fields derived from the interface (name, description, method, URL, auth, schemas and
+// deprecation) are regenerated; headers, body, response template, plugins and scripts of the tool
+// are kept. The tools of external servers are refreshed from the tools they list, and virtual
+// servers are composed again from their sources.
+type ServerSyncer struct {
+	mcpRepo   repository.MCPServerRepository
+	httpRepo  repository.HTTPInterfaceRepository
+	service   *MCPService
+	revisions repository.RevisionRepository
+	approval  func() bool
+}
+
+// NewServerSyncer creates a new server syncer
+func NewServerSyncer(mcpRepo repository.MCPServerRepository, httpRepo repository.HTTPInterfaceRepository, service *MCPService) *ServerSyncer {
+	return &ServerSyncer{
+		mcpRepo:  mcpRepo,
+		httpRepo: httpRepo,
+		service:  service,
+	}
+}
+
+// SetApproval makes the syncs of active servers pending revisions while required returns true
+func (s *ServerSyncer) SetApproval(revisions repository.RevisionRepository, required func() bool) {
+	s.revisions = revisions
+	s.approval = required
+}
+
+// SyncServer regenerates the tools of a server whose interface changed, refreshes the tools of
+// its external servers and composes a virtual server again, bumping the server version if any
+// tool changed
+func (s *ServerSyncer) SyncServer(ctx context.Context, id string) (*SyncResult, error) {
+	return s.syncServer(ctx, id, true)
+}
+
+// syncServer syncs a server, refreshing the tools of its external servers if external is set
+func (s *ServerSyncer) syncServer(ctx context.Context, id string, external bool) (*SyncResult, error) {
+	server, err := s.mcpRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{
+		ServerID: server.ID,
+		Version:  server.Version,
+		Updated:  []string{},
+		Added:    []string{},
+		Missing:  []string{},
+
+		Unreachable: []string{},
+	}
+
+	for i := range server.Tools {
+		tool := &server.Tools[i]
+		if tool.InterfaceID == "" {
+			continue
+		}
+
+		httpInterface, err := s.httpRepo.GetByID(ctx, tool.InterfaceID)
+		if err == repository.ErrNotFound {
+			result.Missing = append(result.Missing, tool.Name)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if httpInterface.Version == tool.InterfaceVersion {
+			continue
+		}
+
+		generated := models.NewToolFromHTTPInterface(*httpInterface)
+		if generated.Name != tool.Name {
+			renameAllowedTool(server, tool.Name, generated.Name)
+		}
+		tool.Name = generated.Name
+		tool.Description = generated.Description
+		tool.RequestTemplate.Method = generated.RequestTemplate.Method
+		tool.RequestTemplate.URL = generated.RequestTemplate.URL
+		tool.RequestTemplate.ContentType = generated.RequestTemplate.ContentType
+		tool.InterfaceVersion = generated.InterfaceVersion
+		tool.Auth = generated.Auth
+		tool.ParamStyles = generated.ParamStyles
+		tool.Deprecated = generated.Deprecated
+		tool.Sunset = generated.Sunset
+		tool.DeprecationMessage = generated.DeprecationMessage
+		tool.InputSchema = generated.InputSchema
+		tool.OutputSchema = generated.OutputSchema
+		result.Updated = append(result.Updated, tool.Name)
+	}
+
+	if external {
+		s.syncExternalTools(ctx, server, result)
+	}
+	if len(server.Sources) > 0 {
+		added, updated, removed, err := s.composeServer(ctx, server, false)
+		if err != nil {
+			return nil, err
+		}
+		result.Added = append(result.Added, added...)
+		result.Updated = append(result.Updated, updated...)
+		result.Missing = append(result.Missing, removed...)
+	}
+
+	// Tools missing from the sources of a virtual server are removed, other missing tools are kept
+	removed := len(server.Sources) > 0 && len(result.Missing) > 0
+	if len(result.Updated) == 0 && len(result.Added) == 0 && !removed {
+		return result, nil
+	}
+
+	note := &models.ChangeNote{Author: "sync", Message: result.summary()}
+	if s.revisions != nil && server.Status == "active" && s.approval() {
+		// The synced tools are served once an approver applies the revision
+		revision, err := repository.SubmitRevision(repository.WithDefaultChangeNote(ctx, note), s.revisions, server, server, "sync")
+		if err != nil {
+			return nil, err
+		}
+		result.Revision = revision.ID
+		return result, nil
+	}
+	if err := s.mcpRepo.Update(repository.WithDefaultChangeNote(ctx, note), server); err != nil {
+		return nil, err
+	}
+	result.Version = server.Version
+
+	// Serve the regenerated tools right away if the server is registered
+	s.service.RefreshServer(server)
+
+	slog.InfoContext(ctx, "Synced MCP server with HTTP interfaces", "id", server.ID,
+		"version", server.Version, "updated", result.Updated, "added", result.Added, "missing", result.Missing)
+
+	// Compose the virtual servers including the server again
+	if _, err := s.SyncSource(ctx, server.ID); err != nil {
+		slog.WarnContext(ctx, "Failed to sync virtual servers with source", "id", server.ID, "error", err)
+	}
+	return result, nil
+}
+
+// syncExternalTools refreshes the tools of the external servers of a server
+func (s *ServerSyncer) syncExternalTools(ctx context.Context, server *models.MCPServer, result *SyncResult) {
+	configured := make(map[string]bool, len(server.External))
+	for _, external := range server.External {
+		configured[external.Name] = true
+
+		listed, err := s.service.ListExternalTools(ctx, external)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to list the tools of external server", "id", server.ID, "external", external.Name, "error", err)
+			result.Unreachable = append(result.Unreachable, external.Name)
+			continue
+		}
+
+		remote := make(map[string]models.Tool, len(listed))
+		for _, tool := range listed {
+			remote[tool.RemoteName] = tool
+		}
+		for i := range server.Tools {
+			tool := &server.Tools[i]
+			if tool.External != external.Name {
+				continue
+			}
+			current, ok := remote[tool.RemoteName]
+			if !ok {
+				result.Missing = append(result.Missing, tool.Name)
+				continue
+			}
+			delete(remote, tool.RemoteName)
+			if current.Name == tool.Name && current.Description == tool.Description &&
+				reflect.DeepEqual(current.InputSchema, tool.InputSchema) && reflect.DeepEqual(current.OutputSchema, tool.OutputSchema) {
+				continue
+			}
+			if current.Name != tool.Name {
+				renameAllowedTool(server, tool.Name, current.Name)
+			}
+			tool.Name = current.Name
+			tool.Description = current.Description
+			tool.InputSchema = current.InputSchema
+			tool.OutputSchema = current.OutputSchema
+			result.Updated = append(result.Updated, tool.Name)
+		}
+
+		// Keep the order in which the external server lists its tools
+		for _, tool := range listed {
+			if _, ok := remote[tool.RemoteName]; ok {
+				server.Tools = append(server.Tools, tool)
+				server.AllowTools = append(server.AllowTools, tool.Name)
+				result.Added = append(result.Added, tool.Name)
+			}
+		}
+	}
+
+	// Tools of external servers removed from the server
+	for _, tool := range server.Tools {
+		if tool.External != "" && !configured[tool.External] {
+			result.Missing = append(result.Missing, tool.Name)
+		}
+	}
+}
+
+// SyncInterface syncs every server with a tool generated from the HTTP interface
+func (s *ServerSyncer) SyncInterface(ctx context.Context, interfaceID string) ([]SyncResult, error) {
+	servers, err := s.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []SyncResult{}
+	for _, server := range servers {
+		if !usesInterface(&server, interfaceID) {
+			continue
+		}
+
+		// Only the interface changed, the external servers need not be contacted
+		result, err := s.syncServer(ctx, server.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// SyncSource composes the virtual servers including a server again
+func (s *ServerSyncer) SyncSource(ctx context.Context, serverID string) ([]SyncResult, error) {
+	servers, err := s.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []SyncResult{}
+	for _, server := range servers {
+		if !usesSource(&server, serverID) {
+			continue
+		}
+
+		result, err := s.syncServer(ctx, server.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// usesSource reports whether the server is a virtual server including the source server
+func usesSource(server *models.MCPServer, serverID string) bool {
+	for _, source := range server.Sources {
+		if source.ServerID == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// usesInterface reports whether a tool of the server was generated from the HTTP interface
+func usesInterface(server *models.MCPServer, interfaceID string) bool {
+	for _, tool := range server.Tools {
+		if tool.InterfaceID == interfaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// renameAllowedTool keeps the allow list and the steps of chained tools in line with a renamed tool
+func renameAllowedTool(server *models.MCPServer, oldName, newName string) {
+	for i, allowed := range server.AllowTools {
+		if allowed == oldName {
+			server.AllowTools[i] = newName
+		}
+	}
+	for i := range server.Tools {
+		for j := range server.Tools[i].Steps {
+			if server.Tools[i].Steps[j].Tool == oldName {
+				server.Tools[i].Steps[j].Tool = newName
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// redacted replaces credentials in traced requests
+const redacted = "[REDACTED]"
+
+// secretHeaders are the request headers carrying credentials, redacted from traced requests and
+// recorded tool parameters
+var secretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// ResolvedRequest is the upstream request of a tool call after templates, environment,
+// scripts, plugins and auth were applied. Credentials are redacted.
+type ResolvedRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// ToolTrace records how a tool call reached its upstream
+type ToolTrace struct {
+	Request         *ResolvedRequest // nil if the call failed before its request was sent
+	UpstreamStatus  int              // 0 if no upstream response was received
+	UpstreamProto   string           // Protocol of the upstream response, e.g. HTTP/1.1
+	UpstreamHeader  http.Header      // Headers of the upstream response
+	UpstreamLatency time.Duration    // Time to the upstream response
+	UpstreamBody    []byte           // Upstream response body, before the response plugins
+	Truncated       bool             // The body was cut off at the timeout and returned partially
+}
+
+type traceKey struct{}
+
+// WithTrace returns a copy of ctx in which tool calls fill trace
+func WithTrace(ctx context.Context, trace *ToolTrace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// traceOf returns the trace of ctx, or nil
+func traceOf(ctx context.Context) *ToolTrace {
+	trace, _ := ctx.Value(traceKey{}).(*ToolTrace)
+	return trace
+}
+
+// resolveRequest describes req with the credentials set by the auth profile and cookies redacted
+func resolveRequest(req *http.Request, auth *models.Auth) *ResolvedRequest {
+	secret := map[string]bool{}
+	for _, name := range secretHeaders {
+		secret[name] = true
+	}
+	u := *req.URL
+	if auth != nil {
+		switch auth.Type {
+		case models.AuthAPIKeyHeader:
+			secret[http.CanonicalHeaderKey(auth.Name)] = true
+		case models.AuthAPIKeyQuery:
+			q := u.Query()
+			if q.Has(auth.Name) {
+				q.Set(auth.Name, redacted)
+				u.RawQuery = q.Encode()
+			}
+		}
+	}
+
+	resolved := &ResolvedRequest{
+		Method:  req.Method,
+		URL:     u.String(),
+		Headers: map[string]string{},
+	}
+	for key, values := range req.Header {
+		if secret[http.CanonicalHeaderKey(key)] {
+			resolved.Headers[key] = redacted
+		} else if len(values) > 0 {
+			resolved.Headers[key] = values[0]
+		}
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxRecordedPayload+1))
+			body.Close()
+			resolved.Body = truncate(string(data), maxRecordedPayload)
+		}
+	}
+	return resolved
+}
+
+// RedactParams returns a copy of the parameters of a tool call with the values of the secret
+// headers of the headers param and of the cookies param redacted
+func RedactParams(params map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		copied[key] = value
+	}
+	if headers, ok := params["headers"].(map[string]interface{}); ok {
+		redactedHeaders := make(map[string]interface{}, len(headers))
+		for name, value := range headers {
+			if slices.Contains(secretHeaders, http.CanonicalHeaderKey(name)) {
+				value = redacted
+			}
+			redactedHeaders[name] = value
+		}
+		copied["headers"] = redactedHeaders
+	}
+	if cookies, ok := params["cookies"].(map[string]interface{}); ok {
+		redactedCookies := make(map[string]interface{}, len(cookies))
+		for name := range cookies {
+			redactedCookies[name] = redacted
+		}
+		copied["cookies"] = redactedCookies
+	}
+	return copied
+}
+
+// StripCredentials removes the secret headers of the headers param and the cookies param from the
+// parameters of a tool call. It returns the removed entries, e.g. headers.Authorization and cookies.
+func StripCredentials(params map[string]interface{}) []string {
+	stripped := []string{}
+	if headers, ok := params["headers"].(map[string]interface{}); ok {
+		kept := make(map[string]interface{}, len(headers))
+		for name, value := range headers {
+			if slices.Contains(secretHeaders, http.CanonicalHeaderKey(name)) {
+				stripped = append(stripped, "headers."+http.CanonicalHeaderKey(name))
+			} else {
+				kept[name] = value
+			}
+		}
+		params["headers"] = kept
+	}
+	if _, ok := params["cookies"]; ok {
+		delete(params, "cookies")
+		stripped = append(stripped, "cookies")
+	}
+	slices.Sort(stripped)
+	return stripped
+}
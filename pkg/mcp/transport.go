@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"golang.org/x/net/http2"
+)
+
+// protocolAuto is reported for the hosts without a protocol
+const protocolAuto = "auto"
+
+// UpstreamConnectionStats counts the connections used by the calls to an upstream host
+type UpstreamConnectionStats struct {
+	Host              string           `json:"host"`              // Host and port
+	Protocol          string           `json:"protocol"`          // Configured protocol: auto, http1, h2 or h2c
+	Requests          int64            `json:"requests"`          // Requests sent
+	Errors            int64            `json:"errors"`            // Requests that got no response
+	NewConnections    int64            `json:"newConnections"`    // Requests sent on a new connection
+	ReusedConnections int64            `json:"reusedConnections"` // Requests sent on a connection kept from an earlier request
+	ReuseRatio        float64          `json:"reuseRatio"`        // Share of the connections that were reused
+	Responses         map[string]int64 `json:"responses"`         // Responses by protocol, e.g. HTTP/1.1 or HTTP/2.0
+	LastUsedAt        time.Time        `json:"lastUsedAt"`
+}
+
+// upstreamTransport sends the calls to upstream hosts with the HTTP protocol set for their host
+// and counts how often their connections are reused
+type upstreamTransport struct {
+	auto  *http.Transport  // HTTP/2 negotiated over TLS, HTTP/1.1 over cleartext TCP
+	http1 *http.Transport  // HTTP/1.1 only
+	h2    *http2.Transport // HTTP/2 over TLS
+	h2c   *http2.Transport // HTTP/2 over cleartext TCP
+
+	mu        sync.RWMutex
+	protocols map[string]string // Protocols by host name, "*.example.com" matches subdomains
+
+	statsMu sync.Mutex
+	stats   map[string]*UpstreamConnectionStats
+}
+
+// newUpstreamTransport creates the transport of the calls to upstream hosts
+func newUpstreamTransport() *upstreamTransport {
+	auto := http.DefaultTransport.(*http.Transport).Clone()
+
+	// A non-nil empty TLSNextProto disables HTTP/2
+	http1 := http.DefaultTransport.(*http.Transport).Clone()
+	http1.ForceAttemptHTTP2 = false
+	http1.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+
+	return &upstreamTransport{
+		auto:  auto,
+		http1: http1,
+		h2:    &http2.Transport{IdleConnTimeout: auto.IdleConnTimeout},
+		h2c: &http2.Transport{
+			AllowHTTP:       true,
+			IdleConnTimeout: auto.IdleConnTimeout,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		},
+		protocols: map[string]string{},
+		stats:     map[string]*UpstreamConnectionStats{},
+	}
+}
+
+// setProtocols replaces the protocols of the upstream hosts
+func (t *upstreamTransport) setProtocols(protocols map[string]string) {
+	normalized := make(map[string]string, len(protocols))
+	for host, protocol := range protocols {
+		normalized[strings.ToLower(strings.TrimSpace(host))] = protocol
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.protocols = normalized
+}
+
+// protocolOf returns the protocol of a host name: the one of the host, else the one of its most
+// specific wildcard, else auto
+func (t *upstreamTransport) protocolOf(host string) string {
+	host = strings.ToLower(host)
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if protocol, ok := t.protocols[host]; ok {
+		return protocol
+	}
+	protocol, longest := protocolAuto, 0
+	for pattern, candidate := range t.protocols {
+		if len(pattern) > longest && hostMatches(pattern, host) {
+			protocol, longest = candidate, len(pattern)
+		}
+	}
+	return protocol
+}
+
+// RoundTrip sends req with the protocol of its host
+func (t *upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	protocol := t.protocolOf(req.URL.Hostname())
+	var next http.RoundTripper
+	switch protocol {
+	case models.ProtocolHTTP1:
+		next = t.http1
+	case models.ProtocolH2:
+		if req.URL.Scheme != "https" {
+			return nil, fmt.Errorf("upstream host %s uses h2, which requires https URLs", req.URL.Hostname())
+		}
+		next = t.h2
+	case models.ProtocolH2C:
+		if req.URL.Scheme != "http" {
+			return nil, fmt.Errorf("upstream host %s uses h2c, which requires http URLs", req.URL.Hostname())
+		}
+		next = t.h2c
+	default:
+		next = t.auto
+	}
+
+	var reused, gotConn bool
+	trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) {
+		reused, gotConn = info.Reused, true
+	}}
+	resp, err := next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+
+	t.statsMu.Lock()
+	defer t.statsMu.Unlock()
+	stats, ok := t.stats[req.URL.Host]
+	if !ok {
+		stats = &UpstreamConnectionStats{Host: req.URL.Host, Responses: map[string]int64{}}
+		t.stats[req.URL.Host] = stats
+	}
+	stats.Protocol = protocol
+	stats.Requests++
+	stats.LastUsedAt = time.Now()
+	if gotConn {
+		if reused {
+			stats.ReusedConnections++
+		} else {
+			stats.NewConnections++
+		}
+	}
+	if err != nil {
+		stats.Errors++
+		return nil, err
+	}
+	stats.Responses[resp.Proto]++
+	return resp, nil
+}
+
+// connectionStats returns the connection statistics of the upstream hosts called so far, by host
+func (t *upstreamTransport) connectionStats() []UpstreamConnectionStats {
+	t.statsMu.Lock()
+	result := make([]UpstreamConnectionStats, 0, len(t.stats))
+	for _, stats := range t.stats {
+		copied := *stats
+		copied.Responses = make(map[string]int64, len(stats.Responses))
+		for proto, count := range stats.Responses {
+			copied.Responses[proto] = count
+		}
+		if connections := copied.NewConnections + copied.ReusedConnections; connections > 0 {
+			copied.ReuseRatio = float64(copied.ReusedConnections) / float64(connections)
+		}
+		result = append(result, copied)
+	}
+	t.statsMu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Host < result[j].Host })
+	return result
+}
+
+// SetUpstreamProtocols sets the HTTP protocol of the calls to upstream hosts, by host name.
+// "*.example.com" matches any subdomain; the other hosts negotiate HTTP/2 over TLS.
+func (s *MCPService) SetUpstreamProtocols(protocols map[string]string) {
+	s.transport.setProtocols(protocols)
+}
+
+// UpstreamConnections returns the protocol and the connection reuse of the calls to each
+// upstream host since the start
+func (s *MCPService) UpstreamConnections() []UpstreamConnectionStats {
+	return s.transport.connectionStats()
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrUnsafeParam is returned when a param would change the upstream host or leave the path of
+// the URL template, e.g. with ".." or a host name with a path
+var ErrUnsafeParam = errors.New("unsafe URL parameter")
+
+// urlPlaceholder matches the {name} placeholders of URL templates
+var urlPlaceholder = regexp.MustCompile(`\{([^{}/?#]+)\}`)
+
+// hostValue matches the values allowed in the host of a URL, e.g. a region in {region}.example.com
+var hostValue = regexp.MustCompile(`^[A-Za-z0-9.-]*$`)
+
+// substituteURLParams replaces the {name} placeholders of a URL template with the params,
+// encoded for where they are: path params are percent-encoded so that "/", "?" and spaces stay
+// in their segment, query params are query-encoded, and host params may only be host names.
+// Placeholders without a param are left as they are.
+func substituteURLParams(tool *models.Tool, template string, params map[string]interface{}) (string, error) {
+	pathStart, queryStart := urlParts(template)
+
+	var out strings.Builder
+	last := 0
+	for _, match := range urlPlaceholder.FindAllStringSubmatchIndex(template, -1) {
+		name := template[match[2]:match[3]]
+		value, ok := params[name]
+		if !ok {
+			continue
+		}
+		raw := strings.Join(paramValues(tool, name, value), ",")
+
+		var encoded string
+		switch {
+		case match[0] < pathStart:
+			if !hostValue.MatchString(raw) {
+				return "", fmt.Errorf("%w: %s must be a host name", ErrUnsafeParam, name)
+			}
+			encoded = raw
+		case match[0] < queryStart:
+			// Dot segments would make the upstream resolve another endpoint
+			if raw == "." || raw == ".." {
+				return "", fmt.Errorf("%w: %s must not be '%s'", ErrUnsafeParam, name, raw)
+			}
+			encoded = url.PathEscape(raw)
+		default:
+			encoded = url.QueryEscape(raw)
+		}
+
+		out.WriteString(template[last:match[0]])
+		out.WriteString(encoded)
+		last = match[1]
+	}
+	out.WriteString(template[last:])
+
+	result := out.String()
+	if _, err := url.Parse(result); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsafeParam, err)
+	}
+	return result, nil
+}
+
+// checkDotSegments returns ErrUnsafeParam if the path of a rendered URL template has a "." or
+// ".." segment, percent-encoded or not, which the output of template actions could add to make
+// the upstream resolve another endpoint
+func checkDotSegments(template string) error {
+	pathStart, queryStart := urlParts(template)
+	path := template[pathStart:queryStart]
+	if decoded, err := url.PathUnescape(path); err == nil {
+		path = decoded
+	}
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("%w: the path must not have '%s' segments", ErrUnsafeParam, segment)
+		}
+	}
+	return nil
+}
+
+// urlParts returns where the path and the query (or fragment) of a URL template start, the end
+// of the template if it has none. Templates without a scheme are all path.
+func urlParts(template string) (pathStart, queryStart int) {
+	if scheme := strings.Index(template, "://"); scheme >= 0 {
+		authority := scheme + len("://")
+		pathStart = len(template)
+		if end := strings.IndexAny(template[authority:], "/?#"); end >= 0 {
+			pathStart = authority + end
+		}
+	}
+	queryStart = len(template)
+	if end := strings.IndexAny(template[pathStart:], "?#"); end >= 0 {
+		queryStart = pathStart + end
+	}
+	return pathStart, queryStart
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/metrics"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"golang.org/x/net/websocket"
+)
+
+// callWebSocket connects to the WebSocket upstream of a tool, sends the message of its request
+// template and returns the collected replies through the post script and the response template
+func (s *MCPService) callWebSocket(ctx context.Context, tool *models.Tool, params map[string]interface{}, scriptHeaders map[string]string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, tool.WebSocket.Timeout())
+	defer cancel()
+
+	// Build the handshake like an HTTP request, so that the auth profile applies to it
+	rawURL, err := substituteURLParams(tool, tool.RequestTemplate.URL, params)
+	if err != nil {
+		return "", 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	if req.URL.Scheme != "ws" && req.URL.Scheme != "wss" {
+		return "", 0, fmt.Errorf("WebSocket URL '%s' must start with ws:// or wss://", rawURL)
+	}
+	for key, value := range tool.RequestTemplate.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range scriptHeaders {
+		req.Header.Set(key, value)
+	}
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		req.Header.Set(logging.RequestIDHeader, requestID)
+	}
+	if err := s.applyAuth(ctx, tool.Auth, req); err != nil {
+		slog.ErrorContext(ctx, "Failed to apply auth profile", "error", err)
+		return "", 0, err
+	}
+
+	// Only call the upstream hosts of the allowlists
+	if err := s.checkHost(req.URL.Hostname()); err != nil {
+		slog.ErrorContext(ctx, "Upstream host rejected", "error", err)
+		return "", 0, err
+	}
+	if err := checkPolicyHosts(ctx, req.URL.Hostname()); err != nil {
+		slog.ErrorContext(ctx, "Upstream host rejected", "error", err)
+		return "", 0, err
+	}
+
+	message := ""
+	if tool.RequestTemplate.Body != "" {
+		message, err = replaceParams(tool.RequestTemplate.Body, params)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	slog.InfoContext(ctx, "Connecting to WebSocket", "url", req.URL.String())
+	trace := traceOf(ctx)
+	if trace != nil {
+		trace.Request = resolveRequest(req, tool.Auth)
+		trace.Request.Body = message
+	}
+
+	start := time.Now()
+	replies, err := exchangeMessages(ctx, req, message, tool.WebSocket)
+	if trace != nil {
+		trace.UpstreamLatency = time.Since(start)
+	}
+	if err != nil {
+		metrics.ObserveUpstreamRequest(req.URL.Host, req.Method, 0, time.Since(start))
+		slog.ErrorContext(ctx, "WebSocket exchange failed", "error", err)
+		return "", 0, err
+	}
+	metrics.ObserveUpstreamRequest(req.URL.Host, req.Method, http.StatusSwitchingProtocols, time.Since(start))
+
+	body := websocketResult(replies)
+	if trace != nil {
+		trace.UpstreamStatus = http.StatusSwitchingProtocols
+		trace.UpstreamBody = body
+	}
+	slog.DebugContext(ctx, "WebSocket replies", "count", len(replies), "body", string(body))
+
+	// Let the post script reshape the replies
+	if tool.PostScript != "" {
+		body, err = s.scripts.RunPost(ctx, tool.PostScript, http.StatusSwitchingProtocols, map[string]string{}, body)
+		if err != nil {
+			slog.ErrorContext(ctx, "Post script failed", "error", err)
+			return "", http.StatusSwitchingProtocols, err
+		}
+	}
+
+	result, err := s.processResponse(tool, body)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to process response", "error", err)
+		return "", http.StatusSwitchingProtocols, err
+	}
+	return result, http.StatusSwitchingProtocols, nil
+}
+
+// exchangeMessages opens the WebSocket of the handshake request, sends the message if there is
+// one and collects the replies until the limit of the exchange is reached, a reply contains its
+// terminator or the upstream closes the connection
+func exchangeMessages(ctx context.Context, req *http.Request, message string, exchange *models.WebSocketExchange) ([]string, error) {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		origin = "http://" + req.URL.Host
+		if req.URL.Scheme == "wss" {
+			origin = "https://" + req.URL.Host
+		}
+	}
+	config, err := websocket.NewConfig(req.URL.String(), origin)
+	if err != nil {
+		return nil, err
+	}
+	config.Header = req.Header.Clone()
+	config.Header.Del("Origin")
+
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("WebSocket handshake with %s failed: %w", req.URL.Host, err)
+	}
+	defer conn.Close()
+
+	// Unblock the reads when the exchange times out or the call is canceled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if message != "" {
+		if err := websocket.Message.Send(conn, message); err != nil {
+			return nil, fmt.Errorf("sending the WebSocket message failed: %w", err)
+		}
+	}
+
+	limit := exchange.MessageLimit()
+	replies := make([]string, 0, min(limit, 16))
+	for len(replies) < limit {
+		var reply []byte
+		if err := websocket.Message.Receive(conn, &reply); err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("WebSocket exchange timed out after %d of %d replies: %w", len(replies), limit, ctx.Err())
+			}
+			// Upstreams streaming a bounded result close the connection once it is sent
+			if errors.Is(err, io.EOF) && len(replies) > 0 {
+				break
+			}
+			return nil, fmt.Errorf("receiving a WebSocket reply failed: %w", err)
+		}
+		replies = append(replies, string(reply))
+		if exchange.Terminator != "" && strings.Contains(string(reply), exchange.Terminator) {
+			break
+		}
+	}
+	return replies, nil
+}
+
+// websocketResult returns a single reply as is, and several as a JSON array of the replies,
+// embedding those that are JSON and quoting the others
+func websocketResult(replies []string) []byte {
+	if len(replies) == 1 {
+		return []byte(replies[0])
+	}
+
+	items := make([]json.RawMessage, len(replies))
+	for i, reply := range replies {
+		if json.Valid([]byte(reply)) {
+			items[i] = json.RawMessage(reply)
+			continue
+		}
+		// Marshaling a string cannot fail
+		quoted, _ := json.Marshal(reply)
+		items[i] = quoted
+	}
+	// Marshaling valid raw messages cannot fail
+	body, _ := json.Marshal(items)
+	return body
+}
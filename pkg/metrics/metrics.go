@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mcp_gateway"
+
+var (
+	// ToolInvocations counts tool invocations by server, tool and outcome
+	ToolInvocations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_invocations_total",
+		Help:      "Total number of tool invocations.",
+	}, []string{"server", "tool", "status"})
+
+	// ToolInvocationDuration observes the end-to-end duration of tool invocations
+	ToolInvocationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_invocation_duration_seconds",
+		Help:      "Duration of tool invocations in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"server", "tool"})
+
+	// LatencyBudgetViolations counts the tool calls slower than the latency budget of their tool,
+	// by whether the call was cut off at the target
+	LatencyBudgetViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "latency_budget_violations_total",
+		Help:      "Total number of tool invocations exceeding the latency budget of the tool.",
+	}, []string{"server", "tool", "enforced"})
+
+	// UpstreamRequestDuration observes the latency of requests sent to upstream APIs
+	UpstreamRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of upstream HTTP requests in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"host", "method", "status_code"})
+
+	// HedgedRequests counts the tool calls that sent a second upstream request, by the request that won
+	HedgedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "hedged_requests_total",
+		Help:      "Total number of tool calls that sent a hedged upstream request.",
+	}, []string{"server", "tool", "winner"})
+
+	// UpstreamRetries counts the upstream requests sent again under the retry policy of a tool
+	UpstreamRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_retries_total",
+		Help:      "Total number of upstream requests retried under the call policy of a tool.",
+	}, []string{"server", "tool"})
+
+	// ShadowCalls counts the calls sent to the shadow tool of a tool, by how their result compared
+	ShadowCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shadow_calls_total",
+		Help:      "Total number of tool calls also sent to a shadow tool.",
+	}, []string{"server", "tool", "result"})
+
+	// UpstreamRevalidations counts the conditional requests sent for kept upstream responses, by outcome
+	UpstreamRevalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_revalidations_total",
+		Help:      "Total number of conditional upstream requests sent for kept responses.",
+	}, []string{"server", "tool", "result"})
+
+	// RepositoryErrors counts failed repository operations
+	RepositoryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repository_errors_total",
+		Help:      "Total number of failed repository operations.",
+	}, []string{"repository", "operation"})
+
+	// DBQueryDuration observes the duration of database statements by operation and table
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of database statements in seconds.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	// DBSlowQueries counts the database statements slower than the slow query threshold
+	DBSlowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_slow_queries_total",
+		Help:      "Total number of database statements slower than the slow query threshold.",
+	}, []string{"operation", "table"})
+
+	// HTTPRequests counts handled HTTP requests
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests handled by the gateway.",
+	}, []string{"method", "route", "status_code"})
+
+	// HTTPRequestDuration observes the duration of handled HTTP requests
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests handled by the gateway in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// RegisterActiveServers registers a gauge reporting the number of active MCP servers
+func RegisterActiveServers(count func() float64) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_servers",
+		Help:      "Number of MCP servers with status active.",
+	}, count)
+}
+
+// ObserveToolInvocation records the outcome and duration of a tool invocation
+func ObserveToolInvocation(server, tool string, err error, duration time.Duration) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	ToolInvocations.WithLabelValues(server, tool, status).Inc()
+	ToolInvocationDuration.WithLabelValues(server, tool).Observe(duration.Seconds())
+}
+
+// ObserveLatencyBudgetViolation records a tool call exceeding the latency budget of the tool,
+// enforced if it was cut off at the target
+func ObserveLatencyBudgetViolation(server, tool string, enforced bool) {
+	LatencyBudgetViolations.WithLabelValues(server, tool, strconv.FormatBool(enforced)).Inc()
+}
+
+// ObserveUpstreamRequest records the latency of an upstream request.
+// A status code of 0 means the request failed before a response was received.
+func ObserveUpstreamRequest(host, method string, statusCode int, duration time.Duration) {
+	UpstreamRequestDuration.WithLabelValues(host, method, strconv.Itoa(statusCode)).Observe(duration.Seconds())
+}
+
+// ObserveHedgedRequest records a hedged tool call. The winner is primary, hedge, or none if both
+// requests failed.
+func ObserveHedgedRequest(server, tool, winner string) {
+	HedgedRequests.WithLabelValues(server, tool, winner).Inc()
+}
+
+// ObserveUpstreamRetry records an upstream request retried under the call policy of a tool
+func ObserveUpstreamRetry(server, tool string) {
+	UpstreamRetries.WithLabelValues(server, tool).Inc()
+}
+
+// ObserveShadowCall records a call sent to the shadow tool of a tool. The result is match,
+// mismatch, or error if the shadow call failed.
+func ObserveShadowCall(server, tool, result string) {
+	ShadowCalls.WithLabelValues(server, tool, result).Inc()
+}
+
+// ObserveUpstreamRevalidation records a conditional upstream request, notModified if the kept
+// response was served
+func ObserveUpstreamRevalidation(server, tool string, notModified bool) {
+	result := "modified"
+	if notModified {
+		result = "not_modified"
+	}
+	UpstreamRevalidations.WithLabelValues(server, tool, result).Inc()
+}
+
+// ObserveDBQuery records the duration of a database statement, counting it as slow if it is
+func ObserveDBQuery(operation, table string, duration time.Duration, slow bool) {
+	DBQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+	if slow {
+		DBSlowQueries.WithLabelValues(operation, table).Inc()
+	}
+}
+
+// Middleware records request counts and durations for every gin route
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Use the route template to keep label cardinality bounded
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler returns the gin handler serving metrics in the Prometheus exposition format
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
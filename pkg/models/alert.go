@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// AlertWebhook represents a webhook notified when tools fail or upstream targets go down
+type AlertWebhook struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name" binding:"required"`
+	URL                string    `json:"url" binding:"required"`
+	Format             string    `json:"format"` // "json" (default) or "slack"
+	Enabled            bool      `json:"enabled"`
+	ErrorRateThreshold float64   `json:"errorRateThreshold"` // Tool error rate (0-1) that fires an alert; 0 disables error rate alerts
+	MinCalls           int       `json:"minCalls"`           // Minimum calls in the window before the error rate is considered
+	WindowSeconds      int       `json:"windowSeconds"`      // Window over which the error rate is computed
+	UpstreamHealth     bool      `json:"upstreamHealth"`     // Notify when upstream targets become unhealthy or recover
+	CooldownSeconds    int       `json:"cooldownSeconds"`    // Minimum time between two notifications for the same alert
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// WithDefaults returns a copy of the webhook with zero values replaced by defaults
+func (w AlertWebhook) WithDefaults() AlertWebhook {
+	if w.Format == "" {
+		w.Format = "json"
+	}
+	if w.MinCalls == 0 {
+		w.MinCalls = 10
+	}
+	if w.WindowSeconds == 0 {
+		w.WindowSeconds = 300
+	}
+	if w.CooldownSeconds == 0 {
+		w.CooldownSeconds = 900
+	}
+	return w
+}
+
+// Alert is the notification sent to alert webhooks
+type Alert struct {
+	Type      string            `json:"type"`   // "tool_error_rate", "upstream_health" or "test"
+	Status    string            `json:"status"` // "firing" or "resolved"
+	Summary   string            `json:"summary"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value,omitempty"`
+	Threshold float64           `json:"threshold,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
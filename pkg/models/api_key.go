@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKey identifies a client invoking tools and limits the cost of its tool calls. A tool call
+// costs the cost weight of its tool, 1 by default. Only a hash of the key is stored: the key is
+// returned once, when it is created.
+type APIKey struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Key             string    `json:"key,omitempty"`   // Returned on creation only
+	Prefix          string    `json:"prefix"`          // Start of the key, to recognize it
+	Hash            string    `json:"-"`               // SHA-256 of the key
+	MaxCostPerDay   float64   `json:"maxCostPerDay"`   // Cost of the tool calls per UTC day, 0 for unlimited
+	MaxCostPerMonth float64   `json:"maxCostPerMonth"` // Cost of the tool calls per UTC month, 0 for unlimited
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// APIKeyPeriodUsage is the usage of an API key in a UTC day or month
+type APIKeyPeriodUsage struct {
+	Period string  `json:"period"` // YYYY-MM-DD or YYYY-MM
+	Calls  int     `json:"calls"`
+	Cost   float64 `json:"cost"`
+}
+
+// APIKeyUsage reports the usage of an API key in a UTC month against its quotas
+type APIKeyUsage struct {
+	KeyID           string              `json:"keyId"`
+	Name            string              `json:"name"`
+	Month           string              `json:"month"` // YYYY-MM
+	Calls           int                 `json:"calls"` // Tool calls of the month
+	Cost            float64             `json:"cost"`  // Cost of the tool calls of the month
+	Days            []APIKeyPeriodUsage `json:"days"`  // Usage of the days with calls, oldest first
+	MaxCostPerDay   float64             `json:"maxCostPerDay"`
+	MaxCostPerMonth float64             `json:"maxCostPerMonth"`
+	RemainingDay    *float64            `json:"remainingDay,omitempty"`   // Cost left today, unset if unlimited or not the current month
+	RemainingMonth  *float64            `json:"remainingMonth,omitempty"` // Cost left this month, unset if unlimited or not the current month
+}
+
+// QuotaRemaining is the cost an API key may still spend, negative if unlimited
+type QuotaRemaining struct {
+	Day   float64
+	Month float64
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Authentication types of an HTTP interface
+const (
+	AuthNone         = "none"
+	AuthBasic        = "basic"
+	AuthBearer       = "bearer"
+	AuthAPIKeyHeader = "api-key-header"
+	AuthAPIKeyQuery  = "api-key-query"
+	AuthOAuth2       = "oauth2" // OAuth2 client credentials grant
+)
+
+// Auth is the authentication profile of an HTTP interface, applied to every request of the tools
+// generated from it. Credentials are never stored in the profile, it references secrets by name.
+type Auth struct {
+	Type     string   `json:"type" binding:"required,oneof=none basic bearer api-key-header api-key-query oauth2"`
+	Username string   `json:"username,omitempty"` // basic
+	Secret   string   `json:"secret,omitempty"`   // Secret holding the password, token, API key or OAuth2 client secret
+	Name     string   `json:"name,omitempty"`     // Header or query parameter carrying the API key
+	TokenURL string   `json:"tokenUrl,omitempty"` // oauth2 token endpoint
+	ClientID string   `json:"clientId,omitempty"` // oauth2
+	Scopes   []string `json:"scopes,omitempty"`   // oauth2
+}
+
+// Validate checks that the profile has the fields its type requires
+func (a *Auth) Validate() error {
+	switch a.Type {
+	case AuthNone:
+		return nil
+	case AuthBasic, AuthBearer, AuthAPIKeyHeader, AuthAPIKeyQuery, AuthOAuth2:
+	default:
+		return fmt.Errorf("invalid auth type '%s'", a.Type)
+	}
+	if a.Secret == "" {
+		return fmt.Errorf("%s auth requires a secret", a.Type)
+	}
+
+	switch a.Type {
+	case AuthBasic:
+		if a.Username == "" {
+			return fmt.Errorf("basic auth requires a username")
+		}
+	case AuthAPIKeyHeader:
+		if a.Name == "" {
+			return fmt.Errorf("api-key-header auth requires the name of the header")
+		}
+	case AuthAPIKeyQuery:
+		if a.Name == "" {
+			return fmt.Errorf("api-key-query auth requires the name of the query parameter")
+		}
+	case AuthOAuth2:
+		if a.TokenURL == "" || a.ClientID == "" {
+			return fmt.Errorf("oauth2 auth requires a tokenUrl and a clientId")
+		}
+	}
+	return nil
+}
+
+// Secret is a named credential referenced by auth profiles. Its value is write-only,
+// the API never returns it.
+type Secret struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name" binding:"required"`
+	Description   string          `json:"description"`
+	Value         string          `json:"value,omitempty"`
+	Rotation      *SecretRotation `json:"rotation,omitempty"`      // Scheduled replacements of the value
+	RotatedAt     *time.Time      `json:"rotatedAt,omitempty"`     // Last successful rotation, set by the gateway
+	RotationError string          `json:"rotationError,omitempty"` // Error of the last rotation if it failed, set by the gateway
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
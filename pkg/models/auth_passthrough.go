@@ -0,0 +1,76 @@
+package models
+
+import "fmt"
+
+// Passthrough modes of the Authorization header of callers
+const (
+	PassthroughForward = "forward" // Sent upstream, over the Authorization of the auth profile of the tool
+	PassthroughReplace = "replace" // Replaced by the stored credential of the policy
+	PassthroughStrip   = "strip"   // Removed, the auth profile of the tool still applies
+)
+
+// AuthPassthrough decides whether the Authorization header of a caller reaches the upstream. The
+// header is taken from the headers param of the call, or else from the request of the call. Set
+// on a tool, the policy overrides the one of its server. Without a policy, an Authorization in the
+// headers param is forwarded unless the auth profile of the tool sets its own, and the header of
+// the request of the call is never forwarded.
+type AuthPassthrough struct {
+	Mode       string `json:"mode" binding:"omitempty,oneof=forward replace strip"`
+	Credential *Auth  `json:"credential,omitempty"` // Auth profile sent instead of the header of the caller with replace
+}
+
+// Validate checks the mode of the policy and the credential replace requires
+func (p *AuthPassthrough) Validate() error {
+	if p == nil {
+		return nil
+	}
+	switch p.Mode {
+	case PassthroughForward, PassthroughStrip:
+		if p.Credential != nil {
+			return fmt.Errorf("auth passthrough %s does not use a credential", p.Mode)
+		}
+		return nil
+	case PassthroughReplace:
+		if p.Credential == nil {
+			return fmt.Errorf("auth passthrough replace requires a credential")
+		}
+		return p.Credential.Validate()
+	default:
+		return fmt.Errorf("invalid auth passthrough mode '%s', must be forward, replace or strip", p.Mode)
+	}
+}
+
+// SetsAuthorization reports whether the profile authenticates with the Authorization header
+func (a *Auth) SetsAuthorization() bool {
+	if a == nil {
+		return false
+	}
+	switch a.Type {
+	case AuthBasic, AuthBearer, AuthOAuth2:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuthPassthroughOf returns the passthrough policy of a tool of the server: its own, else the one
+// of the server, nil if neither has one
+func (m *MCPServer) AuthPassthroughOf(tool *Tool) *AuthPassthrough {
+	if tool.AuthPassthrough != nil {
+		return tool.AuthPassthrough
+	}
+	return m.AuthPassthrough
+}
+
+// ValidateAuthPassthrough checks the passthrough policies of the server and of its tools
+func (m *MCPServer) ValidateAuthPassthrough() error {
+	if err := m.AuthPassthrough.Validate(); err != nil {
+		return err
+	}
+	for _, tool := range m.Tools {
+		if err := tool.AuthPassthrough.Validate(); err != nil {
+			return fmt.Errorf("tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
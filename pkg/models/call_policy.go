@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Defaults of retried calls
+const (
+	DefaultRetryBackoff = 100 * time.Millisecond
+)
+
+// CallPolicy sets the timeout, retries, rate limit and upstream hosts of tool calls. A namespace
+// policy sets the defaults of the servers of the namespace and a server policy those of its tools;
+// the fields a policy leaves unset are inherited.
+type CallPolicy struct {
+	TimeoutMs int            `json:"timeoutMs,omitempty"` // Time allowed for a call, retries included
+	Retry     *RetryPolicy   `json:"retry,omitempty"`     // Retries of the calls whose upstream is unavailable
+	RateLimit *CallRateLimit `json:"rateLimit,omitempty"` // Calls of each caller, counted at the level setting the limit
+	// Upstream hosts the calls may reach, "*.example.com" matching subdomains. Lists narrow the
+	// inherited ones instead of replacing them: a host must be allowed at every level setting one.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+}
+
+// RetryPolicy retries the calls that fail to reach their upstream or get a 502, 503 or 504
+// response. Only GET, HEAD, PUT and DELETE calls, which are idempotent, are retried.
+type RetryPolicy struct {
+	MaxAttempts int `json:"maxAttempts"`         // Attempts of a call, the first included; 1 disables retries
+	BackoffMs   int `json:"backoffMs,omitempty"` // Delay before the first retry, doubled at each retry, 100 by default
+}
+
+// CallRateLimit limits the calls of each caller
+type CallRateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"` // 0 lifts an inherited limit
+	Burst             int     `json:"burst,omitempty"`   // Defaults to the rate rounded up
+}
+
+// NamespacePolicy is the call policy inherited by the servers and tools of a namespace
+type NamespacePolicy struct {
+	Namespace string     `json:"namespace"`
+	Policy    CallPolicy `json:"policy"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Timeout returns the time allowed for a call
+func (p *CallPolicy) Timeout() time.Duration {
+	return time.Duration(p.TimeoutMs) * time.Millisecond
+}
+
+// Backoff returns the delay before the retry following the attempt, counted from 1
+func (r *RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := DefaultRetryBackoff
+	if r.BackoffMs > 0 {
+		backoff = time.Duration(r.BackoffMs) * time.Millisecond
+	}
+	return backoff << (attempt - 1)
+}
+
+// IsEmpty reports whether the policy sets nothing
+func (p *CallPolicy) IsEmpty() bool {
+	return p.TimeoutMs == 0 && p.Retry == nil && p.RateLimit == nil && len(p.AllowedHosts) == 0
+}
+
+// Validate checks the bounds of the policy and its host names
+func (p *CallPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.TimeoutMs < 0 {
+		return fmt.Errorf("timeoutMs must not be negative")
+	}
+	if p.Retry != nil {
+		if p.Retry.MaxAttempts < 1 || p.Retry.MaxAttempts > 10 {
+			return fmt.Errorf("retry.maxAttempts must be between 1 and 10")
+		}
+		if p.Retry.BackoffMs < 0 {
+			return fmt.Errorf("retry.backoffMs must not be negative")
+		}
+	}
+	if p.RateLimit != nil {
+		if p.RateLimit.RequestsPerSecond < 0 {
+			return fmt.Errorf("rateLimit.requestsPerSecond must not be negative")
+		}
+		if p.RateLimit.Burst < 0 {
+			return fmt.Errorf("rateLimit.burst must not be negative")
+		}
+	}
+	for _, host := range p.AllowedHosts {
+		if host == "" || strings.ContainsAny(host, "/: ") || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return fmt.Errorf("allowedHosts entry '%s' must be a host name, optionally prefixed with '*.'", host)
+		}
+	}
+	return nil
+}
+
+// ValidatePolicies checks the call policies of the server and of its tools
+func (m *MCPServer) ValidatePolicies() error {
+	if err := m.Policy.Validate(); err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+	for _, tool := range m.Tools {
+		if err := tool.Policy.Validate(); err != nil {
+			return fmt.Errorf("policy of tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToolStep is a call of a chained tool to another tool of its server. The params of the call
+// are gjson paths into the document {"params": <params of the chained tool>, "steps": [<result
+// of each previous step>]}, e.g. "params.city" or "steps.0.items.0.id".
+type ToolStep struct {
+	Tool   string            `json:"tool" binding:"required"` // Name clients call the tool by
+	Params map[string]string `json:"params,omitempty"`        // Param of the step tool by gjson path, the params of the chained tool if empty
+}
+
+// IsChained reports whether the tool runs a pipeline of other tools instead of a request
+func (t *Tool) IsChained() bool {
+	return len(t.Steps) > 0
+}
+
+// ValidateChains checks that the steps of the chained tools of the server call tools of the
+// server that are not chained themselves
+func (m *MCPServer) ValidateChains() error {
+	for _, tool := range m.Tools {
+		for i, step := range tool.Steps {
+			target := m.FindTool(step.Tool)
+			if target == nil {
+				return fmt.Errorf("step %d of tool %s calls unknown tool %s", i, tool.Name, step.Tool)
+			}
+			if target.IsChained() {
+				return fmt.Errorf("step %d of tool %s calls chained tool %s", i, tool.Name, step.Tool)
+			}
+			for param, path := range step.Params {
+				if strings.TrimSpace(path) == "" {
+					return fmt.Errorf("step %d of tool %s has an empty path for param %s", i, tool.Name, param)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ChainInputSchema returns the JSON Schema of the params of a chained tool, made of the params
+// its steps read through "params." paths. Steps without params receive all the params.
+func ChainInputSchema(tool Tool) map[string]interface{} {
+	names := map[string]bool{}
+	for _, step := range tool.Steps {
+		for _, path := range step.Params {
+			if name, ok := strings.CutPrefix(path, "params."); ok {
+				name, _, _ = strings.Cut(name, ".")
+				names[name] = true
+			}
+		}
+	}
+
+	properties := make(map[string]interface{}, len(names))
+	required := make([]string, 0, len(names))
+	for name := range names {
+		properties[name] = map[string]interface{}{}
+		required = append(required, name)
+	}
+	sort.Strings(required)
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Bounds of change notes
+const (
+	MaxChangeAuthorLength  = 200
+	MaxChangeMessageLength = 2000
+)
+
+// ChangeNote describes the change that created a version of an interface or a server
+type ChangeNote struct {
+	Author  string `json:"author,omitempty"`  // Who made the change, free-form
+	Message string `json:"message,omitempty"` // What changed and why
+}
+
+// VersionInfo is an entry of the version history of an interface or a server
+type VersionInfo struct {
+	Version   int         `json:"version"`
+	UpdatedAt time.Time   `json:"updatedAt"`           // When the version was created
+	Changelog *ChangeNote `json:"changelog,omitempty"` // Note of the change creating the version
+}
+
+// IsEmpty reports whether the note has neither an author nor a message
+func (n *ChangeNote) IsEmpty() bool {
+	return n == nil || (strings.TrimSpace(n.Author) == "" && strings.TrimSpace(n.Message) == "")
+}
+
+// Normalized returns the note with trimmed fields, nil if it is empty
+func (n *ChangeNote) Normalized() *ChangeNote {
+	if n.IsEmpty() {
+		return nil
+	}
+	return &ChangeNote{Author: strings.TrimSpace(n.Author), Message: strings.TrimSpace(n.Message)}
+}
+
+// Equal reports whether the notes have the same author and message, nil notes being equal
+func (n *ChangeNote) Equal(other *ChangeNote) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	return *n == *other
+}
+
+// Validate checks the lengths of the note
+func (n *ChangeNote) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if utf8.RuneCountInString(n.Author) > MaxChangeAuthorLength {
+		return fmt.Errorf("changelog author must not exceed %d characters", MaxChangeAuthorLength)
+	}
+	if utf8.RuneCountInString(n.Message) > MaxChangeMessageLength {
+		return fmt.Errorf("changelog message must not exceed %d characters", MaxChangeMessageLength)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// Collection groups related HTTP interfaces, e.g. everything imported from one OpenAPI spec
+type Collection struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name" binding:"required"`
+	Namespace    string    `json:"namespace"` // Team owning the collection, set from the request
+	Description  string    `json:"description"`
+	InterfaceIDs []string  `json:"interfaceIds"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
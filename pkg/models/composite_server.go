@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Resolutions of tool name conflicts between the sources of a virtual server
+const (
+	ConflictError = "error" // Reject the composition
+	ConflictFirst = "first" // Keep the tool of the earlier source
+	ConflictLast  = "last"  // Keep the tool of the later source
+)
+
+// ServerSource is an MCP server whose allowed tools a virtual server includes
+type ServerSource struct {
+	ServerID string `json:"serverId" binding:"required"`
+	Prefix   string `json:"prefix,omitempty"` // Prepended to the names of its tools, e.g. "billing_"
+}
+
+// ToolConflictError reports a tool name provided by two sources of a virtual server
+type ToolConflictError struct {
+	Tool    string
+	Sources [2]string // IDs of the servers providing the tool
+}
+
+func (e *ToolConflictError) Error() string {
+	return fmt.Sprintf("tool %s is provided by servers %s and %s, set a prefix or a conflict resolution", e.Tool, e.Sources[0], e.Sources[1])
+}
+
+// ValidateSources checks the sources of a virtual server, which must be distinct
+func ValidateSources(sources []ServerSource, resolution string) error {
+	switch resolution {
+	case "", ConflictError, ConflictFirst, ConflictLast:
+	default:
+		return fmt.Errorf("invalid conflict resolution '%s'", resolution)
+	}
+	seen := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if source.ServerID == "" {
+			return fmt.Errorf("source requires a serverId")
+		}
+		if seen[source.ServerID] {
+			return fmt.Errorf("duplicate source server %s", source.ServerID)
+		}
+		seen[source.ServerID] = true
+	}
+	return nil
+}
+
+// ComposeTools returns the union of the allowed tools of the source servers, given in the order of
+// the sources. Each tool forwards to the tool of its source; name conflicts are resolved as set.
+func ComposeTools(sources []ServerSource, servers []MCPServer, resolution string) ([]Tool, error) {
+	tools := []Tool{}
+	index := map[string]int{}
+	for i, source := range sources {
+		server := servers[i]
+		for _, tool := range server.Tools {
+			if !slices.Contains(server.AllowTools, tool.Name) {
+				continue
+			}
+			// Include the tool as the clients of its source see it
+			composed := Tool{
+				Name:         source.Prefix + tool.ExposedName(),
+				Description:  tool.ExposedDescription(),
+				Source:       server.ID,
+				RemoteName:   tool.ExposedName(),
+				InputSchema:  tool.ExposedInputSchema(tool.InputSchema),
+				OutputSchema: tool.OutputSchema,
+
+				Deprecated:         tool.Deprecated,
+				Sunset:             tool.Sunset,
+				DeprecationMessage: tool.DeprecationMessage,
+			}
+
+			existing, ok := index[composed.Name]
+			if !ok {
+				index[composed.Name] = len(tools)
+				tools = append(tools, composed)
+				continue
+			}
+			switch resolution {
+			case ConflictFirst:
+			case ConflictLast:
+				tools[existing] = composed
+			default:
+				return nil, &ToolConflictError{Tool: composed.Name, Sources: [2]string{tools[existing].Source, server.ID}}
+			}
+		}
+	}
+	return tools, nil
+}
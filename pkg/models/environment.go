@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// Environment is a named set of variables substituted into tool templates as {{env:name}}
+type Environment struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name" binding:"required"` // e.g. dev, staging, prod
+	Description string            `json:"description"`
+	Variables   map[string]string `json:"variables"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
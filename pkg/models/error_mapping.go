@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/tmpl"
+)
+
+// Categories of the upstream errors returned to MCP clients
+const (
+	ErrorCategoryAuthentication = "authentication" // Credentials missing or expired
+	ErrorCategoryPermission     = "permission"     // Credentials not allowed to do the call
+	ErrorCategoryNotFound       = "not_found"      // Resource of the call does not exist
+	ErrorCategoryInvalidInput   = "invalid_input"  // Params rejected by the upstream
+	ErrorCategoryConflict       = "conflict"       // Call conflicting with the state of the resource
+	ErrorCategoryRateLimited    = "rate_limited"   // Too many calls, retry later
+	ErrorCategoryUnavailable    = "unavailable"    // Upstream down or overloaded, retry later
+	ErrorCategoryUpstream       = "upstream"       // Any other failure of the upstream
+)
+
+// errorCategories are the valid categories of error mappings
+var errorCategories = map[string]bool{
+	ErrorCategoryAuthentication: true,
+	ErrorCategoryPermission:     true,
+	ErrorCategoryNotFound:       true,
+	ErrorCategoryInvalidInput:   true,
+	ErrorCategoryConflict:       true,
+	ErrorCategoryRateLimited:    true,
+	ErrorCategoryUnavailable:    true,
+	ErrorCategoryUpstream:       true,
+}
+
+// statusPattern matches the statuses of error mappings: a code such as 401 or a class such as 4xx
+var statusPattern = regexp.MustCompile(`^[1-5](\d\d|xx)$`)
+
+// ErrorMapping turns an unsuccessful upstream status into an error the model can act on, instead
+// of the raw response body. The message may use template actions on .status and .body, the
+// decoded response body.
+type ErrorMapping struct {
+	Status      string `json:"status"`                // Status code such as 401, or class such as 4xx
+	Category    string `json:"category"`              // Category of the error, upstream if not set
+	Message     string `json:"message"`               // Message returned to the client, e.g. "credentials expired, ask the user to re-authenticate"
+	IncludeBody bool   `json:"includeBody,omitempty"` // Append the response body to the message
+}
+
+// Matches reports whether the mapping applies to a status code
+func (e *ErrorMapping) Matches(status int) bool {
+	code := strconv.Itoa(status)
+	if e.Status == code {
+		return true
+	}
+	return len(e.Status) == 3 && e.Status[1:] == "xx" && e.Status[0] == code[0]
+}
+
+// Validate checks the status, the category and the message template of the mapping
+func (e *ErrorMapping) Validate() error {
+	if !statusPattern.MatchString(e.Status) {
+		return fmt.Errorf("invalid status '%s', must be a code such as 401 or a class such as 4xx", e.Status)
+	}
+	if e.Status[0] == '2' {
+		return fmt.Errorf("status %s is successful, only unsuccessful statuses can be mapped", e.Status)
+	}
+	if e.Category != "" && !errorCategories[e.Category] {
+		return fmt.Errorf("invalid category '%s' of status %s", e.Category, e.Status)
+	}
+	if e.Message == "" {
+		return fmt.Errorf("status %s has no message", e.Status)
+	}
+	if err := tmpl.Validate(e.Message); err != nil {
+		return fmt.Errorf("message of status %s: %w", e.Status, err)
+	}
+	return nil
+}
+
+// ErrorMappingFor returns the error mapping of a status of the tool: the one of its code, else
+// the one of its class, nil if neither is mapped
+func (t *Tool) ErrorMappingFor(status int) *ErrorMapping {
+	var class *ErrorMapping
+	for i := range t.ErrorMappings {
+		mapping := &t.ErrorMappings[i]
+		if !mapping.Matches(status) {
+			continue
+		}
+		if mapping.Status == strconv.Itoa(status) {
+			return mapping
+		}
+		if class == nil {
+			class = mapping
+		}
+	}
+	return class
+}
+
+// ValidateErrorMappings checks the error mappings of the tools of the server
+func (m *MCPServer) ValidateErrorMappings() error {
+	for _, tool := range m.Tools {
+		seen := map[string]bool{}
+		for i := range tool.ErrorMappings {
+			mapping := &tool.ErrorMappings[i]
+			if err := mapping.Validate(); err != nil {
+				return fmt.Errorf("error mapping of tool %s: %w", tool.Name, err)
+			}
+			if seen[mapping.Status] {
+				return fmt.Errorf("error mapping of tool %s: status %s is mapped twice", tool.Name, mapping.Status)
+			}
+			seen[mapping.Status] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+)
+
+// Lifecycle event types of HTTP interfaces, MCP servers, their revisions and secrets
+const (
+	EventHTTPInterfaceCreated    = "http_interface.created"
+	EventHTTPInterfaceUpdated    = "http_interface.updated"
+	EventHTTPInterfaceDeleted    = "http_interface.deleted"
+	EventHTTPInterfaceArchived   = "http_interface.archived"
+	EventHTTPInterfaceUnarchived = "http_interface.unarchived"
+	EventMCPServerCreated        = "mcp_server.created"
+	EventMCPServerUpdated        = "mcp_server.updated"
+	EventMCPServerDeleted        = "mcp_server.deleted"
+	EventMCPServerActivated      = "mcp_server.activated"
+	EventMCPServerDeactivated    = "mcp_server.deactivated"
+	EventMCPServerArchived       = "mcp_server.archived"
+	EventMCPServerUnarchived     = "mcp_server.unarchived"
+	EventRevisionSubmitted       = "mcp_server.revision_submitted"
+	EventRevisionApproved        = "mcp_server.revision_approved"
+	EventRevisionRejected        = "mcp_server.revision_rejected"
+	EventSecretRotated           = "secret.rotated"
+	EventSecretRotationFailed    = "secret.rotation_failed"
+)
+
+// EventToolInvoked summarizes a tool invocation. It is only sent to the event streams, as
+// webhooks could not keep up with the invocations.
+const EventToolInvoked = "tool.invoked"
+
+// EventTypes lists every lifecycle event type
+var EventTypes = []string{
+	EventHTTPInterfaceCreated,
+	EventHTTPInterfaceUpdated,
+	EventHTTPInterfaceDeleted,
+	EventHTTPInterfaceArchived,
+	EventHTTPInterfaceUnarchived,
+	EventMCPServerCreated,
+	EventMCPServerUpdated,
+	EventMCPServerDeleted,
+	EventMCPServerActivated,
+	EventMCPServerDeactivated,
+	EventMCPServerArchived,
+	EventMCPServerUnarchived,
+	EventRevisionSubmitted,
+	EventRevisionApproved,
+	EventRevisionRejected,
+	EventSecretRotated,
+	EventSecretRotationFailed,
+}
+
+// EventWebhook represents a webhook notified of lifecycle events of gateway entities
+type EventWebhook struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" binding:"required"`
+	URL       string    `json:"url" binding:"required"`
+	Secret    string    `json:"secret,omitempty"` // HMAC-SHA256 signing key, never returned by the API
+	Events    []string  `json:"events"`           // Subscribed event types, all events if empty
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Subscribed reports whether the webhook receives events of the given type
+func (w EventWebhook) Subscribed(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the payload sent to event webhooks and event streams
+type Event struct {
+	ID         string      `json:"id"`   // Unique per event, identical across the retries of a delivery
+	Type       string      `json:"type"` // One of EventTypes, or EventToolInvoked
+	Namespace  string      `json:"namespace"`
+	EntityID   string      `json:"entityId"`
+	EntityName string      `json:"entityName,omitempty"`
+	RequestID  string      `json:"requestId,omitempty"` // Request that caused the change
+	Data       interface{} `json:"data,omitempty"`      // Entity after the change, absent for deletions
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// InvocationSummary is the data of EventToolInvoked events, without the params and the result
+type InvocationSummary struct {
+	Tool       string `json:"tool"`
+	Caller     string `json:"caller,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"` // Upstream status, 0 if no upstream response was received
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
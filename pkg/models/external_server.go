@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Transports of external MCP servers
+const (
+	TransportStreamableHTTP = "streamable-http"
+	TransportSSE            = "sse" // HTTP+SSE transport of the 2024-11-05 protocol revision
+	TransportStdio          = "stdio"
+)
+
+// ExternalServer is an MCP server outside the gateway whose tools an MCP server proxies
+// alongside the tools generated from HTTP interfaces
+type ExternalServer struct {
+	Name      string            `json:"name" binding:"required"`
+	Transport string            `json:"transport" binding:"required,oneof=streamable-http sse stdio"`
+	URL       string            `json:"url,omitempty"`     // Endpoint of the streamable-http and sse transports
+	Headers   map[string]string `json:"headers,omitempty"` // Sent with every HTTP request
+	Auth      *Auth             `json:"auth,omitempty"`    // Authentication of the HTTP requests
+	Command   string            `json:"command,omitempty"` // Program started by the stdio transport
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`    // Added to the environment of the program
+	Prefix    string            `json:"prefix,omitempty"` // Prepended to the names of its tools, e.g. "github_"
+}
+
+// Validate checks that the external server has the fields its transport requires
+func (e *ExternalServer) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("external server requires a name")
+	}
+	switch e.Transport {
+	case TransportStreamableHTTP, TransportSSE:
+		parsed, err := url.Parse(e.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("external server %s requires an http or https url", e.Name)
+		}
+		if e.Auth != nil {
+			if err := e.Auth.Validate(); err != nil {
+				return fmt.Errorf("external server %s: %w", e.Name, err)
+			}
+		}
+	case TransportStdio:
+		if e.Command == "" {
+			return fmt.Errorf("external server %s requires a command", e.Name)
+		}
+	default:
+		return fmt.Errorf("invalid transport '%s' of external server %s", e.Transport, e.Name)
+	}
+	return nil
+}
+
+// ValidateExternalServers checks the external servers of an MCP server, whose names must be unique
+func ValidateExternalServers(servers []ExternalServer) error {
+	names := make(map[string]bool, len(servers))
+	for i := range servers {
+		if err := servers[i].Validate(); err != nil {
+			return err
+		}
+		if names[servers[i].Name] {
+			return fmt.Errorf("duplicate external server %s", servers[i].Name)
+		}
+		names[servers[i].Name] = true
+	}
+	return nil
+}
+
+// NewToolFromExternal creates the tool proxying a tool of an external server
+func NewToolFromExternal(external ExternalServer, name string, description string, inputSchema map[string]interface{}, outputSchema map[string]interface{}) Tool {
+	return Tool{
+		Name:         external.Prefix + name,
+		Description:  description,
+		External:     external.Name,
+		RemoteName:   name,
+		InputSchema:  inputSchema,
+		OutputSchema: outputSchema,
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// HeaderPolicy sets the headers of the upstream requests of the tools of a server: default
+// headers sent by every tool, and the headers clients may pass through with their calls
+type HeaderPolicy struct {
+	Defaults map[string]string `json:"defaults,omitempty"` // Sent by every tool, the headers of the tool template take precedence
+	Allow    []string          `json:"allow,omitempty"`    // Client headers forwarded upstream, "X-Trace-*" matches a prefix, all if empty
+	Reject   bool              `json:"reject,omitempty"`   // Fail the calls passing other headers instead of dropping them
+}
+
+// Allows reports whether a header passed by a client may be forwarded upstream
+func (p *HeaderPolicy) Allows(name string) bool {
+	if p == nil || len(p.Allow) == 0 {
+		return true
+	}
+	name = http.CanonicalHeaderKey(name)
+	for _, allowed := range p.Allow {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(name, http.CanonicalHeaderKey(prefix)) {
+				return true
+			}
+		} else if name == http.CanonicalHeaderKey(allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the names and values of the default headers and the entries of the allowlist
+func (p *HeaderPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for name, value := range p.Defaults {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("default header '%s' is not a valid header name", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("default header %s has an invalid value", name)
+		}
+	}
+	for _, allowed := range p.Allow {
+		name := strings.TrimSuffix(allowed, "*")
+		if name == "" || !httpguts.ValidHeaderFieldName(name) || strings.Contains(name, "*") {
+			return fmt.Errorf("allowed header '%s' must be a header name, optionally ending with '*'", allowed)
+		}
+	}
+	return nil
+}
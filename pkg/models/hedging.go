@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Defaults of hedged requests
+const (
+	DefaultHedgingPercentile = 99
+	DefaultHedgingDelay      = 100 * time.Millisecond
+)
+
+// Hedging cuts the tail latency of an idempotent GET tool against a slow upstream: when the first
+// request is slower than the given percentile of the recent latencies of the tool, a second one is
+// sent, the first response wins and the other request is canceled.
+type Hedging struct {
+	Enabled        bool    `json:"enabled"`
+	Percentile     float64 `json:"percentile,omitempty"`     // Latency percentile after which the second request is sent, 99 by default
+	InitialDelayMs int     `json:"initialDelayMs,omitempty"` // Delay used until enough latencies were observed, 100 by default
+}
+
+// IsEnabled reports whether hedging is enabled
+func (h *Hedging) IsEnabled() bool {
+	return h != nil && h.Enabled
+}
+
+// PercentileOrDefault returns the latency percentile after which the second request is sent
+func (h *Hedging) PercentileOrDefault() float64 {
+	if h.Percentile <= 0 {
+		return DefaultHedgingPercentile
+	}
+	return h.Percentile
+}
+
+// InitialDelay returns the delay used until enough latencies were observed
+func (h *Hedging) InitialDelay() time.Duration {
+	if h.InitialDelayMs <= 0 {
+		return DefaultHedgingDelay
+	}
+	return time.Duration(h.InitialDelayMs) * time.Millisecond
+}
+
+// ValidateHedging checks that only idempotent GET tools calling an HTTP upstream are hedged
+func (m *MCPServer) ValidateHedging() error {
+	for _, tool := range m.Tools {
+		if !tool.Hedging.IsEnabled() {
+			continue
+		}
+		if tool.External != "" || tool.Source != "" || tool.IsChained() || tool.IsWebSocket() {
+			return fmt.Errorf("hedging of tool %s: only tools calling an HTTP interface can be hedged", tool.Name)
+		}
+		if !strings.EqualFold(tool.RequestTemplate.Method, "GET") {
+			return fmt.Errorf("hedging of tool %s: only idempotent GET tools can be hedged", tool.Name)
+		}
+		if tool.Hedging.Percentile < 0 || tool.Hedging.Percentile >= 100 {
+			return fmt.Errorf("hedging of tool %s: percentile must be between 0 and 100", tool.Name)
+		}
+		if tool.Hedging.InitialDelayMs < 0 {
+			return fmt.Errorf("hedging of tool %s: initialDelayMs must not be negative", tool.Name)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 type HTTPInterface struct {
 	ID          string     `json:"id"`
 	Name        string     `json:"name" binding:"required"`
+	Namespace   string     `json:"namespace"` // Team owning the interface, set from the request
 	Description string     `json:"description"`
 	Method      string     `json:"method" binding:"required,oneof=GET POST PUT DELETE PATCH"`
 	Path        string     `json:"path" binding:"required"`
@@ -19,9 +21,16 @@ type HTTPInterface struct {
 	Parameters  []Param    `json:"parameters"`
 	RequestBody *Body      `json:"requestBody,omitempty"`
 	Responses   []Response `json:"responses"`
-	Version     int        `json:"version"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
+	Auth        *Auth      `json:"auth,omitempty"`       // Authentication applied to the requests of its tools
+	Archived    bool       `json:"archived,omitempty"`   // Retired, hidden from listings and not called, see the archive endpoint
+	Deprecated  bool       `json:"deprecated,omitempty"` // Still called, but its tools warn callers to migrate
+	Sunset      *time.Time `json:"sunset,omitempty"`     // Planned removal of a deprecated interface
+	// Migration hint of a deprecated interface, e.g. its replacement, shown in the warnings of its tools
+	DeprecationMessage string      `json:"deprecationMessage,omitempty"`
+	Version            int         `json:"version"`
+	Changelog          *ChangeNote `json:"changelog,omitempty"` // Note of the change creating the version
+	CreatedAt          time.Time   `json:"createdAt"`
+	UpdatedAt          time.Time   `json:"updatedAt"`
 }
 
 // Header represents an HTTP header
@@ -33,14 +42,18 @@ type Header struct {
 	DefaultValue string `json:"defaultValue,omitempty"`
 }
 
-// Param represents a request parameter (query or path)
+// Param represents a request parameter (query, path, header or cookie)
 type Param struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
-	In          string `json:"in" binding:"required,oneof=query path header"`
+	In          string `json:"in" binding:"required,oneof=query path header cookie"`
 	Required    bool   `json:"required"`
 	Type        string `json:"type" binding:"required,oneof=string integer number boolean array object"`
 	Schema      string `json:"schema,omitempty"`
+	// Serialization of an array param as in OpenAPI: form (default), spaceDelimited or
+	// pipeDelimited for query params, simple for path params
+	Style   string `json:"style,omitempty"`
+	Explode *bool  `json:"explode,omitempty"` // Repeat the key of each item of a form param, true by default
 }
 
 // Body represents a request or response body
@@ -57,6 +70,14 @@ type Response struct {
 	Body        *Body  `json:"body,omitempty"`
 }
 
+// RequestContentType returns the content type of the request body, empty without a body
+func (h *HTTPInterface) RequestContentType() string {
+	if h.RequestBody == nil {
+		return ""
+	}
+	return h.RequestBody.ContentType
+}
+
 // ConvertToOpenAPI converts the HTTP interface to OpenAPI format
 func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 	// Create basic OpenAPI structure
@@ -73,6 +94,7 @@ func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 	// Add path
 	pathData := map[string]interface{}{}
 	method := strings.ToLower(h.Method)
+	schemas := []map[string]interface{}{} // Schemas of the params and bodies, see below
 
 	// Build operation object
 	operation := map[string]interface{}{
@@ -80,19 +102,36 @@ func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 		"description": h.Description,
 		"operationId": h.Name,
 	}
+	if h.Deprecated {
+		operation["deprecated"] = true
+	}
 
 	// Add parameters
 	if len(h.Parameters) > 0 {
 		parameters := []map[string]interface{}{}
 		for _, param := range h.Parameters {
+			schema := map[string]interface{}{}
+			if param.Schema != "" {
+				if err := json.Unmarshal([]byte(param.Schema), &schema); err != nil || schema == nil {
+					schema = map[string]interface{}{}
+				}
+			}
+			if _, ok := schema["type"]; !ok {
+				schema["type"] = param.Type
+			}
+			schemas = append(schemas, schema)
 			paramObj := map[string]interface{}{
 				"name":        param.Name,
 				"in":          param.In,
 				"description": param.Description,
 				"required":    param.Required,
-				"schema": map[string]interface{}{
-					"type": param.Type,
-				},
+				"schema":      schema,
+			}
+			if param.Style != "" {
+				paramObj["style"] = param.Style
+			}
+			if param.Explode != nil {
+				paramObj["explode"] = *param.Explode
 			}
 			parameters = append(parameters, paramObj)
 		}
@@ -157,9 +196,10 @@ func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 	// Add request body
 	if h.RequestBody != nil {
 		var schema map[string]interface{}
-		if err := json.Unmarshal([]byte(h.RequestBody.Schema), &schema); err != nil {
+		if err := json.Unmarshal([]byte(h.RequestBody.Schema), &schema); err != nil || schema == nil {
 			schema = map[string]interface{}{"type": "object"}
 		}
+		schemas = append(schemas, schema)
 
 		var example interface{}
 		if h.RequestBody.Example != "" {
@@ -196,9 +236,10 @@ func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 		// Add response body if present
 		if response.Body != nil {
 			var schema map[string]interface{}
-			if err := json.Unmarshal([]byte(response.Body.Schema), &schema); err != nil {
+			if err := json.Unmarshal([]byte(response.Body.Schema), &schema); err != nil || schema == nil {
 				schema = map[string]interface{}{"type": "object"}
 			}
+			schemas = append(schemas, schema)
 
 			var example interface{}
 			if response.Body.Example != "" {
@@ -230,30 +271,58 @@ func (h *HTTPInterface) ConvertToOpenAPI() map[string]interface{} {
 	paths := openAPI["paths"].(map[string]interface{})
 	paths[h.Path] = pathData
 
+	// Move the definitions bundled in the schemas to the components, and declare OpenAPI 3.1 if
+	// the schemas use JSON Schema 2020-12 beyond OpenAPI 3.0
+	components := map[string]interface{}{}
+	for _, schema := range schemas {
+		exportDefs(schema, components)
+	}
+	if len(components) > 0 {
+		openAPI["components"] = map[string]interface{}{"schemas": components}
+	}
+	openAPI31 := false
+	for _, schema := range schemas {
+		openAPI31 = openAPI31 || requiresOpenAPI31(schema)
+	}
+	for _, def := range components {
+		openAPI31 = openAPI31 || requiresOpenAPI31(def)
+	}
+	if openAPI31 {
+		openAPI["openapi"] = "3.1.0"
+	}
+
 	return openAPI
 }
 
-// CreateFromOpenAPI creates a new HTTP interface from OpenAPI specification
-func CreateFromOpenAPI(name string, description string, openAPI map[string]interface{}) ([]HTTPInterface, error) {
+// CreateFromOpenAPI creates a new HTTP interface from OpenAPI specification. OpenAPI 3.0 and 3.1
+// documents are supported; the schemas are stored as JSON Schema with the component schemas they
+// reference bundled under $defs, and the webhooks of 3.1 documents, which the API calls, are not
+// imported. Only the operations selected by filter are imported, all of them if it is nil.
+func CreateFromOpenAPI(name string, description string, openAPI map[string]interface{}, filter *OpenAPIFilter) ([]HTTPInterface, error) {
 	var interfaces []HTTPInterface
+	spec := newOpenAPISpec(openAPI)
 
-	// Extract paths from OpenAPI
+	// Extract paths from OpenAPI, which 3.1 documents describing only webhooks lack
 	paths, ok := openAPI["paths"].(map[string]interface{})
 	if !ok {
+		if webhooks, ok := openAPI["webhooks"].(map[string]interface{}); ok && len(webhooks) > 0 {
+			return nil, fmt.Errorf("invalid OpenAPI format: no paths found, webhooks are not imported")
+		}
 		return nil, fmt.Errorf("invalid OpenAPI format: no paths found")
 	}
 
-	// Process each path
-	for path, pathItemValue := range paths {
-		pathItem, ok := pathItemValue.(map[string]interface{})
+	// Process each path, in order so that the first of operations sharing an operationId keeps it
+	names := map[string]bool{}
+	for _, path := range sortedKeys(paths) {
+		pathItem, ok := paths[path].(map[string]interface{})
 		if !ok {
 			continue
 		}
 
 		// Process each HTTP method
-		for method, operationValue := range pathItem {
-			operation, ok := operationValue.(map[string]interface{})
-			if !ok {
+		for _, method := range sortedKeys(pathItem) {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok || !filter.Matches(method, path, operationTags(operation)) {
 				continue
 			}
 
@@ -277,10 +346,13 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 				Responses:   []Response{},
 			}
 
-			// Extract operation ID if present
-			if opID, ok := operation["operationId"].(string); ok && opID != "" {
+			// Extract operation ID if present, the others sharing it keep the name of their path
+			if opID, ok := operation["operationId"].(string); ok && opID != "" && !names[opID] {
 				httpInterface.Name = opID
 			}
+			names[httpInterface.Name] = true
+
+			httpInterface.Deprecated, _ = operation["deprecated"].(bool)
 
 			// Extract summary or description if present
 			if summary, ok := operation["summary"].(string); ok && summary != "" {
@@ -292,7 +364,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 			// Extract parameters
 			if parameters, ok := operation["parameters"].([]interface{}); ok {
 				for _, paramValue := range parameters {
-					param, ok := paramValue.(map[string]interface{})
+					param, ok := spec.resolve(paramValue)
 					if !ok {
 						continue
 					}
@@ -312,10 +384,8 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 						}
 
 						// Extract type from schema if present
-						if schema, ok := param["schema"].(map[string]interface{}); ok {
-							if paramType, ok := schema["type"].(string); ok {
-								header.Type = paramType
-							}
+						if schema := spec.schema(param["schema"]); schema != nil {
+							header.Type = schemaType(schema, header.Type)
 
 							// Extract default value if present
 							if defaultValue, ok := schema["default"]; ok {
@@ -347,12 +417,17 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 							Type:        "string",
 						}
 
-						// Extract type from schema if present
-						if schema, ok := param["schema"].(map[string]interface{}); ok {
-							if paramType, ok := schema["type"].(string); ok {
-								parameter.Type = paramType
+						// Extract type from schema if present, keeping the schema for its enum and format
+						if schema := spec.schema(param["schema"]); schema != nil {
+							parameter.Type = schemaType(schema, parameter.Type)
+							if schemaJSON, err := json.Marshal(schema); err == nil {
+								parameter.Schema = string(schemaJSON)
 							}
 						}
+						parameter.Style, _ = param["style"].(string)
+						if explode, ok := param["explode"].(bool); ok {
+							parameter.Explode = &explode
+						}
 
 						httpInterface.Parameters = append(httpInterface.Parameters, parameter)
 					}
@@ -360,7 +435,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 			}
 
 			// Extract request body
-			if requestBodyValue, ok := operation["requestBody"].(map[string]interface{}); ok {
+			if requestBodyValue, ok := spec.resolve(operation["requestBody"]); ok {
 				if content, ok := requestBodyValue["content"].(map[string]interface{}); ok {
 					for contentType, contentValue := range content {
 						contentObj, ok := contentValue.(map[string]interface{})
@@ -375,7 +450,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 						}
 
 						// Extract schema
-						if schema, ok := contentObj["schema"].(map[string]interface{}); ok {
+						if schema := spec.schema(contentObj["schema"]); schema != nil {
 							schemaJSON, err := json.Marshal(schema)
 							if err == nil {
 								body.Schema = string(schemaJSON)
@@ -383,7 +458,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 						}
 
 						// Extract example
-						if example, ok := contentObj["example"]; ok {
+						if example, ok := spec.mediaExample(contentObj); ok {
 							exampleJSON, err := json.Marshal(example)
 							if err == nil {
 								body.Example = string(exampleJSON)
@@ -399,7 +474,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 			// Extract responses
 			if responsesValue, ok := operation["responses"].(map[string]interface{}); ok {
 				for statusCode, responseValue := range responsesValue {
-					responseObj, ok := responseValue.(map[string]interface{})
+					responseObj, ok := spec.resolve(responseValue)
 					if !ok {
 						continue
 					}
@@ -431,7 +506,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 							}
 
 							// Extract schema
-							if schema, ok := contentObj["schema"].(map[string]interface{}); ok {
+							if schema := spec.schema(contentObj["schema"]); schema != nil {
 								schemaJSON, err := json.Marshal(schema)
 								if err == nil {
 									body.Schema = string(schemaJSON)
@@ -439,7 +514,7 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 							}
 
 							// Extract example
-							if example, ok := contentObj["example"]; ok {
+							if example, ok := spec.mediaExample(contentObj); ok {
 								exampleJSON, err := json.Marshal(example)
 								if err == nil {
 									body.Example = string(exampleJSON)
@@ -460,12 +535,25 @@ func CreateFromOpenAPI(name string, description string, openAPI map[string]inter
 	}
 
 	if len(interfaces) == 0 {
+		if !filter.IsEmpty() {
+			return nil, fmt.Errorf("no operation of the OpenAPI spec matches the filter")
+		}
 		return nil, fmt.Errorf("no valid HTTP interfaces found in OpenAPI spec")
 	}
 
 	return interfaces, nil
 }
 
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Helper function to sanitize a path for use in an interface name
 func sanitizePath(path string) string {
 	// Replace slashes with hyphens and remove query parameters
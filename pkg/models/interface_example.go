@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+// ExampleArguments returns sample arguments of the tool generated from the interface, as its
+// input schema lays them out: query and path params at the top, headers and cookies in their
+// objects and the request body in body. Values are the ExampleValue of their schema.
+func (h *HTTPInterface) ExampleArguments() map[string]interface{} {
+	args := map[string]interface{}{}
+	headers := map[string]interface{}{}
+	cookies := map[string]interface{}{}
+	for _, header := range h.Headers {
+		if header.DefaultValue != "" {
+			headers[header.Name] = typedValue(header.Type, header.DefaultValue)
+		} else {
+			headers[header.Name] = ExampleValue(map[string]interface{}{"type": header.Type})
+		}
+	}
+	for _, param := range h.Parameters {
+		value := param.ExampleValue()
+		switch param.In {
+		case "header":
+			headers[param.Name] = value
+		case "cookie":
+			cookies[param.Name] = value
+		default:
+			args[param.Name] = value
+		}
+	}
+	if len(headers) > 0 {
+		args["headers"] = headers
+	}
+	if len(cookies) > 0 {
+		args["cookies"] = cookies
+	}
+	if body, ok := h.ExampleBody(); ok {
+		args["body"] = body
+	}
+	return args
+}
+
+// ExampleBody returns a sample request body: the example of the body, decoded if it is JSON,
+// or one generated from its schema. It returns false if the interface takes no body.
+func (h *HTTPInterface) ExampleBody() (interface{}, bool) {
+	if h.RequestBody == nil {
+		return nil, false
+	}
+	if h.RequestBody.Example != "" {
+		var example interface{}
+		if err := json.Unmarshal([]byte(h.RequestBody.Example), &example); err == nil {
+			return example, true
+		}
+		return h.RequestBody.Example, true
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(h.RequestBody.Schema), &schema); err != nil || schema == nil {
+		schema = map[string]interface{}{"type": "object"}
+	}
+	return ExampleValue(schema), true
+}
+
+// ExampleValue returns a sample value of the parameter
+func (p Param) ExampleValue() interface{} {
+	return ExampleValue(p.jsonSchema())
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Invocation represents a recorded tool invocation
+type Invocation struct {
+	ID         string    `json:"id"`
+	ServerID   string    `json:"serverId"`
+	ServerName string    `json:"serverName"`
+	Tool       string    `json:"tool"`
+	Caller     string    `json:"caller"`     // Identity of the client that invoked the tool
+	RequestID  string    `json:"requestId"`  // X-Request-ID of the gateway request
+	StatusCode int       `json:"statusCode"` // Upstream status code, 0 if no response was received
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Request    string    `json:"request"`  // Tool parameters, truncated
+	Response   string    `json:"response"` // Tool result, truncated
+	CreatedAt  time.Time `json:"createdAt"`
+	// HTTP exchange of the call with its upstream, absent for the tools that send no HTTP request
+	Upstream *UpstreamExchange `json:"upstream,omitempty"`
+}
+
+// UpstreamExchange is the last upstream request of a tool invocation and the response it got.
+// Credentials are redacted and the bodies truncated like the tool params and result.
+type UpstreamExchange struct {
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     string            `json:"requestBody,omitempty"`
+	Status          int               `json:"status"`             // Status of the response before the response plugins, 0 without a response
+	Protocol        string            `json:"protocol,omitempty"` // Protocol of the response, e.g. HTTP/1.1, empty without a response
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody,omitempty"` // Before the response plugins, with the redaction rules applied
+	LatencyMs       int64             `json:"latencyMs"`              // Time to the upstream response
+}
+
+// UsageStats aggregates the invocations of a server, a tool or the whole gateway
+type UsageStats struct {
+	ServerID     string  `json:"serverId,omitempty"`
+	ServerName   string  `json:"serverName,omitempty"`
+	Tool         string  `json:"tool,omitempty"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	ErrorRate    float64 `json:"errorRate"` // Errors / calls, between 0 and 1
+	LatencyP50Ms float64 `json:"latencyP50Ms"`
+	LatencyP90Ms float64 `json:"latencyP90Ms"`
+	LatencyP99Ms float64 `json:"latencyP99Ms"`
+}
@@ -0,0 +1,411 @@
+package models
+
+import (
+	"strings"
+)
+
+// Prefixes of the references to the schemas of an OpenAPI document and to the definitions of a
+// JSON Schema
+const (
+	componentSchemaRef = "#/components/schemas/"
+	defsRef            = "#/$defs/"
+)
+
+// maxRefDepth bounds the references followed to resolve a component
+const maxRefDepth = 10
+
+// schemaValueKeywords hold values rather than schemas, a $ref in them is data
+var schemaValueKeywords = map[string]bool{"enum": true, "const": true, "default": true, "example": true, "examples": true}
+
+// annotationKeywords describe a value without constraining it
+var annotationKeywords = map[string]bool{"title": true, "description": true, "default": true, "example": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true}
+
+// openAPISpec resolves the references of an OpenAPI 3.0 or 3.1 document being imported
+type openAPISpec struct {
+	document map[string]interface{}
+	version  string // Value of the openapi field, e.g. 3.1.0
+}
+
+// newOpenAPISpec returns the resolver of an OpenAPI document
+func newOpenAPISpec(document map[string]interface{}) *openAPISpec {
+	version, _ := document["openapi"].(string)
+	return &openAPISpec{document: document, version: version}
+}
+
+// is31 reports whether the document is OpenAPI 3.1, whose schemas are JSON Schema 2020-12
+func (s *openAPISpec) is31() bool {
+	return strings.HasPrefix(s.version, "3.1")
+}
+
+// pointer returns the value of the document at a local reference such as
+// #/components/parameters/id
+func (s *openAPISpec) pointer(ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var value interface{} = s.document
+	for _, token := range strings.Split(ref[2:], "/") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if value, ok = object[token]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// resolve follows the $ref of a parameter, request body, response or example to the component
+// it names. It returns false if the value is not an object or its reference cannot be resolved.
+func (s *openAPISpec) resolve(value interface{}) (map[string]interface{}, bool) {
+	for depth := 0; depth < maxRefDepth; depth++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, true
+		}
+		if value, ok = s.pointer(ref); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// mediaExample returns the example of a media type: its example, else the value of the first of
+// its named examples
+func (s *openAPISpec) mediaExample(media map[string]interface{}) (interface{}, bool) {
+	if example, ok := media["example"]; ok {
+		return example, true
+	}
+	examples, _ := media["examples"].(map[string]interface{})
+	for _, name := range sortedKeys(examples) {
+		if example, ok := s.resolve(examples[name]); ok {
+			if value, ok := example["value"]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// schema returns a schema of the document as a standalone JSON Schema: the component schemas it
+// references are bundled under $defs and referenced there, and the boolean schemas of OpenAPI 3.1
+// become objects. The keywords are kept as they are, so 3.1 schemas keep their type arrays,
+// const, examples or prefixItems and 3.0 schemas their nullable. It returns nil if value is not
+// a schema.
+func (s *openAPISpec) schema(value interface{}) map[string]interface{} {
+	defs := map[string]interface{}{}
+	bundled := s.bundle(value, defs, 0)
+
+	var schema map[string]interface{}
+	switch v := bundled.(type) {
+	case map[string]interface{}:
+		schema = v
+	case bool:
+		schema = booleanSchema(v)
+	default:
+		return nil
+	}
+	if len(defs) > 0 {
+		existing, _ := schema["$defs"].(map[string]interface{})
+		for name, def := range existing {
+			defs[name] = def
+		}
+		schema["$defs"] = defs
+	}
+	return schema
+}
+
+// bundle copies a schema, replacing the references to component schemas with references to
+// defs and adding the schemas they name to defs. Other local references are inlined, up to
+// maxRefDepth levels.
+func (s *openAPISpec) bundle(value interface{}, defs map[string]interface{}, depth int) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if schemaValueKeywords[key] {
+				copied[key] = item
+			} else {
+				copied[key] = s.bundle(item, defs, depth)
+			}
+		}
+		ref, ok := v["$ref"].(string)
+		if !ok {
+			return copied
+		}
+		if name := strings.TrimPrefix(ref, componentSchemaRef); name != ref && !strings.Contains(name, "/") {
+			copied["$ref"] = defsRef + name
+			if _, done := defs[name]; !done {
+				// Mark the definition first, so that recursive schemas stop here
+				defs[name] = true
+				if target, ok := s.pointer(ref); ok {
+					defs[name] = s.bundle(target, defs, depth)
+				}
+			}
+			return copied
+		}
+		if target, ok := s.pointer(ref); ok && depth < maxRefDepth {
+			inlined, ok := s.bundle(target, defs, depth+1).(map[string]interface{})
+			if !ok {
+				return copied
+			}
+			// OpenAPI 3.1 applies the keywords next to a $ref as well
+			delete(copied, "$ref")
+			if len(copied) == 0 || !s.is31() {
+				return inlined
+			}
+			return map[string]interface{}{"allOf": []interface{}{inlined, copied}}
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = s.bundle(item, defs, depth)
+		}
+		return copied
+	}
+	return value
+}
+
+// booleanSchema returns the object form of a JSON Schema boolean schema: true accepts any value,
+// false none
+func booleanSchema(accept bool) map[string]interface{} {
+	if accept {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{"not": map[string]interface{}{}}
+}
+
+// schemaType returns the type of a JSON Schema, the first non-null type of a type array, or
+// fallback if it has none
+func schemaType(schema map[string]interface{}, fallback string) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return fallback
+}
+
+// hoistDefs moves the $defs of a subschema into defs, so that its references resolve from the
+// root of the schema including it
+func hoistDefs(schema map[string]interface{}, defs map[string]interface{}) {
+	nested, ok := schema["$defs"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(schema, "$defs")
+	for name, def := range nested {
+		if _, ok := defs[name]; !ok {
+			defs[name] = def
+		}
+	}
+}
+
+// validationSchema rewrites a JSON Schema 2020-12 into the OpenAPI 3.0 schema kin-openapi
+// validates: references to $defs are inlined, recursive ones accepting any value, const becomes a
+// single-value enum, numeric exclusive bounds become flagged bounds and boolean subschemas
+// objects. Keywords OpenAPI 3.0 lacks, such as prefixItems or if/then/else, are not checked.
+func validationSchema(schema map[string]interface{}) map[string]interface{} {
+	defs, _ := schema["$defs"].(map[string]interface{})
+	converted, _ := downgradeSchema(schema, defs, map[string]bool{}).(map[string]interface{})
+	return converted
+}
+
+// downgradeSchema converts a subschema for validationSchema, expanding being the definitions
+// inlined above it
+func downgradeSchema(value interface{}, defs map[string]interface{}, expanding map[string]bool) interface{} {
+	if accept, ok := value.(bool); ok {
+		if accept {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"not": rejectNothing()}
+	}
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	converted := map[string]interface{}{}
+	for key, item := range schema {
+		switch key {
+		case "$ref", "$defs", "$schema", "$id", "$comment", "examples", "prefixItems":
+		case "const":
+			converted["enum"] = []interface{}{item}
+		case "properties", "patternProperties":
+			if properties, ok := item.(map[string]interface{}); ok {
+				downgraded := make(map[string]interface{}, len(properties))
+				for name, property := range properties {
+					downgraded[name] = downgradeSchema(property, defs, expanding)
+				}
+				converted[key] = downgraded
+			}
+		case "allOf", "anyOf", "oneOf":
+			if items, ok := item.([]interface{}); ok {
+				downgraded := make([]interface{}, len(items))
+				for i, subschema := range items {
+					downgraded[i] = downgradeSchema(subschema, defs, expanding)
+				}
+				converted[key] = downgraded
+			}
+		case "not":
+			converted[key] = downgradeSchema(item, defs, expanding)
+			if negated, ok := converted[key].(map[string]interface{}); ok && len(negated) == 0 {
+				converted[key] = rejectNothing()
+			}
+		case "items", "contains", "propertyNames":
+			converted[key] = downgradeSchema(item, defs, expanding)
+		case "additionalProperties":
+			// kin-openapi reads booleans and schemas there
+			if _, ok := item.(bool); ok {
+				converted[key] = item
+			} else {
+				converted[key] = downgradeSchema(item, defs, expanding)
+			}
+		default:
+			converted[key] = item
+		}
+	}
+
+	for _, bound := range []string{"Minimum", "Maximum"} {
+		exclusive, ok := converted["exclusive"+bound].(float64)
+		if !ok {
+			continue
+		}
+		inclusiveKey := strings.ToLower(bound)
+		inclusive, hasInclusive := converted[inclusiveKey].(float64)
+		if !hasInclusive || (bound == "Minimum" && exclusive >= inclusive) || (bound == "Maximum" && exclusive <= inclusive) {
+			converted[inclusiveKey] = exclusive
+			converted["exclusive"+bound] = true
+		} else {
+			delete(converted, "exclusive"+bound)
+		}
+	}
+
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return converted
+	}
+	name := strings.TrimPrefix(ref, defsRef)
+	target, found := defs[name]
+	if name == ref || !found || expanding[name] {
+		// Unresolvable and recursive references accept any value
+		return converted
+	}
+	expanding[name] = true
+	inlined, _ := downgradeSchema(target, defs, expanding).(map[string]interface{})
+	delete(expanding, name)
+	// Merge the keywords next to the $ref unless they constrain what the definition does, so
+	// that problems are reported by property rather than as an allOf mismatch
+	merged := make(map[string]interface{}, len(inlined)+len(converted))
+	for key, item := range inlined {
+		merged[key] = item
+	}
+	for key, item := range converted {
+		if _, ok := merged[key]; ok && !annotationKeywords[key] {
+			return map[string]interface{}{"allOf": []interface{}{inlined, converted}}
+		}
+		merged[key] = item
+	}
+	return merged
+}
+
+// rejectNothing returns a schema accepting any value that kin-openapi does not take for an empty
+// schema, which it ignores under not
+func rejectNothing() map[string]interface{} {
+	return map[string]interface{}{"nullable": true}
+}
+
+// requiresOpenAPI31 reports whether a JSON Schema uses keywords or forms of JSON Schema 2020-12
+// that OpenAPI 3.0 schemas lack
+func requiresOpenAPI31(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if requiresOpenAPI31(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			switch key {
+			case "const", "prefixItems", "$defs", "if", "then", "else", "dependentRequired",
+				"dependentSchemas", "unevaluatedItems", "unevaluatedProperties", "contains", "$schema", "$id":
+				return true
+			case "type":
+				if _, ok := item.([]interface{}); ok {
+					return true
+				}
+			case "examples":
+				if _, ok := item.([]interface{}); ok {
+					return true
+				}
+			case "exclusiveMinimum", "exclusiveMaximum":
+				if _, ok := item.(float64); ok {
+					return true
+				}
+			case "enum", "default", "example":
+			case "properties", "patternProperties":
+				if properties, ok := item.(map[string]interface{}); ok {
+					for _, property := range properties {
+						if requiresOpenAPI31(property) {
+							return true
+						}
+					}
+				}
+			case "additionalProperties":
+				if _, ok := item.(bool); !ok && requiresOpenAPI31(item) {
+					return true
+				}
+			case "items", "not", "allOf", "anyOf", "oneOf", "propertyNames":
+				if requiresOpenAPI31(item) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// exportDefs moves the $defs of a schema to the component schemas of an OpenAPI document and
+// points its references there
+func exportDefs(schema map[string]interface{}, components map[string]interface{}) map[string]interface{} {
+	rewriteRefs(schema, defsRef, componentSchemaRef)
+	hoistDefs(schema, components)
+	return schema
+}
+
+// rewriteRefs replaces the prefix of the references of a schema and of its subschemas
+func rewriteRefs(value interface{}, from, to string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if schemaValueKeywords[key] {
+				continue
+			}
+			if ref, ok := item.(string); ok && key == "$ref" && strings.HasPrefix(ref, from) {
+				v[key] = to + strings.TrimPrefix(ref, from)
+				continue
+			}
+			rewriteRefs(item, from, to)
+		}
+	case []interface{}:
+		for _, item := range v {
+			rewriteRefs(item, from, to)
+		}
+	}
+}
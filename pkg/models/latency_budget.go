@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatencyBudget is the target latency of a tool, the objective its calls are measured against.
+// Calls slower than the target are counted as budget violations. An enforced budget also cuts
+// the calls off at the target and returns a timeout error to the client instead of a late result.
+type LatencyBudget struct {
+	TargetMs int  `json:"targetMs"`          // Target latency of a call, 0 removes the budget
+	Enforce  bool `json:"enforce,omitempty"` // Cancel calls at the target instead of only counting them
+}
+
+// IsSet reports whether the tool has a latency budget
+func (b *LatencyBudget) IsSet() bool {
+	return b != nil && b.TargetMs > 0
+}
+
+// IsEnforced reports whether calls are canceled at the target
+func (b *LatencyBudget) IsEnforced() bool {
+	return b.IsSet() && b.Enforce
+}
+
+// Target returns the target latency of a call
+func (b *LatencyBudget) Target() time.Duration {
+	return time.Duration(b.TargetMs) * time.Millisecond
+}
+
+// ValidateLatencyBudgets checks the latency budgets of the tools of the server
+func (m *MCPServer) ValidateLatencyBudgets() error {
+	for _, tool := range m.Tools {
+		if tool.LatencyBudget == nil {
+			continue
+		}
+		if tool.LatencyBudget.TargetMs < 0 {
+			return fmt.Errorf("latency budget of tool %s: targetMs must not be negative", tool.Name)
+		}
+		if tool.LatencyBudget.Enforce && tool.LatencyBudget.TargetMs == 0 {
+			return fmt.Errorf("latency budget of tool %s: an enforced budget needs a targetMs", tool.Name)
+		}
+	}
+	return nil
+}
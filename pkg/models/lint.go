@@ -0,0 +1,189 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Codes of the lint warnings of HTTP interfaces
+const (
+	LintMissingDescription    = "missing-description"     // Interface or param without a description, which the model relies on
+	LintMissingResponseSchema = "missing-response-schema" // No successful response with a body schema, so the tool has no output schema
+	LintMissingRequestSchema  = "missing-request-schema"  // Request body whose schema does not describe it
+	LintAmbiguousParameter    = "ambiguous-parameter"     // Param the tool cannot tell apart or place in the request
+	LintDuplicateOperationID  = "duplicate-operation-id"  // operationId shared by operations of an OpenAPI spec
+	LintWebhookNotImported    = "webhook-not-imported"    // Webhook of an OpenAPI 3.1 spec, a request the API sends
+)
+
+// LintWarning is a weakness of an HTTP interface definition that makes a poorer tool, reported
+// on import so that it can be fixed before tools are generated
+type LintWarning struct {
+	Interface string `json:"interface"`       // Name of the interface
+	Field     string `json:"field,omitempty"` // Part of the definition, e.g. parameters.city, empty for the interface
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+}
+
+// pathPlaceholder matches the {name} placeholders of the path of an interface
+var pathPlaceholder = regexp.MustCompile(`\{([^{}/?#]+)\}`)
+
+// toolArguments are the argument names of tools that carry the headers, cookies and body
+var toolArguments = map[string]bool{"headers": true, "cookies": true, "body": true}
+
+// Lint checks the interface for missing descriptions and schemas and for params the generated
+// tool cannot tell apart, returning the warnings sorted by field
+func (h *HTTPInterface) Lint() []LintWarning {
+	var warnings []LintWarning
+	warn := func(field string, code string, format string, args ...interface{}) {
+		warnings = append(warnings, LintWarning{Interface: h.Name, Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(h.Description) == "" {
+		warn("", LintMissingDescription, "the interface has no description, the model only sees its name")
+	}
+	for _, header := range h.Headers {
+		if strings.TrimSpace(header.Description) == "" {
+			warn("headers."+header.Name, LintMissingDescription, "header %s has no description", header.Name)
+		}
+	}
+
+	// Query and path params are tool arguments of their own, headers and cookies share an object
+	locations := map[string][]string{}
+	for _, header := range h.Headers {
+		key := "header:" + strings.ToLower(header.Name)
+		locations[key] = append(locations[key], "header")
+	}
+	declared := map[string]bool{}
+	for _, param := range h.Parameters {
+		field := "parameters." + param.Name
+		if strings.TrimSpace(param.Description) == "" {
+			warn(field, LintMissingDescription, "%s param %s has no description", param.In, param.Name)
+		}
+		switch param.In {
+		case "query", "path":
+			locations[param.Name] = append(locations[param.Name], param.In)
+			if toolArguments[param.Name] {
+				warn(field, LintAmbiguousParameter, "%s param %s has the name of the %s argument of the tool", param.In, param.Name, param.Name)
+			}
+			if param.In == "path" {
+				declared[param.Name] = true
+				if !strings.Contains(h.Path, "{"+param.Name+"}") {
+					warn(field, LintAmbiguousParameter, "path param %s has no {%s} placeholder in the path", param.Name, param.Name)
+				}
+			}
+		case "header":
+			key := "header:" + strings.ToLower(param.Name)
+			locations[key] = append(locations[key], "header")
+		case "cookie":
+			locations["cookie:"+param.Name] = append(locations["cookie:"+param.Name], "cookie")
+		}
+	}
+	for key, in := range locations {
+		if len(in) > 1 {
+			name := key[strings.Index(key, ":")+1:]
+			warn("parameters."+name, LintAmbiguousParameter, "%s is defined %d times (%s), the tool sends the same value to each", name, len(in), strings.Join(in, ", "))
+		}
+	}
+	for _, match := range pathPlaceholder.FindAllStringSubmatch(h.Path, -1) {
+		if !declared[match[1]] {
+			warn("path", LintAmbiguousParameter, "placeholder {%s} of the path has no path param, the tool does not ask for it", match[1])
+		}
+	}
+
+	if h.RequestBody != nil && !describesValue(h.RequestBody.Schema) {
+		warn("requestBody", LintMissingRequestSchema, "the request body schema has no properties, the model has to guess the body")
+	}
+
+	success, noContent := false, false
+	for _, response := range h.Responses {
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			continue
+		}
+		if response.StatusCode == 204 {
+			noContent = true
+		}
+		if response.Body != nil && describesValue(response.Body.Schema) {
+			success = true
+		}
+	}
+	if !success && !noContent {
+		warn("responses", LintMissingResponseSchema, "no successful response has a body schema, the tool has no output schema")
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Field < warnings[j].Field })
+	return warnings
+}
+
+// LintInterfaces lints interfaces, returning their warnings in order
+func LintInterfaces(interfaces []HTTPInterface) []LintWarning {
+	warnings := []LintWarning{}
+	for i := range interfaces {
+		warnings = append(warnings, interfaces[i].Lint()...)
+	}
+	return warnings
+}
+
+// LintOpenAPI reports the operationIds shared by the operations of an OpenAPI spec selected by
+// filter and the webhooks it describes, which are not imported. The first operation, by path and
+// method, keeps the operationId as its name, the others are named after their path.
+func LintOpenAPI(openAPI map[string]interface{}, filter *OpenAPIFilter) []LintWarning {
+	warnings := []LintWarning{}
+	webhooks, _ := openAPI["webhooks"].(map[string]interface{})
+	for _, name := range sortedKeys(webhooks) {
+		warnings = append(warnings, LintWarning{
+			Interface: name,
+			Field:     "webhooks",
+			Code:      LintWebhookNotImported,
+			Message:   fmt.Sprintf("webhook %s describes a request the API sends, it is not imported as an interface", name),
+		})
+	}
+	paths, _ := openAPI["paths"].(map[string]interface{})
+	first := map[string]string{}
+	for _, path := range sortedKeys(paths) {
+		pathItem, ok := paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range sortedKeys(pathItem) {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok || !filter.Matches(method, path, operationTags(operation)) {
+				continue
+			}
+			opID, _ := operation["operationId"].(string)
+			if opID == "" {
+				continue
+			}
+			endpoint := strings.ToUpper(method) + " " + path
+			if previous, ok := first[opID]; ok {
+				warnings = append(warnings, LintWarning{
+					Interface: opID,
+					Field:     "operationId",
+					Code:      LintDuplicateOperationID,
+					Message:   fmt.Sprintf("operationId %s of %s is already used by %s, the interface is named after its path", opID, endpoint, previous),
+				})
+				continue
+			}
+			first[opID] = endpoint
+		}
+	}
+	return warnings
+}
+
+// describesValue reports whether a JSON Schema says something of the value: properties, items,
+// a composition or a type other than a bare object, which imports default to
+func describesValue(schema string) bool {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return false
+	}
+	for _, key := range []string{"properties", "additionalProperties", "items", "prefixItems", "$ref", "oneOf", "anyOf", "allOf", "enum", "const"} {
+		if _, ok := parsed[key]; ok {
+			return true
+		}
+	}
+	schemaType, ok := parsed["type"]
+	return ok && schemaType != "object"
+}
@@ -1,36 +1,96 @@
 package models
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
 	"time"
 )
 
 // MCPServer represents an MCP Server configuration
 type MCPServer struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name" binding:"required"`
-	Description string    `json:"description"`
-	AllowTools  []string  `json:"allowTools"`
-	Tools       []Tool    `json:"tools"`
-	Version     int       `json:"version"`
-	Status      string    `json:"status" binding:"oneof=draft active inactive"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string `json:"id"`
+	Name        string `json:"name" binding:"required"`
+	Namespace   string `json:"namespace"` // Team owning the server, set from the request
+	Description string `json:"description"`
+	// Guidance for the LLMs using the tools, e.g. when to use them, auth notes and rate limits,
+	// sent in the MCP initialize result
+	Instructions       string              `json:"instructions,omitempty"`
+	AllowTools         []string            `json:"allowTools"`
+	Tools              []Tool              `json:"tools"`
+	Plugins            []string            `json:"plugins,omitempty"`            // WASM file IDs applied to every tool
+	DefaultEnvironment string              `json:"defaultEnvironment,omitempty"` // Environment used when the request selects none
+	External           []ExternalServer    `json:"external,omitempty"`           // MCP servers whose tools are proxied
+	Sources            []ServerSource      `json:"sources,omitempty"`            // Servers of the gateway whose tools a virtual server includes
+	ConflictResolution string              `json:"conflictResolution,omitempty"` // error (default), first or last
+	Redactions         []RedactionRule     `json:"redactions,omitempty"`         // Applied to tool results after the global rules
+	Headers            *HeaderPolicy       `json:"headers,omitempty"`            // Default headers and the client headers forwarded upstream
+	AuthPassthrough    *AuthPassthrough    `json:"authPassthrough,omitempty"`    // What reaches the upstreams of the Authorization header of callers
+	Network            *NetworkRestriction `json:"network,omitempty"`            // Client addresses allowed to invoke the tools
+	Strict             bool                `json:"strict,omitempty"`             // Reject calls with params, headers or body fields the tool does not define
+	Schedule           *ActivationSchedule `json:"schedule,omitempty"`           // Scheduled activations and deactivations
+	Policy             *CallPolicy         `json:"policy,omitempty"`             // Timeout, retries, rate limit and hosts of the tools, over those of the namespace
+	Version            int                 `json:"version"`
+	Changelog          *ChangeNote         `json:"changelog,omitempty"` // Note of the change creating the version
+	Status             string              `json:"status" binding:"oneof=draft active inactive archived"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	UpdatedAt          time.Time           `json:"updatedAt"`
 }
 
 // Tool represents a tool in MCP Server
 type Tool struct {
-	Name             string           `json:"name" binding:"required"`
-	Description      string           `json:"description"`
-	RequestTemplate  RequestTemplate  `json:"requestTemplate"`
-	ResponseTemplate ResponseTemplate `json:"responseTemplate"`
+	Name                string                 `json:"name" binding:"required"`
+	Description         string                 `json:"description"`
+	Alias               string                 `json:"alias,omitempty"`               // Name exposed to MCP clients instead of the name
+	DescriptionOverride string                 `json:"descriptionOverride,omitempty"` // Description exposed instead of the generated one
+	ParamDescriptions   map[string]string      `json:"paramDescriptions,omitempty"`   // Descriptions exposed for params by the names clients use
+	RequestTemplate     RequestTemplate        `json:"requestTemplate"`
+	ResponseTemplate    ResponseTemplate       `json:"responseTemplate"`
+	Plugins             []string               `json:"plugins,omitempty"`          // WASM file IDs applied after the server plugins
+	PreScript           string                 `json:"preScript,omitempty"`        // CEL expression adjusting params and headers
+	PostScript          string                 `json:"postScript,omitempty"`       // CEL expression reshaping the response
+	InterfaceID         string                 `json:"interfaceId,omitempty"`      // HTTP interface the tool was generated from
+	InterfaceVersion    int                    `json:"interfaceVersion,omitempty"` // Version of the interface at generation
+	Auth                *Auth                  `json:"auth,omitempty"`             // Authentication profile of the interface
+	AuthPassthrough     *AuthPassthrough       `json:"authPassthrough,omitempty"`  // Overrides the Authorization passthrough policy of the server
+	External            string                 `json:"external,omitempty"`         // External server the tool is proxied to
+	Source              string                 `json:"source,omitempty"`           // MCP server the tool of a virtual server forwards to
+	RemoteName          string                 `json:"remoteName,omitempty"`       // Name of the tool on the external or source server
+	StaticParams        map[string]interface{} `json:"staticParams,omitempty"`     // Params always sent upstream, over those of the caller
+	ParamMapping        map[string]string      `json:"paramMapping,omitempty"`     // Upstream name of a param by the name clients use
+	ParamStyles         map[string]ParamStyle  `json:"paramStyles,omitempty"`      // Serialization of the array params of the interface by upstream name
+	Projection          *Projection            `json:"projection,omitempty"`       // Fields of the result returned to clients
+	Cost                float64                `json:"cost,omitempty"`             // Cost of a call counted against API key quotas, 1 if not set
+	Hedging             *Hedging               `json:"hedging,omitempty"`          // Second request sent when an idempotent GET call is slow
+	LatencyBudget       *LatencyBudget         `json:"latencyBudget,omitempty"`    // Target latency of a call
+	ErrorMappings       []ErrorMapping         `json:"errorMappings,omitempty"`    // Errors returned for unsuccessful upstream statuses instead of the raw body
+	PartialOnTimeout    bool                   `json:"partialOnTimeout,omitempty"` // Return the body received so far when the upstream response is cut off at the timeout
+	Shadow              *Shadow                `json:"shadow,omitempty"`           // Tool also called in the background on a sample of the calls, to compare results
+	Policy              *CallPolicy            `json:"policy,omitempty"`           // Timeout, retries, rate limit and hosts of the calls, over those of the server
+	WebSocket           *WebSocketExchange     `json:"websocket,omitempty"`        // Messages exchanged with a WebSocket upstream instead of a request
+	Steps               []ToolStep             `json:"steps,omitempty"`            // Tools called in order by a chained tool
+	// gjson path selecting the result of a chained tool from its params and step results, the result of the last step by default
+	Output string `json:"output,omitempty"`
+	// Switched off by an operator: a disabled tool stays in allowTools but is not listed and its calls
+	// are rejected. Enabled if not set.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Deprecation of the tool, taken from its interface: deprecated tools are still called, with a warning
+	Deprecated         bool       `json:"deprecated,omitempty"`
+	Sunset             *time.Time `json:"sunset,omitempty"`
+	DeprecationMessage string     `json:"deprecationMessage,omitempty"`
+	// JSON Schema of the tool arguments, generated from the parameters, headers and body of the interface
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"`
+	// JSON Schema of the tool result, the body schema of the successful response of the interface
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
 }
 
 // RequestTemplate represents a request template in MCP Server
 type RequestTemplate struct {
-	Method  string            `json:"method" binding:"required,oneof=GET POST PUT DELETE PATCH"`
-	URL     string            `json:"url" binding:"required"`
-	Headers map[string]string `json:"headers,omitempty"`
-	Body    string            `json:"body,omitempty"`
+	Method      string            `json:"method" binding:"required,oneof=GET POST PUT DELETE PATCH"`
+	URL         string            `json:"url" binding:"required"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	ContentType string            `json:"contentType,omitempty"` // Encoding of the body, taken from the interface, JSON if not set
 }
 
 // ResponseTemplate represents a response template in MCP Server
@@ -38,6 +98,95 @@ type ResponseTemplate struct {
 	Body string `json:"body"`
 }
 
+// CallCost returns the cost of a call of the tool counted against the quotas of API keys
+func (t *Tool) CallCost() float64 {
+	if t.Cost <= 0 {
+		return 1
+	}
+	return t.Cost
+}
+
+// IsEnabled reports whether the tool is listed and called, true unless it was disabled
+func (t *Tool) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// ExposedName returns the name MCP clients call the tool by, its alias if set
+func (t *Tool) ExposedName() string {
+	if t.Alias != "" {
+		return t.Alias
+	}
+	return t.Name
+}
+
+// ExposedDescription returns the description shown to MCP clients, its override if set
+func (t *Tool) ExposedDescription() string {
+	if t.DescriptionOverride != "" {
+		return t.DescriptionOverride
+	}
+	return t.Description
+}
+
+// DeprecationWarning returns the warning shown to the callers of a deprecated tool, empty if the
+// tool is not deprecated
+func (t *Tool) DeprecationWarning() string {
+	if !t.Deprecated {
+		return ""
+	}
+	warning := "Tool " + t.ExposedName() + " is deprecated"
+	if t.Sunset != nil {
+		warning += " and will be removed on " + t.Sunset.UTC().Format(time.DateOnly)
+	}
+	if t.DeprecationMessage != "" {
+		warning += ": " + t.DeprecationMessage
+	}
+	return warning
+}
+
+// ServerInstructions returns the instructions sent to MCP clients, the description of servers
+// without instructions
+func (m *MCPServer) ServerInstructions() string {
+	if m.Instructions != "" {
+		return m.Instructions
+	}
+	return m.Description
+}
+
+// FindTool returns the tool MCP clients call by the name, or nil
+func (m *MCPServer) FindTool(name string) *Tool {
+	for i := range m.Tools {
+		if m.Tools[i].ExposedName() == name {
+			return &m.Tools[i]
+		}
+	}
+	return nil
+}
+
+// AllowsTool reports whether MCP clients may call the tool of the name
+func (m *MCPServer) AllowsTool(name string) bool {
+	tool := m.FindTool(name)
+	return tool != nil && slices.Contains(m.AllowTools, tool.Name)
+}
+
+// ValidateToolNames checks the tool aliases and that MCP clients see unique tool names
+func (m *MCPServer) ValidateToolNames() error {
+	exposed := make(map[string]bool, len(m.Tools))
+	for _, tool := range m.Tools {
+		if tool.Alias != "" && !toolAliasPattern.MatchString(tool.Alias) {
+			return fmt.Errorf("alias '%s' of tool %s must be 1 to 64 letters, digits, '_', '-' or '.'", tool.Alias, tool.Name)
+		}
+		name := tool.ExposedName()
+		if exposed[name] {
+			return fmt.Errorf("duplicate tool name %s", name)
+		}
+		exposed[name] = true
+	}
+	return nil
+}
+
+// toolAliasPattern matches the tool names accepted by MCP clients
+var toolAliasPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+
 // ToYAML converts the MCP Server to YAML format
 func (m *MCPServer) ToYAML() string {
 	// Implementation will be added later
@@ -58,17 +207,7 @@ func NewMCPServerFromHTTPInterfaces(name string, description string, interfaces
 	}
 
 	for _, httpInterface := range interfaces {
-		tool := Tool{
-			Name:        httpInterface.Name,
-			Description: httpInterface.Description,
-			RequestTemplate: RequestTemplate{
-				Method: httpInterface.Method,
-				URL:    httpInterface.Path,
-			},
-			ResponseTemplate: ResponseTemplate{
-				Body: "", // Will be populated based on response schema
-			},
-		}
+		tool := NewToolFromHTTPInterface(httpInterface)
 
 		// Add the tool name to allowed tools
 		server.AllowTools = append(server.AllowTools, tool.Name)
@@ -79,3 +218,28 @@ func NewMCPServerFromHTTPInterfaces(name string, description string, interfaces
 
 	return server
 }
+
+// NewToolFromHTTPInterface converts an HTTP interface to a tool linked to it
+func NewToolFromHTTPInterface(httpInterface HTTPInterface) Tool {
+	return Tool{
+		Name:        httpInterface.Name,
+		Description: httpInterface.Description,
+		RequestTemplate: RequestTemplate{
+			Method:      httpInterface.Method,
+			URL:         httpInterface.Path,
+			ContentType: httpInterface.RequestContentType(),
+		},
+		ResponseTemplate: ResponseTemplate{
+			Body: "", // Will be populated based on response schema
+		},
+		InterfaceID:        httpInterface.ID,
+		InterfaceVersion:   httpInterface.Version,
+		Auth:               httpInterface.Auth,
+		Deprecated:         httpInterface.Deprecated,
+		Sunset:             httpInterface.Sunset,
+		DeprecationMessage: httpInterface.DeprecationMessage,
+		ParamStyles:        httpInterface.ArrayParamStyles(),
+		InputSchema:        httpInterface.InputSchema(),
+		OutputSchema:       httpInterface.OutputSchema(),
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// networkZoneName matches the names of the network zones of the gateway configuration
+var networkZoneName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// NetworkRestriction limits the clients that may invoke the tools of a server to the addresses
+// of CIDR ranges and of the network zones named in the gateway configuration
+type NetworkRestriction struct {
+	Allow []string `json:"allow"` // CIDR ranges, IP addresses or zone names
+}
+
+// IsNetworkZoneName reports whether name can name a network zone
+func IsNetworkZoneName(name string) bool {
+	return networkZoneName.MatchString(name)
+}
+
+// ParseNetwork parses a CIDR range, or an IP address as the range of that address alone
+func ParseNetwork(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Validate checks that every entry is a CIDR range, an IP address or a zone name. Whether the
+// zones exist is only known to the gateway configuration, calls are refused for unknown ones.
+func (r *NetworkRestriction) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if len(r.Allow) == 0 {
+		return errors.New("network restriction must allow at least one range or zone")
+	}
+	for _, entry := range r.Allow {
+		if IsNetworkZoneName(entry) {
+			continue
+		}
+		if _, err := ParseNetwork(entry); err != nil {
+			return fmt.Errorf("allowed network '%s' must be a CIDR range, an IP address or a zone name", entry)
+		}
+	}
+	return nil
+}
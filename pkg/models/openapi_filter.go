@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// openAPIMethods are the operations of an OpenAPI path item
+var openAPIMethods = map[string]bool{"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true}
+
+// OpenAPIFilter selects the operations of an OpenAPI spec an import creates interfaces for. An
+// operation is imported if it matches every include list that is set, by any of its entries, and
+// none of the exclude lists. The zero filter imports every operation.
+type OpenAPIFilter struct {
+	Tags                []string `json:"tags,omitempty" form:"tags"`                               // Operations with one of the tags
+	ExcludeTags         []string `json:"excludeTags,omitempty" form:"excludeTags"`                 // Operations without any of the tags
+	PathPrefixes        []string `json:"pathPrefixes,omitempty" form:"pathPrefixes"`               // Paths under one of the prefixes, e.g. /pets
+	ExcludePathPrefixes []string `json:"excludePathPrefixes,omitempty" form:"excludePathPrefixes"` // Paths under none of the prefixes
+	Methods             []string `json:"methods,omitempty" form:"methods"`                         // Operations of one of the methods
+	ExcludeMethods      []string `json:"excludeMethods,omitempty" form:"excludeMethods"`           // Operations of none of the methods
+}
+
+// IsEmpty reports whether the filter selects every operation
+func (f *OpenAPIFilter) IsEmpty() bool {
+	return f == nil || len(f.Tags)+len(f.ExcludeTags)+len(f.PathPrefixes)+len(f.ExcludePathPrefixes)+len(f.Methods)+len(f.ExcludeMethods) == 0
+}
+
+// Validate checks the methods and path prefixes of the filter
+func (f *OpenAPIFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, method := range append(append([]string{}, f.Methods...), f.ExcludeMethods...) {
+		if !openAPIMethods[strings.ToLower(method)] {
+			return fmt.Errorf("invalid method '%s': must be an HTTP method such as GET or POST", method)
+		}
+	}
+	for _, prefix := range append(append([]string{}, f.PathPrefixes...), f.ExcludePathPrefixes...) {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("invalid path prefix '%s': must start with /", prefix)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the filter selects the operation of the method and path with the tags
+func (f *OpenAPIFilter) Matches(method string, path string, tags []string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if len(f.Tags) > 0 && !containsAny(f.Tags, tags) {
+		return false
+	}
+	if containsAny(f.ExcludeTags, tags) {
+		return false
+	}
+	if len(f.PathPrefixes) > 0 && !underAnyPrefix(path, f.PathPrefixes) {
+		return false
+	}
+	if underAnyPrefix(path, f.ExcludePathPrefixes) {
+		return false
+	}
+	if len(f.Methods) > 0 && !containsMethod(f.Methods, method) {
+		return false
+	}
+	return !containsMethod(f.ExcludeMethods, method)
+}
+
+// operationTags returns the tags of an OpenAPI operation
+func operationTags(operation map[string]interface{}) []string {
+	values, _ := operation["tags"].([]interface{})
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// containsAny reports whether values and candidates share an entry
+func containsAny(values []string, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsMethod reports whether methods hold method, whatever the case
+func containsMethod(methods []string, method string) bool {
+	for _, candidate := range methods {
+		if strings.EqualFold(candidate, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// underAnyPrefix reports whether path is one of the prefixes or below one of them, matching whole
+// segments so that /pets does not select /petstore
+func underAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
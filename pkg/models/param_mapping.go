@@ -0,0 +1,137 @@
+package models
+
+import "fmt"
+
+// reservedParams carry the headers, cookies and body of a call rather than parameters
+var reservedParams = map[string]bool{"headers": true, "cookies": true, "body": true}
+
+// UpstreamParams returns the params of a call as the upstream expects them: renamed by the param
+// mapping of the tool, with its static params set over those of the caller
+func (t *Tool) UpstreamParams(params map[string]interface{}) map[string]interface{} {
+	upstream := make(map[string]interface{}, len(params)+len(t.StaticParams))
+	for name, value := range params {
+		if mapped, ok := t.ParamMapping[name]; ok {
+			name = mapped
+		}
+		upstream[name] = value
+	}
+	for name, value := range t.StaticParams {
+		upstream[name] = value
+	}
+	return upstream
+}
+
+// ExposedInputSchema returns an input schema of the tool as MCP clients see it: without the static
+// params, with the mapped params under the names clients use, with the overridden param
+// descriptions and with the _fields param of selectable projections
+func (t *Tool) ExposedInputSchema(schema map[string]interface{}) map[string]interface{} {
+	selectable := t.Projection != nil && t.Projection.Selectable
+	if schema == nil || len(t.StaticParams) == 0 && len(t.ParamMapping) == 0 && len(t.ParamDescriptions) == 0 && !selectable {
+		return schema
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	// Client name by upstream name
+	clientNames := make(map[string]string, len(t.ParamMapping))
+	for client, upstream := range t.ParamMapping {
+		clientNames[upstream] = client
+	}
+	exposedName := func(name string) (string, bool) {
+		if _, ok := t.StaticParams[name]; ok {
+			return "", false
+		}
+		if client, ok := clientNames[name]; ok {
+			return client, true
+		}
+		return name, true
+	}
+
+	exposed := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		exposed[key] = value
+	}
+	if properties != nil || selectable {
+		exposedProperties := make(map[string]interface{}, len(properties)+1)
+		for name, property := range properties {
+			client, ok := exposedName(name)
+			if !ok {
+				continue
+			}
+			if description, ok := t.ParamDescriptions[client]; ok {
+				property = withDescription(property, description)
+			}
+			exposedProperties[client] = property
+		}
+		if selectable {
+			exposedProperties[FieldsParam] = map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Paths of the result fields to return, e.g. items.#.name, all the default fields if omitted",
+			}
+		}
+		exposed["properties"] = exposedProperties
+	}
+	if required, ok := requiredNames(schema["required"]); ok {
+		exposedRequired := make([]string, 0, len(required))
+		for _, name := range required {
+			if client, ok := exposedName(name); ok {
+				exposedRequired = append(exposedRequired, client)
+			}
+		}
+		exposed["required"] = exposedRequired
+	}
+	return exposed
+}
+
+// withDescription returns a copy of a property schema with another description
+func withDescription(property interface{}, description string) interface{} {
+	schema, ok := property.(map[string]interface{})
+	if !ok {
+		return property
+	}
+	described := make(map[string]interface{}, len(schema)+1)
+	for key, value := range schema {
+		described[key] = value
+	}
+	described["description"] = description
+	return described
+}
+
+// requiredNames reads the required list of a schema, which is []interface{} once decoded from JSON
+func requiredNames(value interface{}) ([]string, bool) {
+	switch required := value.(type) {
+	case []string:
+		return required, true
+	case []interface{}:
+		names := make([]string, 0, len(required))
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names, true
+	}
+	return nil, false
+}
+
+// ValidateParamMappings checks the static params and param mappings of the tools of the server
+func (m *MCPServer) ValidateParamMappings() error {
+	for _, tool := range m.Tools {
+		for name := range tool.StaticParams {
+			if name == "" || reservedParams[name] {
+				return fmt.Errorf("invalid static param '%s' of tool %s", name, tool.Name)
+			}
+		}
+		targets := make(map[string]string, len(tool.ParamMapping))
+		for client, upstream := range tool.ParamMapping {
+			if client == "" || upstream == "" || reservedParams[client] || reservedParams[upstream] {
+				return fmt.Errorf("invalid param mapping '%s' -> '%s' of tool %s", client, upstream, tool.Name)
+			}
+			if other, ok := targets[upstream]; ok {
+				return fmt.Errorf("params %s and %s of tool %s are both mapped to %s", other, client, tool.Name, upstream)
+			}
+			targets[upstream] = client
+		}
+	}
+	return nil
+}
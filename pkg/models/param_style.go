@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Serialization styles of array params, as in OpenAPI
+const (
+	ParamStyleForm           = "form"           // Query: repeated key when exploded, comma separated otherwise
+	ParamStyleSpaceDelimited = "spaceDelimited" // Query: space separated
+	ParamStylePipeDelimited  = "pipeDelimited"  // Query: pipe separated
+	ParamStyleSimple         = "simple"         // Path: comma separated
+)
+
+// ParamStyle is how a tool sends an array param upstream, taken from its interface
+type ParamStyle struct {
+	In      string `json:"in"`    // query or path
+	Style   string `json:"style"` // form, spaceDelimited, pipeDelimited or simple
+	Explode bool   `json:"explode,omitempty"`
+}
+
+// Repeated reports whether every item is sent as its own key=value pair
+func (s ParamStyle) Repeated() bool {
+	return s.In == "query" && s.Style == ParamStyleForm && s.Explode
+}
+
+// Delimiter returns the separator of the items sent as a single value
+func (s ParamStyle) Delimiter() string {
+	switch s.Style {
+	case ParamStyleSpaceDelimited:
+		return " "
+	case ParamStylePipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
+
+// Serialization returns the style of the param, defaulting as OpenAPI does to form with explode
+// for query params and simple for path params
+func (p *Param) Serialization() ParamStyle {
+	style := ParamStyle{In: p.In, Style: p.Style}
+	if style.Style == "" {
+		style.Style = ParamStyleForm
+		if p.In == "path" {
+			style.Style = ParamStyleSimple
+		}
+	}
+	style.Explode = style.Style == ParamStyleForm
+	if p.Explode != nil {
+		style.Explode = *p.Explode
+	}
+	return style
+}
+
+// ArrayParamStyles returns the styles of the array query and path params of the interface by name
+func (h *HTTPInterface) ArrayParamStyles() map[string]ParamStyle {
+	var styles map[string]ParamStyle
+	for i := range h.Parameters {
+		param := &h.Parameters[i]
+		if param.Type != "array" || param.In != "query" && param.In != "path" {
+			continue
+		}
+		if styles == nil {
+			styles = map[string]ParamStyle{}
+		}
+		styles[param.Name] = param.Serialization()
+	}
+	return styles
+}
+
+// ValidateParamStyles checks that the params use the styles OpenAPI defines for their location
+func (h *HTTPInterface) ValidateParamStyles() error {
+	for _, param := range h.Parameters {
+		if param.Style == "" {
+			continue
+		}
+		valid := false
+		switch param.In {
+		case "query":
+			valid = param.Style == ParamStyleForm || param.Style == ParamStyleSpaceDelimited || param.Style == ParamStylePipeDelimited
+		case "path":
+			valid = param.Style == ParamStyleSimple
+		}
+		if !valid {
+			return fmt.Errorf("param %s: style '%s' is not supported for %s params", param.Name, param.Style, param.In)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldsParam is the param callers of a selectable tool choose the fields of its result with
+const FieldsParam = "_fields"
+
+// Projection trims the JSON results of a tool to the fields an agent needs. Paths use the gjson
+// dot syntax: field names, array indexes and "#" for every element of an array, e.g.
+// "items.#.name"; dots in field names are escaped as "\.".
+type Projection struct {
+	Include    []string `json:"include,omitempty"`    // Paths of the fields kept, every field if empty
+	Exclude    []string `json:"exclude,omitempty"`    // Paths of the fields removed from the kept ones
+	Selectable bool     `json:"selectable,omitempty"` // Callers may replace the include paths with the _fields param
+}
+
+// IsEmpty reports whether the projection keeps the results unchanged
+func (p *Projection) IsEmpty() bool {
+	return p == nil || len(p.Include) == 0 && len(p.Exclude) == 0 && !p.Selectable
+}
+
+// Validate checks the paths of the projection
+func (p *Projection) Validate() error {
+	for _, path := range append(append([]string(nil), p.Include...), p.Exclude...) {
+		if err := ValidateFieldPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateFieldPath checks that a projection path has no empty segment
+func ValidateFieldPath(path string) error {
+	for _, segment := range SplitFieldPath(path) {
+		if segment == "" {
+			return fmt.Errorf("invalid field path '%s'", path)
+		}
+	}
+	return nil
+}
+
+// SplitFieldPath splits a projection path into its segments, unescaping the escaped dots
+func SplitFieldPath(path string) []string {
+	segments := []string{}
+	var segment strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			segment.WriteByte('.')
+			i++
+		case path[i] == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(path[i])
+		}
+	}
+	return append(segments, segment.String())
+}
+
+// ValidateProjections checks the projections of the tools of the server
+func (m *MCPServer) ValidateProjections() error {
+	for _, tool := range m.Tools {
+		if tool.Projection == nil {
+			continue
+		}
+		if err := tool.Projection.Validate(); err != nil {
+			return fmt.Errorf("projection of tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// Quota limits the tool calls and resources of a tenant, the namespace it is named after.
+// A zero limit is unlimited.
+type Quota struct {
+	Tenant             string    `json:"tenant"`
+	MaxToolCallsPerDay int       `json:"maxToolCallsPerDay" binding:"min=0"` // Tool calls per UTC day
+	MaxServers         int       `json:"maxServers" binding:"min=0"`
+	MaxInterfaces      int       `json:"maxInterfaces" binding:"min=0"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// TenantUsage is the usage of a tenant against its quota
+type TenantUsage struct {
+	Tenant     string `json:"tenant"`
+	Day        string `json:"day"`       // UTC day of the tool calls, YYYY-MM-DD
+	ToolCalls  int    `json:"toolCalls"` // Tool calls counted on Day
+	Servers    int    `json:"servers"`
+	Interfaces int    `json:"interfaces"`
+	Quota      Quota  `json:"quota"` // Limits of the tenant, all zero if it has no quota
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Built-in detectors of redaction rules
+const (
+	RedactEmail      = "email"
+	RedactCreditCard = "creditCard"
+)
+
+// DefaultReplacement replaces redacted data when a rule sets no replacement
+const DefaultReplacement = "[REDACTED]"
+
+// RedactionRule hides sensitive data of tool results before they reach MCP clients. A rule
+// either replaces the JSON fields at a path, or the text matched by a pattern or a built-in
+// detector in every string of the result.
+type RedactionRule struct {
+	// Dot separated field names, "*" matches any field or element, e.g. "user.email" or "items.*.ssn".
+	// Arrays are traversed by the names of the fields of their elements.
+	Path        string `json:"path,omitempty" yaml:"path"`
+	Pattern     string `json:"pattern,omitempty" yaml:"pattern"`         // Regular expression of the text to replace
+	Builtin     string `json:"builtin,omitempty" yaml:"builtin"`         // email or creditCard
+	Replacement string `json:"replacement,omitempty" yaml:"replacement"` // Defaults to [REDACTED]
+}
+
+// Validate checks that the rule sets exactly one of path, pattern and builtin
+func (r RedactionRule) Validate() error {
+	set := 0
+	for _, value := range []string{r.Path, r.Pattern, r.Builtin} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("redaction rule must set one of path, pattern and builtin")
+	}
+	if r.Path != "" && strings.Contains("."+r.Path+".", "..") {
+		return fmt.Errorf("invalid redaction path '%s'", r.Path)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid redaction pattern '%s': %w", r.Pattern, err)
+		}
+	}
+	switch r.Builtin {
+	case "", RedactEmail, RedactCreditCard:
+	default:
+		return fmt.Errorf("unknown redaction builtin '%s', must be email or creditCard", r.Builtin)
+	}
+	return nil
+}
+
+// ReplacementText returns the text replacing redacted data
+func (r RedactionRule) ReplacementText() string {
+	if r.Replacement == "" {
+		return DefaultReplacement
+	}
+	return r.Replacement
+}
+
+// ValidateRedactions checks a list of redaction rules
+func ValidateRedactions(rules []RedactionRule) error {
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("redaction %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// Statuses of MCP server revisions
+const (
+	RevisionPending  = "pending"
+	RevisionApproved = "approved"
+	RevisionRejected = "rejected"
+)
+
+// Revision is a change of an active MCP server awaiting review. The server keeps serving its
+// current definition until an approver accepts the revision.
+type Revision struct {
+	ID          string     `json:"id"`
+	ServerID    string     `json:"serverId"`
+	ServerName  string     `json:"serverName"`
+	Namespace   string     `json:"namespace"`
+	Change      string     `json:"change"`            // Operation that submitted the revision, e.g. "update" or "tool weather"
+	BaseVersion int        `json:"baseVersion"`       // Version of the server the change was made against
+	Server      MCPServer  `json:"server"`            // Proposed definition
+	Status      string     `json:"status"`            // pending, approved or rejected
+	Comment     string     `json:"comment,omitempty"` // Given by the approver, e.g. the reason of a rejection
+	Version     int        `json:"version,omitempty"` // Version of the server created by the approval
+	SubmittedAt time.Time  `json:"submittedAt"`
+	ReviewedAt  *time.Time `json:"reviewedAt,omitempty"`
+}
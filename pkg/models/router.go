@@ -1,13 +1,18 @@
 package models
 
 import (
+	"fmt"
+	"regexp"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Router represents a routing configuration
 type Router struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name" binding:"required"`
+	Namespace   string    `json:"namespace"` // Team owning the router, set from the request
 	Description string    `json:"description"`
 	Rules       []Rule    `json:"rules"`
 	Version     int       `json:"version"`
@@ -21,9 +26,26 @@ type Rule struct {
 	ID         string      `json:"id"`
 	Path       string      `json:"path" binding:"required"` // Path pattern (e.g., /mcp-server/{name})
 	TargetType string      `json:"targetType" binding:"required,oneof=mcp-server http-backend"`
-	TargetID   string      `json:"targetId" binding:"required"` // ID of the MCP Server or HTTP backend
+	TargetID   string      `json:"targetId" binding:"required"` // ID of the MCP Server, or upstream name / base URL of the HTTP backend
 	Priority   int         `json:"priority"`                    // Higher priority rules are evaluated first
 	Conditions []Condition `json:"conditions,omitempty"`
+	Rewrite    *Rewrite    `json:"rewrite,omitempty"` // Actions applied before the request is forwarded
+}
+
+// Rewrite represents the path and header rewrite actions of a routing rule
+type Rewrite struct {
+	StripPrefix     string       `json:"stripPrefix,omitempty"`     // Prefix removed from the request path
+	PathRegex       string       `json:"pathRegex,omitempty"`       // Regular expression matched against the (stripped) path
+	PathReplacement string       `json:"pathReplacement,omitempty"` // Replacement for PathRegex, may reference groups like $1
+	RequestHeaders  *HeaderRules `json:"requestHeaders,omitempty"`  // Header changes applied to the forwarded request
+	ResponseHeaders *HeaderRules `json:"responseHeaders,omitempty"` // Header changes applied to the returned response
+}
+
+// HeaderRules represents header manipulations, applied in the order remove, set, add
+type HeaderRules struct {
+	Add    map[string]string `json:"add,omitempty"`
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
 }
 
 // Condition represents a condition for a routing rule
@@ -33,3 +55,32 @@ type Condition struct {
 	Operator string `json:"operator" binding:"required,oneof=eq neq contains regex"` // Operator for comparison
 	Value    string `json:"value" binding:"required"`                                // Value to compare against
 }
+
+// PrepareRules assigns IDs to new rules and validates their target types and regular expressions
+func (r *Router) PrepareRules() error {
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+		if rule.ID == "" {
+			rule.ID = uuid.New().String()
+		}
+
+		if rule.TargetType != "mcp-server" && rule.TargetType != "http-backend" {
+			return fmt.Errorf("rule %s: invalid target type '%s'", rule.ID, rule.TargetType)
+		}
+
+		if rule.Rewrite != nil && rule.Rewrite.PathRegex != "" {
+			if _, err := regexp.Compile(rule.Rewrite.PathRegex); err != nil {
+				return fmt.Errorf("rule %s: invalid path regex: %v", rule.ID, err)
+			}
+		}
+
+		for _, condition := range rule.Conditions {
+			if condition.Operator == "regex" {
+				if _, err := regexp.Compile(condition.Value); err != nil {
+					return fmt.Errorf("rule %s: invalid condition regex: %v", rule.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
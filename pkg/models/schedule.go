@@ -0,0 +1,145 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/schedule"
+)
+
+// ActivationSchedule activates and deactivates an MCP server at given times, e.g. for time-limited
+// integrations and maintenance windows. One-off times and cron expressions can be combined; the
+// latest activation or deactivation that is due wins.
+type ActivationSchedule struct {
+	ActivateAt     *time.Time `json:"activateAt,omitempty"`     // One-off activation
+	DeactivateAt   *time.Time `json:"deactivateAt,omitempty"`   // One-off deactivation, after activateAt if both are set
+	ActivateCron   string     `json:"activateCron,omitempty"`   // Cron expression of recurring activations, e.g. "0 8 * * mon-fri"
+	DeactivateCron string     `json:"deactivateCron,omitempty"` // Cron expression of recurring deactivations
+	Timezone       string     `json:"timezone,omitempty"`       // IANA time zone of the cron expressions, UTC by default
+}
+
+// ScheduleStatus is the schedule of an MCP server with its next activation and deactivation
+type ScheduleStatus struct {
+	ServerID         string              `json:"serverId"`
+	Status           string              `json:"status"`
+	Schedule         *ActivationSchedule `json:"schedule,omitempty"`
+	NextActivation   *time.Time          `json:"nextActivation,omitempty"`
+	NextDeactivation *time.Time          `json:"nextDeactivation,omitempty"`
+}
+
+// IsEmpty reports whether the schedule sets no activation nor deactivation
+func (s *ActivationSchedule) IsEmpty() bool {
+	return s == nil || (s.ActivateAt == nil && s.DeactivateAt == nil && s.ActivateCron == "" && s.DeactivateCron == "")
+}
+
+// Validate checks the cron expressions and time zone of the schedule. A nil schedule is valid.
+func (s *ActivationSchedule) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.IsEmpty() {
+		return fmt.Errorf("schedule must set an activation or a deactivation")
+	}
+	if s.ActivateAt != nil && s.DeactivateAt != nil && !s.DeactivateAt.After(*s.ActivateAt) {
+		return fmt.Errorf("schedule deactivateAt must be after activateAt")
+	}
+	for _, expr := range []string{s.ActivateCron, s.DeactivateCron} {
+		if expr == "" {
+			continue
+		}
+		cron, err := schedule.Parse(expr)
+		if err != nil {
+			return fmt.Errorf("schedule: %w", err)
+		}
+		if cron.Next(time.Now()).IsZero() {
+			return fmt.Errorf("schedule: cron expression '%s' never matches", expr)
+		}
+	}
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Location returns the time zone of the cron expressions
+func (s *ActivationSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone '%s'", s.Timezone)
+	}
+	return loc, nil
+}
+
+// location returns the time zone of the cron expressions, UTC if it is invalid
+func (s *ActivationSchedule) location() *time.Location {
+	loc, err := s.Location()
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// NextActivation returns the first activation of the schedule after t, nil if there is none
+func (s *ActivationSchedule) NextActivation(t time.Time) *time.Time {
+	return s.next(t, s.ActivateAt, s.ActivateCron)
+}
+
+// NextDeactivation returns the first deactivation of the schedule after t, nil if there is none
+func (s *ActivationSchedule) NextDeactivation(t time.Time) *time.Time {
+	return s.next(t, s.DeactivateAt, s.DeactivateCron)
+}
+
+// LastActivation returns the last activation of the schedule in (since, t], nil if there is none
+func (s *ActivationSchedule) LastActivation(since, t time.Time) *time.Time {
+	return s.last(since, t, s.ActivateAt, s.ActivateCron)
+}
+
+// LastDeactivation returns the last deactivation of the schedule in (since, t], nil if there is none
+func (s *ActivationSchedule) LastDeactivation(since, t time.Time) *time.Time {
+	return s.last(since, t, s.DeactivateAt, s.DeactivateCron)
+}
+
+// next returns the first of a one-off time and a cron time after t
+func (s *ActivationSchedule) next(t time.Time, at *time.Time, expr string) *time.Time {
+	var first *time.Time
+	if at != nil && at.After(t) {
+		first = at
+	}
+	if cron := s.cron(expr); cron != nil {
+		loc := s.location()
+		if next := cron.Next(t.In(loc)); !next.IsZero() && (first == nil || next.Before(*first)) {
+			first = &next
+		}
+	}
+	return first
+}
+
+// last returns the last of a one-off time and a cron time in (since, t]
+func (s *ActivationSchedule) last(since, t time.Time, at *time.Time, expr string) *time.Time {
+	var last *time.Time
+	if at != nil && at.After(since) && !at.After(t) {
+		last = at
+	}
+	if cron := s.cron(expr); cron != nil {
+		loc := s.location()
+		if prev := cron.Prev(t.In(loc), since.In(loc)); !prev.IsZero() && (last == nil || prev.After(*last)) {
+			last = &prev
+		}
+	}
+	return last
+}
+
+// cron parses a cron expression of the schedule, nil if it is not set or invalid
+func (s *ActivationSchedule) cron(expr string) *schedule.Cron {
+	if expr == "" {
+		return nil
+	}
+	cron, err := schedule.Parse(expr)
+	if err != nil {
+		return nil
+	}
+	return cron
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/schedule"
+)
+
+// SecretRotation replaces the value of a secret at given times, e.g. before an upstream API key
+// expires. At each rotation the value staged in advance is swapped in, or the webhook is called
+// and the value it returns is stored. With both, the staged value wins and the webhook is only
+// notified. One-off times and cron expressions can be combined.
+type SecretRotation struct {
+	At          *time.Time `json:"at,omitempty"`          // One-off rotation
+	Cron        string     `json:"cron,omitempty"`        // Cron expression of recurring rotations, e.g. "0 3 1 * *"
+	Timezone    string     `json:"timezone,omitempty"`    // IANA time zone of the cron expression, UTC by default
+	StagedValue string     `json:"stagedValue,omitempty"` // Value swapped in at the next rotation, never returned by the API
+	Staged      bool       `json:"staged"`                // Whether a value is staged, set by the API
+	WebhookURL  string     `json:"webhookUrl,omitempty"`  // Called with a POST at each rotation, may answer {"value": "..."}
+}
+
+// SecretReference is a use of a secret by name
+type SecretReference struct {
+	Kind        string `json:"kind"` // http-interface, tool, external-server or auth-passthrough
+	Namespace   string `json:"namespace"`
+	InterfaceID string `json:"interfaceId,omitempty"`
+	ServerID    string `json:"serverId,omitempty"`
+	Name        string `json:"name"` // Name of the interface, server, tool or external server
+}
+
+// Kinds of secret references
+const (
+	SecretRefHTTPInterface   = "http-interface"
+	SecretRefTool            = "tool"
+	SecretRefExternalServer  = "external-server"
+	SecretRefAuthPassthrough = "auth-passthrough"
+)
+
+// SecretRotationStatus is the rotation of a secret with its next time and the entities using it
+type SecretRotationStatus struct {
+	SecretID      string            `json:"secretId"`
+	Name          string            `json:"name"`
+	Rotation      *SecretRotation   `json:"rotation,omitempty"`
+	RotatedAt     *time.Time        `json:"rotatedAt,omitempty"`
+	RotationError string            `json:"rotationError,omitempty"`
+	NextRotation  *time.Time        `json:"nextRotation,omitempty"`
+	References    []SecretReference `json:"references"`
+}
+
+// Validate checks the times and the source of the new values of the rotation. A nil rotation is valid.
+func (r *SecretRotation) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.At == nil && r.Cron == "" {
+		return fmt.Errorf("rotation must set an at time or a cron expression")
+	}
+	if r.Cron != "" {
+		cron, err := schedule.Parse(r.Cron)
+		if err != nil {
+			return fmt.Errorf("rotation: %w", err)
+		}
+		if cron.Next(time.Now()).IsZero() {
+			return fmt.Errorf("rotation: cron expression '%s' never matches", r.Cron)
+		}
+	}
+	if _, err := r.location(); err != nil {
+		return err
+	}
+	if r.WebhookURL != "" {
+		parsed, err := url.Parse(r.WebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid rotation webhookUrl '%s'", r.WebhookURL)
+		}
+	}
+	if r.StagedValue == "" && r.WebhookURL == "" && r.Cron != "" {
+		return fmt.Errorf("recurring rotations need a webhookUrl returning the new values")
+	}
+	return nil
+}
+
+// location returns the time zone of the cron expression
+func (r *SecretRotation) location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rotation timezone '%s'", r.Timezone)
+	}
+	return loc, nil
+}
+
+// cron parses the cron expression, nil if it is not set or invalid
+func (r *SecretRotation) cron() (*schedule.Cron, *time.Location) {
+	if r.Cron == "" {
+		return nil, nil
+	}
+	cron, err := schedule.Parse(r.Cron)
+	if err != nil {
+		return nil, nil
+	}
+	loc, err := r.location()
+	if err != nil {
+		loc = time.UTC
+	}
+	return cron, loc
+}
+
+// Next returns the first rotation after t, nil if there is none
+func (r *SecretRotation) Next(t time.Time) *time.Time {
+	if r == nil {
+		return nil
+	}
+	var first *time.Time
+	if r.At != nil && r.At.After(t) {
+		first = r.At
+	}
+	if cron, loc := r.cron(); cron != nil {
+		if next := cron.Next(t.In(loc)); !next.IsZero() && (first == nil || next.Before(*first)) {
+			first = &next
+		}
+	}
+	return first
+}
+
+// Last returns the last rotation in (since, t], nil if there is none
+func (r *SecretRotation) Last(since, t time.Time) *time.Time {
+	if r == nil {
+		return nil
+	}
+	var last *time.Time
+	if r.At != nil && r.At.After(since) && !r.At.After(t) {
+		last = r.At
+	}
+	if cron, loc := r.cron(); cron != nil {
+		if prev := cron.Prev(t.In(loc), since.In(loc)); !prev.IsZero() && (last == nil || prev.After(*last)) {
+			last = &prev
+		}
+	}
+	return last
+}
+
+// SecretReferences returns the uses of the named secret by the interface
+func (h *HTTPInterface) SecretReferences(name string) []SecretReference {
+	if h.Auth == nil || h.Auth.Secret != name {
+		return nil
+	}
+	return []SecretReference{{Kind: SecretRefHTTPInterface, Namespace: h.Namespace, InterfaceID: h.ID, Name: h.Name}}
+}
+
+// SecretReferences returns the uses of the named secret by the tools, the external servers and
+// the Authorization passthrough of the server
+func (m *MCPServer) SecretReferences(name string) []SecretReference {
+	var refs []SecretReference
+	ref := func(kind, entity, interfaceID string) {
+		refs = append(refs, SecretReference{Kind: kind, Namespace: m.Namespace, ServerID: m.ID, InterfaceID: interfaceID, Name: entity})
+	}
+	if m.AuthPassthrough != nil && m.AuthPassthrough.Credential != nil && m.AuthPassthrough.Credential.Secret == name {
+		ref(SecretRefAuthPassthrough, m.Name, "")
+	}
+	for _, external := range m.External {
+		if external.Auth != nil && external.Auth.Secret == name {
+			ref(SecretRefExternalServer, external.Name, "")
+		}
+	}
+	for _, tool := range m.Tools {
+		if tool.Auth != nil && tool.Auth.Secret == name {
+			ref(SecretRefTool, tool.Name, tool.InterfaceID)
+		}
+		if tool.AuthPassthrough != nil && tool.AuthPassthrough.Credential != nil && tool.AuthPassthrough.Credential.Secret == name {
+			ref(SecretRefAuthPassthrough, tool.Name, tool.InterfaceID)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// serverNamePattern matches the names of new MCP servers: URL-safe slugs of lowercase letters,
+// digits and dashes, like namespaces
+var serverNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// ValidateServerName checks that the name of a new or renamed MCP server is a slug, since it
+// appears in the URLs of the server. Servers named before slugs were enforced keep their name.
+func ValidateServerName(name string) error {
+	if !serverNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid MCP server name '%s': use 1 to 63 lowercase letters, digits and dashes, starting and ending with a letter or digit", name)
+	}
+	return nil
+}
+
+// ServerAlias is a former name of a renamed MCP server. Requests using it are redirected to the
+// current name of the server until the alias expires, so that clients can migrate.
+type ServerAlias struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"` // Former name of the server
+	ServerID  string    `json:"serverId"`
+	CreatedAt time.Time `json:"createdAt"` // Time of the rename
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the alias expired at now
+func (a *ServerAlias) Expired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// MCPSession is a session of an MCP client with a server, issued at initialize on the Streamable
+// HTTP transport and selected by the Mcp-Session-Id header of the following requests. It keeps
+// the state the client would otherwise send with every request.
+type MCPSession struct {
+	ID              string    `json:"id"`
+	Namespace       string    `json:"namespace"`
+	ServerID        string    `json:"serverId"`
+	ServerName      string    `json:"serverName"`
+	ProtocolVersion string    `json:"protocolVersion"` // Revision negotiated at initialize
+	ClientName      string    `json:"clientName,omitempty"`
+	ClientVersion   string    `json:"clientVersion,omitempty"`
+	Caller          string    `json:"caller"`                // Client address at initialize
+	Environment     string    `json:"environment,omitempty"` // Environment of the tool calls without X-MCP-Environment
+	CookieJar       string    `json:"cookieJar"`             // Cookie jar of the tool calls without X-MCP-Cookie-Jar
+	CreatedAt       time.Time `json:"createdAt"`
+	LastSeenAt      time.Time `json:"lastSeenAt"`
+	ExpiresAt       time.Time `json:"expiresAt"` // Extended by every request of the session
+}
+
+// Expired reports whether the session expired at now
+func (s *MCPSession) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shadow sends a sample of the calls of a tool to another definition of it in the background,
+// e.g. the next version of its templates kept out of allowTools. Clients get the result of the
+// tool; the result of the shadow tool is only compared with it, and the differences are logged.
+type Shadow struct {
+	Tool    string  `json:"tool"`    // Name of the tool of the server called in the background
+	Percent float64 `json:"percent"` // Share of the calls also sent to the shadow tool, 0 to 100
+}
+
+// IsSet reports whether calls of the tool are shadowed
+func (s *Shadow) IsSet() bool {
+	return s != nil && s.Tool != "" && s.Percent > 0
+}
+
+// ValidateShadows checks that the shadow tools of the tools of the server exist and, since their
+// calls come on top of those of the clients, are idempotent GET tools calling an HTTP upstream
+func (m *MCPServer) ValidateShadows() error {
+	for _, tool := range m.Tools {
+		if tool.Shadow == nil {
+			continue
+		}
+		if tool.Shadow.Percent < 0 || tool.Shadow.Percent > 100 {
+			return fmt.Errorf("shadow of tool %s: percent must be between 0 and 100", tool.Name)
+		}
+		if tool.Shadow.Tool == tool.Name {
+			return fmt.Errorf("shadow of tool %s: a tool cannot shadow itself", tool.Name)
+		}
+		var shadow *Tool
+		for i := range m.Tools {
+			if m.Tools[i].Name == tool.Shadow.Tool {
+				shadow = &m.Tools[i]
+				break
+			}
+		}
+		if shadow == nil {
+			return fmt.Errorf("shadow of tool %s: tool %s not found", tool.Name, tool.Shadow.Tool)
+		}
+		if shadow.External != "" || shadow.Source != "" || shadow.IsChained() || shadow.IsWebSocket() {
+			return fmt.Errorf("shadow of tool %s: tool %s must call an HTTP interface", tool.Name, shadow.Name)
+		}
+		if !strings.EqualFold(shadow.RequestTemplate.Method, "GET") {
+			return fmt.Errorf("shadow of tool %s: tool %s must be an idempotent GET tool", tool.Name, shadow.Name)
+		}
+	}
+	return nil
+}
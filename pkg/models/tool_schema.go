@@ -0,0 +1,351 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// InputSchema returns the JSON Schema of the arguments of a tool generated from the HTTP
+// interface, shaped like the params of a tool call: path and query parameters are top-level
+// properties, headers go under "headers", cookies under "cookies" and the request body under "body".
+// The $defs of the param and body schemas are gathered at its root, where their references point.
+func (h *HTTPInterface) InputSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	defs := map[string]interface{}{}
+
+	headerProperties := map[string]interface{}{}
+	headersRequired := []string{}
+	for _, header := range h.Headers {
+		schema := map[string]interface{}{"type": header.Type}
+		if header.Description != "" {
+			schema["description"] = header.Description
+		}
+		if header.DefaultValue != "" {
+			schema["default"] = typedValue(header.Type, header.DefaultValue)
+		}
+		headerProperties[header.Name] = schema
+		if header.Required {
+			headersRequired = append(headersRequired, header.Name)
+		}
+	}
+
+	cookieProperties := map[string]interface{}{}
+	cookiesRequired := []string{}
+	for _, param := range h.Parameters {
+		schema := param.jsonSchema()
+		hoistDefs(schema, defs)
+		switch param.In {
+		case "header":
+			headerProperties[param.Name] = schema
+			if param.Required {
+				headersRequired = append(headersRequired, param.Name)
+			}
+			continue
+		case "cookie":
+			cookieProperties[param.Name] = schema
+			if param.Required {
+				cookiesRequired = append(cookiesRequired, param.Name)
+			}
+			continue
+		}
+		properties[param.Name] = schema
+		// Path parameters are always required, an unset one leaves a placeholder in the URL
+		if param.Required || param.In == "path" {
+			required = append(required, param.Name)
+		}
+	}
+
+	if len(headerProperties) > 0 {
+		properties["headers"] = objectSchema("HTTP headers to include in the request", headerProperties, headersRequired)
+		if len(headersRequired) > 0 {
+			required = append(required, "headers")
+		}
+	}
+	if len(cookieProperties) > 0 {
+		properties["cookies"] = objectSchema("Cookies to include in the request", cookieProperties, cookiesRequired)
+		if len(cookiesRequired) > 0 {
+			required = append(required, "cookies")
+		}
+	}
+
+	if h.RequestBody != nil {
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(h.RequestBody.Schema), &body); err != nil || body == nil {
+			body = map[string]interface{}{"type": "object"}
+		}
+		hoistDefs(body, defs)
+		if _, ok := body["description"]; !ok {
+			body["description"] = "Request body (" + h.RequestBody.ContentType + ")"
+		}
+		if h.RequestBody.Example != "" {
+			if _, ok := body["examples"]; !ok {
+				var example interface{}
+				if err := json.Unmarshal([]byte(h.RequestBody.Example), &example); err == nil {
+					body["examples"] = []interface{}{example}
+				}
+			}
+		}
+		properties["body"] = body
+		required = append(required, "body")
+	}
+
+	sort.Strings(required)
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	return schema
+}
+
+// objectSchema returns the schema of an object with the properties, listing the required ones
+func objectSchema(description string, properties map[string]interface{}, required []string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":        "object",
+		"description": description,
+		"properties":  properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// OutputSchema returns the JSON Schema of the result of a tool generated from the HTTP interface,
+// the body schema of its first successful response. It returns nil if no such response has a body.
+func (h *HTTPInterface) OutputSchema() map[string]interface{} {
+	var success *Response
+	for i := range h.Responses {
+		response := &h.Responses[i]
+		if response.StatusCode < 200 || response.StatusCode > 299 || response.Body == nil {
+			continue
+		}
+		if success == nil || response.StatusCode < success.StatusCode {
+			success = response
+		}
+	}
+	if success == nil {
+		return nil
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(success.Body.Schema), &schema); err != nil || schema == nil {
+		return nil
+	}
+	if _, ok := schema["description"]; !ok && success.Description != "" {
+		schema["description"] = success.Description
+	}
+	return schema
+}
+
+// jsonSchema returns the JSON Schema of the parameter. Its Schema, which may carry an enum,
+// a format or bounds, is completed with the type and description of the parameter.
+func (p Param) jsonSchema() map[string]interface{} {
+	var schema map[string]interface{}
+	if p.Schema != "" {
+		if err := json.Unmarshal([]byte(p.Schema), &schema); err != nil {
+			schema = nil
+		}
+	}
+	if schema == nil {
+		schema = map[string]interface{}{}
+	}
+	if _, ok := schema["type"]; !ok && p.Type != "" {
+		schema["type"] = p.Type
+	}
+	if _, ok := schema["description"]; !ok && p.Description != "" {
+		schema["description"] = p.Description
+	}
+	return schema
+}
+
+// typedValue converts a string value to the JSON type, keeping the string if it doesn't parse
+func typedValue(jsonType string, value string) interface{} {
+	switch jsonType {
+	case "integer":
+		if val, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return val
+		}
+	case "number":
+		if val, err := strconv.ParseFloat(value, 64); err == nil {
+			return val
+		}
+	case "boolean":
+		if val, err := strconv.ParseBool(value); err == nil {
+			return val
+		}
+	case "array", "object":
+		var val interface{}
+		if err := json.Unmarshal([]byte(value), &val); err == nil {
+			return val
+		}
+	}
+	return value
+}
+
+// ValidateJSON checks a value, such as the params of a tool call or a decoded response body,
+// against a JSON Schema and returns the problems found, sorted. It returns nil if the value matches.
+// JSON Schema 2020-12 schemas are checked as OpenAPI 3.0 schemas, see validationSchema.
+func ValidateJSON(schema map[string]interface{}, value interface{}) ([]string, error) {
+	data, err := json.Marshal(validationSchema(schema))
+	if err != nil {
+		return nil, err
+	}
+	var parsed openapi3.Schema
+	if err := parsed.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+
+	// Validate the JSON form of the value, so numbers are float64 as for a decoded request
+	data, err = json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	err = parsed.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil, nil
+	}
+	var problems []string
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		for _, item := range multi {
+			problems = append(problems, schemaProblem(item))
+		}
+	} else {
+		problems = append(problems, schemaProblem(err))
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// schemaProblem describes a schema validation error with the path of the offending value
+func schemaProblem(err error) string {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		reason := schemaErr.Reason
+		if reason == "" {
+			// kin-openapi gives no reason for a value matching a not
+			reason = fmt.Sprintf("doesn't match schema %q", schemaErr.SchemaField)
+		}
+		if path := schemaErr.JSONPointer(); len(path) > 0 {
+			return strings.Join(path, ".") + ": " + reason
+		}
+		return reason
+	}
+	return err.Error()
+}
+
+// ExampleValue returns an example of a JSON Schema: its example, the first of its examples, its
+// default, its const, the first value of its enum or else a placeholder of its type. The example of
+// an object holds its required properties and the optional ones having an example or a default.
+// References to the $defs of the schema are followed once per branch.
+func ExampleValue(schema map[string]interface{}) interface{} {
+	defs, _ := schema["$defs"].(map[string]interface{})
+	return exampleValue(schema, defs, map[string]bool{})
+}
+
+// exampleValue returns the example of a subschema for ExampleValue, expanding being the
+// definitions followed above it
+func exampleValue(schema map[string]interface{}, defs map[string]interface{}, expanding map[string]bool) interface{} {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, defsRef)
+		if target, ok := defs[name].(map[string]interface{}); ok && name != ref && !expanding[name] {
+			expanding[name] = true
+			defer delete(expanding, name)
+			return exampleValue(target, defs, expanding)
+		}
+		return nil
+	}
+
+	for _, key := range []string{"example", "default"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+		if key == "example" {
+			if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+				return examples[0]
+			}
+		}
+	}
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	switch schemaType(schema, "") {
+	case "object":
+		example := map[string]interface{}{}
+		properties, _ := schema["properties"].(map[string]interface{})
+		required := map[string]bool{}
+		switch names := schema["required"].(type) {
+		case []interface{}:
+			for _, name := range names {
+				if name, ok := name.(string); ok {
+					required[name] = true
+				}
+			}
+		case []string:
+			for _, name := range names {
+				required[name] = true
+			}
+		}
+		for name, property := range properties {
+			property, ok := property.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			_, hasExample := property["example"]
+			_, hasDefault := property["default"]
+			_, hasExamples := property["examples"]
+			_, hasConst := property["const"]
+			if required[name] || hasExample || hasDefault || hasExamples || hasConst {
+				example[name] = exampleValue(property, defs, expanding)
+			}
+		}
+		return example
+	case "array":
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			return []interface{}{exampleValue(items, defs, expanding)}
+		}
+		return []interface{}{}
+	case "integer", "number":
+		if minimum, ok := schema["minimum"].(float64); ok {
+			return minimum
+		}
+		return 1
+	case "boolean":
+		return true
+	case "string":
+		switch schema["format"] {
+		case "date":
+			return "2024-01-01"
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		}
+		return "example"
+	}
+	return nil
+}
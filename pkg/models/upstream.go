@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// Upstream represents a named group of backend targets.
+// Tool URLs whose host equals the upstream name (e.g. http://user-service/users/{id})
+// are resolved to one of the healthy targets at invocation time.
+type Upstream struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	Targets     []string    `json:"targets" binding:"required,min=1"` // Base URLs, e.g. https://10.0.0.1:8443
+	HealthCheck HealthCheck `json:"healthCheck"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+// HealthCheck represents the active health check configuration of an upstream
+type HealthCheck struct {
+	Path               string `json:"path"`               // Path probed on every target; empty disables active checks
+	IntervalSeconds    int    `json:"intervalSeconds"`    // Time between two probes
+	TimeoutSeconds     int    `json:"timeoutSeconds"`     // Timeout of a single probe
+	ExpectedStatus     int    `json:"expectedStatus"`     // Status code considered healthy
+	UnhealthyThreshold int    `json:"unhealthyThreshold"` // Consecutive failures before a target is marked unhealthy
+	HealthyThreshold   int    `json:"healthyThreshold"`   // Consecutive successes before a target is marked healthy again
+}
+
+// WithDefaults returns a copy of the health check with zero values replaced by defaults
+func (h HealthCheck) WithDefaults() HealthCheck {
+	if h.IntervalSeconds <= 0 {
+		h.IntervalSeconds = 10
+	}
+	if h.TimeoutSeconds <= 0 {
+		h.TimeoutSeconds = 2
+	}
+	if h.ExpectedStatus == 0 {
+		h.ExpectedStatus = 200
+	}
+	if h.UnhealthyThreshold <= 0 {
+		h.UnhealthyThreshold = 3
+	}
+	if h.HealthyThreshold <= 0 {
+		h.HealthyThreshold = 1
+	}
+	return h
+}
+
+// HTTP protocols of the calls to upstream hosts. Hosts without one negotiate HTTP/2 over TLS and
+// use HTTP/1.1 over cleartext TCP.
+const (
+	ProtocolHTTP1 = "http1" // HTTP/1.1 only, for upstreams that mishandle HTTP/2
+	ProtocolH2    = "h2"    // HTTP/2 over TLS, without falling back to HTTP/1.1
+	ProtocolH2C   = "h2c"   // HTTP/2 over cleartext TCP, with prior knowledge
+)
+
+// IsUpstreamProtocol reports whether protocol is an HTTP protocol of upstream hosts
+func IsUpstreamProtocol(protocol string) bool {
+	return protocol == ProtocolHTTP1 || protocol == ProtocolH2 || protocol == ProtocolH2C
+}
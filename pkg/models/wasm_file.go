@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// WasmFile represents an uploaded WebAssembly module
+type WasmFile struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ServerID  string    `json:"serverId,omitempty"` // Owner MCP server, empty for shared modules
+	Version   int       `json:"version"`            // Incremented for every upload with the same name and owner
+	Checksum  string    `json:"checksum"`           // Hex-encoded SHA-256 of the content
+	Size      int64     `json:"size"`
+	Path      string    `json:"-"` // Location of the content on disk
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Limits of WebSocket tools
+const (
+	DefaultWebSocketTimeout = 10 * time.Second
+	MaxWebSocketMessages    = 1000
+)
+
+// paramPlaceholder matches the {name} placeholders of request templates
+var paramPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_-]+)\}`)
+
+// WebSocketExchange makes a tool talk to the WebSocket upstream of its request template URL
+// (ws:// or wss://) instead of sending an HTTP request. The headers of the request template are
+// sent with the handshake, its body is sent as a text message once connected and the replies are
+// collected until enough were received or one contains the terminator.
+type WebSocketExchange struct {
+	Messages   int    `json:"messages,omitempty"`   // Replies collected, 1 by default, or up to 1000 with a terminator
+	Terminator string `json:"terminator,omitempty"` // Collecting stops after a reply containing it
+	TimeoutMs  int    `json:"timeoutMs,omitempty"`  // Time allowed for the whole exchange, 10000 by default
+}
+
+// IsWebSocket reports whether the tool exchanges messages with a WebSocket upstream
+func (t *Tool) IsWebSocket() bool {
+	return t.WebSocket != nil
+}
+
+// MessageLimit returns the number of replies after which collecting stops
+func (w *WebSocketExchange) MessageLimit() int {
+	switch {
+	case w.Messages > 0:
+		return w.Messages
+	case w.Terminator != "":
+		return MaxWebSocketMessages
+	default:
+		return 1
+	}
+}
+
+// Timeout returns the time allowed for the whole exchange
+func (w *WebSocketExchange) Timeout() time.Duration {
+	if w.TimeoutMs <= 0 {
+		return DefaultWebSocketTimeout
+	}
+	return time.Duration(w.TimeoutMs) * time.Millisecond
+}
+
+// ValidateWebSockets checks the URL and the collection settings of the WebSocket tools of the server
+func (m *MCPServer) ValidateWebSockets() error {
+	for _, tool := range m.Tools {
+		if !tool.IsWebSocket() {
+			continue
+		}
+		if tool.External != "" || tool.Source != "" || tool.IsChained() {
+			return fmt.Errorf("tool %s cannot both call a WebSocket upstream and forward its calls", tool.Name)
+		}
+		// The scheme of URLs starting with an environment variable is only known at call time
+		if !strings.HasPrefix(tool.RequestTemplate.URL, "{{env:") {
+			parsed, err := url.Parse(tool.RequestTemplate.URL)
+			if err != nil || (parsed.Scheme != "ws" && parsed.Scheme != "wss") || parsed.Host == "" {
+				return fmt.Errorf("tool %s: WebSocket URL '%s' must start with ws:// or wss://", tool.Name, tool.RequestTemplate.URL)
+			}
+		}
+		if tool.WebSocket.Messages < 0 || tool.WebSocket.Messages > MaxWebSocketMessages {
+			return fmt.Errorf("tool %s: messages must be between 0 and %d", tool.Name, MaxWebSocketMessages)
+		}
+		if tool.WebSocket.TimeoutMs < 0 {
+			return fmt.Errorf("tool %s: timeoutMs must not be negative", tool.Name)
+		}
+	}
+	return nil
+}
+
+// WebSocketInputSchema returns the JSON Schema of the params of a WebSocket tool, made of the
+// {name} placeholders of its URL and message
+func WebSocketInputSchema(tool Tool) map[string]interface{} {
+	names := map[string]bool{}
+	for _, template := range []string{tool.RequestTemplate.URL, tool.RequestTemplate.Body} {
+		for _, match := range paramPlaceholder.FindAllStringSubmatch(template, -1) {
+			names[match[1]] = true
+		}
+	}
+
+	properties := make(map[string]interface{}, len(names))
+	required := make([]string, 0, len(names))
+	for name := range names {
+		properties[name] = map[string]interface{}{}
+		required = append(required, name)
+	}
+	sort.Strings(required)
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
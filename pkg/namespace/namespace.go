@@ -0,0 +1,52 @@
+// Package namespace scopes HTTP interfaces, MCP servers and routers to the team owning them.
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// Header selects the namespace of an API request
+const Header = "X-MCP-Namespace"
+
+// Default is the namespace of requests without header and of resources created before namespaces
+const Default = "default"
+
+// validName matches namespace names: lowercase letters, digits and dashes, like DNS labels
+var validName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+type namespaceKey struct{}
+
+// With returns a copy of ctx scoped to the namespace
+func With(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, name)
+}
+
+// FromContext returns the namespace ctx is scoped to, false if it sees every namespace
+func FromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(namespaceKey{}).(string)
+	return name, ok
+}
+
+// Validate checks a namespace name
+func Validate(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid namespace '%s': must be at most 63 lowercase letters, digits and dashes, starting and ending with a letter or digit", name)
+	}
+	return nil
+}
+
+// OrDefault returns name, or Default if it is empty
+func OrDefault(name string) string {
+	if name == "" {
+		return Default
+	}
+	return name
+}
+
+// Unscoped returns a copy of ctx that sees every namespace, e.g. to look for the uses of
+// resources shared by all namespaces
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, nil)
+}
@@ -0,0 +1,237 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+const (
+	// Functions exported by plugins
+	exportAlloc      = "alloc"
+	exportOnRequest  = "on_request"
+	exportOnResponse = "on_response"
+
+	// hookTimeout bounds the execution of a single hook call
+	hookTimeout = time.Second
+
+	// memoryLimitPages caps plugin memory at 16MiB (64KiB pages)
+	memoryLimitPages = 256
+)
+
+// Request is the outgoing upstream request passed to the on_request hook
+type Request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+	Error   string            `json:"error,omitempty"` // Set by the plugin to reject the request
+}
+
+// Response is the upstream response passed to the on_response hook
+type Response struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+	Error      string            `json:"error,omitempty"` // Set by the plugin to fail the tool call
+}
+
+// ModuleStore looks up uploaded WASM modules
+type ModuleStore interface {
+	GetByID(ctx context.Context, id string) (*models.WasmFile, error)
+}
+
+// Host runs WASM plugins that transform tool requests and upstream responses.
+//
+// A plugin exports its linear memory, "alloc(size i32) i32" and at least one of
+// "on_request(ptr i32, len i32) i64" and "on_response(ptr i32, len i32) i64".
+// The hooks receive the JSON encoded Request or Response and return the
+// location of the transformed JSON packed as ptr<<32|len, or 0 to leave it unchanged.
+// Every call runs in a fresh module instance.
+type Host struct {
+	runtime wazero.Runtime
+	store   ModuleStore
+	modules map[string]wazero.CompiledModule // Keyed by checksum
+	mu      sync.Mutex
+}
+
+// NewHost creates a new plugin host loading modules from store
+func NewHost(ctx context.Context, store ModuleStore) (*Host, error) {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(memoryLimitPages).
+		WithCloseOnContextDone(true))
+
+	// Allow plugins built for WASI (TinyGo, Rust wasm32-wasi)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		runtime.Close(ctx)
+		return nil, err
+	}
+
+	// Expose gateway.log(level i32, ptr i32, len i32) to plugins
+	_, err := runtime.NewHostModuleBuilder("gateway").
+		NewFunctionBuilder().WithFunc(hostLog).Export("log").
+		Instantiate(ctx)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, err
+	}
+
+	return &Host{
+		runtime: runtime,
+		store:   store,
+		modules: make(map[string]wazero.CompiledModule),
+	}, nil
+}
+
+// Close releases the runtime and all compiled modules
+func (h *Host) Close(ctx context.Context) error {
+	return h.runtime.Close(ctx)
+}
+
+// OnRequest passes req through the on_request hook of each plugin in order
+func (h *Host) OnRequest(ctx context.Context, pluginIDs []string, req *Request) (*Request, error) {
+	for _, id := range pluginIDs {
+		out := *req
+		changed, err := h.call(ctx, id, exportOnRequest, req, &out)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", id, err)
+		}
+		if !changed {
+			continue
+		}
+		if out.Error != "" {
+			return nil, fmt.Errorf("plugin %s rejected request: %s", id, out.Error)
+		}
+		req = &out
+	}
+	return req, nil
+}
+
+// OnResponse passes resp through the on_response hook of each plugin in order
+func (h *Host) OnResponse(ctx context.Context, pluginIDs []string, resp *Response) (*Response, error) {
+	for _, id := range pluginIDs {
+		out := *resp
+		changed, err := h.call(ctx, id, exportOnResponse, resp, &out)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", id, err)
+		}
+		if !changed {
+			continue
+		}
+		if out.Error != "" {
+			return nil, fmt.Errorf("plugin %s rejected response: %s", id, out.Error)
+		}
+		resp = &out
+	}
+	return resp, nil
+}
+
+// call invokes hook of the plugin with the JSON encoding of in and decodes the result into out.
+// It returns false if the plugin does not export the hook or left the input unchanged.
+func (h *Host) call(ctx context.Context, id, hook string, in, out interface{}) (bool, error) {
+	compiled, err := h.compiledModule(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := compiled.ExportedFunctions()[hook]; !ok {
+		return false, nil
+	}
+
+	input, err := json.Marshal(in)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	// Reactor modules are initialized, WASI commands must not run main
+	mod, err := h.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return false, fmt.Errorf("failed to instantiate: %w", err)
+	}
+	defer mod.Close(ctx)
+
+	alloc := mod.ExportedFunction(exportAlloc)
+	if alloc == nil || mod.Memory() == nil {
+		return false, errors.New("plugin must export memory and alloc")
+	}
+
+	results, err := alloc.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return false, fmt.Errorf("alloc failed: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !mod.Memory().Write(ptr, input) {
+		return false, errors.New("alloc returned an out of range pointer")
+	}
+
+	results, err = mod.ExportedFunction(hook).Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return false, fmt.Errorf("%s failed: %w", hook, err)
+	}
+	if results[0] == 0 {
+		return false, nil
+	}
+
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0])
+	output, ok := mod.Memory().Read(outPtr, outLen)
+	if !ok {
+		return false, fmt.Errorf("%s returned an out of range result", hook)
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return false, fmt.Errorf("%s returned invalid JSON: %w", hook, err)
+	}
+
+	return true, nil
+}
+
+// compiledModule returns the compiled plugin module, compiling it on first use
+func (h *Host) compiledModule(ctx context.Context, id string) (wazero.CompiledModule, error) {
+	wasmFile, err := h.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load WASM file: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if compiled, ok := h.modules[wasmFile.Checksum]; ok {
+		return compiled, nil
+	}
+
+	content, err := os.ReadFile(wasmFile.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WASM file: %w", err)
+	}
+
+	compiled, err := h.runtime.CompileModule(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile WASM file: %w", err)
+	}
+	h.modules[wasmFile.Checksum] = compiled
+
+	slog.InfoContext(ctx, "Compiled WASM plugin", "id", wasmFile.ID, "name", wasmFile.Name, "version", wasmFile.Version)
+	return compiled, nil
+}
+
+// hostLog writes a plugin message to the gateway log
+func hostLog(ctx context.Context, mod api.Module, level, ptr, length uint32) {
+	message, ok := mod.Memory().Read(ptr, length)
+	if !ok {
+		return
+	}
+	slog.Log(ctx, slog.Level(int32(level)), "Plugin log", "message", string(message))
+}
@@ -0,0 +1,128 @@
+package quota
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// keyPrefix starts the generated API keys, so that they are recognized in configurations and logs
+const keyPrefix = "mgw_"
+
+// KeyTracker counts the tool calls of API keys and their cost per UTC day and month
+type KeyTracker struct {
+	keys repository.APIKeyRepository
+	now  func() time.Time
+}
+
+// NewKeyTracker creates a new API key tracker
+func NewKeyTracker(keys repository.APIKeyRepository) *KeyTracker {
+	return &KeyTracker{
+		keys: keys,
+		now:  time.Now,
+	}
+}
+
+// GenerateKey sets a new random key on an API key, with its prefix and hash
+func GenerateKey(key *models.APIKey) error {
+	random := make([]byte, 24)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+	key.Key = keyPrefix + hex.EncodeToString(random)
+	key.Prefix = key.Key[:len(keyPrefix)+6]
+	key.Hash = HashKey(key.Key)
+	return nil
+}
+
+// HashKey returns the hash an API key is stored and looked up by
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Resolve returns the API key a client presents, ErrNotFound if it is unknown
+func (t *KeyTracker) Resolve(ctx context.Context, key string) (*models.APIKey, error) {
+	return t.keys.GetByHash(ctx, HashKey(key))
+}
+
+// CountCall counts a tool call of cost for an API key, returning false if it would exceed the
+// daily or monthly quota of the key, and the cost the key may still spend
+func (t *KeyTracker) CountCall(ctx context.Context, key *models.APIKey, cost float64) (bool, models.QuotaRemaining, error) {
+	day, month := t.period()
+	counted, dayUsage, monthUsage, err := t.keys.CountCall(ctx, key.ID, day, month, cost, key.MaxCostPerDay, key.MaxCostPerMonth)
+	if err != nil {
+		return false, models.QuotaRemaining{Day: -1, Month: -1}, err
+	}
+	return counted, models.QuotaRemaining{
+		Day:   remaining(key.MaxCostPerDay, dayUsage.Cost),
+		Month: remaining(key.MaxCostPerMonth, monthUsage.Cost),
+	}, nil
+}
+
+// Usage returns the usage of an API key in a month (YYYY-MM), the current one if empty
+func (t *KeyTracker) Usage(ctx context.Context, key *models.APIKey, month string) (*models.APIKeyUsage, error) {
+	today, currentMonth := t.period()
+	if month == "" {
+		month = currentMonth
+	}
+	usage := &models.APIKeyUsage{
+		KeyID:           key.ID,
+		Name:            key.Name,
+		Month:           month,
+		Days:            []models.APIKeyPeriodUsage{},
+		MaxCostPerDay:   key.MaxCostPerDay,
+		MaxCostPerMonth: key.MaxCostPerMonth,
+	}
+
+	periods, err := t.keys.Usage(ctx, key.ID, month)
+	if err != nil {
+		return nil, err
+	}
+	var todayCost float64
+	for _, period := range periods {
+		if period.Period == month {
+			usage.Calls, usage.Cost = period.Calls, period.Cost
+			continue
+		}
+		usage.Days = append(usage.Days, period)
+		if period.Period == today {
+			todayCost = period.Cost
+		}
+	}
+
+	if month == currentMonth {
+		if key.MaxCostPerDay > 0 {
+			left := remaining(key.MaxCostPerDay, todayCost)
+			usage.RemainingDay = &left
+		}
+		if key.MaxCostPerMonth > 0 {
+			left := remaining(key.MaxCostPerMonth, usage.Cost)
+			usage.RemainingMonth = &left
+		}
+	}
+
+	return usage, nil
+}
+
+// remaining returns the cost left under a limit, -1 if it is unlimited
+func remaining(limit float64, cost float64) float64 {
+	if limit <= 0 {
+		return -1
+	}
+	if cost >= limit {
+		return 0
+	}
+	return limit - cost
+}
+
+// period returns the current UTC day and month
+func (t *KeyTracker) period() (string, string) {
+	now := t.now().UTC()
+	return now.Format(time.DateOnly), now.Format("2006-01")
+}
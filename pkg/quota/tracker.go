@@ -0,0 +1,84 @@
+// Package quota enforces the daily tool call quotas of tenants and the cost quotas of API keys,
+// and reports their usage.
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// Tracker counts the tool calls of tenants per UTC day. Tenants are namespaces.
+type Tracker struct {
+	quotas   repository.QuotaRepository
+	httpRepo repository.HTTPInterfaceRepository
+	mcpRepo  repository.MCPServerRepository
+	now      func() time.Time
+}
+
+// NewTracker creates a new tracker. The interface and server repositories must be
+// namespaced, they are used to count the resources of a tenant.
+func NewTracker(quotas repository.QuotaRepository, httpRepo repository.HTTPInterfaceRepository,
+	mcpRepo repository.MCPServerRepository) *Tracker {
+	return &Tracker{
+		quotas:   quotas,
+		httpRepo: httpRepo,
+		mcpRepo:  mcpRepo,
+		now:      time.Now,
+	}
+}
+
+// CountToolCall counts a tool call of the tenant, returning false if its daily quota is used up
+func (t *Tracker) CountToolCall(ctx context.Context, tenant string) (bool, error) {
+	limit := 0
+	quota, err := t.quotas.Get(ctx, tenant)
+	if err == nil {
+		limit = quota.MaxToolCallsPerDay
+	} else if err != repository.ErrNotFound {
+		return false, err
+	}
+
+	return t.quotas.CountToolCall(ctx, tenant, t.day(), limit)
+}
+
+// Usage returns today's tool calls, the resources and the quota of the tenant
+func (t *Tracker) Usage(ctx context.Context, tenant string) (*models.TenantUsage, error) {
+	usage := &models.TenantUsage{
+		Tenant: tenant,
+		Day:    t.day(),
+		Quota:  models.Quota{Tenant: tenant},
+	}
+
+	quota, err := t.quotas.Get(ctx, tenant)
+	if err == nil {
+		usage.Quota = *quota
+	} else if err != repository.ErrNotFound {
+		return nil, err
+	}
+
+	if usage.ToolCalls, err = t.quotas.ToolCalls(ctx, tenant, usage.Day); err != nil {
+		return nil, err
+	}
+
+	ctx = namespace.With(ctx, tenant)
+	interfaces, err := t.httpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage.Interfaces = len(interfaces)
+	servers, err := t.mcpRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage.Servers = len(servers)
+
+	return usage, nil
+}
+
+// day returns the current UTC day
+func (t *Tracker) day() string {
+	return t.now().UTC().Format(time.DateOnly)
+}
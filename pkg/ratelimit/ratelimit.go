@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is the number of tracked keys above which full buckets are dropped
+const maxIdleBuckets = 10000
+
+// bucket is the token bucket of a single key
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// State is the limit of a key at a point in time
+type State struct {
+	Limit      int           // Events allowed in a burst, 0 if limiting is disabled
+	Remaining  int           // Events allowed right now
+	Reset      time.Duration // Time until the bucket of the key is full again
+	RetryAfter time.Duration // Time until the next event is allowed, 0 if one is allowed now
+}
+
+// ResetSeconds returns the time until the bucket is full again in whole seconds, rounded up
+func (s State) ResetSeconds() int {
+	return ceilSeconds(s.Reset)
+}
+
+// RetryAfterSeconds returns the time until the next event is allowed in whole seconds, rounded up
+func (s State) RetryAfterSeconds() int {
+	return ceilSeconds(s.RetryAfter)
+}
+
+// ceilSeconds returns d in whole seconds, rounded up
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// Limiter limits the rate of events per key with token buckets.
+// The limit can be changed at runtime; a zero rate disables limiting.
+type Limiter struct {
+	rate    float64 // Tokens added per second
+	burst   float64 // Bucket capacity
+	buckets map[string]*bucket
+	mu      sync.Mutex
+}
+
+// NewLimiter creates a new limiter allowing rate events per second with the given burst
+func NewLimiter(rate float64, burst int) *Limiter {
+	l := &Limiter{buckets: make(map[string]*bucket)}
+	l.SetLimit(rate, burst)
+	return l
+}
+
+// SetLimit changes the rate and burst, keeping the current buckets.
+// A burst below one defaults to the rate rounded up.
+func (l *Limiter) SetLimit(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = rate
+	l.burst = float64(burst)
+	if burst < 1 {
+		l.burst = math.Max(1, math.Ceil(rate))
+	}
+}
+
+// Allow reports whether an event for key may happen now, consuming a token if so
+func (l *Limiter) Allow(key string) bool {
+	allowed, _ := l.Take(key)
+	return allowed
+}
+
+// Take reports whether an event for key may happen now, consuming a token if so, and returns the
+// state of the limit of key after the event
+func (l *Limiter) Take(key string) (bool, State) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return true, State{}
+	}
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+	if b.tokens < 1 {
+		return false, l.state(b.tokens)
+	}
+	b.tokens--
+	return true, l.state(b.tokens)
+}
+
+// Peek returns the state of the limit of key without consuming a token
+func (l *Limiter) Peek(key string) State {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return State{}
+	}
+	tokens := l.burst
+	if b, ok := l.buckets[key]; ok {
+		tokens = math.Min(l.burst, b.tokens+time.Since(b.updated).Seconds()*l.rate)
+	}
+	return l.state(tokens)
+}
+
+// state returns the state of a bucket holding tokens
+func (l *Limiter) state(tokens float64) State {
+	state := State{
+		Limit:     int(l.burst),
+		Remaining: int(tokens),
+		Reset:     time.Duration((l.burst - tokens) / l.rate * float64(time.Second)),
+	}
+	if tokens < 1 {
+		state.RetryAfter = time.Duration((1 - tokens) / l.rate * float64(time.Second))
+	}
+	return state
+}
+
+// prune drops the buckets that have refilled, they behave like new ones
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Package rotation replaces the values of secrets at their scheduled rotations, and finds the
+// HTTP interfaces and MCP servers using a secret so that rotations can be coordinated with the
+// teams owning them.
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+const (
+	// lookback bounds how far back due rotations are looked for, e.g. after the gateway was stopped
+	lookback = 24 * time.Hour
+	// webhookTimeout bounds the calls of rotation webhooks
+	webhookTimeout = 10 * time.Second
+	// maxWebhookResponse bounds the responses read from rotation webhooks
+	maxWebhookResponse = 1 << 20
+)
+
+// WebhookRequest is the body POSTed to the rotation webhook of a secret
+type WebhookRequest struct {
+	SecretID    string                   `json:"secretId"`
+	Name        string                   `json:"name"`
+	ScheduledAt time.Time                `json:"scheduledAt"`
+	Staged      bool                     `json:"staged"` // The staged value is swapped in, the response value is ignored
+	References  []models.SecretReference `json:"references"`
+}
+
+// WebhookResponse is the optional body of the response of a rotation webhook
+type WebhookResponse struct {
+	Value string `json:"value"` // New value of the secret
+}
+
+// Rotator rotates the secrets whose rotation is due
+type Rotator struct {
+	secrets    repository.SecretRepository
+	interfaces repository.HTTPInterfaceRepository
+	servers    repository.MCPServerRepository
+	publisher  repository.EventPublisher
+	client     *http.Client
+	mu         sync.Mutex // Serializes rotations
+}
+
+// NewRotator creates a rotator of the secrets of the repository, publishing the outcome of rotations
+func NewRotator(secrets repository.SecretRepository, interfaces repository.HTTPInterfaceRepository, servers repository.MCPServerRepository, publisher repository.EventPublisher) *Rotator {
+	return &Rotator{
+		secrets:    secrets,
+		interfaces: interfaces,
+		servers:    servers,
+		publisher:  publisher,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// References returns the uses of the named secret by the interfaces and MCP servers of every
+// namespace, since secrets are shared by all of them
+func (r *Rotator) References(ctx context.Context, name string) ([]models.SecretReference, error) {
+	ctx = namespace.Unscoped(ctx)
+	refs := []models.SecretReference{}
+	interfaces, err := r.interfaces.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range interfaces {
+		refs = append(refs, interfaces[i].SecretReferences(name)...)
+	}
+	servers, err := r.servers.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		refs = append(refs, servers[i].SecretReferences(name)...)
+	}
+	return refs, nil
+}
+
+// Status returns the rotation of the secret with its next time after now and its references
+func (r *Rotator) Status(ctx context.Context, secret *models.Secret, now time.Time) (models.SecretRotationStatus, error) {
+	refs, err := r.References(ctx, secret.Name)
+	if err != nil {
+		return models.SecretRotationStatus{}, err
+	}
+	return models.SecretRotationStatus{
+		SecretID:      secret.ID,
+		Name:          secret.Name,
+		Rotation:      Redact(secret.Rotation),
+		RotatedAt:     secret.RotatedAt,
+		RotationError: secret.RotationError,
+		NextRotation:  secret.Rotation.Next(now),
+		References:    refs,
+	}, nil
+}
+
+// RotateDue rotates the secrets whose rotation is due at now. Only the latest rotation since the
+// last change of a secret is applied, so that a secret changed by hand, or whose rotation failed,
+// waits for its next scheduled time.
+func (r *Rotator) RotateDue(ctx context.Context, now time.Time) error {
+	secrets, err := r.secrets.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Rotation == nil {
+			continue
+		}
+		since := secret.UpdatedAt
+		if earliest := now.Add(-lookback); since.Before(earliest) {
+			since = earliest
+		}
+		due := secret.Rotation.Last(since, now)
+		if due == nil {
+			continue
+		}
+		// Skip the secrets changed meanwhile, e.g. rotated by another gateway instance
+		current, err := r.secrets.GetByID(ctx, secret.ID)
+		if err != nil || !current.UpdatedAt.Equal(secret.UpdatedAt) {
+			continue
+		}
+		if err := r.rotate(ctx, secret, *due); err != nil {
+			slog.ErrorContext(ctx, "Failed to rotate secret", "id", secret.ID, "name", secret.Name, "scheduledAt", *due, "error", err)
+		}
+	}
+	return nil
+}
+
+// Rotate rotates the secret with the ID now, whether or not a rotation is due
+func (r *Rotator) Rotate(ctx context.Context, id string) (*models.Secret, error) {
+	secret, err := r.secrets.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Rotation == nil {
+		return nil, errors.New("the secret has no rotation")
+	}
+	if err := r.rotate(ctx, secret, time.Now()); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// rotate swaps the staged value of the secret in, or stores the value returned by its webhook,
+// and records the outcome on the secret
+func (r *Rotator) rotate(ctx context.Context, secret *models.Secret, scheduledAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	refs, err := r.References(ctx, secret.Name)
+	if err != nil {
+		return err
+	}
+
+	value, err := r.newValue(ctx, secret, scheduledAt, refs)
+	if err != nil {
+		secret.RotationError = err.Error()
+		if updateErr := r.secrets.Update(ctx, secret); updateErr != nil {
+			return updateErr
+		}
+		r.publish(ctx, models.EventSecretRotationFailed, secret, scheduledAt, refs)
+		return err
+	}
+
+	// The staged value is used once, the rotation is copied as the repository may share it
+	rotation := *secret.Rotation
+	rotation.StagedValue = ""
+	rotation.Staged = false
+	rotatedAt := time.Now()
+	secret.Value = value
+	secret.Rotation = &rotation
+	secret.RotatedAt = &rotatedAt
+	secret.RotationError = ""
+	if err := r.secrets.Update(ctx, secret); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Rotated secret", "id", secret.ID, "name", secret.Name, "scheduledAt", scheduledAt, "references", len(refs))
+	r.publish(ctx, models.EventSecretRotated, secret, scheduledAt, refs)
+	return nil
+}
+
+// newValue returns the value the secret is rotated to, calling its webhook if it has one
+func (r *Rotator) newValue(ctx context.Context, secret *models.Secret, scheduledAt time.Time, refs []models.SecretReference) (string, error) {
+	rotation := secret.Rotation
+	if rotation.WebhookURL == "" {
+		if rotation.StagedValue == "" {
+			return "", errors.New("no value is staged and the rotation has no webhookUrl")
+		}
+		return rotation.StagedValue, nil
+	}
+
+	body, err := json.Marshal(WebhookRequest{
+		SecretID:    secret.ID,
+		Name:        secret.Name,
+		ScheduledAt: scheduledAt,
+		Staged:      rotation.StagedValue != "",
+		References:  refs,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rotation.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("rotation webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponse))
+	if err != nil {
+		return "", fmt.Errorf("rotation webhook: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("rotation webhook returned status %d", resp.StatusCode)
+	}
+
+	if rotation.StagedValue != "" {
+		return rotation.StagedValue, nil
+	}
+	var response WebhookResponse
+	if err := json.Unmarshal(data, &response); err != nil || response.Value == "" {
+		return "", errors.New("rotation webhook returned no value and none is staged")
+	}
+	return response.Value, nil
+}
+
+// publish sends the event of a rotation, with its status as data
+func (r *Rotator) publish(ctx context.Context, eventType string, secret *models.Secret, scheduledAt time.Time, refs []models.SecretReference) {
+	if r.publisher == nil {
+		return
+	}
+	r.publisher.Publish(ctx, eventType, secret.ID, secret.Name, models.SecretRotationStatus{
+		SecretID:      secret.ID,
+		Name:          secret.Name,
+		Rotation:      Redact(secret.Rotation),
+		RotatedAt:     secret.RotatedAt,
+		RotationError: secret.RotationError,
+		NextRotation:  secret.Rotation.Next(scheduledAt),
+		References:    refs,
+	})
+}
+
+// Start rotates the due secrets every interval until stop is called
+func (r *Rotator) Start(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := r.RotateDue(context.Background(), now); err != nil {
+					slog.Error("Failed to rotate due secrets", "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Redact returns a copy of the rotation without its staged value, reporting whether one is staged
+func Redact(rotation *models.SecretRotation) *models.SecretRotation {
+	if rotation == nil {
+		return nil
+	}
+	redacted := *rotation
+	redacted.Staged = rotation.StagedValue != ""
+	redacted.StagedValue = ""
+	return &redacted
+}
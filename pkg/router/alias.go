@@ -0,0 +1,66 @@
+package router
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
+)
+
+// SetAliases redirects the requests using the former names of renamed servers
+func (r *MCPServerRouter) SetAliases(aliases repository.ServerAliasRepository) {
+	r.aliases = aliases
+}
+
+// RedirectRenamed answers a request naming a server by a former name with a 308 redirect to the
+// same URL with its current name, and reports whether it did. Clients repeat the request,
+// method and body included, at the new URL.
+func RedirectRenamed(c *gin.Context, aliases repository.ServerAliasRepository, servers repository.MCPServerRepository, name string) bool {
+	if aliases == nil {
+		return false
+	}
+	ctx := c.Request.Context()
+	owner, _ := namespace.FromContext(ctx)
+	alias, err := aliases.Get(ctx, namespace.OrDefault(owner), name)
+	if err != nil {
+		return false
+	}
+	if alias.Expired(time.Now()) {
+		aliases.Delete(ctx, alias.Namespace, alias.Name)
+		return false
+	}
+	server, err := servers.GetByID(ctx, alias.ServerID)
+	if err != nil {
+		return false
+	}
+
+	location := renamedURL(c, server.Name)
+	slog.InfoContext(ctx, "Redirecting the former name of a renamed MCP server", "name", name, "current", server.Name)
+	c.Header("Location", location)
+	apierror.RespondDetails(c, http.StatusPermanentRedirect, "server_renamed", "MCP server "+name+" was renamed to "+server.Name,
+		gin.H{"location": location})
+	return true
+}
+
+// renamedURL returns the URL of the request with the :name parameter of its route replaced
+func renamedURL(c *gin.Context, name string) string {
+	segments := strings.Split(c.FullPath(), "/")
+	for i, segment := range segments {
+		switch {
+		case segment == ":name":
+			segments[i] = name
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = c.Param(segment[1:])
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = strings.TrimPrefix(c.Param(segment[1:]), "/")
+		}
+	}
+	location := url.URL{Path: strings.Join(segments, "/"), RawQuery: c.Request.URL.RawQuery}
+	return location.String()
+}
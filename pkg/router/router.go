@@ -3,19 +3,26 @@ package router
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
 	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
 	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/namespace"
 )
 
 // MCPServerRouter handles routing requests to MCP servers
 type MCPServerRouter struct {
-	mcpRepo    repository.MCPServerRepository
-	mcpService *mcp.MCPService
+	mcpRepo        repository.MCPServerRepository
+	mcpService     *mcp.MCPService
+	sessions       repository.MCPSessionRepository // Sessions of the transport, nil if it is stateless
+	sessionTimeout time.Duration
+	aliases        repository.ServerAliasRepository // Former names of renamed servers, nil if not kept
 }
 
 // NewMCPServerRouter creates a new MCP server router
@@ -31,6 +38,22 @@ func (r *MCPServerRouter) RegisterRoutes(router *gin.Engine) {
 	// Main MCP server endpoint for dynamic routing by server name
 	mcpServerGroup := router.Group("/router/mcp-servers")
 	mcpServerGroup.Any("/:name/*path", r.HandleMCPServerByNameRequest)
+
+	// The same endpoint for clients unable to send the namespace header
+	namespacedGroup := router.Group("/router/namespaces/:namespace/mcp-servers")
+	namespacedGroup.Any("/:name/*path", r.HandleNamespacedMCPServerRequest)
+}
+
+// HandleNamespacedMCPServerRequest handles requests to MCP servers by their namespace and name
+func (r *MCPServerRouter) HandleNamespacedMCPServerRequest(c *gin.Context) {
+	name := c.Param("namespace")
+	if err := namespace.Validate(name); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.Request = c.Request.WithContext(namespace.With(c.Request.Context(), name))
+
+	r.HandleMCPServerByNameRequest(c)
 }
 
 // HandleMCPServerByNameRequest handles all requests to MCP servers by their name
@@ -38,51 +61,49 @@ func (r *MCPServerRouter) HandleMCPServerByNameRequest(c *gin.Context) {
 	serverName := c.Param("name")
 	path := c.Param("path")
 
-	fmt.Printf("INFO: Handling MCP server request by name: server=%s, path=%s\n", serverName, path)
+	slog.InfoContext(c.Request.Context(), "Handling MCP server request by name", "server", serverName, "path", path)
 
-	// Get all MCP servers
-	servers, err := r.mcpRepo.GetAll(c.Request.Context())
-	if err != nil {
-		fmt.Printf("ERROR: Failed to get MCP servers: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	// Find the server by name in the namespace of the request
+	targetServer, err := r.mcpRepo.GetByName(c.Request.Context(), serverName)
+	if err == repository.ErrNotFound {
+		if RedirectRenamed(c, r.aliases, r.mcpRepo, serverName) {
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "MCP server not found", "server", serverName)
+		apierror.Respond(c, http.StatusNotFound, "MCP server not found")
+		return
+	} else if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to get MCP server", "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// Find the server by name
-	var targetServer *models.MCPServer
-	for _, server := range servers {
-		if server.Name == serverName {
-			copyServer := server // Create a copy to avoid issues with loop variable
-			targetServer = &copyServer
-			break
-		}
-	}
+	r.ServeMCPServer(c, targetServer, path)
+}
 
-	if targetServer == nil {
-		fmt.Printf("ERROR: MCP server not found: %s\n", serverName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "MCP server not found"})
-		return
-	}
+// ServeMCPServer handles an MCP protocol request for the given server and sub-path
+func (r *MCPServerRouter) ServeMCPServer(c *gin.Context, targetServer *models.MCPServer, path string) {
+	serverName := targetServer.Name
 
 	// Check if server is active
 	if targetServer.Status != "active" {
-		fmt.Printf("ERROR: MCP server is not active: %s, status=%s\n", serverName, targetServer.Status)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "MCP server is not active"})
+		slog.ErrorContext(c.Request.Context(), "MCP server is not active", "server", serverName, "status", targetServer.Status)
+		apierror.Respond(c, http.StatusBadRequest, "MCP server is not active")
 		return
 	}
 
 	// Register server with MCP service if not already registered
 	server, err := r.mcpRepo.GetByID(c.Request.Context(), targetServer.ID)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to get MCP server: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		slog.ErrorContext(c.Request.Context(), "Failed to get MCP server", "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	err = r.mcpService.RegisterServer(server)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to register server with MCP service: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register server"})
+		slog.ErrorContext(c.Request.Context(), "Failed to register server with MCP service", "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Failed to register server")
 		return
 	}
 
@@ -93,7 +114,10 @@ func (r *MCPServerRouter) HandleMCPServerByNameRequest(c *gin.Context) {
 	}
 
 	// Route the request based on path
-	if path == "tools" && c.Request.Method == http.MethodGet {
+	if path == TransportPath {
+		// Handle MCP protocol messages
+		r.handleTransport(c, server)
+	} else if path == "tools" && c.Request.Method == http.MethodGet {
 		// Handle get tools request
 		r.handleGetTools(c, server)
 	} else if path == "resources" && c.Request.Method == http.MethodGet {
@@ -108,8 +132,8 @@ func (r *MCPServerRouter) HandleMCPServerByNameRequest(c *gin.Context) {
 		r.handleToolInvocation(c, server, toolName)
 	} else {
 		// Unknown path
-		fmt.Printf("ERROR: Unknown path: %s\n", path)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown path"})
+		slog.ErrorContext(c.Request.Context(), "Unknown path", "path", path)
+		apierror.Respond(c, http.StatusNotFound, "Unknown path")
 	}
 }
 
@@ -118,40 +142,27 @@ func (r *MCPServerRouter) handleGetTools(c *gin.Context, server *models.MCPServe
 	// Format tools according to MCP protocol specification
 	toolsResponse := make([]map[string]interface{}, 0, len(server.Tools))
 	for _, tool := range server.Tools {
-		// Create a properties map for the parameters
-		properties := make(map[string]interface{})
-		required := []string{}
-
-		// Extract parameters from URL path
-		url := tool.RequestTemplate.URL
-		urlParams := extractURLParams(url)
-		for _, param := range urlParams {
-			properties[param] = map[string]interface{}{
-				"type":        "string",
-				"description": fmt.Sprintf("Path parameter '%s'", param),
-			}
-			required = append(required, param)
+		if !tool.IsEnabled() {
+			continue
 		}
+		parameters := toolParameters(tool)
 
-		// Add query parameters if they can be inferred
-		if tool.RequestTemplate.Method == "POST" || tool.RequestTemplate.Method == "PUT" || tool.RequestTemplate.Method == "PATCH" {
-			properties["data"] = map[string]interface{}{
-				"type":        "object",
-				"description": "Request body data",
-			}
-			required = append(required, "data")
+		// Prefer the schema generated from the HTTP interface of the tool
+		inputSchema := tool.ExposedInputSchema(tool.InputSchema)
+		if inputSchema == nil {
+			inputSchema = parameters
 		}
 
-		// Define a schema object for the parameters
 		toolDef := map[string]interface{}{
-			"name":        tool.Name,
-			"description": tool.Description,
-			"parameters": map[string]interface{}{
-				"type":       "object",
-				"properties": properties,
-				"required":   required,
-			},
+			"name":        tool.ExposedName(),
+			"description": tool.ExposedDescription(),
+			"inputSchema": inputSchema,
+			"parameters":  parameters,
+		}
+		if tool.OutputSchema != nil {
+			toolDef["outputSchema"] = tool.OutputSchema
 		}
+		addDeprecation(toolDef, tool)
 
 		toolsResponse = append(toolsResponse, toolDef)
 	}
@@ -159,6 +170,39 @@ func (r *MCPServerRouter) handleGetTools(c *gin.Context, server *models.MCPServe
 	c.JSON(http.StatusOK, toolsResponse)
 }
 
+// toolParameters infers the parameters schema of a tool from its request template, as clients see it
+func toolParameters(tool models.Tool) map[string]interface{} {
+	// Create a properties map for the parameters
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	// Extract parameters from URL path
+	url := tool.RequestTemplate.URL
+	urlParams := extractURLParams(url)
+	for _, param := range urlParams {
+		properties[param] = map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Path parameter '%s'", param),
+		}
+		required = append(required, param)
+	}
+
+	// Add query parameters if they can be inferred
+	if tool.RequestTemplate.Method == "POST" || tool.RequestTemplate.Method == "PUT" || tool.RequestTemplate.Method == "PATCH" {
+		properties["data"] = map[string]interface{}{
+			"type":        "object",
+			"description": "Request body data",
+		}
+		required = append(required, "data")
+	}
+
+	return tool.ExposedInputSchema(map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	})
+}
+
 // handleGetResources handles requests to get resources metadata
 func (r *MCPServerRouter) handleGetResources(c *gin.Context, server *models.MCPServer) {
 	// For now, return an empty resources array as placeholder
@@ -173,42 +217,34 @@ func (r *MCPServerRouter) handleGetPrompts(c *gin.Context, server *models.MCPSer
 
 // handleToolInvocation handles tool invocation requests
 func (r *MCPServerRouter) handleToolInvocation(c *gin.Context, server *models.MCPServer, toolName string) {
-	fmt.Printf("INFO: Handling tool invocation: server=%s, tool=%s\n", server.Name, toolName)
+	slog.InfoContext(c.Request.Context(), "Handling tool invocation", "server", server.Name, "tool", toolName)
 
 	// Check if the tool exists and is allowed
-	toolExists := false
-	for _, allowed := range server.AllowTools {
-		if allowed == toolName {
-			toolExists = true
-			break
-		}
-	}
-
-	if !toolExists {
-		fmt.Printf("ERROR: Tool not found or not allowed: %s\n", toolName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tool not found or not allowed"})
+	if !server.AllowsTool(toolName) {
+		slog.ErrorContext(c.Request.Context(), "Tool not found or not allowed", "server", server.Name, "tool", toolName)
+		apierror.Respond(c, http.StatusNotFound, "Tool not found or not allowed")
 		return
 	}
 
 	// Get tool parameters
 	var params map[string]interface{}
 	if err := c.ShouldBindJSON(&params); err != nil {
-		fmt.Printf("WARNING: Could not parse request body, using empty params: %v\n", err)
+		slog.WarnContext(c.Request.Context(), "Could not parse request body, using empty params", "error", err)
 		params = make(map[string]interface{})
 	} else {
-		fmt.Printf("INFO: Parsed parameters: %v\n", params)
+		slog.InfoContext(c.Request.Context(), "Parsed parameters", "params", params)
 	}
 
 	// Execute the tool
-	fmt.Printf("INFO: Executing tool: server=%s, tool=%s\n", server.Name, toolName)
+	slog.InfoContext(c.Request.Context(), "Executing tool", "server", server.Name, "tool", toolName)
 	result, err := r.mcpService.HandleToolRequest(c.Request.Context(), server.ID, toolName, params)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to execute tool: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute tool: " + err.Error()})
+		slog.ErrorContext(c.Request.Context(), "Failed to execute tool", "server", server.Name, "tool", toolName, "error", err)
+		apierror.RespondCode(c, mcp.ErrorStatus(err), mcp.ErrorCode(err), "Failed to execute tool: "+err.Error())
 		return
 	}
 
-	fmt.Printf("INFO: Tool executed successfully\n")
+	slog.InfoContext(c.Request.Context(), "Tool executed successfully", "server", server.Name, "tool", toolName)
 
 	// Try to parse result as JSON
 	var jsonResult interface{}
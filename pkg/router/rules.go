@@ -0,0 +1,318 @@
+package router
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+	"github.com/wangfeng/mcp-gateway2/pkg/upstream"
+)
+
+// RuleRouter routes gateway requests according to the rules of active routers
+type RuleRouter struct {
+	routerRepo repository.RouterRepository
+	mcpRepo    repository.MCPServerRepository
+	mcpRouter  *MCPServerRouter
+	upstreams  *upstream.Manager
+	regexCache sync.Map // pattern -> *regexp.Regexp
+}
+
+// NewRuleRouter creates a new rule-based router
+func NewRuleRouter(routerRepo repository.RouterRepository, mcpRepo repository.MCPServerRepository, mcpRouter *MCPServerRouter, upstreams *upstream.Manager) *RuleRouter {
+	return &RuleRouter{
+		routerRepo: routerRepo,
+		mcpRepo:    mcpRepo,
+		mcpRouter:  mcpRouter,
+		upstreams:  upstreams,
+	}
+}
+
+// RegisterRoutes registers the gateway entry point served by routing rules
+func (r *RuleRouter) RegisterRoutes(router *gin.Engine) {
+	router.Any("/gateway/*path", r.HandleGatewayRequest)
+}
+
+// HandleGatewayRequest matches the request against the rules of all active routers
+// and forwards it to the target of the first matching rule
+func (r *RuleRouter) HandleGatewayRequest(c *gin.Context) {
+	path := c.Param("path")
+
+	routers, err := r.routerRepo.GetAll(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to get routers", "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Collect the rules of active routers, highest priority first
+	var rules []models.Rule
+	for _, router := range routers {
+		if router.Status == "active" {
+			rules = append(rules, router.Rules...)
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+
+	for _, rule := range rules {
+		pathParams, ok := matchPath(rule.Path, path)
+		if !ok || !r.matchConditions(c, rule.Conditions, pathParams) {
+			continue
+		}
+
+		slog.InfoContext(c.Request.Context(), "Gateway request matched rule", "path", path, "rule", rule.ID, "targetType", rule.TargetType, "target", rule.TargetID)
+		r.forward(c, rule, path)
+		return
+	}
+
+	slog.ErrorContext(c.Request.Context(), "No routing rule matches path", "path", path)
+	apierror.Respond(c, http.StatusNotFound, "No matching route")
+}
+
+// forward applies the rule's rewrite actions and sends the request to its target
+func (r *RuleRouter) forward(c *gin.Context, rule models.Rule, path string) {
+	rewrite := rule.Rewrite
+	if rewrite == nil {
+		rewrite = &models.Rewrite{}
+	}
+
+	rewrittenPath, err := r.RewritePath(rewrite, path)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to rewrite path", "path", path, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Invalid rewrite rule: "+err.Error())
+		return
+	}
+	if rewrittenPath != path {
+		slog.DebugContext(c.Request.Context(), "Rewrote path", "from", path, "to", rewrittenPath)
+	}
+
+	ApplyHeaderRules(c.Request.Header, rewrite.RequestHeaders)
+
+	switch rule.TargetType {
+	case "mcp-server":
+		server, err := r.mcpRepo.GetByID(c.Request.Context(), rule.TargetID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				apierror.Respond(c, http.StatusNotFound, "MCP server not found")
+				return
+			}
+			apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		if rewrite.ResponseHeaders != nil {
+			c.Writer = &headerRewriteWriter{ResponseWriter: c.Writer, rules: rewrite.ResponseHeaders}
+		}
+		r.mcpRouter.ServeMCPServer(c, server, rewrittenPath)
+	case "http-backend":
+		r.proxy(c, rule.TargetID, rewrittenPath, rewrite.ResponseHeaders)
+	default:
+		apierror.Respond(c, http.StatusInternalServerError, "Unsupported target type: "+rule.TargetType)
+	}
+}
+
+// proxy forwards the request to an HTTP backend given as upstream name or base URL
+func (r *RuleRouter) proxy(c *gin.Context, targetID string, path string, responseHeaders *models.HeaderRules) {
+	base := targetID
+	if !strings.Contains(targetID, "://") {
+		target, err := r.upstreams.Pick(targetID)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to pick upstream target", "upstream", targetID, "error", err)
+			apierror.Respond(c, http.StatusBadGateway, err.Error())
+			return
+		}
+		base = target
+	}
+
+	targetURL, err := url.Parse(base)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, "Invalid backend URL: "+base)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Path = path
+			pr.Out.URL.RawPath = ""
+			pr.SetURL(targetURL)
+			pr.SetXForwarded()
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			ApplyHeaderRules(resp.Header, responseHeaders)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			slog.ErrorContext(req.Context(), "Backend request failed", "method", req.Method, "url", req.URL.String(), "error", err)
+			apierror.Respond(c, http.StatusBadGateway, "Backend request failed: "+err.Error())
+		},
+	}
+
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// RewritePath applies the strip prefix and regex rewrite actions to a path
+func (r *RuleRouter) RewritePath(rewrite *models.Rewrite, path string) (string, error) {
+	if rewrite.StripPrefix != "" {
+		path = strings.TrimPrefix(path, rewrite.StripPrefix)
+	}
+
+	if rewrite.PathRegex != "" {
+		re, err := r.compile(rewrite.PathRegex)
+		if err != nil {
+			return "", err
+		}
+		path = re.ReplaceAllString(path, rewrite.PathReplacement)
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return path, nil
+}
+
+// compile compiles a regular expression, caching the result
+func (r *RuleRouter) compile(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := r.regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	r.regexCache.Store(pattern, re)
+
+	return re, nil
+}
+
+// matchConditions checks whether all conditions of a rule hold for the request
+func (r *RuleRouter) matchConditions(c *gin.Context, conditions []models.Condition, pathParams map[string]string) bool {
+	for _, condition := range conditions {
+		var actual string
+		switch condition.Type {
+		case "header":
+			actual = c.GetHeader(condition.Name)
+		case "query":
+			actual = c.Query(condition.Name)
+		case "path":
+			actual = pathParams[condition.Name]
+		case "method":
+			actual = c.Request.Method
+		}
+
+		switch condition.Operator {
+		case "eq":
+			if actual != condition.Value {
+				return false
+			}
+		case "neq":
+			if actual == condition.Value {
+				return false
+			}
+		case "contains":
+			if !strings.Contains(actual, condition.Value) {
+				return false
+			}
+		case "regex":
+			re, err := r.compile(condition.Value)
+			if err != nil || !re.MatchString(actual) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchPath matches a path against a rule pattern.
+// "{name}" matches a single segment and "*" as last segment matches the remainder.
+// e.g. "/billing/{version}/*" matches "/billing/v1/invoices/42" with version=v1
+func matchPath(pattern string, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	params := make(map[string]string)
+
+	for i, part := range patternParts {
+		if part == "*" && i == len(patternParts)-1 {
+			return params, true
+		}
+		if i >= len(pathParts) {
+			return nil, false
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			params[part[1:len(part)-1]] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+
+	if len(pathParts) != len(patternParts) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// ApplyHeaderRules applies remove, set and add header actions in that order
+func ApplyHeaderRules(header http.Header, rules *models.HeaderRules) {
+	if rules == nil {
+		return
+	}
+
+	for _, name := range rules.Remove {
+		header.Del(name)
+	}
+	for name, value := range rules.Set {
+		header.Set(name, value)
+	}
+	for name, value := range rules.Add {
+		header.Add(name, value)
+	}
+}
+
+// headerRewriteWriter applies response header rules right before the headers are written
+type headerRewriteWriter struct {
+	gin.ResponseWriter
+	rules   *models.HeaderRules
+	applied bool
+}
+
+func (w *headerRewriteWriter) apply() {
+	if !w.applied {
+		w.applied = true
+		ApplyHeaderRules(w.ResponseWriter.Header(), w.rules)
+	}
+}
+
+// WriteHeaderNow applies the header rules and writes the headers
+func (w *headerRewriteWriter) WriteHeaderNow() {
+	w.apply()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write applies the header rules and writes the body
+func (w *headerRewriteWriter) Write(data []byte) (int, error) {
+	w.apply()
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString applies the header rules and writes the body
+func (w *headerRewriteWriter) WriteString(s string) (int, error) {
+	w.apply()
+	return w.ResponseWriter.WriteString(s)
+}
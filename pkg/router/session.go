@@ -0,0 +1,159 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/logging"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// SessionHeader carries the session ID issued at initialize on the following requests
+const SessionHeader = "Mcp-Session-Id"
+
+// sessionTouchInterval bounds how often the expiry of a session is extended, so that a busy
+// session isn't written on every request
+const sessionTouchInterval = 30 * time.Second
+
+// rpcSessionNotFound is the JSON-RPC error of requests selecting an unknown or expired session
+const rpcSessionNotFound = -32001
+
+// SetSessions issues sessions at initialize on the transport, stored in sessions and expiring
+// when unused for idleTimeout. Without sessions the transport is stateless.
+func (r *MCPServerRouter) SetSessions(sessions repository.MCPSessionRepository, idleTimeout time.Duration) {
+	r.sessions = sessions
+	r.sessionTimeout = idleTimeout
+}
+
+// startSession issues a session for the initialize message of a client. The environment and the
+// cookie jar selected by the request become the state of the session; without a cookie jar the
+// session gets its own, so that upstream cookies are kept per client.
+func (r *MCPServerRouter) startSession(c *gin.Context, server *models.MCPServer, message rpcMessage) (*models.MCPSession, error) {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ClientInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"clientInfo"`
+	}
+	json.Unmarshal(message.Params, &params)
+
+	ctx := c.Request.Context()
+	now := time.Now()
+	session := &models.MCPSession{
+		Namespace:       server.Namespace,
+		ServerID:        server.ID,
+		ServerName:      server.Name,
+		ProtocolVersion: negotiateVersion(params.ProtocolVersion),
+		ClientName:      params.ClientInfo.Name,
+		ClientVersion:   params.ClientInfo.Version,
+		Caller:          mcp.Caller(ctx),
+		Environment:     mcp.EnvironmentName(ctx),
+		CookieJar:       mcp.CookieJarID(ctx),
+		LastSeenAt:      now,
+		ExpiresAt:       now.Add(r.sessionTimeout),
+	}
+	if err := r.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	if session.CookieJar == "" {
+		session.CookieJar = session.ID
+		if err := r.sessions.Update(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "Started MCP session", "session", session.ID, "server", server.Name, "client", session.ClientName)
+	return session, nil
+}
+
+// resumeSession returns the session selected by the request, extending its expiry. It answers
+// the request itself and returns nil if the session is unknown, expired or of another server.
+func (r *MCPServerRouter) resumeSession(c *gin.Context, server *models.MCPServer, id string) *models.MCPSession {
+	ctx := c.Request.Context()
+	now := time.Now()
+	session, err := r.sessions.GetByID(ctx, id)
+	if err != nil && err != repository.ErrNotFound {
+		slog.ErrorContext(ctx, "Failed to get MCP session", "session", id, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
+		return nil
+	}
+	if err == repository.ErrNotFound || session.ServerID != server.ID || session.Expired(now) {
+		// Clients start a new session with initialize when they get a 404
+		c.JSON(http.StatusNotFound, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcSessionNotFound, Message: "Session not found"}})
+		return nil
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		session.LastSeenAt = now
+		session.ExpiresAt = now.Add(r.sessionTimeout)
+		if err := r.sessions.Update(ctx, session); err != nil {
+			slog.WarnContext(ctx, "Failed to extend MCP session", "session", id, "error", err)
+		}
+	}
+	return session
+}
+
+// applySession selects the environment and the cookie jar of the session for the tool calls of
+// the request, unless the request selects its own with the headers
+func applySession(c *gin.Context, session *models.MCPSession) {
+	ctx := logging.With(c.Request.Context(), "session", session.ID)
+	if mcp.EnvironmentName(ctx) == "" && session.Environment != "" {
+		ctx = mcp.WithEnvironment(ctx, session.Environment)
+	}
+	if mcp.CookieJarID(ctx) == "" && session.CookieJar != "" {
+		ctx = mcp.WithCookieJar(ctx, session.CookieJar)
+	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Header(SessionHeader, session.ID)
+}
+
+// endSession terminates the session selected by a DELETE request
+func (r *MCPServerRouter) endSession(c *gin.Context, server *models.MCPServer) {
+	id := c.GetHeader(SessionHeader)
+	if id == "" {
+		apierror.Respond(c, http.StatusBadRequest, SessionHeader+" header is required")
+		return
+	}
+	session := r.resumeSession(c, server, id)
+	if session == nil {
+		return
+	}
+	if err := r.sessions.Delete(c.Request.Context(), session.ID); err != nil && err != repository.ErrNotFound {
+		slog.ErrorContext(c.Request.Context(), "Failed to delete MCP session", "session", id, "error", err)
+		apierror.Respond(c, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	slog.InfoContext(c.Request.Context(), "Ended MCP session", "session", id, "server", server.Name)
+	c.Status(http.StatusNoContent)
+}
+
+// StartSessionCleanup deletes the expired sessions every interval until stop is called
+func (r *MCPServerRouter) StartSessionCleanup(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				deleted, err := r.sessions.DeleteExpired(context.Background(), now)
+				if err != nil {
+					slog.Error("Failed to delete expired MCP sessions", "error", err)
+				} else if deleted > 0 {
+					slog.Info("Deleted expired MCP sessions", "deleted", deleted)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,259 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wangfeng/mcp-gateway2/pkg/apierror"
+	"github.com/wangfeng/mcp-gateway2/pkg/mcp"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// TransportPath is the sub-path of an MCP server serving the Streamable HTTP transport
+const TransportPath = "mcp"
+
+// protocolVersions are the MCP revisions supported by the transport, latest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// truncatedNote follows the partial upstream responses returned to models
+const truncatedNote = "The upstream response was cut off at the timeout, this result is partial."
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcMessage is a JSON-RPC 2.0 request or notification; notifications have no ID
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// handleTransport serves the MCP Streamable HTTP transport of a server. Each POST carries a
+// JSON-RPC message or batch and is answered with a JSON body; no stream is offered for
+// server-initiated messages. With sessions, initialize issues a session ID that the following
+// requests send in the Mcp-Session-Id header and a DELETE terminates. Requests without a session
+// ID are served statelessly.
+func (r *MCPServerRouter) handleTransport(c *gin.Context, server *models.MCPServer) {
+	if r.sessions != nil && c.Request.Method == http.MethodDelete {
+		r.endSession(c, server)
+		return
+	}
+	if c.Request.Method != http.MethodPost {
+		allow := http.MethodPost
+		if r.sessions != nil {
+			allow += ", " + http.MethodDelete
+		}
+		c.Header("Allow", allow)
+		apierror.Respond(c, http.StatusMethodNotAllowed, "The MCP endpoint only accepts "+allow)
+		return
+	}
+
+	var session *models.MCPSession
+	if id := c.GetHeader(SessionHeader); r.sessions != nil && id != "" {
+		if session = r.resumeSession(c, server, id); session == nil {
+			return
+		}
+		applySession(c, session)
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}})
+		return
+	}
+
+	// Accept a single message or a batch
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+	var messages []rpcMessage
+	if batch {
+		err = json.Unmarshal(body, &messages)
+	} else {
+		var message rpcMessage
+		err = json.Unmarshal(body, &message)
+		messages = []rpcMessage{message}
+	}
+	if err != nil || len(messages) == 0 {
+		message := "empty batch"
+		if err != nil {
+			message = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: message}})
+		return
+	}
+
+	responses := make([]rpcResponse, 0, len(messages))
+	for _, message := range messages {
+		// Notifications and responses to server requests are acknowledged without a reply
+		if len(message.ID) == 0 || message.Method == "" {
+			continue
+		}
+		if message.Method == "initialize" && r.sessions != nil && session == nil {
+			var err error
+			if session, err = r.startSession(c, server, message); err != nil {
+				slog.ErrorContext(c.Request.Context(), "Failed to start MCP session", "server", server.Name, "error", err)
+				apierror.Respond(c, http.StatusInternalServerError, "Failed to start session")
+				return
+			}
+			applySession(c, session)
+		}
+		responses = append(responses, r.handleRPC(c, server, message))
+	}
+
+	switch {
+	case len(responses) == 0:
+		c.Status(http.StatusAccepted)
+	case batch:
+		c.JSON(http.StatusOK, responses)
+	default:
+		c.JSON(http.StatusOK, responses[0])
+	}
+}
+
+// handleRPC answers a JSON-RPC request to a server
+func (r *MCPServerRouter) handleRPC(c *gin.Context, server *models.MCPServer, message rpcMessage) rpcResponse {
+	response := rpcResponse{JSONRPC: "2.0", ID: message.ID}
+	fail := func(code int, text string) rpcResponse {
+		response.Error = &rpcError{Code: code, Message: text}
+		return response
+	}
+	if message.JSONRPC != "2.0" {
+		return fail(rpcInvalidRequest, "jsonrpc must be 2.0")
+	}
+
+	switch message.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(message.Params, &params)
+		result := map[string]interface{}{
+			"protocolVersion": negotiateVersion(params.ProtocolVersion),
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+			"serverInfo":      map[string]interface{}{"name": server.Name, "version": strconv.Itoa(server.Version)},
+		}
+		if instructions := server.ServerInstructions(); instructions != "" {
+			result["instructions"] = instructions
+		}
+		response.Result = result
+	case "ping":
+		response.Result = map[string]interface{}{}
+	case "tools/list":
+		tools := make([]map[string]interface{}, 0, len(server.Tools))
+		for _, tool := range server.Tools {
+			if !slices.Contains(server.AllowTools, tool.Name) || !tool.IsEnabled() {
+				continue
+			}
+			inputSchema := tool.ExposedInputSchema(tool.InputSchema)
+			if inputSchema == nil {
+				inputSchema = toolParameters(tool)
+			}
+			definition := map[string]interface{}{
+				"name":        tool.ExposedName(),
+				"description": tool.ExposedDescription(),
+				"inputSchema": inputSchema,
+			}
+			addDeprecation(definition, tool)
+			tools = append(tools, definition)
+		}
+		response.Result = map[string]interface{}{"tools": tools}
+	case "tools/call":
+		var params struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments"`
+		}
+		if err := json.Unmarshal(message.Params, &params); err != nil || params.Name == "" {
+			return fail(rpcInvalidParams, "tools/call requires the tool name")
+		}
+		if !server.AllowsTool(params.Name) {
+			return fail(rpcInvalidParams, "Tool not found or not allowed: "+params.Name)
+		}
+		if params.Arguments == nil {
+			params.Arguments = map[string]interface{}{}
+		}
+
+		// Tool failures are results, so that the model can see them
+		var truncated bool
+		ctx := mcp.WithTruncation(c.Request.Context(), &truncated)
+		result, err := r.mcpService.HandleToolRequest(ctx, server.ID, params.Name, params.Arguments)
+		var toolResult map[string]interface{}
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to execute tool", "server", server.Name, "tool", params.Name, "error", err)
+			toolResult = map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": err.Error()}},
+				"isError": true,
+			}
+		} else {
+			content := []map[string]interface{}{{"type": "text", "text": result}}
+			if truncated {
+				content = append(content, map[string]interface{}{"type": "text", "text": truncatedNote})
+			}
+			toolResult = map[string]interface{}{
+				"content": content,
+				"isError": false,
+			}
+			if truncated {
+				toolResult["truncated"] = true
+			}
+		}
+		if tool := server.FindTool(params.Name); tool != nil && tool.Deprecated {
+			toolResult["warning"] = tool.DeprecationWarning()
+		}
+		response.Result = toolResult
+	default:
+		return fail(rpcMethodNotFound, "Method not found: "+message.Method)
+	}
+	return response
+}
+
+// negotiateVersion returns the requested protocol revision if supported, otherwise the latest one
+func negotiateVersion(requested string) string {
+	if slices.Contains(protocolVersions, requested) {
+		return requested
+	}
+	return protocolVersions[0]
+}
+
+// addDeprecation marks the definition of a deprecated tool listed to MCP clients: the warning is
+// appended to its description, so that models see it, and set in _meta with the sunset date
+func addDeprecation(definition map[string]interface{}, tool models.Tool) {
+	warning := tool.DeprecationWarning()
+	if warning == "" {
+		return
+	}
+	if description, _ := definition["description"].(string); description != "" {
+		definition["description"] = description + "\n\n" + warning
+	} else {
+		definition["description"] = warning
+	}
+	meta := map[string]interface{}{"deprecated": true, "warning": warning}
+	if tool.Sunset != nil {
+		meta["sunset"] = tool.Sunset.UTC().Format(time.RFC3339)
+	}
+	definition["_meta"] = meta
+}
@@ -0,0 +1,228 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// evalTimeout bounds the evaluation of a single script
+	evalTimeout = 100 * time.Millisecond
+
+	// costLimit bounds the work of a single script in CEL cost units
+	costLimit = 1000000
+
+	// interruptCheckFrequency is the number of comprehension iterations between timeout checks
+	interruptCheckFrequency = 100
+)
+
+var valueType = reflect.TypeOf(&structpb.Value{})
+
+// Engine evaluates the CEL pre and post scripts of tools.
+//
+// A pre script sees the tool parameters as `params` and returns a map with
+// optional `params` (merged into the parameters, null removes a parameter)
+// and `headers` (set on the outgoing request) entries.
+// A post script sees the upstream response as `response` (decoded JSON, or a
+// string if the body is not JSON), `status` and `headers`; its result replaces
+// the response body, encoded as JSON unless it is a string.
+type Engine struct {
+	preEnv   *cel.Env
+	postEnv  *cel.Env
+	programs map[string]cel.Program // Keyed by env and source
+	mu       sync.Mutex
+}
+
+// NewEngine creates a new script engine
+func NewEngine() (*Engine, error) {
+	preEnv, err := newEnv(
+		cel.Variable("params", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	postEnv, err := newEnv(
+		cel.Variable("response", cel.DynType),
+		cel.Variable("status", cel.IntType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		preEnv:   preEnv,
+		postEnv:  postEnv,
+		programs: make(map[string]cel.Program),
+	}, nil
+}
+
+// newEnv creates a CEL environment with the standard extensions and the given variables
+func newEnv(variables ...cel.EnvOption) (*cel.Env, error) {
+	options := []cel.EnvOption{
+		ext.Strings(),
+		ext.Encoders(),
+		ext.Math(),
+		ext.Lists(),
+		ext.Sets(),
+		ext.Bindings(),
+		ext.TwoVarComprehensions(),
+	}
+	return cel.NewEnv(append(options, variables...)...)
+}
+
+// Validate compiles the given scripts, reporting syntax and type errors
+func (e *Engine) Validate(preScript, postScript string) error {
+	if preScript != "" {
+		if _, err := e.program(e.preEnv, "pre", preScript); err != nil {
+			return fmt.Errorf("invalid preScript: %w", err)
+		}
+	}
+	if postScript != "" {
+		if _, err := e.program(e.postEnv, "post", postScript); err != nil {
+			return fmt.Errorf("invalid postScript: %w", err)
+		}
+	}
+	return nil
+}
+
+// RunPre evaluates a pre script, updating params in place and returning the headers to set
+func (e *Engine) RunPre(ctx context.Context, src string, params map[string]interface{}) (map[string]string, error) {
+	prg, err := e.program(e.preEnv, "pre", src)
+	if err != nil {
+		return nil, fmt.Errorf("preScript: %w", err)
+	}
+
+	result, err := eval(ctx, prg, map[string]interface{}{"params": params})
+	if err != nil {
+		return nil, fmt.Errorf("preScript: %w", err)
+	}
+
+	output, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("preScript must return a map, got %T", result)
+	}
+
+	headers := map[string]string{}
+	for key, value := range output {
+		switch key {
+		case "params":
+			updates, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("preScript: params must be a map")
+			}
+			for name, param := range updates {
+				if param == nil {
+					delete(params, name)
+				} else {
+					params[name] = param
+				}
+			}
+		case "headers":
+			updates, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("preScript: headers must be a map")
+			}
+			for name, header := range updates {
+				headers[name] = fmt.Sprintf("%v", header)
+			}
+		default:
+			return nil, fmt.Errorf("preScript returned unknown key %q", key)
+		}
+	}
+
+	return headers, nil
+}
+
+// RunPost evaluates a post script, returning the new response body
+func (e *Engine) RunPost(ctx context.Context, src string, status int, headers map[string]string, body []byte) ([]byte, error) {
+	prg, err := e.program(e.postEnv, "post", src)
+	if err != nil {
+		return nil, fmt.Errorf("postScript: %w", err)
+	}
+
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		response = string(body)
+	}
+
+	result, err := eval(ctx, prg, map[string]interface{}{
+		"response": response,
+		"status":   status,
+		"headers":  headers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postScript: %w", err)
+	}
+
+	if s, ok := result.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(result)
+}
+
+// program returns the compiled program of src, compiling it on first use
+func (e *Engine) program(env *cel.Env, kind, src string) (cel.Program, error) {
+	key := kind + "\x00" + src
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if prg, ok := e.programs[key]; ok {
+		return prg, nil
+	}
+
+	ast, issues := env.Compile(src)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	prg, err := env.Program(ast,
+		cel.CostLimit(costLimit),
+		cel.InterruptCheckFrequency(interruptCheckFrequency))
+	if err != nil {
+		return nil, err
+	}
+	e.programs[key] = prg
+
+	return prg, nil
+}
+
+// eval runs prg within the evaluation timeout and converts the result to plain Go values
+func eval(ctx context.Context, prg cel.Program, vars map[string]interface{}) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, evalTimeout)
+	defer cancel()
+
+	val, _, err := prg.ContextEval(ctx, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return toNative(val)
+}
+
+// toNative converts a CEL value to the values produced by encoding/json
+func toNative(val ref.Val) (interface{}, error) {
+	if val == types.NullValue {
+		return nil, nil
+	}
+
+	native, err := val.ConvertToNative(valueType)
+	if err != nil {
+		return nil, err
+	}
+
+	return native.(*structpb.Value).AsInterface(), nil
+}
@@ -0,0 +1,159 @@
+// Package seed creates fixture resources on demand, such as example HTTP interfaces and a demo
+// MCP server, from packs embedded in the binary.
+package seed
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/wangfeng/mcp-gateway2/internal/repository"
+	"github.com/wangfeng/mcp-gateway2/pkg/gitops"
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+// ErrUnknownPack is returned for pack names that are not defined
+var ErrUnknownPack = errors.New("unknown seed pack")
+
+//go:embed packs/*.yaml
+var packFiles embed.FS
+
+// Pack is a named set of fixture resources, stored as the bundle packs/<name>.yaml
+type Pack struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Requires    []string `json:"requires,omitempty"` // Packs seeded first, whose resources this one uses
+}
+
+// packs lists the available packs in the order they are seeded
+var packs = []Pack{
+	{Name: "examples", Description: "HTTP interfaces of public APIs: get-user (randomuser.me) and get-weather (OpenWeatherMap, needs an API key)"},
+	{Name: "demo", Description: "Active MCP server demo with a get-user tool", Requires: []string{"examples"}},
+}
+
+// Packs returns the available packs
+func Packs() []Pack {
+	return append([]Pack(nil), packs...)
+}
+
+// Seeder creates the resources of packs that do not exist yet
+type Seeder struct {
+	reconciler *gitops.Reconciler
+}
+
+// NewSeeder creates a seeder applying packs with the reconciler
+func NewSeeder(reconciler *gitops.Reconciler) *Seeder {
+	return &Seeder{reconciler: reconciler}
+}
+
+// Seed creates the resources of the named packs, and of the packs they require, in the namespace
+// of ctx. Resources that already exist are left alone, even if they differ from the pack, so that
+// seeding twice changes nothing. With dryRun, the creations are only returned.
+func (s *Seeder) Seed(ctx context.Context, names []string, dryRun bool) ([]gitops.Change, error) {
+	bundle, err := load(names)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.reconciler.Diff(ctx, bundle, false)
+	if err != nil {
+		return nil, err
+	}
+	missing := &gitops.Bundle{}
+	creates := []gitops.Change{}
+	for _, change := range changes {
+		if change.Action != gitops.ActionCreate {
+			continue
+		}
+		creates = append(creates, change)
+		switch change.Kind {
+		case gitops.KindHTTPInterface:
+			for _, httpInterface := range bundle.Interfaces {
+				if httpInterface.Name == change.Name {
+					missing.Interfaces = append(missing.Interfaces, httpInterface)
+				}
+			}
+		case gitops.KindMCPServer:
+			for _, server := range bundle.Servers {
+				if server.Name == change.Name {
+					missing.Servers = append(missing.Servers, server)
+				}
+			}
+		case gitops.KindRouter:
+			for _, router := range bundle.Routers {
+				if router.Name == change.Name {
+					missing.Routers = append(missing.Routers, router)
+				}
+			}
+		}
+	}
+	if dryRun || len(creates) == 0 {
+		return creates, nil
+	}
+
+	slog.InfoContext(ctx, "Seeding fixture resources", "packs", names, "resources", len(creates))
+	note := &models.ChangeNote{Author: "seed", Message: "Seeded the fixture packs " + strings.Join(names, ", ")}
+	return s.reconciler.Apply(repository.WithDefaultChangeNote(ctx, note), missing, false)
+}
+
+// load merges the bundles of the named packs and of the packs they require, each pack once
+func load(names []string) (*gitops.Bundle, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no seed pack selected")
+	}
+	selected := make(map[string]bool)
+	var visit func(name string) error
+	visit = func(name string) error {
+		pack, ok := findPack(name)
+		if !ok {
+			return fmt.Errorf("%w '%s'", ErrUnknownPack, name)
+		}
+		if selected[name] {
+			return nil
+		}
+		selected[name] = true
+		for _, required := range pack.Requires {
+			if err := visit(required); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle := &gitops.Bundle{}
+	for _, pack := range packs {
+		if !selected[pack.Name] {
+			continue
+		}
+		data, err := packFiles.ReadFile("packs/" + pack.Name + ".yaml")
+		if err != nil {
+			return nil, err
+		}
+		part, err := gitops.ParseBundle(data)
+		if err != nil {
+			return nil, fmt.Errorf("seed pack %s: %w", pack.Name, err)
+		}
+		bundle.Interfaces = append(bundle.Interfaces, part.Interfaces...)
+		bundle.Servers = append(bundle.Servers, part.Servers...)
+		bundle.Routers = append(bundle.Routers, part.Routers...)
+	}
+	return bundle, bundle.Validate()
+}
+
+// findPack returns the pack of a name
+func findPack(name string) (Pack, bool) {
+	for _, pack := range packs {
+		if pack.Name == name {
+			return pack, true
+		}
+	}
+	return Pack{}, false
+}
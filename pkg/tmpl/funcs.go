@@ -0,0 +1,368 @@
+package tmpl
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/tidwall/gjson"
+)
+
+// funcs are the helpers available in templates. As in pipelines the value comes last, e.g.
+// {{ .name | replace " " "-" }}.
+var funcs = template.FuncMap{
+	// Dates
+	"now":        time.Now,
+	"formatTime": formatTime,
+	"parseTime":  parseTime,
+	"unixTime":   unixTime,
+	"addTime":    addTime,
+
+	// Strings
+	"upper":     func(v interface{}) string { return strings.ToUpper(toString(v)) },
+	"lower":     func(v interface{}) string { return strings.ToLower(toString(v)) },
+	"title":     title,
+	"trim":      func(v interface{}) string { return strings.TrimSpace(toString(v)) },
+	"replace":   func(old, new string, v interface{}) string { return strings.ReplaceAll(toString(v), old, new) },
+	"camelCase": camelCase,
+	"snakeCase": func(v interface{}) string { return strings.Join(lowerWords(v), "_") },
+	"kebabCase": func(v interface{}) string { return strings.Join(lowerWords(v), "-") },
+	"default":   defaultValue,
+
+	// Encoding
+	"b64enc":     func(v interface{}) string { return base64.StdEncoding.EncodeToString([]byte(toString(v))) },
+	"b64dec":     b64dec,
+	"urlEncode":  func(v interface{}) string { return url.QueryEscape(toString(v)) },
+	"urlDecode":  func(v interface{}) (string, error) { return url.QueryUnescape(toString(v)) },
+	"pathEscape": func(v interface{}) string { return url.PathEscape(toString(v)) },
+
+	// Hashing, hex encoded
+	"md5":        md5Hex,
+	"sha1":       sha1Hex,
+	"sha256":     sha256Hex,
+	"hmacSHA256": hmacSHA256,
+
+	// JSON
+	"toJSON":   toJSON,
+	"jsonPath": jsonPath,
+
+	// Arithmetic
+	"add":   add,
+	"sub":   sub,
+	"mul":   mul,
+	"div":   div,
+	"mod":   mod,
+	"round": round,
+}
+
+// toString formats a value for the string helpers, nil as empty
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toFloat converts numbers and numeric strings for the arithmetic helpers
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	default:
+		return 0, fmt.Errorf("not a number: %v", v)
+	}
+}
+
+// number returns integral results as integers, so that they render without exponent
+func number(f float64) interface{} {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	return f
+}
+
+// toTime converts times, RFC 3339 strings and Unix seconds
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		return time.Parse(time.RFC3339, t)
+	default:
+		seconds, err := toFloat(v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("not a time: %v", v)
+		}
+		whole, frac := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+	}
+}
+
+// formatTime formats a time with a Go layout, or "unix" and "unixMilli"
+func formatTime(layout string, v interface{}) (string, error) {
+	t, err := toTime(v)
+	if err != nil {
+		return "", err
+	}
+	switch layout {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case "unixMilli":
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	}
+	return t.Format(layout), nil
+}
+
+// parseTime parses a time with a Go layout
+func parseTime(layout string, v interface{}) (time.Time, error) {
+	return time.Parse(layout, toString(v))
+}
+
+// unixTime returns the Unix seconds of a time
+func unixTime(v interface{}) (int64, error) {
+	t, err := toTime(v)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// addTime adds a Go duration such as "-24h" or "90m" to a time
+func addTime(duration string, v interface{}) (time.Time, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(d), nil
+}
+
+// words splits a value into words at separators and case changes, e.g. "userID list" into
+// user, ID and list
+func words(v interface{}) []string {
+	var result []string
+	var current []rune
+	runes := []rune(toString(v))
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		// A new word starts at an upper case letter after a lower case one, or before a lower
+		// case one in a run of upper case letters
+		if len(current) > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || unicode.IsUpper(previous) && nextLower {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// lowerWords returns the words of a value in lower case
+func lowerWords(v interface{}) []string {
+	list := words(v)
+	for i, word := range list {
+		list[i] = strings.ToLower(word)
+	}
+	return list
+}
+
+// capitalize upper cases the first letter of a lower case word
+func capitalize(word string) string {
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// title capitalizes the words of a value, keeping the separators
+func title(v interface{}) string {
+	runes := []rune(toString(v))
+	start := true
+	for i, r := range runes {
+		if start && unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+		}
+		start = unicode.IsSpace(r)
+	}
+	return string(runes)
+}
+
+// camelCase joins the words of a value as in userIdList
+func camelCase(v interface{}) string {
+	list := lowerWords(v)
+	for i := 1; i < len(list); i++ {
+		list[i] = capitalize(list[i])
+	}
+	return strings.Join(list, "")
+}
+
+// defaultValue returns the fallback for nil, empty strings and missing params
+func defaultValue(fallback, v interface{}) interface{} {
+	if v == nil || v == "" {
+		return fallback
+	}
+	return v
+}
+
+// b64dec decodes standard or URL base64, padded or not
+func b64dec(v interface{}) (string, error) {
+	s := strings.TrimRight(toString(v), "=")
+	for _, encoding := range []*base64.Encoding{base64.RawStdEncoding, base64.RawURLEncoding} {
+		if data, err := encoding.DecodeString(s); err == nil {
+			return string(data), nil
+		}
+	}
+	return "", errors.New("b64dec: invalid base64")
+}
+
+// md5Hex returns the hex MD5 of a value
+func md5Hex(v interface{}) string {
+	sum := md5.Sum([]byte(toString(v)))
+	return hex.EncodeToString(sum[:])
+}
+
+// sha1Hex returns the hex SHA-1 of a value
+func sha1Hex(v interface{}) string {
+	sum := sha1.Sum([]byte(toString(v)))
+	return hex.EncodeToString(sum[:])
+}
+
+// sha256Hex returns the hex SHA-256 of a value
+func sha256Hex(v interface{}) string {
+	sum := sha256.Sum256([]byte(toString(v)))
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the hex HMAC-SHA256 of a value with the key, e.g. to sign requests
+func hmacSHA256(key string, v interface{}) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(toString(v)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// toJSON encodes a value as JSON
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// jsonPath returns the value at a gjson path of a value, or of a JSON string. Objects and
+// arrays are returned as values to pipe further or into toJSON.
+func jsonPath(path string, v interface{}) (interface{}, error) {
+	document, ok := v.(string)
+	if !ok {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		document = string(data)
+	}
+	result := gjson.Get(document, path)
+	if !result.Exists() {
+		return nil, nil
+	}
+	if result.Type == gjson.Number {
+		return json.Number(result.Raw), nil
+	}
+	return result.Value(), nil
+}
+
+// arithmetic applies op to two numbers
+func arithmetic(a, b interface{}, op func(x, y float64) float64) (interface{}, error) {
+	x, err := toFloat(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toFloat(b)
+	if err != nil {
+		return nil, err
+	}
+	return number(op(x, y)), nil
+}
+
+// add returns a + b
+func add(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b, func(x, y float64) float64 { return x + y })
+}
+
+// sub returns a - b
+func sub(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b, func(x, y float64) float64 { return x - y })
+}
+
+// mul returns a * b
+func mul(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b, func(x, y float64) float64 { return x * y })
+}
+
+// div divides a by b
+func div(a, b interface{}) (interface{}, error) {
+	if y, err := toFloat(b); err == nil && y == 0 {
+		return nil, errors.New("div: division by zero")
+	}
+	return arithmetic(a, b, func(x, y float64) float64 { return x / y })
+}
+
+// mod returns the remainder of a divided by b
+func mod(a, b interface{}) (interface{}, error) {
+	if y, err := toFloat(b); err == nil && y == 0 {
+		return nil, errors.New("mod: division by zero")
+	}
+	return arithmetic(a, b, math.Mod)
+}
+
+// round rounds a number to a number of decimal places
+func round(places int, v interface{}) (interface{}, error) {
+	x, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	scale := math.Pow(10, float64(places))
+	return number(math.Round(x*scale) / scale), nil
+}
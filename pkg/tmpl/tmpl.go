@@ -0,0 +1,107 @@
+// Package tmpl renders the {{ }} actions of the request and response templates of tools with
+// Go template syntax and a library of helper functions.
+//
+// Request templates see the tool params as the data ({{ .city | upper }}), response templates
+// the decoded upstream response ({{ .results | jsonPath "0.name.first" }}). Templates without
+// actions are left to the {name} placeholder substitution.
+package tmpl
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Uses reports whether src contains template actions
+func Uses(src string) bool {
+	return strings.Contains(src, "{{")
+}
+
+// Validate parses src, reporting syntax errors and unknown functions
+func Validate(src string) error {
+	if !Uses(src) {
+		return nil
+	}
+	_, err := parseTemplate(src)
+	return err
+}
+
+// Render executes src with data. A missing field of a map renders as "<no value>"; use default
+// for optional params.
+func Render(src string, data interface{}) (string, error) {
+	t, err := parseTemplate(src)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Fields returns the names of the fields of the data src refers to, e.g. city for {{ .city }},
+// which the template consumes
+func Fields(src string) (map[string]bool, error) {
+	fields := map[string]bool{}
+	if !Uses(src) {
+		return fields, nil
+	}
+	t, err := parseTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+	collectFields(t.Tree.Root, true, fields)
+	return fields, nil
+}
+
+// parseTemplate parses src with the helper functions. Errors read as "template: tool:LINE: ...".
+func parseTemplate(src string) (*template.Template, error) {
+	return template.New("tool").Funcs(funcs).Parse(src)
+}
+
+// collectFields adds the fields node refers to. Only fields of the root data are collected,
+// not those of the values range and with move the dot to.
+func collectFields(node parse.Node, root bool, fields map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, root, fields)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, root, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, root, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, root, fields)
+		}
+	case *parse.FieldNode:
+		if root && len(n.Ident) > 0 {
+			fields[n.Ident[0]] = true
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, root, fields)
+	case *parse.IfNode:
+		collectFields(n.Pipe, root, fields)
+		collectFields(n.List, root, fields)
+		collectFields(n.ElseList, root, fields)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, root, fields)
+		collectFields(n.List, false, fields)
+		collectFields(n.ElseList, root, fields)
+	case *parse.WithNode:
+		collectFields(n.Pipe, root, fields)
+		collectFields(n.List, false, fields)
+		collectFields(n.ElseList, root, fields)
+	}
+}
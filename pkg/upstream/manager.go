@@ -0,0 +1,321 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wangfeng/mcp-gateway2/pkg/models"
+)
+
+var (
+	ErrNoHealthyTarget = errors.New("no healthy target available")
+)
+
+// TargetStatus is the health state of a single upstream target
+type TargetStatus struct {
+	URL                  string    `json:"url"`
+	Healthy              bool      `json:"healthy"`
+	LastChecked          time.Time `json:"lastChecked,omitempty"`
+	LastStatusCode       int       `json:"lastStatusCode,omitempty"`
+	LastError            string    `json:"lastError,omitempty"`
+	LatencyMs            int64     `json:"latencyMs"`
+	ConsecutiveFailures  int       `json:"consecutiveFailures"`
+	ConsecutiveSuccesses int       `json:"consecutiveSuccesses"`
+}
+
+// Health is the aggregated health state of an upstream
+type Health struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Healthy     bool           `json:"healthy"`
+	HealthCheck bool           `json:"healthCheckEnabled"`
+	Targets     []TargetStatus `json:"targets"`
+}
+
+// HealthEvent reports a target switching between healthy and unhealthy
+type HealthEvent struct {
+	Upstream string
+	Target   string
+	Healthy  bool
+	Error    string // Last probe error when the target became unhealthy
+	Time     time.Time
+}
+
+// entry holds the runtime state of one upstream
+type entry struct {
+	upstream models.Upstream
+	targets  []*TargetStatus
+	next     int
+	cancel   context.CancelFunc
+}
+
+// Manager runs periodic health checks and balances requests over healthy targets
+type Manager struct {
+	mu         sync.RWMutex
+	upstreams  map[string]*entry // keyed by upstream name
+	httpClient *http.Client
+	listeners  []func(HealthEvent)
+}
+
+// NewManager creates a new upstream manager
+func NewManager() *Manager {
+	return &Manager{
+		upstreams:  make(map[string]*entry),
+		httpClient: &http.Client{},
+	}
+}
+
+// OnHealthChange registers a listener called whenever a target changes health state
+func (m *Manager) OnHealthChange(listener func(HealthEvent)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// notify hands an event to the listeners without holding up the health checks.
+// The caller must hold m.mu.
+func (m *Manager) notify(event HealthEvent) {
+	for _, listener := range m.listeners {
+		go listener(event)
+	}
+}
+
+// Set adds or replaces an upstream and (re)starts its health checks
+func (m *Manager) Set(upstream models.Upstream) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Stop checks of the previous definition, also when the upstream was renamed
+	for name, existing := range m.upstreams {
+		if existing.upstream.ID == upstream.ID || name == upstream.Name {
+			existing.cancel()
+			delete(m.upstreams, name)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &entry{
+		upstream: upstream,
+		targets:  make([]*TargetStatus, 0, len(upstream.Targets)),
+		cancel:   cancel,
+	}
+	for _, target := range upstream.Targets {
+		// Targets are optimistically considered healthy until a probe says otherwise
+		e.targets = append(e.targets, &TargetStatus{URL: strings.TrimRight(target, "/"), Healthy: true})
+	}
+	m.upstreams[upstream.Name] = e
+
+	if upstream.HealthCheck.Path != "" {
+		go m.run(ctx, e)
+	}
+
+	slog.Info("Upstream loaded", "name", upstream.Name, "targets", len(upstream.Targets),
+		"healthCheck", upstream.HealthCheck.Path != "")
+}
+
+// Remove stops the health checks of an upstream and forgets it
+func (m *Manager) Remove(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, e := range m.upstreams {
+		if e.upstream.ID == id {
+			e.cancel()
+			delete(m.upstreams, name)
+		}
+	}
+}
+
+// Stop stops all running health checks
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.upstreams {
+		e.cancel()
+	}
+}
+
+// run probes all targets of an upstream until the context is cancelled
+func (m *Manager) run(ctx context.Context, e *entry) {
+	check := e.upstream.HealthCheck.WithDefaults()
+	ticker := time.NewTicker(time.Duration(check.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		for _, target := range e.targets {
+			m.probe(ctx, e.upstream.Name, check, target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe performs a single health check against a target and updates its status
+func (m *Manager) probe(ctx context.Context, upstreamName string, check models.HealthCheck, target *TargetStatus) {
+	probeCtx, cancel := context.WithTimeout(ctx, time.Duration(check.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	statusCode := 0
+	var probeErr error
+
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, target.URL+"/"+strings.TrimLeft(check.Path, "/"), nil)
+	if err != nil {
+		probeErr = err
+	} else {
+		resp, err := m.httpClient.Do(req)
+		if err != nil {
+			probeErr = err
+		} else {
+			resp.Body.Close()
+			statusCode = resp.StatusCode
+			if statusCode != check.ExpectedStatus {
+				probeErr = fmt.Errorf("unexpected status code %d, expected %d", statusCode, check.ExpectedStatus)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		// The upstream was removed or replaced while probing
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	target.LastChecked = time.Now()
+	target.LastStatusCode = statusCode
+	target.LatencyMs = time.Since(start).Milliseconds()
+
+	if probeErr != nil {
+		target.LastError = probeErr.Error()
+		target.ConsecutiveSuccesses = 0
+		target.ConsecutiveFailures++
+		if target.Healthy && target.ConsecutiveFailures >= check.UnhealthyThreshold {
+			target.Healthy = false
+			slog.WarnContext(ctx, "Upstream target marked unhealthy", "upstream", upstreamName,
+				"target", target.URL, "error", probeErr)
+			m.notify(HealthEvent{Upstream: upstreamName, Target: target.URL, Healthy: false, Error: probeErr.Error(), Time: target.LastChecked})
+		}
+		return
+	}
+
+	target.LastError = ""
+	target.ConsecutiveFailures = 0
+	target.ConsecutiveSuccesses++
+	if !target.Healthy && target.ConsecutiveSuccesses >= check.HealthyThreshold {
+		target.Healthy = true
+		slog.InfoContext(ctx, "Upstream target recovered", "upstream", upstreamName, "target", target.URL)
+		m.notify(HealthEvent{Upstream: upstreamName, Target: target.URL, Healthy: true, Time: target.LastChecked})
+	}
+}
+
+// Pick returns the next healthy target of an upstream using round robin
+func (m *Manager) Pick(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.upstreams[name]
+	if !ok {
+		return "", fmt.Errorf("upstream not found: %s", name)
+	}
+
+	for i := 0; i < len(e.targets); i++ {
+		target := e.targets[(e.next+i)%len(e.targets)]
+		if target.Healthy {
+			e.next = (e.next + i + 1) % len(e.targets)
+			return target.URL, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w for upstream %s", ErrNoHealthyTarget, name)
+}
+
+// ResolveURL rewrites a URL whose host names an upstream to a healthy target.
+// URLs that don't reference an upstream are returned unchanged.
+func (m *Manager) ResolveURL(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL, nil
+	}
+
+	m.mu.RLock()
+	_, ok := m.upstreams[parsed.Hostname()]
+	m.mu.RUnlock()
+	if !ok {
+		return rawURL, nil
+	}
+
+	target, err := m.Pick(parsed.Hostname())
+	if err != nil {
+		return "", err
+	}
+
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid target URL %s: %v", target, err)
+	}
+
+	parsed.Scheme = targetURL.Scheme
+	parsed.Host = targetURL.Host
+	parsed.Path = strings.TrimRight(targetURL.Path, "/") + parsed.Path
+	if parsed.RawPath != "" {
+		parsed.RawPath = strings.TrimRight(targetURL.EscapedPath(), "/") + parsed.RawPath
+	}
+
+	return parsed.String(), nil
+}
+
+// Status returns the health state of all upstreams
+func (m *Manager) Status() []Health {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]Health, 0, len(m.upstreams))
+	for _, e := range m.upstreams {
+		health := Health{
+			ID:          e.upstream.ID,
+			Name:        e.upstream.Name,
+			HealthCheck: e.upstream.HealthCheck.Path != "",
+			Targets:     make([]TargetStatus, 0, len(e.targets)),
+		}
+		for _, target := range e.targets {
+			if target.Healthy {
+				health.Healthy = true
+			}
+			health.Targets = append(health.Targets, *target)
+		}
+		result = append(result, health)
+	}
+
+	return result
+}
+
+// CheckHealthy returns an error naming the upstreams that have no healthy target left
+func (m *Manager) CheckHealthy(ctx context.Context) error {
+	var unhealthy []string
+	for _, health := range m.Status() {
+		if !health.Healthy {
+			unhealthy = append(unhealthy, health.Name)
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return fmt.Errorf("no healthy target for upstreams: %s", strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
@@ -32,7 +32,7 @@ func main() {
 	}
 
 	if len(httpInterfaces) == 0 {
-		log.Fatalf("No HTTP interfaces found")
+		log.Fatalf("No HTTP interfaces found, start the gateway with --seed examples")
 	}
 
 	// Step 2: Create MCP server